@@ -68,6 +68,8 @@ type (
 	DependencyType     = types.DependencyType
 	Label              = types.Label
 	Comment            = types.Comment
+	Attachment         = types.Attachment
+	WorkLog            = types.WorkLog
 	Event              = types.Event
 	EventType          = types.EventType
 	BlockedIssue       = types.BlockedIssue
@@ -128,4 +130,8 @@ const (
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
 	EventCompacted         = types.EventCompacted
+	EventAttachmentAdded   = types.EventAttachmentAdded
+	EventAttachmentRemoved = types.EventAttachmentRemoved
+	EventWorkLogged        = types.EventWorkLogged
+	EventCascadeClosed     = types.EventCascadeClosed
 )
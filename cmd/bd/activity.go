@@ -347,6 +347,8 @@ func getEventDisplay(e rpc.MutationEvent) (symbol, message string) {
 			return "↺", fmt.Sprintf("%s reopened%s", e.IssueID, context)
 		}
 		return "→", fmt.Sprintf("%s → %s%s", e.IssueID, e.NewStatus, context)
+	case rpc.MutationLock:
+		return "🔒", fmt.Sprintf("lock %s %s%s", e.IssueID, e.NewStatus, context)
 	default:
 		return "•", fmt.Sprintf("%s %s%s", e.IssueID, e.Type, context)
 	}
@@ -403,7 +405,7 @@ func printEvent(e rpc.MutationEvent) {
 		coloredSymbol = ui.RenderFail(symbol)
 	case rpc.MutationComment:
 		coloredSymbol = ui.RenderAccent(symbol)
-	case rpc.MutationSquashed:
+	case rpc.MutationSquashed, rpc.MutationLock:
 		coloredSymbol = ui.RenderAccent(symbol)
 	case rpc.MutationStatus:
 		// Color based on new status
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/lease"
+	"github.com/steveyegge/beads/internal/locks"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -41,7 +43,8 @@ States:
   stuck     - Agent is blocked and needs help
   done      - Agent completed its current work
   stopped   - Agent has cleanly shut down
-  dead      - Agent died without clean shutdown (set by Witness via timeout)
+  dead      - Agent died without clean shutdown (set by Witness or the
+              daemon watchdog via timeout; see 'bd agent watchdog')
 
 Examples:
   bd agent state gt-emma running     # Set emma's state to running
@@ -74,6 +77,10 @@ var agentHeartbeatCmd = &cobra.Command{
 Use this for periodic heartbeats to indicate the agent is still alive.
 The Witness can use this to detect dead agents via timeout.
 
+Also renews the claim leases (see 'bd claim') and lock holds (see 'bd lock')
+of the agent or the calling actor, so claimed work isn't returned to the
+ready queue and held locks aren't handed to the next waiter.
+
 Examples:
   bd agent heartbeat gt-emma   # Update emma's last_activity
   bd agent heartbeat gt-mayor  # Update mayor's last_activity`,
@@ -419,6 +426,37 @@ func runAgentHeartbeat(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Renew claim leases held by the agent (or by whoever is heartbeating for it)
+	holders := []string{agentID, agentArg, actor}
+	var renewed []*lease.Lease
+	var renewedLocks []string
+	if daemonClient != nil && !needsRouting(agentArg) {
+		resp, err := daemonClient.RenewLeases(&rpc.RenewLeasesArgs{Holders: holders, LeaseTTL: configuredLeaseTTL()})
+		if err != nil {
+			return fmt.Errorf("failed to renew leases: %w", err)
+		}
+		if err := json.Unmarshal(resp.Data, &renewed); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		resp, err = daemonClient.LockRenew(&rpc.LockRenewArgs{Holders: holders, TTL: configuredLockTTL()})
+		if err != nil {
+			return fmt.Errorf("failed to renew locks: %w", err)
+		}
+		if err := json.Unmarshal(resp.Data, &renewedLocks); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+	} else {
+		var err error
+		renewed, err = lease.Renew(ctx, activeStore, holders, configuredLeaseTTL(), time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to renew leases: %w", err)
+		}
+		renewedLocks, err = locks.Renew(ctx, activeStore, holders, configuredLockTTL(), time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to renew locks: %w", err)
+		}
+	}
+
 	// Trigger auto-flush
 	if flushManager != nil {
 		flushManager.MarkDirty(false)
@@ -426,14 +464,20 @@ func runAgentHeartbeat(cmd *cobra.Command, args []string) error {
 
 	if jsonOutput {
 		result := map[string]interface{}{
-			"agent":         agentID,
-			"last_activity": time.Now().Format(time.RFC3339),
+			"agent":          agentID,
+			"last_activity":  time.Now().Format(time.RFC3339),
+			"leases_renewed": len(renewed),
+			"locks_renewed":  len(renewedLocks),
 		}
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(result)
 	}
 
+	if len(renewed) > 0 || len(renewedLocks) > 0 {
+		fmt.Printf("%s %s heartbeat (renewed %d lease(s), %d lock(s))\n", ui.RenderPass("✓"), agentID, len(renewed), len(renewedLocks))
+		return nil
+	}
 	fmt.Printf("%s %s heartbeat\n", ui.RenderPass("✓"), agentID)
 	return nil
 }
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/locks"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// watchdogActor is recorded on changes made by the agent watchdog.
+const watchdogActor = "watchdog"
+
+// AgentLivenessChange records what the watchdog did to one silent agent.
+type AgentLivenessChange struct {
+	AgentID   string           `json:"agent_id"`
+	OldState  types.AgentState `json:"old_state,omitempty"`
+	NewState  types.AgentState `json:"new_state"`
+	SilentFor string           `json:"silent_for"`
+	Released  []string         `json:"released,omitempty"` // Hooked work returned to the ready queue
+	Locks     []string         `json:"locks,omitempty"`    // Named locks (and merge slots) given up or no longer waited on
+	Error     string           `json:"error,omitempty"`
+}
+
+var agentWatchdogCmd = &cobra.Command{
+	Use:   "watchdog",
+	Short: "Mark silent agents stuck or dead now (what the daemon does)",
+	Long: `Check every agent bead (gt:agent) for silence: the time since its
+last_activity, which 'bd agent heartbeat' and 'bd agent state' update.
+
+  agent.stuck-after   mark the agent stuck after this much silence
+  agent.dead-after    mark the agent dead after this much silence
+
+Both are Go durations in config.yaml and are off unless set. A dead agent's
+hooked work (the bead in its hook slot and any issue hooked to it) goes back
+to open and unassigned with a comment, its hook slot is cleared, it gives up
+every named lock (bd lock, including the merge slot) it holds or waits for,
+and the on_agent_dead hook runs.
+
+Silence is measured from last_activity, or from when the agent bead was
+created if it never reported any.
+
+Stopped and dead agents are skipped. The daemon runs this every minute; run
+it from cron when no daemon is running.
+
+Examples:
+  bd config set agent.stuck-after 15m
+  bd config set agent.dead-after 1h
+  bd agent watchdog`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("agent watchdog")
+		cfg := config.GetAgentWatchdogConfig()
+		if !cfg.Enabled() {
+			FatalErrorRespectJSON("agent watchdog is off (set agent.stuck-after or agent.dead-after)")
+		}
+		if err := ensureDirectMode("agent watchdog requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		changes, err := checkAgentLiveness(ctx, store, cfg, time.Now(), agentDeadHook(beads.FindBeadsDir()))
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(changes) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			if changes == nil {
+				changes = []*AgentLivenessChange{}
+			}
+			outputJSON(changes)
+			return
+		}
+		if len(changes) == 0 {
+			fmt.Println("No silent agents")
+			return
+		}
+		for _, c := range changes {
+			printAgentLivenessChange(c)
+		}
+	},
+}
+
+func printAgentLivenessChange(c *AgentLivenessChange) {
+	symbol := ui.RenderWarn("!")
+	if c.NewState == types.StateDead {
+		symbol = ui.RenderFail("✗")
+	}
+	fmt.Printf("%s %s %s (silent for %s)\n", symbol, ui.RenderID(c.AgentID), c.NewState, c.SilentFor)
+	if len(c.Released) > 0 {
+		fmt.Printf("  Returned to ready: %s\n", strings.Join(c.Released, ", "))
+	}
+	if len(c.Locks) > 0 {
+		fmt.Printf("  Released locks: %s\n", strings.Join(c.Locks, ", "))
+	}
+	if c.Error != "" {
+		fmt.Printf("  %s %s\n", ui.RenderFail("Error:"), c.Error)
+	}
+}
+
+// checkAgentLiveness marks agents that have been silent too long as stuck or
+// dead, and releases the work and locks of agents it marks dead. onDead runs
+// after an agent's work is released; it may be nil.
+func checkAgentLiveness(ctx context.Context, s storage.Storage, cfg config.AgentWatchdogConfig, now time.Time, onDead func(*types.Issue) error) ([]*AgentLivenessChange, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	agents, err := s.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{"gt:agent"}})
+	if err != nil {
+		return nil, fmt.Errorf("listing agents: %w", err)
+	}
+
+	var changes []*AgentLivenessChange
+	for _, agent := range agents {
+		if agent.Status == types.StatusClosed || agent.Status == types.StatusTombstone {
+			continue
+		}
+		if agent.AgentState == types.StateStopped || agent.AgentState == types.StateDead {
+			continue
+		}
+		// Not UpdatedAt: the watchdog's own state change bumps it
+		last := agent.CreatedAt
+		if agent.LastActivity != nil {
+			last = *agent.LastActivity
+		}
+		silence := now.Sub(last)
+
+		var state types.AgentState
+		switch {
+		case cfg.DeadAfter > 0 && silence >= cfg.DeadAfter:
+			state = types.StateDead
+		case cfg.StuckAfter > 0 && silence >= cfg.StuckAfter && agent.AgentState != types.StateStuck:
+			state = types.StateStuck
+		default:
+			continue
+		}
+
+		c := &AgentLivenessChange{
+			AgentID:   agent.ID,
+			OldState:  agent.AgentState,
+			NewState:  state,
+			SilentFor: silence.Round(time.Second).String(),
+		}
+		changes = append(changes, c)
+		if err := s.UpdateIssue(ctx, agent.ID, map[string]interface{}{"agent_state": string(state)}, watchdogActor); err != nil {
+			c.Error = fmt.Sprintf("setting state: %v", err)
+			continue
+		}
+		if state != types.StateDead {
+			continue
+		}
+		if err := releaseAgentWork(ctx, s, agent, last, now, c); err != nil {
+			c.Error = err.Error()
+			continue
+		}
+		if onDead != nil {
+			if err := onDead(agent); err != nil {
+				c.Error = err.Error()
+			}
+		}
+	}
+	return changes, nil
+}
+
+// releaseAgentWork returns a dead agent's hooked work to the ready queue,
+// clears its hook slot and releases its named locks, recording each in c.
+func releaseAgentWork(ctx context.Context, s storage.Storage, agent *types.Issue, lastActivity, now time.Time, c *AgentLivenessChange) error {
+	comment := fmt.Sprintf("Agent %s was silent since %s and marked dead; returned to the ready queue.",
+		agent.ID, lastActivity.UTC().Format(time.RFC3339))
+	reopen := func(issue *types.Issue) error {
+		if issue == nil || containsString(c.Released, issue.ID) {
+			return nil
+		}
+		if issue.Status != types.StatusHooked && issue.Status != types.StatusInProgress {
+			return nil
+		}
+		updates := map[string]interface{}{
+			"status":   string(types.StatusOpen),
+			"assignee": "",
+		}
+		if err := s.UpdateIssue(ctx, issue.ID, updates, watchdogActor); err != nil {
+			return fmt.Errorf("releasing %s: %w", issue.ID, err)
+		}
+		if err := s.AddComment(ctx, issue.ID, watchdogActor, comment); err != nil {
+			return fmt.Errorf("releasing %s: %w", issue.ID, err)
+		}
+		c.Released = append(c.Released, issue.ID)
+		return nil
+	}
+
+	if agent.HookBead != "" {
+		issue, err := s.GetIssue(ctx, agent.HookBead)
+		if err != nil {
+			return fmt.Errorf("loading hook %s: %w", agent.HookBead, err)
+		}
+		if err := reopen(issue); err != nil {
+			return err
+		}
+		if err := s.UpdateIssue(ctx, agent.ID, map[string]interface{}{"hook_bead": ""}, watchdogActor); err != nil {
+			return fmt.Errorf("clearing hook slot: %w", err)
+		}
+	}
+
+	hooked := types.StatusHooked
+	assignee := agent.ID
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Status: &hooked, Assignee: &assignee})
+	if err != nil {
+		return fmt.Errorf("listing hooked work: %w", err)
+	}
+	for _, issue := range issues {
+		if err := reopen(issue); err != nil {
+			return err
+		}
+	}
+
+	if c.Locks, err = locks.ReleaseAll(ctx, s, agent.ID, now); err != nil {
+		return fmt.Errorf("releasing locks: %w", err)
+	}
+	return nil
+}
+
+// agentDeadHook runs the on_agent_dead hook for an agent marked dead.
+func agentDeadHook(beadsDir string) func(*types.Issue) error {
+	runner := hooks.NewRunner(filepath.Join(beadsDir, "hooks"))
+	return func(agent *types.Issue) error {
+		if err := runner.RunSync(hooks.EventAgentDead, agent); err != nil {
+			return fmt.Errorf("on_agent_dead hook: %w", err)
+		}
+		return nil
+	}
+}
+
+func init() {
+	agentCmd.AddCommand(agentWatchdogCmd)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/locks"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAgentWatchdog(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	now := time.Now()
+
+	create := func(issue *types.Issue, labels ...string) *types.Issue {
+		t.Helper()
+		if issue.IssueType == "" {
+			issue.IssueType = types.TypeTask
+		}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range labels {
+			if err := s.AddLabel(ctx, issue.ID, l, "test"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return issue
+	}
+	agent := func(title string, state types.AgentState, silence time.Duration) *types.Issue {
+		t.Helper()
+		a := create(&types.Issue{Title: title, Status: types.StatusOpen, IssueType: "agent"}, "gt:agent")
+		updates := map[string]interface{}{"agent_state": string(state), "last_activity": now.Add(-silence)}
+		if err := s.UpdateIssue(ctx, a.ID, updates, "test"); err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+
+	alive := agent("alive", types.StateWorking, time.Minute)
+	quiet := agent("quiet", types.StateWorking, 20*time.Minute)
+	stopped := agent("stopped", types.StateStopped, 5*time.Hour)
+	dead := agent("dead", types.StateWorking, 2*time.Hour)
+
+	onHook := create(&types.Issue{Title: "on the hook", Status: types.StatusHooked, Assignee: dead.ID})
+	hooked := create(&types.Issue{Title: "also hooked", Status: types.StatusHooked, Assignee: dead.ID})
+	if err := s.UpdateIssue(ctx, dead.ID, map[string]interface{}{"hook_bead": onHook.ID}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := locks.Acquire(ctx, s, locks.Request{Name: "deploy", Holder: dead.ID}, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := locks.Acquire(ctx, s, locks.Request{Name: "bd-merge-slot", Holder: dead.ID, Capacity: 1}, now); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []string{alive.ID, dead.ID, quiet.ID} {
+		if _, err := locks.Acquire(ctx, s, locks.Request{Name: "qa-merge-slot", Holder: h, Capacity: 1, Queue: true}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An agent that never reported activity is silent since it was created,
+	// however often the watchdog itself touches it
+	mute := create(&types.Issue{Title: "mute", Status: types.StatusOpen, IssueType: "agent", AgentState: types.StateStuck,
+		CreatedAt: now.Add(-2 * time.Hour)}, "gt:agent")
+	if err := s.UpdateIssue(ctx, mute.ID, map[string]interface{}{"agent_state": string(types.StateStuck)}, "watchdog"); err != nil {
+		t.Fatal(err)
+	}
+
+	var hooksRun []string
+	onDead := func(a *types.Issue) error {
+		hooksRun = append(hooksRun, a.ID)
+		return nil
+	}
+	cfg := config.AgentWatchdogConfig{StuckAfter: 15 * time.Minute, DeadAfter: time.Hour}
+	changes, err := checkAgentLiveness(ctx, s, cfg, now, onDead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byAgent := make(map[string]*AgentLivenessChange)
+	for _, c := range changes {
+		if c.Error != "" {
+			t.Errorf("%s: %s", c.AgentID, c.Error)
+		}
+		byAgent[c.AgentID] = c
+	}
+	if len(changes) != 3 || byAgent[quiet.ID] == nil || byAgent[dead.ID] == nil || byAgent[mute.ID] == nil {
+		for _, c := range changes {
+			t.Logf("%+v", *c)
+		}
+		t.Fatalf("got %d changes; want only %s, %s and %s", len(changes), quiet.ID, dead.ID, mute.ID)
+	}
+	if byAgent[mute.ID].NewState != types.StateDead {
+		t.Errorf("mute agent = %s, want dead", byAgent[mute.ID].NewState)
+	}
+	if byAgent[quiet.ID].NewState != types.StateStuck {
+		t.Errorf("quiet agent = %s, want stuck", byAgent[quiet.ID].NewState)
+	}
+	d := byAgent[dead.ID]
+	if d.NewState != types.StateDead || !reflect.DeepEqual(d.Released, []string{onHook.ID, hooked.ID}) ||
+		!reflect.DeepEqual(d.Locks, []string{"bd-merge-slot", "deploy", "qa-merge-slot"}) {
+		t.Errorf("dead agent change = %+v", d)
+	}
+	all, err := locks.List(ctx, s, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range all {
+		switch l.Name {
+		case "bd-merge-slot", "deploy":
+			t.Errorf("lock %s still held by %v", l.Name, lockHolderNames(l.Holders))
+		case "qa-merge-slot":
+			if got, waiting := lockHolderNames(l.Holders), lockHolderNames(l.Waiters); !reflect.DeepEqual(got, []string{alive.ID}) || !reflect.DeepEqual(waiting, []string{quiet.ID}) {
+				t.Errorf("qa-merge-slot = %v waited on by %v, want held by %s with only %s waiting", got, waiting, alive.ID, quiet.ID)
+			}
+		}
+	}
+	if !reflect.DeepEqual(hooksRun, []string{dead.ID, mute.ID}) && !reflect.DeepEqual(hooksRun, []string{mute.ID, dead.ID}) {
+		t.Errorf("on_agent_dead ran for %v", hooksRun)
+	}
+
+	for _, id := range []string{onHook.ID, hooked.ID} {
+		got, _ := s.GetIssue(ctx, id)
+		if got.Status != types.StatusOpen || got.Assignee != "" {
+			t.Errorf("%s = %s/%q, want open and unassigned", id, got.Status, got.Assignee)
+		}
+	}
+	if got, _ := s.GetIssue(ctx, dead.ID); got.AgentState != types.StateDead || got.HookBead != "" {
+		t.Errorf("dead agent = %s, hook %q", got.AgentState, got.HookBead)
+	}
+	for _, id := range []string{alive.ID, stopped.ID} {
+		if got, _ := s.GetIssue(ctx, id); got.AgentState == types.StateStuck || got.AgentState == types.StateDead {
+			t.Errorf("%s should be left alone, got %s", id, got.AgentState)
+		}
+	}
+
+	// A second pass has nothing left to do
+	if changes, err := checkAgentLiveness(ctx, s, cfg, now, onDead); err != nil || len(changes) != 0 {
+		t.Errorf("second pass = %+v, %v", changes, err)
+	}
+}
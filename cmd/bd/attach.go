@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var attachCmd = &cobra.Command{
+	Use:     "attach <issue-id> <file>",
+	GroupID: "issues",
+	Short:   "Attach a file to an issue",
+	Long: `Attach logs, screenshots and other files to an issue.
+
+The file is stored once as a content-addressed blob in .beads/blobs/<sha256>;
+the issue only records its name, size, MIME type and hash. This keeps
+issues.jsonl small and merge-friendly no matter how large the attachment is.
+Blobs are committed alongside issues.jsonl by bd sync.
+
+Examples:
+  bd attach bd-42 build.log                 # Attach a file
+  bd attach bd-42 /tmp/shot.png --name ui.png
+  bd attach list bd-42                      # List attachments
+  bd attach get bd-42 build.log             # Write ./build.log
+  bd attach get bd-42 build.log -o -        # Write to stdout
+  bd attach rm bd-42 build.log              # Detach (blob kept until gc)
+  bd attach gc                              # Delete unreferenced blobs
+  bd attach lfs                             # Store blobs with git-lfs`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("attach")
+		if err := ensureDirectMode("attach writes blobs into .beads/blobs"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		filePath := args[1]
+		info, err := os.Stat(filePath)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if info.IsDir() {
+			FatalErrorRespectJSON("%s is a directory", filePath)
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			name = filepath.Base(filePath)
+		}
+
+		attachment, err := attachFile(ctx, store, blobStore(), issueID, filePath, name, actor)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id":   issueID,
+				"attachment": attachment,
+			})
+			return
+		}
+		fmt.Printf("%s Attached %s to %s (%s, %s)\n", ui.RenderPass("✓"), attachment.Name, issueID,
+			formatBytes(attachment.Size), shortHash(attachment.Hash))
+	},
+}
+
+var attachListCmd = &cobra.Command{
+	Use:   "list <issue-id>",
+	Short: "List attachments on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("attach list reads .beads/blobs"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		attachments, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			if attachments == nil {
+				attachments = []*types.Attachment{}
+			}
+			outputJSON(attachments)
+			return
+		}
+		if len(attachments) == 0 {
+			fmt.Printf("No attachments on %s\n", issueID)
+			return
+		}
+		fmt.Printf("\n%s Attachments on %s (%d):\n\n", ui.RenderAccent("📎"), issueID, len(attachments))
+		printAttachments(attachments, blobStore())
+		fmt.Println()
+	},
+}
+
+var attachGetCmd = &cobra.Command{
+	Use:   "get <issue-id> <name-or-hash>",
+	Short: "Retrieve an attachment",
+	Long: `Retrieve an attachment by file name or hash prefix.
+
+By default the file is written to the current directory under its attached
+name. Use -o to choose a path, or -o - to write to stdout.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("attach get reads .beads/blobs"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		attachments, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		attachment, err := findAttachment(attachments, args[1])
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", issueID, err)
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		force, _ := cmd.Flags().GetBool("force")
+		if output == "" {
+			output = filepath.Base(attachment.Name)
+		}
+
+		src, err := blobStore().Open(attachment.Hash)
+		if err != nil {
+			FatalErrorRespectJSON("%v (run 'bd sync' to fetch blobs, or 'bd doctor' to check)", err)
+		}
+		defer func() { _ = src.Close() }()
+
+		if output == "-" {
+			if _, err := io.Copy(os.Stdout, src); err != nil {
+				FatalError("writing attachment: %v", err)
+			}
+			return
+		}
+
+		flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		if force {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		}
+		dst, err := os.OpenFile(output, flags, 0644) // #nosec G304,G302 -- user-chosen output path
+		if err != nil {
+			if os.IsExist(err) {
+				FatalErrorRespectJSON("%s already exists (use --force to overwrite)", output)
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		if _, err := io.Copy(dst, src); err != nil {
+			_ = dst.Close()
+			FatalErrorRespectJSON("writing %s: %v", output, err)
+		}
+		if err := dst.Close(); err != nil {
+			FatalErrorRespectJSON("writing %s: %v", output, err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id":   issueID,
+				"attachment": attachment,
+				"path":       output,
+			})
+			return
+		}
+		fmt.Printf("%s Wrote %s (%s)\n", ui.RenderPass("✓"), output, formatBytes(attachment.Size))
+	},
+}
+
+var attachRemoveCmd = &cobra.Command{
+	Use:     "rm <issue-id> <name-or-hash>",
+	Aliases: []string{"remove"},
+	Short:   "Remove an attachment from an issue",
+	Long: `Remove an attachment from an issue.
+
+Only the issue's reference is removed. The blob stays in .beads/blobs until
+'bd attach gc' deletes blobs that no issue references.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("attach rm")
+		if err := ensureDirectMode("attach rm updates attachment metadata"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		attachments, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		attachment, err := findAttachment(attachments, args[1])
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", issueID, err)
+		}
+		if err := store.RemoveAttachment(ctx, issueID, attachment.Hash, actor); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id": issueID,
+				"removed":  attachment,
+			})
+			return
+		}
+		fmt.Printf("%s Removed %s from %s\n", ui.RenderPass("✓"), attachment.Name, issueID)
+	},
+}
+
+var attachGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete blobs no issue references",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("attach gc")
+		}
+		if err := ensureDirectMode("attach gc reads .beads/blobs"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+
+		removed, freed, err := gcBlobs(ctx, store, blobStore(), dryRun)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			if removed == nil {
+				removed = []string{}
+			}
+			outputJSON(map[string]interface{}{
+				"dry_run": dryRun,
+				"blobs":   removed,
+				"bytes":   freed,
+			})
+			return
+		}
+		if len(removed) == 0 {
+			fmt.Println("No unreferenced blobs")
+			return
+		}
+		verb := "Deleted"
+		if dryRun {
+			verb = "Would delete"
+		}
+		fmt.Printf("%s %d unreferenced blob(s), %s\n", verb, len(removed), formatBytes(freed))
+	},
+}
+
+var attachLFSCmd = &cobra.Command{
+	Use:   "lfs",
+	Short: "Store attachment blobs with git-lfs",
+	Long: `Add a .beads/.gitattributes rule so blobs are stored with git-lfs.
+
+Requires git-lfs to be installed ('git lfs install'). Blobs committed before
+the rule was added stay in regular git history.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsDir := beads.FindBeadsDir()
+		if beadsDir == "" {
+			FatalErrorRespectJSON("no .beads directory found")
+		}
+		if err := blobs.EnsureLFSAttributes(beadsDir); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"lfs": true})
+			return
+		}
+		fmt.Printf("%s Attachment blobs will be stored with git-lfs (.beads/.gitattributes)\n", ui.RenderPass("✓"))
+	},
+}
+
+// blobStore returns the attachment blob store for the active .beads directory.
+func blobStore() *blobs.Store {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" && dbPath != "" {
+		beadsDir = filepath.Dir(dbPath)
+	}
+	return blobs.New(beadsDir)
+}
+
+// attachFile stores the file as a blob and records it on the issue.
+func attachFile(ctx context.Context, s storage.Storage, bs *blobs.Store, issueID, filePath, name, actor string) (*types.Attachment, error) {
+	hash, size, err := bs.PutFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("storing %s: %w", filePath, err)
+	}
+
+	var head []byte
+	if f, err := bs.Open(hash); err == nil {
+		head = make([]byte, 512)
+		n, _ := io.ReadFull(f, head)
+		head = head[:n]
+		_ = f.Close()
+	}
+
+	attachment := &types.Attachment{
+		Hash:     hash,
+		Name:     name,
+		Size:     size,
+		MimeType: blobs.DetectMimeType(name, head),
+	}
+	if err := s.AddAttachment(ctx, issueID, attachment, actor); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// findAttachment looks up an attachment by exact name, then by unique hash prefix.
+func findAttachment(attachments []*types.Attachment, ref string) (*types.Attachment, error) {
+	for _, a := range attachments {
+		if a.Name == ref {
+			return a, nil
+		}
+	}
+	var match *types.Attachment
+	if len(ref) >= 4 {
+		for _, a := range attachments {
+			if strings.HasPrefix(a.Hash, strings.ToLower(ref)) {
+				if match != nil {
+					return nil, fmt.Errorf("hash prefix %q is ambiguous", ref)
+				}
+				match = a
+			}
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no attachment named %q", ref)
+	}
+	return match, nil
+}
+
+// referencedBlobs returns the set of blob hashes referenced by any issue,
+// including tombstones so a deleted issue's blobs survive until it is pruned.
+func referencedBlobs(ctx context.Context, s storage.Storage) (map[string]bool, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	all, err := s.GetAttachmentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	referenced := make(map[string]bool)
+	for _, list := range all {
+		for _, a := range list {
+			referenced[a.Hash] = true
+		}
+	}
+	return referenced, nil
+}
+
+// gcBlobs deletes blobs that no issue references and returns their hashes
+// and total size.
+func gcBlobs(ctx context.Context, s storage.Storage, bs *blobs.Store, dryRun bool) ([]string, int64, error) {
+	referenced, err := referencedBlobs(ctx, s)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, orphaned, err := bs.Check(referenced)
+	if err != nil {
+		return nil, 0, err
+	}
+	var freed int64
+	for _, hash := range orphaned {
+		if path, err := bs.Path(hash); err == nil {
+			if info, err := os.Stat(path); err == nil {
+				freed += info.Size()
+			}
+		}
+		if !dryRun {
+			if err := bs.Remove(hash); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return orphaned, freed, nil
+}
+
+// populateAttachments fills in attachment metadata for export.
+func populateAttachments(ctx context.Context, s storage.Storage, issues []*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	all, err := s.GetAttachmentsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Attachments = all[issue.ID]
+	}
+	return nil
+}
+
+// printAttachments renders attachments one per line, flagging missing blobs.
+func printAttachments(attachments []*types.Attachment, bs *blobs.Store) {
+	for _, a := range attachments {
+		line := fmt.Sprintf("  %s  %s  %s", a.Name, ui.RenderMuted(formatBytes(a.Size)), ui.RenderMuted(shortHash(a.Hash)))
+		if a.MimeType != "" {
+			line += "  " + ui.RenderMuted(a.MimeType)
+		}
+		if bs != nil && !bs.Has(a.Hash) {
+			line += "  " + ui.RenderWarn("(blob missing)")
+		}
+		fmt.Println(line)
+	}
+}
+
+// shortHash abbreviates a blob hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+func init() {
+	attachCmd.Flags().String("name", "", "Attachment name (default: file name)")
+	attachGetCmd.Flags().StringP("output", "o", "", "Output path ('-' for stdout)")
+	attachGetCmd.Flags().Bool("force", false, "Overwrite an existing output file")
+	attachGCCmd.Flags().Bool("dry-run", false, "List blobs that would be deleted")
+
+	attachCmd.AddCommand(attachListCmd)
+	attachCmd.AddCommand(attachGetCmd)
+	attachCmd.AddCommand(attachRemoveCmd)
+	attachCmd.AddCommand(attachGCCmd)
+	attachCmd.AddCommand(attachLFSCmd)
+	rootCmd.AddCommand(attachCmd)
+}
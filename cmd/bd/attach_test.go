@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAttachAndGC(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	s := newTestStore(t, filepath.Join(beadsDir, "beads.db"))
+	bs := blobs.New(beadsDir)
+	ctx := context.Background()
+
+	issue := &types.Issue{ID: "test-1", Title: "flaky build", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	logPath := filepath.Join(tmpDir, "build.log")
+	if err := os.WriteFile(logPath, []byte("FAIL: TestSomething\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	a, err := attachFile(ctx, s, bs, "test-1", logPath, "build.log", "test")
+	if err != nil {
+		t.Fatalf("attachFile failed: %v", err)
+	}
+	if a.Size != 20 || !bs.Has(a.Hash) {
+		t.Fatalf("attachment = %+v, blob present: %v", a, bs.Has(a.Hash))
+	}
+
+	list, err := s.GetAttachments(ctx, "test-1")
+	if err != nil || len(list) != 1 {
+		t.Fatalf("GetAttachments = %v, %v", list, err)
+	}
+	if got, err := findAttachment(list, "build.log"); err != nil || got.Hash != a.Hash {
+		t.Errorf("findAttachment by name = %v, %v", got, err)
+	}
+	if got, err := findAttachment(list, a.Hash[:8]); err != nil || got.Name != "build.log" {
+		t.Errorf("findAttachment by hash prefix = %v, %v", got, err)
+	}
+	if _, err := findAttachment(list, a.Hash[:3]); err == nil {
+		t.Error("findAttachment should reject hash prefixes shorter than 4 chars")
+	}
+
+	// Referenced blobs survive gc
+	removed, _, err := gcBlobs(ctx, s, bs, false)
+	if err != nil || len(removed) != 0 {
+		t.Fatalf("gcBlobs = %v, %v; want nothing removed", removed, err)
+	}
+
+	if err := s.RemoveAttachment(ctx, "test-1", a.Hash, "test"); err != nil {
+		t.Fatal(err)
+	}
+	removed, freed, err := gcBlobs(ctx, s, bs, true)
+	if err != nil || len(removed) != 1 || freed != 20 {
+		t.Fatalf("gcBlobs dry run = %v, %d, %v", removed, freed, err)
+	}
+	if !bs.Has(a.Hash) {
+		t.Fatal("dry run should not delete blobs")
+	}
+	if _, _, err := gcBlobs(ctx, s, bs, false); err != nil {
+		t.Fatal(err)
+	}
+	if bs.Has(a.Hash) {
+		t.Error("orphaned blob should be deleted")
+	}
+}
+
+func TestDropCompactedAttachments(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	s := newTestStore(t, filepath.Join(beadsDir, "beads.db"))
+	bs := blobs.New(beadsDir)
+	ctx := context.Background()
+
+	for _, id := range []string{"test-old", "test-new"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, ClosedAt: ptrTime(time.Now())}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.ApplyCompaction(ctx, "test-old", 1, 100, 10, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// The shared blob is attached to both issues and must survive
+	shared := write("shared.txt", "shared")
+	only := write("only.txt", "compacted only")
+	a1, err := attachFile(ctx, s, bs, "test-old", shared, "shared.txt", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := attachFile(ctx, s, bs, "test-old", only, "only.txt", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := attachFile(ctx, s, bs, "test-new", shared, "shared.txt", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := dropCompactedAttachments(ctx, s, bs, "test", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.AttachmentCount != 2 || len(preview.BlobsRemoved) != 1 || preview.BlobsRemoved[0] != a2.Hash {
+		t.Fatalf("dry run = %+v", preview)
+	}
+	if list, _ := s.GetAttachments(ctx, "test-old"); len(list) != 2 {
+		t.Fatalf("dry run removed attachments: %v", list)
+	}
+
+	result, err := dropCompactedAttachments(ctx, s, bs, "test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.IssueIDs) != 1 || result.IssueIDs[0] != "test-old" {
+		t.Errorf("IssueIDs = %v, want [test-old]", result.IssueIDs)
+	}
+	if list, _ := s.GetAttachments(ctx, "test-old"); len(list) != 0 {
+		t.Errorf("compacted issue still has attachments: %v", list)
+	}
+	if list, _ := s.GetAttachments(ctx, "test-new"); len(list) != 1 {
+		t.Errorf("uncompacted issue lost attachments: %v", list)
+	}
+	if !bs.Has(a1.Hash) || bs.Has(a2.Hash) {
+		t.Errorf("shared blob present=%v (want true), compacted-only blob present=%v (want false)",
+			bs.Has(a1.Hash), bs.Has(a2.Hash))
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
@@ -104,7 +105,13 @@ func findJSONLPath() string {
 		return utils.CanonicalizeIfRelative(jsonlPath)
 	}
 
-	return utils.CanonicalizeIfRelative(jsonlPath)
+	// Sharded layout: issues.jsonl is an untracked working copy of the shards,
+	// so rebuild it if git changed the shards (pull, checkout, fresh clone).
+	jsonlPath = utils.CanonicalizeIfRelative(jsonlPath)
+	if err := shard.Sync(jsonlPath); err != nil {
+		debug.Logf("failed to reconcile sharded JSONL: %v", err)
+	}
+	return jsonlPath
 }
 
 // getWorktreeJSONLPath converts a main repo JSONL path to its worktree equivalent.
@@ -301,9 +308,11 @@ func autoImportIfNewer() {
 	}
 
 	// Content changed - parse all issues
+	verify := config.GetBool("import.verify")
 	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
 	scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024) // 2MB buffer for large JSON lines
 	var allIssues []*types.Issue
+	var rawLines [][]byte // Exact record bytes for import.verify, parallel to allIssues
 	lineNo := 0
 
 	for scanner.Scan() {
@@ -332,6 +341,9 @@ func autoImportIfNewer() {
 		}
 
 		allIssues = append(allIssues, &issue)
+		if verify {
+			rawLines = append(rawLines, bytes.Clone(scanner.Bytes()))
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -339,6 +351,13 @@ func autoImportIfNewer() {
 		return
 	}
 
+	if verify {
+		if allIssues, err = verifySignedImport(jsonlPath, allIssues, rawLines); err != nil {
+			fmt.Fprintf(os.Stderr, "Auto-import skipped: %v\n", err)
+			return
+		}
+	}
+
 	// Clear export_hashes before import to prevent staleness
 	// Import operations may add/update issues, so export_hashes entries become invalid
 	if err := store.ClearAllExportHashes(ctx); err != nil {
@@ -596,6 +615,11 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 		debug.Logf("failed to set file permissions: %v", err)
 	}
 
+	if err := shard.Export(jsonlPath); err != nil {
+		return nil, fmt.Errorf("failed to write JSONL shards: %w", err)
+	}
+	signExportBestEffort(jsonlPath)
+
 	return exportedIDs, nil
 }
 
@@ -698,6 +722,20 @@ func fetchAndMergeIssues(ctx context.Context, s storage.Storage, dirtyIDs []stri
 		}
 		issue.Comments = comments
 
+		// Get attachment metadata for this issue
+		attachments, err := s.GetAttachments(ctx, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to get attachments for %s: %w", issueID, err)
+		}
+		issue.Attachments = attachments
+
+		// Get work logs for this issue
+		workLogs, err := s.GetWorkLogs(ctx, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to get work logs for %s: %w", issueID, err)
+		}
+		issue.WorkLogs = workLogs
+
 		// Update map
 		issueMap[issueID] = issue
 	}
@@ -915,6 +953,9 @@ func flushToJSONLWithState(state flushState) {
 	// Update metadata (hashes, timestamps)
 	updateFlushExportMetadata(ctx, store, jsonlPath)
 
+	// Carry ID length pins alongside the issues
+	exportIDNamespaceBestEffort(ctx, store, filepath.Dir(jsonlPath))
+
 	recordFlushSuccess()
 }
 
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/git"
 	"github.com/steveyegge/beads/internal/storage"
@@ -231,13 +232,17 @@ func getLocalSyncBranch(beadsDir string) string {
 
 // importFromJSONLData imports issues from raw JSONL bytes.
 // This is the shared implementation used by both importFromGit and importFromLocalJSONL.
+// jsonlPath is the JSONL file whose signatures vouch for the data when
+// import.verify is set.
 // Returns the number of issues imported and any error.
-func importFromJSONLData(ctx context.Context, dbFilePath string, store storage.Storage, jsonlData []byte) (int, error) {
+func importFromJSONLData(ctx context.Context, dbFilePath string, store storage.Storage, jsonlPath string, jsonlData []byte) (int, error) {
 	// Parse JSONL data
+	verify := config.GetBool("import.verify")
 	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
 	// Increase buffer size to handle large JSONL lines (e.g., big descriptions)
 	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024) // allow up to 64MB per line
 	var issues []*types.Issue
+	var rawLines [][]byte // Exact record bytes for import.verify, parallel to issues
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -251,12 +256,22 @@ func importFromJSONLData(ctx context.Context, dbFilePath string, store storage.S
 		}
 		issue.SetDefaults() // Apply defaults for omitted fields
 		issues = append(issues, &issue)
+		if verify {
+			rawLines = append(rawLines, bytes.Clone(scanner.Bytes()))
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return 0, fmt.Errorf("failed to scan JSONL: %w", err)
 	}
 
+	if verify {
+		var err error
+		if issues, err = verifySignedImport(jsonlPath, issues, rawLines); err != nil {
+			return 0, err
+		}
+	}
+
 	// CRITICAL: Set issue_prefix from first imported issue if missing
 	// This prevents derivePrefixFromPath fallback which caused duplicate issues
 	if len(issues) > 0 {
@@ -297,7 +312,7 @@ func importFromLocalJSONL(ctx context.Context, dbFilePath string, store storage.
 	if err != nil {
 		return 0, fmt.Errorf("failed to read local JSONL file: %w", err)
 	}
-	return importFromJSONLData(ctx, dbFilePath, store, jsonlData)
+	return importFromJSONLData(ctx, dbFilePath, store, localPath, jsonlData)
 }
 
 // importFromGit imports issues from git at the specified ref (bd-0is: supports sync-branch)
@@ -306,6 +321,11 @@ func importFromGit(ctx context.Context, dbFilePath string, store storage.Storage
 	if err != nil {
 		return err
 	}
-	_, err = importFromJSONLData(ctx, dbFilePath, store, jsonlData)
+	// Records read from the ref are checked against the signatures in the
+	// working tree; any they don't cover are rejected
+	if !filepath.IsAbs(jsonlPath) {
+		jsonlPath = filepath.Join(git.GetRepoRoot(), jsonlPath)
+	}
+	_, err = importFromJSONLData(ctx, dbFilePath, store, jsonlPath, jsonlData)
 	return err
 }
@@ -0,0 +1,716 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/util"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+	"golang.org/x/term"
+)
+
+// bulkPreviewLimit caps how many issues the preview lists.
+const bulkPreviewLimit = 50
+
+// bulkPlan is what a bd bulk command does to each matching issue.
+type bulkPlan struct {
+	Op           string                 // update, close, reopen or defer
+	Updates      map[string]interface{} // Field updates, keyed like UpdateIssue
+	AddLabels    []string
+	RemoveLabels []string
+	Reason       string // Close or reopen reason
+	Session      string // Recorded on close
+	Force        bool   // Close pinned and blocked issues, as bd close --force
+}
+
+// BulkChange is the diff one bulk command makes to one issue.
+type BulkChange struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Changes []string `json:"changes"`
+
+	status       types.Status // Status before the change
+	updates      map[string]interface{}
+	addLabels    []string
+	removeLabels []string
+}
+
+// closes reports whether the change closes the issue.
+func (c *BulkChange) closes() bool {
+	return c.updates["status"] == string(types.StatusClosed)
+}
+
+// BulkRefusal is an issue the bulk command leaves alone because bd close
+// would refuse to close it.
+type BulkRefusal struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// BulkResult reports what a bulk command changed, or would change.
+type BulkResult struct {
+	Op      string         `json:"op"`
+	Matched int            `json:"matched"`
+	Changes []*BulkChange  `json:"changes"`
+	Refused []*BulkRefusal `json:"refused,omitempty"`
+	Applied bool           `json:"applied"`
+}
+
+var bulkCmd = &cobra.Command{
+	Use:     "bulk",
+	GroupID: "issues",
+	Short:   "Update, close, reopen or defer many issues at once",
+	Long: `Apply one change to every issue matching a filter, in a single transaction.
+
+--filter takes the filter flags of bd list, quoted as one argument, and
+selects what bd list would show. Issue IDs given as arguments narrow the
+match further. Each command previews the
+affected issues as a diff first; changing more than --confirm-above issues
+asks for confirmation (or --yes). Issues the change would not alter are
+skipped.
+
+Examples:
+  bd bulk update --filter '--label triage --priority-min 3' --set priority=2 --remove-label triage
+  bd bulk update --filter '--assignee alice' --assignee bob --add-label handoff
+  bd bulk close --filter '--label wontfix' --reason "Triaged as won't fix"
+  bd bulk reopen --filter '--closed-after 2025-01-10 --label regression'
+  bd bulk defer --filter '--type chore --priority 4' --until 'next month' --dry-run`,
+}
+
+var bulkUpdateCmd = &cobra.Command{
+	Use:   "update [id...]",
+	Short: "Set fields and labels on matching issues",
+	Long: `Set fields and labels on every matching issue.
+
+--set takes field=value and may repeat. Fields: status, priority, type,
+assignee, title, description, design, notes, acceptance, external-ref,
+estimate (minutes), due and defer (relative or absolute times). An empty
+value clears assignee, due and defer. Setting status=closed closes issues as
+bd bulk close does.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sets, _ := cmd.Flags().GetStringArray("set")
+		updates, err := parseBulkSet(sets)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if cmd.Flags().Changed("assignee") {
+			assignee, _ := cmd.Flags().GetString("assignee")
+			updates["assignee"] = assignee
+		}
+		addLabels, _ := cmd.Flags().GetStringSlice("add-label")
+		removeLabels, _ := cmd.Flags().GetStringSlice("remove-label")
+		force, _ := cmd.Flags().GetBool("force")
+		plan := &bulkPlan{
+			Op:           "update",
+			Updates:      updates,
+			AddLabels:    util.NormalizeLabels(addLabels),
+			RemoveLabels: util.NormalizeLabels(removeLabels),
+			Session:      os.Getenv("CLAUDE_SESSION_ID"),
+			Force:        force,
+		}
+		if len(plan.Updates) == 0 && len(plan.AddLabels) == 0 && len(plan.RemoveLabels) == 0 {
+			FatalErrorRespectJSON("nothing to change: use --set, --assignee, --add-label or --remove-label")
+		}
+		runBulk(cmd, args, plan)
+	},
+}
+
+var bulkCloseCmd = &cobra.Command{
+	Use:   "close [id...]",
+	Short: "Close matching issues",
+	Long: `Close matching issues. As with bd close, templates, pinned issues and
+issues blocked by open issues are refused and listed in the preview; --force
+closes pinned and blocked issues anyway. An issue blocked only by issues this
+command also closes is not refused.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		reason, _ := cmd.Flags().GetString("reason")
+		force, _ := cmd.Flags().GetBool("force")
+		runBulk(cmd, args, &bulkPlan{Op: "close", Reason: reason, Session: os.Getenv("CLAUDE_SESSION_ID"), Force: force})
+	},
+}
+
+var bulkReopenCmd = &cobra.Command{
+	Use:   "reopen [id...]",
+	Short: "Reopen matching closed issues",
+	Long: `Reopen matching issues. Unless --filter sets --status, only closed issues
+match. As with bd reopen, only closed and deferred issues are reopened.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		reason, _ := cmd.Flags().GetString("reason")
+		runBulk(cmd, args, &bulkPlan{Op: "reopen", Reason: reason})
+	},
+}
+
+var bulkDeferCmd = &cobra.Command{
+	Use:   "defer [id...]",
+	Short: "Defer matching issues",
+	Run: func(cmd *cobra.Command, args []string) {
+		plan := &bulkPlan{Op: "defer", Updates: map[string]interface{}{}}
+		if untilStr, _ := cmd.Flags().GetString("until"); untilStr != "" {
+			t, err := timeparsing.ParseRelativeTime(untilStr, time.Now())
+			if err != nil {
+				FatalErrorRespectJSON("invalid --until format %q. Examples: +1h, tomorrow, next monday, 2025-01-15", untilStr)
+			}
+			plan.Updates["defer_until"] = t
+		}
+		runBulk(cmd, args, plan)
+	},
+}
+
+// runBulk selects the matching issues, previews the diff, confirms and
+// applies the plan in one transaction.
+func runBulk(cmd *cobra.Command, args []string, plan *bulkPlan) {
+	CheckReadonly("bulk " + plan.Op)
+	filterSpec, _ := cmd.Flags().GetString("filter")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+	confirmAbove, _ := cmd.Flags().GetInt("confirm-above")
+	if filterSpec == "" && len(args) == 0 {
+		FatalErrorRespectJSON("bulk %s needs --filter or issue IDs", plan.Op)
+	}
+
+	var defaultStatus *types.Status
+	if plan.Op == "reopen" {
+		closed := types.StatusClosed
+		defaultStatus = &closed
+	}
+	filter, err := parseBulkFilter(filterSpec, defaultStatus)
+	if err != nil {
+		FatalErrorRespectJSON("invalid --filter: %v", err)
+	}
+
+	if err := ensureDirectMode("bulk edits run in one transaction"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	ctx := rootCtx
+
+	if len(args) > 0 {
+		ids, err := utils.ResolvePartialIDs(ctx, store, args)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		filter.IDs = ids
+	}
+
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		FatalErrorRespectJSON("selecting issues: %v", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		FatalErrorRespectJSON("loading labels: %v", err)
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+	}
+
+	result := &BulkResult{Op: plan.Op, Matched: len(issues), Changes: planBulkChanges(issues, plan)}
+	result.Changes, result.Refused, err = refuseUnclosable(ctx, store, issues, result.Changes, plan.Force)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if result.Changes == nil {
+		result.Changes = []*BulkChange{}
+	}
+	if !jsonOutput {
+		printBulkPreview(result, dryRun)
+	}
+	if dryRun || len(result.Changes) == 0 {
+		if jsonOutput {
+			outputJSON(result)
+		}
+		return
+	}
+
+	if len(result.Changes) > confirmAbove && !yes {
+		if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+			FatalErrorRespectJSON("refusing to change %d issues without --yes (over --confirm-above %d)", len(result.Changes), confirmAbove)
+		}
+		fmt.Printf("\n%s %d issues? [y/N] ", strings.ToUpper(plan.Op[:1])+plan.Op[1:], len(result.Changes))
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			fmt.Println("Canceled")
+			return
+		}
+	}
+
+	if err := applyBulkPlan(ctx, store, plan, result.Changes, actor); err != nil {
+		FatalErrorRespectJSON("bulk %s failed, nothing was changed: %v", plan.Op, err)
+	}
+	result.Applied = true
+	markDirtyAndScheduleFlush()
+
+	if !jsonOutput {
+		fmt.Printf("%s Bulk %s changed %d issue(s)\n", ui.RenderPass("✓"), plan.Op, len(result.Changes))
+	}
+	finishBulkChanges(ctx, store, plan, result.Changes)
+	if jsonOutput {
+		outputJSON(result)
+	}
+}
+
+// finishBulkChanges runs what bd close and bd update do after each change
+// is stored: the work auto-timer, hooks and, for close, the cascade report.
+// The transaction has committed, so these only warn on failure.
+func finishBulkChanges(ctx context.Context, s storage.Storage, plan *bulkPlan, changes []*BulkChange) {
+	for _, c := range changes {
+		issue, err := s.GetIssue(ctx, c.ID)
+		if err != nil || issue == nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not reload %s after bulk %s: %v\n", c.ID, plan.Op, err)
+			continue
+		}
+		if issue.Status != c.status {
+			applyAutoTimer(ctx, s, c.ID, c.status, issue.Status)
+		}
+		if c.closes() {
+			if hookRunner != nil {
+				hookRunner.Run(hooks.EventClose, issue)
+			}
+			closeCascade(ctx, s, c.ID)
+		} else if hookRunner != nil {
+			hookRunner.Run(hooks.EventUpdate, issue)
+		}
+	}
+}
+
+func printBulkPreview(result *BulkResult, dryRun bool) {
+	if len(result.Changes) == 0 {
+		fmt.Printf("No issues to %s (%d matched, none would change)\n", result.Op, result.Matched)
+	} else {
+		verb := "Will"
+		if dryRun {
+			verb = "Would"
+		}
+		fmt.Printf("%s %s %d of %d matching issue(s):\n", verb, result.Op, len(result.Changes), result.Matched)
+		for i, c := range result.Changes {
+			if i == bulkPreviewLimit {
+				fmt.Printf("  ... and %d more\n", len(result.Changes)-bulkPreviewLimit)
+				break
+			}
+			fmt.Printf("  %s %s\n", ui.RenderID(c.ID), c.Title)
+			for _, line := range c.Changes {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+
+	if len(result.Refused) == 0 {
+		return
+	}
+	fmt.Printf("%s Refusing to close %d issue(s):\n", ui.RenderWarn("⚠"), len(result.Refused))
+	for i, r := range result.Refused {
+		if i == bulkPreviewLimit {
+			fmt.Printf("  ... and %d more\n", len(result.Refused)-bulkPreviewLimit)
+			break
+		}
+		fmt.Printf("  %s %s\n", ui.RenderID(r.ID), r.Title)
+		fmt.Printf("      %s\n", r.Reason)
+	}
+}
+
+// refuseUnclosable drops the changes that close an issue bd close would
+// refuse to close: templates, and unless force, pinned issues and issues
+// blocked by open issues. Blockers closed by the same changes do not count,
+// as if bd close had been given the issues in order.
+func refuseUnclosable(ctx context.Context, s storage.Storage, issues []*types.Issue, changes []*BulkChange, force bool) ([]*BulkChange, []*BulkRefusal, error) {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	closing := make(map[string]bool)
+	for _, c := range changes {
+		if c.closes() {
+			closing[c.ID] = true
+		}
+	}
+
+	var kept []*BulkChange
+	var refused []*BulkRefusal
+	for _, c := range changes {
+		if !c.closes() {
+			kept = append(kept, c)
+			continue
+		}
+		reason := ""
+		if err := validateIssueClosable(c.ID, byID[c.ID], force); err != nil {
+			reason = err.Error()
+		} else if !force {
+			// Check if issue has open blockers (GH#962)
+			blocked, blockers, err := s.IsBlocked(ctx, c.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("checking blockers for %s: %w", c.ID, err)
+			}
+			var open []string
+			for _, b := range blockers {
+				if !closing[b] {
+					open = append(open, b)
+				}
+			}
+			if blocked && len(open) > 0 {
+				reason = fmt.Sprintf("blocked by open issues %v (use --force to override)", open)
+			}
+		}
+		if reason == "" {
+			kept = append(kept, c)
+			continue
+		}
+		refused = append(refused, &BulkRefusal{ID: c.ID, Title: c.Title, Reason: reason})
+	}
+	return kept, refused, nil
+}
+
+// parseBulkFilter parses bd list filter flags, given as one string, into a
+// filter built exactly as bd list builds it. defaultStatus, if set, replaces
+// bd list's default of excluding closed issues when --status, --all and
+// --ready are not set. Display flags such as --limit or --sort are refused.
+func parseBulkFilter(spec string, defaultStatus *types.Status) (types.IssueFilter, error) {
+	words, err := splitFlagWords(spec)
+	if err != nil {
+		return types.IssueFilter{}, err
+	}
+
+	cmd := &cobra.Command{Use: "filter"}
+	registerListFilterFlags(cmd)
+	cmd.Flags().SetOutput(&strings.Builder{})
+	if err := cmd.Flags().Parse(words); err != nil {
+		if strings.HasPrefix(err.Error(), "unknown") {
+			return types.IssueFilter{}, fmt.Errorf("%v (--filter takes only the filter flags of bd list; see 'bd list --help')", err)
+		}
+		return types.IssueFilter{}, err
+	}
+	if cmd.Flags().NArg() > 0 {
+		return types.IssueFilter{}, fmt.Errorf("unexpected argument %q (pass issue IDs outside --filter)", cmd.Flags().Arg(0))
+	}
+
+	filter, err := buildListFilter(cmd)
+	if err != nil {
+		return filter, err
+	}
+	if defaultStatus != nil && filter.Status == nil && filter.ExcludeStatus != nil {
+		filter.Status = defaultStatus
+		filter.ExcludeStatus = nil
+	}
+	return filter, nil
+}
+
+// splitFlagWords splits a flag string into words, honoring single and double
+// quotes.
+func splitFlagWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// parseBulkSet turns field=value pairs into UpdateIssue updates.
+func parseBulkSet(pairs []string) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+	for _, pair := range pairs {
+		field, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("--set %q: want field=value", pair)
+		}
+		field = strings.TrimSpace(field)
+		switch field {
+		case "status":
+			if !types.Status(value).IsValid() {
+				return nil, fmt.Errorf("--set status: invalid status %q", value)
+			}
+			updates["status"] = value
+		case "priority":
+			p, err := validation.ValidatePriority(value)
+			if err != nil {
+				return nil, fmt.Errorf("--set priority: %w", err)
+			}
+			updates["priority"] = p
+		case "type":
+			t := util.NormalizeIssueType(value)
+			if !types.IssueType(t).IsValid() {
+				return nil, fmt.Errorf("--set type: invalid issue type %q", value)
+			}
+			updates["issue_type"] = t
+		case "assignee", "title", "description", "design", "notes":
+			if field == "title" && strings.TrimSpace(value) == "" {
+				return nil, fmt.Errorf("--set title: title cannot be empty")
+			}
+			updates[field] = value
+		case "acceptance":
+			updates["acceptance_criteria"] = value
+		case "external-ref":
+			updates["external_ref"] = value
+		case "estimate":
+			minutes, err := strconv.Atoi(value)
+			if err != nil || minutes < 0 {
+				return nil, fmt.Errorf("--set estimate: want a non-negative number of minutes, got %q", value)
+			}
+			updates["estimated_minutes"] = minutes
+		case "due", "defer":
+			key := map[string]string{"due": "due_at", "defer": "defer_until"}[field]
+			if value == "" {
+				updates[key] = nil
+				continue
+			}
+			t, err := timeparsing.ParseRelativeTime(value, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("--set %s: invalid time %q. Examples: +1h, tomorrow, next monday, 2025-01-15", field, value)
+			}
+			updates[key] = t
+		default:
+			return nil, fmt.Errorf("--set: unknown field %q (status, priority, type, assignee, title, description, design, notes, acceptance, external-ref, estimate, due, defer)", field)
+		}
+	}
+	return updates, nil
+}
+
+// planBulkChanges works out what the plan changes on each issue, leaving out
+// issues it would not change.
+func planBulkChanges(issues []*types.Issue, plan *bulkPlan) []*BulkChange {
+	var changes []*BulkChange
+	for _, issue := range issues {
+		c := &BulkChange{ID: issue.ID, Title: issue.Title, status: issue.Status, updates: map[string]interface{}{}}
+		updates := plan.Updates
+		switch plan.Op {
+		case "close":
+			if issue.Status == types.StatusClosed {
+				continue
+			}
+			updates = map[string]interface{}{"status": string(types.StatusClosed)}
+		case "reopen":
+			// Like bd reopen, only closed and deferred issues reopen
+			if issue.Status != types.StatusClosed && issue.Status != types.StatusDeferred {
+				continue
+			}
+			updates = map[string]interface{}{"status": string(types.StatusOpen)}
+		case "defer":
+			if issue.Status == types.StatusClosed {
+				continue
+			}
+			updates = map[string]interface{}{"status": string(types.StatusDeferred)}
+			for k, v := range plan.Updates {
+				updates[k] = v
+			}
+		}
+
+		keys := make([]string, 0, len(updates))
+		for k := range updates {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			oldValue, newValue := bulkFieldValue(issue, key), formatBulkValue(key, updates[key])
+			if oldValue == newValue {
+				continue
+			}
+			c.updates[key] = updates[key]
+			c.Changes = append(c.Changes, fmt.Sprintf("%s: %s → %s", key, oldValue, newValue))
+		}
+
+		has := make(map[string]bool, len(issue.Labels))
+		for _, l := range issue.Labels {
+			has[l] = true
+		}
+		for _, l := range plan.AddLabels {
+			if !has[l] {
+				c.addLabels = append(c.addLabels, l)
+				c.Changes = append(c.Changes, "+label "+l)
+			}
+		}
+		for _, l := range plan.RemoveLabels {
+			if has[l] {
+				c.removeLabels = append(c.removeLabels, l)
+				c.Changes = append(c.Changes, "-label "+l)
+			}
+		}
+
+		if len(c.Changes) > 0 {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// bulkFieldValue renders an issue's current value of an update key.
+func bulkFieldValue(issue *types.Issue, key string) string {
+	switch key {
+	case "status":
+		return string(issue.Status)
+	case "priority":
+		return fmt.Sprintf("P%d", issue.Priority)
+	case "issue_type":
+		return string(issue.IssueType)
+	case "assignee":
+		return formatBulkValue(key, issue.Assignee)
+	case "title":
+		return issue.Title
+	case "description":
+		return formatBulkValue(key, issue.Description)
+	case "design":
+		return formatBulkValue(key, issue.Design)
+	case "notes":
+		return formatBulkValue(key, issue.Notes)
+	case "acceptance_criteria":
+		return formatBulkValue(key, issue.AcceptanceCriteria)
+	case "external_ref":
+		if issue.ExternalRef == nil {
+			return formatBulkValue(key, "")
+		}
+		return formatBulkValue(key, *issue.ExternalRef)
+	case "estimated_minutes":
+		if issue.EstimatedMinutes == nil {
+			return "(none)"
+		}
+		return formatBulkValue(key, *issue.EstimatedMinutes)
+	case "due_at":
+		if issue.DueAt == nil {
+			return "(none)"
+		}
+		return formatBulkValue(key, *issue.DueAt)
+	case "defer_until":
+		if issue.DeferUntil == nil {
+			return "(none)"
+		}
+		return formatBulkValue(key, *issue.DeferUntil)
+	}
+	return ""
+}
+
+// formatBulkValue renders an update value for the diff.
+func formatBulkValue(key string, v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		if v == "" {
+			return "(none)"
+		}
+		if len(v) > 40 {
+			return fmt.Sprintf("%q", v[:37]+"...")
+		}
+		if key == "status" || key == "issue_type" || key == "title" {
+			return v
+		}
+		return fmt.Sprintf("%q", v)
+	case int:
+		if key == "priority" {
+			return fmt.Sprintf("P%d", v)
+		}
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.Local().Format("2006-01-02 15:04")
+	}
+	return fmt.Sprint(v)
+}
+
+// applyBulkPlan applies the planned changes in one transaction, so either
+// every issue changes or none does.
+func applyBulkPlan(ctx context.Context, s storage.Storage, plan *bulkPlan, changes []*BulkChange, actor string) error {
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, c := range changes {
+			updates := c.updates
+			if c.closes() {
+				// Closing goes through CloseIssue, as bd close does, so the
+				// close reason, timestamp and session are recorded
+				reason := plan.Reason
+				if reason == "" {
+					reason = "Closed"
+				}
+				if err := tx.CloseIssue(ctx, c.ID, reason, actor, plan.Session); err != nil {
+					return fmt.Errorf("closing %s: %w", c.ID, err)
+				}
+				updates = make(map[string]interface{}, len(c.updates))
+				for k, v := range c.updates {
+					if k != "status" {
+						updates[k] = v
+					}
+				}
+			}
+			if len(updates) > 0 {
+				if err := tx.UpdateIssue(ctx, c.ID, updates, actor); err != nil {
+					return fmt.Errorf("updating %s: %w", c.ID, err)
+				}
+			}
+			for _, l := range c.addLabels {
+				if err := tx.AddLabel(ctx, c.ID, l, actor); err != nil {
+					return fmt.Errorf("labeling %s: %w", c.ID, err)
+				}
+			}
+			for _, l := range c.removeLabels {
+				if err := tx.RemoveLabel(ctx, c.ID, l, actor); err != nil {
+					return fmt.Errorf("unlabeling %s: %w", c.ID, err)
+				}
+			}
+			if plan.Op == "reopen" && plan.Reason != "" {
+				if err := tx.AddComment(ctx, c.ID, actor, plan.Reason); err != nil {
+					return fmt.Errorf("commenting on %s: %w", c.ID, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func init() {
+	bulkCmd.PersistentFlags().String("filter", "", "bd list flags selecting the issues, quoted as one argument")
+	bulkCmd.PersistentFlags().Bool("dry-run", false, "Preview the changes without applying them")
+	bulkCmd.PersistentFlags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	bulkCmd.PersistentFlags().Int("confirm-above", 20, "Ask for confirmation when changing more issues than this")
+
+	bulkUpdateCmd.Flags().StringArray("set", nil, "Set a field: field=value (repeatable)")
+	bulkUpdateCmd.Flags().String("assignee", "", "Set the assignee (empty to unassign)")
+	bulkUpdateCmd.Flags().StringSlice("add-label", nil, "Add labels (repeatable)")
+	bulkUpdateCmd.Flags().StringSlice("remove-label", nil, "Remove labels (repeatable)")
+	bulkUpdateCmd.Flags().BoolP("force", "f", false, "With --set status=closed, close pinned and blocked issues too")
+	bulkCloseCmd.Flags().StringP("reason", "r", "", "Reason for closing")
+	bulkCloseCmd.Flags().BoolP("force", "f", false, "Close pinned and blocked issues too")
+	bulkReopenCmd.Flags().StringP("reason", "r", "", "Reason for reopening (added as a comment)")
+	bulkDeferCmd.Flags().String("until", "", "Defer until this time (e.g. +1w, next monday, 2025-02-01)")
+
+	bulkCmd.AddCommand(bulkUpdateCmd, bulkCloseCmd, bulkReopenCmd, bulkDeferCmd)
+	rootCmd.AddCommand(bulkCmd)
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/worklog"
+)
+
+func TestSplitFlagWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"--label triage  --priority 1", []string{"--label", "triage", "--priority", "1"}},
+		{`--title-contains 'login page' --assignee "bob smith"`, []string{"--title-contains", "login page", "--assignee", "bob smith"}},
+		{`--notes-contains ""`, []string{"--notes-contains", ""}},
+	}
+	for _, tt := range tests {
+		got, err := splitFlagWords(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitFlagWords(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := splitFlagWords("--title 'open"); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func TestParseBulkFilter(t *testing.T) {
+	f, err := parseBulkFilter("--label triage -l ui --priority-min 2 --type bug --created-after 2025-01-01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(f.Labels, []string{"triage", "ui"}) || f.PriorityMin == nil || *f.PriorityMin != 2 ||
+		f.IssueType == nil || *f.IssueType != types.TypeBug || f.CreatedAfter == nil {
+		t.Errorf("filter = %+v", f)
+	}
+	if !reflect.DeepEqual(f.ExcludeStatus, []types.Status{types.StatusClosed}) {
+		t.Errorf("closed issues should be excluded by default, got %v", f.ExcludeStatus)
+	}
+
+	closed := types.StatusClosed
+	if f, _ := parseBulkFilter("", &closed); f.Status == nil || *f.Status != closed || f.ExcludeStatus != nil {
+		t.Errorf("default status filter = %+v", f)
+	}
+	if f, _ := parseBulkFilter("--all", nil); f.Status != nil || f.ExcludeStatus != nil {
+		t.Errorf("--all filter = %+v", f)
+	}
+
+	if f, _ := parseBulkFilter("--ready --type gate", &closed); f.Status == nil || *f.Status != types.StatusOpen || f.ExcludeTypes != nil {
+		t.Errorf("--ready --type gate filter = %+v", f)
+	}
+
+	for _, bad := range []string{"--priority 9", "--bogus", "stray", "--created-after nope", "--pinned --no-pinned"} {
+		if _, err := parseBulkFilter(bad, nil); err == nil {
+			t.Errorf("parseBulkFilter(%q) should fail", bad)
+		}
+	}
+	// bd list display flags are not filters
+	for _, display := range []string{"--limit 5", "--sort priority", "-n 5"} {
+		if _, err := parseBulkFilter(display, nil); err == nil || !strings.Contains(err.Error(), "bd list") {
+			t.Errorf("parseBulkFilter(%q) = %v, want an error pointing to bd list", display, err)
+		}
+	}
+}
+
+func TestParseBulkSet(t *testing.T) {
+	updates, err := parseBulkSet([]string{"priority=P1", "type=enhancement", "acceptance=done when green", "assignee=", "due="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"priority":            1,
+		"issue_type":          "feature",
+		"acceptance_criteria": "done when green",
+		"assignee":            "",
+		"due_at":              nil,
+	}
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("updates = %v, want %v", updates, want)
+	}
+	for _, bad := range []string{"priority", "priority=7", "status=nope", "colour=red", "estimate=-5", "title="} {
+		if _, err := parseBulkSet([]string{bad}); err == nil {
+			t.Errorf("parseBulkSet(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBulkPlanAndApply(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+
+	create := func(title string, priority int, labels ...string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Priority: priority, Status: types.StatusOpen, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range labels {
+			if err := s.AddLabel(ctx, issue.ID, l, "test"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		issue.Labels = labels
+		return issue
+	}
+	a := create("needs triage", 3, "triage")
+	b := create("already done", 2)
+
+	plan := &bulkPlan{
+		Op:           "update",
+		Updates:      map[string]interface{}{"priority": 2},
+		AddLabels:    []string{"triaged"},
+		RemoveLabels: []string{"triage"},
+	}
+	changes := planBulkChanges([]*types.Issue{a, b}, plan)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+	if want := []string{"priority: P3 → P2", "+label triaged", "-label triage"}; !reflect.DeepEqual(changes[0].Changes, want) {
+		t.Errorf("a changes = %q, want %q", changes[0].Changes, want)
+	}
+	if want := []string{"+label triaged"}; !reflect.DeepEqual(changes[1].Changes, want) {
+		t.Errorf("b changes = %q, want %q (priority is already 2)", changes[1].Changes, want)
+	}
+
+	if err := applyBulkPlan(ctx, s, plan, changes, "test"); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.GetIssue(ctx, a.ID)
+	labels, _ := s.GetLabels(ctx, a.ID)
+	if got.Priority != 2 || !reflect.DeepEqual(labels, []string{"triaged"}) {
+		t.Errorf("a = p%d %v", got.Priority, labels)
+	}
+
+	// Closing skips issues already closed
+	got.Labels = labels
+	if err := s.CloseIssue(ctx, b.ID, "done", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+	closedB, _ := s.GetIssue(ctx, b.ID)
+	closePlan := &bulkPlan{Op: "close", Reason: "triaged"}
+	changes = planBulkChanges([]*types.Issue{got, closedB}, closePlan)
+	if len(changes) != 1 || changes[0].ID != a.ID {
+		t.Fatalf("close changes = %+v, want only %s", changes, a.ID)
+	}
+	if err := applyBulkPlan(ctx, s, closePlan, changes, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetIssue(ctx, a.ID); got.Status != types.StatusClosed || got.CloseReason != "triaged" {
+		t.Errorf("a = %s %q, want closed as triaged", got.Status, got.CloseReason)
+	}
+}
+
+func TestBulkApplyIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	issue := &types.Issue{Title: "real", Priority: 2, Status: types.StatusOpen, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := &bulkPlan{Op: "update", Updates: map[string]interface{}{"priority": 0}}
+	changes := []*BulkChange{
+		{ID: issue.ID, updates: map[string]interface{}{"priority": 0}},
+		{ID: "test-missing", updates: map[string]interface{}{"priority": 0}},
+	}
+	if err := applyBulkPlan(ctx, s, plan, changes, "test"); err == nil {
+		t.Fatal("expected the missing issue to fail the batch")
+	}
+	if got, _ := s.GetIssue(ctx, issue.ID); got.Priority != 2 {
+		t.Errorf("priority = %d, want the failed batch rolled back", got.Priority)
+	}
+}
+
+func TestBulkReopenAndCloseFollowUp(t *testing.T) {
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("failed to initialize config: %v", err)
+	}
+	config.Set(worklog.AutoTimerKey, true)
+	t.Cleanup(func() { config.Set(worklog.AutoTimerKey, false) })
+
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	var issues []*types.Issue
+	for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusDeferred, types.StatusClosed} {
+		issue := &types.Issue{Title: string(status), Priority: 2, Status: status, IssueType: types.TypeTask}
+		if status == types.StatusClosed {
+			now := time.Now()
+			issue.ClosedAt = &now
+		}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		issues = append(issues, issue)
+	}
+
+	// Only closed and deferred issues reopen
+	changes := planBulkChanges(issues, &bulkPlan{Op: "reopen"})
+	var ids []string
+	for _, c := range changes {
+		ids = append(ids, c.ID)
+	}
+	if want := []string{issues[3].ID, issues[4].ID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("reopen changes = %v, want %v", ids, want)
+	}
+
+	// Closing in bulk stops the running timer, as bd close does
+	inProgress := issues[1]
+	if _, err := worklog.Start(ctx, s, inProgress.ID, "test", "", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	plan := &bulkPlan{Op: "close", Reason: "done"}
+	changes = planBulkChanges([]*types.Issue{inProgress}, plan)
+	if err := applyBulkPlan(ctx, s, plan, changes, "test"); err != nil {
+		t.Fatal(err)
+	}
+	finishBulkChanges(ctx, s, plan, changes)
+	logs, err := s.GetWorkLogs(ctx, inProgress.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := worklog.Running(logs, "test"); w != nil {
+		t.Errorf("timer still running after bulk close: %+v", w)
+	}
+}
+
+func TestBulkCloseRefusesLikeClose(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	create := func(issue *types.Issue) *types.Issue {
+		t.Helper()
+		issue.Priority = 2
+		issue.IssueType = types.TypeTask
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		return issue
+	}
+	block := func(blocked, blocker *types.Issue) {
+		t.Helper()
+		dep := &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plain := create(&types.Issue{Title: "plain", Status: types.StatusOpen})
+	pinned := create(&types.Issue{Title: "pinned", Status: types.StatusPinned})
+	template := create(&types.Issue{Title: "template", Status: types.StatusOpen, IsTemplate: true})
+	outside := create(&types.Issue{Title: "outside blocker", Status: types.StatusOpen})
+	blocked := create(&types.Issue{Title: "blocked", Status: types.StatusOpen})
+	block(blocked, outside)
+	// Blocked only by an issue closed in the same batch
+	chained := create(&types.Issue{Title: "chained", Status: types.StatusOpen})
+	block(chained, plain)
+
+	refusedIDs := func(plan *bulkPlan) ([]string, []*BulkChange) {
+		t.Helper()
+		changes := planBulkChanges([]*types.Issue{plain, pinned, template, blocked, chained}, plan)
+		kept, refused, err := refuseUnclosable(ctx, s, []*types.Issue{plain, pinned, template, blocked, chained}, changes, plan.Force)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, r := range refused {
+			ids = append(ids, r.ID)
+		}
+		return ids, kept
+	}
+
+	if got, _ := refusedIDs(&bulkPlan{Op: "close"}); !reflect.DeepEqual(got, []string{pinned.ID, template.ID, blocked.ID}) {
+		t.Errorf("refused %v, want pinned, template and blocked", got)
+	}
+	if got, _ := refusedIDs(&bulkPlan{Op: "close", Force: true}); !reflect.DeepEqual(got, []string{template.ID}) {
+		t.Errorf("refused with --force %v, want only the template", got)
+	}
+
+	// --set status=closed is a close too: it is validated and closes through
+	// CloseIssue, recording the close reason
+	plan := &bulkPlan{Op: "update", Updates: map[string]interface{}{"status": string(types.StatusClosed), "priority": 1}}
+	got, kept := refusedIDs(plan)
+	if !reflect.DeepEqual(got, []string{pinned.ID, template.ID, blocked.ID}) {
+		t.Errorf("update refused %v, want pinned, template and blocked", got)
+	}
+	if err := applyBulkPlan(ctx, s, plan, kept, "test"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{plain.ID, chained.ID} {
+		issue, _ := s.GetIssue(ctx, id)
+		if issue.Status != types.StatusClosed || issue.ClosedAt == nil || issue.CloseReason != "Closed" || issue.Priority != 1 {
+			t.Errorf("%s = %s p%d %q, want closed as Closed at P1", id, issue.Status, issue.Priority, issue.CloseReason)
+		}
+	}
+}
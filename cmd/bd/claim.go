@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/lease"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+var claimCmd = &cobra.Command{
+	Use:     "claim",
+	GroupID: "issues",
+	Short:   "Atomically claim the top ready issue",
+	Long: `Claim the top ready issue: pick it, assign it to you and set it in_progress
+in one transaction, so agents running bd claim at the same time never get the
+same issue.
+
+Only open, unassigned work is considered; the filters narrow it like bd ready.
+The claim is a lease: unless renewed it expires after --ttl (default
+claim.lease-ttl, 30m), and the issue goes back to open and unassigned with a
+comment saying whose lease lapsed. 'bd agent heartbeat <agent>' renews the
+leases held by that agent and by the calling actor. The daemon reclaims
+expired leases every minute; without one, the next bd claim does it.
+
+Exits with status 1 when there is nothing to claim.
+
+Examples:
+  bd claim                          # Claim the top ready issue as $BD_ACTOR
+  bd claim -t bug -p 0              # Only P0 bugs
+  bd claim -l backend --ttl 2h      # Backend work, with a 2 hour lease
+  bd claim --assignee gt-emma       # Claim on behalf of an agent
+  bd claim list                     # Show active leases`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("claim")
+		ctx := rootCtx
+
+		holder, _ := cmd.Flags().GetString("assignee")
+		if holder == "" {
+			holder = actor
+		}
+		ttl, err := claimLeaseTTL(cmd)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		issueType, _ := cmd.Flags().GetString("type")
+		issueType = util.NormalizeIssueType(issueType)
+		sortPolicy, _ := cmd.Flags().GetString("sort")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		parentID, _ := cmd.Flags().GetString("parent")
+		var priority *int
+		if cmd.Flags().Changed("priority") {
+			p, _ := cmd.Flags().GetInt("priority")
+			priority = &p
+		}
+		if !types.SortPolicy(sortPolicy).IsValid() {
+			FatalErrorRespectJSON("invalid sort policy '%s'. Valid values: hybrid, priority, oldest, due", sortPolicy)
+		}
+
+		var result rpc.ClaimResult
+		if daemonClient != nil {
+			resp, err := daemonClient.Claim(&rpc.ClaimArgs{
+				Holder:     holder,
+				LeaseTTL:   ttl,
+				Priority:   priority,
+				Type:       issueType,
+				SortPolicy: sortPolicy,
+				Labels:     labels,
+				LabelsAny:  labelsAny,
+				ParentID:   parentID,
+			})
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if err := json.Unmarshal(resp.Data, &result); err != nil {
+				FatalErrorRespectJSON("parsing response: %v", err)
+			}
+		} else {
+			filter := types.WorkFilter{
+				Type:       issueType,
+				Priority:   priority,
+				SortPolicy: types.SortPolicy(sortPolicy),
+				Labels:     util.NormalizeLabels(labels),
+				LabelsAny:  util.NormalizeLabels(labelsAny),
+			}
+			if parentID != "" {
+				filter.ParentID = &parentID
+			}
+			if err := ensureDatabaseFresh(ctx); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			now := time.Now()
+			if _, err := lease.Reclaim(ctx, store, now); err != nil {
+				FatalErrorRespectJSON("reclaiming expired leases: %v", err)
+			}
+			result.Issue, result.Lease, err = lease.ClaimNext(ctx, store, filter, holder, ttl, now)
+			markDirtyAndScheduleFlush()
+			if err != nil {
+				if errors.Is(err, lease.ErrNoWork) {
+					FatalErrorRespectJSON("%v", err)
+				}
+				FatalErrorRespectJSON("claim failed: %v", err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		fmt.Printf("%s Claimed %s: %s\n", ui.RenderPass("✓"), ui.RenderID(result.Issue.ID), result.Issue.Title)
+		fmt.Printf("  Assignee: %s, lease expires %s\n", result.Lease.Holder, formatLeaseExpiry(result.Lease, time.Now()))
+	},
+}
+
+var claimListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show active claim leases",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureStoreActive(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		leases, err := lease.List(rootCtx, store)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if leases == nil {
+				leases = []*lease.Lease{}
+			}
+			outputJSON(leases)
+			return
+		}
+		if len(leases) == 0 {
+			fmt.Println("No active leases")
+			return
+		}
+		now := time.Now()
+		for _, l := range leases {
+			expiry := formatLeaseExpiry(l, now)
+			if l.Expired(now) {
+				expiry = ui.RenderWarn(expiry)
+			}
+			fmt.Printf("%s  %-20s  %s\n", ui.RenderID(l.IssueID), l.Holder, expiry)
+		}
+	},
+}
+
+// claimLeaseTTL returns --ttl, or claim.lease-ttl from config.
+func claimLeaseTTL(cmd *cobra.Command) (time.Duration, error) {
+	value, _ := cmd.Flags().GetString("ttl")
+	if value == "" {
+		return configuredLeaseTTL(), nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("--ttl must be a positive duration like 30m or 2h, got %q", value)
+	}
+	return ttl, nil
+}
+
+// configuredLeaseTTL returns claim.lease-ttl, or the default if it is unset
+// or invalid.
+func configuredLeaseTTL() time.Duration {
+	ttl, err := time.ParseDuration(config.GetString("claim.lease-ttl"))
+	if err != nil || ttl <= 0 {
+		return lease.DefaultTTL
+	}
+	return ttl
+}
+
+func formatLeaseExpiry(l *lease.Lease, now time.Time) string {
+	left := l.ExpiresAt.Sub(now).Round(time.Second)
+	if left <= 0 {
+		return fmt.Sprintf("expired %s ago", (-left).String())
+	}
+	return fmt.Sprintf("in %s", left.String())
+}
+
+func init() {
+	claimCmd.Flags().String("assignee", "", "Claim on behalf of this holder (default: actor)")
+	claimCmd.Flags().String("ttl", "", "Lease length, e.g. 30m or 2h (default: claim.lease-ttl)")
+	claimCmd.Flags().IntP("priority", "p", 0, "Only claim issues with this priority")
+	claimCmd.Flags().StringP("type", "t", "", "Only claim issues of this type")
+	claimCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest, due")
+	claimCmd.Flags().StringSliceP("label", "l", []string{}, "Only claim issues with ALL of these labels")
+	claimCmd.Flags().StringSlice("label-any", []string{}, "Only claim issues with AT LEAST ONE of these labels")
+	claimCmd.Flags().String("parent", "", "Only claim descendants of this bead/epic")
+	claimCmd.AddCommand(claimListCmd)
+	rootCmd.AddCommand(claimCmd)
+}
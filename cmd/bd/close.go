@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
@@ -20,7 +23,12 @@ var closeCmd = &cobra.Command{
 	Long: `Close one or more issues.
 
 If no issue ID is provided, closes the last touched issue (from most recent
-create, update, show, or close operation).`,
+create, update, show, or close operation).
+
+Closing an issue may close others in the same transaction under the
+close.cascade-* settings in config.yaml: finished epics, the issue work was
+delegated from, and issues linked "until" it. They are listed under the
+close and linked to it with caused-by edges.`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("close")
@@ -173,6 +181,9 @@ create, update, show, or close operation).`,
 						fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), id, reason)
 					}
 				}
+				if cascaded := closeCascade(ctx, daemonCascadeReader{daemonClient}, id); jsonOutput {
+					closedIssues = append(closedIssues, cascaded...)
+				}
 			}
 
 			// Handle routed IDs via direct mode (cross-rig)
@@ -216,6 +227,7 @@ create, update, show, or close operation).`,
 					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 					continue
 				}
+				applyAutoTimer(ctx, result.Store, result.ResolvedID, result.Issue.Status, types.StatusClosed)
 
 				// Get updated issue for hook
 				closedIssue, _ := result.Store.GetIssue(ctx, result.ResolvedID)
@@ -230,6 +242,9 @@ create, update, show, or close operation).`,
 				} else {
 					fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), result.ResolvedID, reason)
 				}
+				if cascaded := closeCascade(ctx, result.Store, result.ResolvedID); jsonOutput {
+					closedIssues = append(closedIssues, cascaded...)
+				}
 				result.Close()
 			}
 
@@ -277,6 +292,9 @@ create, update, show, or close operation).`,
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				continue
 			}
+			if issue != nil {
+				applyAutoTimer(ctx, store, id, issue.Status, types.StatusClosed)
+			}
 
 			closedCount++
 
@@ -293,6 +311,9 @@ create, update, show, or close operation).`,
 			} else {
 				fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), id, reason)
 			}
+			if cascaded := closeCascade(ctx, store, id); jsonOutput {
+				closedIssues = append(closedIssues, cascaded...)
+			}
 		}
 
 		// Handle routed IDs (cross-rig)
@@ -336,6 +357,7 @@ create, update, show, or close operation).`,
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				continue
 			}
+			applyAutoTimer(ctx, result.Store, result.ResolvedID, result.Issue.Status, types.StatusClosed)
 
 			closedCount++
 
@@ -352,6 +374,9 @@ create, update, show, or close operation).`,
 			} else {
 				fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), result.ResolvedID, reason)
 			}
+			if cascaded := closeCascade(ctx, result.Store, result.ResolvedID); jsonOutput {
+				closedIssues = append(closedIssues, cascaded...)
+			}
 			result.Close()
 		}
 
@@ -403,6 +428,75 @@ create, update, show, or close operation).`,
 	},
 }
 
+// closeCascade returns the issues that closing id closed by cascade
+// (close.cascade-* in config.yaml), running their close hooks and listing
+// them unless output is JSON. The cascade has already been committed, so
+// failing to read it back is only a warning.
+func closeCascade(ctx context.Context, r storage.CascadeReader, id string) []*types.Issue {
+	if !config.GetCloseCascadeConfig().Enabled() {
+		return nil
+	}
+	cascaded, err := storage.CascadedCloses(ctx, r, id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not list issues closed by cascade from %s: %v\n", id, err)
+		return nil
+	}
+	for _, issue := range cascaded {
+		if hookRunner != nil {
+			hookRunner.Run(hooks.EventClose, issue)
+		}
+		if !jsonOutput {
+			fmt.Printf("  %s Closed %s: %s\n", ui.RenderPass("↳"), issue.ID, issue.CloseReason)
+		}
+	}
+	return cascaded
+}
+
+// daemonCascadeReader reads cascaded closes back through the daemon.
+type daemonCascadeReader struct {
+	client *rpc.Client
+}
+
+func (r daemonCascadeReader) details(id string) (*types.IssueDetails, error) {
+	resp, err := r.client.Show(&rpc.ShowArgs{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	var details types.IssueDetails
+	if err := json.Unmarshal(resp.Data, &details); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &details, nil
+}
+
+func (r daemonCascadeReader) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	details, err := r.details(id)
+	if err != nil {
+		return nil, err
+	}
+	return &details.Issue, nil
+}
+
+func (r daemonCascadeReader) GetEvents(_ context.Context, id string, limit int) ([]*types.Event, error) {
+	resp, err := r.client.ListEvents(&rpc.EventListArgs{ID: id, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	var events []*types.Event
+	if err := json.Unmarshal(resp.Data, &events); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return events, nil
+}
+
+func (r daemonCascadeReader) GetDependentsWithMetadata(_ context.Context, id string) ([]*types.IssueWithDependencyMetadata, error) {
+	details, err := r.details(id)
+	if err != nil {
+		return nil, err
+	}
+	return details.Dependents, nil
+}
+
 func init() {
 	closeCmd.Flags().StringP("reason", "r", "", "Reason for closing")
 	closeCmd.Flags().String("resolution", "", "Alias for --reason (Jira CLI convention)")
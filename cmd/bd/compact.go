@@ -34,6 +34,7 @@ var (
 	compactLimit           int
 	compactOlderThan       int
 	compactDolt            bool
+	compactDropAttachments bool
 )
 
 var compactCmd = &cobra.Command{
@@ -50,6 +51,7 @@ Modes:
   - Apply: Accept agent-provided summary (no API key needed)
   - Auto: AI-powered compaction (requires ANTHROPIC_API_KEY, legacy)
   - Dolt: Run Dolt garbage collection (for Dolt-backend repositories)
+  - Drop attachments: Remove attachments from already-compacted issues
 
 Tiers:
   - Tier 1: Semantic compression (30 days closed, 70% reduction)
@@ -72,6 +74,12 @@ Dolt Garbage Collection:
   --dolt: Run Dolt GC on .beads/dolt directory to free disk space.
           This removes unreachable commits and compacts storage.
 
+Attachment Cleanup:
+  Compaction discards an issue's original text but leaves its attachments.
+
+  --drop-attachments: Remove attachment metadata from compacted issues and
+           delete blobs in .beads/blobs that nothing references anymore.
+
 Examples:
   # Age-based pruning
   bd compact --prune                       # Remove tombstones older than 30 days
@@ -86,6 +94,10 @@ Examples:
   bd compact --dolt                        # Run Dolt GC
   bd compact --dolt --dry-run              # Preview without running GC
 
+  # Attachment cleanup
+  bd compact --drop-attachments --dry-run  # Preview attachments to drop
+  bd compact --drop-attachments            # Drop attachments of compacted issues
+
   # Agent-driven workflow (recommended)
   bd compact --analyze --json              # Get candidates with full content
   bd compact --apply --id bd-42 --summary summary.txt
@@ -127,6 +139,12 @@ Examples:
 			return
 		}
 
+		// Handle drop-attachments mode (blob cleanup for compacted issues)
+		if compactDropAttachments {
+			runCompactDropAttachments(ctx)
+			return
+		}
+
 		// Handle prune mode (standalone tombstone pruning by age)
 		if compactPrune {
 			runCompactPrune()
@@ -938,6 +956,7 @@ func init() {
 	compactCmd.Flags().StringVar(&compactActor, "actor", "agent", "Actor name for audit trail")
 	compactCmd.Flags().IntVar(&compactLimit, "limit", 0, "Limit number of candidates (0 = no limit)")
 	compactCmd.Flags().BoolVar(&compactDolt, "dolt", false, "Dolt mode: run Dolt garbage collection on .beads/dolt")
+	compactCmd.Flags().BoolVar(&compactDropAttachments, "drop-attachments", false, "Drop attachments of compacted issues and delete unreferenced blobs")
 
 	// Note: compactCmd is added to adminCmd in admin.go
 }
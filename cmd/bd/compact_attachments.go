@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// dropAttachmentsResult describes what --drop-attachments removed (or would remove).
+type dropAttachmentsResult struct {
+	IssueIDs        []string `json:"issue_ids"`
+	AttachmentCount int      `json:"attachment_count"`
+	BlobsRemoved    []string `json:"blobs_removed"`
+	BytesFreed      int64    `json:"bytes_freed"`
+}
+
+// dropCompactedAttachments removes attachment metadata from issues that have
+// already been compacted, then deletes blobs nothing references anymore.
+// Compaction discards the original text of an issue, so the logs and
+// screenshots attached to it are dropped along with it.
+func dropCompactedAttachments(ctx context.Context, s storage.Storage, bs *blobs.Store, actor string, dryRun bool) (*dropAttachmentsResult, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	all, err := s.GetAttachmentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	result := &dropAttachmentsResult{}
+	dropped := make(map[string]bool)
+	for _, id := range ids {
+		list := all[id]
+		if len(list) == 0 {
+			continue
+		}
+		// Search results don't carry compaction metadata, so fetch the full issue
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", id, err)
+		}
+		if issue == nil || issue.CompactionLevel == 0 {
+			continue
+		}
+		dropped[id] = true
+		result.IssueIDs = append(result.IssueIDs, id)
+		result.AttachmentCount += len(list)
+		for _, a := range list {
+			if dryRun {
+				continue
+			}
+			if err := s.RemoveAttachment(ctx, id, a.Hash, actor); err != nil {
+				return nil, fmt.Errorf("failed to remove %s from %s: %w", a.Name, id, err)
+			}
+		}
+	}
+
+	if dryRun {
+		// Blobs still shared with other issues survive the gc
+		kept := make(map[string]bool)
+		for id, list := range all {
+			if dropped[id] {
+				continue
+			}
+			for _, a := range list {
+				kept[a.Hash] = true
+			}
+		}
+		_, orphaned, err := bs.Check(kept)
+		if err != nil {
+			return nil, err
+		}
+		result.BlobsRemoved = orphaned
+		for _, hash := range orphaned {
+			if path, err := bs.Path(hash); err == nil {
+				if info, err := os.Stat(path); err == nil {
+					result.BytesFreed += info.Size()
+				}
+			}
+		}
+		return result, nil
+	}
+
+	result.BlobsRemoved, result.BytesFreed, err = gcBlobs(ctx, s, bs, false)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// runCompactDropAttachments handles the --drop-attachments mode.
+func runCompactDropAttachments(ctx context.Context) {
+	start := time.Now()
+
+	if err := ensureDirectMode("compact --drop-attachments requires direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	actor := compactActor
+	if actor == "" {
+		actor = "agent"
+	}
+
+	result, err := dropCompactedAttachments(ctx, store, blobStore(), actor, compactDryRun)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"dry_run":          compactDryRun,
+			"issue_ids":        result.IssueIDs,
+			"attachment_count": result.AttachmentCount,
+			"blobs_removed":    result.BlobsRemoved,
+			"bytes_freed":      result.BytesFreed,
+			"elapsed_ms":       time.Since(start).Milliseconds(),
+		})
+		return
+	}
+
+	if result.AttachmentCount == 0 {
+		fmt.Println("No attachments on compacted issues")
+		return
+	}
+
+	if compactDryRun {
+		fmt.Printf("DRY RUN - Attachment Cleanup\n\n")
+		fmt.Printf("Attachments that would be dropped: %d (on %d compacted issue(s))\n",
+			result.AttachmentCount, len(result.IssueIDs))
+		fmt.Printf("Blobs that would be deleted: %d (%s)\n", len(result.BlobsRemoved), formatBytes(result.BytesFreed))
+		return
+	}
+
+	fmt.Printf("✓ Dropped %d attachment(s) from %d compacted issue(s)\n", result.AttachmentCount, len(result.IssueIDs))
+	fmt.Printf("  Blobs deleted: %d (%s)\n", len(result.BlobsRemoved), formatBytes(result.BytesFreed))
+	fmt.Printf("  Time: %v\n", time.Since(start))
+
+	markDirtyAndScheduleFlush()
+}
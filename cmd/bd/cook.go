@@ -457,17 +457,19 @@ func cookFormulaToSubgraph(f *formula.Formula, protoID string) (*TemplateSubgrap
 		rootDesc = "{{desc}}"
 	}
 
-	// Create root proto epic
+	// Create root proto epic. The root records which formula it was cooked
+	// from so poured molecules can later be upgraded (bd mol upgrade).
 	rootIssue := &types.Issue{
-		ID:          protoID,
-		Title:       rootTitle,
-		Description: rootDesc,
-		Status:      types.StatusOpen,
-		Priority:    2,
-		IssueType:   types.TypeEpic,
-		IsTemplate:  true,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:            protoID,
+		Title:         rootTitle,
+		Description:   rootDesc,
+		Status:        types.StatusOpen,
+		Priority:      2,
+		IssueType:     types.TypeEpic,
+		IsTemplate:    true,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		SourceFormula: f.Formula,
 	}
 	issues = append(issues, rootIssue)
 	issueMap[protoID] = rootIssue
@@ -528,7 +530,18 @@ func createGateIssue(step *formula.Step, parentID string) *types.Issue {
 		IsTemplate:  true,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+		// Source tracing: the gate belongs to its step
+		SourceFormula:  step.SourceFormula,
+		SourceLocation: gateSourceLocation(step),
+	}
+}
+
+// gateSourceLocation returns the source location of the gate guarding step.
+func gateSourceLocation(step *formula.Step) string {
+	if step.SourceLocation == "" {
+		return ""
 	}
+	return step.SourceLocation + ".gate"
 }
 
 // processStepToIssue converts a formula.Step to a types.Issue.
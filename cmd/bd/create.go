@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/routing"
+	"github.com/steveyegge/beads/internal/similarity"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/factory"
@@ -305,12 +306,31 @@ var createCmd = &cobra.Command{
 
 		// Use global jsonOutput set by PersistentPreRun
 
+		// Multi-repo: --repo naming a hydrated repo writes into the unified
+		// database under that source_repo (direct mode, so we can export it)
+		var hydratedRepo string
+		if cmd.Flags().Changed("repo") {
+			var err error
+			hydratedRepo, err = resolveCreateRepo(repoOverride)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if hydratedRepo != "" {
+				if err := ensureDirectMode("create --repo writes to a hydrated repo"); err != nil {
+					FatalError("%v", err)
+				}
+			}
+		}
+
 		// Determine target repository using routing logic
 		repoPath := "." // default to current directory
-		if cmd.Flags().Changed("repo") {
+		switch {
+		case hydratedRepo != "":
+			// Stays in the unified database; source_repo selects the JSONL
+		case cmd.Flags().Changed("repo"):
 			// Explicit --repo flag overrides auto-routing
 			repoPath = repoOverride
-		} else {
+		default:
 			// Auto-routing based on user role
 			userRole, err := routing.DetectUserRole(".")
 			if err != nil {
@@ -341,6 +361,9 @@ var createCmd = &cobra.Command{
 
 			repoPath = routing.DetermineTargetRepo(routingConfig, userRole, ".")
 		}
+		if isReadOnlyRepo(repoPath) {
+			FatalError("repo %s is read-only (listed in repos.readonly); refusing to create issues in it", repoPath)
+		}
 
 		// Switch to target repo for multi-repo support (bd-6x6g)
 		// When routing to a different repo, we bypass daemon mode and use direct storage
@@ -456,6 +479,12 @@ var createCmd = &cobra.Command{
 			externalRefPtr = &externalRef
 		}
 
+		// Look for open issues this one resembles; warned about after create
+		var similar []similarity.Match
+		if !silent && !debug.IsQuiet() && !wisp {
+			similar = findSimilarOpenIssues(rootCtx, title, description)
+		}
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			createArgs := &rpc.CreateArgs{
@@ -516,6 +545,8 @@ var createCmd = &cobra.Command{
 				fmt.Printf("  Status: %s\n", issue.Status)
 			}
 
+			warnSimilarIssues(issue.ID, similar)
+
 			// Track as last touched issue
 			SetLastTouchedID(issue.ID)
 			return
@@ -580,10 +611,16 @@ var createCmd = &cobra.Command{
 		// If we found a discovered-from dependency, inherit source_repo from parent
 		if discoveredFromParentID != "" {
 			parentIssue, err := store.GetIssue(ctx, discoveredFromParentID)
-			if err == nil && parentIssue.SourceRepo != "" {
+			if err == nil && parentIssue.SourceRepo != "" && !isReadOnlyRepo(parentIssue.SourceRepo) {
 				issue.SourceRepo = parentIssue.SourceRepo
 			}
-			// If error getting parent or parent has no source_repo, continue with default
+			// If error getting parent, parent has no source_repo, or its repo is
+			// read-only, continue with default
+		}
+
+		// Explicit --repo wins over inheritance
+		if hydratedRepo != "" {
+			issue.SourceRepo = hydratedRepo
 		}
 
 		if err := store.CreateIssue(ctx, issue, actor); err != nil {
@@ -710,6 +747,16 @@ var createCmd = &cobra.Command{
 		// Schedule auto-flush
 		markDirtyAndScheduleFlush()
 
+		// Issues owned by an additional repo go into that repo's JSONL now;
+		// auto-flush only writes the primary's
+		if repo := types.ExternalSourceRepo(issue); repo != "" && config.GetMultiRepoConfig() != nil {
+			if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+				if _, err := sqliteStore.ExportSourceRepo(ctx, repo); err != nil {
+					WarnError("failed to export issue to repo %s: %v", repo, err)
+				}
+			}
+		}
+
 		// Run create hook
 		if hookRunner != nil {
 			hookRunner.Run(hooks.EventCreate, issue)
@@ -724,10 +771,14 @@ var createCmd = &cobra.Command{
 			fmt.Printf("  Title: %s\n", issue.Title)
 			fmt.Printf("  Priority: P%d\n", issue.Priority)
 			fmt.Printf("  Status: %s\n", issue.Status)
+			if repo := types.ExternalSourceRepo(issue); repo != "" {
+				fmt.Printf("  Repo: %s\n", repo)
+			}
 
 			// Show tip after successful create (direct mode only)
 			maybeShowTip(store)
 		}
+		warnSimilarIssues(issue.ID, similar)
 
 		// Track as last touched issue
 		SetLastTouchedID(issue.ID)
@@ -751,7 +802,7 @@ func init() {
 	createCmd.Flags().String("waits-for", "", "Spawner issue ID to wait for (creates waits-for dependency for fanout gate)")
 	createCmd.Flags().String("waits-for-gate", "all-children", "Gate type: all-children (wait for all) or any-children (wait for first)")
 	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix")
-	createCmd.Flags().String("repo", "", "Target repository for issue (overrides auto-routing)")
+	createCmd.Flags().String("repo", "", "Target repository for issue (overrides auto-routing; in multi-repo mode, a repos.additional path or name)")
 	createCmd.Flags().String("rig", "", "Create issue in a different rig (e.g., --rig beads)")
 	createCmd.Flags().String("prefix", "", "Create issue in rig by prefix (e.g., --prefix bd- or --prefix bd or --prefix beads)")
 	createCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
@@ -592,6 +592,14 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, autoPull, local
 
 	// Set daemon configuration for status reporting
 	server.SetConfig(autoCommit, autoPush, autoPull, localMode, interval.String(), daemonMode)
+	if !localMode {
+		go runSyncHealthLoop(serverCtx, store, server, log)
+	}
+	go runScheduleLoop(serverCtx, store, beadsDir, server, log)
+	go runSLALoop(serverCtx, store, beadsDir, server, log)
+	go runLeaseLoop(serverCtx, server, log)
+	go runAgentWatchdogLoop(serverCtx, store, beadsDir, server, log)
+	go runDaemonHTTP(serverCtx, store, log)
 
 	// Register daemon in global registry
 	registry, err := daemon.NewRegistry()
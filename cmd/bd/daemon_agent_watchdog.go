@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// agentWatchdogInterval is how often the daemon checks agents for silence.
+const agentWatchdogInterval = time.Minute
+
+// runAgentWatchdogLoop marks silent agents stuck or dead until ctx is done.
+// Watchdog config is re-read each pass, so it can be turned on without a
+// restart.
+func runAgentWatchdogLoop(ctx context.Context, store storage.Storage, beadsDir string, server *rpc.Server, log daemonLogger) {
+	check := func() {
+		cfg := config.GetAgentWatchdogConfig()
+		if !cfg.Enabled() {
+			return
+		}
+		changes, err := checkAgentLiveness(ctx, store, cfg, time.Now(), agentDeadHook(beadsDir))
+		if err != nil {
+			log.Warn("agent watchdog failed", "error", err)
+			return
+		}
+		for _, c := range changes {
+			if c.Error != "" {
+				log.Warn("agent watchdog action failed", "agent", c.AgentID, "state", c.NewState, "error", c.Error)
+			} else {
+				log.Info("agent went silent", "agent", c.AgentID, "state", c.NewState, "silent_for", c.SilentFor, "released", c.Released)
+			}
+			server.NotifyMutation(rpc.MutationEvent{
+				Type:      rpc.MutationStatus,
+				IssueID:   c.AgentID,
+				Actor:     watchdogActor,
+				OldStatus: string(c.OldState),
+				NewStatus: string(c.NewState),
+			})
+			for _, id := range c.Released {
+				server.NotifyMutation(rpc.MutationEvent{
+					Type:      rpc.MutationStatus,
+					IssueID:   id,
+					Actor:     watchdogActor,
+					NewStatus: string(types.StatusOpen),
+				})
+			}
+			for _, name := range c.Locks {
+				server.NotifyMutation(rpc.MutationEvent{Type: rpc.MutationLock, IssueID: name, Actor: c.AgentID, NewStatus: "released"})
+			}
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(agentWatchdogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
@@ -85,6 +85,10 @@ func runEventDrivenLoop(
 					return
 				}
 				log.log("Mutation detected: %s %s", event.Type, event.IssueID)
+				if event.Type == rpc.MutationLock {
+					// Locks live in the database only; nothing to export
+					continue
+				}
 				exportDebouncer.Trigger()
 
 			case <-ctx.Done():
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/ical"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// runDaemonHTTP serves read-only HTTP endpoints on daemon.http-addr until
+// ctx is done. Nothing listens unless the address is set; it is read once
+// at daemon start.
+func runDaemonHTTP(ctx context.Context, store storage.Storage, log daemonLogger) {
+	addr := config.GetString("daemon.http-addr")
+	if addr == "" {
+		return
+	}
+	if err := checkDaemonHTTPAddr(addr, config.GetBool("daemon.http-allow-remote")); err != nil {
+		log.Warn("HTTP server not started", "addr", addr, "error", err)
+		return
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Warn("HTTP server not started", "addr", addr, "error", err)
+		return
+	}
+	server := &http.Server{Handler: newDaemonHTTPHandler(store), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	log.Info("HTTP server listening", "addr", listener.Addr().String())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Warn("HTTP server stopped", "error", err)
+	}
+}
+
+// checkDaemonHTTPAddr refuses listen addresses reachable from other hosts
+// unless allowRemote is set: the endpoints have no authentication. Only
+// localhost and loopback IPs count as local; an empty host listens on every
+// interface.
+func checkDaemonHTTPAddr(addr string, allowRemote bool) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid daemon.http-addr: %w", err)
+	}
+	if allowRemote || host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("%s is not a loopback address and the HTTP endpoints are unauthenticated; use 127.0.0.1 or set daemon.http-allow-remote: true", addr)
+}
+
+func newDaemonHTTPHandler(store storage.Storage) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar.ics", func(w http.ResponseWriter, r *http.Request) {
+		serveCalendar(w, r, store)
+	})
+	return mux
+}
+
+// serveCalendar renders the iCalendar feed for the filter in the query
+// string. The ETag and Last-Modified headers let polling clients skip
+// unchanged feeds.
+func serveCalendar(w http.ResponseWriter, r *http.Request, store storage.Storage) {
+	filter, err := calendarFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	issues, err := calendarIssues(r.Context(), store, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, issues, ical.Options{Name: calendarName(r.Context(), store)}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var modified time.Time
+	for _, issue := range issues {
+		if issue.UpdatedAt.After(modified) {
+			modified = issue.UpdatedAt
+		}
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+	http.ServeContent(w, r, "calendar.ics", modified, bytes.NewReader(buf.Bytes()))
+}
+
+// calendarFilter reads the feed's filters: status, assignee, type, label
+// (repeatable, all must match) and priority-max.
+func calendarFilter(q url.Values) (types.IssueFilter, error) {
+	var filter types.IssueFilter
+	for key := range q {
+		switch key {
+		case "status", "assignee", "type", "label", "priority-max":
+		default:
+			return filter, fmt.Errorf("unknown filter %q (use status, assignee, type, label, priority-max)", key)
+		}
+	}
+	if s := q.Get("status"); s != "" {
+		status := types.Status(s)
+		filter.Status = &status
+	}
+	if a := q.Get("assignee"); a != "" {
+		filter.Assignee = &a
+	}
+	if t := q.Get("type"); t != "" {
+		issueType := types.IssueType(util.NormalizeIssueType(t))
+		filter.IssueType = &issueType
+	}
+	filter.Labels = util.NormalizeLabels(q["label"])
+	if p := q.Get("priority-max"); p != "" {
+		priority, err := validation.ValidatePriority(p)
+		if err != nil {
+			return filter, fmt.Errorf("priority-max: %w", err)
+		}
+		filter.PriorityMax = &priority
+	}
+	return filter, nil
+}
+
+// calendarIssues returns the dated issues matching filter, with labels,
+// sorted by ID.
+func calendarIssues(ctx context.Context, store storage.Storage, filter types.IssueFilter) ([]*types.Issue, error) {
+	found, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	var issues []*types.Issue
+	var ids []string
+	for _, issue := range found {
+		if !issue.Ephemeral && ical.Dated(issue) {
+			issues = append(issues, issue)
+			ids = append(ids, issue.ID)
+		}
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+	}
+	slices.SortFunc(issues, func(a, b *types.Issue) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return issues, nil
+}
+
+// calendarName names a feed after the project's issue prefix.
+func calendarName(ctx context.Context, store storage.Storage) string {
+	if prefix, err := store.GetConfig(ctx, "issue_prefix"); err == nil && prefix != "" {
+		return prefix + " issues"
+	}
+	return "beads issues"
+}
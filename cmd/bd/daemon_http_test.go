@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestServeCalendar(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+
+	due := time.Now().Add(48 * time.Hour)
+	create := func(title, assignee string, dueAt *time.Time) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Assignee: assignee, Priority: 1, Status: types.StatusOpen,
+			IssueType: types.TypeTask, DueAt: dueAt}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		return issue
+	}
+	mine := create("Mine", "alice", &due)
+	theirs := create("Theirs", "bob", &due)
+	undated := create("Undated", "alice", nil)
+	if err := s.AddLabel(ctx, mine.ID, "release", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := newDaemonHTTPHandler(s)
+	get := func(target string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/calendar.ics?assignee=alice")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("GET = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "UID:"+mine.ID+"-due@beads") || !strings.Contains(body, "CATEGORIES:release") {
+		t.Errorf("feed is missing %s:\n%s", mine.ID, body)
+	}
+	for _, id := range []string{theirs.ID, undated.ID} {
+		if strings.Contains(body, id) {
+			t.Errorf("feed should not include %s:\n%s", id, body)
+		}
+	}
+
+	// Unchanged feed: clients revalidating with the ETag get 304
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+	if rec := get("/calendar.ics?assignee=alice", "If-None-Match", etag); rec.Code != http.StatusNotModified {
+		t.Errorf("revalidation = %d, want 304", rec.Code)
+	}
+
+	if rec := get("/calendar.ics?colour=red"); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown filter = %d, want 400", rec.Code)
+	}
+	if rec := get("/calendar.ics?priority-max=9"); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad priority = %d, want 400", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/calendar.ics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want 405", rec.Code)
+	}
+}
+
+func TestCheckDaemonHTTPAddr(t *testing.T) {
+	for _, tt := range []struct {
+		addr        string
+		allowRemote bool
+		wantErr     bool
+	}{
+		{"127.0.0.1:8787", false, false},
+		{"localhost:8787", false, false},
+		{"[::1]:8787", false, false},
+		{":8787", false, true},
+		{"0.0.0.0:8787", false, true},
+		{"192.168.1.10:8787", false, true},
+		{"calendar.example.com:8787", false, true},
+		{":8787", true, false},
+		{"8787", true, true},
+	} {
+		err := checkDaemonHTTPAddr(tt.addr, tt.allowRemote)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkDaemonHTTPAddr(%q, %v) = %v, want error %v", tt.addr, tt.allowRemote, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// leaseCheckInterval is how often the daemon returns work from lapsed claim
+// leases to the ready queue.
+const leaseCheckInterval = time.Minute
+
+// runLeaseLoop reclaims expired claim leases until ctx is done. The server
+// emits a mutation for each reclaimed issue, so the change gets exported.
+func runLeaseLoop(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	check := func() {
+		reclaimed, err := server.ReclaimExpiredLeases()
+		if err != nil {
+			log.Warn("lease check failed", "error", err)
+			return
+		}
+		for _, l := range reclaimed {
+			log.Info("claim lease expired, issue reopened", "issue", l.IssueID, "holder", l.Holder, "expired_at", l.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(leaseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
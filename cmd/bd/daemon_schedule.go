@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// scheduleCheckInterval is how often the daemon looks for due schedules.
+// Cron rules have minute resolution, so checking more often gains nothing.
+const scheduleCheckInterval = time.Minute
+
+// runScheduleLoop materializes due schedules from config.yaml until ctx is
+// done. New instances are reported as mutations so they get exported.
+func runScheduleLoop(ctx context.Context, store storage.Storage, beadsDir string, server *rpc.Server, log daemonLogger) {
+	configPath := filepath.Join(beadsDir, "config.yaml")
+	check := func() {
+		schedules, err := config.GetSchedulesFromYAML(configPath)
+		if err != nil {
+			log.Warn("reading schedules failed", "error", err)
+			return
+		}
+		if len(schedules) == 0 {
+			return
+		}
+		for _, run := range runDueSchedules(ctx, store, beadsDir, schedules, time.Now(), scheduleActor) {
+			switch {
+			case run.Error != "":
+				log.Warn("schedule failed", "schedule", run.ScheduleID, "error", run.Error)
+			case run.Skipped != "":
+				log.Info("schedule occurrence skipped", "schedule", run.ScheduleID, "occurrence", run.Occurrence, "reason", run.Skipped)
+			default:
+				log.Info("schedule materialized", "schedule", run.ScheduleID, "issue", run.IssueID, "created", run.Created)
+				server.NotifyMutation(rpc.MutationEvent{Type: rpc.MutationCreate, IssueID: run.IssueID})
+			}
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// slaCheckInterval is how often the daemon looks for new SLA breaches.
+const slaCheckInterval = 5 * time.Minute
+
+// runSLALoop escalates new SLA breaches until ctx is done. SLA config is
+// re-read each pass, so setting sla.pN takes effect without a restart.
+func runSLALoop(ctx context.Context, store storage.Storage, beadsDir string, server *rpc.Server, log daemonLogger) {
+	check := func() {
+		cfg := config.GetSLAConfig()
+		if len(cfg.Windows) == 0 {
+			return
+		}
+		escalations, err := escalateSLABreaches(ctx, store, &cfg, time.Now(), slaNotifier(ctx, store, beadsDir))
+		if err != nil {
+			log.Warn("SLA check failed", "error", err)
+			return
+		}
+		for _, e := range escalations {
+			if e.Error != "" {
+				log.Warn("SLA escalation failed", "issue", e.IssueID, "error", e.Error)
+			} else {
+				log.Info("SLA breach escalated", "issue", e.IssueID, "actions", e.Actions)
+			}
+			server.NotifyMutation(rpc.MutationEvent{Type: rpc.MutationUpdate, IssueID: e.IssueID})
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(slaCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
@@ -11,27 +11,29 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/daemon"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
 // DaemonStatusReport is a single daemon status entry for JSON output
 type DaemonStatusReport struct {
-	Workspace       string  `json:"workspace"`
-	PID             int     `json:"pid,omitempty"`
-	Version         string  `json:"version,omitempty"`
-	Status          string  `json:"status"`
-	Issue           string  `json:"issue,omitempty"`
-	Started         string  `json:"started,omitempty"`
-	UptimeSeconds   float64 `json:"uptime_seconds,omitempty"`
-	AutoCommit      bool    `json:"auto_commit,omitempty"`
-	AutoPush        bool    `json:"auto_push,omitempty"`
-	AutoPull        bool    `json:"auto_pull,omitempty"`
-	LocalMode       bool    `json:"local_mode,omitempty"`
-	SyncInterval    string  `json:"sync_interval,omitempty"`
-	DaemonMode      string  `json:"daemon_mode,omitempty"`
-	LogPath         string  `json:"log_path,omitempty"`
-	VersionMismatch bool    `json:"version_mismatch,omitempty"`
-	IsCurrent       bool    `json:"is_current,omitempty"`
+	Workspace       string             `json:"workspace"`
+	PID             int                `json:"pid,omitempty"`
+	Version         string             `json:"version,omitempty"`
+	Status          string             `json:"status"`
+	Issue           string             `json:"issue,omitempty"`
+	Started         string             `json:"started,omitempty"`
+	UptimeSeconds   float64            `json:"uptime_seconds,omitempty"`
+	AutoCommit      bool               `json:"auto_commit,omitempty"`
+	AutoPush        bool               `json:"auto_push,omitempty"`
+	AutoPull        bool               `json:"auto_pull,omitempty"`
+	LocalMode       bool               `json:"local_mode,omitempty"`
+	SyncInterval    string             `json:"sync_interval,omitempty"`
+	DaemonMode      string             `json:"daemon_mode,omitempty"`
+	SyncBranch      *syncbranch.Health `json:"sync_branch,omitempty"`
+	LogPath         string             `json:"log_path,omitempty"`
+	VersionMismatch bool               `json:"version_mismatch,omitempty"`
+	IsCurrent       bool               `json:"is_current,omitempty"`
 }
 
 // DaemonStatusAllResponse is returned for --all mode
@@ -224,6 +226,7 @@ func showCurrentDaemonStatus() {
 			report.LocalMode = rpcStatus.LocalMode
 			report.SyncInterval = rpcStatus.SyncInterval
 			report.DaemonMode = rpcStatus.DaemonMode
+			report.SyncBranch = rpcStatus.SyncBranch
 		}
 		outputJSON(report)
 		return
@@ -272,6 +275,9 @@ func showCurrentDaemonStatus() {
 		if rpcStatus.LocalMode {
 			fmt.Printf("  Local:      %s\n", ui.RenderWarn("yes (no git sync)"))
 		}
+		if h := rpcStatus.SyncBranch; h != nil {
+			fmt.Printf("  Branch:     %s %s\n", h.Branch, formatSyncHealthSummary(h))
+		}
 	}
 
 	if logPath != "" {
@@ -442,3 +448,29 @@ func showAllDaemonsStatus(cmd *cobra.Command) {
 		os.Exit(1)
 	}
 }
+
+// formatSyncHealthSummary renders the daemon's last sync branch diagnosis
+// as a one-line status.
+func formatSyncHealthSummary(h *syncbranch.Health) string {
+	if h.OK() {
+		return ui.RenderPass(ui.IconPass) + " healthy"
+	}
+	var problems []string
+	if !h.WorktreeHealthy {
+		problems = append(problems, "worktree broken")
+	}
+	if h.ForcePush {
+		problems = append(problems, "force-push detected")
+	}
+	if h.Diverged {
+		problems = append(problems, fmt.Sprintf("diverged (%d local, %d remote)", h.LocalAhead, h.RemoteAhead))
+	}
+	if h.MassDelete {
+		problems = append(problems, "merge would mass-delete")
+	}
+	if h.MergeError != "" {
+		problems = append(problems, "merge would fail")
+	}
+	problems = append(problems, h.Errors...)
+	return ui.RenderWarn(ui.IconWarn+" "+strings.Join(problems, ", ")) + " — run 'bd sync doctor'"
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"cmp"
 	"context"
 	"encoding/json"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -91,6 +93,16 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		issue.Comments = comments
 	}
 
+	// Populate attachment metadata for all issues
+	if err := populateAttachments(ctx, store, issues); err != nil {
+		return err
+	}
+
+	// Populate work logs for all issues
+	if err := populateWorkLogs(ctx, store, issues); err != nil {
+		return err
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -138,11 +150,26 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		return writeErr
 	}
 
+	if writeErr = shard.Export(jsonlPath); writeErr != nil {
+		writeErr = fmt.Errorf("failed to write JSONL shards: %w", writeErr)
+		return writeErr
+	}
+
+	// Carry ID length pins alongside the issues
+	exportIDNamespaceBestEffort(ctx, store, filepath.Dir(jsonlPath))
+	signExportBestEffort(jsonlPath)
+
 	return nil
 }
 
 // importToJSONLWithStore imports issues from JSONL using the provided store
 func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPath string) error {
+	// Multi-repo hydration reads other repos' JSONL without their signatures
+	verify := config.GetBool("import.verify")
+	if verify && config.GetMultiRepoConfig() != nil {
+		return fmt.Errorf("import.verify is set but multi-repo hydration cannot check signatures; import each repo with bd import -i <file> --verify")
+	}
+
 	// Try multi-repo import first
 	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
 	if ok {
@@ -157,6 +184,10 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 	}
 
 	// Single-repo mode - use existing logic
+	if err := shard.Sync(jsonlPath); err != nil {
+		return fmt.Errorf("failed to reconcile JSONL shards: %w", err)
+	}
+
 	// Read JSONL file
 	file, err := os.Open(jsonlPath) // #nosec G304 - controlled path from config
 	if err != nil {
@@ -166,6 +197,7 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 
 	// Parse all issues
 	var issues []*types.Issue
+	var rawLines [][]byte // Exact record bytes for import.verify, parallel to issues
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
 
@@ -188,12 +220,21 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		issue.SetDefaults() // Apply defaults for omitted fields
 
 		issues = append(issues, &issue)
+		if verify {
+			rawLines = append(rawLines, bytes.Clone(scanner.Bytes()))
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return fmt.Errorf("failed to read JSONL: %w", err)
 	}
 
+	if verify {
+		if issues, err = verifySignedImport(jsonlPath, issues, rawLines); err != nil {
+			return err
+		}
+	}
+
 	// Use existing import logic with auto-conflict resolution
 	opts := ImportOptions{
 		DryRun:               false,
@@ -11,6 +11,8 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/syncbranch"
 )
@@ -139,7 +141,7 @@ func getGitRoot(ctx context.Context) (string, error) {
 // gitHasChangesInWorktree checks if there are changes in the worktree
 func gitHasChangesInWorktree(ctx context.Context, worktreePath, filePath string) (bool, error) {
 	// Make filePath relative to worktree
-	relPath, err := filepath.Rel(worktreePath, filePath)
+	relPath, err := filepath.Rel(worktreePath, shard.TrackedPath(filePath))
 	if err != nil {
 		return false, fmt.Errorf("failed to make path relative: %w", err)
 	}
@@ -149,7 +151,23 @@ func gitHasChangesInWorktree(ctx context.Context, worktreePath, filePath string)
 	if err != nil {
 		return false, fmt.Errorf("git status failed in worktree: %w", err)
 	}
-	return len(strings.TrimSpace(string(output))) > 0, nil
+	if len(strings.TrimSpace(string(output))) > 0 {
+		return true, nil
+	}
+	if !shard.IsWorkingCopy(filePath) {
+		return false, nil
+	}
+	// Just migrated: the working copy is still tracked and must be removed
+	fileRel, err := filepath.Rel(worktreePath, filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to make path relative: %w", err)
+	}
+	lsCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "ls-files", fileRel) // #nosec G204 - worktreePath and fileRel are derived from trusted git operations
+	lsOutput, err := lsCmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("git ls-files failed in worktree: %w", err)
+	}
+	return len(strings.TrimSpace(string(lsOutput))) > 0, nil
 }
 
 // gitCommitInWorktree commits changes in the worktree
@@ -162,10 +180,25 @@ func gitCommitInWorktree(ctx context.Context, worktreePath, filePath, message st
 
 	// Stage the file
 	// Use --sparse to work correctly with sparse-checkout enabled worktrees (fixes #1076)
-	addCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "add", "--sparse", relPath) // #nosec G204 - worktreePath and relPath are derived from trusted git operations
+	// In the sharded layout, the shard directory is staged and the local
+	// working copy is removed from the index.
+	addPath := relPath
+	sharded := shard.IsWorkingCopy(filePath)
+	if sharded {
+		if addPath, err = filepath.Rel(worktreePath, shard.TrackedPath(filePath)); err != nil {
+			return fmt.Errorf("failed to make path relative: %w", err)
+		}
+	}
+	addCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "add", "--sparse", addPath) // #nosec G204 - worktreePath and addPath are derived from trusted git operations
 	if err := addCmd.Run(); err != nil {
 		return fmt.Errorf("git add failed in worktree: %w", err)
 	}
+	if sharded {
+		rmCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "rm", "--cached", "-q", "--ignore-unmatch", "--sparse", relPath) // #nosec G204 - worktreePath and relPath are derived from trusted git operations
+		if output, err := rmCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git rm --cached failed in worktree: %w\n%s", err, output)
+		}
+	}
 
 	// Build commit args with config-based author and signing options (GH#1051)
 	// Also use --no-verify to skip hooks (pre-commit hook would fail in worktree context)
@@ -348,3 +381,53 @@ func syncBranchPull(ctx context.Context, store storage.Storage, log daemonLogger
 	
 	return true, nil
 }
+
+// syncHealthInterval is how often the daemon re-diagnoses the sync branch
+// for 'bd daemon status'. Each check fetches from the remote.
+const syncHealthInterval = 5 * time.Minute
+
+// runSyncHealthLoop periodically diagnoses the sync branch (the same checks
+// as 'bd sync doctor') and publishes the result through the RPC server's
+// status response. It returns when ctx is cancelled.
+func runSyncHealthLoop(ctx context.Context, store storage.Storage, server *rpc.Server, log daemonLogger) {
+	check := func() {
+		if !hasGitRemote(ctx) {
+			return
+		}
+		syncBranch, err := syncbranch.Get(ctx, store)
+		if err != nil || syncBranch == "" {
+			server.SetSyncHealth(nil)
+			return
+		}
+		repoRoot, err := git.GetMainRepoRoot()
+		if err != nil {
+			return
+		}
+		jsonlPath := findJSONLPath()
+		if jsonlPath == "" {
+			return
+		}
+		health, err := syncbranch.Diagnose(ctx, store, repoRoot, syncBranch, jsonlPath)
+		if err != nil {
+			log.Warn("sync branch health check failed", "error", err)
+			return
+		}
+		if !health.OK() {
+			strategy, reason := health.Strategy()
+			log.Warn("sync branch needs attention; run 'bd sync doctor'", "strategy", strategy, "reason", reason)
+		}
+		server.SetSyncHealth(health)
+	}
+
+	check()
+	ticker := time.NewTicker(syncHealthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
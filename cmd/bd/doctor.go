@@ -306,6 +306,13 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false
 	}
 
+	// Check 3a: Hash ID collision risk across clones
+	collisionCheck := convertWithCategory(doctor.CheckIDCollisionRisk(path), doctor.CategoryCore)
+	result.Checks = append(result.Checks, collisionCheck)
+	if collisionCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
 	// Check 4: CLI version (GitHub)
 	versionCheck := convertWithCategory(doctor.CheckCLIVersion(Version), doctor.CategoryCore)
 	result.Checks = append(result.Checks, versionCheck)
@@ -575,6 +582,11 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, childParentDepsCheck)
 	// Don't fail overall check for child→parent deps, just warn
 
+	// Check 22b: Attachment blobs out of sync with attachment metadata
+	attachmentBlobsCheck := convertWithCategory(doctor.CheckAttachmentBlobs(path), doctor.CategoryData)
+	result.Checks = append(result.Checks, attachmentBlobsCheck)
+	// Don't fail overall check for blob drift, just warn
+
 	// Check 23: Duplicate issues (from bd validate)
 	duplicatesCheck := convertDoctorCheck(doctor.CheckDuplicateIssues(path, doctorGastown, gastownDuplicatesThreshold))
 	result.Checks = append(result.Checks, duplicatesCheck)
@@ -0,0 +1,164 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/configfile"
+	storagefactory "github.com/steveyegge/beads/internal/storage/factory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CheckAttachmentBlobs compares attachment metadata in the database with the
+// blobs in .beads/blobs. Missing blobs usually mean a teammate attached a file
+// but the blob hasn't been pulled yet; orphaned blobs are left behind by
+// 'bd attach rm' and can be garbage collected.
+func CheckAttachmentBlobs(path string) DoctorCheck {
+	_, beadsDir := getBackendAndBeadsDir(path)
+
+	dbPath := filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
+		dbPath = cfg.DatabasePath(beadsDir)
+	}
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:    "Attachment Blobs",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	ctx := context.Background()
+	store, err := storagefactory.NewFromConfigWithOptions(ctx, beadsDir, storagefactory.Options{ReadOnly: true})
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Attachment Blobs",
+			Status:  StatusWarning,
+			Message: "Unable to open database",
+			Detail:  err.Error(),
+		}
+	}
+	defer func() { _ = store.Close() }()
+
+	// Tombstones keep their attachments until the tombstone is pruned
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Attachment Blobs",
+			Status:  StatusWarning,
+			Message: "Unable to query issues",
+			Detail:  err.Error(),
+		}
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	attachments, err := store.GetAttachmentsForIssues(ctx, ids)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Attachment Blobs",
+			Status:  StatusWarning,
+			Message: "Unable to query attachments",
+			Detail:  err.Error(),
+		}
+	}
+	referenced := make(map[string]bool)
+	for _, list := range attachments {
+		for _, a := range list {
+			referenced[a.Hash] = true
+		}
+	}
+
+	bs := blobs.New(beadsDir)
+	missing, orphaned, err := bs.Check(referenced)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Attachment Blobs",
+			Status:  StatusWarning,
+			Message: "Unable to read blob directory",
+			Detail:  err.Error(),
+		}
+	}
+
+	missingSet := make(map[string]bool, len(missing))
+	for _, h := range missing {
+		missingSet[h] = true
+	}
+	var corrupt []string
+	for h := range referenced {
+		if missingSet[h] {
+			continue
+		}
+		if err := bs.Verify(h); err != nil {
+			corrupt = append(corrupt, h)
+		}
+	}
+	sort.Strings(corrupt)
+
+	if len(missing) == 0 && len(orphaned) == 0 && len(corrupt) == 0 {
+		if len(referenced) == 0 {
+			return DoctorCheck{
+				Name:    "Attachment Blobs",
+				Status:  StatusOK,
+				Message: "N/A (no attachments)",
+			}
+		}
+		return DoctorCheck{
+			Name:    "Attachment Blobs",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d blob(s) present and verified", len(referenced)),
+		}
+	}
+
+	var parts, details, fixes []string
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("%d missing", len(missing)))
+		details = append(details, "Missing: "+shortHashes(missing))
+		fixes = append(fixes, "Run 'bd sync' or 'git pull' to fetch missing blobs")
+	}
+	if len(corrupt) > 0 {
+		parts = append(parts, fmt.Sprintf("%d corrupt", len(corrupt)))
+		details = append(details, "Corrupt: "+shortHashes(corrupt))
+		fixes = append(fixes, "Restore corrupt blobs with 'git checkout -- .beads/blobs'")
+	}
+	if len(orphaned) > 0 {
+		parts = append(parts, fmt.Sprintf("%d orphaned", len(orphaned)))
+		details = append(details, "Orphaned: "+shortHashes(orphaned))
+		fixes = append(fixes, "Run 'bd attach gc' to delete orphaned blobs")
+	}
+
+	return DoctorCheck{
+		Name:    "Attachment Blobs",
+		Status:  StatusWarning,
+		Message: strings.Join(parts, ", ") + " blob(s)",
+		Detail:  strings.Join(details, "\n"),
+		Fix:     strings.Join(fixes, "; "),
+	}
+}
+
+// shortHashes abbreviates a list of blob hashes for display.
+func shortHashes(hashes []string) string {
+	const maxShown = 5
+	shown := make([]string, 0, maxShown)
+	for i, h := range hashes {
+		if i == maxShown {
+			break
+		}
+		if len(h) > 12 {
+			h = h[:12]
+		}
+		shown = append(shown, h)
+	}
+	out := strings.Join(shown, ", ")
+	if len(hashes) > maxShown {
+		out += fmt.Sprintf(" (+%d more)", len(hashes)-maxShown)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckAttachmentBlobs_NoDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(tmpDir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	check := CheckAttachmentBlobs(tmpDir)
+	if check.Name != "Attachment Blobs" || check.Status != StatusOK {
+		t.Errorf("got %q/%q, want Attachment Blobs/ok", check.Name, check.Status)
+	}
+}
+
+func TestCheckAttachmentBlobs_MissingAndOrphaned(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.Mkdir(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(beadsDir, beads.CanonicalDatabaseName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{
+		ID:        "bd-1",
+		Title:     "crash on startup",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeBug,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := blobs.New(beadsDir)
+	kept, size, err := bs.Put(strings.NewReader("stack trace"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := bs.Put(strings.NewReader("detached screenshot")); err != nil {
+		t.Fatal(err)
+	}
+	missing := strings.Repeat("b", 64)
+	for _, a := range []*types.Attachment{
+		{Hash: kept, Name: "trace.log", Size: size},
+		{Hash: missing, Name: "core.dump", Size: 10},
+	} {
+		if err := store.AddAttachment(ctx, "bd-1", a, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = store.Close()
+
+	check := CheckAttachmentBlobs(tmpDir)
+	if check.Status != StatusWarning {
+		t.Fatalf("Status = %q, want %q (%s)", check.Status, StatusWarning, check.Message)
+	}
+	if check.Message != "1 missing, 1 orphaned blob(s)" {
+		t.Errorf("Message = %q", check.Message)
+	}
+	if !strings.Contains(check.Detail, missing[:12]) {
+		t.Errorf("Detail = %q, want missing hash", check.Detail)
+	}
+	if !strings.Contains(check.Fix, "bd attach gc") {
+		t.Errorf("Fix = %q, want gc hint", check.Fix)
+	}
+}
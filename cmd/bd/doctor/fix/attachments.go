@@ -0,0 +1,31 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+)
+
+// AttachmentBlobs deletes orphaned attachment blobs by running 'bd attach gc'.
+// Missing and corrupt blobs can't be repaired locally and are left for the
+// user to fetch from git.
+func AttachmentBlobs(path string) error {
+	if err := validateBeadsWorkspace(path); err != nil {
+		return err
+	}
+
+	bdBinary, err := getBdBinary()
+	if err != nil {
+		return err
+	}
+
+	cmd := newBdCmd(bdBinary, "attach", "gc")
+	cmd.Dir = path
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to garbage collect blobs: %w", err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/syncbranch"
 )
 
@@ -50,6 +51,10 @@ beads.right.meta.json
 # These files are machine-specific and should not be shared across clones
 .sync.lock
 sync_base.jsonl
+shard-state.json
+
+# Recovery snapshots written by 'bd sync doctor --auto-repair'
+sync-recovery/
 
 # NOTE: Do NOT add negation patterns (e.g., !issues.jsonl) here.
 # They would override fork protection in .git/info/exclude, allowing
@@ -73,6 +78,20 @@ var requiredPatterns = []string{
 	"sync_base.jsonl",
 }
 
+// ShardedGitignoreLines returns the extra .beads/.gitignore lines needed when
+// beadsDir uses the sharded JSONL layout, where the single JSONL file is a
+// local working copy of the tracked shards. It returns nil otherwise.
+func ShardedGitignoreLines(beadsDir string) []string {
+	m, err := shard.ReadManifest(beadsDir)
+	if err != nil {
+		return nil
+	}
+	return []string{
+		"# Sharded layout: issues are tracked in " + shard.DirName + "/, this is a local working copy",
+		m.File,
+	}
+}
+
 // CheckGitignore checks if .beads/.gitignore is up to date
 func CheckGitignore() DoctorCheck {
 	gitignorePath := filepath.Join(".beads", ".gitignore")
@@ -96,6 +115,9 @@ func CheckGitignore() DoctorCheck {
 			missing = append(missing, pattern)
 		}
 	}
+	if sharded := ShardedGitignoreLines(".beads"); sharded != nil && !hasLine(contentStr, sharded[1]) {
+		missing = append(missing, sharded[1])
+	}
 
 	if len(missing) > 0 {
 		return DoctorCheck{
@@ -128,7 +150,11 @@ func FixGitignore() error {
 	}
 
 	// Write canonical template with secure file permissions
-	if err := os.WriteFile(gitignorePath, []byte(GitignoreTemplate), 0600); err != nil {
+	content := GitignoreTemplate
+	if sharded := ShardedGitignoreLines(".beads"); sharded != nil {
+		content += "\n" + strings.Join(sharded, "\n") + "\n"
+	}
+	if err := os.WriteFile(gitignorePath, []byte(content), 0600); err != nil {
 		return err
 	}
 
@@ -140,6 +166,16 @@ func FixGitignore() error {
 	return nil
 }
 
+// hasLine reports whether content contains line as a whole line.
+func hasLine(content, line string) bool {
+	for _, l := range strings.Split(content, "\n") {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
+
 // CheckIssuesTracking verifies that issues.jsonl is tracked by git.
 // This catches cases where global gitignore patterns (e.g., *.jsonl) would
 // cause issues.jsonl to be ignored, breaking bd sync.
@@ -157,6 +193,17 @@ func CheckIssuesTracking() DoctorCheck {
 		}
 	}
 
+	// In the sharded layout, issues.jsonl is an ignored working copy and the
+	// shards under .beads/issues/ are tracked instead.
+	if shard.IsWorkingCopy(issuesPath) {
+		return DoctorCheck{
+			Name:    "Issues Tracking",
+			Status:  StatusOK,
+			Message: "N/A (sharded layout)",
+			Detail:  fmt.Sprintf("Issues tracked in %s", shard.Dir(".beads")),
+		}
+	}
+
 	// In sync-branch mode, JSONL files may be intentionally ignored in working branches.
 	// They are tracked only in the dedicated sync branch.
 	if branch := syncbranch.GetFromYAML(); branch != "" {
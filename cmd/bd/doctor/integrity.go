@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,7 +19,10 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/idgen"
 	storagefactory "github.com/steveyegge/beads/internal/storage/factory"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // CheckIDFormat checks whether issues use hash-based or sequential IDs
@@ -117,6 +122,130 @@ func CheckIDFormat(path string) DoctorCheck {
 	}
 }
 
+// CheckIDCollisionRisk estimates the birthday-collision probability for each
+// hash length in use. Clones pick hash lengths from their local issue count,
+// so short IDs minted on several clones can pile up in the same small
+// namespace; collisions then only surface at import time.
+func CheckIDCollisionRisk(path string) DoctorCheck {
+	_, beadsDir := getBackendAndBeadsDir(path)
+
+	dbPath := filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
+		dbPath = cfg.DatabasePath(beadsDir)
+	}
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:    "ID Collision Risk",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	ctx := context.Background()
+	store, err := storagefactory.NewFromConfigWithOptions(ctx, beadsDir, storagefactory.Options{ReadOnly: true})
+	if err != nil {
+		return DoctorCheck{
+			Name:    "ID Collision Risk",
+			Status:  StatusWarning,
+			Message: "Unable to open database",
+			Detail:  err.Error(),
+		}
+	}
+	defer func() { _ = store.Close() }()
+
+	prefix, _ := store.GetConfig(ctx, "issue_prefix")
+	if prefix == "" {
+		return DoctorCheck{
+			Name:    "ID Collision Risk",
+			Status:  StatusOK,
+			Message: "N/A (no issue prefix configured)",
+		}
+	}
+
+	threshold := sqlite.DefaultAdaptiveConfig().MaxCollisionProbability
+	if v, _ := store.GetConfig(ctx, "max_collision_prob"); v != "" {
+		if p, err := strconv.ParseFloat(v, 64); err == nil {
+			threshold = p
+		}
+	}
+
+	// Tombstones keep their IDs, so they still occupy the namespace
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return DoctorCheck{
+			Name:    "ID Collision Risk",
+			Status:  StatusWarning,
+			Message: "Unable to query issues",
+			Detail:  err.Error(),
+		}
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	buckets := hashLengthBuckets(ids, prefix)
+
+	pinned := 0
+	if v, _ := store.GetConfig(ctx, idgen.NamespaceConfigKey(prefix)); v != "" {
+		pinned, _ = strconv.Atoi(v)
+	}
+	if filePins, err := idgen.LoadNamespace(beadsDir); err == nil && filePins[prefix] > pinned {
+		pinned = filePins[prefix]
+	}
+
+	lengths := make([]int, 0, len(buckets))
+	for length := range buckets {
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+
+	var risky []string
+	var worst float64
+	for _, length := range lengths {
+		p := idgen.CollisionProbability(buckets[length], length)
+		if p > worst {
+			worst = p
+		}
+		if p > threshold {
+			risky = append(risky, fmt.Sprintf("%d-char IDs: %d in use, %.0f%% collision probability", length, buckets[length], p*100))
+		}
+	}
+
+	if len(risky) > 0 {
+		return DoctorCheck{
+			Name:    "ID Collision Risk",
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%s- namespace is crowded (threshold %.0f%%)", prefix, threshold*100),
+			Detail:  strings.Join(risky, "\n"),
+			Fix:     "Run 'bd migrate hash-ids --lengthen' to rewrite short IDs and pin a longer length for every clone",
+		}
+	}
+
+	msg := fmt.Sprintf("%.1f%% worst-case collision probability", worst*100)
+	if pinned > 0 {
+		msg += fmt.Sprintf(", pinned to %d+ chars", pinned)
+	}
+	return DoctorCheck{
+		Name:    "ID Collision Risk",
+		Status:  StatusOK,
+		Message: msg,
+	}
+}
+
+// hashLengthBuckets counts top-level hash IDs with the given prefix by hash
+// length. Child IDs (prefix-abc.1) reuse their parent's hash and are skipped.
+func hashLengthBuckets(ids []string, prefix string) map[int]int {
+	buckets := make(map[int]int)
+	for _, id := range ids {
+		hash, ok := strings.CutPrefix(id, prefix+"-")
+		if !ok || strings.Contains(hash, ".") || !isHashID(id) {
+			continue
+		}
+		buckets[len(hash)]++
+	}
+	return buckets
+}
+
 // CheckDependencyCycles checks for circular dependencies in the issue graph
 func CheckDependencyCycles(path string) DoctorCheck {
 	// Follow redirect to resolve actual beads directory (bd-tvus fix)
@@ -144,7 +273,8 @@ func CheckDependencyCycles(path string) DoctorCheck {
 	}
 	defer db.Close()
 
-	// Query for cycles using simplified SQL
+	// Query for cycles using simplified SQL. relates-to and caused-by edges
+	// are not cycle-checked (see types.DependencyType.IsCycleChecked)
 	query := `
 		WITH RECURSIVE paths AS (
 			SELECT
@@ -154,6 +284,7 @@ func CheckDependencyCycles(path string) DoctorCheck {
 				issue_id || '→' || depends_on_id as path,
 				0 as depth
 			FROM dependencies
+			WHERE type NOT IN ('relates-to', 'caused-by')
 
 			UNION ALL
 
@@ -166,6 +297,7 @@ func CheckDependencyCycles(path string) DoctorCheck {
 			FROM dependencies d
 			JOIN paths p ON d.issue_id = p.depends_on_id
 			WHERE p.depth < 100
+			  AND d.type NOT IN ('relates-to', 'caused-by')
 			  AND p.path NOT LIKE '%' || d.depends_on_id || '→%'
 		)
 		SELECT DISTINCT start_id
@@ -1,9 +1,18 @@
 package doctor
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // TestIntegrityChecks_NoBeadsDir verifies all integrity check functions handle
@@ -15,6 +24,7 @@ func TestIntegrityChecks_NoBeadsDir(t *testing.T) {
 		wantName string
 	}{
 		{"IDFormat", CheckIDFormat, "Issue IDs"},
+		{"IDCollisionRisk", CheckIDCollisionRisk, "ID Collision Risk"},
 		{"DependencyCycles", CheckDependencyCycles, "Dependency Cycles"},
 		{"Tombstones", CheckTombstones, "Tombstones"},
 		{"DeletionsManifest", CheckDeletionsManifest, "Deletions Manifest"},
@@ -40,6 +50,7 @@ func TestIntegrityChecks_EmptyBeadsDir(t *testing.T) {
 		fn   func(string) DoctorCheck
 	}{
 		{"IDFormat", CheckIDFormat},
+		{"IDCollisionRisk", CheckIDCollisionRisk},
 		{"DependencyCycles", CheckDependencyCycles},
 		{"Tombstones", CheckTombstones},
 		{"DeletionsManifest", CheckDeletionsManifest},
@@ -84,3 +95,63 @@ func TestCheckDeletionsManifest_LegacyFile(t *testing.T) {
 		t.Errorf("Status = %q, want %q", check.Status, StatusWarning)
 	}
 }
+
+func TestHashLengthBuckets(t *testing.T) {
+	ids := []string{
+		"bd-a1b", "bd-c2d", "bd-a1b.1", // child shares its parent's hash
+		"bd-e3f4g5", "bd-12", // sequential IDs are not hashes
+		"other-x9z", // different prefix
+	}
+	got := hashLengthBuckets(ids, "bd")
+	if len(got) != 2 || got[3] != 2 || got[6] != 1 {
+		t.Errorf("hashLengthBuckets = %v, want map[3:2 6:1]", got)
+	}
+}
+
+func TestCheckIDCollisionRisk_CrowdedNamespace(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.Mkdir(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(beadsDir, beads.CanonicalDatabaseName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	// 200 three-char IDs put the 46K namespace well past the 25% threshold
+	seen := make(map[string]bool)
+	for i := 0; len(seen) < 200; i++ {
+		id := idgen.GenerateHashID("bd", fmt.Sprintf("issue %d", i), "", "test", time.Unix(0, 0), 3, 0)
+		if seen[id] || !isHashID(id) {
+			continue
+		}
+		seen[id] = true
+		issue := &types.Issue{
+			ID:        id,
+			Title:     fmt.Sprintf("issue %d", i),
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = store.Close()
+
+	check := CheckIDCollisionRisk(tmpDir)
+	if check.Status != StatusWarning {
+		t.Fatalf("Status = %q, want %q (%s)", check.Status, StatusWarning, check.Message)
+	}
+	if !strings.Contains(check.Detail, "3-char IDs: 200 in use") {
+		t.Errorf("Detail = %q, want 3-char bucket", check.Detail)
+	}
+	if !strings.Contains(check.Fix, "--lengthen") {
+		t.Errorf("Fix = %q, want lengthen hint", check.Fix)
+	}
+}
@@ -157,6 +157,7 @@ func CheckLegacyJSONLFilename(repoPath string, gastownMode bool) DoctorCheck {
 			name == "deletions.jsonl" ||
 			name == "interactions.jsonl" ||
 			name == "molecules.jsonl" ||
+			name == "namespace.jsonl" ||
 			name == "sync_base.jsonl" ||
 			// Git merge conflict artifacts (e.g., issues.base.jsonl, issues.left.jsonl)
 			strings.Contains(lowerName, ".base.jsonl") ||
@@ -306,7 +307,7 @@ func CheckDatabaseConfig(repoPath string) DoctorCheck {
 
 	// Check if configured JSONL exists
 	if cfg.JSONLExport != "" {
-		if cfg.JSONLExport == "deletions.jsonl" || cfg.JSONLExport == "interactions.jsonl" || cfg.JSONLExport == "molecules.jsonl" {
+		if cfg.JSONLExport == "deletions.jsonl" || cfg.JSONLExport == "interactions.jsonl" || cfg.JSONLExport == "molecules.jsonl" || cfg.JSONLExport == "namespace.jsonl" {
 			return DoctorCheck{
 				Name:    "Database Config",
 				Status:  "error",
@@ -334,6 +335,7 @@ func CheckDatabaseConfig(repoPath string) DoctorCheck {
 						name != "deletions.jsonl" &&
 						name != "interactions.jsonl" &&
 						name != "molecules.jsonl" &&
+						name != "namespace.jsonl" &&
 						!strings.Contains(lowerName, ".base.jsonl") &&
 						!strings.Contains(lowerName, ".left.jsonl") &&
 						!strings.Contains(lowerName, ".right.jsonl") {
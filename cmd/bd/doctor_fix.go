@@ -277,6 +277,9 @@ func applyFixList(path string, fixes []doctorCheck) {
 			err = fix.MergeArtifacts(path)
 		case "Orphaned Dependencies":
 			err = fix.OrphanedDependencies(path, doctorVerbose)
+		case "Attachment Blobs":
+			// Only orphaned blobs can be fixed locally; missing ones must be pulled
+			err = fix.AttachmentBlobs(path)
 		case "Child-Parent Dependencies":
 			// Requires explicit opt-in flag (destructive, may remove intentional deps)
 			if !doctorFixChildParent {
@@ -18,13 +18,29 @@ The merge target is chosen by:
 1. Reference count (most referenced issue wins)
 2. Lexicographically smallest ID if reference counts are equal
 Only groups issues with matching status (open with open, closed with closed).
+
+With --fuzzy, open issues are compared by TF-IDF similarity of their title
+and description instead, so reworded reports of the same problem are found.
+Each group suggests a canonical issue and the bd duplicate commands to close
+the rest; nothing is merged automatically. Scoring runs locally.
 Example:
   bd duplicates                    # Show all duplicate groups
+  bd duplicates --fuzzy            # Show groups of similar issues
+  bd duplicates --fuzzy --threshold 0.8 --json
   bd duplicates --auto-merge       # Automatically merge all duplicates
   bd duplicates --dry-run          # Show what would be merged`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		autoMerge, _ := cmd.Flags().GetBool("auto-merge")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		fuzzy, _ := cmd.Flags().GetBool("fuzzy")
+		thresholdFlag, _ := cmd.Flags().GetFloat64("threshold")
+		threshold, err := duplicatesThreshold(thresholdFlag, cmd.Flags().Changed("threshold"))
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if fuzzy && autoMerge {
+			FatalErrorRespectJSON("--auto-merge cannot be used with --fuzzy; review the groups and run the suggested bd duplicate commands")
+		}
 		// Block writes in readonly mode (merging modifies data)
 		if autoMerge && !dryRun {
 			CheckReadonly("duplicates --auto-merge")
@@ -60,6 +76,11 @@ Example:
 			openIssues = append(openIssues, issue)
 		}
 	}
+	// Similarity-based detection reports near-duplicates for review
+	if fuzzy {
+		runFuzzyDuplicates(openIssues, allIssues, threshold)
+		return
+	}
 	// Find duplicates (only among open issues)
 	duplicateGroups := findDuplicateGroups(openIssues)
 		if len(duplicateGroups) == 0 {
@@ -164,6 +185,8 @@ Example:
 func init() {
 	duplicatesCmd.Flags().Bool("auto-merge", false, "Automatically merge all duplicates")
 	duplicatesCmd.Flags().Bool("dry-run", false, "Show what would be merged without making changes")
+	duplicatesCmd.Flags().Bool("fuzzy", false, "Find similar (not just identical) issues by title and description")
+	duplicatesCmd.Flags().Float64("threshold", 0, "Minimum similarity for --fuzzy, 0-1 (default: duplicates.threshold, 0.6)")
 	rootCmd.AddCommand(duplicatesCmd)
 }
 // contentKey represents the fields we use to identify duplicate issues
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/similarity"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// maxSimilarOnCreate caps how many look-alikes bd create warns about
+const maxSimilarOnCreate = 3
+
+// fuzzyDuplicateGroup is a cluster of open issues with similar text
+type fuzzyDuplicateGroup struct {
+	Issues    []*types.Issue
+	Pairs     []similarity.Pair
+	Canonical *types.Issue
+}
+
+// duplicatesThreshold returns --threshold if set, else duplicates.threshold.
+func duplicatesThreshold(flagValue float64, flagSet bool) (float64, error) {
+	threshold := flagValue
+	if !flagSet {
+		threshold = config.GetFloat64("duplicates.threshold")
+	}
+	if threshold <= 0 || threshold > 1 {
+		return 0, fmt.Errorf("similarity threshold must be in (0, 1], got %v", threshold)
+	}
+	return threshold, nil
+}
+
+// issueDocument is the text similarity compares: title and description.
+func issueDocument(issue *types.Issue) similarity.Document {
+	return similarity.Document{ID: issue.ID, Title: issue.Title, Body: issue.Description}
+}
+
+// findFuzzyDuplicateGroups clusters issues whose text scores at least
+// threshold against another issue in the cluster. Canonicals are chosen the
+// same way as for exact duplicates.
+func findFuzzyDuplicateGroups(issues []*types.Issue, threshold float64, refCounts map[string]int, structuralScores func([][]*types.Issue) map[string]*issueScore) []*fuzzyDuplicateGroup {
+	byID := make(map[string]*types.Issue, len(issues))
+	docs := make([]similarity.Document, 0, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		docs = append(docs, issueDocument(issue))
+	}
+	pairs := similarity.NewIndex(docs).Pairs(threshold)
+	clusters := similarity.Clusters(pairs)
+
+	var groups []*fuzzyDuplicateGroup
+	var issueGroups [][]*types.Issue
+	for _, cluster := range clusters {
+		group := &fuzzyDuplicateGroup{}
+		members := make(map[string]bool, len(cluster))
+		for _, id := range cluster {
+			group.Issues = append(group.Issues, byID[id])
+			members[id] = true
+		}
+		for _, p := range pairs {
+			if members[p.A] {
+				group.Pairs = append(group.Pairs, p)
+			}
+		}
+		groups = append(groups, group)
+		issueGroups = append(issueGroups, group.Issues)
+	}
+
+	scores := map[string]*issueScore{}
+	if structuralScores != nil && len(issueGroups) > 0 {
+		scores = structuralScores(issueGroups)
+	}
+	for _, g := range groups {
+		g.Canonical = chooseMergeTarget(g.Issues, refCounts, scores)
+	}
+	return groups
+}
+
+// runFuzzyDuplicates reports clusters of similar open issues and the
+// bd duplicate commands that would fold them into their canonical issue.
+func runFuzzyDuplicates(openIssues, allIssues []*types.Issue, threshold float64) {
+	groups := findFuzzyDuplicateGroups(openIssues, threshold, countReferences(allIssues), countStructuralRelationships)
+
+	if jsonOutput {
+		out := make([]map[string]interface{}, 0, len(groups))
+		for _, g := range groups {
+			issues := make([]map[string]interface{}, len(g.Issues))
+			var commands []string
+			for i, issue := range g.Issues {
+				issues[i] = map[string]interface{}{
+					"id":           issue.ID,
+					"title":        issue.Title,
+					"status":       issue.Status,
+					"priority":     issue.Priority,
+					"is_canonical": issue.ID == g.Canonical.ID,
+				}
+				if issue.ID != g.Canonical.ID {
+					commands = append(commands, fmt.Sprintf("bd duplicate %s --of %s", issue.ID, g.Canonical.ID))
+				}
+			}
+			out = append(out, map[string]interface{}{
+				"canonical":          g.Canonical.ID,
+				"issues":             issues,
+				"pairs":              g.Pairs,
+				"suggested_commands": commands,
+			})
+		}
+		outputJSON(map[string]interface{}{
+			"fuzzy":            true,
+			"threshold":        threshold,
+			"duplicate_groups": len(groups),
+			"groups":           out,
+		})
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("No similar issues found (threshold %.2f)\n", threshold)
+		return
+	}
+	fmt.Printf("%s Found %d group(s) of similar issues (threshold %.2f):\n\n", ui.RenderWarn("🔍"), len(groups), threshold)
+	for i, g := range groups {
+		fmt.Printf("%s Group %d: %s\n", ui.RenderAccent("━━"), i+1, g.Canonical.Title)
+		for _, issue := range g.Issues {
+			marker := "  "
+			detail := ""
+			if issue.ID == g.Canonical.ID {
+				marker = ui.RenderPass("→ ")
+			} else {
+				detail = fmt.Sprintf(", %.0f%% similar", 100*bestPairScore(g.Pairs, issue.ID, g.Canonical.ID))
+			}
+			fmt.Printf("%s%s (%s, P%d%s) %s\n", marker, issue.ID, issue.Status, issue.Priority, detail, issue.Title)
+		}
+		for _, issue := range g.Issues {
+			if issue.ID != g.Canonical.ID {
+				fmt.Printf("  %s bd duplicate %s --of %s\n", ui.RenderAccent("Suggested:"), issue.ID, g.Canonical.ID)
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%s Review each group; similar text is not always the same work\n", ui.RenderAccent("💡"))
+}
+
+// bestPairScore returns the score linking id to the canonical, or failing
+// that, id's best score within the group.
+func bestPairScore(pairs []similarity.Pair, id, canonicalID string) float64 {
+	best := 0.0
+	for _, p := range pairs {
+		if p.A != id && p.B != id {
+			continue
+		}
+		if p.A == canonicalID || p.B == canonicalID {
+			return p.Score
+		}
+		if p.Score > best {
+			best = p.Score
+		}
+	}
+	return best
+}
+
+// findSimilarOpenIssues returns open issues whose text resembles a new
+// issue's title and description. Failures only cost the warning.
+func findSimilarOpenIssues(ctx context.Context, title, description string) []similarity.Match {
+	if !config.GetBool("create.similarity-check") {
+		return nil
+	}
+	threshold, err := duplicatesThreshold(0, false)
+	if err != nil {
+		debug.Logf("similarity check skipped: %v", err)
+		return nil
+	}
+
+	var candidates []*types.Issue
+	if daemonClient != nil {
+		resp, err := daemonClient.List(&rpc.ListArgs{ExcludeStatus: []string{string(types.StatusClosed)}})
+		if err == nil {
+			err = json.Unmarshal(resp.Data, &candidates)
+		}
+		if err != nil {
+			debug.Logf("similarity check skipped: %v", err)
+			return nil
+		}
+	} else if store != nil {
+		candidates, err = store.SearchIssues(ctx, "", types.IssueFilter{
+			ExcludeStatus: []types.Status{types.StatusClosed},
+		})
+		if err != nil {
+			debug.Logf("similarity check skipped: %v", err)
+			return nil
+		}
+	}
+
+	docs := make([]similarity.Document, 0, len(candidates))
+	for _, issue := range candidates {
+		if issue.Ephemeral || issue.IsTemplate || issue.Status == types.StatusTombstone {
+			continue
+		}
+		docs = append(docs, issueDocument(issue))
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	return similarity.NewIndex(docs).Similar(title, description, threshold, maxSimilarOnCreate)
+}
+
+// warnSimilarIssues tells the creator of newID which open issues it resembles.
+func warnSimilarIssues(newID string, matches []similarity.Match) {
+	var others []similarity.Match
+	for _, m := range matches {
+		if m.ID != newID {
+			others = append(others, m)
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+	sort.SliceStable(others, func(i, j int) bool { return others[i].Score > others[j].Score })
+	for _, m := range others {
+		fmt.Fprintf(os.Stderr, "%s %s looks similar to %s (%.0f%% match)\n", ui.RenderWarn("⚠"), newID, m.ID, 100*m.Score)
+	}
+	fmt.Fprintf(os.Stderr, "  If it is the same work: bd duplicate %s --of %s\n", newID, others[0].ID)
+}
@@ -434,3 +434,45 @@ func TestPerformMerge(t *testing.T) {
 		}
 	}
 }
+
+func TestFindFuzzyDuplicateGroups(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Login fails with expired session token", Description: "Users get a 500 after the session token expires", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Expired session token breaks login", Description: "After the session token expires login returns 500", Status: types.StatusOpen},
+		{ID: "bd-3", Title: "Add dark mode to the settings page", Status: types.StatusOpen},
+		{ID: "bd-4", Title: "Upgrade CI runners to new images", Status: types.StatusOpen},
+	}
+	// bd-2 is referenced from another issue, so it becomes the canonical
+	refCounts := map[string]int{"bd-2": 1}
+
+	groups := findFuzzyDuplicateGroups(issues, 0.6, refCounts, nil)
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %+v", len(groups), groups)
+	}
+	g := groups[0]
+	if len(g.Issues) != 2 || g.Issues[0].ID != "bd-1" || g.Issues[1].ID != "bd-2" {
+		t.Errorf("group issues = %v, want bd-1 and bd-2", g.Issues)
+	}
+	if g.Canonical.ID != "bd-2" {
+		t.Errorf("canonical = %s, want bd-2 (most referenced)", g.Canonical.ID)
+	}
+	if score := bestPairScore(g.Pairs, "bd-1", "bd-2"); score < 0.6 || score > 1 {
+		t.Errorf("pair score = %v", score)
+	}
+
+	if groups := findFuzzyDuplicateGroups(issues, 0.95, refCounts, nil); len(groups) != 0 {
+		t.Errorf("threshold 0.95 grouped %+v", groups)
+	}
+}
+
+func TestDuplicatesThreshold(t *testing.T) {
+	if got, err := duplicatesThreshold(0.8, true); err != nil || got != 0.8 {
+		t.Errorf("duplicatesThreshold(0.8, set) = %v, %v", got, err)
+	}
+	if _, err := duplicatesThreshold(1.5, true); err == nil {
+		t.Error("expected error for threshold above 1")
+	}
+	if _, err := duplicatesThreshold(0, true); err == nil {
+		t.Error("expected error for zero threshold")
+	}
+}
@@ -12,7 +12,10 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/codec"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/ical"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
@@ -114,9 +117,9 @@ func validateExportPath(path string) error {
 var exportCmd = &cobra.Command{
 	Use:     "export",
 	GroupID: "sync",
-	Short:   "Export issues to JSONL or Obsidian format",
-	Long: `Export all issues to JSON Lines or Obsidian Tasks markdown format.
-Issues are sorted by ID for consistent diffs.
+	Short:   "Export issues to JSONL, Obsidian, CSV, markdown, Org or iCalendar format",
+	Long: `Export all issues to JSON Lines, Obsidian Tasks markdown, or an editable
+CSV, markdown or Org-mode file. Issues are sorted by ID for consistent diffs.
 
 Output to stdout by default, or use -o flag for file output.
 For obsidian format, defaults to ai_docs/changes-log.md
@@ -124,11 +127,27 @@ For obsidian format, defaults to ai_docs/changes-log.md
 Formats:
   jsonl     - JSON Lines format (one JSON object per line) [default]
   obsidian  - Obsidian Tasks markdown format with checkboxes, priorities, dates
+  csv       - One row per issue, for spreadsheets
+  markdown  - "## Title" per issue with a "### Section" per field
+  org       - Org-mode headings with TODO keywords, tags and properties
+  ics       - iCalendar feed of due dates (VTODO) and deferrals (VEVENT)
+
+The csv, markdown and org formats carry labels and dependencies and can be
+edited and read back with bd import --format; see bd import --help. They
+leave out deleted issues. Rename columns or headings with --map or the
+codec.<format> config key, e.g. --map title=Summary.
+
+The ics format only includes issues with a due date or, unless closed, a
+defer date. UIDs are derived from issue IDs, so calendar clients update
+entries in place instead of duplicating them. For a live feed, set
+daemon.http-addr and subscribe to the daemon's /calendar.ics.
 
 Examples:
   bd export --status open -o open-issues.jsonl
   bd export --format obsidian                    # outputs to ai_docs/changes-log.md
   bd export --format obsidian -o custom.md       # outputs to custom.md
+  bd export --format csv --status open -o backlog.csv
+  bd export --format ics --assignee alice -o alice.ics
   bd export --type bug --priority-max 1
   bd export --created-after 2025-01-01 --assignee alice`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -152,9 +171,19 @@ Examples:
 
 		debug.Logf("Debug: export flags - output=%q, force=%v\n", output, force)
 
-		if format != "jsonl" && format != "obsidian" {
-			fmt.Fprintf(os.Stderr, "Error: format must be 'jsonl' or 'obsidian'\n")
-			os.Exit(1)
+		// csv, markdown, org and ics are formats for people, not sync
+		// targets: they skip the JSONL safety checks and bookkeeping below.
+		syncFormat := format == "jsonl" || format == "obsidian"
+		var issueCodec codec.Codec
+		if !syncFormat && format != "ics" {
+			mapping, err := codecMapping(cmd, format)
+			if err == nil {
+				issueCodec, err = codec.New(format, mapping)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		// Default output path for obsidian format
@@ -206,7 +235,7 @@ Examples:
 			filter.IncludeTombstones = (status == types.StatusTombstone)
 		} else {
 			// No status filter: include tombstones for sync propagation
-			filter.IncludeTombstones = true
+			filter.IncludeTombstones = syncFormat
 		}
 		if assignee != "" {
 			filter.Assignee = &assignee
@@ -294,7 +323,7 @@ Examples:
 		}
 
 		// Safety check: prevent exporting empty database over non-empty JSONL
-		if len(issues) == 0 && output != "" && !force {
+		if len(issues) == 0 && output != "" && !force && syncFormat {
 			existingCount, err := countIssuesInJSONL(output)
 			if err != nil {
 				// If we can't read the file, it might not exist yet, which is fine
@@ -313,7 +342,7 @@ Examples:
 		}
 
 		// Safety check: prevent exporting stale database that would lose issues
-		if output != "" && !force {
+		if output != "" && !force && syncFormat {
 			debug.Logf("Debug: checking staleness - output=%s, force=%v\n", output, force)
 
 			// Read existing JSONL to get issue IDs
@@ -406,6 +435,18 @@ Examples:
 			issue.Labels = labels
 		}
 
+		// Populate attachment metadata (blob content stays in .beads/blobs)
+		if err := populateAttachments(ctx, store, issues); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Populate work logs
+		if err := populateWorkLogs(ctx, store, issues); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Open output
 		out := os.Stdout
 		var tempFile *os.File
@@ -452,7 +493,29 @@ Examples:
 		exportedIDs := make([]string, 0, len(issues))
 		skippedCount := 0
 
-		if format == "obsidian" {
+		if format == "ics" {
+			var dated []*types.Issue
+			for _, issue := range issues {
+				if ical.Dated(issue) {
+					dated = append(dated, issue)
+				}
+			}
+			if err := ical.Write(out, dated, ical.Options{Name: calendarName(ctx, store)}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing iCalendar export: %v\n", err)
+				os.Exit(1)
+			}
+			for _, issue := range dated {
+				exportedIDs = append(exportedIDs, issue.ID)
+			}
+		} else if issueCodec != nil {
+			if err := issueCodec.Encode(out, issues); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing %s export: %v\n", format, err)
+				os.Exit(1)
+			}
+			for _, issue := range issues {
+				exportedIDs = append(exportedIDs, issue.ID)
+			}
+		} else if format == "obsidian" {
 			// Write Obsidian Tasks markdown format
 			if err := writeObsidianExport(out, issues); err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing Obsidian export: %v\n", err)
@@ -480,7 +543,7 @@ Examples:
 
 		// Only clear dirty issues and auto-flush state if exporting to the default JSONL path
 		// This prevents clearing dirty flags when exporting to custom paths (e.g., bd export -o backup.jsonl)
-		if output == "" || output == findJSONLPath() {
+		if (output == "" || output == findJSONLPath()) && syncFormat {
 			// Clear only the issues that were actually exported (fixes bd-52 race condition)
 			if err := store.ClearDirtyIssuesByID(ctx, exportedIDs); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to clear dirty issues: %v\n", err)
@@ -546,7 +609,14 @@ Examples:
 			// Update database mtime to be >= JSONL mtime (fixes #278, #301, #321)
 			// Only do this when exporting to default JSONL path (not arbitrary outputs)
 			// This prevents validatePreExport from incorrectly blocking on next export
-			if output == "" || output == findJSONLPath() {
+			if (output == "" || output == findJSONLPath()) && syncFormat {
+				if format == "jsonl" {
+					if err := shard.Export(finalPath); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(1)
+					}
+					signExportBestEffort(finalPath)
+				}
 				// Dolt backend does not have a SQLite DB file, so only touch mtime for SQLite.
 				if _, ok := store.(*sqlite.SQLiteStorage); ok {
 					beadsDir := filepath.Dir(finalPath)
@@ -577,7 +647,8 @@ Examples:
 }
 
 func init() {
-	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format: jsonl, obsidian")
+	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format: jsonl, obsidian, csv, markdown, org, ics")
+	exportCmd.Flags().StringArray("map", nil, "Rename a csv column or markdown/org heading: field=Name (repeatable)")
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	exportCmd.Flags().StringP("status", "s", "", "Filter by status")
 	exportCmd.Flags().Bool("force", false, "Force export even if database is empty")
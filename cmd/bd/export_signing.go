@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/signing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// exportSigningKey returns the key configured for signing exports from
+// beadsDir, or nil if signing is not configured.
+func exportSigningKey(beadsDir string) (*signing.Key, error) {
+	path := config.GetString("signing.key")
+	if path == "" {
+		return nil, nil
+	}
+	path, err := expandUserPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := &signing.Key{
+		Format:   config.GetString("signing.format"),
+		Path:     path,
+		Identity: config.GetString("signing.identity"),
+	}
+	if key.Identity == "" {
+		// Same default as git: sign as the committer
+		// #nosec G204 - fixed git arguments
+		out, err := exec.Command("git", "-C", beadsDir, "config", "--get", "user.email").Output()
+		if err == nil {
+			key.Identity = strings.TrimSpace(string(out))
+		}
+	}
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// signExportBestEffort signs jsonlPath with the configured key, if any.
+// Signing never fails an export: a missing agent or key only costs the
+// signature, and imports that require one will say so.
+func signExportBestEffort(jsonlPath string) {
+	key, err := exportSigningKey(filepath.Dir(jsonlPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: export not signed: %v\n", err)
+		return
+	}
+	if key == nil {
+		return
+	}
+	changed, err := signing.Sign(jsonlPath, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: export not signed: %v\n", err)
+		return
+	}
+	if changed {
+		debug.Logf("signed %s as %s", filepath.Base(jsonlPath), key.Identity)
+	}
+}
+
+// allowedSignersPath returns the trust file used to verify imports into
+// beadsDir, from signing.allowed_signers. There is no default: a trust file
+// inside the repository can be edited by the forks it is meant to guard
+// against, so paths inside the repository are refused too.
+func allowedSignersPath(beadsDir string) (string, error) {
+	path := config.GetString("signing.allowed_signers")
+	if path == "" {
+		return "", fmt.Errorf("signing.allowed_signers is not set (point it at a trust file outside the repository)")
+	}
+	path, err := expandUserPath(path)
+	if err != nil {
+		return "", err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return "", err
+	}
+	repoRoot, err := gitOutputIn(beadsDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		// Not a git repository: the workspace is the parent of .beads
+		repoRoot = filepath.Dir(beadsDir)
+	}
+	if pathWithin(resolveSymlinks(repoRoot), resolveSymlinks(path)) {
+		return "", fmt.Errorf("signing.allowed_signers (%s) is inside the repository, where forks can edit it; keep it outside %s", path, repoRoot)
+	}
+	return path, nil
+}
+
+// resolveSymlinks resolves symlinks in path, or in its directory if path
+// itself does not exist yet.
+func resolveSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+	return path
+}
+
+// pathWithin reports whether path is root or inside it.
+func pathWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator)))
+}
+
+// expandUserPath expands a leading ~/ to the home directory.
+func expandUserPath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// rejectedRecord is an imported record that failed signature verification.
+type rejectedRecord struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// verifyImportRecords checks each issue's raw JSONL line (lines[i] belongs to
+// issues[i]) against the signatures next to jsonlPath and returns the issues
+// that a trusted signer vouches for. Unsigned and tampered records are
+// returned as rejected; manifests that were ignored are returned as problems.
+func verifyImportRecords(jsonlPath, allowedPath string, issues []*types.Issue, lines [][]byte) (kept []*types.Issue, rejected []rejectedRecord, problems []string, err error) {
+	allowed, err := signing.ReadAllowedSigners(allowedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil, fmt.Errorf("no allowed signers file at %s (set signing.allowed_signers)", allowedPath)
+		}
+		return nil, nil, nil, fmt.Errorf("failed to read allowed signers: %w", err)
+	}
+	if allowed.Len() == 0 {
+		return nil, nil, nil, fmt.Errorf("allowed signers file %s lists no keys", allowedPath)
+	}
+	verifier, err := signing.NewVerifier(jsonlPath, allowed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for i, issue := range issues {
+		if _, err := verifier.Check(issue.ID, lines[i]); err != nil {
+			rejected = append(rejected, rejectedRecord{ID: issue.ID, Reason: err.Error()})
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, rejected, verifier.Problems, nil
+}
+
+// verifySignedImport drops the records of jsonlPath that no trusted key
+// signed and reports them on stderr. lines[i] is the raw JSONL line of
+// issues[i]. Every import of a JSONL file runs this when import.verify is
+// set, not just bd import: auto-import, post-pull and git hook imports are
+// exactly where records from a fork arrive.
+func verifySignedImport(jsonlPath string, issues []*types.Issue, lines [][]byte) ([]*types.Issue, error) {
+	allowedPath, err := allowedSignersPath(filepath.Dir(jsonlPath))
+	if err != nil {
+		return nil, err
+	}
+	kept, rejected, problems, err := verifyImportRecords(jsonlPath, allowedPath, issues, lines)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify %s: %w", jsonlPath, err)
+	}
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring signature %s\n", p)
+	}
+	if len(rejected) > 0 {
+		fmt.Fprintf(os.Stderr, "Rejected %d record(s) without a valid signature from %s:\n", len(rejected), allowedPath)
+		for _, r := range rejected {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", r.ID, r.Reason)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Verified %d of %d record(s)\n", len(kept), len(issues))
+	return kept, nil
+}
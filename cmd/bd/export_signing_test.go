@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/signing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSignedExportVerifyImport(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	if err := config.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(dir, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	pub, err := os.ReadFile(keyPath + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Set("signing.key", keyPath)
+	config.Set("signing.identity", "maintainer@example.com")
+	t.Cleanup(func() {
+		config.Set("signing.key", "")
+		config.Set("signing.identity", "")
+	})
+
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	line1 := `{"id":"test-1","title":"Signed"}`
+	line2 := `{"id":"test-2","title":"Also signed"}`
+	if err := os.WriteFile(jsonlPath, []byte(line1+"\n"+line2+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	signExportBestEffort(jsonlPath)
+	if _, err := os.Stat(signing.ManifestPath(jsonlPath, "maintainer@example.com")); err != nil {
+		t.Fatalf("export was not signed: %v", err)
+	}
+
+	// The trust file must be configured and live outside the repository
+	if _, err := allowedSignersPath(beadsDir); err == nil {
+		t.Error("expected error without signing.allowed_signers")
+	}
+	config.Set("signing.allowed_signers", filepath.Join(beadsDir, "allowed_signers"))
+	t.Cleanup(func() { config.Set("signing.allowed_signers", "") })
+	if _, err := allowedSignersPath(beadsDir); err == nil || !strings.Contains(err.Error(), "inside the repository") {
+		t.Errorf("allowedSignersPath() inside the repository: err = %v", err)
+	}
+	trustDir := t.TempDir()
+	config.Set("signing.allowed_signers", filepath.Join(trustDir, "allowed_signers"))
+	allowedPath, err := allowedSignersPath(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolveSymlinks(allowedPath) != resolveSymlinks(filepath.Join(trustDir, "allowed_signers")) {
+		t.Errorf("allowedSignersPath() = %s", allowedPath)
+	}
+	if _, _, _, err := verifyImportRecords(jsonlPath, allowedPath, nil, nil); err == nil {
+		t.Error("expected error without an allowed signers file")
+	}
+	if err := os.WriteFile(allowedPath, []byte("maintainer@example.com "+string(pub)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fork edits one record and adds another without re-signing.
+	tampered := `{"id":"test-2","title":"Edited in a fork"}`
+	added := `{"id":"test-3","title":"Added in a fork"}`
+	issues := []*types.Issue{{ID: "test-1"}, {ID: "test-2"}, {ID: "test-3"}}
+	lines := [][]byte{[]byte(line1), []byte(tampered), []byte(added)}
+	kept, rejected, problems, err := verifyImportRecords(jsonlPath, allowedPath, issues, lines)
+	if err != nil {
+		t.Fatalf("verifyImportRecords() error = %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	if len(kept) != 1 || kept[0].ID != "test-1" {
+		t.Errorf("kept = %v, want only test-1", kept)
+	}
+	if len(rejected) != 2 || rejected[0].ID != "test-2" || !strings.Contains(rejected[0].Reason, "signed hash") ||
+		rejected[1].ID != "test-3" || !strings.Contains(rejected[1].Reason, "not signed") {
+		t.Errorf("rejected = %+v", rejected)
+	}
+
+	// import.verify applies to auto-import paths too, not just bd import
+	config.Set("import.verify", true)
+	t.Cleanup(func() { config.Set("import.verify", false) })
+	if err := os.WriteFile(jsonlPath, []byte(line1+"\n"+tampered+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	testStore := newTestStoreWithPrefix(t, filepath.Join(beadsDir, "beads.db"), "test")
+	if err := importFromJSONLToStore(context.Background(), testStore, jsonlPath); err != nil {
+		t.Fatalf("importFromJSONLToStore() error = %v", err)
+	}
+	if got, _ := testStore.GetIssue(context.Background(), "test-1"); got == nil {
+		t.Error("signed record test-1 was not imported")
+	}
+	if got, _ := testStore.GetIssue(context.Background(), "test-2"); got != nil {
+		t.Errorf("tampered record test-2 was imported: %+v", got)
+	}
+}
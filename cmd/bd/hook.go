@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -15,8 +16,11 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/shard"
+	"github.com/steveyegge/beads/internal/signing"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/factory"
 	"github.com/steveyegge/beads/internal/types"
@@ -31,6 +35,17 @@ var jsonlFilePaths = []string{
 	".beads/beads.jsonl", // Legacy filename, kept for backwards compatibility
 }
 
+// stagedJSONLPaths returns jsonlFilePaths with the working copy of a sharded
+// layout replaced by its shard directory, which is what git tracks, plus the
+// export signature directory.
+func stagedJSONLPaths() []string {
+	paths := make([]string, 0, len(jsonlFilePaths)+1)
+	for _, f := range jsonlFilePaths {
+		paths = append(paths, shard.TrackedPath(f))
+	}
+	return append(paths, filepath.Join(".beads", signing.DirName))
+}
+
 // hookCmd is the main "bd hook" command that git hooks call into.
 // This is distinct from "bd hooks" (plural) which manages hook installation.
 var hookCmd = &cobra.Command{
@@ -382,7 +397,7 @@ func hookPreCommit() int {
 	if os.Getenv("BEADS_NO_AUTO_STAGE") == "" {
 		rc, rcErr := beads.GetRepoContext()
 		ctx := context.Background()
-		for _, f := range jsonlFilePaths {
+		for _, f := range stagedJSONLPaths() {
 			if _, err := os.Stat(f); err == nil {
 				var gitAdd *exec.Cmd
 				if rcErr == nil {
@@ -542,7 +557,7 @@ func stageJSONLFiles(ctx context.Context) {
 	}
 
 	rc, rcErr := beads.GetRepoContext()
-	for _, f := range jsonlFilePaths {
+	for _, f := range stagedJSONLPaths() {
 		if _, err := os.Stat(f); err == nil {
 			var gitAdd *exec.Cmd
 			if rcErr == nil {
@@ -841,6 +856,10 @@ func hookPostCheckout(args []string) int {
 // importFromJSONLToStore imports issues from JSONL to a store.
 // This is a placeholder - the actual implementation should use the store's methods.
 func importFromJSONLToStore(ctx context.Context, store storage.Storage, jsonlPath string) error {
+	if err := shard.Sync(jsonlPath); err != nil {
+		return fmt.Errorf("failed to reconcile JSONL shards: %w", err)
+	}
+
 	// Parse JSONL into issues
 	// #nosec G304 - jsonlPath is derived from beadsDir (trusted workspace path)
 	f, err := os.Open(jsonlPath)
@@ -853,7 +872,9 @@ func importFromJSONLToStore(ctx context.Context, store storage.Storage, jsonlPat
 	// 2MB buffer for large issues
 	scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024)
 
+	verify := config.GetBool("import.verify")
 	var allIssues []*types.Issue
+	var rawLines [][]byte // Exact record bytes for import.verify, parallel to allIssues
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
@@ -865,10 +886,18 @@ func importFromJSONLToStore(ctx context.Context, store storage.Storage, jsonlPat
 		}
 		issue.SetDefaults()
 		allIssues = append(allIssues, &issue)
+		if verify {
+			rawLines = append(rawLines, bytes.Clone(scanner.Bytes()))
+		}
 	}
 	if err := scanner.Err(); err != nil {
 		return err
 	}
+	if verify {
+		if allIssues, err = verifySignedImport(jsonlPath, allIssues, rawLines); err != nil {
+			return err
+		}
+	}
 
 	// Import using shared logic (no subprocess).
 	// Use store.Path() as the database path (works for both sqlite and dolt).
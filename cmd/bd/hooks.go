@@ -578,7 +578,7 @@ func runPreCommitHook() int {
 	if os.Getenv("BEADS_NO_AUTO_STAGE") != "" {
 		// Safe mode: check for unstaged changes and block if found
 		var unstaged []string
-		for _, f := range jsonlFilePaths {
+		for _, f := range stagedJSONLPaths() {
 			if _, err := os.Stat(f); err == nil {
 				if hasUnstagedChanges(f) {
 					unstaged = append(unstaged, f)
@@ -599,7 +599,7 @@ func runPreCommitHook() int {
 		// Default: auto-stage JSONL files
 		rc, rcErr := beads.GetRepoContext()
 		ctx := context.Background()
-		for _, f := range jsonlFilePaths {
+		for _, f := range stagedJSONLPaths() {
 			if _, err := os.Stat(f); err == nil {
 				var gitAdd *exec.Cmd
 				if rcErr == nil {
@@ -695,7 +695,7 @@ func runPrePushHook(args []string) int {
 
 	// Check for uncommitted JSONL changes
 	files := []string{}
-	for _, f := range jsonlFilePaths {
+	for _, f := range stagedJSONLPaths() {
 		// Check if file exists or is tracked
 		if _, err := os.Stat(f); err == nil {
 			files = append(files, f)
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// namespacePinsFromConfig extracts the per-prefix ID length pins stored under
+// id.namespace.<prefix> config keys.
+func namespacePinsFromConfig(ctx context.Context, s storage.Storage) (map[string]int, error) {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pins := make(map[string]int)
+	for key, value := range all {
+		prefix, ok := strings.CutPrefix(key, idgen.NamespaceConfigPrefix)
+		if !ok || prefix == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && n > 0 {
+			pins[prefix] = n
+		}
+	}
+	return pins, nil
+}
+
+// exportIDNamespace writes the database's ID length pins to namespace.jsonl
+// next to the issues export so they travel with the next git sync. Pins
+// already in the file are kept (longest wins), and the file is only touched
+// when its content would change.
+func exportIDNamespace(ctx context.Context, s storage.Storage, beadsDir string) error {
+	dbPins, err := namespacePinsFromConfig(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to read namespace pins: %w", err)
+	}
+	filePins, err := idgen.LoadNamespace(beadsDir)
+	if err != nil {
+		return err
+	}
+	merged := idgen.MergeNamespace(filePins, dbPins)
+	if maps.Equal(merged, filePins) {
+		return nil
+	}
+	return idgen.SaveNamespace(beadsDir, merged)
+}
+
+// exportIDNamespaceBestEffort is exportIDNamespace for export paths where a
+// failure must not block the issues export itself.
+func exportIDNamespaceBestEffort(ctx context.Context, s storage.Storage, beadsDir string) {
+	if err := exportIDNamespace(ctx, s, beadsDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to export %s: %v\n", idgen.NamespaceFileName, err)
+	}
+}
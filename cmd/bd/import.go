@@ -15,7 +15,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -25,8 +27,9 @@ import (
 var importCmd = &cobra.Command{
 	Use:     "import",
 	GroupID: "sync",
-	Short:   "Import issues from JSONL format",
-	Long: `Import issues from JSON Lines format (one JSON object per line).
+	Short:   "Import issues from JSONL, CSV, markdown or Org format",
+	Long: `Import issues from JSON Lines format (one JSON object per line), or
+from an edited CSV, markdown or Org-mode file with --format.
 
 Reads from stdin by default, or use -i flag for file input.
 
@@ -36,6 +39,42 @@ Behavior:
   - Collisions (same ID, different content) are detected and reported
   - Use --dedupe-after to find and merge content duplicates after import
   - Use --dry-run to preview changes without applying them
+  - Use --verify to reject records that no trusted key signed (see below)
+
+Signed exports:
+  With signing.key set, every export records a sha256 per issue record in
+  .beads/signatures/ and signs that list with your SSH or minisign key.
+  --verify checks those signatures offline against the allowed signers file
+  (signing.allowed_signers, which must be outside the repository) and skips
+  any record that is unsigned or was edited after signing. With
+  import.verify: true every JSONL import is verified, including auto-import
+  and the imports bd sync and the git hooks run after a pull. Together with
+  fork protection this lets maintainers pull JSONL from untrusted forks
+  without taking in forged issues.
+
+CSV, markdown and Org files (--format csv|markdown|org):
+  These are the files bd export --format writes, or hand-written ones. Each
+  record is matched to an issue by ID, then by external ref; records that
+  match nothing become new issues. Only fields present in the file are
+  changed: a CSV with just ID and Priority columns only sets priorities.
+  Labels and dependencies present in a record replace the issue's own, so
+  an export, edit, import cycle applies exactly the edits and importing an
+  unchanged file changes nothing. Dependencies are written "bd-1" (blocks)
+  or "type:bd-1". Everything is applied in one transaction.
+
+  Rename columns or headings with --map field=Name or in config.yaml:
+    codec:
+      csv:
+        title: Summary
+        priority: Severity
+
+  Fields: id, title, status, priority, type, assignee, labels,
+  dependencies, external_ref, estimate, due, defer, description, design,
+  acceptance_criteria, notes.
+
+  Examples:
+    bd export --format csv -o backlog.csv && bd import --format csv -i backlog.csv
+    bd import --format markdown -i plan.md --dry-run
 
 NOTE: Import requires direct database access and does not work with daemon mode.
       The command automatically uses --no-daemon when executed.`,
@@ -102,6 +141,18 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		protectLeftSnapshot, _ := cmd.Flags().GetBool("protect-left-snapshot")
 		noGitHistory, _ := cmd.Flags().GetBool("no-git-history")
 		_ = noGitHistory // Accepted for compatibility with bd sync subprocess calls
+		if format, _ := cmd.Flags().GetString("format"); format != "jsonl" {
+			runCodecImport(cmd, format, input, dryRun)
+			return
+		}
+		verify, _ := cmd.Flags().GetBool("verify")
+		if !cmd.Flags().Changed("verify") {
+			verify = config.GetBool("import.verify")
+		}
+		if verify && input == "" {
+			fmt.Fprintf(os.Stderr, "Error: --verify needs -i: signatures are found next to the input file\n")
+			os.Exit(1)
+		}
 
 		// Check if stdin is being used interactively (not piped)
 		if input == "" && term.IsTerminal(int(os.Stdin.Fd())) {
@@ -118,6 +169,11 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		// Open input
 		in := os.Stdin
 		if input != "" {
+			// Sharded layout: rebuild the working copy from the shards first
+			if err := shard.Sync(input); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reconciling JSONL shards: %v\n", err)
+				os.Exit(1)
+			}
 			// #nosec G304 - user-provided file path is intentional
 			f, err := os.Open(input)
 			if err != nil {
@@ -137,6 +193,7 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		scanner := bufio.NewScanner(in)
 
 		var allIssues []*types.Issue
+		var rawLines [][]byte // Exact record bytes for --verify, parallel to allIssues
 		lineNum := 0
 
 		for scanner.Scan() {
@@ -193,8 +250,9 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 					in = f
 					scanner = bufio.NewScanner(in)
 					allIssues = nil // Reset issues list
-					lineNum = 0     // Reset line counter
-					continue        // Restart parsing from beginning
+					rawLines = nil
+					lineNum = 0 // Reset line counter
+					continue    // Restart parsing from beginning
 				} else {
 					// Can't retry stdin - should not happen since git conflicts only in files
 					fmt.Fprintf(os.Stderr, "Error: Cannot retry merge from stdin\n")
@@ -211,6 +269,9 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			issue.SetDefaults() // Apply defaults for omitted fields (beads-399)
 
 			allIssues = append(allIssues, &issue)
+			if verify {
+				rawLines = append(rawLines, bytes.Clone(rawLine))
+			}
 		}
 
 		if err := scanner.Err(); err != nil {
@@ -218,6 +279,16 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			os.Exit(1)
 		}
 
+		// Drop records no trusted key vouches for before anything touches the DB
+		if verify {
+			kept, err := verifySignedImport(input, allIssues, rawLines)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			allIssues = kept
+		}
+
 		// Check if database needs initialization (prefix not set)
 		// Detect prefix from the imported issues
 		initCtx := rootCtx
@@ -793,6 +864,8 @@ func detectPrefixFromIssues(issues []*types.Issue) string {
 
 func init() {
 	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
+	importCmd.Flags().StringP("format", "f", "jsonl", "Input format: jsonl, csv, markdown, org")
+	importCmd.Flags().StringArray("map", nil, "Read a csv column or markdown/org heading as a field: field=Name (repeatable)")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
 	importCmd.Flags().Bool("strict", false, "Fail on dependency errors instead of treating them as warnings")
 	importCmd.Flags().Bool("dedupe-after", false, "Detect and report content duplicates after import")
@@ -803,6 +876,7 @@ func init() {
 	importCmd.Flags().Bool("force", false, "Force metadata update even when database is already in sync with JSONL")
 	importCmd.Flags().Bool("protect-left-snapshot", false, "Protect issues in left snapshot from git-history-backfill")
 	importCmd.Flags().Bool("no-git-history", false, "Skip git history backfill for deletions (passed by bd sync)")
+	importCmd.Flags().Bool("verify", false, "Reject records not signed by a key in the allowed signers file (default: import.verify config)")
 	importCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output import statistics in JSON format")
 	rootCmd.AddCommand(importCmd)
 }
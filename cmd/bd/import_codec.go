@@ -0,0 +1,416 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/codec"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/util"
+	"golang.org/x/term"
+)
+
+// CodecImportResult reports what a csv, markdown or org import changed.
+type CodecImportResult struct {
+	Format    string              `json:"format"`
+	Created   []*CodecImportIssue `json:"created"`
+	Updated   []*CodecImportIssue `json:"updated"`
+	Unchanged int                 `json:"unchanged"`
+	Applied   bool                `json:"applied"`
+}
+
+// CodecImportIssue is one created or updated issue. ID is empty for new
+// issues in a dry run.
+type CodecImportIssue struct {
+	ID      string   `json:"id,omitempty"`
+	Title   string   `json:"title"`
+	Line    int      `json:"line"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// codecChange is what importing one record does to the database.
+type codecChange struct {
+	rec      *codec.Record
+	existing *types.Issue // nil creates an issue
+
+	updates      map[string]interface{}
+	changed      []string // fields touched, for the report
+	addLabels    []string
+	removeLabels []string
+	addDeps      []*types.Dependency
+	removeDeps   []*types.Dependency
+}
+
+func (c *codecChange) empty() bool {
+	return c.existing != nil && len(c.updates) == 0 && len(c.addLabels) == 0 && len(c.removeLabels) == 0 &&
+		len(c.addDeps) == 0 && len(c.removeDeps) == 0
+}
+
+// codecUpdateKeys maps codec fields to UpdateIssue keys.
+var codecUpdateKeys = map[string]string{
+	codec.FieldTitle:              "title",
+	codec.FieldStatus:             "status",
+	codec.FieldPriority:           "priority",
+	codec.FieldType:               "issue_type",
+	codec.FieldAssignee:           "assignee",
+	codec.FieldExternalRef:        "external_ref",
+	codec.FieldEstimate:           "estimated_minutes",
+	codec.FieldDue:                "due_at",
+	codec.FieldDefer:              "defer_until",
+	codec.FieldDescription:        "description",
+	codec.FieldDesign:             "design",
+	codec.FieldAcceptanceCriteria: "acceptance_criteria",
+	codec.FieldNotes:              "notes",
+}
+
+// codecMapping merges the codec.<format> config mapping with --map flags,
+// the flags winning.
+func codecMapping(cmd *cobra.Command, format string) (codec.Mapping, error) {
+	overrides := make(map[string]string)
+	for field, name := range config.GetStringMapString("codec." + format) {
+		overrides[field] = name
+	}
+	pairs, _ := cmd.Flags().GetStringArray("map")
+	flagOverrides, err := codec.ParseMappingFlags(pairs)
+	if err != nil {
+		return nil, err
+	}
+	for field, name := range flagOverrides {
+		overrides[field] = name
+	}
+	return codec.NewMapping(overrides)
+}
+
+// runCodecImport imports a csv, markdown or org file. Unlike JSONL import,
+// which syncs whole records, it applies only the fields the file carries.
+func runCodecImport(cmd *cobra.Command, format, input string, dryRun bool) {
+	mapping, err := codecMapping(cmd, format)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	c, err := codec.New(format, mapping)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	var in io.Reader = os.Stdin
+	if input != "" {
+		// #nosec G304 - user-provided file path is intentional
+		f, err := os.Open(input)
+		if err != nil {
+			FatalErrorRespectJSON("opening input file: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	} else if term.IsTerminal(int(os.Stdin.Fd())) {
+		FatalErrorRespectJSON("no input specified (use -i FILE or pipe the %s file in)", format)
+	}
+
+	records, err := c.Decode(in)
+	if err != nil {
+		FatalErrorRespectJSON("reading %s: %v", format, err)
+	}
+
+	ctx := rootCtx
+	changes, err := planCodecImport(ctx, store, records)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if !dryRun {
+		if err := applyCodecImport(ctx, store, changes, actor); err != nil {
+			FatalErrorRespectJSON("import failed, nothing was changed: %v", err)
+		}
+	}
+	result := codecImportResult(format, changes, !dryRun)
+	if result.Applied && len(result.Created)+len(result.Updated) > 0 {
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	printCodecImportResult(result, input)
+}
+
+// planCodecImport matches records to issues, by ID and then by external
+// ref, and works out the changes. Records that match nothing are new issues.
+func planCodecImport(ctx context.Context, s storage.Storage, records []*codec.Record) ([]*codecChange, error) {
+	matched := make(map[string]int) // issue ID -> line of the record that matched it
+	var changes []*codecChange
+	for _, rec := range records {
+		existing, err := matchCodecRecord(ctx, s, rec)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", rec.Line, err)
+		}
+		change := &codecChange{rec: rec, existing: existing, updates: map[string]interface{}{}}
+		if existing == nil {
+			if strings.TrimSpace(rec.Issue.Title) == "" {
+				return nil, fmt.Errorf("line %d: new issue has no title", rec.Line)
+			}
+			change.addLabels = util.NormalizeLabels(rec.Issue.Labels)
+			change.addDeps = rec.Issue.Dependencies
+			changes = append(changes, change)
+			continue
+		}
+		if prev, dup := matched[existing.ID]; dup {
+			return nil, fmt.Errorf("line %d: %s was already matched by line %d", rec.Line, existing.ID, prev)
+		}
+		matched[existing.ID] = rec.Line
+		if err := diffCodecRecord(ctx, s, change); err != nil {
+			return nil, fmt.Errorf("line %d: %w", rec.Line, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func matchCodecRecord(ctx context.Context, s storage.Storage, rec *codec.Record) (*types.Issue, error) {
+	if id := rec.Issue.ID; id != "" {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil && issue.Status != types.StatusTombstone {
+			return issue, nil
+		}
+	}
+	if ref := rec.Issue.ExternalRef; ref != nil && *ref != "" {
+		issue, err := s.GetIssueByExternalRef(ctx, *ref)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil && issue.Status != types.StatusTombstone {
+			return issue, nil
+		}
+	}
+	return nil, nil
+}
+
+// diffCodecRecord fills in the changes for a record that matched an issue,
+// comparing only the fields present in the record.
+func diffCodecRecord(ctx context.Context, s storage.Storage, change *codecChange) error {
+	rec, existing := change.rec, change.existing
+	for _, field := range codec.Fields {
+		key, ok := codecUpdateKeys[field]
+		if !ok || !rec.Has(field) {
+			continue
+		}
+		// Compare the text forms so that formatting differences (trailing
+		// newlines, sub-second times) don't count as edits
+		if strings.TrimSpace(codec.FormatField(existing, field)) == codec.FormatField(rec.Issue, field) {
+			continue
+		}
+		if field == codec.FieldTitle && rec.Issue.Title == "" {
+			return fmt.Errorf("title of %s cannot be empty", existing.ID)
+		}
+		change.updates[key] = codecUpdateValue(rec.Issue, field)
+		change.changed = append(change.changed, field)
+	}
+
+	if rec.Has(codec.FieldLabels) {
+		current, err := s.GetLabels(ctx, existing.ID)
+		if err != nil {
+			return err
+		}
+		want := util.NormalizeLabels(rec.Issue.Labels)
+		for _, l := range want {
+			if !slices.Contains(current, l) {
+				change.addLabels = append(change.addLabels, l)
+			}
+		}
+		for _, l := range current {
+			if !slices.Contains(want, l) {
+				change.removeLabels = append(change.removeLabels, l)
+			}
+		}
+	}
+
+	if rec.Has(codec.FieldDependencies) {
+		current, err := s.GetDependencyRecords(ctx, existing.ID)
+		if err != nil {
+			return err
+		}
+		sameDep := func(a, b *types.Dependency) bool {
+			return a.DependsOnID == b.DependsOnID && a.Type == b.Type
+		}
+		for _, d := range rec.Issue.Dependencies {
+			if d.DependsOnID != existing.ID && !slices.ContainsFunc(current, func(c *types.Dependency) bool { return sameDep(c, d) }) {
+				change.addDeps = append(change.addDeps, d)
+			}
+		}
+		for _, c := range current {
+			if !slices.ContainsFunc(rec.Issue.Dependencies, func(d *types.Dependency) bool { return sameDep(c, d) }) {
+				change.removeDeps = append(change.removeDeps, c)
+			}
+		}
+	}
+	return nil
+}
+
+func codecUpdateValue(issue *types.Issue, field string) interface{} {
+	switch field {
+	case codec.FieldTitle:
+		return issue.Title
+	case codec.FieldStatus:
+		return string(issue.Status)
+	case codec.FieldPriority:
+		return issue.Priority
+	case codec.FieldType:
+		return string(issue.IssueType)
+	case codec.FieldAssignee:
+		return issue.Assignee
+	case codec.FieldExternalRef:
+		if issue.ExternalRef == nil {
+			return nil
+		}
+		return *issue.ExternalRef
+	case codec.FieldEstimate:
+		if issue.EstimatedMinutes == nil {
+			return nil
+		}
+		return *issue.EstimatedMinutes
+	case codec.FieldDue:
+		if issue.DueAt == nil {
+			return nil
+		}
+		return *issue.DueAt
+	case codec.FieldDefer:
+		if issue.DeferUntil == nil {
+			return nil
+		}
+		return *issue.DeferUntil
+	case codec.FieldDescription:
+		return issue.Description
+	case codec.FieldDesign:
+		return issue.Design
+	case codec.FieldAcceptanceCriteria:
+		return issue.AcceptanceCriteria
+	case codec.FieldNotes:
+		return issue.Notes
+	}
+	return nil
+}
+
+// applyCodecImport applies the changes in one transaction. Dependencies are
+// added after every issue exists, so records can depend on each other.
+func applyCodecImport(ctx context.Context, s storage.Storage, changes []*codecChange, actor string) error {
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, c := range changes {
+			if c.existing != nil {
+				continue
+			}
+			issue := c.rec.Issue
+			if !c.rec.Has(codec.FieldStatus) || issue.Status == "" {
+				issue.Status = types.StatusOpen
+			}
+			if !c.rec.Has(codec.FieldPriority) {
+				issue.Priority = 2
+			}
+			if issue.IssueType == "" {
+				issue.IssueType = types.TypeTask
+			}
+			issue.Labels, issue.Dependencies = nil, nil
+			if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+				return fmt.Errorf("line %d: %w", c.rec.Line, err)
+			}
+		}
+
+		for _, c := range changes {
+			id := c.rec.Issue.ID
+			if c.existing != nil {
+				id = c.existing.ID
+				if len(c.updates) > 0 {
+					if err := tx.UpdateIssue(ctx, id, c.updates, actor); err != nil {
+						return fmt.Errorf("updating %s: %w", id, err)
+					}
+				}
+			}
+			for _, l := range c.removeLabels {
+				if err := tx.RemoveLabel(ctx, id, l, actor); err != nil {
+					return fmt.Errorf("removing label %q from %s: %w", l, id, err)
+				}
+			}
+			for _, l := range c.addLabels {
+				if err := tx.AddLabel(ctx, id, l, actor); err != nil {
+					return fmt.Errorf("adding label %q to %s: %w", l, id, err)
+				}
+			}
+			for _, d := range c.removeDeps {
+				if err := tx.RemoveDependency(ctx, id, d.DependsOnID, actor); err != nil {
+					return fmt.Errorf("removing dependency %s -> %s: %w", id, d.DependsOnID, err)
+				}
+			}
+		}
+
+		// Second pass so that a removed dependency can come back with a new type
+		for _, c := range changes {
+			id := c.rec.Issue.ID
+			if c.existing != nil {
+				id = c.existing.ID
+			}
+			for _, d := range c.addDeps {
+				dep := &types.Dependency{IssueID: id, DependsOnID: d.DependsOnID, Type: d.Type}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("adding dependency %s -> %s: %w", id, d.DependsOnID, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func codecImportResult(format string, changes []*codecChange, applied bool) *CodecImportResult {
+	result := &CodecImportResult{Format: format, Created: []*CodecImportIssue{}, Updated: []*CodecImportIssue{}, Applied: applied}
+	for _, c := range changes {
+		if c.empty() {
+			result.Unchanged++
+			continue
+		}
+		entry := &CodecImportIssue{ID: c.rec.Issue.ID, Title: c.rec.Issue.Title, Line: c.rec.Line}
+		if c.existing == nil {
+			result.Created = append(result.Created, entry)
+			continue
+		}
+		entry.ID, entry.Title = c.existing.ID, c.existing.Title
+		entry.Changes = append(entry.Changes, c.changed...)
+		if len(c.addLabels)+len(c.removeLabels) > 0 {
+			entry.Changes = append(entry.Changes, codec.FieldLabels)
+		}
+		if len(c.addDeps)+len(c.removeDeps) > 0 {
+			entry.Changes = append(entry.Changes, codec.FieldDependencies)
+		}
+		result.Updated = append(result.Updated, entry)
+	}
+	return result
+}
+
+func printCodecImportResult(result *CodecImportResult, input string) {
+	if input == "" {
+		input = "stdin"
+	}
+	verb := "Imported"
+	if !result.Applied {
+		verb = "Would import"
+	}
+	fmt.Printf("%s %s from %s: %d created, %d updated, %d unchanged\n", verb, result.Format, input,
+		len(result.Created), len(result.Updated), result.Unchanged)
+	for _, e := range result.Created {
+		id := e.ID
+		if id == "" {
+			id = fmt.Sprintf("line %d", e.Line)
+		}
+		fmt.Printf("  %s %s  %s\n", ui.RenderPass("+"), ui.RenderID(id), e.Title)
+	}
+	for _, e := range result.Updated {
+		fmt.Printf("  %s %s  %s (%s)\n", ui.RenderAccent("~"), ui.RenderID(e.ID), e.Title, strings.Join(e.Changes, ", "))
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/codec"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// exportForCodec loads issues the way bd export does.
+func exportForCodec(t *testing.T, ctx context.Context, s storage.Storage) []*types.Issue {
+	t.Helper()
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = deps[issue.ID]
+		if issue.Labels, err = s.GetLabels(ctx, issue.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return issues
+}
+
+func importCodecText(t *testing.T, ctx context.Context, s storage.Storage, format, text string) *CodecImportResult {
+	t.Helper()
+	c, err := codec.New(format, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := c.Decode(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := planCodecImport(ctx, s, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := applyCodecImport(ctx, s, changes, "test"); err != nil {
+		t.Fatal(err)
+	}
+	return codecImportResult(format, changes, true)
+}
+
+func TestCodecImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+
+	a := &types.Issue{Title: "Alpha", Description: "trailing newline\n", Priority: 1, Status: types.StatusOpen, IssueType: types.TypeBug}
+	b := &types.Issue{Title: "Beta", Priority: 2, Status: types.StatusInProgress, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AddLabel(ctx, a.ID, "area:web", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range codec.Formats() {
+		c, _ := codec.New(format, nil)
+		var buf bytes.Buffer
+		if err := c.Encode(&buf, exportForCodec(t, ctx, s)); err != nil {
+			t.Fatal(err)
+		}
+		result := importCodecText(t, ctx, s, format, buf.String())
+		if len(result.Created) != 0 || len(result.Updated) != 0 || result.Unchanged != 2 {
+			t.Errorf("%s: re-importing an export = %d created, %d updated, %d unchanged; want no changes",
+				format, len(result.Created), len(result.Updated), result.Unchanged)
+		}
+	}
+
+	// Edit the markdown export: new priority, labels and dependency type,
+	// plus a new issue that depends on Beta
+	c, _ := codec.New("markdown", nil)
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, exportForCodec(t, ctx, s)); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	text = strings.Replace(text, "### Priority\nP1", "### Priority\nP0", 1)
+	text = strings.Replace(text, "### Labels\narea:web", "### Labels\nurgent", 1)
+	text = strings.Replace(text, "### Dependencies\n"+b.ID, "### Dependencies\nrelated:"+b.ID, 1)
+	text += "\n## Gamma\nNew work.\n\n### Dependencies\n" + b.ID + "\n"
+
+	result := importCodecText(t, ctx, s, "markdown", text)
+	if len(result.Created) != 1 || len(result.Updated) != 1 || result.Unchanged != 1 {
+		t.Fatalf("edit import = %+v", result)
+	}
+	if want := []string{"priority", "labels", "dependencies"}; !reflect.DeepEqual(result.Updated[0].Changes, want) {
+		t.Errorf("changes = %v, want %v", result.Updated[0].Changes, want)
+	}
+
+	got, _ := s.GetIssue(ctx, a.ID)
+	labels, _ := s.GetLabels(ctx, a.ID)
+	deps, _ := s.GetDependencyRecords(ctx, a.ID)
+	if got.Priority != 0 || !reflect.DeepEqual(labels, []string{"urgent"}) ||
+		len(deps) != 1 || deps[0].Type != types.DepRelated {
+		t.Errorf("a = p%d %v %+v", got.Priority, labels, deps)
+	}
+	gamma := result.Created[0]
+	if gamma.ID == "" {
+		t.Fatal("created issue has no ID")
+	}
+	if deps, _ := s.GetDependencyRecords(ctx, gamma.ID); len(deps) != 1 || deps[0].DependsOnID != b.ID {
+		t.Errorf("gamma deps = %+v", deps)
+	}
+	if g, _ := s.GetIssue(ctx, gamma.ID); g.Priority != 2 || g.Status != types.StatusOpen || g.Description != "New work." {
+		t.Errorf("gamma = %+v", g)
+	}
+}
+
+func TestCodecImportPartialAndExternalRef(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ref := "JIRA-7"
+	issue := &types.Issue{Title: "Tracked", Description: "keep me", Priority: 3, Status: types.StatusOpen,
+		IssueType: types.TypeTask, ExternalRef: &ref}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddLabel(ctx, issue.ID, "keep", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// No ID column: matched by external ref; only priority changes
+	result := importCodecText(t, ctx, s, "csv", "External Ref,Priority\nJIRA-7,P1\n")
+	if len(result.Updated) != 1 || result.Updated[0].ID != issue.ID {
+		t.Fatalf("result = %+v", result)
+	}
+	got, _ := s.GetIssue(ctx, issue.ID)
+	labels, _ := s.GetLabels(ctx, issue.ID)
+	if got.Priority != 1 || got.Title != "Tracked" || got.Description != "keep me" || !reflect.DeepEqual(labels, []string{"keep"}) {
+		t.Errorf("issue = %+v labels=%v", got, labels)
+	}
+
+	c, _ := codec.New("csv", nil)
+	for _, bad := range []string{
+		"External Ref,Priority\n,P1\n", // new issue without a title
+		"ID,Title\n" + issue.ID + ",a\n" + issue.ID + ",b\n",
+	} {
+		records, err := c.Decode(strings.NewReader(bad))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := planCodecImport(ctx, s, records); err == nil {
+			t.Errorf("planCodecImport(%q) should fail", bad)
+		}
+	}
+}
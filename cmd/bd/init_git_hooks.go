@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -679,6 +680,16 @@ func installMergeDriver() error {
 		if err := os.WriteFile(gitattributesPath, []byte(newContent), 0644); err != nil {
 			return fmt.Errorf("failed to update .gitattributes: %w", err)
 		}
+		existingContent = newContent
+	}
+
+	// Sharded layout: the shards are what git merges
+	if shard.IsSharded(".beads") && !strings.Contains(existingContent, ".beads/issues/**/*.jsonl merge=beads") {
+		shardAttr := "\n# Use bd merge for sharded beads JSONL files\n.beads/issues/**/*.jsonl merge=beads\n"
+		// #nosec G306 - .gitattributes needs to be readable
+		if err := os.WriteFile(gitattributesPath, []byte(strings.TrimRight(existingContent, "\n")+"\n"+shardAttr), 0644); err != nil {
+			return fmt.Errorf("failed to update .gitattributes: %w", err)
+		}
 	}
 
 	return nil
@@ -14,6 +14,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -79,6 +80,11 @@ func isJSONLNewerWithStore(jsonlPath string, st storage.Storage) bool {
 // computeJSONLHash computes SHA256 hash of JSONL file content.
 // Returns hex-encoded hash string and any error encountered reading the file.
 func computeJSONLHash(jsonlPath string) (string, error) {
+	// Sharded layout: hash the assembled shards, which is byte-for-byte what
+	// a single-file export would contain.
+	if err := shard.Sync(jsonlPath); err != nil {
+		return "", fmt.Errorf("failed to reconcile JSONL shards: %w", err)
+	}
 	jsonlData, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path
 	if err != nil {
 		return "", err
@@ -463,6 +469,16 @@ func computeDBHash(ctx context.Context, store storage.Storage) (string, error) {
 		issue.Comments = comments
 	}
 
+	// Populate attachment metadata
+	if err := populateAttachments(ctx, store, issues); err != nil {
+		return "", err
+	}
+
+	// Populate work logs
+	if err := populateWorkLogs(ctx, store, issues); err != nil {
+		return "", err
+	}
+
 	// Serialize to JSON and hash
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
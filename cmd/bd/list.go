@@ -127,6 +127,19 @@ func parseTimeFlag(s string) (time.Time, error) {
 	return timeparsing.ParseRelativeTime(s, time.Now())
 }
 
+// parseDueWithin turns a --due-within window such as "3d" into the due_at
+// cutoff. Overdue issues fall inside every window.
+func parseDueWithin(s string, now time.Time) (time.Time, error) {
+	if strings.HasPrefix(s, "-") {
+		return time.Time{}, fmt.Errorf("window must not be negative: %q", s)
+	}
+	t, err := timeparsing.ParseCompactDuration(s, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a duration like 6h, 3d or 2w: %w", err)
+	}
+	return t, nil
+}
+
 // pinIndicator returns a pushpin emoji prefix for pinned issues
 func pinIndicator(issue *types.Issue) string {
 	if issue.Pinned {
@@ -169,13 +182,13 @@ func formatPrettyIssue(issue *types.Issue) string {
 	if issue.Status == types.StatusClosed {
 		return fmt.Sprintf("%s %s %s %s%s",
 			statusIcon,
-			ui.RenderMuted(issue.ID),
+			ui.RenderMuted(issue.ID+repoTag(issue)),
 			ui.RenderMuted(fmt.Sprintf("● P%d", issue.Priority)),
 			ui.RenderMuted(string(issue.IssueType)),
 			ui.RenderMuted(" "+issue.Title))
 	}
 
-	return fmt.Sprintf("%s %s %s %s%s", statusIcon, issue.ID, priorityTag, typeBadge, issue.Title)
+	return fmt.Sprintf("%s %s%s %s %s%s", statusIcon, issue.ID, renderRepoTag(issue), priorityTag, typeBadge, issue.Title)
 }
 
 // buildIssueTree builds parent-child tree structure from issues
@@ -451,15 +464,16 @@ func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
 func formatIssueLong(buf *strings.Builder, issue *types.Issue, labels []string) {
 	status := string(issue.Status)
 	if status == "closed" {
-		line := fmt.Sprintf("%s%s [P%d] [%s] %s\n  %s",
-			pinIndicator(issue), issue.ID, issue.Priority,
+		line := fmt.Sprintf("%s%s%s [P%d] [%s] %s\n  %s",
+			pinIndicator(issue), issue.ID, repoTag(issue), issue.Priority,
 			issue.IssueType, status, issue.Title)
 		buf.WriteString(ui.RenderClosedLine(line))
 		buf.WriteString("\n")
 	} else {
-		buf.WriteString(fmt.Sprintf("%s%s [%s] [%s] %s\n",
+		buf.WriteString(fmt.Sprintf("%s%s%s [%s] [%s] %s\n",
 			pinIndicator(issue),
 			ui.RenderID(issue.ID),
+			renderRepoTag(issue),
 			ui.RenderPriority(issue.Priority),
 			ui.RenderType(string(issue.IssueType)),
 			ui.RenderStatus(status)))
@@ -482,7 +496,7 @@ func formatAgentIssue(buf *strings.Builder, issue *types.Issue) {
 
 // formatIssueCompact formats a single issue in compact format to a buffer
 // Uses status icons for better scanability - consistent with bd graph
-// Format: [icon] [pin] ID [Priority] [Type] @assignee [labels] - Title
+// Format: [icon] [pin] ID [⟨repo⟩] [Priority] [Type] @assignee [labels] - Title
 func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []string) {
 	labelsStr := ""
 	if len(labels) > 0 {
@@ -498,23 +512,255 @@ func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []strin
 
 	if issue.Status == types.StatusClosed {
 		// Closed issues: entire line muted (fades visually)
-		line := fmt.Sprintf("%s %s%s [P%d] [%s]%s%s - %s",
-			statusIcon, pinIndicator(issue), issue.ID, issue.Priority,
+		line := fmt.Sprintf("%s %s%s%s [P%d] [%s]%s%s - %s",
+			statusIcon, pinIndicator(issue), issue.ID, repoTag(issue), issue.Priority,
 			issue.IssueType, assigneeStr, labelsStr, issue.Title)
 		buf.WriteString(ui.RenderClosedLine(line))
 		buf.WriteString("\n")
 	} else {
 		// Active issues: status icon + semantic colors for priority/type
-		buf.WriteString(fmt.Sprintf("%s %s%s [%s] [%s]%s%s - %s\n",
+		buf.WriteString(fmt.Sprintf("%s %s%s%s [%s] [%s]%s%s - %s\n",
 			statusIcon,
 			pinIndicator(issue),
 			ui.RenderID(issue.ID),
+			renderRepoTag(issue),
 			ui.RenderPriority(issue.Priority),
 			ui.RenderType(string(issue.IssueType)),
 			assigneeStr, labelsStr, issue.Title))
 	}
 }
 
+// registerListFilterFlags registers the flags that select issues for bd list.
+// bd bulk parses its --filter with the same flags.
+func registerListFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, deferred, closed)")
+	registerPriorityFlag(cmd, "")
+	cmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	cmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore, merge-request, molecule, gate, convoy). Aliases: mr→merge-request, feat→feature, mol→molecule")
+	cmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
+	cmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	cmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
+	cmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
+	cmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
+
+	// Pattern matching
+	cmd.Flags().String("title-contains", "", "Filter by title substring (case-insensitive)")
+	cmd.Flags().String("desc-contains", "", "Filter by description substring (case-insensitive)")
+	cmd.Flags().String("notes-contains", "", "Filter by notes substring (case-insensitive)")
+
+	// Date ranges
+	cmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().String("created-before", "", "Filter issues created before date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().String("updated-after", "", "Filter issues updated after date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().String("updated-before", "", "Filter issues updated before date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().String("closed-after", "", "Filter issues closed after date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().String("closed-before", "", "Filter issues closed before date (YYYY-MM-DD or RFC3339)")
+
+	// Empty/null checks
+	cmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
+	cmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
+	cmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
+
+	// Priority ranges
+	cmd.Flags().String("priority-min", "", "Filter by minimum priority (inclusive, 0-4 or P0-P4)")
+	cmd.Flags().String("priority-max", "", "Filter by maximum priority (inclusive, 0-4 or P0-P4)")
+
+	// Pinned filtering
+	cmd.Flags().Bool("pinned", false, "Show only pinned issues")
+	cmd.Flags().Bool("no-pinned", false, "Exclude pinned issues")
+
+	// Template filtering: exclude templates by default
+	cmd.Flags().Bool("include-templates", false, "Include template molecules in output")
+
+	// Gate filtering: exclude gate issues by default (bd-7zka.2)
+	cmd.Flags().Bool("include-gates", false, "Include gate issues in output (normally hidden)")
+
+	// Parent filtering: filter children by parent issue
+	cmd.Flags().String("parent", "", "Filter by parent issue ID (shows children of specified issue)")
+	cmd.Flags().String("filter-parent", "", "Alias for --parent")
+
+	// Molecule type filtering
+	cmd.Flags().String("mol-type", "", "Filter by molecule type: swarm, patrol, or work")
+
+	// Time-based scheduling filters (GH#820)
+	cmd.Flags().Bool("deferred", false, "Show only issues with defer_until set")
+	cmd.Flags().String("defer-after", "", "Filter issues deferred after date (supports relative: +6h, tomorrow)")
+	cmd.Flags().String("defer-before", "", "Filter issues deferred before date (supports relative: +6h, tomorrow)")
+	cmd.Flags().String("due-after", "", "Filter issues due after date (supports relative: +6h, tomorrow)")
+	cmd.Flags().String("due-before", "", "Filter issues due before date (supports relative: +6h, tomorrow)")
+	cmd.Flags().Bool("overdue", false, "Show only issues with due_at in the past (not closed)")
+	cmd.Flags().String("due-within", "", "Show issues due within a window, including overdue ones (e.g., 3d, 12h)")
+
+	// Ready filter: show only issues ready to be worked on (bd-ihu31)
+	cmd.Flags().Bool("ready", false, "Show only ready issues (status=open, excludes hooked/in_progress/blocked/deferred)")
+}
+
+// buildListFilter builds the issue filter from the flags registered by
+// registerListFilterFlags. Closed issues are excluded unless --status, --all
+// or --ready is set (GH#788). Limit, repo and directory label scoping are
+// left to the caller.
+func buildListFilter(cmd *cobra.Command) (types.IssueFilter, error) {
+	var filter types.IssueFilter
+	flags := cmd.Flags()
+
+	status, _ := flags.GetString("status")
+	allFlag, _ := flags.GetBool("all")
+	readyFlag, _ := flags.GetBool("ready")
+
+	// --ready flag: show only open issues (excludes hooked/in_progress/blocked/deferred) (bd-ihu31)
+	if readyFlag {
+		s := types.StatusOpen
+		filter.Status = &s
+	} else if status != "" && status != "all" {
+		s := types.Status(status)
+		filter.Status = &s
+	}
+
+	// Default to non-closed issues unless --all or explicit --status (GH#788)
+	if status == "" && !allFlag && !readyFlag {
+		filter.ExcludeStatus = []types.Status{types.StatusClosed}
+	}
+
+	// Priorities: use Changed() to properly handle P0 (priority=0)
+	for _, p := range []struct {
+		name string
+		dst  **int
+	}{
+		{"priority", &filter.Priority},
+		{"priority-min", &filter.PriorityMin},
+		{"priority-max", &filter.PriorityMax},
+	} {
+		if !flags.Changed(p.name) {
+			continue
+		}
+		value, _ := flags.GetString(p.name)
+		priority, err := validation.ValidatePriority(value)
+		if err != nil {
+			return filter, fmt.Errorf("parsing --%s: %w", p.name, err)
+		}
+		*p.dst = &priority
+	}
+
+	if assignee, _ := flags.GetString("assignee"); assignee != "" {
+		filter.Assignee = &assignee
+	}
+	issueType, _ := flags.GetString("type")
+	issueType = util.NormalizeIssueType(issueType) // Expand aliases (mr→merge-request, etc.)
+	if issueType != "" {
+		t := types.IssueType(issueType)
+		filter.IssueType = &t
+	}
+
+	// Normalize labels: trim, dedupe, remove empty
+	labels, _ := flags.GetStringSlice("label")
+	if labels = util.NormalizeLabels(labels); len(labels) > 0 {
+		filter.Labels = labels
+	}
+	labelsAny, _ := flags.GetStringSlice("label-any")
+	if labelsAny = util.NormalizeLabels(labelsAny); len(labelsAny) > 0 {
+		filter.LabelsAny = labelsAny
+	}
+	filter.TitleSearch, _ = flags.GetString("title")
+	if idFilter, _ := flags.GetString("id"); idFilter != "" {
+		if ids := util.NormalizeLabels(strings.Split(idFilter, ",")); len(ids) > 0 {
+			filter.IDs = ids
+		}
+	}
+
+	// Pattern matching
+	filter.TitleContains, _ = flags.GetString("title-contains")
+	filter.DescriptionContains, _ = flags.GetString("desc-contains")
+	filter.NotesContains, _ = flags.GetString("notes-contains")
+
+	// Date ranges
+	for _, d := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created-after", &filter.CreatedAfter},
+		{"created-before", &filter.CreatedBefore},
+		{"updated-after", &filter.UpdatedAfter},
+		{"updated-before", &filter.UpdatedBefore},
+		{"closed-after", &filter.ClosedAfter},
+		{"closed-before", &filter.ClosedBefore},
+		{"defer-after", &filter.DeferAfter},
+		{"defer-before", &filter.DeferBefore},
+		{"due-after", &filter.DueAfter},
+		{"due-before", &filter.DueBefore},
+	} {
+		value, _ := flags.GetString(d.name)
+		if value == "" {
+			continue
+		}
+		t, err := parseTimeFlag(value)
+		if err != nil {
+			return filter, fmt.Errorf("parsing --%s: %w", d.name, err)
+		}
+		*d.dst = &t
+	}
+	if dueWithin, _ := flags.GetString("due-within"); dueWithin != "" {
+		if filter.DueBefore != nil {
+			return filter, fmt.Errorf("--due-within and --due-before cannot be combined")
+		}
+		t, err := parseDueWithin(dueWithin, time.Now())
+		if err != nil {
+			return filter, fmt.Errorf("parsing --due-within: %w", err)
+		}
+		filter.DueBefore = &t
+	}
+
+	// Empty/null checks
+	filter.EmptyDescription, _ = flags.GetBool("empty-description")
+	filter.NoAssignee, _ = flags.GetBool("no-assignee")
+	filter.NoLabels, _ = flags.GetBool("no-labels")
+
+	// Pinned filtering: --pinned and --no-pinned are mutually exclusive
+	pinnedFlag, _ := flags.GetBool("pinned")
+	noPinnedFlag, _ := flags.GetBool("no-pinned")
+	if pinnedFlag && noPinnedFlag {
+		return filter, fmt.Errorf("--pinned and --no-pinned are mutually exclusive")
+	}
+	if pinnedFlag || noPinnedFlag {
+		filter.Pinned = &pinnedFlag
+	}
+
+	// Template filtering: exclude templates by default
+	// Use --include-templates to show all issues including templates
+	if includeTemplates, _ := flags.GetBool("include-templates"); !includeTemplates {
+		isTemplate := false
+		filter.IsTemplate = &isTemplate
+	}
+
+	// Gate filtering: exclude gate issues by default (bd-7zka.2)
+	// Use --include-gates or --type gate to show gate issues
+	if includeGates, _ := flags.GetBool("include-gates"); !includeGates && issueType != "gate" {
+		filter.ExcludeTypes = append(filter.ExcludeTypes, "gate")
+	}
+
+	// Parent filtering (--filter-parent is alias for --parent)
+	parentID, _ := flags.GetString("parent")
+	if parentID == "" {
+		parentID, _ = flags.GetString("filter-parent")
+	}
+	if parentID != "" {
+		filter.ParentID = &parentID
+	}
+
+	// Molecule type filtering
+	if molTypeStr, _ := flags.GetString("mol-type"); molTypeStr != "" {
+		molType := types.MolType(molTypeStr)
+		if !molType.IsValid() {
+			return filter, fmt.Errorf("invalid mol-type %q (must be swarm, patrol, or work)", molTypeStr)
+		}
+		filter.MolType = &molType
+	}
+
+	// Time-based scheduling filters (GH#820)
+	filter.Deferred, _ = flags.GetBool("deferred")
+	filter.Overdue, _ = flags.GetBool("overdue")
+	return filter, nil
+}
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	GroupID: "issues",
@@ -525,73 +771,21 @@ var listCmd = &cobra.Command{
 		issueType, _ := cmd.Flags().GetString("type")
 		issueType = util.NormalizeIssueType(issueType) // Expand aliases (mr→merge-request, etc.)
 		limit, _ := cmd.Flags().GetInt("limit")
-		allFlag, _ := cmd.Flags().GetBool("all")
 		formatStr, _ := cmd.Flags().GetString("format")
-		labels, _ := cmd.Flags().GetStringSlice("label")
-		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
 		titleSearch, _ := cmd.Flags().GetString("title")
-		idFilter, _ := cmd.Flags().GetString("id")
 		longFormat, _ := cmd.Flags().GetBool("long")
 		sortBy, _ := cmd.Flags().GetString("sort")
 		reverse, _ := cmd.Flags().GetBool("reverse")
-
-		// Pattern matching flags
-		titleContains, _ := cmd.Flags().GetString("title-contains")
-		descContains, _ := cmd.Flags().GetString("desc-contains")
-		notesContains, _ := cmd.Flags().GetString("notes-contains")
-
-		// Date range flags
-		createdAfter, _ := cmd.Flags().GetString("created-after")
-		createdBefore, _ := cmd.Flags().GetString("created-before")
-		updatedAfter, _ := cmd.Flags().GetString("updated-after")
-		updatedBefore, _ := cmd.Flags().GetString("updated-before")
-		closedAfter, _ := cmd.Flags().GetString("closed-after")
-		closedBefore, _ := cmd.Flags().GetString("closed-before")
-
-		// Empty/null check flags
-		emptyDesc, _ := cmd.Flags().GetBool("empty-description")
-		noAssignee, _ := cmd.Flags().GetBool("no-assignee")
-		noLabels, _ := cmd.Flags().GetBool("no-labels")
-
-		// Priority range flags
-		priorityMinStr, _ := cmd.Flags().GetString("priority-min")
-		priorityMaxStr, _ := cmd.Flags().GetString("priority-max")
-
-		// Pinned filtering flags
-		pinnedFlag, _ := cmd.Flags().GetBool("pinned")
-		noPinnedFlag, _ := cmd.Flags().GetBool("no-pinned")
-
-		// Template filtering
 		includeTemplates, _ := cmd.Flags().GetBool("include-templates")
 
-		// Gate filtering (bd-7zka.2)
-		includeGates, _ := cmd.Flags().GetBool("include-gates")
-
 		// Parent filtering (--filter-parent is alias for --parent)
 		parentID, _ := cmd.Flags().GetString("parent")
 		if parentID == "" {
 			parentID, _ = cmd.Flags().GetString("filter-parent")
 		}
 
-		// Molecule type filtering
-		molTypeStr, _ := cmd.Flags().GetString("mol-type")
-		var molType *types.MolType
-		if molTypeStr != "" {
-			mt := types.MolType(molTypeStr)
-			if !mt.IsValid() {
-				fmt.Fprintf(os.Stderr, "Error: invalid mol-type %q (must be swarm, patrol, or work)\n", molTypeStr)
-				os.Exit(1)
-			}
-			molType = &mt
-		}
-
-		// Time-based scheduling filters (GH#820)
-		deferredFlag, _ := cmd.Flags().GetBool("deferred")
-		deferAfter, _ := cmd.Flags().GetString("defer-after")
-		deferBefore, _ := cmd.Flags().GetString("defer-before")
-		dueAfter, _ := cmd.Flags().GetString("due-after")
-		dueBefore, _ := cmd.Flags().GetString("due-before")
-		overdueFlag, _ := cmd.Flags().GetBool("overdue")
+		// Ready filter (bd-ihu31)
+		readyFlag, _ := cmd.Flags().GetBool("ready")
 
 		// Pretty and watch flags (GH#654)
 		prettyFormat, _ := cmd.Flags().GetBool("pretty")
@@ -602,8 +796,8 @@ var listCmd = &cobra.Command{
 		// Pager control (bd-jdz3)
 		noPager, _ := cmd.Flags().GetBool("no-pager")
 
-		// Ready filter (bd-ihu31)
-		readyFlag, _ := cmd.Flags().GetBool("ready")
+		// Multi-repo selector
+		sourceRepo := repoFilterFromFlag(cmd)
 
 		// Watch mode implies pretty format
 		if watchMode {
@@ -612,16 +806,19 @@ var listCmd = &cobra.Command{
 
 		// Use global jsonOutput set by PersistentPreRun
 
-		// Normalize labels: trim, dedupe, remove empty
-		labels = util.NormalizeLabels(labels)
-		labelsAny = util.NormalizeLabels(labelsAny)
+		filter, err := buildListFilter(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		// Apply directory-aware label scoping if no labels explicitly provided (GH#541)
-		if len(labels) == 0 && len(labelsAny) == 0 {
+		if len(filter.Labels) == 0 && len(filter.LabelsAny) == 0 {
 			if dirLabels := config.GetDirectoryLabels(); len(dirLabels) > 0 {
-				labelsAny = dirLabels
+				filter.LabelsAny = dirLabels
 			}
 		}
+		labels, labelsAny := filter.Labels, filter.LabelsAny
 
 		// Handle limit: --limit 0 means unlimited (explicit override)
 		// Otherwise use the value (default 50 or user-specified)
@@ -632,221 +829,11 @@ var listCmd = &cobra.Command{
 		} else if !cmd.Flags().Changed("limit") && ui.IsAgentMode() {
 			effectiveLimit = 20 // Agent mode default
 		}
+		filter.Limit = effectiveLimit
 
-		filter := types.IssueFilter{
-			Limit: effectiveLimit,
-		}
-
-		// --ready flag: show only open issues (excludes hooked/in_progress/blocked/deferred) (bd-ihu31)
-		if readyFlag {
-			s := types.StatusOpen
-			filter.Status = &s
-		} else if status != "" && status != "all" {
-			s := types.Status(status)
-			filter.Status = &s
-		}
-
-		// Default to non-closed issues unless --all or explicit --status (GH#788)
-		if status == "" && !allFlag && !readyFlag {
-			filter.ExcludeStatus = []types.Status{types.StatusClosed}
-		}
-		// Use Changed() to properly handle P0 (priority=0)
-		if cmd.Flags().Changed("priority") {
-			priorityStr, _ := cmd.Flags().GetString("priority")
-			priority, err := validation.ValidatePriority(priorityStr)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-			filter.Priority = &priority
-		}
-		if assignee != "" {
-			filter.Assignee = &assignee
-		}
-		if issueType != "" {
-			t := types.IssueType(issueType)
-			filter.IssueType = &t
-		}
-		if len(labels) > 0 {
-			filter.Labels = labels
-		}
-		if len(labelsAny) > 0 {
-			filter.LabelsAny = labelsAny
-		}
-		if titleSearch != "" {
-			filter.TitleSearch = titleSearch
-		}
-		if idFilter != "" {
-			ids := util.NormalizeLabels(strings.Split(idFilter, ","))
-			if len(ids) > 0 {
-				filter.IDs = ids
-			}
-		}
-
-		// Pattern matching
-		if titleContains != "" {
-			filter.TitleContains = titleContains
-		}
-		if descContains != "" {
-			filter.DescriptionContains = descContains
-		}
-		if notesContains != "" {
-			filter.NotesContains = notesContains
-		}
-
-		// Date ranges
-		if createdAfter != "" {
-			t, err := parseTimeFlag(createdAfter)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --created-after: %v\n", err)
-				os.Exit(1)
-			}
-			filter.CreatedAfter = &t
-		}
-		if createdBefore != "" {
-			t, err := parseTimeFlag(createdBefore)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --created-before: %v\n", err)
-				os.Exit(1)
-			}
-			filter.CreatedBefore = &t
-		}
-		if updatedAfter != "" {
-			t, err := parseTimeFlag(updatedAfter)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --updated-after: %v\n", err)
-				os.Exit(1)
-			}
-			filter.UpdatedAfter = &t
-		}
-		if updatedBefore != "" {
-			t, err := parseTimeFlag(updatedBefore)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --updated-before: %v\n", err)
-				os.Exit(1)
-			}
-			filter.UpdatedBefore = &t
-		}
-		if closedAfter != "" {
-			t, err := parseTimeFlag(closedAfter)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --closed-after: %v\n", err)
-				os.Exit(1)
-			}
-			filter.ClosedAfter = &t
-		}
-		if closedBefore != "" {
-			t, err := parseTimeFlag(closedBefore)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --closed-before: %v\n", err)
-				os.Exit(1)
-			}
-			filter.ClosedBefore = &t
-		}
-
-		// Empty/null checks
-		if emptyDesc {
-			filter.EmptyDescription = true
-		}
-		if noAssignee {
-			filter.NoAssignee = true
-		}
-		if noLabels {
-			filter.NoLabels = true
-		}
-
-		// Priority ranges
-		if cmd.Flags().Changed("priority-min") {
-			priorityMin, err := validation.ValidatePriority(priorityMinStr)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --priority-min: %v\n", err)
-				os.Exit(1)
-			}
-			filter.PriorityMin = &priorityMin
-		}
-		if cmd.Flags().Changed("priority-max") {
-			priorityMax, err := validation.ValidatePriority(priorityMaxStr)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --priority-max: %v\n", err)
-				os.Exit(1)
-			}
-			filter.PriorityMax = &priorityMax
-		}
-
-		// Pinned filtering: --pinned and --no-pinned are mutually exclusive
-		if pinnedFlag && noPinnedFlag {
-			fmt.Fprintf(os.Stderr, "Error: --pinned and --no-pinned are mutually exclusive\n")
-			os.Exit(1)
-		}
-		if pinnedFlag {
-			pinned := true
-			filter.Pinned = &pinned
-		} else if noPinnedFlag {
-			pinned := false
-			filter.Pinned = &pinned
-		}
-
-		// Template filtering: exclude templates by default
-		// Use --include-templates to show all issues including templates
-		if !includeTemplates {
-			isTemplate := false
-			filter.IsTemplate = &isTemplate
-		}
-
-		// Gate filtering: exclude gate issues by default (bd-7zka.2)
-		// Use --include-gates or --type gate to show gate issues
-		if !includeGates && issueType != "gate" {
-			filter.ExcludeTypes = append(filter.ExcludeTypes, "gate")
-		}
-
-		// Parent filtering: filter children by parent issue
-		if parentID != "" {
-			filter.ParentID = &parentID
-		}
-
-		// Molecule type filtering
-		if molType != nil {
-			filter.MolType = molType
-		}
-
-		// Time-based scheduling filters (GH#820)
-		if deferredFlag {
-			filter.Deferred = true
-		}
-		if deferAfter != "" {
-			t, err := parseTimeFlag(deferAfter)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --defer-after: %v\n", err)
-				os.Exit(1)
-			}
-			filter.DeferAfter = &t
-		}
-		if deferBefore != "" {
-			t, err := parseTimeFlag(deferBefore)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --defer-before: %v\n", err)
-				os.Exit(1)
-			}
-			filter.DeferBefore = &t
-		}
-		if dueAfter != "" {
-			t, err := parseTimeFlag(dueAfter)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --due-after: %v\n", err)
-				os.Exit(1)
-			}
-			filter.DueAfter = &t
-		}
-		if dueBefore != "" {
-			t, err := parseTimeFlag(dueBefore)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --due-before: %v\n", err)
-				os.Exit(1)
-			}
-			filter.DueBefore = &t
-		}
-		if overdueFlag {
-			filter.Overdue = true
+		// Multi-repo filtering
+		if sourceRepo != "" {
+			filter.SourceRepo = &sourceRepo
 		}
 
 		// Check database freshness before reading
@@ -872,15 +859,7 @@ var listCmd = &cobra.Command{
 				Assignee:  assignee,
 				Limit:     effectiveLimit,
 			}
-			if cmd.Flags().Changed("priority") {
-				priorityStr, _ := cmd.Flags().GetString("priority")
-				priority, err := validation.ValidatePriority(priorityStr)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-					os.Exit(1)
-				}
-				listArgs.Priority = &priority
-			}
+			listArgs.Priority = filter.Priority
 			if len(labels) > 0 {
 				listArgs.Labels = labels
 			}
@@ -896,9 +875,9 @@ var listCmd = &cobra.Command{
 			}
 
 			// Pattern matching
-			listArgs.TitleContains = titleContains
-			listArgs.DescriptionContains = descContains
-			listArgs.NotesContains = notesContains
+			listArgs.TitleContains = filter.TitleContains
+			listArgs.DescriptionContains = filter.DescriptionContains
+			listArgs.NotesContains = filter.NotesContains
 
 			// Date ranges
 			if filter.CreatedAfter != nil {
@@ -952,6 +931,9 @@ var listCmd = &cobra.Command{
 				}
 			}
 
+			// Multi-repo filtering
+			listArgs.SourceRepo = sourceRepo
+
 			// Time-based scheduling filters (GH#820)
 			listArgs.Deferred = filter.Deferred
 			if filter.DeferAfter != nil {
@@ -991,11 +973,12 @@ var listCmd = &cobra.Command{
 			// Show upgrade notification if needed
 			maybeShowUpgradeNotification()
 
-			var issues []*types.Issue
-			if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			var issuesWithCounts []*types.IssueWithCounts
+			if err := json.Unmarshal(resp.Data, &issuesWithCounts); err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
+			issues := unwrapIssuesWithCounts(issuesWithCounts)
 
 			// Apply sorting
 			sortIssues(issues, sortBy, reverse)
@@ -1187,6 +1170,7 @@ var listCmd = &cobra.Command{
 					Issue:           issue,
 					DependencyCount: counts.DependencyCount,
 					DependentCount:  counts.DependentCount,
+					SourceRepo:      types.ExternalSourceRepo(issue),
 				}
 			}
 			outputJSON(issuesWithCounts)
@@ -1245,67 +1229,15 @@ var listCmd = &cobra.Command{
 }
 
 func init() {
-	listCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, deferred, closed)")
-	registerPriorityFlag(listCmd, "")
-	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
-	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore, merge-request, molecule, gate, convoy). Aliases: mr→merge-request, feat→feature, mol→molecule")
-	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
-	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
-	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
-	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
+	registerListFilterFlags(listCmd)
 	listCmd.Flags().IntP("limit", "n", 50, "Limit results (default 50, use 0 for unlimited)")
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
-	listCmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
 	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
 	listCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 
-	// Pattern matching
-	listCmd.Flags().String("title-contains", "", "Filter by title substring (case-insensitive)")
-	listCmd.Flags().String("desc-contains", "", "Filter by description substring (case-insensitive)")
-	listCmd.Flags().String("notes-contains", "", "Filter by notes substring (case-insensitive)")
-
-	// Date ranges
-	listCmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("created-before", "", "Filter issues created before date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("updated-after", "", "Filter issues updated after date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("updated-before", "", "Filter issues updated before date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("closed-after", "", "Filter issues closed after date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("closed-before", "", "Filter issues closed before date (YYYY-MM-DD or RFC3339)")
-
-	// Empty/null checks
-	listCmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
-	listCmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
-	listCmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
-
-	// Priority ranges
-	listCmd.Flags().String("priority-min", "", "Filter by minimum priority (inclusive, 0-4 or P0-P4)")
-	listCmd.Flags().String("priority-max", "", "Filter by maximum priority (inclusive, 0-4 or P0-P4)")
-
-	// Pinned filtering
-	listCmd.Flags().Bool("pinned", false, "Show only pinned issues")
-	listCmd.Flags().Bool("no-pinned", false, "Exclude pinned issues")
-
-	// Template filtering: exclude templates by default
-	listCmd.Flags().Bool("include-templates", false, "Include template molecules in output")
-
-	// Gate filtering: exclude gate issues by default (bd-7zka.2)
-	listCmd.Flags().Bool("include-gates", false, "Include gate issues in output (normally hidden)")
-
-	// Parent filtering: filter children by parent issue
-	listCmd.Flags().String("parent", "", "Filter by parent issue ID (shows children of specified issue)")
-	listCmd.Flags().String("filter-parent", "", "Alias for --parent")
-
-	// Molecule type filtering
-	listCmd.Flags().String("mol-type", "", "Filter by molecule type: swarm, patrol, or work")
-
-	// Time-based scheduling filters (GH#820)
-	listCmd.Flags().Bool("deferred", false, "Show only issues with defer_until set")
-	listCmd.Flags().String("defer-after", "", "Filter issues deferred after date (supports relative: +6h, tomorrow)")
-	listCmd.Flags().String("defer-before", "", "Filter issues deferred before date (supports relative: +6h, tomorrow)")
-	listCmd.Flags().String("due-after", "", "Filter issues due after date (supports relative: +6h, tomorrow)")
-	listCmd.Flags().String("due-before", "", "Filter issues due before date (supports relative: +6h, tomorrow)")
-	listCmd.Flags().Bool("overdue", false, "Show only issues with due_at in the past (not closed)")
+	// Multi-repo filtering
+	listCmd.Flags().String("repo", "", "Filter by owning repo in multi-repo mode ('.' for primary, or a repos.additional path/name)")
 
 	// Pretty and watch flags (GH#654)
 	listCmd.Flags().Bool("pretty", false, "Display issues in a tree format with status/priority symbols")
@@ -1315,9 +1247,6 @@ func init() {
 	// Pager control (bd-jdz3)
 	listCmd.Flags().Bool("no-pager", false, "Disable pager output")
 
-	// Ready filter: show only issues ready to be worked on (bd-ihu31)
-	listCmd.Flags().Bool("ready", false, "Show only ready issues (status=open, excludes hooked/in_progress/blocked/deferred)")
-
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(listCmd)
 }
@@ -892,3 +892,16 @@ func TestHierarchicalChildren(t *testing.T) {
 		}
 	})
 }
+
+func TestParseDueWithin(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	got, err := parseDueWithin("3d", now)
+	if err != nil || !got.Equal(now.AddDate(0, 0, 3)) {
+		t.Errorf("parseDueWithin(3d) = %v, %v", got, err)
+	}
+	for _, bad := range []string{"-1d", "soon", ""} {
+		if _, err := parseDueWithin(bad, now); err == nil {
+			t.Errorf("parseDueWithin(%q) succeeded, want error", bad)
+		}
+	}
+}
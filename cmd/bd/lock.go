@@ -0,0 +1,429 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/locks"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var lockCmd = &cobra.Command{
+	Use:     "lock",
+	GroupID: "issues",
+	Short:   "Named locks and semaphores for coordinating agents",
+	Long: `Named locks serialize access to shared resources: a deploy slot, a test
+database, the release train. A lock admits up to its capacity of holders at
+once (1 unless set with --capacity); everyone else queues.
+
+The queue is fair: a freed slot goes to the head of the queue, never to a
+newcomer. Under --policy fifo (the default) the queue is first come, first
+served; under --policy priority lower --priority numbers go first, then FIFO.
+
+Holds and queue places are leases that lapse after --ttl (default lock.ttl,
+10m) unless renewed by 'bd lock heartbeat' or 'bd agent heartbeat', so a
+crashed agent cannot keep a lock. When holders wait for each other's locks in
+a cycle, 'bd lock list' reports the deadlock and 'bd lock wait' gives up.
+
+With a daemon running, waiters are woken as soon as a lock is released;
+without one, 'bd lock wait' polls. 'bd merge-slot' is the lock
+<prefix>-merge-slot with capacity 1.
+
+Examples:
+  bd lock acquire deploy                 # Take the deploy lock or exit 1
+  bd lock acquire test-db --capacity 3   # Up to 3 holders at a time
+  bd lock wait deploy --timeout 30m      # Queue and block until acquired
+  bd lock release deploy
+  bd lock list                           # Holders, queues and deadlocks
+  bd lock heartbeat                      # Keep my holds alive`,
+}
+
+var lockAcquireCmd = &cobra.Command{
+	Use:   "acquire <name>",
+	Short: "Acquire a lock without blocking",
+	Long: `Acquire a slot in the named lock. Exits 1 if no slot is free, after joining
+the queue when --queue is given. Acquiring a lock you already hold renews it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("lock acquire")
+		lockArgs := lockAcquireArgsFromFlags(cmd, args[0])
+		lockArgs.Queue, _ = cmd.Flags().GetBool("queue")
+
+		result, err := lockAcquireOnce(rootCtx, lockArgs)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		printLockAcquireResult(result, lockArgs.Queue)
+		if !result.Acquired {
+			os.Exit(1)
+		}
+	},
+}
+
+var lockWaitCmd = &cobra.Command{
+	Use:   "wait <name>",
+	Short: "Queue for a lock and block until it is acquired",
+	Long: `Join the named lock's queue and block until a slot is granted. Gives up,
+leaving the queue, after --timeout or as soon as the wait would deadlock;
+either way it exits 1.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("lock wait")
+		ctx := rootCtx
+		lockArgs := lockAcquireArgsFromFlags(cmd, args[0])
+		lockArgs.Queue = true
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		deadline := time.Now().Add(timeout)
+		var result *rpc.LockAcquireResult
+		for {
+			remaining := time.Until(deadline)
+			if daemonClient != nil {
+				lockArgs.Wait = min(max(remaining, 0), rpc.MaxLockWait)
+			}
+			var err error
+			result, err = lockAcquireOnce(ctx, lockArgs)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if result.Acquired || result.Deadlock != nil || time.Until(deadline) <= 0 {
+				break
+			}
+			if daemonClient == nil {
+				time.Sleep(min(time.Second, time.Until(deadline)))
+			}
+		}
+
+		if !result.Acquired {
+			// Best effort: don't leave a queue place behind that would hold up others
+			_, _ = lockReleaseOnce(ctx, lockArgs.Name, lockArgs.Holder)
+		}
+		printLockAcquireResult(result, false)
+		if !result.Acquired {
+			if result.Deadlock == nil && !jsonOutput {
+				fmt.Printf("  Gave up after %s\n", timeout)
+			}
+			os.Exit(1)
+		}
+	},
+}
+
+var lockReleaseCmd = &cobra.Command{
+	Use:   "release <name>",
+	Short: "Release a lock (or leave its queue)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("lock release")
+		holder := lockHolder(cmd)
+		if _, err := lockReleaseOnce(rootCtx, args[0], holder); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"name": args[0], "holder": holder, "released": true})
+			return
+		}
+		fmt.Printf("%s Released lock %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+var lockListCmd = &cobra.Command{
+	Use:   "list [name]",
+	Short: "Show locks, their holders and queues, and any deadlocks",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		result, err := lockListOnce(rootCtx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(args) == 1 {
+			var filtered []*locks.Lock
+			for _, l := range result.Locks {
+				if l.Name == args[0] {
+					filtered = append(filtered, l)
+				}
+			}
+			result.Locks = filtered
+		}
+		if result.Locks == nil {
+			result.Locks = []*locks.Lock{}
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if len(result.Locks) == 0 {
+			fmt.Println("No locks")
+			return
+		}
+		now := time.Now()
+		for _, l := range result.Locks {
+			fmt.Printf("%s  %d/%d held  %s\n", ui.RenderAccent(l.Name), len(l.Holders), l.Capacity, l.Policy)
+			for _, e := range l.Holders {
+				fmt.Printf("  holder  %-20s  expires %s\n", e.Holder, formatLockExpiry(e, now))
+			}
+			for i, e := range l.Waiters {
+				fmt.Printf("  #%-6d %-20s  P%d, waiting %s\n", i+1, e.Holder, e.Priority, now.Sub(e.Since).Round(time.Second))
+			}
+		}
+		for _, d := range result.Deadlocks {
+			fmt.Printf("%s Deadlock: %s\n", ui.RenderFail("✗"), d)
+		}
+	},
+}
+
+var lockHeartbeatCmd = &cobra.Command{
+	Use:   "heartbeat",
+	Short: "Renew all holds and queue places of a holder",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("lock heartbeat")
+		holder := lockHolder(cmd)
+		ttl, err := lockTTL(cmd)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		var renewed []string
+		if daemonClient != nil {
+			resp, err := daemonClient.LockRenew(&rpc.LockRenewArgs{Holders: []string{holder}, TTL: ttl})
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if err := json.Unmarshal(resp.Data, &renewed); err != nil {
+				FatalErrorRespectJSON("parsing response: %v", err)
+			}
+		} else {
+			if err := ensureStoreActive(); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			renewed, err = locks.Renew(rootCtx, store, []string{holder}, ttl, time.Now())
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		if renewed == nil {
+			renewed = []string{}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"holder": holder, "renewed": renewed})
+			return
+		}
+		if len(renewed) == 0 {
+			fmt.Printf("%s holds no locks\n", holder)
+			return
+		}
+		fmt.Printf("%s Renewed %s for %s\n", ui.RenderPass("✓"), strings.Join(renewed, ", "), ttl)
+	},
+}
+
+func lockAcquireArgsFromFlags(cmd *cobra.Command, name string) rpc.LockAcquireArgs {
+	ttl, err := lockTTL(cmd)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	capacity, _ := cmd.Flags().GetInt("capacity")
+	policy, _ := cmd.Flags().GetString("policy")
+	priority, _ := cmd.Flags().GetInt("priority")
+	if capacity < 0 {
+		FatalErrorRespectJSON("--capacity must be at least 1")
+	}
+	if policy != "" && !locks.Policy(policy).IsValid() {
+		FatalErrorRespectJSON("invalid policy '%s'. Valid values: fifo, priority", policy)
+	}
+	if err := locks.ValidateName(name); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return rpc.LockAcquireArgs{
+		Name:     name,
+		Holder:   lockHolder(cmd),
+		Capacity: capacity,
+		Policy:   policy,
+		Priority: priority,
+		TTL:      ttl,
+	}
+}
+
+// lockAcquireOnce makes one acquire attempt through the daemon or directly.
+// Through the daemon, args.Wait lets the attempt block until woken.
+func lockAcquireOnce(ctx context.Context, args rpc.LockAcquireArgs) (*rpc.LockAcquireResult, error) {
+	var result rpc.LockAcquireResult
+	if daemonClient != nil {
+		resp, err := daemonClient.LockAcquire(&args)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		return &result, nil
+	}
+
+	if err := ensureStoreActive(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	res, err := locks.Acquire(ctx, store, locks.Request{
+		Name:     args.Name,
+		Holder:   args.Holder,
+		Capacity: args.Capacity,
+		Policy:   locks.Policy(args.Policy),
+		Priority: args.Priority,
+		TTL:      args.TTL,
+		Queue:    args.Queue,
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	result.Result = *res
+	if !res.Acquired {
+		list, err := locks.List(ctx, store, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range locks.FindDeadlocks(list) {
+			if d.Involves(args.Holder) {
+				result.Deadlock = &d
+				break
+			}
+		}
+	}
+	return &result, nil
+}
+
+// lockReleaseOnce releases holder's slot or queue place and returns the
+// lock as left behind.
+func lockReleaseOnce(ctx context.Context, name, holder string) (*locks.Lock, error) {
+	if daemonClient != nil {
+		resp, err := daemonClient.LockRelease(&rpc.LockReleaseArgs{Name: name, Holder: holder})
+		if err != nil {
+			return nil, err
+		}
+		var l locks.Lock
+		if err := json.Unmarshal(resp.Data, &l); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		return &l, nil
+	}
+	if err := ensureStoreActive(); err != nil {
+		return nil, err
+	}
+	l, err := locks.Release(ctx, store, name, holder, time.Now())
+	if err != nil {
+		if errors.Is(err, locks.ErrNotHeld) {
+			return nil, fmt.Errorf("%s does not hold or await lock %s", holder, name)
+		}
+		return nil, fmt.Errorf("failed to release lock: %w", err)
+	}
+	return l, nil
+}
+
+// lockListOnce lists all locks and deadlocks through the daemon or directly.
+func lockListOnce(ctx context.Context) (*rpc.LockListResult, error) {
+	var result rpc.LockListResult
+	if daemonClient != nil {
+		resp, err := daemonClient.LockList()
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		return &result, nil
+	}
+	if err := ensureStoreActive(); err != nil {
+		return nil, err
+	}
+	list, err := locks.List(ctx, store, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.LockListResult{Locks: list, Deadlocks: locks.FindDeadlocks(list)}, nil
+}
+
+// printLockAcquireResult reports an acquire attempt. inQueue says whether
+// the holder kept its place in the queue.
+func printLockAcquireResult(result *rpc.LockAcquireResult, inQueue bool) {
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	l := result.Lock
+	if result.Acquired {
+		fmt.Printf("%s Acquired lock %s (%d/%d held)\n", ui.RenderPass("✓"), l.Name, len(l.Holders), l.Capacity)
+		return
+	}
+	var holders []string
+	for _, e := range l.Holders {
+		holders = append(holders, e.Holder)
+	}
+	fmt.Printf("%s Lock %s is held by %s\n", ui.RenderFail("✗"), l.Name, strings.Join(holders, ", "))
+	if result.Deadlock != nil {
+		fmt.Printf("  Deadlock: %s\n", result.Deadlock)
+	}
+	if inQueue && result.Position > 0 {
+		fmt.Printf("  Queued at position %d\n", result.Position)
+	}
+}
+
+// lockHolder returns --holder, or the actor.
+func lockHolder(cmd *cobra.Command) string {
+	if holder, _ := cmd.Flags().GetString("holder"); holder != "" {
+		return holder
+	}
+	return actor
+}
+
+// lockTTL returns --ttl, or lock.ttl from config.
+func lockTTL(cmd *cobra.Command) (time.Duration, error) {
+	value, _ := cmd.Flags().GetString("ttl")
+	if value == "" {
+		return configuredLockTTL(), nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("--ttl must be a positive duration like 10m or 1h, got %q", value)
+	}
+	return ttl, nil
+}
+
+// configuredLockTTL returns lock.ttl, or the default if it is unset or invalid.
+func configuredLockTTL() time.Duration {
+	ttl, err := time.ParseDuration(config.GetString("lock.ttl"))
+	if err != nil || ttl <= 0 {
+		return locks.DefaultTTL
+	}
+	return ttl
+}
+
+func formatLockExpiry(e *locks.Entry, now time.Time) string {
+	return fmt.Sprintf("in %s", e.ExpiresAt.Sub(now).Round(time.Second))
+}
+
+func init() {
+	for _, c := range []*cobra.Command{lockAcquireCmd, lockWaitCmd} {
+		c.Flags().Int("capacity", 0, "Set the lock's number of slots (new locks: 1)")
+		c.Flags().String("policy", "", "Set the queue policy: fifo or priority (new locks: fifo)")
+		c.Flags().IntP("priority", "p", locks.DefaultPriority, "Queue priority under the priority policy (0 = highest)")
+	}
+	for _, c := range []*cobra.Command{lockAcquireCmd, lockWaitCmd, lockHeartbeatCmd} {
+		c.Flags().String("ttl", "", "Lease length, e.g. 10m or 1h (default: lock.ttl)")
+	}
+	for _, c := range []*cobra.Command{lockAcquireCmd, lockWaitCmd, lockReleaseCmd, lockHeartbeatCmd} {
+		c.Flags().String("holder", "", "Act on behalf of this holder (default: actor)")
+	}
+	lockAcquireCmd.Flags().Bool("queue", false, "Join the queue if no slot is free")
+	lockWaitCmd.Flags().Duration("timeout", 10*time.Minute, "Give up after this long")
+
+	lockCmd.AddCommand(lockAcquireCmd, lockWaitCmd, lockReleaseCmd, lockListCmd, lockHeartbeatCmd)
+	rootCmd.AddCommand(lockCmd)
+}
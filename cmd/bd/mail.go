@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
 )
 
 // mailCmd delegates to an external mail provider.
@@ -88,6 +90,13 @@ Examples:
 // findMailDelegate checks for mail delegation configuration
 // Priority: env vars > bd config
 func findMailDelegate() string {
+	// This works even without a database connection since we use direct mode
+	return mailDelegate(rootCtx, store)
+}
+
+// mailDelegate resolves the mail delegate from the environment or, when s
+// is non-nil, the mail.delegate config setting.
+func mailDelegate(ctx context.Context, s storage.Storage) string {
 	// Check environment variables first
 	if delegate := os.Getenv("BEADS_MAIL_DELEGATE"); delegate != "" {
 		return delegate
@@ -97,9 +106,8 @@ func findMailDelegate() string {
 	}
 
 	// Check bd config (requires database)
-	// This works even without a database connection since we use direct mode
-	if store != nil {
-		if delegate, err := store.GetConfig(rootCtx, "mail.delegate"); err == nil && delegate != "" {
+	if s != nil {
+		if delegate, err := s.GetConfig(ctx, "mail.delegate"); err == nil && delegate != "" {
 			return delegate
 		}
 	}
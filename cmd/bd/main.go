@@ -345,6 +345,7 @@ var rootCmd = &cobra.Command{
 			"hooks",
 			"human",
 			"init",
+			"mcp",
 			"merge",
 			"onboard",
 			"powershell",
@@ -548,6 +549,10 @@ var rootCmd = &cobra.Command{
 		// Set actor for audit trail
 		actor = getActorWithGit()
 
+		// Tag mutations with the actor and agent session for bd undo
+		rootCtx = storage.WithSession(rootCtx, actor, os.Getenv("CLAUDE_SESSION_ID"))
+		rootCtx = storage.WithCommand(rootCtx, storage.NewCommandID())
+
 		// Track bd version changes
 		// Best-effort tracking - failures are silent
 		trackBdVersion()
@@ -633,6 +638,7 @@ var rootCmd = &cobra.Command{
 								health, healthErr = client.Health()
 								if healthErr == nil && health.Status == statusHealthy {
 									client.SetActor(actor)
+									client.SetSession(os.Getenv("CLAUDE_SESSION_ID"), storage.CommandFromContext(rootCtx))
 									daemonClient = client
 									daemonStatus.Mode = cmdDaemon
 									daemonStatus.Connected = true
@@ -651,6 +657,7 @@ var rootCmd = &cobra.Command{
 					} else {
 						// Daemon is healthy and compatible - use it
 						client.SetActor(actor)
+						client.SetSession(os.Getenv("CLAUDE_SESSION_ID"), storage.CommandFromContext(rootCtx))
 						daemonClient = client
 						daemonStatus.Mode = cmdDaemon
 						daemonStatus.Connected = true
@@ -702,6 +709,7 @@ var rootCmd = &cobra.Command{
 						health, healthErr := client.Health()
 						if healthErr == nil && health.Status == statusHealthy {
 							client.SetActor(actor)
+							client.SetSession(os.Getenv("CLAUDE_SESSION_ID"), storage.CommandFromContext(rootCtx))
 							daemonClient = client
 							daemonStatus.Mode = cmdDaemon
 							daemonStatus.Connected = true
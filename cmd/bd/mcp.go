@@ -0,0 +1,499 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/mcp"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/factory"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:     "mcp",
+	GroupID: "setup",
+	Short:   "Model Context Protocol server for agent hosts",
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve beads tools over MCP (stdio, or streamable HTTP with --http)",
+	Long: `Run a Model Context Protocol server exposing beads as tools:
+ready, list, show, create, update, close, dep, dep_remove, label_add,
+label_remove, comment and comments.
+
+Tool arguments mirror the daemon's RPC arguments. Every tool also accepts
+workspace_root, a directory inside the project to act on; the .beads
+directory is found from it the same way bd finds it from the current
+directory. Without it, the directory bd mcp serve was started in is used.
+
+Calls go through the workspace's daemon when one is running. Otherwise
+bd opens the database itself and exports JSONL after every change.
+
+By default the server speaks newline-delimited JSON-RPC on stdin/stdout.
+With --http it serves streamable HTTP on the given address instead
+(POST only; bind to localhost unless you mean to expose it).
+
+Example MCP client configuration:
+  {"mcpServers": {"beads": {"command": "bd", "args": ["mcp", "serve"]}}}`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		httpAddr, _ := cmd.Flags().GetString("http")
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if actor == "" {
+			actor = getActorWithGit()
+		}
+		router := newMCPRouter(rootCtx, cwd, actor)
+		defer router.close()
+		server := newBeadsMCPServer(router)
+
+		ctx := rootCtx
+		if httpAddr == "" {
+			if err := server.ServeStdio(ctx, os.Stdin, os.Stdout); err != nil && !errors.Is(err, context.Canceled) {
+				FatalError("mcp: %v", err)
+			}
+			return
+		}
+
+		listener, err := net.Listen("tcp", httpAddr)
+		if err != nil {
+			FatalError("mcp: %v", err)
+		}
+		httpServer := &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = httpServer.Shutdown(shutdownCtx)
+		}()
+		fmt.Fprintf(os.Stderr, "bd MCP server listening on http://%s\n", listener.Addr())
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			FatalError("mcp: %v", err)
+		}
+	},
+}
+
+// mcpToolSpec maps an MCP tool onto an RPC operation and its argument struct.
+type mcpToolSpec struct {
+	name         string
+	description  string
+	op           string
+	args         interface{} // Zero value of the rpc *Args struct
+	fields       []string    // Exposed argument names (all when empty)
+	optional     []string    // Required in the struct, but defaulted here
+	descriptions map[string]string
+	idFields     []string // Partial IDs resolved before the call
+	mutates      bool
+	defaults     func(args map[string]interface{}, actor string)
+}
+
+var mcpTools = []mcpToolSpec{
+	{
+		name:        "ready",
+		description: "Find open issues with no blockers, ready to be worked on.",
+		op:          rpc.OpReady,
+		args:        rpc.ReadyArgs{},
+		fields:      []string{"assignee", "unassigned", "priority", "type", "limit", "sort_policy", "labels", "labels_any", "parent_id", "include_deferred"},
+		descriptions: map[string]string{
+			"limit":       "Maximum issues to return (default 10)",
+			"sort_policy": "hybrid (default), priority, oldest or due",
+			"labels":      "Must have all of these labels",
+			"labels_any":  "Must have at least one of these labels",
+			"parent_id":   "Only descendants of this issue",
+		},
+		defaults: func(args map[string]interface{}, _ string) {
+			setDefault(args, "limit", 10)
+		},
+	},
+	{
+		name:        "list",
+		description: "List issues matching filters. Closed issues are excluded unless status is given.",
+		op:          rpc.OpList,
+		args:        rpc.ListArgs{},
+		fields: []string{"query", "status", "priority", "issue_type", "assignee", "labels", "labels_any", "ids", "limit",
+			"title_contains", "parent_id", "no_assignee", "overdue", "due_before", "due_after", "created_after", "updated_after"},
+		descriptions: map[string]string{
+			"query":  "Search text in titles and IDs",
+			"status": "open, in_progress, blocked, deferred or closed",
+			"limit":  "Maximum issues to return (default 20)",
+		},
+		defaults: func(args map[string]interface{}, _ string) {
+			setDefault(args, "limit", 20)
+			if _, ok := args["status"]; !ok {
+				setDefault(args, "exclude_status", []string{"closed"})
+			}
+		},
+	},
+	{
+		name:         "show",
+		description:  "Show an issue with its labels, dependencies and dependents.",
+		op:           rpc.OpShow,
+		args:         rpc.ShowArgs{},
+		descriptions: map[string]string{"id": "Issue ID (a unique prefix is enough)"},
+		idFields:     []string{"id"},
+	},
+	{
+		name:        "create",
+		description: "Create an issue.",
+		op:          rpc.OpCreate,
+		args:        rpc.CreateArgs{},
+		fields: []string{"id", "parent", "title", "description", "issue_type", "priority", "design", "acceptance_criteria",
+			"notes", "assignee", "external_ref", "estimated_minutes", "labels", "dependencies", "due_at", "defer_until"},
+		optional: []string{"issue_type", "priority"},
+		descriptions: map[string]string{
+			"issue_type":   "bug, feature, task (default), epic or chore",
+			"priority":     "0 (critical) to 4 (backlog), default 2",
+			"parent":       "Create as a child of this issue",
+			"dependencies": "Issues this one depends on, as 'id' or 'type:id' (e.g. discovered-from:bd-1)",
+			"due_at":       "Due date: ISO date or relative (+3d, tomorrow)",
+		},
+		mutates: true,
+		defaults: func(args map[string]interface{}, _ string) {
+			setDefault(args, "issue_type", "task")
+			setDefault(args, "priority", 2)
+		},
+	},
+	{
+		name:        "update",
+		description: "Update an issue's fields. Set claim to atomically take unassigned work.",
+		op:          rpc.OpUpdate,
+		args:        rpc.UpdateArgs{},
+		fields: []string{"id", "title", "description", "status", "priority", "design", "acceptance_criteria", "notes",
+			"assignee", "external_ref", "estimated_minutes", "issue_type", "add_labels", "remove_labels", "parent", "claim", "due_at", "defer_until"},
+		descriptions: map[string]string{
+			"id":    "Issue ID (a unique prefix is enough)",
+			"claim": "Assign to you and set in_progress; fails if already claimed",
+		},
+		idFields: []string{"id"},
+		mutates:  true,
+	},
+	{
+		name:         "close",
+		description:  "Close an issue as done.",
+		op:           rpc.OpClose,
+		args:         rpc.CloseArgs{},
+		fields:       []string{"id", "reason", "suggest_next", "force"},
+		descriptions: map[string]string{"suggest_next": "Also return issues unblocked by this close", "force": "Close even with open blockers"},
+		idFields:     []string{"id"},
+		mutates:      true,
+	},
+	{
+		name:        "dep",
+		description: "Add a dependency: from_id depends on to_id.",
+		op:          rpc.OpDepAdd,
+		args:        rpc.DepAddArgs{},
+		optional:    []string{"dep_type"},
+		descriptions: map[string]string{
+			"dep_type": "blocks (default), related, parent-child or discovered-from",
+		},
+		idFields: []string{"from_id", "to_id"},
+		mutates:  true,
+		defaults: func(args map[string]interface{}, _ string) {
+			setDefault(args, "dep_type", "blocks")
+		},
+	},
+	{
+		name:        "dep_remove",
+		description: "Remove a dependency between two issues.",
+		op:          rpc.OpDepRemove,
+		args:        rpc.DepRemoveArgs{},
+		idFields:    []string{"from_id", "to_id"},
+		mutates:     true,
+	},
+	{
+		name:        "label_add",
+		description: "Add a label to an issue.",
+		op:          rpc.OpLabelAdd,
+		args:        rpc.LabelAddArgs{},
+		idFields:    []string{"id"},
+		mutates:     true,
+	},
+	{
+		name:        "label_remove",
+		description: "Remove a label from an issue.",
+		op:          rpc.OpLabelRemove,
+		args:        rpc.LabelRemoveArgs{},
+		idFields:    []string{"id"},
+		mutates:     true,
+	},
+	{
+		name:        "comment",
+		description: "Add a comment to an issue.",
+		op:          rpc.OpCommentAdd,
+		args:        rpc.CommentAddArgs{},
+		optional:    []string{"author"},
+		descriptions: map[string]string{
+			"author": "Comment author (default: the bd actor)",
+		},
+		idFields: []string{"id"},
+		mutates:  true,
+		defaults: func(args map[string]interface{}, actor string) {
+			setDefault(args, "author", actor)
+		},
+	},
+	{
+		name:        "comments",
+		description: "List the comments on an issue.",
+		op:          rpc.OpCommentList,
+		args:        rpc.CommentListArgs{},
+		idFields:    []string{"id"},
+	},
+}
+
+func setDefault(args map[string]interface{}, key string, value interface{}) {
+	if _, ok := args[key]; !ok {
+		args[key] = value
+	}
+}
+
+// newBeadsMCPServer registers every tool in mcpTools against router.
+func newBeadsMCPServer(router *mcpRouter) *mcp.Server {
+	server := mcp.NewServer("beads", Version)
+	workspaceRoot := map[string]map[string]interface{}{
+		"workspace_root": {"type": "string", "description": "Directory in the project to act on (default: where the server started)"},
+	}
+	for i := range mcpTools {
+		spec := &mcpTools[i]
+		server.AddTool(&mcp.Tool{
+			Name:        spec.name,
+			Description: spec.description,
+			InputSchema: mcp.SchemaFor(spec.args, mcp.SchemaOptions{
+				Fields:       spec.fields,
+				Optional:     spec.optional,
+				Descriptions: spec.descriptions,
+				Extra:        workspaceRoot,
+			}),
+			Handler: func(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+				return router.call(spec, raw)
+			},
+		})
+	}
+	return server
+}
+
+// mcpRouter sends tool calls to the workspace each one names.
+type mcpRouter struct {
+	ctx         context.Context
+	defaultRoot string
+	actor       string
+
+	mu         sync.Mutex
+	workspaces map[string]*mcpWorkspace // By .beads directory
+}
+
+func newMCPRouter(ctx context.Context, defaultRoot, actor string) *mcpRouter {
+	return &mcpRouter{ctx: ctx, defaultRoot: defaultRoot, actor: actor, workspaces: make(map[string]*mcpWorkspace)}
+}
+
+func (r *mcpRouter) call(spec *mcpToolSpec, raw json.RawMessage) (interface{}, error) {
+	var args map[string]interface{}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args == nil {
+		args = make(map[string]interface{})
+	}
+	root := r.defaultRoot
+	if v, ok := args["workspace_root"].(string); ok && v != "" {
+		root = v
+	}
+	delete(args, "workspace_root")
+
+	ws, err := r.workspace(root)
+	if err != nil {
+		return nil, err
+	}
+	if spec.defaults != nil {
+		spec.defaults(args, r.actor)
+	}
+	for _, field := range spec.idFields {
+		if id, ok := args[field].(string); ok && id != "" {
+			resolved, err := ws.resolveID(id, r.actor)
+			if err != nil {
+				return nil, err
+			}
+			args[field] = resolved
+		}
+	}
+
+	// Decode into the RPC struct so unknown or mistyped arguments are
+	// reported rather than silently dropped
+	encoded, _ := json.Marshal(args)
+	target := reflect.New(reflect.TypeOf(spec.args)).Interface()
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(target); err != nil {
+		return nil, fmt.Errorf("invalid arguments for %s: %w", spec.name, err)
+	}
+
+	data, err := ws.execute(spec.op, target, r.actor)
+	if err != nil {
+		return nil, err
+	}
+	if spec.mutates {
+		if err := ws.afterMutation(r.actor); err != nil {
+			return nil, fmt.Errorf("%s succeeded but exporting JSONL failed: %w", spec.name, err)
+		}
+	}
+	if len(data) == 0 {
+		return "ok", nil
+	}
+	return data, nil
+}
+
+// workspace returns the cached backend for the project containing root.
+func (r *mcpRouter) workspace(root string) (*mcpWorkspace, error) {
+	beadsDir := beads.FindBeadsDirFrom(root)
+	if beadsDir == "" {
+		return nil, fmt.Errorf("no beads project found at %s (run 'bd init' there, or pass workspace_root)", root)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ws := r.workspaces[beadsDir]; ws != nil {
+		return ws, nil
+	}
+	ws, err := openMCPWorkspace(r.ctx, beadsDir, r.actor)
+	if err != nil {
+		return nil, err
+	}
+	r.workspaces[beadsDir] = ws
+	return ws, nil
+}
+
+func (r *mcpRouter) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for dir, ws := range r.workspaces {
+		ws.close()
+		delete(r.workspaces, dir)
+	}
+}
+
+// mcpWorkspace executes RPC operations for one project: through its daemon
+// when one is running, otherwise with the daemon's handlers in-process.
+type mcpWorkspace struct {
+	beadsDir string
+	root     string
+
+	mu        sync.Mutex // The daemon client holds a single connection
+	client    *rpc.Client
+	local     *rpc.Server
+	store     storage.Storage
+	jsonlPath string
+}
+
+func openMCPWorkspace(ctx context.Context, beadsDir, actor string) (*mcpWorkspace, error) {
+	root := filepath.Dir(beadsDir)
+	ws := &mcpWorkspace{beadsDir: beadsDir, root: root}
+
+	socketPath := rpc.ShortSocketPath(root)
+	if env := os.Getenv("BD_SOCKET"); env != "" {
+		socketPath = env
+	}
+	dbPath := beads.FindDatabaseInBeadsDir(beadsDir)
+	if client, err := rpc.TryConnect(socketPath); err == nil && client != nil {
+		client.SetActor(actor)
+		client.SetDatabasePath(dbPath)
+		ws.client = client
+		return ws, nil
+	}
+
+	if dbPath == "" {
+		return nil, fmt.Errorf("no database in %s (run 'bd init' or 'bd import')", beadsDir)
+	}
+	s, err := factory.NewFromConfig(ctx, beadsDir)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", dbPath, err)
+	}
+	ws.store = s
+	ws.local = rpc.NewServer("", s, root, dbPath)
+	ws.jsonlPath = beads.FindJSONLPath(dbPath)
+	return ws, nil
+}
+
+func (w *mcpWorkspace) execute(op string, args interface{}, actor string) (json.RawMessage, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.client != nil {
+		resp, err := w.client.ExecuteWithCwd(op, args, w.root)
+		if err != nil {
+			if resp != nil && resp.Error != "" {
+				return nil, errors.New(resp.Error)
+			}
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	resp := w.local.Handle(&rpc.Request{
+		Operation:     op,
+		Args:          argsJSON,
+		Actor:         actor,
+		ClientVersion: rpc.ClientVersion,
+		Cwd:           w.root,
+		ExpectedDB:    w.store.Path(),
+	})
+	if !resp.Success {
+		return nil, errors.New(resp.Error)
+	}
+	return resp.Data, nil
+}
+
+func (w *mcpWorkspace) resolveID(id, actor string) (string, error) {
+	data, err := w.execute(rpc.OpResolveID, &rpc.ResolveIDArgs{ID: id}, actor)
+	if err != nil {
+		return "", err
+	}
+	var resolved string
+	if err := json.Unmarshal(data, &resolved); err != nil {
+		return "", fmt.Errorf("resolving %s: %w", id, err)
+	}
+	return resolved, nil
+}
+
+// afterMutation exports JSONL when there is no daemon to do it.
+func (w *mcpWorkspace) afterMutation(actor string) error {
+	if w.local == nil || w.jsonlPath == "" {
+		return nil
+	}
+	_, err := w.execute(rpc.OpExport, &rpc.ExportArgs{JSONLPath: w.jsonlPath}, actor)
+	return err
+}
+
+func (w *mcpWorkspace) close() {
+	if w.client != nil {
+		_ = w.client.Close()
+	}
+	if w.store != nil {
+		_ = w.store.Close()
+	}
+}
+
+func init() {
+	mcpServeCmd.Flags().String("http", "", "Serve streamable HTTP on this address (e.g., 127.0.0.1:8765) instead of stdio")
+	mcpCmd.AddCommand(mcpServeCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
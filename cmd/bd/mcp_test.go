@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/mcp"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMCPToolSchemasMatchRPCArgs(t *testing.T) {
+	for _, spec := range mcpTools {
+		props := mcp.SchemaFor(spec.args, mcp.SchemaOptions{})["properties"].(map[string]interface{})
+		for _, f := range append(append([]string{}, spec.fields...), spec.idFields...) {
+			if _, ok := props[f]; !ok {
+				t.Errorf("%s: field %q is not in %T", spec.name, f, spec.args)
+			}
+		}
+	}
+}
+
+func mcpCall(t *testing.T, server *mcp.Server, tool string, args map[string]interface{}) (string, bool) {
+	t.Helper()
+	msg, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+		"params": map[string]interface{}{"name": tool, "arguments": args},
+	})
+	var resp struct {
+		Result mcp.CallToolResult `json:"result"`
+	}
+	if err := json.Unmarshal(server.HandleMessage(context.Background(), msg), &resp); err != nil {
+		t.Fatalf("%s: %v", tool, err)
+	}
+	if len(resp.Result.Content) == 0 {
+		t.Fatalf("%s: empty result", tool)
+	}
+	return resp.Result.Content[0].Text, resp.Result.IsError
+}
+
+func TestMCPServeLocalWorkspace(t *testing.T) {
+	root := t.TempDir()
+	beadsDir := filepath.Join(root, ".beads")
+	s := newTestStore(t, filepath.Join(beadsDir, "beads.db"))
+	s.Close()
+	t.Setenv("BD_SOCKET", filepath.Join(root, "no-daemon.sock"))
+
+	router := newMCPRouter(context.Background(), root, "tester")
+	defer router.close()
+	server := newBeadsMCPServer(router)
+
+	text, isErr := mcpCall(t, server, "create", map[string]interface{}{"title": "Wire up MCP", "labels": []string{"mcp"}})
+	if isErr {
+		t.Fatalf("create: %s", text)
+	}
+	var created types.Issue
+	if err := json.Unmarshal([]byte(text), &created); err != nil {
+		t.Fatalf("create result %q: %v", text, err)
+	}
+	if created.IssueType != types.TypeTask || created.Priority != 2 {
+		t.Errorf("defaults not applied: type=%s priority=%d", created.IssueType, created.Priority)
+	}
+
+	// Partial IDs resolve, and workspace_root may point anywhere inside the project
+	sub := filepath.Join(root, "src")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	partial := strings.TrimPrefix(created.ID, "test-")
+	if text, isErr = mcpCall(t, server, "update", map[string]interface{}{"id": partial, "status": "in_progress", "workspace_root": sub}); isErr {
+		t.Fatalf("update: %s", text)
+	}
+	if text, isErr = mcpCall(t, server, "comment", map[string]interface{}{"id": created.ID, "text": "started"}); isErr {
+		t.Fatalf("comment: %s", text)
+	}
+	if text, _ = mcpCall(t, server, "comments", map[string]interface{}{"id": created.ID}); !strings.Contains(text, `"author":"tester"`) {
+		t.Errorf("comments = %s, want author defaulted to the actor", text)
+	}
+
+	if text, isErr = mcpCall(t, server, "close", map[string]interface{}{"id": created.ID, "reason": "done"}); isErr {
+		t.Fatalf("close: %s", text)
+	}
+	if text, _ = mcpCall(t, server, "list", nil); strings.Contains(text, created.ID) {
+		t.Errorf("list without status should exclude closed issues: %s", text)
+	}
+	if text, _ = mcpCall(t, server, "list", map[string]interface{}{"status": "closed"}); !strings.Contains(text, created.ID) {
+		t.Errorf("list status=closed = %s", text)
+	}
+
+	if text, isErr = mcpCall(t, server, "show", map[string]interface{}{"id": created.ID, "bogus": true}); !isErr || !strings.Contains(text, "bogus") {
+		t.Errorf("unknown argument should be rejected, got %s", text)
+	}
+	if _, isErr = mcpCall(t, server, "ready", map[string]interface{}{"workspace_root": t.TempDir()}); !isErr {
+		t.Error("ready outside any project should fail")
+	}
+
+	// With no daemon, every change is exported to JSONL
+	data, err := os.ReadFile(filepath.Join(beadsDir, "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("JSONL not exported: %v", err)
+	}
+	if !strings.Contains(string(data), created.ID) || !strings.Contains(string(data), `"status":"closed"`) {
+		t.Errorf("JSONL = %s", data)
+	}
+}
@@ -35,6 +35,11 @@ Designed to work as a git merge driver. Configure with:
   git config merge.beads.name "bd JSONL merge driver"
   echo ".beads/issues.jsonl merge=beads" >> .gitattributes
 
+In the sharded layout ('bd migrate sharded') each shard is an ordinary JSONL
+file and is merged the same way; the attribute pattern becomes:
+
+  .beads/issues/**/*.jsonl merge=beads
+
 Or use 'bd init' which automatically configures the merge driver.
 
 Exit codes:
@@ -1,17 +1,15 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/locks"
 	"github.com/steveyegge/beads/internal/rpc"
-	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
-	"github.com/steveyegge/beads/internal/utils"
 )
 
 // mergeSlotCmd is the parent command for merge-slot operations
@@ -25,31 +23,28 @@ A merge slot is an exclusive access primitive: only one agent can hold it at a t
 This prevents "monkey knife fights" where multiple polecats race to resolve conflicts
 and create cascading conflicts.
 
-Each rig has one merge slot bead: <prefix>-merge-slot (labeled gt:slot).
-The slot uses:
-  - status=open: slot is available
-  - status=in_progress: slot is held
-  - holder field: who currently holds the slot
-  - waiters field: priority-ordered queue of waiters
+Each rig has one merge slot: the named lock <prefix>-merge-slot with capacity 1
+(see 'bd lock'). Holding it is a lease that lapses after --ttl (default
+lock.ttl) unless renewed with 'bd lock heartbeat' or 'bd agent heartbeat', and
+waiters are served first come, first served.
 
 Examples:
-  bd merge-slot create              # Create merge slot for current rig
   bd merge-slot check               # Check if slot is available
   bd merge-slot acquire             # Try to acquire the slot
+  bd merge-slot acquire --wait      # Join the queue if the slot is held
   bd merge-slot release             # Release the slot
-  bd merge-slot wait                # Wait for slot to become available`,
+  bd lock wait <prefix>-merge-slot  # Block until the slot is acquired`,
 }
 
-// mergeSlotCreateCmd creates a merge slot bead for the current rig
+// mergeSlotCreateCmd is kept for scripts written against slot beads
 var mergeSlotCreateCmd = &cobra.Command{
 	Use:   "create",
-	Short: "Create a merge slot bead for the current rig",
-	Long: `Create a merge slot bead for serialized conflict resolution.
-
-The slot ID is automatically generated based on the beads prefix (e.g., gt-merge-slot).
-The slot is created with status=open (available).`,
+	Short: "Show the merge slot for the current rig (no setup is needed)",
+	Long: `Merge slots are named locks and exist as soon as they are acquired, so
+there is nothing to create. This prints the slot name for scripts that still
+run 'bd merge-slot create' first.`,
 	Args: cobra.NoArgs,
-	RunE: runMergeSlotCreate,
+	Run:  runMergeSlotCreate,
 }
 
 // mergeSlotCheckCmd checks the current merge slot status
@@ -60,10 +55,9 @@ var mergeSlotCheckCmd = &cobra.Command{
 
 Returns:
   - available: slot can be acquired
-  - held by <holder>: slot is currently held
-  - not found: no merge slot exists for this rig`,
+  - held by <holder>: slot is currently held, with the queue of waiters`,
 	Args: cobra.NoArgs,
-	RunE: runMergeSlotCheck,
+	Run:  runMergeSlotCheck,
 }
 
 // mergeSlotAcquireCmd attempts to acquire the merge slot
@@ -72,39 +66,30 @@ var mergeSlotAcquireCmd = &cobra.Command{
 	Short: "Acquire the merge slot",
 	Long: `Attempt to acquire the merge slot for exclusive access.
 
-If the slot is available (status=open), it will be acquired:
-  - status set to in_progress
-  - holder set to the requester
-
-If the slot is held (status=in_progress), the command fails and the
-requester is optionally added to the waiters list (use --wait flag).
+If the slot is free and nobody is queued ahead, it is acquired. Otherwise the
+command exits 1, after joining the queue when --wait is given. Acquiring a
+slot you already hold renews it.
 
-Use --holder to specify who is acquiring (default: BD_ACTOR env var).`,
+Use --holder to specify who is acquiring (default: actor).`,
 	Args: cobra.NoArgs,
-	RunE: runMergeSlotAcquire,
+	Run:  runMergeSlotAcquire,
 }
 
 // mergeSlotReleaseCmd releases the merge slot
 var mergeSlotReleaseCmd = &cobra.Command{
 	Use:   "release",
 	Short: "Release the merge slot",
-	Long: `Release the merge slot after conflict resolution is complete.
-
-Sets status back to open and clears the holder field.
-If there are waiters, the highest-priority waiter should then acquire.`,
+	Long: `Release the merge slot after conflict resolution is complete, or leave
+its queue. The head of the queue can then acquire it.`,
 	Args: cobra.NoArgs,
-	RunE: runMergeSlotRelease,
+	Run:  runMergeSlotRelease,
 }
 
-var (
-	mergeSlotHolder    string
-	mergeSlotAddWaiter bool
-)
-
 func init() {
-	mergeSlotAcquireCmd.Flags().StringVar(&mergeSlotHolder, "holder", "", "Who is acquiring the slot (default: BD_ACTOR)")
-	mergeSlotAcquireCmd.Flags().BoolVar(&mergeSlotAddWaiter, "wait", false, "Add to waiters list if slot is held")
-	mergeSlotReleaseCmd.Flags().StringVar(&mergeSlotHolder, "holder", "", "Who is releasing the slot (for verification)")
+	mergeSlotAcquireCmd.Flags().Bool("wait", false, "Join the queue if the slot is held")
+	mergeSlotAcquireCmd.Flags().String("ttl", "", "Lease length, e.g. 10m or 1h (default: lock.ttl)")
+	mergeSlotAcquireCmd.Flags().String("holder", "", "Who is acquiring the slot (default: actor)")
+	mergeSlotReleaseCmd.Flags().String("holder", "", "Who is releasing the slot (default: actor)")
 
 	mergeSlotCmd.AddCommand(mergeSlotCreateCmd)
 	mergeSlotCmd.AddCommand(mergeSlotCheckCmd)
@@ -113,7 +98,7 @@ func init() {
 	rootCmd.AddCommand(mergeSlotCmd)
 }
 
-// getMergeSlotID returns the merge slot bead ID for the current rig
+// getMergeSlotID returns the merge slot lock name for the current rig
 func getMergeSlotID() string {
 	// Use the prefix from beads config (default "bd")
 	prefix := "bd"
@@ -135,414 +120,158 @@ func getMergeSlotID() string {
 	return prefix + "-merge-slot"
 }
 
-func runMergeSlotCreate(cmd *cobra.Command, args []string) error {
-	CheckReadonly("merge-slot create")
-
-	slotID := getMergeSlotID()
-	ctx := rootCtx
-
-	// Check if slot already exists
-	var existing *types.Issue
-	if daemonClient != nil {
-		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: slotID})
-		if err == nil && resp.Success {
-			if uerr := json.Unmarshal(resp.Data, &existing); uerr == nil {
-				fmt.Printf("Merge slot already exists: %s\n", slotID)
-				return nil
-			}
-		}
-	} else {
-		existing, _ = store.GetIssue(ctx, slotID)
-		if existing != nil {
-			fmt.Printf("Merge slot already exists: %s\n", slotID)
-			return nil
+// mergeSlotState returns the merge slot's lock, or an idle one if nobody
+// holds or awaits it.
+func mergeSlotState(name string) (*locks.Lock, error) {
+	result, err := lockListOnce(rootCtx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range result.Locks {
+		if l.Name == name {
+			return l, nil
 		}
 	}
+	return &locks.Lock{Name: name, Capacity: 1, Policy: locks.PolicyFIFO}, nil
+}
 
-	// Create the merge slot bead
-	title := "Merge Slot"
-	description := "Exclusive access slot for serialized conflict resolution in the merge queue."
-
-	if daemonClient != nil {
-		createArgs := &rpc.CreateArgs{
-			ID:          slotID,
-			Title:       title,
-			Description: description,
-			IssueType:   string(types.TypeTask), // Use task type; gt:slot label marks it as slot
-			Priority:    0,                      // P0 - system infrastructure
-			Labels:      []string{"gt:slot"},   // Gas Town slot label
-		}
-		resp, err := daemonClient.Create(createArgs)
-		if err != nil {
-			return fmt.Errorf("failed to create merge slot: %w", err)
-		}
-		if !resp.Success {
-			return fmt.Errorf("failed to create merge slot: %s", resp.Error)
-		}
-	} else {
-		issue := &types.Issue{
-			ID:          slotID,
-			Title:       title,
-			Description: description,
-			IssueType:   types.TypeTask, // Use task type; gt:slot label marks it as slot
-			Status:      types.StatusOpen,
-			Priority:    0,
-		}
-		if err := store.CreateIssue(ctx, issue, actor); err != nil {
-			return fmt.Errorf("failed to create merge slot: %w", err)
-		}
-		// Add gt:slot label to mark as slot bead
-		if err := store.AddLabel(ctx, slotID, "gt:slot", actor); err != nil {
-			// Non-fatal: log warning but don't fail creation
-			fmt.Fprintf(os.Stderr, "warning: failed to add gt:slot label: %v\n", err)
-		}
-		markDirtyAndScheduleFlush()
+func lockHolderNames(entries []*locks.Entry) []string {
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Holder)
 	}
+	return names
+}
 
+func runMergeSlotCreate(cmd *cobra.Command, args []string) {
+	slotID := getMergeSlotID()
 	if jsonOutput {
-		result := map[string]interface{}{
-			"id":     slotID,
-			"status": "open",
-		}
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(result)
+		outputJSON(map[string]interface{}{"id": slotID, "status": "open"})
+		return
 	}
-
-	fmt.Printf("%s Created merge slot: %s\n", ui.RenderPass("✓"), slotID)
-	return nil
+	fmt.Printf("%s Merge slot: %s (a named lock; no setup needed)\n", ui.RenderPass("✓"), slotID)
 }
 
-func runMergeSlotCheck(cmd *cobra.Command, args []string) error {
+func runMergeSlotCheck(cmd *cobra.Command, args []string) {
 	slotID := getMergeSlotID()
-	ctx := rootCtx
-
-	// Get the slot bead
-	var slot *types.Issue
-	if daemonClient != nil {
-		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: slotID})
-		if err != nil {
-			if jsonOutput {
-				result := map[string]interface{}{
-					"id":        slotID,
-					"available": false,
-					"error":     "not found",
-				}
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(result)
-			}
-			fmt.Printf("Merge slot not found: %s\n", slotID)
-			fmt.Printf("Run 'bd merge-slot create' to create one.\n")
-			return nil
-		}
-		var details types.IssueDetails
-		if uerr := json.Unmarshal(resp.Data, &details); uerr != nil {
-			return fmt.Errorf("parsing response: %w", uerr)
-		}
-		slot = &details.Issue
-	} else {
-		var err error
-		slot, err = store.GetIssue(ctx, slotID)
-		if err != nil || slot == nil {
-			if jsonOutput {
-				result := map[string]interface{}{
-					"id":        slotID,
-					"available": false,
-					"error":     "not found",
-				}
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(result)
-			}
-			fmt.Printf("Merge slot not found: %s\n", slotID)
-			fmt.Printf("Run 'bd merge-slot create' to create one.\n")
-			return nil
-		}
+	slot, err := mergeSlotState(slotID)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
 	}
 
-	available := slot.Status == types.StatusOpen
-	holder := slot.Holder
-	waiters := slot.Waiters
+	available := slot.Free() > 0 && len(slot.Waiters) == 0
+	holder := strings.Join(lockHolderNames(slot.Holders), ", ")
+	waiters := lockHolderNames(slot.Waiters)
 
 	if jsonOutput {
-		result := map[string]interface{}{
+		outputJSON(map[string]interface{}{
 			"id":        slotID,
 			"available": available,
-			"status":    string(slot.Status),
 			"holder":    emptyToNil(holder),
 			"waiters":   waiters,
-		}
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(result)
+		})
+		return
 	}
 
 	if available {
 		fmt.Printf("%s Merge slot available: %s\n", ui.RenderPass("✓"), slotID)
-	} else {
-		fmt.Printf("%s Merge slot held: %s\n", ui.RenderAccent("○"), slotID)
-		fmt.Printf("  Holder: %s\n", holder)
-		if len(waiters) > 0 {
-			fmt.Printf("  Waiters: %d\n", len(waiters))
-			for i, w := range waiters {
-				fmt.Printf("    %d. %s\n", i+1, w)
-			}
+		return
+	}
+	fmt.Printf("%s Merge slot held: %s\n", ui.RenderAccent("○"), slotID)
+	fmt.Printf("  Holder: %s\n", holder)
+	if len(waiters) > 0 {
+		fmt.Printf("  Waiters: %d\n", len(waiters))
+		for i, w := range waiters {
+			fmt.Printf("    %d. %s\n", i+1, w)
 		}
 	}
-
-	return nil
 }
 
-func runMergeSlotAcquire(cmd *cobra.Command, args []string) error {
+func runMergeSlotAcquire(cmd *cobra.Command, args []string) {
 	CheckReadonly("merge-slot acquire")
 
-	slotID := getMergeSlotID()
-	ctx := rootCtx
-
-	// Determine holder
-	holder := mergeSlotHolder
+	holder := lockHolder(cmd)
 	if holder == "" {
-		holder = actor
+		FatalErrorRespectJSON("no holder specified; use --holder or set BD_ACTOR env var")
 	}
-	if holder == "" {
-		return fmt.Errorf("no holder specified; use --holder or set BD_ACTOR env var")
+	ttl, err := lockTTL(cmd)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
 	}
+	wait, _ := cmd.Flags().GetBool("wait")
 
-	// Get the slot bead
-	var slot *types.Issue
-	if daemonClient != nil {
-		// Try to resolve the slot ID first
-		resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: slotID})
-		if err != nil {
-			return fmt.Errorf("merge slot not found: %s (run 'bd merge-slot create' first)", slotID)
-		}
-		var resolvedID string
-		if uerr := json.Unmarshal(resp.Data, &resolvedID); uerr != nil {
-			return fmt.Errorf("parsing response: %w", uerr)
-		}
-
-		showResp, showErr := daemonClient.Show(&rpc.ShowArgs{ID: resolvedID})
-		if showErr != nil {
-			return fmt.Errorf("merge slot not found: %s", slotID)
-		}
-		var details types.IssueDetails
-		if uerr := json.Unmarshal(showResp.Data, &details); uerr != nil {
-			return fmt.Errorf("parsing response: %w", uerr)
-		}
-		slot = &details.Issue
-	} else {
-		var err error
-		resolvedID, err := utils.ResolvePartialID(ctx, store, slotID)
-		if err != nil {
-			return fmt.Errorf("merge slot not found: %s (run 'bd merge-slot create' first)", slotID)
-		}
-		slot, err = store.GetIssue(ctx, resolvedID)
-		if err != nil || slot == nil {
-			return fmt.Errorf("merge slot not found: %s", slotID)
-		}
+	slotID := getMergeSlotID()
+	result, err := lockAcquireOnce(rootCtx, rpc.LockAcquireArgs{
+		Name:     slotID,
+		Holder:   holder,
+		Capacity: 1,
+		TTL:      ttl,
+		Queue:    wait,
+	})
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
 	}
 
-	// Check slot availability
-	if slot.Status != types.StatusOpen {
-		// Slot is held
-		if mergeSlotAddWaiter {
-			// Add to waiters list
-			alreadyWaiting := false
-			for _, w := range slot.Waiters {
-				if w == holder {
-					alreadyWaiting = true
-					break
-				}
-			}
-
-			if !alreadyWaiting {
-				newWaiters := append(slot.Waiters, holder)
-				if daemonClient != nil {
-					updateArgs := &rpc.UpdateArgs{
-						ID:      slot.ID,
-						Waiters: newWaiters,
-					}
-					_, err := daemonClient.Update(updateArgs)
-					if err != nil {
-						return fmt.Errorf("failed to add waiter: %w", err)
-					}
-				} else {
-					updates := map[string]interface{}{
-						"waiters": newWaiters,
-					}
-					if err := store.UpdateIssue(ctx, slot.ID, updates, actor); err != nil {
-						return fmt.Errorf("failed to add waiter: %w", err)
-					}
-					markDirtyAndScheduleFlush()
-				}
-			}
-
-			if jsonOutput {
-				result := map[string]interface{}{
-					"id":       slot.ID,
-					"acquired": false,
-					"waiting":  true,
-					"holder":   slot.Holder,
-					"position": len(slot.Waiters) + 1,
-				}
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(result)
-			}
-
-			fmt.Printf("%s Slot held by %s, added to waiters queue (position %d)\n",
-				ui.RenderAccent("○"), slot.Holder, len(slot.Waiters)+1)
-			os.Exit(1) // Exit with error to indicate slot not acquired
-		}
-
+	if result.Acquired {
 		if jsonOutput {
-			result := map[string]interface{}{
-				"id":       slot.ID,
-				"acquired": false,
-				"holder":   slot.Holder,
-			}
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			return encoder.Encode(result)
+			outputJSON(map[string]interface{}{"id": slotID, "acquired": true, "holder": holder})
+			return
 		}
-
-		fmt.Printf("%s Slot held by: %s\n", ui.RenderFail("✗"), slot.Holder)
-		fmt.Printf("Use --wait to add yourself to the waiters queue.\n")
-		os.Exit(1) // Exit with error to indicate slot not acquired
+		fmt.Printf("%s Acquired merge slot: %s\n", ui.RenderPass("✓"), slotID)
+		fmt.Printf("  Holder: %s\n", holder)
+		return
 	}
 
-	// Slot is available - acquire it
-	inProgressStatus := string(types.StatusInProgress)
-	if daemonClient != nil {
-		updateArgs := &rpc.UpdateArgs{
-			ID:     slot.ID,
-			Status: &inProgressStatus,
-			Holder: &holder,
-		}
-		_, err := daemonClient.Update(updateArgs)
-		if err != nil {
-			return fmt.Errorf("failed to acquire slot: %w", err)
-		}
-	} else {
-		updates := map[string]interface{}{
-			"status": types.StatusInProgress,
-			"holder": holder,
-		}
-		if err := store.UpdateIssue(ctx, slot.ID, updates, actor); err != nil {
-			return fmt.Errorf("failed to acquire slot: %w", err)
-		}
-		markDirtyAndScheduleFlush()
+	// Exit with error to indicate slot not acquired. A free slot is still
+	// refused while someone queued earlier is entitled to it
+	current := strings.Join(lockHolderNames(result.Lock.Holders), ", ")
+	if current == "" && len(result.Lock.Waiters) > 0 {
+		current = result.Lock.Waiters[0].Holder + " (next in queue)"
 	}
-
 	if jsonOutput {
-		result := map[string]interface{}{
-			"id":       slot.ID,
-			"acquired": true,
-			"holder":   holder,
+		out := map[string]interface{}{"id": slotID, "acquired": false, "holder": current}
+		if wait {
+			out["waiting"] = true
+			out["position"] = result.Position
 		}
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(result)
+		outputJSON(out)
+		os.Exit(1)
 	}
-
-	fmt.Printf("%s Acquired merge slot: %s\n", ui.RenderPass("✓"), slot.ID)
-	fmt.Printf("  Holder: %s\n", holder)
-	return nil
+	if wait {
+		fmt.Printf("%s Slot held by %s, added to waiters queue (position %d)\n",
+			ui.RenderAccent("○"), current, result.Position)
+	} else {
+		fmt.Printf("%s Slot held by: %s\n", ui.RenderFail("✗"), current)
+		fmt.Printf("Use --wait to add yourself to the waiters queue.\n")
+	}
+	os.Exit(1)
 }
 
-func runMergeSlotRelease(cmd *cobra.Command, args []string) error {
+func runMergeSlotRelease(cmd *cobra.Command, args []string) {
 	CheckReadonly("merge-slot release")
 
+	holder := lockHolder(cmd)
 	slotID := getMergeSlotID()
-	ctx := rootCtx
-
-	// Get the slot bead
-	var slot *types.Issue
-	if daemonClient != nil {
-		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: slotID})
-		if err != nil {
-			return fmt.Errorf("merge slot not found: %s", slotID)
-		}
-		var details types.IssueDetails
-		if uerr := json.Unmarshal(resp.Data, &details); uerr != nil {
-			return fmt.Errorf("parsing response: %w", uerr)
-		}
-		slot = &details.Issue
-	} else {
-		var err error
-		slot, err = store.GetIssue(ctx, slotID)
-		if err != nil || slot == nil {
-			return fmt.Errorf("merge slot not found: %s", slotID)
-		}
-	}
-
-	// Verify holder if specified
-	if mergeSlotHolder != "" && slot.Holder != mergeSlotHolder {
-		return fmt.Errorf("slot held by %s, not %s", slot.Holder, mergeSlotHolder)
-	}
-
-	// Check if slot is actually held
-	if slot.Status == types.StatusOpen {
-		if jsonOutput {
-			result := map[string]interface{}{
-				"id":       slot.ID,
-				"released": false,
-				"error":    "slot not held",
-			}
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			return encoder.Encode(result)
-		}
-		fmt.Printf("Slot is not held: %s\n", slot.ID)
-		return nil
-	}
-
-	previousHolder := slot.Holder
-	waiters := slot.Waiters
-
-	// Release the slot
-	openStatus := string(types.StatusOpen)
-	emptyHolder := ""
-	if daemonClient != nil {
-		updateArgs := &rpc.UpdateArgs{
-			ID:     slot.ID,
-			Status: &openStatus,
-			Holder: &emptyHolder,
-		}
-		_, err := daemonClient.Update(updateArgs)
-		if err != nil {
-			return fmt.Errorf("failed to release slot: %w", err)
-		}
-	} else {
-		updates := map[string]interface{}{
-			"status": types.StatusOpen,
-			"holder": "",
-		}
-		if err := store.UpdateIssue(ctx, slot.ID, updates, actor); err != nil {
-			return fmt.Errorf("failed to release slot: %w", err)
-		}
-		markDirtyAndScheduleFlush()
+	slot, err := lockReleaseOnce(rootCtx, slotID, holder)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
 	}
+	waiters := lockHolderNames(slot.Waiters)
 
 	if jsonOutput {
-		result := map[string]interface{}{
-			"id":              slot.ID,
+		outputJSON(map[string]interface{}{
+			"id":              slotID,
 			"released":        true,
-			"previous_holder": previousHolder,
+			"previous_holder": holder,
 			"waiters":         len(waiters),
-		}
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(result)
+		})
+		return
 	}
 
-	fmt.Printf("%s Released merge slot: %s\n", ui.RenderPass("✓"), slot.ID)
-	fmt.Printf("  Previous holder: %s\n", previousHolder)
+	fmt.Printf("%s Released merge slot: %s\n", ui.RenderPass("✓"), slotID)
+	fmt.Printf("  Previous holder: %s\n", holder)
 	if len(waiters) > 0 {
 		fmt.Printf("  Waiters pending: %d\n", len(waiters))
 		fmt.Printf("  Next in queue: %s\n", waiters[0])
 	}
-
-	return nil
 }
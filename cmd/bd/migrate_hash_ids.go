@@ -42,12 +42,27 @@ USE CASES:
 - Upgrading from beads v1.x to v2.x (sequential → hash IDs)
 - One-time migration only - do not run on already-migrated databases
 
+LENGTHENING HASH IDS:
+With --lengthen, rewrites every hash ID shorter than the target length
+(children and text references included) and pins that length for the
+prefix in .beads/namespace.jsonl. The pin is synced through git, so every
+clone generates IDs at least that long from then on. Without --length the
+target is the shortest length that keeps collision probability under
+max_collision_prob for the current issue count.
+
 EXAMPLES:
   bd migrate-hash-ids --dry-run       # Preview changes
   bd migrate-hash-ids                 # Perform migration (creates backup)
+  bd migrate hash-ids --lengthen --dry-run
+  bd migrate hash-ids --lengthen --length 6
 
 WARNING: Backup your database before running this command, even though it creates one automatically.`,
 	Run: func(cmd *cobra.Command, _ []string) {
+		if lengthen, _ := cmd.Flags().GetBool("lengthen"); lengthen {
+			runLengthenHashIDs(cmd)
+			return
+		}
+
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		// Block writes in readonly mode
@@ -423,6 +438,8 @@ func copyFile(src, dst string) error {
 
 func init() {
 	migrateHashIDsCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
+	migrateHashIDsCmd.Flags().Bool("lengthen", false, "Rewrite short hash IDs to a longer length and pin it for all clones")
+	migrateHashIDsCmd.Flags().Int("length", 0, "Target hash length for --lengthen (default: based on issue count)")
 	migrateCmd.AddCommand(migrateHashIDsCmd)
 
 	// Backwards compatibility alias at root level (hidden)
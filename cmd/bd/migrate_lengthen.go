@@ -0,0 +1,280 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// maxHashLength is the longest hash suffix idgen.GenerateHashID produces.
+const maxHashLength = 8
+
+// runLengthenHashIDs implements 'bd migrate hash-ids --lengthen': it rewrites
+// every top-level hash ID shorter than the target length (plus its children
+// and all references), then pins the target in the synced id.namespace record
+// so every clone keeps generating IDs at least that long.
+func runLengthenHashIDs(cmd *cobra.Command) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	length, _ := cmd.Flags().GetInt("length")
+
+	if !dryRun {
+		CheckReadonly("migrate hash-ids --lengthen")
+	}
+	if err := ensureStoreActive(); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	ctx := rootCtx
+
+	prefix, err := store.GetConfig(ctx, "issue_prefix")
+	if err != nil || prefix == "" {
+		FatalErrorRespectJSON("issue_prefix not configured")
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		FatalErrorRespectJSON("failed to list issues: %v", err)
+	}
+
+	if length == 0 {
+		length = recommendedHashLength(ctx, store, prefix, issues)
+	}
+	if length < 3 || length > maxHashLength {
+		FatalErrorRespectJSON("--length must be between 3 and %d", maxHashLength)
+	}
+
+	mapping, err := planLengthenHashIDs(issues, prefix, length)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if !dryRun {
+		// Back up SQLite databases before rewriting primary keys
+		if dbPath := beads.FindDatabasePath(); dbPath != "" {
+			if _, ok := store.(*sqlite.SQLiteStorage); ok {
+				backupPath := strings.TrimSuffix(dbPath, ".db") + ".backup-" + time.Now().Format("20060102-150405") + ".db"
+				if err := copyFile(dbPath, backupPath); err != nil {
+					FatalErrorRespectJSON("failed to create backup: %v", err)
+				}
+				if !jsonOutput {
+					fmt.Printf("%s\n", ui.RenderPass(fmt.Sprintf("✓ Created backup: %s", filepath.Base(backupPath))))
+				}
+			}
+		}
+
+		if err := applyLengthenHashIDs(ctx, store, issues, prefix, length, mapping, actor); err != nil {
+			FatalErrorRespectJSON("migration failed: %v", err)
+		}
+
+		if jsonlPath := findJSONLPath(); jsonlPath != "" {
+			if len(mapping) > 0 {
+				mappingPath := filepath.Join(filepath.Dir(jsonlPath), "hash-id-mapping.json")
+				if err := saveMappingFile(mappingPath, mapping); err != nil && !jsonOutput {
+					fmt.Printf("%s\n", ui.RenderWarn(fmt.Sprintf("Warning: failed to save mapping file: %v", err)))
+				}
+			}
+			if err := exportIDNamespace(ctx, store, filepath.Dir(jsonlPath)); err != nil {
+				FatalErrorRespectJSON("failed to write %s: %v", idgen.NamespaceFileName, err)
+			}
+		}
+		markDirtyAndScheduleFullExport()
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"status":          "success",
+			"dry_run":         dryRun,
+			"prefix":          prefix,
+			"length":          length,
+			"issues_migrated": len(mapping),
+			"mapping":         mapping,
+		})
+		return
+	}
+
+	olds := make([]string, 0, len(mapping))
+	for old := range mapping {
+		olds = append(olds, old)
+	}
+	slices.Sort(olds)
+
+	if dryRun {
+		fmt.Printf("Dry run: would pin %s- IDs to %d+ chars and rewrite %d issue(s)\n", prefix, length, len(mapping))
+	} else {
+		fmt.Printf("%s Pinned %s- IDs to %d+ chars and rewrote %d issue(s)\n", ui.RenderPass("✓"), prefix, length, len(mapping))
+	}
+	for i, old := range olds {
+		if i >= 10 {
+			fmt.Printf("  ... and %d more\n", len(olds)-10)
+			break
+		}
+		fmt.Printf("  %s → %s\n", old, mapping[old])
+	}
+	if !dryRun {
+		fmt.Println("\nRun 'bd sync' to share the new IDs and the namespace pin with other clones.")
+	}
+}
+
+// recommendedHashLength picks the shortest length that keeps the collision
+// probability for every top-level ID of prefix under max_collision_prob, and
+// never shrinks an existing pin.
+func recommendedHashLength(ctx context.Context, s storage.Storage, prefix string, issues []*types.Issue) int {
+	threshold := sqlite.DefaultAdaptiveConfig().MaxCollisionProbability
+	if v, _ := s.GetConfig(ctx, "max_collision_prob"); v != "" {
+		if p, err := strconv.ParseFloat(v, 64); err == nil {
+			threshold = p
+		}
+	}
+	minLength := sqlite.DefaultAdaptiveConfig().MinLength
+	if v, _ := s.GetConfig(ctx, "min_hash_length"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			minLength = n
+		}
+	}
+	if v, _ := s.GetConfig(ctx, idgen.NamespaceConfigKey(prefix)); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > minLength {
+			minLength = n
+		}
+	}
+
+	topLevel := 0
+	for _, issue := range issues {
+		if hash, ok := strings.CutPrefix(issue.ID, prefix+"-"); ok && !strings.Contains(hash, ".") {
+			topLevel++
+		}
+	}
+	for length := minLength; length < maxHashLength; length++ {
+		if idgen.CollisionProbability(topLevel, length) <= threshold {
+			return length
+		}
+	}
+	return maxHashLength
+}
+
+// planLengthenHashIDs maps every top-level hash ID of prefix shorter than
+// length (and each of its hierarchical children) to a new ID of that length.
+// Sequential IDs are left alone; 'bd migrate hash-ids' handles those.
+func planLengthenHashIDs(issues []*types.Issue, prefix string, length int) (map[string]string, error) {
+	taken := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		taken[issue.ID] = true
+	}
+
+	// Process in ID order so the mapping is deterministic
+	sorted := slices.Clone(issues)
+	slices.SortFunc(sorted, func(a, b *types.Issue) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	mapping := make(map[string]string)
+	for _, issue := range sorted {
+		hash, ok := strings.CutPrefix(issue.ID, prefix+"-")
+		if !ok || strings.Contains(hash, ".") || len(hash) >= length || !isHashID(issue.ID) {
+			continue
+		}
+		newID := ""
+		for nonce := 0; nonce < 100; nonce++ {
+			candidate := idgen.GenerateHashID(prefix, issue.Title, issue.Description, "system", issue.CreatedAt, length, nonce)
+			if !taken[candidate] {
+				newID = candidate
+				break
+			}
+		}
+		if newID == "" {
+			return nil, fmt.Errorf("failed to generate a unique %d-char ID for %s", length, issue.ID)
+		}
+		taken[newID] = true
+		mapping[issue.ID] = newID
+	}
+
+	// Children keep their suffix under the renamed parent
+	for _, issue := range sorted {
+		root, suffix, ok := strings.Cut(issue.ID, ".")
+		if !ok {
+			continue
+		}
+		if newRoot, renamed := mapping[root]; renamed {
+			mapping[issue.ID] = newRoot + "." + suffix
+		}
+	}
+	return mapping, nil
+}
+
+// applyLengthenHashIDs renames the mapped issues and rewrites textual
+// references to them in every issue, then pins length for prefix, all in
+// one transaction so a failure leaves every ID as it was.
+func applyLengthenHashIDs(ctx context.Context, s storage.Storage, issues []*types.Issue, prefix string, length int, mapping map[string]string, actor string) error {
+	idPattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(prefix) + `-[0-9a-z]+(?:\.\d+)*\b`)
+	rewrite := func(text string) string {
+		return idPattern.ReplaceAllStringFunc(text, func(match string) string {
+			if newID, ok := mapping[match]; ok {
+				return newID
+			}
+			return match
+		})
+	}
+
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		rtx, ok := tx.(storage.RenamingTransaction)
+		if !ok {
+			return fmt.Errorf("this storage backend cannot rename issues inside a transaction")
+		}
+		for _, issue := range issues {
+			title := rewrite(issue.Title)
+			description := rewrite(issue.Description)
+			design := rewrite(issue.Design)
+			acceptance := rewrite(issue.AcceptanceCriteria)
+			notes := rewrite(issue.Notes)
+
+			if newID, ok := mapping[issue.ID]; ok {
+				updated := *issue
+				updated.Title, updated.Description, updated.Design = title, description, design
+				updated.AcceptanceCriteria, updated.Notes = acceptance, notes
+				if err := rtx.UpdateIssueID(ctx, issue.ID, newID, &updated, actor); err != nil {
+					return fmt.Errorf("failed to update issue %s → %s: %w", issue.ID, newID, err)
+				}
+				continue
+			}
+
+			if issue.Status == types.StatusTombstone {
+				continue
+			}
+			updates := make(map[string]interface{})
+			if title != issue.Title {
+				updates["title"] = title
+			}
+			if description != issue.Description {
+				updates["description"] = description
+			}
+			if design != issue.Design {
+				updates["design"] = design
+			}
+			if acceptance != issue.AcceptanceCriteria {
+				updates["acceptance_criteria"] = acceptance
+			}
+			if notes != issue.Notes {
+				updates["notes"] = notes
+			}
+			if len(updates) > 0 {
+				if err := tx.UpdateIssue(ctx, issue.ID, updates, actor); err != nil {
+					return fmt.Errorf("failed to rewrite references in %s: %w", issue.ID, err)
+				}
+			}
+		}
+
+		return tx.SetConfig(ctx, idgen.NamespaceConfigKey(prefix), strconv.Itoa(length))
+	})
+}
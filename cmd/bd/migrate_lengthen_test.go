@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestLengthenHashIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := newTestStoreWithPrefix(t, filepath.Join(tmpDir, ".beads", "beads.db"), "bd")
+	ctx := context.Background()
+
+	create := func(id, title, desc string) {
+		t.Helper()
+		issue := &types.Issue{ID: id, Title: title, Description: desc, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", id, err)
+		}
+	}
+	create("bd-a1b", "short parent", "")
+	create("bd-a1b.1", "child", "part of bd-a1b")
+	create("bd-x9z", "blocker", "")
+	create("bd-k3m8q2", "already long", "see bd-x9z and bd-a1b.1")
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: "bd-a1b", DependsOnID: "bd-x9z", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	mapping, err := planLengthenHashIDs(issues, "bd", 6)
+	if err != nil {
+		t.Fatalf("planLengthenHashIDs failed: %v", err)
+	}
+
+	if len(mapping) != 3 {
+		t.Fatalf("mapping = %v, want 3 entries", mapping)
+	}
+	if _, ok := mapping["bd-k3m8q2"]; ok {
+		t.Error("IDs already at the target length must not be rewritten")
+	}
+	for _, old := range []string{"bd-a1b", "bd-x9z"} {
+		if got := strings.TrimPrefix(mapping[old], "bd-"); len(got) != 6 {
+			t.Errorf("%s → %q, want 6-char hash", old, mapping[old])
+		}
+	}
+	if mapping["bd-a1b.1"] != mapping["bd-a1b"]+".1" {
+		t.Errorf("child mapped to %q, want %s.1", mapping["bd-a1b.1"], mapping["bd-a1b"])
+	}
+
+	if err := applyLengthenHashIDs(ctx, s, issues, "bd", 6, mapping, "test"); err != nil {
+		t.Fatalf("applyLengthenHashIDs failed: %v", err)
+	}
+
+	if got, _ := s.GetIssue(ctx, "bd-a1b"); got != nil {
+		t.Error("old ID still resolves after lengthening")
+	}
+	child, err := s.GetIssue(ctx, mapping["bd-a1b.1"])
+	if err != nil || child == nil {
+		t.Fatalf("renamed child missing: %v", err)
+	}
+	if child.Description != "part of "+mapping["bd-a1b"] {
+		t.Errorf("child description = %q, want rewritten reference", child.Description)
+	}
+	long, err := s.GetIssue(ctx, "bd-k3m8q2")
+	if err != nil || long == nil {
+		t.Fatalf("unchanged issue missing: %v", err)
+	}
+	if want := "see " + mapping["bd-x9z"] + " and " + mapping["bd-a1b.1"]; long.Description != want {
+		t.Errorf("description = %q, want %q", long.Description, want)
+	}
+	deps, err := s.GetDependencyRecords(ctx, mapping["bd-a1b"])
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != mapping["bd-x9z"] {
+		t.Errorf("dependency not rewritten: %+v", deps)
+	}
+	if got, _ := s.GetConfig(ctx, idgen.NamespaceConfigKey("bd")); got != "6" {
+		t.Errorf("namespace pin = %q, want 6", got)
+	}
+
+	// New issues honor the pin even though the database is tiny
+	fresh := &types.Issue{Title: "fresh", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, fresh, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if got := strings.TrimPrefix(fresh.ID, "bd-"); len(got) < 6 {
+		t.Errorf("new ID %s shorter than pinned length", fresh.ID)
+	}
+}
+
+func TestLengthenHashIDsRollsBack(t *testing.T) {
+	s := newTestStoreWithPrefix(t, filepath.Join(t.TempDir(), ".beads", "beads.db"), "bd")
+	ctx := context.Background()
+
+	issue := &types.Issue{ID: "bd-a1b", Title: "short", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	// The second rename fails, so the first must not stick either
+	missing := &types.Issue{ID: "bd-q7r", Title: "gone", Status: types.StatusOpen}
+	mapping := map[string]string{"bd-a1b": "bd-a1b2c3", "bd-q7r": "bd-q7r8s9"}
+	if err := applyLengthenHashIDs(ctx, s, []*types.Issue{issue, missing}, "bd", 6, mapping, "test"); err == nil {
+		t.Fatal("applyLengthenHashIDs succeeded with a missing issue")
+	}
+	if got, _ := s.GetIssue(ctx, "bd-a1b"); got == nil {
+		t.Error("bd-a1b was renamed despite the failure")
+	}
+	if got, _ := s.GetIssue(ctx, "bd-a1b2c3"); got != nil {
+		t.Error("bd-a1b2c3 exists despite the failure")
+	}
+	if got, _ := s.GetConfig(ctx, idgen.NamespaceConfigKey("bd")); got != "" {
+		t.Errorf("namespace pin = %q, want none", got)
+	}
+}
+
+func TestExportIDNamespace(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	s := newTestStoreWithPrefix(t, filepath.Join(beadsDir, "beads.db"), "bd")
+	ctx := context.Background()
+
+	// Nothing pinned: no file is created
+	if err := exportIDNamespace(ctx, s, beadsDir); err != nil {
+		t.Fatalf("exportIDNamespace failed: %v", err)
+	}
+	if pins, _ := idgen.LoadNamespace(beadsDir); len(pins) != 0 {
+		t.Errorf("pins = %v, want none", pins)
+	}
+
+	// A pin from another clone already in the file is kept
+	if err := idgen.SaveNamespace(beadsDir, map[string]int{"gt": 5, "bd": 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetConfig(ctx, idgen.NamespaceConfigKey("bd"), "6"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetConfig(ctx, idgen.NamespaceConfigKey("hq"), "4"); err != nil {
+		t.Fatal(err)
+	}
+	if err := exportIDNamespace(ctx, s, beadsDir); err != nil {
+		t.Fatalf("exportIDNamespace failed: %v", err)
+	}
+	pins, err := idgen.LoadNamespace(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pins) != 3 || pins["bd"] != 7 || pins["gt"] != 5 || pins["hq"] != 4 {
+		t.Errorf("pins = %v, want map[bd:7 gt:5 hq:4]", pins)
+	}
+}
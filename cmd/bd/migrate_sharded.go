@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/shard"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var migrateShardedCmd = &cobra.Command{
+	Use:   "sharded",
+	Short: "Split issues.jsonl into hash-bucketed shards for very large repos",
+	Long: `Switch this repository to the sharded JSONL layout.
+
+Instead of a single .beads/issues.jsonl, issues are tracked in git as
+
+  .beads/issues/<prefix>/<bucket>.jsonl
+
+where the bucket is a hash of the issue's root ID (children live with their
+parent). A mutation only rewrites the shards it touches, so commits, diffs and
+merges stay small even with tens of thousands of issues.
+
+The command will:
+  1. Write .beads/issues/manifest.json and split issues.jsonl into shards
+  2. Ignore issues.jsonl in .beads/.gitignore (it stays as a local working
+     copy that bd keeps in sync with the shards)
+  3. Route the shards through the bd merge driver in .gitattributes
+  4. Remove issues.jsonl from the git index
+
+Commit the result (e.g. with 'bd sync'). Other clones pick up the layout on
+their next pull; older bd versions only understand the single-file layout.
+
+Examples:
+  bd migrate sharded --dry-run
+  bd migrate sharded
+  bd migrate sharded --buckets 64`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		buckets, _ := cmd.Flags().GetInt("buckets")
+		if !dryRun {
+			CheckReadonly("migrate sharded")
+		}
+
+		jsonlPath := findJSONLPath()
+		if jsonlPath == "" {
+			FatalErrorRespectJSON("not in a bd workspace (no .beads directory found)")
+		}
+
+		result, err := runMigrateSharded(jsonlPath, buckets, dryRun)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if dryRun {
+			fmt.Println("=== DRY RUN - No changes will be made ===")
+			fmt.Println()
+			fmt.Printf("Would split %d issue(s) from %s into %d shard(s) (%d bucket(s) per prefix)\n",
+				result.Issues, filepath.Base(jsonlPath), result.Shards, result.Buckets)
+			return
+		}
+		fmt.Printf("%s Split %d issue(s) into %d shard(s) under %s\n",
+			ui.RenderPass("✓"), result.Issues, result.Shards, result.ShardDir)
+		for _, w := range result.Warnings {
+			fmt.Printf("%s %s\n", ui.RenderWarn("⚠"), w)
+		}
+		fmt.Printf("\nCommit .beads/ and .gitattributes to share the new layout (e.g. %s).\n", ui.RenderAccent("bd sync"))
+	},
+}
+
+// migrateShardedResult is the result of 'bd migrate sharded'.
+type migrateShardedResult struct {
+	DryRun   bool     `json:"dry_run"`
+	ShardDir string   `json:"shard_dir"`
+	Buckets  int      `json:"buckets"`
+	Issues   int      `json:"issues"`
+	Shards   int      `json:"shards"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// runMigrateSharded converts the .beads directory holding jsonlPath to the
+// sharded layout. Git bookkeeping (.gitignore, .gitattributes, index) is best
+// effort and reported as warnings, since the shards themselves are written.
+func runMigrateSharded(jsonlPath string, buckets int, dryRun bool) (*migrateShardedResult, error) {
+	beadsDir := filepath.Dir(jsonlPath)
+	if shard.IsSharded(beadsDir) {
+		return nil, fmt.Errorf("%s already uses the sharded layout", beadsDir)
+	}
+	if buckets == 0 {
+		buckets = shard.DefaultBuckets
+	}
+	result := &migrateShardedResult{DryRun: dryRun, ShardDir: shard.Dir(beadsDir), Buckets: buckets}
+
+	content, err := os.ReadFile(jsonlPath) // #nosec G304 - jsonlPath is from findJSONLPath
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", jsonlPath, err)
+	}
+	m := &shard.Manifest{Layout: shard.LayoutSharded, Buckets: buckets, Hash: shard.HashFNV32a, File: filepath.Base(jsonlPath)}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	shards, err := shard.Split(content, m)
+	if err != nil {
+		return nil, fmt.Errorf("cannot shard %s: %w", filepath.Base(jsonlPath), err)
+	}
+	result.Issues = countIssueLines(content)
+	result.Shards = len(shards)
+	if dryRun {
+		return result, nil
+	}
+
+	if _, err := shard.Migrate(jsonlPath, buckets); err != nil {
+		return nil, err
+	}
+
+	if err := appendMissingLines(filepath.Join(beadsDir, ".gitignore"), doctor.ShardedGitignoreLines(beadsDir)); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update .beads/.gitignore: %v", err))
+	}
+
+	repoRoot, err := gitOutputIn(beadsDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		result.Warnings = append(result.Warnings, "not in a git repository; skipped .gitattributes and index updates")
+		return result, nil
+	}
+	if relShardDir, err := filepath.Rel(repoRoot, shard.Dir(beadsDir)); err == nil {
+		attr := filepath.ToSlash(relShardDir) + "/**/*.jsonl merge=beads"
+		lines := []string{"# Use bd merge for sharded beads JSONL files", attr}
+		if err := appendMissingLines(filepath.Join(repoRoot, ".gitattributes"), lines); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update .gitattributes: %v", err))
+		}
+	}
+	if _, err := gitOutputIn(beadsDir, "rm", "--cached", "-q", "--ignore-unmatch", "--", filepath.Base(jsonlPath)); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to untrack %s: %v", filepath.Base(jsonlPath), err))
+	}
+	return result, nil
+}
+
+// countIssueLines counts the non-blank lines of JSONL content.
+func countIssueLines(content []byte) int {
+	n := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// appendMissingLines appends the lines of block that are not already present
+// in path, creating it if needed. Comment lines are only written along with
+// at least one missing non-comment line.
+func appendMissingLines(path string, block []string) error {
+	existing, err := os.ReadFile(path) // #nosec G304 - path is inside the repository
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	present := make(map[string]bool)
+	for _, l := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(l)] = true
+	}
+	var add []string
+	needed := false
+	for _, l := range block {
+		if present[l] {
+			continue
+		}
+		if !strings.HasPrefix(l, "#") {
+			needed = true
+		}
+		add = append(add, l)
+	}
+	if !needed {
+		return nil
+	}
+	out := string(existing)
+	if len(out) > 0 && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	out += strings.Join(add, "\n") + "\n"
+	// #nosec G306 - .gitignore/.gitattributes need to be readable
+	return os.WriteFile(path, []byte(out), 0644)
+}
+
+// gitOutputIn runs git in dir and returns its trimmed output.
+func gitOutputIn(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...) // #nosec G204 - args are fixed by callers
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func init() {
+	migrateShardedCmd.Flags().Bool("dry-run", false, "Preview the shard layout without making changes")
+	migrateShardedCmd.Flags().Int("buckets", shard.DefaultBuckets, "Hash buckets per issue prefix (1-256)")
+	migrateCmd.AddCommand(migrateShardedCmd)
+}
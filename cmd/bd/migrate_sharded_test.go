@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/shard"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMigrateSharded(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitInDir(t, repoDir, "init", "--initial-branch=main")
+	runGitInDir(t, repoDir, "config", "user.email", "test@test.com")
+	runGitInDir(t, repoDir, "config", "user.name", "Test User")
+
+	beadsDir := filepath.Join(repoDir, ".beads")
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonlPath, []byte(syncDoctorJSONL(t, "test-1", "test-1.1", "test-2", "test-3")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, repoDir, "add", ".beads/issues.jsonl")
+	runGitInDir(t, repoDir, "commit", "-m", "single file")
+
+	singleHash, err := computeJSONLHash(jsonlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := runMigrateSharded(jsonlPath, 4, true)
+	if err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if dry.Issues != 4 || dry.Shards == 0 || shard.IsSharded(beadsDir) {
+		t.Fatalf("dry run: %+v, sharded=%v", dry, shard.IsSharded(beadsDir))
+	}
+	if _, err := runMigrateSharded(jsonlPath, 1000, true); err == nil {
+		t.Error("expected error for out-of-range bucket count")
+	}
+
+	result, err := runMigrateSharded(jsonlPath, 4, false)
+	if err != nil {
+		t.Fatalf("runMigrateSharded() error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", result.Warnings)
+	}
+	if !shard.IsWorkingCopy(jsonlPath) {
+		t.Fatal("issues.jsonl is not a sharded working copy after migration")
+	}
+	if _, err := runMigrateSharded(jsonlPath, 4, false); err == nil {
+		t.Error("second migration succeeded, want error")
+	}
+
+	gitignore, _ := os.ReadFile(filepath.Join(beadsDir, ".gitignore"))
+	if !strings.Contains(string(gitignore), "\nissues.jsonl\n") {
+		t.Errorf(".beads/.gitignore = %q, want issues.jsonl ignored", gitignore)
+	}
+	attrs, _ := os.ReadFile(filepath.Join(repoDir, ".gitattributes"))
+	if !strings.Contains(string(attrs), ".beads/issues/**/*.jsonl merge=beads") {
+		t.Errorf(".gitattributes = %q, want shard merge driver", attrs)
+	}
+	if tracked := getGitOutputInDir(t, repoDir, "ls-files", ".beads/issues.jsonl"); strings.TrimSpace(tracked) != "" {
+		t.Errorf("issues.jsonl still in the git index: %q", tracked)
+	}
+
+	// The integrity hash is the same in both layouts.
+	if hash, err := computeJSONLHash(jsonlPath); err != nil || hash != singleHash {
+		t.Errorf("computeJSONLHash() after migration = %s, %v; want %s", hash, err, singleHash)
+	}
+
+	// Exports keep the shards up to date...
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "test-1", Title: "Issue test-1", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		{ID: "test-4", Title: "Issue test-4", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+	}
+	if _, err := writeJSONLAtomic(jsonlPath, issues); err != nil {
+		t.Fatalf("writeJSONLAtomic() error = %v", err)
+	}
+	shards, err := shard.ReadShards(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined, err := shard.Join(shards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(joined), `"test-4"`) || strings.Contains(string(joined), `"test-2"`) {
+		t.Errorf("shards not updated by export:\n%s", joined)
+	}
+
+	// ...and a fresh clone (no working copy) rebuilds issues.jsonl from them.
+	exported, _ := os.ReadFile(jsonlPath)
+	if err := os.Remove(jsonlPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := computeJSONLHash(jsonlPath); err != nil {
+		t.Fatalf("computeJSONLHash() after removing working copy: %v", err)
+	}
+	rebuilt, _ := os.ReadFile(jsonlPath)
+	if string(rebuilt) != string(exported) {
+		t.Errorf("rebuilt working copy differs:\n got %s\nwant %s", rebuilt, exported)
+	}
+}
@@ -54,6 +54,7 @@ Commands:
   squash     Condense molecule to digest
   burn       Discard wisp
   distill    Extract proto from ad-hoc epic
+  upgrade    Apply formula changes to a poured mol
 
 Use "bd formula list" to list available formulas.`,
 }
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/formula"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var molUpgradeCmd = &cobra.Command{
+	Use:   "upgrade <mol-id>",
+	Short: "Apply formula changes to an already-poured molecule",
+	Long: `Upgrade a poured molecule to the current version of its formula.
+
+The formula is cooked again and compared to the molecule's issues step by step,
+matching on the source formula and step location recorded at pour time:
+
+  add       Steps in the formula that the molecule does not have are created
+  update    Open (unstarted) steps get the formula's new title and text
+  keep      Started or closed steps are never changed, even if the text differs
+  orphaned  Steps no longer in the formula are left as they are
+
+Variables are substituted as in pour, so pass the same --var values used when
+the molecule was poured. Running upgrade twice makes no further changes.
+
+--to names the formula to upgrade to when it differs from the one recorded at
+pour time. --to <formula>@<version> upgrades to a pinned version instead of the
+current one: it loads <formula>@<version>.formula.toml (or .json) from the
+formula search path, which must declare the same formula name.
+
+Examples:
+  bd mol upgrade bd-mol-abc --dry-run                 # Show the plan
+  bd mol upgrade bd-mol-abc --var component=auth      # Upgrade to latest formula
+  bd mol upgrade bd-mol-abc --to mol-feature@2        # Upgrade to mol-feature@2.formula.toml`,
+	Args: cobra.ExactArgs(1),
+	Run:  runMolUpgrade,
+}
+
+// Molecule upgrade actions
+const (
+	upgradeActionAdd       = "add"
+	upgradeActionUpdate    = "update"
+	upgradeActionKeep      = "keep"
+	upgradeActionOrphaned  = "orphaned"
+	upgradeActionAmbiguous = "ambiguous"
+)
+
+// MolUpgradeStep is one entry in a molecule upgrade plan
+type MolUpgradeStep struct {
+	Action  string   `json:"action"`
+	Source  string   `json:"source"`             // formula@location
+	IssueID string   `json:"issue_id,omitempty"` // poured issue (or created issue after apply)
+	Title   string   `json:"title"`
+	Fields  []string `json:"fields,omitempty"` // text fields that differ
+	Reason  string   `json:"reason,omitempty"`
+
+	proto   *types.Issue           // proto step to create (add)
+	updates map[string]interface{} // field updates to apply (update)
+}
+
+// MolUpgradeResult holds the plan (and outcome) of a molecule upgrade
+type MolUpgradeResult struct {
+	MoleculeID string            `json:"molecule_id"`
+	Formula    string            `json:"formula"`
+	DryRun     bool              `json:"dry_run"`
+	Steps      []*MolUpgradeStep `json:"steps"`
+	Added      int               `json:"added"`
+	Updated    int               `json:"updated"`
+	Kept       int               `json:"kept"`
+	Orphaned   int               `json:"orphaned"`
+}
+
+// upgradeTextFields are the issue fields that upgrade keeps in sync with the formula
+var upgradeTextFields = []struct {
+	name string
+	get  func(*types.Issue) string
+}{
+	{"title", func(i *types.Issue) string { return i.Title }},
+	{"description", func(i *types.Issue) string { return i.Description }},
+	{"design", func(i *types.Issue) string { return i.Design }},
+	{"acceptance_criteria", func(i *types.Issue) string { return i.AcceptanceCriteria }},
+	{"notes", func(i *types.Issue) string { return i.Notes }},
+}
+
+func runMolUpgrade(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	to, _ := cmd.Flags().GetString("to")
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+
+	if !dryRun {
+		CheckReadonly("mol upgrade")
+	}
+
+	// Upgrade requires direct store access (same as pour)
+	if store == nil {
+		if daemonClient != nil {
+			fmt.Fprintf(os.Stderr, "Error: mol upgrade requires direct database access\n")
+			fmt.Fprintf(os.Stderr, "Hint: use --no-daemon flag: bd --no-daemon mol upgrade %s ...\n", args[0])
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: no database connection\n")
+		}
+		os.Exit(1)
+	}
+
+	vars := make(map[string]string)
+	for _, v := range varFlags {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			fmt.Fprintf(os.Stderr, "Error: invalid variable format '%s', expected 'key=value'\n", v)
+			os.Exit(1)
+		}
+		vars[parts[0]] = parts[1]
+	}
+
+	molID, err := utils.ResolvePartialID(ctx, store, args[0])
+	if err != nil {
+		FatalErrorRespectJSON("molecule '%s' not found", args[0])
+	}
+	mol, err := loadTemplateSubgraph(ctx, store, molID)
+	if err != nil {
+		FatalErrorRespectJSON("loading molecule: %v", err)
+	}
+
+	formulaName, version, err := parseUpgradeTarget(to, mol.Root)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if version > 0 {
+		if formulaName, err = resolveVersionedFormula(formula.NewParser(), formulaName, version); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+	}
+
+	proto, err := resolveAndCookFormulaWithVars(formulaName, nil, vars)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	vars = applyVariableDefaults(vars, proto)
+	var missingVars []string
+	for _, v := range extractRequiredVariables(proto) {
+		if _, ok := vars[v]; !ok {
+			missingVars = append(missingVars, v)
+		}
+	}
+	if len(missingVars) > 0 {
+		FatalErrorRespectJSON("missing required variables: %s (provide them with --var %s=<value>)",
+			strings.Join(missingVars, ", "), missingVars[0])
+	}
+
+	result, err := planMolUpgrade(mol, proto, vars)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	result.Formula = formulaName
+	result.DryRun = dryRun
+
+	if !dryRun && (result.Added > 0 || result.Updated > 0) {
+		if err := applyMolUpgrade(ctx, store, mol, proto, result, vars, actor); err != nil {
+			FatalErrorRespectJSON("upgrading molecule: %v", err)
+		}
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	printMolUpgrade(result)
+}
+
+// parseUpgradeTarget parses --to <formula>[@<version>]. Without --to, the
+// formula recorded on the molecule root at pour time is used.
+func parseUpgradeTarget(to string, root *types.Issue) (string, int, error) {
+	if to == "" {
+		if root.SourceFormula == "" {
+			return "", 0, fmt.Errorf("%s does not record the formula it was poured from; use --to <formula>", root.ID)
+		}
+		return root.SourceFormula, 0, nil
+	}
+	name, ver, found := strings.Cut(to, "@")
+	if name == "" {
+		return "", 0, fmt.Errorf("invalid --to %q: expected <formula>[@<version>]", to)
+	}
+	if !found {
+		return name, 0, nil
+	}
+	version, err := strconv.Atoi(ver)
+	if err != nil || version < 1 {
+		return "", 0, fmt.Errorf("invalid --to %q: version must be a positive integer", to)
+	}
+	return name, version, nil
+}
+
+// resolveVersionedFormula returns the name under which version of formula name
+// is found in the search path: the formula file <name>@<version>. The file must
+// declare formula name, or its steps would not match the poured ones.
+func resolveVersionedFormula(parser *formula.Parser, name string, version int) (string, error) {
+	versioned := fmt.Sprintf("%s@%d", name, version)
+	f, err := parser.LoadByName(versioned)
+	if err != nil {
+		return "", fmt.Errorf("loading formula %s version %d (%s%s in the formula search path): %w",
+			name, version, versioned, formula.FormulaExtTOML, err)
+	}
+	if f.Formula != name {
+		return "", fmt.Errorf("formula file %s declares formula %q, not %q", versioned, f.Formula, name)
+	}
+	return versioned, nil
+}
+
+// upgradeSourceKey returns the key that matches a poured issue to the proto
+// step it came from, or "" if the issue carries no source location (roots).
+func upgradeSourceKey(issue *types.Issue) string {
+	if issue.SourceLocation == "" {
+		return ""
+	}
+	return issue.SourceFormula + "@" + issue.SourceLocation
+}
+
+// planMolUpgrade diffs a freshly cooked proto against a poured molecule.
+// Steps whose source key appears more than once on either side cannot be
+// matched reliably and are reported as ambiguous instead of being touched.
+func planMolUpgrade(mol *MoleculeSubgraph, proto *TemplateSubgraph, vars map[string]string) (*MolUpgradeResult, error) {
+	result := &MolUpgradeResult{MoleculeID: mol.Root.ID}
+
+	poured := make(map[string]*types.Issue)
+	ambiguous := make(map[string]bool)
+	for _, issue := range mol.Issues {
+		key := upgradeSourceKey(issue)
+		if key == "" || issue.ID == mol.Root.ID {
+			continue
+		}
+		if _, dup := poured[key]; dup {
+			ambiguous[key] = true
+		}
+		poured[key] = issue
+	}
+	if len(poured) == 0 && len(mol.Issues) > 1 {
+		return nil, fmt.Errorf("no steps of %s record a source formula location (poured before upgrade support?)", mol.Root.ID)
+	}
+
+	inProto := make(map[string]bool)
+	for _, step := range proto.Issues {
+		key := upgradeSourceKey(step)
+		if key == "" || step.ID == proto.Root.ID {
+			continue
+		}
+		if inProto[key] {
+			ambiguous[key] = true
+		}
+		inProto[key] = true
+	}
+
+	reported := make(map[string]bool)
+	for _, step := range proto.Issues {
+		key := upgradeSourceKey(step)
+		if key == "" || step.ID == proto.Root.ID {
+			continue
+		}
+		title := substituteVariables(step.Title, vars)
+		if ambiguous[key] {
+			if !reported[key] {
+				reported[key] = true
+				result.Steps = append(result.Steps, &MolUpgradeStep{
+					Action: upgradeActionAmbiguous,
+					Source: key,
+					Title:  title,
+					Reason: "source location is not unique; left unchanged",
+				})
+			}
+			continue
+		}
+
+		issue, ok := poured[key]
+		if !ok {
+			result.Steps = append(result.Steps, &MolUpgradeStep{
+				Action: upgradeActionAdd,
+				Source: key,
+				Title:  title,
+				proto:  step,
+			})
+			result.Added++
+			continue
+		}
+
+		updates := make(map[string]interface{})
+		var fields []string
+		for _, field := range upgradeTextFields {
+			want := substituteVariables(field.get(step), vars)
+			if field.get(issue) != want {
+				updates[field.name] = want
+				fields = append(fields, field.name)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if issue.Status != types.StatusOpen {
+			result.Steps = append(result.Steps, &MolUpgradeStep{
+				Action:  upgradeActionKeep,
+				Source:  key,
+				IssueID: issue.ID,
+				Title:   issue.Title,
+				Fields:  fields,
+				Reason:  fmt.Sprintf("step is %s", issue.Status),
+			})
+			result.Kept++
+			continue
+		}
+		result.Steps = append(result.Steps, &MolUpgradeStep{
+			Action:  upgradeActionUpdate,
+			Source:  key,
+			IssueID: issue.ID,
+			Title:   title,
+			Fields:  fields,
+			updates: updates,
+		})
+		result.Updated++
+	}
+
+	for _, issue := range mol.Issues {
+		key := upgradeSourceKey(issue)
+		if key == "" || issue.ID == mol.Root.ID || inProto[key] || ambiguous[key] {
+			continue
+		}
+		result.Steps = append(result.Steps, &MolUpgradeStep{
+			Action:  upgradeActionOrphaned,
+			Source:  key,
+			IssueID: issue.ID,
+			Title:   issue.Title,
+			Reason:  "no longer in formula; left unchanged",
+		})
+		result.Orphaned++
+	}
+
+	return result, nil
+}
+
+// applyMolUpgrade creates added steps (wiring their dependencies to the
+// existing molecule) and updates the text of unstarted steps in one transaction.
+func applyMolUpgrade(ctx context.Context, s storage.Storage, mol *MoleculeSubgraph, proto *TemplateSubgraph, result *MolUpgradeResult, vars map[string]string, actorName string) error {
+	// Map proto IDs to molecule IDs for every step that can be matched
+	idMapping := map[string]string{proto.Root.ID: mol.Root.ID}
+	poured := make(map[string]string)
+	for _, issue := range mol.Issues {
+		if key := upgradeSourceKey(issue); key != "" {
+			poured[key] = issue.ID
+		}
+	}
+	for _, step := range proto.Issues {
+		if id, ok := poured[upgradeSourceKey(step)]; ok {
+			idMapping[step.ID] = id
+		}
+	}
+
+	prefix := "mol"
+	if mol.Root.Ephemeral {
+		prefix = "wisp"
+	}
+
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		added := make(map[string]bool)
+		for _, planned := range result.Steps {
+			if planned.Action != upgradeActionAdd {
+				continue
+			}
+			step := planned.proto
+			newIssue := &types.Issue{
+				Title:              substituteVariables(step.Title, vars),
+				Description:        substituteVariables(step.Description, vars),
+				Design:             substituteVariables(step.Design, vars),
+				AcceptanceCriteria: substituteVariables(step.AcceptanceCriteria, vars),
+				Notes:              substituteVariables(step.Notes, vars),
+				Status:             types.StatusOpen,
+				Priority:           step.Priority,
+				IssueType:          step.IssueType,
+				Assignee:           step.Assignee,
+				EstimatedMinutes:   step.EstimatedMinutes,
+				Ephemeral:          mol.Root.Ephemeral,
+				IDPrefix:           prefix,
+				AwaitType:          step.AwaitType,
+				AwaitID:            substituteVariables(step.AwaitID, vars),
+				Timeout:            step.Timeout,
+				CreatedAt:          time.Now(),
+				UpdatedAt:          time.Now(),
+				SourceFormula:      step.SourceFormula,
+				SourceLocation:     step.SourceLocation,
+			}
+			if err := tx.CreateIssue(ctx, newIssue, actorName); err != nil {
+				return fmt.Errorf("failed to create step %s: %w", planned.Source, err)
+			}
+			idMapping[step.ID] = newIssue.ID
+			added[step.ID] = true
+			planned.IssueID = newIssue.ID
+		}
+
+		// Recreate proto dependencies that touch an added step
+		for _, dep := range proto.Dependencies {
+			if !added[dep.IssueID] && !added[dep.DependsOnID] {
+				continue
+			}
+			fromID, ok1 := idMapping[dep.IssueID]
+			toID, ok2 := idMapping[dep.DependsOnID]
+			if !ok1 || !ok2 {
+				continue // other end is ambiguous
+			}
+			if err := tx.AddDependency(ctx, &types.Dependency{
+				IssueID:     fromID,
+				DependsOnID: toID,
+				Type:        dep.Type,
+			}, actorName); err != nil {
+				return fmt.Errorf("failed to add dependency %s -> %s: %w", fromID, toID, err)
+			}
+		}
+
+		for _, planned := range result.Steps {
+			if planned.Action != upgradeActionUpdate {
+				continue
+			}
+			if err := tx.UpdateIssue(ctx, planned.IssueID, planned.updates, actorName); err != nil {
+				return fmt.Errorf("failed to update %s: %w", planned.IssueID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// printMolUpgrade renders the upgrade plan for humans
+func printMolUpgrade(result *MolUpgradeResult) {
+	if len(result.Steps) == 0 {
+		fmt.Printf("%s %s is up to date with %s\n", ui.RenderPass("✓"), result.MoleculeID, result.Formula)
+		return
+	}
+
+	if result.DryRun {
+		fmt.Printf("\nDry run: upgrade plan for %s from %s\n\n", result.MoleculeID, result.Formula)
+	} else {
+		fmt.Printf("%s Upgraded %s from %s\n\n", ui.RenderPass("✓"), result.MoleculeID, result.Formula)
+	}
+	for _, step := range result.Steps {
+		id := step.IssueID
+		if id == "" {
+			id = "(new)"
+		}
+		switch step.Action {
+		case upgradeActionAdd:
+			fmt.Printf("  %s add     %s %s [%s]\n", ui.RenderPass("+"), id, step.Title, step.Source)
+		case upgradeActionUpdate:
+			fmt.Printf("  %s update  %s %s (%s)\n", ui.RenderAccent("~"), id, step.Title, strings.Join(step.Fields, ", "))
+		case upgradeActionKeep:
+			fmt.Printf("  %s keep    %s %s (%s)\n", ui.RenderWarn("="), id, step.Title, step.Reason)
+		default:
+			fmt.Printf("  %s %-7s %s %s (%s)\n", ui.RenderWarn("!"), step.Action, id, step.Title, step.Reason)
+		}
+	}
+	fmt.Printf("\n%d added, %d updated, %d kept, %d orphaned\n", result.Added, result.Updated, result.Kept, result.Orphaned)
+}
+
+func init() {
+	molUpgradeCmd.Flags().String("to", "", "Formula to upgrade to, as <formula>[@<version>]; @<version> loads <formula>@<version> from the formula search path (default: the formula it was poured from)")
+	molUpgradeCmd.Flags().StringArray("var", []string{}, "Variable substitution (key=value)")
+	molUpgradeCmd.Flags().Bool("dry-run", false, "Show the upgrade plan without changing anything")
+
+	molCmd.AddCommand(molUpgradeCmd)
+}
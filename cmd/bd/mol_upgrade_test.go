@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/formula"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// cookUpgradeFormula cooks steps as formula mol-upgrade with source tracing.
+func cookUpgradeFormula(t *testing.T, steps ...*formula.Step) *TemplateSubgraph {
+	t.Helper()
+	f := &formula.Formula{
+		Formula: "mol-upgrade",
+		Version: 1,
+		Type:    formula.TypeWorkflow,
+		Steps:   steps,
+	}
+	formula.SetSourceInfo(f)
+	subgraph, err := cookFormulaToSubgraph(f, f.Formula)
+	if err != nil {
+		t.Fatalf("cookFormulaToSubgraph: %v", err)
+	}
+	return subgraph
+}
+
+func upgradeStepsByAction(result *MolUpgradeResult) map[string][]*MolUpgradeStep {
+	byAction := make(map[string][]*MolUpgradeStep)
+	for _, step := range result.Steps {
+		byAction[step.Action] = append(byAction[step.Action], step)
+	}
+	return byAction
+}
+
+func TestParseUpgradeTarget(t *testing.T) {
+	root := &types.Issue{ID: "bd-mol-1", SourceFormula: "mol-feature"}
+	tests := []struct {
+		to          string
+		wantName    string
+		wantVersion int
+		wantErr     bool
+	}{
+		{"", "mol-feature", 0, false},
+		{"mol-other", "mol-other", 0, false},
+		{"mol-other@2", "mol-other", 2, false},
+		{"mol-other@x", "", 0, true},
+		{"mol-other@0", "", 0, true},
+		{"@2", "", 0, true},
+	}
+	for _, tt := range tests {
+		name, version, err := parseUpgradeTarget(tt.to, root)
+		if (err != nil) != tt.wantErr || name != tt.wantName || version != tt.wantVersion {
+			t.Errorf("parseUpgradeTarget(%q) = %q, %d, %v", tt.to, name, version, err)
+		}
+	}
+	if _, _, err := parseUpgradeTarget("", &types.Issue{ID: "bd-1"}); err == nil {
+		t.Error("expected error for molecule without source formula")
+	}
+}
+
+func TestResolveVersionedFormula(t *testing.T) {
+	dir := t.TempDir()
+	write := func(file, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("mol-feature.formula.json", `{"formula": "mol-feature", "version": 1, "steps": [{"id": "b", "title": "B"}]}`)
+	write("mol-feature@2.formula.json", `{"formula": "mol-feature", "version": 1, "steps": [{"id": "a", "title": "A"}]}`)
+	write("mol-feature@3.formula.json", `{"formula": "mol-other", "version": 1, "steps": [{"id": "a", "title": "A"}]}`)
+
+	got, err := resolveVersionedFormula(formula.NewParser(dir), "mol-feature", 2)
+	if err != nil || got != "mol-feature@2" {
+		t.Errorf("resolveVersionedFormula(mol-feature, 2) = %q, %v; want mol-feature@2", got, err)
+	}
+	if _, err := resolveVersionedFormula(formula.NewParser(dir), "mol-feature", 3); err == nil {
+		t.Error("expected an error for a versioned file declaring another formula")
+	}
+	if _, err := resolveVersionedFormula(formula.NewParser(dir), "mol-feature", 4); err == nil {
+		t.Error("expected an error for a version missing from the search path")
+	}
+}
+
+func TestMolUpgrade(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	defer s.Close()
+
+	v1 := cookUpgradeFormula(t,
+		&formula.Step{ID: "design", Title: "Design {{component}}"},
+		&formula.Step{ID: "build", Title: "Build {{component}}", DependsOn: []string{"design"}},
+		&formula.Step{ID: "docs", Title: "Write docs"},
+		&formula.Step{ID: "legacy", Title: "Legacy step"},
+	)
+	vars := map[string]string{"component": "auth"}
+	poured, err := spawnMolecule(ctx, s, v1, vars, "", "test", false, "mol")
+	if err != nil {
+		t.Fatalf("spawnMolecule: %v", err)
+	}
+	designID := poured.IDMapping["mol-upgrade.design"]
+	docsID := poured.IDMapping["mol-upgrade.docs"]
+	if err := s.CloseIssue(ctx, designID, "done", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// v2 rewords design (closed) and docs (open), adds a test step after
+	// build, and drops the legacy step.
+	v2 := cookUpgradeFormula(t,
+		&formula.Step{ID: "design", Title: "Design {{component}} carefully"},
+		&formula.Step{ID: "build", Title: "Build {{component}}", DependsOn: []string{"design"}},
+		&formula.Step{ID: "test", Title: "Test {{component}}", DependsOn: []string{"build"}},
+		&formula.Step{ID: "docs", Title: "Write docs", Description: "Cover {{component}} setup"},
+		&formula.Step{ID: "legacy2", Title: "Replacement"},
+	)
+
+	mol, err := loadTemplateSubgraph(ctx, s, poured.NewEpicID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := planMolUpgrade(mol, v2, vars)
+	if err != nil {
+		t.Fatalf("planMolUpgrade: %v", err)
+	}
+	byAction := upgradeStepsByAction(result)
+	if len(byAction[upgradeActionAdd]) != 2 || result.Added != 2 {
+		t.Errorf("add = %+v, want test and legacy2", byAction[upgradeActionAdd])
+	}
+	if got := byAction[upgradeActionUpdate]; len(got) != 1 || got[0].IssueID != docsID || got[0].Fields[0] != "description" {
+		t.Errorf("update = %+v, want docs description", got)
+	}
+	if got := byAction[upgradeActionKeep]; len(got) != 1 || got[0].IssueID != designID {
+		t.Errorf("keep = %+v, want closed design step", got)
+	}
+	if got := byAction[upgradeActionOrphaned]; len(got) != 1 || got[0].Title != "Legacy step" {
+		t.Errorf("orphaned = %+v, want legacy step", got)
+	}
+
+	if err := applyMolUpgrade(ctx, s, mol, v2, result, vars, "test"); err != nil {
+		t.Fatalf("applyMolUpgrade: %v", err)
+	}
+
+	docs, err := s.GetIssue(ctx, docsID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs.Description != "Cover auth setup" {
+		t.Errorf("docs description = %q", docs.Description)
+	}
+	design, err := s.GetIssue(ctx, designID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if design.Title != "Design auth" {
+		t.Errorf("closed step was changed: %q", design.Title)
+	}
+
+	var testID string
+	for _, step := range byAction[upgradeActionAdd] {
+		if step.Source == "mol-upgrade@steps[test]" {
+			testID = step.IssueID
+		}
+	}
+	added, err := s.GetIssue(ctx, testID)
+	if err != nil || added == nil {
+		t.Fatalf("added step %q not found: %v", testID, err)
+	}
+	if added.Title != "Test auth" || added.SourceLocation != "steps[test]" {
+		t.Errorf("added step = %q at %q", added.Title, added.SourceLocation)
+	}
+	deps, err := s.GetDependencyRecords(ctx, testID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDeps := map[string]types.DependencyType{
+		poured.NewEpicID:                      types.DepParentChild,
+		poured.IDMapping["mol-upgrade.build"]: types.DepBlocks,
+	}
+	for _, dep := range deps {
+		if wantDeps[dep.DependsOnID] == dep.Type {
+			delete(wantDeps, dep.DependsOnID)
+		}
+	}
+	if len(wantDeps) != 0 {
+		t.Errorf("added step is missing dependencies: %v (got %+v)", wantDeps, deps)
+	}
+
+	// Upgrading again is a no-op apart from the steps it never touches.
+	mol, err = loadTemplateSubgraph(ctx, s, poured.NewEpicID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := planMolUpgrade(mol, v2, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Added != 0 || again.Updated != 0 || again.Kept != 1 || again.Orphaned != 1 {
+		t.Errorf("second plan = %+v, want only keep and orphaned", again)
+	}
+}
+
+func TestMolUpgradeAmbiguous(t *testing.T) {
+	proto := cookUpgradeFormula(t, &formula.Step{ID: "a", Title: "A"})
+	mol := &MoleculeSubgraph{
+		Root: &types.Issue{ID: "test-mol-1", SourceFormula: "mol-upgrade"},
+	}
+	mol.Issues = []*types.Issue{
+		mol.Root,
+		{ID: "test-mol-2", Title: "A", Status: types.StatusOpen, SourceFormula: "mol-upgrade", SourceLocation: "steps[a]"},
+		{ID: "test-mol-3", Title: "A copy", Status: types.StatusOpen, SourceFormula: "mol-upgrade", SourceLocation: "steps[a]"},
+	}
+	result, err := planMolUpgrade(mol, proto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Steps) != 1 || result.Steps[0].Action != upgradeActionAmbiguous {
+		t.Errorf("plan = %+v, want a single ambiguous step", result.Steps)
+	}
+
+	untraced := &MoleculeSubgraph{Root: mol.Root, Issues: []*types.Issue{mol.Root, {ID: "test-mol-4"}}}
+	if _, err := planMolUpgrade(untraced, proto, nil); err == nil {
+		t.Error("expected error for molecule without source locations")
+	}
+}
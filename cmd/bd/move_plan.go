@@ -0,0 +1,451 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/routing"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// movePlan is everything a cross-rig move writes. It is built from the source
+// without side effects, so --dry-run can print it, and executeMove applies it.
+type movePlan struct {
+	SourceID     string              `json:"source"`
+	SourceRig    string              `json:"source_rig"`
+	TargetRig    string              `json:"target_rig"`
+	TargetPrefix string              `json:"target_prefix"`
+	Issue        *types.Issue        `json:"issue"` // Issue to create in the target; ID assigned on execute
+	Labels       []string            `json:"labels,omitempty"`
+	Comments     []*types.Comment    `json:"comments,omitempty"`
+	Events       []*types.Event      `json:"events,omitempty"` // Oldest first
+	Attachments  []*types.Attachment `json:"attachments,omitempty"`
+	WorkLogs     []*types.WorkLog    `json:"work_logs,omitempty"`
+	Outbound     []movedDependency   `json:"outbound,omitempty"`
+	Inbound      []*types.Dependency `json:"inbound,omitempty"` // Source dependencies on the moved issue
+	CloseSource  bool                `json:"close_source"`
+	Verb         string              `json:"-"` // "Moved" or "Refiled", for the description note and close reason
+}
+
+// movedDependency is an outbound dependency of the moved issue: the record in
+// the source rig and what it points at from the new issue.
+type movedDependency struct {
+	*types.Dependency
+	Target string `json:"target"`
+}
+
+// moveResult reports what executeMove wrote.
+type moveResult struct {
+	Source        string `json:"source"`
+	Target        string `json:"target"`
+	Closed        bool   `json:"closed"`
+	DepsRemapped  int    `json:"deps_remapped"`
+	DepsRewritten int    `json:"deps_rewritten"`
+	Comments      int    `json:"comments"`
+	Events        int    `json:"events"`
+}
+
+// historyImporter is implemented by stores that can insert comments and
+// events with their original authors and timestamps (SQLite).
+type historyImporter interface {
+	ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt string) (*types.Comment, error)
+	ImportEvents(ctx context.Context, issueID string, events []*types.Event) error
+}
+
+// rigNamer maps an issue prefix to the rig name used in external refs.
+type rigNamer func(prefix string) string
+
+// townRigNamer names rigs after the first path component of their route in
+// the town's routes.jsonl, falling back to the bare prefix.
+func townRigNamer(townBeadsDir string) rigNamer {
+	routes, _ := routing.LoadTownRoutes(townBeadsDir)
+	return func(prefix string) string {
+		for _, route := range routes {
+			if route.Prefix != prefix {
+				continue
+			}
+			if project := routing.ExtractProjectFromPath(route.Path); project != "" && project != "." {
+				return project
+			}
+		}
+		return strings.TrimSuffix(prefix, "-")
+	}
+}
+
+// externalRef formats a reference to an issue in another rig.
+func externalRef(rig, id string) string {
+	return fmt.Sprintf("external:%s:%s", rig, id)
+}
+
+// buildMovePlan reads everything attached to the source issue and works out
+// how it will look in the target rig.
+func buildMovePlan(ctx context.Context, src storage.Storage, issue *types.Issue, targetPrefix string, rigName rigNamer, verb string, skipDeps bool) (*movePlan, error) {
+	sourceID := issue.ID
+	plan := &movePlan{
+		SourceID:     sourceID,
+		SourceRig:    rigName(routing.ExtractPrefix(sourceID)),
+		TargetRig:    rigName(targetPrefix),
+		TargetPrefix: targetPrefix,
+		Issue:        newMovedIssue(issue, verb),
+		Verb:         verb,
+	}
+
+	var err error
+	if plan.Labels, err = src.GetLabels(ctx, sourceID); err != nil {
+		return nil, fmt.Errorf("getting labels: %w", err)
+	}
+	if plan.Comments, err = src.GetIssueComments(ctx, sourceID); err != nil {
+		return nil, fmt.Errorf("getting comments: %w", err)
+	}
+	events, err := src.GetEvents(ctx, sourceID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("getting events: %w", err)
+	}
+	// GetEvents returns newest first; replay oldest first
+	for i := len(events) - 1; i >= 0; i-- {
+		plan.Events = append(plan.Events, events[i])
+	}
+	if plan.Attachments, err = src.GetAttachments(ctx, sourceID); err != nil {
+		return nil, fmt.Errorf("getting attachments: %w", err)
+	}
+	if plan.WorkLogs, err = src.GetWorkLogs(ctx, sourceID); err != nil {
+		return nil, fmt.Errorf("getting work logs: %w", err)
+	}
+
+	outbound, err := src.GetDependencyRecords(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("getting dependencies from %s: %w", sourceID, err)
+	}
+	for _, dep := range outbound {
+		plan.Outbound = append(plan.Outbound, movedDependency{
+			Dependency: dep,
+			Target:     rewriteOutboundDep(dep.DependsOnID, targetPrefix, rigName),
+		})
+	}
+
+	if !skipDeps {
+		if plan.Inbound, err = inboundDependencies(ctx, src, sourceID); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// newMovedIssue copies the source issue's content into a fresh issue for the
+// target rig. The ID is left empty so the target generates one.
+func newMovedIssue(src *types.Issue, verb string) *types.Issue {
+	issue := &types.Issue{
+		Title:              src.Title,
+		Description:        src.Description,
+		Design:             src.Design,
+		AcceptanceCriteria: src.AcceptanceCriteria,
+		Notes:              src.Notes,
+		Status:             types.StatusOpen, // Always start as open
+		Priority:           src.Priority,
+		IssueType:          src.IssueType,
+		Assignee:           src.Assignee,
+		ExternalRef:        src.ExternalRef,
+		EstimatedMinutes:   src.EstimatedMinutes,
+		SourceRepo:         src.SourceRepo,
+		Ephemeral:          src.Ephemeral,
+		MolType:            src.MolType,
+		RoleType:           src.RoleType,
+		Rig:                src.Rig,
+		DueAt:              src.DueAt,
+		DeferUntil:         src.DeferUntil,
+		CreatedBy:          actor,
+	}
+	if issue.Description != "" {
+		issue.Description += "\n\n"
+	}
+	issue.Description += fmt.Sprintf("(%s from %s)", verb, src.ID)
+	return issue
+}
+
+// rewriteOutboundDep maps a dependency target of the moved issue to what it
+// must be in the target rig: issues already in the target rig and existing
+// external refs stay as they are, everything else becomes an external ref to
+// its own rig.
+func rewriteOutboundDep(dependsOnID, targetPrefix string, rigName rigNamer) string {
+	if strings.HasPrefix(dependsOnID, "external:") {
+		return dependsOnID
+	}
+	prefix := routing.ExtractPrefix(dependsOnID)
+	if prefix == targetPrefix {
+		return dependsOnID
+	}
+	return externalRef(rigName(prefix), dependsOnID)
+}
+
+// inboundDependencies returns the dependency records in s that point at id.
+func inboundDependencies(ctx context.Context, s storage.Storage, id string) ([]*types.Dependency, error) {
+	dependents, err := s.GetDependents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting dependents of %s: %w", id, err)
+	}
+	var inbound []*types.Dependency
+	for _, dependent := range dependents {
+		records, err := s.GetDependencyRecords(ctx, dependent.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting dependencies for %s: %w", dependent.ID, err)
+		}
+		for _, dep := range records {
+			if dep.DependsOnID == id {
+				inbound = append(inbound, dep)
+			}
+		}
+	}
+	return inbound, nil
+}
+
+// executeMove applies a plan. Target writes happen first: the issue, labels
+// and outbound dependencies in one transaction, then history, attachments
+// and work logs. The source is only touched once the target is complete, in
+// a single transaction that repoints inbound dependencies, drops outbound
+// ones and closes it. The new issue supersedes the old one, as with bd
+// supersede, so the supersedes edge is created along with it in the target.
+// If any step after the target issue exists fails, the new issue and the
+// blobs copied for it are deleted again so neither rig is left half-moved.
+func executeMove(ctx context.Context, source, target *routing.RoutedStorage, plan *movePlan, actor string) (*moveResult, error) {
+	newIssue := *plan.Issue
+	err := target.Storage.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, &newIssue, actor); err != nil {
+			return fmt.Errorf("creating issue: %w", err)
+		}
+		for _, label := range plan.Labels {
+			if err := tx.AddLabel(ctx, newIssue.ID, label, actor); err != nil {
+				return fmt.Errorf("copying label %s: %w", label, err)
+			}
+		}
+		for _, dep := range plan.Outbound {
+			d := *dep.Dependency
+			d.IssueID = newIssue.ID
+			d.DependsOnID = dep.Target
+			if err := tx.AddDependency(ctx, &d, actor); err != nil {
+				return fmt.Errorf("adding dependency on %s: %w", d.DependsOnID, err)
+			}
+		}
+		supersedes := &types.Dependency{
+			IssueID:     newIssue.ID,
+			DependsOnID: externalRef(plan.SourceRig, plan.SourceID),
+			Type:        types.DepSupersedes,
+			CreatedBy:   actor,
+		}
+		if err := tx.AddDependency(ctx, supersedes, actor); err != nil {
+			return fmt.Errorf("adding supersedes edge: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("target rig %s: %w", plan.TargetRig, err)
+	}
+	newID := newIssue.ID
+
+	var copiedBlobs []string
+	rollback := func(cause error) error {
+		if derr := target.Storage.DeleteIssue(ctx, newID); derr != nil {
+			return fmt.Errorf("%w; rollback failed, delete %s in rig %s manually: %v", cause, newID, plan.TargetRig, derr)
+		}
+		dst := blobs.New(target.BeadsDir)
+		for _, hash := range copiedBlobs {
+			if derr := dst.Remove(hash); derr != nil {
+				return fmt.Errorf("%w; rollback failed, run bd attach gc in rig %s: %v", cause, plan.TargetRig, derr)
+			}
+		}
+		return fmt.Errorf("%w (rolled back: %s removed from rig %s)", cause, newID, plan.TargetRig)
+	}
+
+	result := &moveResult{Source: plan.SourceID, Target: newID, DepsRewritten: len(plan.Outbound)}
+	if err := copyMoveHistory(ctx, target.Storage, newID, plan, result); err != nil {
+		return nil, rollback(err)
+	}
+	copiedBlobs, err = copyMoveAttachments(ctx, source.BeadsDir, target, newID, plan, actor)
+	if err != nil {
+		return nil, rollback(err)
+	}
+	for _, w := range plan.WorkLogs {
+		entry := *w
+		if err := target.Storage.AddWorkLog(ctx, newID, &entry, actor); err != nil {
+			return nil, rollback(fmt.Errorf("copying work log: %w", err))
+		}
+	}
+
+	outbound := make([]*types.Dependency, len(plan.Outbound))
+	for i, dep := range plan.Outbound {
+		outbound[i] = dep.Dependency
+	}
+	err = source.Storage.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		remapped, err := remapDependencies(ctx, tx, plan.SourceID, outbound, plan.Inbound, externalRef(plan.TargetRig, newID), actor)
+		if err != nil {
+			return err
+		}
+		result.DepsRemapped = remapped
+
+		if plan.CloseSource {
+			if err := tx.CloseIssue(ctx, plan.SourceID, fmt.Sprintf("%s to %s", plan.Verb, newID), actor, ""); err != nil {
+				return fmt.Errorf("closing source issue: %w", err)
+			}
+			result.Closed = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, rollback(fmt.Errorf("source rig %s: %w", plan.SourceRig, err))
+	}
+	return result, nil
+}
+
+// copyMoveHistory copies comments and events to the new issue, preserving
+// authors and timestamps where the target store supports it.
+func copyMoveHistory(ctx context.Context, s storage.Storage, newID string, plan *movePlan, result *moveResult) error {
+	importer, ok := s.(historyImporter)
+	for _, c := range plan.Comments {
+		var err error
+		if ok {
+			_, err = importer.ImportIssueComment(ctx, newID, c.Author, c.Text, c.CreatedAt.UTC().Format(time.RFC3339))
+		} else {
+			_, err = s.AddIssueComment(ctx, newID, c.Author, c.Text)
+		}
+		if err != nil {
+			return fmt.Errorf("copying comment by %s: %w", c.Author, err)
+		}
+		result.Comments++
+	}
+	if ok {
+		if err := importer.ImportEvents(ctx, newID, plan.Events); err != nil {
+			return fmt.Errorf("replaying events: %w", err)
+		}
+		result.Events = len(plan.Events)
+	}
+	return nil
+}
+
+// copyMoveAttachments copies attachment blobs into the target rig's blob
+// store and records the attachments on the new issue. Blobs missing from the
+// source are recorded anyway; bd doctor reports them in both rigs. Returns
+// the hashes of the blobs it wrote, even on error, so a rollback can remove
+// them.
+func copyMoveAttachments(ctx context.Context, sourceBeadsDir string, target *routing.RoutedStorage, newID string, plan *movePlan, actor string) ([]string, error) {
+	if len(plan.Attachments) == 0 {
+		return nil, nil
+	}
+	var src *blobs.Store
+	if sourceBeadsDir != "" {
+		src = blobs.New(sourceBeadsDir)
+	}
+	dst := blobs.New(target.BeadsDir)
+	var copied []string
+	for _, a := range plan.Attachments {
+		if src != nil && src.Has(a.Hash) && !dst.Has(a.Hash) {
+			if err := copyBlob(src, dst, a.Hash); err != nil {
+				return copied, fmt.Errorf("copying attachment %s: %w", a.Name, err)
+			}
+			copied = append(copied, a.Hash)
+		}
+		attachment := *a
+		if err := target.Storage.AddAttachment(ctx, newID, &attachment, actor); err != nil {
+			return copied, fmt.Errorf("copying attachment %s: %w", a.Name, err)
+		}
+	}
+	return copied, nil
+}
+
+func copyBlob(src, dst *blobs.Store, hash string) error {
+	f, err := src.Open(hash)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	got, _, err := dst.Put(f)
+	if err != nil {
+		return err
+	}
+	if got != hash {
+		return fmt.Errorf("blob %s is corrupt (content hashes to %s)", shortHash(hash), shortHash(got))
+	}
+	return nil
+}
+
+// dependencyWriter is the subset of storage.Storage and storage.Transaction
+// needed to rewrite dependency edges.
+type dependencyWriter interface {
+	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
+	RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error
+}
+
+// remapDependencies removes the moved issue's outbound dependencies from the
+// source rig (they now live on the new issue) and points inbound ones at
+// newRef, preserving type and metadata. Returns the number of inbound
+// dependencies remapped.
+func remapDependencies(ctx context.Context, w dependencyWriter, oldID string, outbound, inbound []*types.Dependency, newRef, actor string) (int, error) {
+	for _, dep := range outbound {
+		if err := w.RemoveDependency(ctx, oldID, dep.DependsOnID, actor); err != nil {
+			return 0, fmt.Errorf("removing dependency %s->%s: %w", oldID, dep.DependsOnID, err)
+		}
+	}
+	for _, dep := range inbound {
+		if err := w.RemoveDependency(ctx, dep.IssueID, oldID, actor); err != nil {
+			return 0, fmt.Errorf("removing dependency %s->%s: %w", dep.IssueID, oldID, err)
+		}
+		newDep := &types.Dependency{
+			IssueID:     dep.IssueID,
+			DependsOnID: newRef,
+			Type:        dep.Type,
+			CreatedBy:   actor,
+			Metadata:    dep.Metadata,
+			ThreadID:    dep.ThreadID,
+		}
+		if err := w.AddDependency(ctx, newDep, actor); err != nil {
+			return 0, fmt.Errorf("adding dependency %s->%s: %w", dep.IssueID, newRef, err)
+		}
+	}
+	return len(inbound), nil
+}
+
+// printMovePlan renders a plan for --dry-run.
+func printMovePlan(plan *movePlan) {
+	newRef := externalRef(plan.TargetRig, "<new-id>")
+	fmt.Printf("\n%s Move plan: %s → rig %s (%s)\n\n", ui.RenderAccent("📦"), plan.SourceID, plan.TargetRig, plan.TargetPrefix)
+
+	fmt.Printf("  Create   %q in %s (status %s, P%d)\n", plan.Issue.Title, plan.TargetRig, plan.Issue.Status, plan.Issue.Priority)
+	if plan.Issue.ExternalRef != nil && *plan.Issue.ExternalRef != "" {
+		fmt.Printf("  Keep     external ref %s\n", *plan.Issue.ExternalRef)
+	}
+	if len(plan.Labels) > 0 {
+		fmt.Printf("  Copy     %d label%s: %s\n", len(plan.Labels), pluralize(len(plan.Labels)), strings.Join(plan.Labels, ", "))
+	}
+	if len(plan.Comments) > 0 {
+		fmt.Printf("  Copy     %d comment%s with original authors and timestamps\n", len(plan.Comments), pluralize(len(plan.Comments)))
+	}
+	if len(plan.Events) > 0 {
+		fmt.Printf("  Replay   %d event%s\n", len(plan.Events), pluralize(len(plan.Events)))
+	}
+	if len(plan.Attachments) > 0 {
+		fmt.Printf("  Copy     %d attachment%s with blobs\n", len(plan.Attachments), pluralize(len(plan.Attachments)))
+	}
+	if len(plan.WorkLogs) > 0 {
+		fmt.Printf("  Copy     work log (%d)\n", len(plan.WorkLogs))
+	}
+	if len(plan.Outbound) > 0 {
+		fmt.Printf("  Rewrite  outbound dependencies (%d):\n", len(plan.Outbound))
+		for _, dep := range plan.Outbound {
+			fmt.Printf("             <new-id> --%s--> %s\n", dep.Type, dep.Target)
+		}
+	}
+	if len(plan.Inbound) > 0 {
+		fmt.Printf("  Repoint  inbound dependencies (%d):\n", len(plan.Inbound))
+		for _, dep := range plan.Inbound {
+			fmt.Printf("             %s --%s--> %s\n", dep.IssueID, dep.Type, newRef)
+		}
+	}
+	fmt.Printf("  Link     <new-id> --%s--> %s\n", types.DepSupersedes, externalRef(plan.SourceRig, plan.SourceID))
+	if plan.CloseSource {
+		fmt.Printf("  Close    %s (%q)\n", plan.SourceID, fmt.Sprintf("%s to <new-id>", plan.Verb))
+	}
+	fmt.Printf("\n%s\n", ui.RenderMuted("Dry run: nothing was written. If any target write fails, the move is rolled back."))
+}
@@ -3,12 +3,32 @@ package main
 import (
 	"context"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/beads/internal/blobs"
+	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// remapForTest gathers the dependencies of oldID and remaps them to an
+// external ref for newID in targetRig, as the source side of bd move does.
+func remapForTest(t *testing.T, s *sqlite.SQLiteStorage, oldID, newID, targetRig string) (int, error) {
+	t.Helper()
+	ctx := context.Background()
+	outbound, err := s.GetDependencyRecords(ctx, oldID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	inbound, err := inboundDependencies(ctx, s, oldID)
+	if err != nil {
+		t.Fatalf("inboundDependencies failed: %v", err)
+	}
+	return remapDependencies(ctx, s, oldID, outbound, inbound, externalRef(targetRig, newID), "test-actor")
+}
+
 func TestRemapDependencies(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
@@ -78,7 +98,7 @@ func TestRemapDependencies(t *testing.T) {
 	// Now remap: A moves from test-aaa to other-xxx (CROSS-rig, different prefix)
 	// This simulates moving from one rig to another
 	newID := "other-xxx"
-	count, err := remapDependencies(ctx, testStore, issueA.ID, newID, "other", "test-actor")
+	count, err := remapForTest(t, testStore, issueA.ID, newID, "other")
 	if err != nil {
 		t.Fatalf("remapDependencies failed: %v", err)
 	}
@@ -148,7 +168,7 @@ func TestRemapDependencies_NoDeps(t *testing.T) {
 		t.Fatalf("Failed to create issue: %v", err)
 	}
 
-	count, err := remapDependencies(ctx, testStore, issue.ID, "other-id", "other", "test-actor")
+	count, err := remapForTest(t, testStore, issue.ID, "other-id", "other")
 	if err != nil {
 		t.Fatalf("remapDependencies failed: %v", err)
 	}
@@ -209,7 +229,7 @@ func TestRemapDependencies_PreservesMetadata(t *testing.T) {
 
 	// Remap: A moves to other-xxx (cross-rig)
 	newID := "other-xxx"
-	_, err = remapDependencies(ctx, testStore, issueA.ID, newID, "other", "test-actor")
+	_, err = remapForTest(t, testStore, issueA.ID, newID, "other")
 	if err != nil {
 		t.Fatalf("remapDependencies failed: %v", err)
 	}
@@ -233,3 +253,176 @@ func TestRemapDependencies_PreservesMetadata(t *testing.T) {
 		t.Errorf("Metadata not preserved: got %s", bDepRecords[0].Metadata)
 	}
 }
+
+// newRigStore creates a SQLite store in its own .beads directory with the
+// given issue prefix, standing in for one rig of a town.
+func newRigStore(t *testing.T, prefix string) (*sqlite.SQLiteStorage, string) {
+	t.Helper()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	s := newTestStore(t, filepath.Join(beadsDir, "beads.db"))
+	if err := s.SetConfig(context.Background(), "issue_prefix", prefix); err != nil {
+		t.Fatalf("Failed to set issue_prefix: %v", err)
+	}
+	return s, beadsDir
+}
+
+func testRigNamer(prefix string) string {
+	return map[string]string{"hq-": "town", "gt-": "gastown"}[prefix]
+}
+
+// seedMoveSource creates hq-aaa (the issue to move) with a label, an old
+// comment, an attachment and a work log, blocked by hq-ccc and blocking hq-bbb.
+func seedMoveSource(t *testing.T, s *sqlite.SQLiteStorage, beadsDir string) {
+	t.Helper()
+	ctx := context.Background()
+	ref := "JIRA-7"
+	for _, issue := range []*types.Issue{
+		{ID: "hq-aaa", Title: "Move me", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, ExternalRef: &ref},
+		{ID: "hq-bbb", Title: "Blocked by A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "hq-ccc", Title: "Blocks A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := s.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("Failed to create %s: %v", issue.ID, err)
+		}
+	}
+	if err := s.AddLabel(ctx, "hq-aaa", "backend", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ImportIssueComment(ctx, "hq-aaa", "bob", "repro attached", "2025-01-02T03:04:05Z"); err != nil {
+		t.Fatal(err)
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "hq-aaa", DependsOnID: "hq-ccc", Type: types.DepBlocks},
+		{IssueID: "hq-bbb", DependsOnID: "hq-aaa", Type: types.DepBlocks},
+	} {
+		if err := s.AddDependency(ctx, dep, "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hash, size, err := blobs.New(beadsDir).Put(strings.NewReader("stack trace"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddAttachment(ctx, "hq-aaa", &types.Attachment{Hash: hash, Name: "trace.txt", Size: size}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	end := time.Date(2025, 1, 2, 5, 0, 0, 0, time.UTC)
+	if err := s.AddWorkLog(ctx, "hq-aaa", &types.WorkLog{StartedAt: end.Add(-time.Hour), EndedAt: &end}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecuteMove_Lossless(t *testing.T) {
+	ctx := context.Background()
+	src, srcDir := newRigStore(t, "hq")
+	dst, dstDir := newRigStore(t, "gt")
+	seedMoveSource(t, src, srcDir)
+
+	issue, _ := src.GetIssue(ctx, "hq-aaa")
+	plan, err := buildMovePlan(ctx, src, issue, "gt-", testRigNamer, "Moved", false)
+	if err != nil {
+		t.Fatalf("buildMovePlan failed: %v", err)
+	}
+	plan.CloseSource = true
+	sourceEvents := len(plan.Events)
+
+	result, err := executeMove(ctx,
+		&routing.RoutedStorage{Storage: src, BeadsDir: srcDir},
+		&routing.RoutedStorage{Storage: dst, BeadsDir: dstDir, Routed: true},
+		plan, "mover")
+	if err != nil {
+		t.Fatalf("executeMove failed: %v", err)
+	}
+	newID := result.Target
+
+	// Target: content, labels, history, attachments and rewritten deps
+	moved, err := dst.GetIssue(ctx, newID)
+	if err != nil || moved == nil {
+		t.Fatalf("moved issue %s not found: %v", newID, err)
+	}
+	if moved.ExternalRef == nil || *moved.ExternalRef != "JIRA-7" {
+		t.Errorf("ExternalRef not preserved: %v", moved.ExternalRef)
+	}
+	if labels, _ := dst.GetLabels(ctx, newID); len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("labels = %v", labels)
+	}
+	comments, _ := dst.GetIssueComments(ctx, newID)
+	if len(comments) != 1 || comments[0].Author != "bob" || comments[0].CreatedAt.Year() != 2025 {
+		t.Errorf("comment author/timestamp not preserved: %+v", comments)
+	}
+	if events, _ := dst.GetEvents(ctx, newID, 0); len(events) <= sourceEvents {
+		t.Errorf("expected %d replayed events plus the target's own, got %d", sourceEvents, len(events))
+	}
+	// The new issue supersedes the old one, as with bd supersede
+	deps, _ := dst.GetDependencyRecords(ctx, newID)
+	want := map[string]types.DependencyType{"external:town:hq-ccc": types.DepBlocks, "external:town:hq-aaa": types.DepSupersedes}
+	if len(deps) != len(want) {
+		t.Errorf("outbound deps = %+v, want %v", deps, want)
+	}
+	for _, dep := range deps {
+		if want[dep.DependsOnID] != dep.Type {
+			t.Errorf("unexpected outbound dep %s --%s--> %s", dep.IssueID, dep.Type, dep.DependsOnID)
+		}
+	}
+	attachments, _ := dst.GetAttachments(ctx, newID)
+	if len(attachments) != 1 || !blobs.New(dstDir).Has(attachments[0].Hash) {
+		t.Errorf("attachment or blob not copied: %+v", attachments)
+	}
+	if logs, _ := dst.GetWorkLogs(ctx, newID); len(logs) != 1 || logs[0].Actor != "bob" {
+		t.Errorf("work logs = %+v", logs)
+	}
+
+	// Source: closed, inbound repointed, outbound dropped
+	old, _ := src.GetIssue(ctx, "hq-aaa")
+	if old.Status != types.StatusClosed || !result.Closed {
+		t.Errorf("source status = %s, want closed", old.Status)
+	}
+	if oldDeps, _ := src.GetDependencyRecords(ctx, "hq-aaa"); len(oldDeps) != 0 {
+		t.Errorf("source deps = %+v, want none", oldDeps)
+	}
+	bDeps, _ := src.GetDependencyRecords(ctx, "hq-bbb")
+	if len(bDeps) != 1 || bDeps[0].DependsOnID != "external:gastown:"+newID {
+		t.Errorf("inbound dep not repointed: %+v", bDeps)
+	}
+}
+
+func TestExecuteMove_RollsBackTarget(t *testing.T) {
+	ctx := context.Background()
+	src, srcDir := newRigStore(t, "hq")
+	dst, dstDir := newRigStore(t, "gt")
+	seedMoveSource(t, src, srcDir)
+
+	issue, _ := src.GetIssue(ctx, "hq-aaa")
+	plan, err := buildMovePlan(ctx, src, issue, "gt-", testRigNamer, "Moved", false)
+	if err != nil {
+		t.Fatalf("buildMovePlan failed: %v", err)
+	}
+	plan.CloseSource = true
+
+	// The source changes after planning, so its transaction fails
+	if err := src.RemoveDependency(ctx, "hq-bbb", "hq-aaa", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = executeMove(ctx,
+		&routing.RoutedStorage{Storage: src, BeadsDir: srcDir},
+		&routing.RoutedStorage{Storage: dst, BeadsDir: dstDir, Routed: true},
+		plan, "mover")
+	if err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("executeMove error = %v, want a rolled back failure", err)
+	}
+
+	if issues, _ := dst.SearchIssues(ctx, "", types.IssueFilter{}); len(issues) != 0 {
+		t.Errorf("target still has %d issue(s) after rollback", len(issues))
+	}
+	if hashes, _ := blobs.New(dstDir).List(); len(hashes) != 0 {
+		t.Errorf("target still has blobs after rollback: %v", hashes)
+	}
+	old, _ := src.GetIssue(ctx, "hq-aaa")
+	if old.Status != types.StatusOpen {
+		t.Errorf("source status = %s, want open", old.Status)
+	}
+	if deps, _ := src.GetDependencyRecords(ctx, "hq-aaa"); len(deps) != 1 || deps[0].DependsOnID != "hq-ccc" {
+		t.Errorf("source deps changed despite rollback: %+v", deps)
+	}
+}
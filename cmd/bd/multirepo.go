@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// repoFilterFromFlag resolves the --repo selector of list/ready to the
+// source_repo value it matches ("." for the primary repo). Returns "" when
+// the flag is unset. Exits if multi-repo mode is off or the name is unknown.
+func repoFilterFromFlag(cmd *cobra.Command) string {
+	name, _ := cmd.Flags().GetString("repo")
+	if name == "" {
+		return ""
+	}
+	multiRepo := config.GetMultiRepoConfig()
+	if multiRepo == nil {
+		FatalErrorRespectJSON("--repo requires multi-repo mode (configure repos with 'bd repo add')")
+	}
+	repo, err := multiRepo.ResolveRepo(name)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return repo
+}
+
+// resolveCreateRepo checks a 'bd create --repo' target against the
+// multi-repo config. When target names a hydrated additional repo it returns
+// the source_repo to stamp on the new issue, so the issue lands in the unified
+// database and that repo's JSONL. It returns "" when regular routing should
+// handle the target (single-repo mode, the primary repo, or a path that isn't
+// configured), and an error when the target is ambiguous or read-only.
+func resolveCreateRepo(target string) (string, error) {
+	multiRepo := config.GetMultiRepoConfig()
+	if multiRepo == nil {
+		return "", nil
+	}
+	repo, err := multiRepo.ResolveRepo(target)
+	if errors.Is(err, config.ErrUnknownRepo) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if repo == "." {
+		return "", nil
+	}
+	if multiRepo.IsReadOnly(repo) {
+		return "", fmt.Errorf("repo %s is read-only (listed in repos.readonly); refusing to create issues in it", repo)
+	}
+	return repo, nil
+}
+
+// isReadOnlyRepo reports whether repo is a configured read-only source.
+func isReadOnlyRepo(repo string) bool {
+	multiRepo := config.GetMultiRepoConfig()
+	return multiRepo != nil && multiRepo.IsReadOnly(repo)
+}
+
+// repoTag returns the " ⟨repo⟩" column shown after an issue ID for issues
+// hydrated from an additional repo, or "" for primary-repo issues.
+func repoTag(issue *types.Issue) string {
+	repo := types.ExternalSourceRepo(issue)
+	if repo == "" {
+		return ""
+	}
+	return fmt.Sprintf(" ⟨%s⟩", repo)
+}
+
+// renderRepoTag is repoTag styled for active (non-muted) lines.
+func renderRepoTag(issue *types.Issue) string {
+	if tag := repoTag(issue); tag != "" {
+		return " " + ui.RenderMuted(tag[1:])
+	}
+	return ""
+}
+
+// unwrapIssuesWithCounts extracts the issues from a list RPC response,
+// restoring each issue's SourceRepo (which types.Issue does not serialize).
+func unwrapIssuesWithCounts(withCounts []*types.IssueWithCounts) []*types.Issue {
+	issues := make([]*types.Issue, 0, len(withCounts))
+	for _, iwc := range withCounts {
+		if iwc == nil || iwc.Issue == nil {
+			continue
+		}
+		iwc.Issue.SourceRepo = iwc.SourceRepo
+		issues = append(issues, iwc.Issue)
+	}
+	return issues
+}
+
+// unwrapIssuesWithRepo is unwrapIssuesWithCounts for ready RPC responses.
+func unwrapIssuesWithRepo(withRepo []*types.IssueWithRepo) []*types.Issue {
+	issues := make([]*types.Issue, 0, len(withRepo))
+	for _, iwr := range withRepo {
+		if iwr == nil || iwr.Issue == nil {
+			continue
+		}
+		iwr.Issue.SourceRepo = iwr.SourceRepo
+		issues = append(issues, iwr.Issue)
+	}
+	return issues
+}
+
+// wrapIssuesWithRepo pairs issues with their owning repo for JSON output.
+// Always returns a non-nil slice so empty results encode as [].
+func wrapIssuesWithRepo(issues []*types.Issue) []*types.IssueWithRepo {
+	withRepo := make([]*types.IssueWithRepo, len(issues))
+	for i, issue := range issues {
+		withRepo[i] = &types.IssueWithRepo{Issue: issue, SourceRepo: types.ExternalSourceRepo(issue)}
+	}
+	return withRepo
+}
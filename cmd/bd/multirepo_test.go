@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestResolveCreateRepo(t *testing.T) {
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("config.Initialize() error = %v", err)
+	}
+	defer func() {
+		config.Set("repos.primary", "")
+		config.Set("repos.additional", nil)
+		config.Set("repos.readonly", nil)
+	}()
+
+	// Single-repo mode: always fall through to regular routing
+	config.Set("repos.primary", "")
+	if repo, err := resolveCreateRepo("~/planning"); err != nil || repo != "" {
+		t.Errorf("single-repo mode: got (%q, %v), want (\"\", nil)", repo, err)
+	}
+
+	config.Set("repos.primary", ".")
+	config.Set("repos.additional", []string{"~/planning", "../upstream"})
+	config.Set("repos.readonly", []string{"../upstream"})
+
+	tests := []struct {
+		target  string
+		want    string
+		wantErr string
+	}{
+		{target: "planning", want: "~/planning"},
+		{target: "~/planning", want: "~/planning"},
+		{target: ".", want: ""},
+		{target: "/somewhere/else", want: ""},
+		{target: "upstream", wantErr: "read-only"},
+	}
+	for _, tt := range tests {
+		got, err := resolveCreateRepo(tt.target)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveCreateRepo(%q) error = %v, want %q", tt.target, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolveCreateRepo(%q) = (%q, %v), want %q", tt.target, got, err, tt.want)
+		}
+	}
+
+	if !isReadOnlyRepo("../upstream") || isReadOnlyRepo("~/planning") {
+		t.Error("isReadOnlyRepo disagrees with repos.readonly")
+	}
+}
+
+func TestIssuesWithRepoRoundTrip(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Primary", SourceRepo: "."},
+		{ID: "plan-1", Title: "Planning", SourceRepo: "~/planning"},
+	}
+
+	data, err := json.Marshal(wrapIssuesWithRepo(issues))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Count(string(data), `"source_repo"`) != 1 {
+		t.Errorf("only the non-primary issue should carry source_repo: %s", data)
+	}
+
+	var decoded []*types.IssueWithRepo
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got := unwrapIssuesWithRepo(decoded)
+	if len(got) != 2 || got[0].SourceRepo != "" || got[1].SourceRepo != "~/planning" {
+		t.Errorf("unexpected round trip: %+v", got)
+	}
+	if repoTag(got[0]) != "" || repoTag(got[1]) != " ⟨~/planning⟩" {
+		t.Errorf("unexpected repo tags: %q %q", repoTag(got[0]), repoTag(got[1]))
+	}
+
+	// Plain issue decoding (older clients) ignores the extra field
+	var plain []*types.Issue
+	if err := json.Unmarshal(data, &plain); err != nil || len(plain) != 2 {
+		t.Errorf("plain decode failed: %v", err)
+	}
+
+	if empty := wrapIssuesWithRepo(nil); empty == nil {
+		t.Error("wrapIssuesWithRepo(nil) should encode as []")
+	}
+}
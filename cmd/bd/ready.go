@@ -26,6 +26,17 @@ Use --mol to filter to a specific molecule's steps:
 Use --gated to find molecules ready for gate-resume dispatch:
   bd ready --gated           # Find molecules where a gate closed
 
+In multi-repo mode, 'blocks' dependencies between repos are honored and
+issues from additional repos are tagged with their repo. Use --repo to
+restrict to one repo:
+  bd ready --repo planning   # Ready work hydrated from ~/planning
+
+Use --for to route work by capability. An issue labeled needs:<cap> is only
+ready for agents that have <cap>: declared with a can:<cap> label on the
+agent bead, attested by an attests edge, or role:<role_type> and
+rig:<rig>. Work needing more of the agent's capabilities comes first:
+  bd ready --for gt-agent-7  # Work agent gt-agent-7 is qualified for
+
 This is useful for agents executing molecules to see which steps can run next.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Handle --gated flag (gate-resume discovery)
@@ -54,6 +65,8 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		molTypeStr, _ := cmd.Flags().GetString("mol-type")
 		prettyFormat, _ := cmd.Flags().GetBool("pretty")
 		includeDeferred, _ := cmd.Flags().GetBool("include-deferred")
+		forAgent, _ := cmd.Flags().GetString("for")
+		sourceRepo := repoFilterFromFlag(cmd)
 		var molType *types.MolType
 		if molTypeStr != "" {
 			mt := types.MolType(molTypeStr)
@@ -100,9 +113,12 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		if molType != nil {
 			filter.MolType = molType
 		}
+		if sourceRepo != "" {
+			filter.SourceRepo = &sourceRepo
+		}
 		// Validate sort policy
 		if !filter.SortPolicy.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest\n", sortPolicy)
+			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest, due\n", sortPolicy)
 			os.Exit(1)
 		}
 		// If daemon is running, use RPC
@@ -118,6 +134,8 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				ParentID:        parentID,
 				MolType:         molTypeStr,
 				IncludeDeferred: includeDeferred, // GH#820
+				SourceRepo:      sourceRepo,
+				ForAgent:        forAgent,
 			}
 			if cmd.Flags().Changed("priority") {
 				priority, _ := cmd.Flags().GetInt("priority")
@@ -128,16 +146,14 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
-			var issues []*types.Issue
-			if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			var issuesWithRepo []*types.IssueWithRepo
+			if err := json.Unmarshal(resp.Data, &issuesWithRepo); err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
+			issues := unwrapIssuesWithRepo(issuesWithRepo)
 			if jsonOutput {
-				if issues == nil {
-					issues = []*types.Issue{}
-				}
-				outputJSON(issues)
+				outputJSON(wrapIssuesWithRepo(issues))
 				return
 			}
 
@@ -167,10 +183,10 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			} else {
 				fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", ui.RenderAccent("📋"), len(issues))
 				for i, issue := range issues {
-					fmt.Printf("%d. [%s] [%s] %s: %s\n", i+1,
+					fmt.Printf("%d. [%s] [%s] %s%s: %s\n", i+1,
 						ui.RenderPriority(issue.Priority),
 						ui.RenderType(string(issue.IssueType)),
-						ui.RenderID(issue.ID), issue.Title)
+						ui.RenderID(issue.ID), renderRepoTag(issue), issue.Title)
 					if issue.EstimatedMinutes != nil {
 						fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 					}
@@ -194,6 +210,15 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			}
 		}
 
+		if forAgent != "" {
+			agentID, err := utils.ResolvePartialID(ctx, store, forAgent)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: resolving agent %s: %v\n", forAgent, err)
+				os.Exit(1)
+			}
+			filter.ForAgent = agentID
+		}
+
 		issues, err := store.GetReadyWork(ctx, filter)
 		if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -212,10 +237,7 @@ This is useful for agents executing molecules to see which steps can run next.`,
 	}
 		if jsonOutput {
 			// Always output array, even if empty
-			if issues == nil {
-				issues = []*types.Issue{}
-			}
-			outputJSON(issues)
+			outputJSON(wrapIssuesWithRepo(issues))
 			return
 		}
 		// Show upgrade notification if needed
@@ -242,10 +264,10 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		} else {
 			fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", ui.RenderAccent("📋"), len(issues))
 			for i, issue := range issues {
-				fmt.Printf("%d. [%s] [%s] %s: %s\n", i+1,
+				fmt.Printf("%d. [%s] [%s] %s%s: %s\n", i+1,
 					ui.RenderPriority(issue.Priority),
 					ui.RenderType(string(issue.IssueType)),
-					ui.RenderID(issue.ID), issue.Title)
+					ui.RenderID(issue.ID), renderRepoTag(issue), issue.Title)
 				if issue.EstimatedMinutes != nil {
 					fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 				}
@@ -452,7 +474,7 @@ func init() {
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	readyCmd.Flags().BoolP("unassigned", "u", false, "Show only unassigned issues")
-	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
+	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest, due")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
 	readyCmd.Flags().StringP("type", "t", "", "Filter by issue type (task, bug, feature, epic, merge-request). Aliases: mr→merge-request, feat→feature, mol→molecule")
@@ -461,7 +483,9 @@ func init() {
 	readyCmd.Flags().String("mol-type", "", "Filter by molecule type: swarm, patrol, or work")
 	readyCmd.Flags().Bool("pretty", false, "Display issues in a tree format with status/priority symbols")
 	readyCmd.Flags().Bool("include-deferred", false, "Include issues with future defer_until timestamps")
+	readyCmd.Flags().String("repo", "", "Filter by owning repo in multi-repo mode ('.' for primary, or a repos.additional path/name)")
 	readyCmd.Flags().Bool("gated", false, "Find molecules ready for gate-resume dispatch")
+	readyCmd.Flags().String("for", "", "Only work this agent is qualified for (needs:<cap> labels), best match first")
 	rootCmd.AddCommand(readyCmd)
 	blockedCmd.Flags().String("parent", "", "Filter to descendants of this bead/epic")
 	rootCmd.AddCommand(blockedCmd)
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// sharedLabelWeight is how much each shared label adds to a related issue's
+// score. One hop scores 1.0, two hops 0.5, so two shared labels lift a
+// second-degree neighbor level with a direct one.
+const sharedLabelWeight = 0.25
+
+// RelatedIssue is a graph neighbor ranked for bd related
+type RelatedIssue struct {
+	*types.GraphNeighbor
+	SharedLabels []string `json:"shared_labels,omitempty"`
+	Score        float64  `json:"score"`
+}
+
+var relatedCmd = &cobra.Command{
+	Use:     "related <issue-id>",
+	GroupID: "deps",
+	Short:   "Show issues near an issue in the dependency graph",
+	Long: `Show the neighborhood of an issue: every issue reachable within --depth
+edges, following dependencies of any type in either direction.
+
+Results are ranked by graph proximity (1/distance) plus a bonus for each label
+shared with the issue, so closely linked and topically similar issues come
+first. Use --types to restrict the walk to knowledge-graph edges.
+
+Examples:
+  bd related bd-42                                  # Two hops, all edge types
+  bd related bd-42 --depth 3 --types relates-to,duplicates,supersedes
+  bd related bd-42 --types caused-by,discovered-from --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		depth, _ := cmd.Flags().GetInt("depth")
+		limit, _ := cmd.Flags().GetInt("limit")
+		edgeTypes := graphEdgeTypesFlag(cmd)
+		if depth < 1 {
+			FatalErrorRespectJSON("--depth must be >= 1")
+		}
+
+		closeStore := ensureGraphStore(ctx)
+		defer closeStore()
+		issueID := resolveGraphIssueID(ctx, args[0])
+
+		neighbors, err := store.GetNeighborhood(ctx, issueID, depth, edgeTypes)
+		if err != nil {
+			FatalErrorRespectJSON("loading neighborhood: %v", err)
+		}
+		ids := []string{issueID}
+		for _, n := range neighbors {
+			ids = append(ids, n.ID)
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("loading labels: %v", err)
+		}
+		related := rankRelated(labels[issueID], neighbors, labels)
+		if limit > 0 && len(related) > limit {
+			related = related[:limit]
+		}
+
+		if jsonOutput {
+			if related == nil {
+				related = []*RelatedIssue{}
+			}
+			outputJSON(related)
+			return
+		}
+
+		if len(related) == 0 {
+			fmt.Printf("\nNo issues within %d edges of %s\n", depth, issueID)
+			return
+		}
+		fmt.Printf("\n%s Related to %s (depth %d):\n\n", ui.RenderAccent("🕸"), issueID, depth)
+		for _, r := range related {
+			hops := "hops"
+			if r.Distance == 1 {
+				hops = "hop"
+			}
+			detail := fmt.Sprintf("%d %s via %s", r.Distance, hops, r.Via)
+			if len(r.SharedLabels) > 0 {
+				detail += "; labels: " + strings.Join(r.SharedLabels, ", ")
+			}
+			fmt.Printf("  %s [P%d] %s %s  %s\n", ui.RenderID(r.ID), r.Priority, r.Status, r.Title, ui.RenderMuted("("+detail+")"))
+		}
+		fmt.Println()
+	},
+}
+
+var pathCmd = &cobra.Command{
+	Use:     "path <from-id> <to-id>",
+	GroupID: "deps",
+	Short:   "Find the shortest dependency path between two issues",
+	Long: `Find the shortest chain of dependency edges linking two issues.
+
+Edges are followed in either direction; each step shows the edge type and
+whether it was walked along or against the stored direction.
+
+Examples:
+  bd path bd-12 bd-40
+  bd path bd-12 bd-40 --types caused-by,discovered-from
+  bd path bd-12 bd-40 --max-depth 4 --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		edgeTypes := graphEdgeTypesFlag(cmd)
+		if maxDepth < 1 {
+			FatalErrorRespectJSON("--max-depth must be >= 1")
+		}
+
+		closeStore := ensureGraphStore(ctx)
+		defer closeStore()
+		fromID := resolveGraphIssueID(ctx, args[0])
+		toID := resolveGraphIssueID(ctx, args[1])
+
+		path, err := store.FindPath(ctx, fromID, toID, maxDepth, edgeTypes)
+		if err != nil {
+			FatalErrorRespectJSON("finding path: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"from":  fromID,
+				"to":    toID,
+				"found": path != nil,
+				"path":  path,
+			})
+			return
+		}
+
+		if path == nil {
+			fmt.Printf("\nNo path from %s to %s within %d edges\n", fromID, toID, maxDepth)
+			return
+		}
+		fmt.Printf("\n%s Path from %s to %s (%d edges):\n\n", ui.RenderAccent("🔗"), fromID, toID, len(path))
+		printPathIssue(ctx, fromID)
+		for _, step := range path {
+			if step.Reverse {
+				fmt.Printf("    %s\n", ui.RenderMuted("↑ "+string(step.Type)))
+			} else {
+				fmt.Printf("    %s\n", ui.RenderMuted("↓ "+string(step.Type)))
+			}
+			printPathIssue(ctx, step.ToID)
+		}
+		fmt.Println()
+	},
+}
+
+// rankRelated scores neighbors by proximity and labels shared with the start
+// issue, highest first. Ties fall back to distance, then ID.
+func rankRelated(startLabels []string, neighbors []*types.GraphNeighbor, labels map[string][]string) []*RelatedIssue {
+	start := make(map[string]bool, len(startLabels))
+	for _, l := range startLabels {
+		start[l] = true
+	}
+
+	var related []*RelatedIssue
+	for _, n := range neighbors {
+		var shared []string
+		for _, l := range labels[n.ID] {
+			if start[l] {
+				shared = append(shared, l)
+			}
+		}
+		sort.Strings(shared)
+		related = append(related, &RelatedIssue{
+			GraphNeighbor: n,
+			SharedLabels:  shared,
+			Score:         1/float64(n.Distance) + sharedLabelWeight*float64(len(shared)),
+		})
+	}
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		if related[i].Distance != related[j].Distance {
+			return related[i].Distance < related[j].Distance
+		}
+		return related[i].ID < related[j].ID
+	})
+	return related
+}
+
+// graphEdgeTypesFlag parses --types into dependency types (empty means all).
+func graphEdgeTypesFlag(cmd *cobra.Command) []types.DependencyType {
+	raw, _ := cmd.Flags().GetStringSlice("types")
+	var edgeTypes []types.DependencyType
+	for _, t := range raw {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		dt := types.DependencyType(t)
+		if !dt.IsValid() {
+			FatalErrorRespectJSON("invalid dependency type %q", t)
+		}
+		edgeTypes = append(edgeTypes, dt)
+	}
+	return edgeTypes
+}
+
+// ensureGraphStore opens direct storage when only the daemon is connected;
+// graph queries are not exposed over RPC. The returned func closes it.
+func ensureGraphStore(ctx context.Context) func() {
+	if daemonClient != nil && store == nil {
+		s, err := sqlite.New(ctx, dbPath)
+		if err != nil {
+			FatalErrorRespectJSON("failed to open database: %v", err)
+		}
+		store = s
+		return func() { _ = s.Close() }
+	}
+	if store == nil {
+		FatalErrorRespectJSON("no database connection")
+	}
+	return func() {}
+}
+
+// resolveGraphIssueID resolves a partial ID via the daemon or direct storage.
+func resolveGraphIssueID(ctx context.Context, arg string) string {
+	if daemonClient != nil {
+		resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: arg})
+		if err != nil {
+			FatalErrorRespectJSON("issue '%s' not found", arg)
+		}
+		var id string
+		if err := json.Unmarshal(resp.Data, &id); err != nil {
+			FatalErrorRespectJSON("unmarshaling resolved ID: %v", err)
+		}
+		return id
+	}
+	id, err := utils.ResolvePartialID(ctx, store, arg)
+	if err != nil {
+		FatalErrorRespectJSON("issue '%s' not found", arg)
+	}
+	return id
+}
+
+// printPathIssue prints one node of a path, tolerating external references.
+func printPathIssue(ctx context.Context, id string) {
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil || issue == nil {
+		fmt.Printf("  %s\n", ui.RenderID(id))
+		return
+	}
+	fmt.Printf("  %s [P%d] %s %s\n", ui.RenderID(issue.ID), issue.Priority, issue.Status, issue.Title)
+}
+
+func init() {
+	relatedCmd.Flags().Int("depth", storage.DefaultNeighborhoodDepth, "Maximum number of edges from the issue")
+	relatedCmd.Flags().StringSlice("types", nil, "Only follow these dependency types (comma-separated)")
+	relatedCmd.Flags().Int("limit", 20, "Maximum number of results (0 for all)")
+	relatedCmd.ValidArgsFunction = issueIDCompletion
+
+	pathCmd.Flags().Int("max-depth", storage.DefaultPathDepth, "Maximum path length in edges")
+	pathCmd.Flags().StringSlice("types", nil, "Only follow these dependency types (comma-separated)")
+	pathCmd.ValidArgsFunction = issueIDCompletion
+
+	rootCmd.AddCommand(relatedCmd)
+	rootCmd.AddCommand(pathCmd)
+}
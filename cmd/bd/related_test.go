@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRankRelated(t *testing.T) {
+	neighbor := func(id string, distance int) *types.GraphNeighbor {
+		return &types.GraphNeighbor{Issue: types.Issue{ID: id}, Distance: distance, Via: types.DepRelatesTo}
+	}
+	neighbors := []*types.GraphNeighbor{
+		neighbor("bd-far", 3),
+		neighbor("bd-near", 1),
+		neighbor("bd-topical", 2),
+		neighbor("bd-also-near", 1),
+	}
+	labels := map[string][]string{
+		"bd-topical": {"ui", "auth", "backend"},
+		"bd-far":     {"docs"},
+	}
+
+	related := rankRelated([]string{"backend", "auth"}, neighbors, labels)
+	var got []string
+	for _, r := range related {
+		got = append(got, r.ID)
+	}
+	// bd-topical: 1/2 + 2*0.25 = 1.0 ties the direct neighbors and sorts after them by distance
+	want := []string{"bd-also-near", "bd-near", "bd-topical", "bd-far"}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("rankRelated order = %v, want %v", got, want)
+		}
+	}
+	if shared := related[2].SharedLabels; len(shared) != 2 || shared[0] != "auth" || shared[1] != "backend" {
+		t.Errorf("shared labels = %v, want [auth backend]", shared)
+	}
+	if related[3].Score >= related[2].Score {
+		t.Errorf("distant issue scored %v, topical %v", related[3].Score, related[2].Score)
+	}
+}
@@ -27,9 +27,16 @@ Configuration is stored in .beads/config.yaml under the 'repos' section:
     additional:
       - ~/beads-planning
       - ~/work-repo
+    readonly:
+      - ~/work-repo
+
+Issues from every repo are visible to list/ready/show (filter with --repo),
+and 'blocks' dependencies between repos count toward ready work. New issues
+can be written into a writable repo with 'bd create --repo <name>'.
 
 Examples:
   bd repo add ~/beads-planning       # Add planning repo
+  bd repo add --readonly ~/work-repo # Hydrate, but never write to it
   bd repo add ../other-repo          # Add relative path repo
   bd repo list                       # Show all configured repos
   bd repo remove ~/beads-planning    # Remove by path
@@ -44,6 +51,10 @@ var repoAddCmd = &cobra.Command{
 The path should point to a directory containing a .beads folder.
 Paths can be absolute or relative (they are stored as-is).
 
+With --readonly the repository is also listed under repos.readonly:
+its issues are hydrated, but 'bd create --repo' refuses to write to it
+and multi-repo export leaves its JSONL untouched.
+
 This modifies .beads/config.yaml, which is version-controlled and
 shared across all clones of this repository.`,
 	Args: cobra.ExactArgs(1),
@@ -75,11 +86,18 @@ shared across all clones of this repository.`,
 		if err := config.AddRepo(configPath, repoPath); err != nil {
 			return fmt.Errorf("failed to add repository: %w", err)
 		}
+		readonly, _ := cmd.Flags().GetBool("readonly")
+		if readonly {
+			if err := config.SetRepoReadOnly(configPath, repoPath, true); err != nil {
+				return fmt.Errorf("failed to mark repository read-only: %w", err)
+			}
+		}
 
 		if jsonOutput {
 			result := map[string]interface{}{
-				"added": true,
-				"path":  repoPath,
+				"added":    true,
+				"path":     repoPath,
+				"readonly": readonly,
 			}
 			return json.NewEncoder(os.Stdout).Encode(result)
 		}
@@ -184,6 +202,7 @@ repositories configured for hydration.`,
 			result := map[string]interface{}{
 				"primary":    primary,
 				"additional": repos.Additional,
+				"readonly":   repos.ReadOnly,
 			}
 			return json.NewEncoder(os.Stdout).Encode(result)
 		}
@@ -197,8 +216,16 @@ repositories configured for hydration.`,
 			fmt.Println("No additional repositories configured")
 		} else {
 			fmt.Println("\nAdditional repositories:")
+			readonly := make(map[string]bool, len(repos.ReadOnly))
+			for _, path := range repos.ReadOnly {
+				readonly[path] = true
+			}
 			for _, path := range repos.Additional {
-				fmt.Printf("  - %s\n", path)
+				if readonly[path] {
+					fmt.Printf("  - %s (read-only)\n", path)
+				} else {
+					fmt.Printf("  - %s\n", path)
+				}
 			}
 		}
 		return nil
@@ -249,6 +276,7 @@ func init() {
 	repoCmd.AddCommand(repoSyncCmd)
 
 	repoAddCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON")
+	repoAddCmd.Flags().Bool("readonly", false, "Hydrate issues from this repo but never write to it")
 	repoRemoveCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON")
 	repoListCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON")
 	repoSyncCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON")
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/worklog"
+)
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	GroupID: "views",
+	Short:   "Reports over issue history",
+}
+
+var reportEstimatesCmd = &cobra.Command{
+	Use:   "estimates",
+	Short: "Compare logged work with estimates",
+	Long: `Compare actual time from work logs ('bd work') with EstimatedMinutes,
+grouped by issue type and by assignee.
+
+Only issues with both an estimate and completed work log entries are counted.
+By default only closed issues are included, since open work is still
+accumulating time. A ratio above 1.00 means the work took longer than
+estimated.
+
+Examples:
+  bd report estimates              # By type and by assignee
+  bd report estimates --by type    # One table only
+  bd report estimates --all        # Include open issues`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("report reads work logs directly"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		by, _ := cmd.Flags().GetString("by")
+		includeOpen, _ := cmd.Flags().GetBool("all")
+		if by != "" && by != "type" && by != "assignee" {
+			FatalErrorRespectJSON("invalid --by %q (expected type or assignee)", by)
+		}
+
+		report, err := buildEstimateReport(rootCtx, store, includeOpen)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		switch by {
+		case "type":
+			report.ByAssignee = nil
+		case "assignee":
+			report.ByType = nil
+		}
+
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		if len(report.ByType) == 0 && len(report.ByAssignee) == 0 {
+			fmt.Println("No issues with both an estimate and logged work")
+			return
+		}
+		if report.ByType != nil {
+			printEstimateRows("BY TYPE", report.ByType)
+		}
+		if report.ByAssignee != nil {
+			printEstimateRows("BY ASSIGNEE", report.ByAssignee)
+		}
+		fmt.Println()
+	},
+}
+
+// estimateReport is the JSON shape of 'bd report estimates'.
+type estimateReport struct {
+	ByType     []worklog.EstimateRow `json:"by_type,omitempty"`
+	ByAssignee []worklog.EstimateRow `json:"by_assignee,omitempty"`
+}
+
+// buildEstimateReport loads issues and their work logs and compares actuals
+// with estimates. Only closed issues are considered unless includeOpen is set.
+func buildEstimateReport(ctx context.Context, s storage.Storage, includeOpen bool) (*estimateReport, error) {
+	filter := types.IssueFilter{}
+	if !includeOpen {
+		closed := types.StatusClosed
+		filter.Status = &closed
+	}
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if issue.EstimatedMinutes != nil {
+			ids = append(ids, issue.ID)
+		}
+	}
+	logs, err := s.GetWorkLogsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+
+	return &estimateReport{
+		ByType:     worklog.CompareEstimates(issues, logs, worklog.ByType),
+		ByAssignee: worklog.CompareEstimates(issues, logs, worklog.ByAssignee),
+	}, nil
+}
+
+func printEstimateRows(title string, rows []worklog.EstimateRow) {
+	fmt.Printf("\n%s\n", ui.RenderBold(title))
+	fmt.Printf("  %-20s %6s %10s %10s %7s\n", "", "ISSUES", "ESTIMATE", "ACTUAL", "RATIO")
+	for _, row := range rows {
+		ratio := fmt.Sprintf("%7.2f", row.Ratio)
+		switch {
+		case row.Ratio > 1.25:
+			ratio = ui.RenderWarn(ratio)
+		case row.Ratio < 0.8:
+			ratio = ui.RenderAccent(ratio)
+		}
+		fmt.Printf("  %-20s %6d %10s %10s %s\n", row.Group, row.Issues,
+			formatWorkDuration(time.Duration(row.EstimatedMinutes)*time.Minute),
+			formatWorkDuration(time.Duration(row.ActualMinutes)*time.Minute), ratio)
+	}
+}
+
+func init() {
+	reportEstimatesCmd.Flags().String("by", "", "Only group by 'type' or 'assignee' (default: both)")
+	reportEstimatesCmd.Flags().Bool("all", false, "Include issues that are not closed")
+
+	reportCmd.AddCommand(reportEstimatesCmd)
+	rootCmd.AddCommand(reportCmd)
+}
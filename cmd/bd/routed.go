@@ -16,6 +16,7 @@ type RoutedResult struct {
 	Store      storage.Storage // The store that contains this issue (may be routed)
 	Routed     bool            // true if the issue was found via routing
 	ResolvedID string          // The resolved (full) issue ID
+	BeadsDir   string          // The .beads directory of Store (empty if unknown)
 	closeFn    func()          // Function to close routed storage (if any)
 }
 
@@ -54,6 +55,7 @@ func resolveAndGetIssueWithRouting(ctx context.Context, localStore storage.Stora
 			return nil, err
 		}
 		if result != nil {
+			result.BeadsDir = routedStorage.BeadsDir
 			result.closeFn = func() { _ = routedStorage.Close() }
 			return result, nil
 		}
@@ -61,7 +63,11 @@ func resolveAndGetIssueWithRouting(ctx context.Context, localStore storage.Stora
 	}
 
 	// Step 3: Fall back to local store
-	return resolveAndGetFromStore(ctx, localStore, id, false)
+	result, err := resolveAndGetFromStore(ctx, localStore, id, false)
+	if result != nil {
+		result.BeadsDir = beadsDir
+	}
+	return result, err
 }
 
 // resolveAndGetFromStore resolves a partial ID and gets the issue from a specific store.
@@ -0,0 +1,661 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/recur"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ScheduleLabelPrefix marks the root of every instance a schedule creates
+// (schedule:<id>), which is how earlier instances are found.
+const ScheduleLabelPrefix = "schedule:"
+
+// scheduleActor is recorded as the creator of instances made by the daemon.
+const scheduleActor = "schedule"
+
+// scheduleCursorPrefix keys the local metadata recording the last
+// occurrence each schedule handled in this clone.
+const scheduleCursorPrefix = "schedule_last."
+
+var scheduleIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// ScheduleRun is the outcome of one attempt to materialize an occurrence.
+type ScheduleRun struct {
+	ScheduleID string    `json:"schedule_id"`
+	Occurrence time.Time `json:"occurrence"`
+	IssueID    string    `json:"issue_id"`
+	Created    int       `json:"created,omitempty"`
+	Skipped    string    `json:"skipped,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:     "schedule",
+	GroupID: "issues",
+	Short:   "Create issues on a recurring schedule",
+	Long: `Manage recurring schedules that pour a formula or clone a template.
+
+Schedules are stored in .beads/config.yaml under 'schedules', so commit the
+file to share them with every clone. The daemon checks them each minute and
+materializes the latest due occurrence. Each instance gets an ID derived
+from the schedule and occurrence time, so clones that both see it due
+create the same issue and sync dedupes it.
+
+Rules are five-field cron ("0 9 * * MON", "@daily") or an RRULE
+("FREQ=WEEKLY;INTERVAL=2;BYDAY=FR"), evaluated in --tz (default UTC).
+An occurrence is skipped while an earlier instance is still open, unless the
+schedule allows overlap.
+
+Templates and formulas can use {{schedule_id}} and {{schedule_date}}
+(the occurrence date, YYYY-MM-DD).
+
+Examples:
+  bd schedule add deps-bump --rule "0 9 * * MON" --formula mol-deps-bump --due 2d
+  bd schedule add oncall --rule "FREQ=WEEKLY;BYDAY=FR;BYHOUR=15;BYMINUTE=0" --template bd-abc --tz Europe/Berlin
+  bd schedule list
+  bd schedule pause deps-bump
+  bd schedule run-now oncall`,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List schedules and their next occurrence",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, schedules := loadSchedules()
+		now := time.Now()
+
+		type scheduleEntry struct {
+			config.ScheduleConfig
+			Next *time.Time `json:"next,omitempty"`
+		}
+		entries := make([]scheduleEntry, 0, len(schedules))
+		for _, s := range schedules {
+			entry := scheduleEntry{ScheduleConfig: s}
+			if next, err := nextScheduleOccurrence(&s, now); err == nil && !next.IsZero() {
+				entry.Next = &next
+			}
+			entries = append(entries, entry)
+		}
+
+		if jsonOutput {
+			outputJSON(entries)
+			return
+		}
+		if len(entries) == 0 {
+			fmt.Println("No schedules configured")
+			fmt.Println("Add one with: bd schedule add <id> --rule <cron|rrule> --formula <name>")
+			return
+		}
+		for _, e := range entries {
+			state := ui.RenderPass("active")
+			if e.Paused {
+				state = ui.RenderWarn("paused")
+			}
+			fmt.Printf("%s  %s  %s\n", ui.RenderAccent(e.ID), state, scheduleTarget(&e.ScheduleConfig))
+			tz := e.Timezone
+			if tz == "" {
+				tz = "UTC"
+			}
+			fmt.Printf("    rule: %s (%s)\n", e.Rule, tz)
+			if e.Next != nil && !e.Paused {
+				fmt.Printf("    next: %s\n", e.Next.Format("2006-01-02 15:04 MST"))
+			}
+		}
+	},
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <id>",
+	Short: "Add a recurring schedule",
+	Long: `Add a schedule that pours a formula (--formula) or clones a proto
+issue (--template) on every occurrence of --rule.
+
+--lead creates each instance ahead of time, deferred until the occurrence.
+--due sets the instance's due date relative to the occurrence. Both take
+compact durations such as 6h, 2d or 1w.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("schedule add")
+		id := args[0]
+		rule, _ := cmd.Flags().GetString("rule")
+		formulaName, _ := cmd.Flags().GetString("formula")
+		template, _ := cmd.Flags().GetString("template")
+		varFlags, _ := cmd.Flags().GetStringArray("var")
+		startFlag, _ := cmd.Flags().GetString("start")
+		tz, _ := cmd.Flags().GetString("tz")
+		lead, _ := cmd.Flags().GetString("lead")
+		due, _ := cmd.Flags().GetString("due")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		allowOverlap, _ := cmd.Flags().GetBool("allow-overlap")
+
+		if !scheduleIDPattern.MatchString(id) {
+			FatalErrorRespectJSON("invalid schedule ID %q: use letters, digits, dash, underscore or dot", id)
+		}
+		if (formulaName == "") == (template == "") {
+			FatalErrorRespectJSON("specify exactly one of --formula or --template")
+		}
+
+		s := config.ScheduleConfig{
+			ID:           id,
+			Rule:         rule,
+			Formula:      formulaName,
+			Vars:         parseScheduleVars(varFlags),
+			Timezone:     tz,
+			Lead:         lead,
+			Due:          due,
+			Assignee:     assignee,
+			AllowOverlap: allowOverlap,
+			Start:        time.Now().UTC().Truncate(time.Second),
+		}
+		if startFlag != "" {
+			start, err := timeparsing.ParseRelativeTime(startFlag, time.Now())
+			if err != nil {
+				FatalErrorRespectJSON("invalid --start: %v", err)
+			}
+			s.Start = start.UTC()
+		}
+		if err := validateSchedule(&s); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if template != "" {
+			ctx := rootCtx
+			if err := ensureDirectMode("schedule add needs the template from the database"); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			protoID, err := utils.ResolvePartialID(ctx, store, template)
+			if err != nil {
+				FatalErrorRespectJSON("template '%s' not found", template)
+			}
+			proto, err := store.GetIssue(ctx, protoID)
+			if err != nil || proto == nil {
+				FatalErrorRespectJSON("template '%s' not found", template)
+			}
+			labels, _ := store.GetLabels(ctx, protoID)
+			proto.Labels = labels
+			if !isProto(proto) {
+				FatalErrorRespectJSON("%s is not a proto (missing '%s' label)", protoID, MoleculeLabel)
+			}
+			s.Template = protoID
+		} else if _, err := resolveAndCookFormulaWithVars(formulaName, scheduleFormulaPaths(beads.FindBeadsDir()), s.Vars); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		configPath, schedules := loadSchedules()
+		if existing, _ := config.FindSchedule(schedules, id); existing != nil {
+			FatalErrorRespectJSON("schedule %q already exists", id)
+		}
+		schedules = append(schedules, s)
+		if err := config.SetSchedulesInYAML(configPath, schedules); err != nil {
+			FatalErrorRespectJSON("saving schedule: %v", err)
+		}
+
+		next, _ := nextScheduleOccurrence(&s, time.Now())
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"added": s, "next": next})
+			return
+		}
+		fmt.Printf("%s Added schedule %s: %s\n", ui.RenderPass("✓"), id, scheduleTarget(&s))
+		if !next.IsZero() {
+			fmt.Printf("  Next occurrence: %s\n", next.Format("2006-01-02 15:04 MST"))
+		}
+		fmt.Printf("  Stored in %s; commit it to share with other clones\n", configPath)
+	},
+}
+
+var schedulePauseCmd = &cobra.Command{
+	Use:   "pause <id>",
+	Short: "Stop a schedule from creating instances",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setSchedulePaused(args[0], true)
+	},
+}
+
+var scheduleResumeCmd = &cobra.Command{
+	Use:   "resume <id>",
+	Short: "Resume a paused schedule",
+	Long: `Resume a paused schedule. Only the most recent occurrence missed while
+it was paused is created, on the next check; earlier ones are not backfilled.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setSchedulePaused(args[0], false)
+	},
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Delete a schedule (existing instances are kept)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("schedule remove")
+		configPath, schedules := loadSchedules()
+		_, i := config.FindSchedule(schedules, args[0])
+		if i < 0 {
+			FatalErrorRespectJSON("schedule %q not found", args[0])
+		}
+		schedules = append(schedules[:i], schedules[i+1:]...)
+		if err := config.SetSchedulesInYAML(configPath, schedules); err != nil {
+			FatalErrorRespectJSON("saving schedules: %v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"removed": args[0]})
+			return
+		}
+		fmt.Printf("%s Removed schedule %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+var scheduleRunNowCmd = &cobra.Command{
+	Use:   "run-now <id>",
+	Short: "Create an instance of a schedule immediately",
+	Long: `Create an instance now, outside the schedule's rule. The regular
+occurrences are unaffected. Like a scheduled run it is skipped while an
+earlier instance is open, unless --force is given or the schedule allows
+overlap. Works on paused schedules too.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("schedule run-now")
+		force, _ := cmd.Flags().GetBool("force")
+		ctx := rootCtx
+
+		_, schedules := loadSchedules()
+		s, _ := config.FindSchedule(schedules, args[0])
+		if s == nil {
+			FatalErrorRespectJSON("schedule %q not found", args[0])
+		}
+		if err := ensureDirectMode("schedule run-now requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		run, err := materializeSchedule(ctx, store, beads.FindBeadsDir(), s, time.Now(), force, actor)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if run.Created > 0 {
+			markDirtyAndScheduleFlush()
+		}
+		printScheduleRuns([]*ScheduleRun{run})
+	},
+}
+
+var scheduleTickCmd = &cobra.Command{
+	Use:   "tick",
+	Short: "Create every due instance now (what the daemon does each minute)",
+	Long: `Materialize every schedule whose latest occurrence is due and has not
+been handled in this clone. The daemon does this every minute; run it from
+cron or CI when no daemon is running.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("schedule tick")
+		ctx := rootCtx
+		_, schedules := loadSchedules()
+		if len(schedules) == 0 {
+			printScheduleRuns(nil)
+			return
+		}
+		if err := ensureDirectMode("schedule tick requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		runs := runDueSchedules(ctx, store, beads.FindBeadsDir(), schedules, time.Now(), actor)
+		for _, r := range runs {
+			if r.Created > 0 {
+				markDirtyAndScheduleFlush()
+				break
+			}
+		}
+		printScheduleRuns(runs)
+	},
+}
+
+// loadSchedules reads the schedules from this project's config.yaml.
+func loadSchedules() (string, []config.ScheduleConfig) {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		FatalErrorRespectJSON("no .beads directory found")
+	}
+	configPath := filepath.Join(beadsDir, "config.yaml")
+	schedules, err := config.GetSchedulesFromYAML(configPath)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return configPath, schedules
+}
+
+func setSchedulePaused(id string, paused bool) {
+	CheckReadonly("schedule pause")
+	configPath, schedules := loadSchedules()
+	s, _ := config.FindSchedule(schedules, id)
+	if s == nil {
+		FatalErrorRespectJSON("schedule %q not found", id)
+	}
+	s.Paused = paused
+	if err := config.SetSchedulesInYAML(configPath, schedules); err != nil {
+		FatalErrorRespectJSON("saving schedules: %v", err)
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"id": id, "paused": paused})
+		return
+	}
+	if paused {
+		fmt.Printf("%s Paused schedule %s\n", ui.RenderPass("✓"), id)
+	} else {
+		fmt.Printf("%s Resumed schedule %s\n", ui.RenderPass("✓"), id)
+	}
+}
+
+func printScheduleRuns(runs []*ScheduleRun) {
+	if jsonOutput {
+		if runs == nil {
+			runs = []*ScheduleRun{}
+		}
+		outputJSON(runs)
+		return
+	}
+	if len(runs) == 0 {
+		fmt.Println("Nothing due")
+		return
+	}
+	for _, r := range runs {
+		when := r.Occurrence.Format("2006-01-02 15:04 MST")
+		switch {
+		case r.Error != "":
+			fmt.Printf("%s %s (%s): %s\n", ui.RenderWarn("✗"), r.ScheduleID, when, r.Error)
+		case r.Skipped != "":
+			fmt.Printf("%s %s (%s): skipped, %s\n", ui.RenderMuted("-"), r.ScheduleID, when, r.Skipped)
+		default:
+			fmt.Printf("%s %s (%s): created %s (%d issues)\n", ui.RenderPass("✓"), r.ScheduleID, when, ui.RenderID(r.IssueID), r.Created)
+		}
+	}
+}
+
+// scheduleTarget describes what a schedule creates.
+func scheduleTarget(s *config.ScheduleConfig) string {
+	if s.Formula != "" {
+		return "formula " + s.Formula
+	}
+	return "template " + s.Template
+}
+
+func parseScheduleVars(flags []string) map[string]string {
+	if len(flags) == 0 {
+		return nil
+	}
+	vars := make(map[string]string, len(flags))
+	for _, v := range flags {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			FatalErrorRespectJSON("invalid variable format '%s', expected 'key=value'", v)
+		}
+		vars[parts[0]] = parts[1]
+	}
+	return vars
+}
+
+// validateSchedule checks everything about a schedule that does not need
+// the database: its rule, time zone and offsets.
+func validateSchedule(s *config.ScheduleConfig) error {
+	if _, _, err := scheduleRule(s); err != nil {
+		return err
+	}
+	for name, spec := range map[string]string{"lead": s.Lead, "due": s.Due} {
+		if spec != "" && (!timeparsing.IsCompactDuration(spec) || strings.HasPrefix(spec, "-")) {
+			return fmt.Errorf("invalid --%s %q: use a duration such as 6h, 2d or 1w", name, spec)
+		}
+	}
+	return nil
+}
+
+// scheduleRule parses a schedule's rule in its time zone.
+func scheduleRule(s *config.ScheduleConfig) (recur.Rule, *time.Location, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return nil, nil, err
+	}
+	rule, err := recur.Parse(s.Rule, s.Start, loc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schedule %s: invalid rule %q: %w", s.ID, s.Rule, err)
+	}
+	return rule, loc, nil
+}
+
+// scheduleOffset applies a compact duration (6h, 2d) to t; sign -1 subtracts.
+func scheduleOffset(spec string, t time.Time, sign int) time.Time {
+	if spec == "" {
+		return t
+	}
+	spec = strings.TrimPrefix(spec, "+")
+	if sign < 0 {
+		spec = "-" + spec
+	}
+	v, err := timeparsing.ParseCompactDuration(spec, t)
+	if err != nil {
+		return t
+	}
+	return v
+}
+
+// nextScheduleOccurrence returns the next occurrence after now (or at the
+// start, if that is later).
+func nextScheduleOccurrence(s *config.ScheduleConfig, now time.Time) (time.Time, error) {
+	rule, _, err := scheduleRule(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	from := now
+	if earliest := s.Start.Add(-time.Second); earliest.After(from) {
+		from = earliest
+	}
+	return rule.Next(from), nil
+}
+
+// dueScheduleOccurrence returns the latest occurrence in (after, now+lead],
+// or the zero time if nothing new is due.
+func dueScheduleOccurrence(s *config.ScheduleConfig, rule recur.Rule, after, now time.Time) time.Time {
+	if earliest := s.Start.Add(-time.Second); earliest.After(after) {
+		after = earliest
+	}
+	return recur.Latest(rule, after, scheduleOffset(s.Lead, now, 1))
+}
+
+// scheduleInstanceID derives the root ID of an occurrence's instance from
+// the schedule ID and occurrence time, so every clone picks the same one.
+func scheduleInstanceID(prefix, scheduleID string, occurrence time.Time) string {
+	sum := sha256.Sum256([]byte(scheduleID + "|" + occurrence.UTC().Format(time.RFC3339)))
+	return prefix + "-" + idgen.EncodeBase36(sum[:], 8)
+}
+
+// scheduleFormulaPaths puts the project's formulas first. The daemon runs
+// from inside .beads, so the working-directory default would miss them.
+func scheduleFormulaPaths(beadsDir string) []string {
+	var paths []string
+	if beadsDir != "" {
+		paths = append(paths, filepath.Join(beadsDir, "formulas"))
+	}
+	return append(paths, getFormulaSearchPaths()...)
+}
+
+// openScheduleInstances returns the unclosed instances of a schedule.
+func openScheduleInstances(ctx context.Context, s storage.Storage, scheduleID string) ([]*types.Issue, error) {
+	return s.SearchIssues(ctx, "", types.IssueFilter{
+		Labels:        []string{ScheduleLabelPrefix + scheduleID},
+		ExcludeStatus: []types.Status{types.StatusClosed},
+	})
+}
+
+// materializeSchedule creates the instance for one occurrence unless it
+// already exists or, without force, an earlier instance is still open.
+func materializeSchedule(ctx context.Context, s storage.Storage, beadsDir string, sched *config.ScheduleConfig, occurrence time.Time, force bool, actorName string) (*ScheduleRun, error) {
+	_, loc, err := scheduleRule(sched)
+	if err != nil {
+		return nil, err
+	}
+	occurrence = occurrence.In(loc).Truncate(time.Minute)
+	prefix, err := s.GetConfig(ctx, "issue_prefix")
+	if err != nil || prefix == "" {
+		return nil, fmt.Errorf("issue_prefix is not configured")
+	}
+
+	run := &ScheduleRun{
+		ScheduleID: sched.ID,
+		Occurrence: occurrence,
+		IssueID:    scheduleInstanceID(prefix+"-mol", sched.ID, occurrence),
+	}
+	if existing, err := s.GetIssue(ctx, run.IssueID); err != nil {
+		return nil, err
+	} else if existing != nil {
+		run.Skipped = "already created"
+		return run, nil
+	}
+	if !force && !sched.AllowOverlap {
+		open, err := openScheduleInstances(ctx, s, sched.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(open) > 0 {
+			ids := make([]string, len(open))
+			for i, issue := range open {
+				ids[i] = issue.ID
+			}
+			sort.Strings(ids)
+			run.Skipped = "previous instance still open: " + strings.Join(ids, ", ")
+			return run, nil
+		}
+	}
+
+	vars := make(map[string]string, len(sched.Vars)+2)
+	for k, v := range sched.Vars {
+		vars[k] = v
+	}
+	if _, ok := vars["schedule_id"]; !ok {
+		vars["schedule_id"] = sched.ID
+	}
+	if _, ok := vars["schedule_date"]; !ok {
+		vars["schedule_date"] = occurrence.Format("2006-01-02")
+	}
+
+	var subgraph *TemplateSubgraph
+	if sched.Formula != "" {
+		subgraph, err = resolveAndCookFormulaWithVars(sched.Formula, scheduleFormulaPaths(beadsDir), vars)
+	} else {
+		subgraph, err = loadTemplateSubgraph(ctx, s, sched.Template)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", sched.ID, err)
+	}
+	vars = applyVariableDefaults(vars, subgraph)
+	var missing []string
+	for _, v := range extractRequiredVariables(subgraph) {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("schedule %s: missing required variables: %s", sched.ID, strings.Join(missing, ", "))
+	}
+
+	opts := CloneOptions{
+		Vars:       vars,
+		Assignee:   sched.Assignee,
+		Actor:      actorName,
+		Prefix:     "mol",
+		RootID:     run.IssueID,
+		RootLabels: []string{ScheduleLabelPrefix + sched.ID},
+	}
+	if sched.Lead != "" {
+		deferUntil := occurrence
+		opts.DeferUntil = &deferUntil
+	}
+	if sched.Due != "" {
+		due := scheduleOffset(sched.Due, occurrence, 1)
+		opts.DueAt = &due
+	}
+	result, err := cloneSubgraph(ctx, s, subgraph, opts)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", sched.ID, err)
+	}
+	run.Created = result.Created
+	return run, nil
+}
+
+// runDueSchedules materializes the latest due occurrence of every active
+// schedule. Each schedule's cursor advances even when its occurrence is
+// skipped, so a skipped occurrence is not retried once the earlier
+// instance closes.
+func runDueSchedules(ctx context.Context, s storage.Storage, beadsDir string, schedules []config.ScheduleConfig, now time.Time, actorName string) []*ScheduleRun {
+	var runs []*ScheduleRun
+	for i := range schedules {
+		sched := &schedules[i]
+		if sched.Paused {
+			continue
+		}
+		rule, _, err := scheduleRule(sched)
+		if err != nil {
+			runs = append(runs, &ScheduleRun{ScheduleID: sched.ID, Error: err.Error()})
+			continue
+		}
+
+		cursorKey := scheduleCursorPrefix + sched.ID
+		var after time.Time
+		if raw, err := s.GetMetadata(ctx, cursorKey); err == nil && raw != "" {
+			after, _ = time.Parse(time.RFC3339, raw)
+		}
+		occurrence := dueScheduleOccurrence(sched, rule, after, now)
+		if occurrence.IsZero() {
+			continue
+		}
+
+		run, err := materializeSchedule(ctx, s, beadsDir, sched, occurrence, false, actorName)
+		if err != nil {
+			runs = append(runs, &ScheduleRun{ScheduleID: sched.ID, Occurrence: occurrence, Error: err.Error()})
+			continue // retried next tick
+		}
+		runs = append(runs, run)
+		if err := s.SetMetadata(ctx, cursorKey, occurrence.UTC().Format(time.RFC3339)); err != nil {
+			run.Error = fmt.Sprintf("saving schedule cursor: %v", err)
+		}
+	}
+	return runs
+}
+
+func init() {
+	scheduleAddCmd.Flags().String("rule", "", "Cron expression or RRULE (required)")
+	scheduleAddCmd.Flags().String("formula", "", "Formula to pour on each occurrence")
+	scheduleAddCmd.Flags().String("template", "", "Proto issue to clone on each occurrence")
+	scheduleAddCmd.Flags().StringArray("var", []string{}, "Variable substitution (key=value)")
+	scheduleAddCmd.Flags().String("assignee", "", "Assign each instance's root issue")
+	scheduleAddCmd.Flags().String("tz", "", "IANA time zone for the rule (default UTC)")
+	scheduleAddCmd.Flags().String("lead", "", "Create instances this long before the occurrence, deferred until it (e.g. 1d)")
+	scheduleAddCmd.Flags().String("due", "", "Set the due date this long after the occurrence (e.g. 2d)")
+	scheduleAddCmd.Flags().Bool("allow-overlap", false, "Create instances even while an earlier one is open")
+	scheduleAddCmd.Flags().String("start", "", "First time the schedule may fire (default now)")
+	_ = scheduleAddCmd.MarkFlagRequired("rule")
+
+	scheduleRunNowCmd.Flags().Bool("force", false, "Create the instance even if an earlier one is open")
+
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(schedulePauseCmd)
+	scheduleCmd.AddCommand(scheduleResumeCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	scheduleCmd.AddCommand(scheduleRunNowCmd)
+	scheduleCmd.AddCommand(scheduleTickCmd)
+	rootCmd.AddCommand(scheduleCmd)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// createScheduleProto creates a proto with one child step.
+func createScheduleProto(t *testing.T, ctx context.Context, s *sqlite.SQLiteStorage) string {
+	t.Helper()
+	root := &types.Issue{Title: "Deps bump {{schedule_date}}", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic}
+	if err := s.CreateIssue(ctx, root, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddLabel(ctx, root.ID, MoleculeLabel, "test"); err != nil {
+		t.Fatal(err)
+	}
+	child := &types.Issue{ID: root.ID + ".1", Title: "Run {{tool}}", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, child, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: root.ID, Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	return root.ID
+}
+
+func TestScheduleInstanceID(t *testing.T) {
+	occ := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	a := scheduleInstanceID("bd-mol", "deps", occ)
+	if a != scheduleInstanceID("bd-mol", "deps", occ.In(time.FixedZone("X", 3600))) {
+		t.Error("instance ID depends on the occurrence's zone")
+	}
+	if a == scheduleInstanceID("bd-mol", "deps", occ.Add(time.Minute)) || a == scheduleInstanceID("bd-mol", "other", occ) {
+		t.Error("instance IDs collide across occurrences or schedules")
+	}
+	if !strings.HasPrefix(a, "bd-mol-") || len(a) != len("bd-mol-")+8 {
+		t.Errorf("instance ID %q has unexpected shape", a)
+	}
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	ctx := context.Background()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	s := newTestStore(t, filepath.Join(beadsDir, "beads.db"))
+	defer s.Close()
+	protoID := createScheduleProto(t, ctx, s)
+
+	// Sunday 2026-10-18; the schedule fires Mondays at 09:00 UTC
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	schedules := []config.ScheduleConfig{{
+		ID:       "deps",
+		Rule:     "0 9 * * MON",
+		Template: protoID,
+		Vars:     map[string]string{"tool": "renovate"},
+		Due:      "2d",
+		Start:    start,
+	}}
+
+	if runs := runDueSchedules(ctx, s, beadsDir, schedules, start.Add(time.Hour), "test"); len(runs) != 0 {
+		t.Fatalf("nothing should be due before the first Monday, got %+v", runs)
+	}
+
+	monday := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	runs := runDueSchedules(ctx, s, beadsDir, schedules, monday, "test")
+	if len(runs) != 1 || runs[0].Created != 2 || runs[0].Error != "" {
+		t.Fatalf("first Monday runs = %+v", runs)
+	}
+	occ := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	rootID := runs[0].IssueID
+	if want := scheduleInstanceID("test-mol", "deps", occ); rootID != want {
+		t.Errorf("instance ID = %s, want %s", rootID, want)
+	}
+	root, err := s.GetIssue(ctx, rootID)
+	if err != nil || root == nil {
+		t.Fatalf("instance %s not created: %v", rootID, err)
+	}
+	if root.Title != "Deps bump 2026-10-19" {
+		t.Errorf("title = %q", root.Title)
+	}
+	if root.DueAt == nil || !root.DueAt.Equal(occ.AddDate(0, 0, 2)) {
+		t.Errorf("due_at = %v, want two days after the occurrence", root.DueAt)
+	}
+	child, _ := s.GetIssue(ctx, rootID+".1")
+	if child == nil || child.Title != "Run renovate" {
+		t.Errorf("child = %+v", child)
+	}
+
+	// The same tick again is a no-op, and re-running the occurrence (as
+	// another clone would) finds the existing instance
+	if runs := runDueSchedules(ctx, s, beadsDir, schedules, monday.Add(time.Minute), "test"); len(runs) != 0 {
+		t.Errorf("second tick runs = %+v", runs)
+	}
+	again, err := materializeSchedule(ctx, s, beadsDir, &schedules[0], occ, false, "test")
+	if err != nil || again.Skipped != "already created" {
+		t.Errorf("re-materialize = %+v, %v", again, err)
+	}
+
+	// Next Monday is skipped while the first instance is open
+	nextMonday := monday.AddDate(0, 0, 7)
+	runs = runDueSchedules(ctx, s, beadsDir, schedules, nextMonday, "test")
+	if len(runs) != 1 || !strings.Contains(runs[0].Skipped, rootID) {
+		t.Fatalf("overlapping run = %+v", runs)
+	}
+
+	// Once it is closed, the following Monday materializes again
+	if err := s.CloseIssue(ctx, rootID, "done", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+	runs = runDueSchedules(ctx, s, beadsDir, schedules, nextMonday.AddDate(0, 0, 7), "test")
+	if len(runs) != 1 || runs[0].Created != 2 {
+		t.Fatalf("run after close = %+v", runs)
+	}
+
+	// Paused schedules do nothing
+	schedules[0].Paused = true
+	if runs := runDueSchedules(ctx, s, beadsDir, schedules, nextMonday.AddDate(0, 0, 14), "test"); len(runs) != 0 {
+		t.Errorf("paused schedule runs = %+v", runs)
+	}
+}
+
+func TestScheduleLead(t *testing.T) {
+	ctx := context.Background()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	s := newTestStore(t, filepath.Join(beadsDir, "beads.db"))
+	defer s.Close()
+	protoID := createScheduleProto(t, ctx, s)
+
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	schedules := []config.ScheduleConfig{{
+		ID: "handoff", Rule: "0 9 * * MON", Template: protoID, Vars: map[string]string{"tool": "x"},
+		Lead: "1d", Start: start,
+	}}
+
+	// A day ahead, Sunday 09:00, the Monday instance is created but deferred
+	runs := runDueSchedules(ctx, s, beadsDir, schedules, time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC).Add(3*time.Hour), "test")
+	if len(runs) != 1 || runs[0].Created == 0 {
+		t.Fatalf("lead runs = %+v", runs)
+	}
+	root, _ := s.GetIssue(ctx, runs[0].IssueID)
+	want := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	if root == nil || root.DeferUntil == nil || !root.DeferUntil.Equal(want) {
+		t.Errorf("defer_until = %v, want %v", root.DeferUntil, want)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	valid := config.ScheduleConfig{ID: "x", Rule: "@daily", Due: "2d", Lead: "6h", Start: start}
+	if err := validateSchedule(&valid); err != nil {
+		t.Errorf("valid schedule rejected: %v", err)
+	}
+	for _, s := range []config.ScheduleConfig{
+		{ID: "x", Rule: "every day", Start: start},
+		{ID: "x", Rule: "@daily", Timezone: "Mars/Olympus", Start: start},
+		{ID: "x", Rule: "@daily", Due: "-1d", Start: start},
+		{ID: "x", Rule: "@daily", Lead: "soon", Start: start},
+	} {
+		if err := validateSchedule(&s); err == nil {
+			t.Errorf("validateSchedule(%+v) succeeded, want error", s)
+		}
+	}
+}
@@ -128,13 +128,15 @@ var showCmd = &cobra.Command{
 				}
 				if jsonOutput {
 					// Get labels and deps for JSON output
-					details := &types.IssueDetails{Issue: *issue}
+					details := &types.IssueDetails{Issue: *issue, SourceRepo: types.ExternalSourceRepo(issue)}
 					details.Labels, _ = issueStore.GetLabels(ctx, issue.ID)
 					if sqliteStore, ok := issueStore.(*sqlite.SQLiteStorage); ok {
 						details.Dependencies, _ = sqliteStore.GetDependenciesWithMetadata(ctx, issue.ID)
 						details.Dependents, _ = sqliteStore.GetDependentsWithMetadata(ctx, issue.ID)
 					}
 					details.Comments, _ = issueStore.GetIssueComments(ctx, issue.ID)
+					details.Attachments, _ = issueStore.GetAttachments(ctx, issue.ID)
+					details.WorkLogs, _ = issueStore.GetWorkLogs(ctx, issue.ID)
 					// Compute parent from dependencies
 					for _, dep := range details.Dependencies {
 						if dep.DependencyType == types.DepParentChild {
@@ -195,6 +197,7 @@ var showCmd = &cobra.Command{
 						os.Exit(1)
 					}
 					issue := &details.Issue
+					issue.SourceRepo = details.SourceRepo
 
 					if shortMode {
 						fmt.Println(formatShortIssue(issue))
@@ -296,6 +299,16 @@ var showCmd = &cobra.Command{
 						}
 					}
 
+					if len(details.Attachments) > 0 {
+						fmt.Printf("\n%s\n", ui.RenderBold("ATTACHMENTS"))
+						printAttachments(details.Attachments, blobStore())
+					}
+
+					if len(details.WorkLogs) > 0 {
+						fmt.Printf("\n%s\n", ui.RenderBold("WORK LOG"))
+						printWorkLogs(details.WorkLogs, details.EstimatedMinutes)
+					}
+
 					if len(details.Comments) > 0 {
 						fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
 						for _, comment := range details.Comments {
@@ -356,7 +369,7 @@ var showCmd = &cobra.Command{
 
 			if jsonOutput {
 				// Include labels, dependencies (with metadata), dependents (with metadata), and comments in JSON output
-				details := &types.IssueDetails{Issue: *issue}
+				details := &types.IssueDetails{Issue: *issue, SourceRepo: types.ExternalSourceRepo(issue)}
 				details.Labels, _ = issueStore.GetLabels(ctx, issue.ID)
 
 				// Get dependencies with metadata (dependency_type field)
@@ -376,6 +389,8 @@ var showCmd = &cobra.Command{
 				}
 
 				details.Comments, _ = issueStore.GetIssueComments(ctx, issue.ID)
+				details.Attachments, _ = issueStore.GetAttachments(ctx, issue.ID)
+				details.WorkLogs, _ = issueStore.GetWorkLogs(ctx, issue.ID)
 				// Compute parent from dependencies
 				for _, dep := range details.Dependencies {
 					if dep.DependencyType == types.DepParentChild {
@@ -500,6 +515,18 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show attachments
+			if attachments, _ := issueStore.GetAttachments(ctx, issue.ID); len(attachments) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("ATTACHMENTS"))
+				printAttachments(attachments, blobStore())
+			}
+
+			// Show work log
+			if workLogs, _ := issueStore.GetWorkLogs(ctx, issue.ID); len(workLogs) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("WORK LOG"))
+				printWorkLogs(workLogs, issue.EstimatedMinutes)
+			}
+
 			// Show comments
 			comments, _ := issueStore.GetIssueComments(ctx, issue.ID)
 			if len(comments) > 0 {
@@ -534,7 +561,7 @@ var showCmd = &cobra.Command{
 
 
 // formatShortIssue returns a compact one-line representation of an issue
-// Format: STATUS_ICON ID PRIORITY [Type] Title
+// Format: STATUS_ICON ID [⟨repo⟩] PRIORITY [Type] Title
 func formatShortIssue(issue *types.Issue) string {
 	statusIcon := ui.RenderStatusIcon(string(issue.Status))
 	priorityTag := ui.RenderPriority(issue.Priority)
@@ -552,13 +579,13 @@ func formatShortIssue(issue *types.Issue) string {
 	if issue.Status == types.StatusClosed {
 		return fmt.Sprintf("%s %s %s %s%s",
 			statusIcon,
-			ui.RenderMuted(issue.ID),
+			ui.RenderMuted(issue.ID+repoTag(issue)),
 			ui.RenderMuted(fmt.Sprintf("● P%d", issue.Priority)),
 			ui.RenderMuted(string(issue.IssueType)),
 			ui.RenderMuted(" "+issue.Title))
 	}
 
-	return fmt.Sprintf("%s %s %s %s%s", statusIcon, issue.ID, priorityTag, typeBadge, issue.Title)
+	return fmt.Sprintf("%s %s%s %s %s%s", statusIcon, issue.ID, renderRepoTag(issue), priorityTag, typeBadge, issue.Title)
 }
 
 // formatIssueHeader returns the Tufte-aligned header line
@@ -598,7 +625,7 @@ func formatIssueHeader(issue *types.Issue) string {
 }
 
 // formatIssueMetadata returns the metadata line(s) with grouped info
-// Format: Owner: user · Type: task
+// Format: Owner: user · Type: task [· Repo: path]
 //
 //	Created: 2026-01-06 · Updated: 2026-01-08
 func formatIssueMetadata(issue *types.Issue) string {
@@ -622,6 +649,9 @@ func formatIssueMetadata(issue *types.Issue) string {
 		typeStr = ui.TypeBugStyle.Render("bug")
 	}
 	metaParts = append(metaParts, fmt.Sprintf("Type: %s", typeStr))
+	if repo := types.ExternalSourceRepo(issue); repo != "" {
+		metaParts = append(metaParts, fmt.Sprintf("Repo: %s", repo))
+	}
 
 	if len(metaParts) > 0 {
 		lines = append(lines, strings.Join(metaParts, " · "))
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// slaActor is recorded on changes made by SLA escalation.
+const slaActor = "sla"
+
+// SLABreach is an issue that stayed open longer than its priority's SLA.
+type SLABreach struct {
+	IssueID   string    `json:"issue_id"`
+	Title     string    `json:"title"`
+	Priority  int       `json:"priority"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Window    string    `json:"window"`
+	Deadline  time.Time `json:"deadline"`
+	Escalated bool      `json:"escalated"` // Already carries the breach label
+}
+
+// SLAReport summarizes SLA compliance across the database.
+type SLAReport struct {
+	Windows      map[string]string `json:"windows"` // "p0" -> "24h"
+	OpenBreaches []*SLABreach      `json:"open_breaches"`
+	ClosedLate   int               `json:"closed_late"`
+	ClosedInTime int               `json:"closed_in_time"`
+}
+
+// SLAEscalation records what escalation did for one breach.
+type SLAEscalation struct {
+	IssueID     string   `json:"issue_id"`
+	Actions     []string `json:"actions"`
+	NewPriority *int     `json:"new_priority,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+var slaCmd = &cobra.Command{
+	Use:     "sla",
+	GroupID: "views",
+	Short:   "Show issues that breached their priority's SLA",
+	Long: `Report SLA compliance. An SLA is the time an issue of a given priority
+may stay open, measured from creation, configured per priority in
+config.yaml:
+
+  bd config set sla.p0 24h
+  bd config set sla.p1 3d
+
+Open issues past their deadline are breaches. With the daemon running,
+breaches are escalated once: they get the sla.label label (default
+sla-breach), plus the actions in sla.escalate:
+
+  bump     raise the priority by one level
+  notify   run the on_sla_breach hook and send mail via the mail delegate
+           to the assignee (or sla.notify-to)
+
+Examples:
+  bd sla                  # Open breaches and closed-late counts
+  bd sla escalate         # Escalate new breaches now (what the daemon does)`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.GetSLAConfig()
+		if len(cfg.Windows) == 0 {
+			if jsonOutput {
+				outputJSON(&SLAReport{Windows: map[string]string{}, OpenBreaches: []*SLABreach{}})
+				return
+			}
+			fmt.Println("No SLAs configured")
+			fmt.Println("Set one with: bd config set sla.p0 24h")
+			return
+		}
+		if err := ensureStoreActive(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		report, err := buildSLAReport(rootCtx, store, &cfg, time.Now())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		printSLAReport(report)
+	},
+}
+
+var slaEscalateCmd = &cobra.Command{
+	Use:   "escalate",
+	Short: "Escalate new SLA breaches now (what the daemon does)",
+	Long: `Label and escalate every open issue that breached its SLA and has not
+been escalated yet. The daemon does this every few minutes; run it from
+cron or CI when no daemon is running.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("sla escalate")
+		cfg := config.GetSLAConfig()
+		if len(cfg.Windows) == 0 {
+			FatalErrorRespectJSON("no SLAs configured (set sla.p0 .. sla.p4)")
+		}
+		if err := ensureDirectMode("sla escalate requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		escalations, err := escalateSLABreaches(ctx, store, &cfg, time.Now(), slaNotifier(ctx, store, beads.FindBeadsDir()))
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(escalations) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			if escalations == nil {
+				escalations = []*SLAEscalation{}
+			}
+			outputJSON(escalations)
+			return
+		}
+		if len(escalations) == 0 {
+			fmt.Println("No new SLA breaches")
+			return
+		}
+		for _, e := range escalations {
+			if e.Error != "" {
+				fmt.Printf("%s %s: %s\n", ui.RenderFail("✗"), ui.RenderID(e.IssueID), e.Error)
+				continue
+			}
+			fmt.Printf("%s %s: %s\n", ui.RenderWarn("!"), ui.RenderID(e.IssueID), strings.Join(e.Actions, ", "))
+		}
+	},
+}
+
+// slaDeadline returns when an issue's SLA expires, or false when its
+// priority has no SLA.
+func slaDeadline(cfg *config.SLAConfig, issue *types.Issue) (time.Time, string, bool) {
+	window, ok := cfg.Windows[issue.Priority]
+	if !ok {
+		return time.Time{}, "", false
+	}
+	deadline, err := timeparsing.ParseCompactDuration(window, issue.CreatedAt)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return deadline, window, true
+}
+
+// buildSLAReport checks every issue against its priority's SLA. Closed
+// issues count as late when they were closed after the deadline.
+func buildSLAReport(ctx context.Context, s storage.Storage, cfg *config.SLAConfig, now time.Time) (*SLAReport, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+
+	report := &SLAReport{Windows: make(map[string]string), OpenBreaches: []*SLABreach{}}
+	for p, w := range cfg.Windows {
+		report.Windows[fmt.Sprintf("p%d", p)] = w
+	}
+	for _, issue := range issues {
+		if issue.Status == types.StatusTombstone {
+			continue
+		}
+		deadline, window, ok := slaDeadline(cfg, issue)
+		if !ok {
+			continue
+		}
+		if issue.Status == types.StatusClosed {
+			if issue.ClosedAt != nil && issue.ClosedAt.After(deadline) {
+				report.ClosedLate++
+			} else {
+				report.ClosedInTime++
+			}
+			continue
+		}
+		if !now.After(deadline) {
+			continue
+		}
+		labels, err := s.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("reading labels of %s: %w", issue.ID, err)
+		}
+		report.OpenBreaches = append(report.OpenBreaches, &SLABreach{
+			IssueID:   issue.ID,
+			Title:     issue.Title,
+			Priority:  issue.Priority,
+			Assignee:  issue.Assignee,
+			Window:    window,
+			Deadline:  deadline,
+			Escalated: containsString(labels, cfg.Label),
+		})
+	}
+	sort.Slice(report.OpenBreaches, func(i, j int) bool {
+		return report.OpenBreaches[i].Deadline.Before(report.OpenBreaches[j].Deadline)
+	})
+	return report, nil
+}
+
+// escalateSLABreaches applies the configured escalation to each open breach
+// not yet carrying the breach label. The label goes on first, so each breach
+// is escalated once even if a later action fails. notify may be nil.
+func escalateSLABreaches(ctx context.Context, s storage.Storage, cfg *config.SLAConfig, now time.Time, notify func(*types.Issue, *SLABreach) error) ([]*SLAEscalation, error) {
+	report, err := buildSLAReport(ctx, s, cfg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var escalations []*SLAEscalation
+	for _, b := range report.OpenBreaches {
+		if b.Escalated {
+			continue
+		}
+		e := &SLAEscalation{IssueID: b.IssueID}
+		escalations = append(escalations, e)
+		if err := s.AddLabel(ctx, b.IssueID, cfg.Label, slaActor); err != nil {
+			e.Error = fmt.Sprintf("adding label: %v", err)
+			continue
+		}
+		e.Actions = append(e.Actions, "labeled "+cfg.Label)
+
+		for _, action := range cfg.Escalate {
+			if err := applySLAAction(ctx, s, action, b, e, notify); err != nil {
+				e.Error = err.Error()
+				break
+			}
+		}
+	}
+	return escalations, nil
+}
+
+func applySLAAction(ctx context.Context, s storage.Storage, action string, b *SLABreach, e *SLAEscalation, notify func(*types.Issue, *SLABreach) error) error {
+	switch action {
+	case "bump":
+		if b.Priority == 0 {
+			return nil
+		}
+		p := b.Priority - 1
+		if err := s.UpdateIssue(ctx, b.IssueID, map[string]interface{}{"priority": p}, slaActor); err != nil {
+			return fmt.Errorf("bumping priority: %w", err)
+		}
+		e.NewPriority = &p
+		e.Actions = append(e.Actions, fmt.Sprintf("priority P%d -> P%d", b.Priority, p))
+	case "notify":
+		if notify == nil {
+			return nil
+		}
+		issue, err := s.GetIssue(ctx, b.IssueID)
+		if err != nil || issue == nil {
+			return fmt.Errorf("loading %s: %v", b.IssueID, err)
+		}
+		if err := notify(issue, b); err != nil {
+			return fmt.Errorf("notifying: %w", err)
+		}
+		e.Actions = append(e.Actions, "notified")
+	}
+	return nil
+}
+
+// slaNotifier runs the on_sla_breach hook and, when a mail delegate is
+// configured, mails the assignee (or sla.notify-to) about the breach.
+func slaNotifier(ctx context.Context, s storage.Storage, beadsDir string) func(*types.Issue, *SLABreach) error {
+	runner := hooks.NewRunner(filepath.Join(beadsDir, "hooks"))
+	notifyTo := config.GetSLAConfig().NotifyTo
+	return func(issue *types.Issue, b *SLABreach) error {
+		if err := runner.RunSync(hooks.EventSLABreach, issue); err != nil {
+			return fmt.Errorf("on_sla_breach hook: %w", err)
+		}
+
+		delegate := mailDelegate(ctx, s)
+		to := issue.Assignee
+		if to == "" {
+			to = notifyTo
+		}
+		if delegate == "" || to == "" {
+			return nil
+		}
+		parts := strings.Fields(delegate)
+		subject := fmt.Sprintf("SLA breach: %s %s", issue.ID, issue.Title)
+		body := fmt.Sprintf("%s (P%d) has been open longer than its %s SLA (deadline %s).",
+			issue.ID, b.Priority, b.Window, b.Deadline.Format("2006-01-02 15:04 MST"))
+		args := append(parts[1:], "send", to, "-s", subject, "-m", body)
+		// #nosec G204 - the delegate comes from user configuration (mail.delegate)
+		out, err := exec.CommandContext(ctx, parts[0], args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s send: %v: %s", delegate, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+}
+
+func printSLAReport(r *SLAReport) {
+	keys := make([]string, 0, len(r.Windows))
+	for k := range r.Windows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	windows := make([]string, 0, len(keys))
+	for _, k := range keys {
+		windows = append(windows, strings.ToUpper(k)+" "+r.Windows[k])
+	}
+	fmt.Printf("SLAs: %s\n\n", strings.Join(windows, ", "))
+
+	if len(r.OpenBreaches) == 0 {
+		fmt.Printf("%s No open issues past their SLA\n", ui.RenderPass("✓"))
+	} else {
+		fmt.Printf("%s %d open issue(s) past their SLA:\n", ui.RenderFail("✗"), len(r.OpenBreaches))
+		now := time.Now()
+		for _, b := range r.OpenBreaches {
+			late := now.Sub(b.Deadline).Round(time.Minute)
+			line := fmt.Sprintf("  %s [P%d] %s — %s late (SLA %s)", ui.RenderID(b.IssueID), b.Priority, b.Title, late, b.Window)
+			if b.Escalated {
+				line += ui.RenderMuted(" escalated")
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Printf("\nClosed within SLA: %d, closed late: %d\n", r.ClosedInTime, r.ClosedLate)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	slaCmd.AddCommand(slaEscalateCmd)
+	rootCmd.AddCommand(slaCmd)
+}
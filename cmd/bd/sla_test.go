@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSLAReportAndEscalation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	defer s.Close()
+
+	now := time.Now()
+	create := func(title string, priority int, age time.Duration) *types.Issue {
+		at := now.Add(-age)
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask, CreatedAt: at, UpdatedAt: at}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		return issue
+	}
+	breached := create("p1 open for four days", 1, 4*24*time.Hour)
+	create("p1 open for a day", 1, 24*time.Hour)
+	create("p3 has no SLA", 3, 30*24*time.Hour)
+	closedLate := create("p1 closed late", 1, 5*24*time.Hour)
+	if err := s.CloseIssue(ctx, closedLate.ID, "done", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.SLAConfig{Windows: map[int]string{0: "24h", 1: "3d"}, Escalate: []string{"bump", "notify"}, Label: "sla-breach"}
+	report, err := buildSLAReport(ctx, s, cfg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.OpenBreaches) != 1 || report.OpenBreaches[0].IssueID != breached.ID {
+		t.Fatalf("open breaches = %+v", report.OpenBreaches)
+	}
+	if report.ClosedLate != 1 || report.ClosedInTime != 0 {
+		t.Errorf("closed late/in time = %d/%d", report.ClosedLate, report.ClosedInTime)
+	}
+
+	var notified []string
+	notify := func(issue *types.Issue, b *SLABreach) error {
+		notified = append(notified, issue.ID)
+		return nil
+	}
+	escalations, err := escalateSLABreaches(ctx, s, cfg, now, notify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(escalations) != 1 || escalations[0].Error != "" || escalations[0].NewPriority == nil || *escalations[0].NewPriority != 0 {
+		t.Fatalf("escalations = %+v", escalations)
+	}
+	if len(notified) != 1 || notified[0] != breached.ID {
+		t.Errorf("notified = %v", notified)
+	}
+	issue, _ := s.GetIssue(ctx, breached.ID)
+	labels, _ := s.GetLabels(ctx, breached.ID)
+	if issue.Priority != 0 || !containsString(labels, "sla-breach") {
+		t.Errorf("after escalation priority = %d, labels = %v", issue.Priority, labels)
+	}
+
+	// Escalation happens once, even though the bumped issue still breaches
+	escalations, err = escalateSLABreaches(ctx, s, cfg, now.Add(time.Hour), notify)
+	if err != nil || len(escalations) != 0 || len(notified) != 1 {
+		t.Errorf("second pass = %+v, %v, notified %v", escalations, err, notified)
+	}
+}
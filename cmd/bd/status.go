@@ -12,6 +12,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -20,6 +21,7 @@ import (
 type StatusOutput struct {
 	Summary        *types.Statistics      `json:"summary"`
 	RecentActivity *RecentActivitySummary `json:"recent_activity,omitempty"`
+	SLA            *SLAReport             `json:"sla,omitempty"`
 }
 
 // RecentActivitySummary represents activity from git history
@@ -42,7 +44,8 @@ var statusCmd = &cobra.Command{
 
 This command provides a summary of issue counts by state (open, in_progress,
 blocked, closed), ready work, extended statistics (tombstones, pinned issues,
-average lead time), and recent activity over the last 24 hours from git history.
+average lead time), SLA breaches when sla.p0..sla.p4 are configured, and
+recent activity over the last 24 hours from git history.
 
 Similar to how 'git status' shows working tree state, 'bd status' gives you
 a quick overview of your issue database without needing multiple queries.
@@ -124,6 +127,7 @@ Examples:
 		output := &StatusOutput{
 			Summary:        stats,
 			RecentActivity: recentActivity,
+			SLA:            getSLAReport(),
 		}
 
 		// JSON output
@@ -161,6 +165,17 @@ Examples:
 			}
 		}
 
+		if sla := output.SLA; sla != nil {
+			fmt.Printf("\nSLA:\n")
+			breaches := fmt.Sprintf("%d", len(sla.OpenBreaches))
+			if len(sla.OpenBreaches) > 0 {
+				breaches = ui.RenderFail(breaches) + " (see 'bd sla')"
+			}
+			fmt.Printf("  Open Breaches:          %s\n", breaches)
+			fmt.Printf("  Closed Within SLA:      %d\n", sla.ClosedInTime)
+			fmt.Printf("  Closed Late:            %d\n", sla.ClosedLate)
+		}
+
 		if recentActivity != nil {
 			fmt.Printf("\nRecent Activity (last %d hours):\n", recentActivity.HoursTracked)
 			fmt.Printf("  Commits:                %d\n", recentActivity.CommitCount)
@@ -180,6 +195,23 @@ Examples:
 	},
 }
 
+// getSLAReport returns SLA compliance, or nil when no SLA is configured or
+// the database can't be read directly.
+func getSLAReport() *SLAReport {
+	cfg := config.GetSLAConfig()
+	if len(cfg.Windows) == 0 {
+		return nil
+	}
+	if err := ensureStoreActive(); err != nil {
+		return nil
+	}
+	report, err := buildSLAReport(rootCtx, store, &cfg, time.Now())
+	if err != nil {
+		return nil
+	}
+	return report
+}
+
 // getGitActivity calculates activity stats from git log of issues.jsonl.
 // GH#1110: Now uses RepoContext to ensure git commands run in beads repo.
 func getGitActivity(hours int) *RecentActivitySummary {
@@ -909,13 +909,15 @@ If given a single issue (not an epic), it will be auto-wrapped:
 Examples:
   bd swarm create gt-epic-123                          # Create swarm for epic
   bd swarm create gt-epic-123 --coordinator=witness/   # With specific coordinator
-  bd swarm create gt-task-456                          # Auto-wrap single issue`,
+  bd swarm create gt-task-456                          # Auto-wrap single issue
+  bd swarm create gt-epic-123 --agents=gt-a,gt-b       # Assign the first wave by capability`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("swarm create")
 		ctx := rootCtx
 		coordinator, _ := cmd.Flags().GetString("coordinator")
 		force, _ := cmd.Flags().GetBool("force")
+		agentArgs, _ := cmd.Flags().GetStringSlice("agents")
 
 		// Swarm commands require direct store access
 		if store == nil {
@@ -1063,13 +1065,27 @@ Examples:
 			FatalErrorRespectJSON("failed to link swarm to epic: %v", err)
 		}
 
+		// Hand the first wave to the agents by capability
+		var wave *SwarmWaveAssignment
+		if len(agentArgs) > 0 {
+			wave, err = assignSwarmWave(ctx, store, epicID, resolveSwarmAgents(ctx, agentArgs))
+			if err != nil {
+				FatalErrorRespectJSON("failed to assign wave: %v", err)
+			}
+			markDirtyAndScheduleFlush()
+		}
+
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"swarm_id":    swarmMol.ID,
 				"epic_id":     epicID,
 				"coordinator": coordinator,
 				"analysis":    analysis,
-			})
+			}
+			if wave != nil {
+				result["wave"] = wave
+			}
+			outputJSON(result)
 		} else {
 			fmt.Printf("\n%s Created swarm molecule: %s\n", ui.RenderPass("✓"), ui.RenderID(swarmMol.ID))
 			fmt.Printf("   Epic: %s (%s)\n", epicID, epicTitle)
@@ -1079,6 +1095,10 @@ Examples:
 			fmt.Printf("   Total issues: %d\n", analysis.TotalIssues)
 			fmt.Printf("   Max parallelism: %d\n", analysis.MaxParallelism)
 			fmt.Printf("   Waves: %d\n", len(analysis.ReadyFronts))
+			if wave != nil {
+				fmt.Println()
+				printSwarmWaveAssignment(wave)
+			}
 		}
 	},
 }
@@ -1212,6 +1232,7 @@ func init() {
 	swarmValidateCmd.Flags().Bool("verbose", false, "Include detailed issue graph in output")
 	swarmCreateCmd.Flags().String("coordinator", "", "Coordinator address (e.g., gastown/witness)")
 	swarmCreateCmd.Flags().Bool("force", false, "Create new swarm even if one already exists")
+	swarmCreateCmd.Flags().StringSlice("agents", nil, "Agent bead IDs to assign the first wave to by capability (see bd swarm assign)")
 
 	swarmCmd.AddCommand(swarmValidateCmd)
 	swarmCmd.AddCommand(swarmStatusCmd)
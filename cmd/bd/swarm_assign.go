@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// SwarmWaveAssignment records the agents given work from an epic's ready wave.
+type SwarmWaveAssignment struct {
+	EpicID     string           `json:"epic_id"`
+	Assigned   []SwarmWaveEntry `json:"assigned"`
+	Unroutable []string         `json:"unroutable,omitempty"` // Ready work no listed agent is qualified for
+}
+
+// SwarmWaveEntry is one issue handed to an agent.
+type SwarmWaveEntry struct {
+	IssueID string `json:"issue_id"`
+	Agent   string `json:"agent"`
+}
+
+var swarmAssignCmd = &cobra.Command{
+	Use:   "assign [epic-id]",
+	Short: "Assign an epic's ready wave to agents by capability",
+	Long: `Hand out the epic's current wave (its ready, unassigned children) to the
+given agents, using the same capability routing as 'bd ready --for'.
+
+Agents take turns picking their best match until the wave is handed out or
+no agent is qualified for what is left. Issues labeled needs:<cap> only go
+to agents that have <cap>; work nobody can take is reported as unroutable.
+Run it again as waves complete.
+
+Examples:
+  bd swarm assign gt-epic-123 --agents gt-agent-a,gt-agent-b
+  bd swarm create gt-epic-123 --agents gt-agent-a,gt-agent-b   # Create and assign`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("swarm assign")
+		ctx := rootCtx
+		agentArgs, _ := cmd.Flags().GetStringSlice("agents")
+		if len(agentArgs) == 0 {
+			FatalErrorRespectJSON("--agents is required")
+		}
+
+		// Swarm commands require direct store access
+		if store == nil {
+			if daemonClient != nil {
+				var err error
+				store, err = sqlite.New(ctx, dbPath)
+				if err != nil {
+					FatalErrorRespectJSON("failed to open database: %v", err)
+				}
+				defer func() { _ = store.Close() }()
+			} else {
+				FatalErrorRespectJSON("no database connection")
+			}
+		}
+
+		epicID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("epic '%s' not found: %v", args[0], err)
+		}
+		agents := resolveSwarmAgents(ctx, agentArgs)
+
+		result, err := assignSwarmWave(ctx, store, epicID, agents)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(result.Assigned) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		printSwarmWaveAssignment(result)
+	},
+}
+
+// resolveSwarmAgents resolves --agents to full agent bead IDs, exiting on
+// any that cannot be found.
+func resolveSwarmAgents(ctx context.Context, agentArgs []string) []string {
+	agents := make([]string, 0, len(agentArgs))
+	for _, a := range agentArgs {
+		id, err := utils.ResolvePartialID(ctx, store, a)
+		if err != nil {
+			FatalErrorRespectJSON("agent '%s' not found: %v", a, err)
+		}
+		agents = append(agents, id)
+	}
+	return agents
+}
+
+// assignSwarmWave assigns the epic's ready, unassigned work to agents. Each
+// round every agent takes the best remaining issue it is qualified for,
+// until a round assigns nothing.
+func assignSwarmWave(ctx context.Context, s storage.Storage, epicID string, agents []string) (*SwarmWaveAssignment, error) {
+	result := &SwarmWaveAssignment{EpicID: epicID, Assigned: []SwarmWaveEntry{}}
+	for {
+		assigned := false
+		for _, agent := range agents {
+			work, err := s.GetReadyWork(ctx, types.WorkFilter{
+				Status:     types.StatusOpen,
+				ParentID:   &epicID,
+				Unassigned: true,
+				ForAgent:   agent,
+				SortPolicy: types.SortPolicyPriority,
+				Limit:      1,
+			})
+			if err != nil {
+				return result, fmt.Errorf("finding work for %s: %w", agent, err)
+			}
+			if len(work) == 0 {
+				continue
+			}
+			if err := s.UpdateIssue(ctx, work[0].ID, map[string]interface{}{"assignee": agent}, actor); err != nil {
+				return result, fmt.Errorf("assigning %s to %s: %w", work[0].ID, agent, err)
+			}
+			result.Assigned = append(result.Assigned, SwarmWaveEntry{IssueID: work[0].ID, Agent: agent})
+			assigned = true
+		}
+		if !assigned {
+			break
+		}
+	}
+
+	left, err := s.GetReadyWork(ctx, types.WorkFilter{
+		Status:     types.StatusOpen,
+		ParentID:   &epicID,
+		Unassigned: true,
+		SortPolicy: types.SortPolicyPriority,
+	})
+	if err != nil {
+		return result, fmt.Errorf("listing unassigned work: %w", err)
+	}
+	for _, issue := range left {
+		result.Unroutable = append(result.Unroutable, issue.ID)
+	}
+	return result, nil
+}
+
+func printSwarmWaveAssignment(result *SwarmWaveAssignment) {
+	if len(result.Assigned) == 0 {
+		fmt.Printf("No ready work in %s could be assigned\n", result.EpicID)
+	}
+	for _, a := range result.Assigned {
+		fmt.Printf("%s %s → %s\n", ui.RenderPass("✓"), ui.RenderID(a.IssueID), a.Agent)
+	}
+	if len(result.Unroutable) > 0 {
+		fmt.Printf("%s No qualified agent for: %s\n", ui.RenderWarn("⚠"), strings.Join(result.Unroutable, ", "))
+	}
+}
+
+func init() {
+	swarmAssignCmd.Flags().StringSlice("agents", nil, "Agent bead IDs to hand the wave to (comma-separated)")
+	swarmCmd.AddCommand(swarmAssignCmd)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAssignSwarmWave(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+
+	create := func(title string, issueType types.IssueType, labels ...string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range labels {
+			if err := s.AddLabel(ctx, issue.ID, l, "test"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return issue
+	}
+	epic := create("Epic", types.TypeEpic)
+	goDev := create("go dev", types.TypeTask, "gt:agent", "can:go")
+	sqlDev := create("sql dev", types.TypeTask, "gt:agent", "can:go", "can:sql")
+
+	schema := create("schema", types.TypeTask, "needs:go", "needs:sql")
+	api := create("api", types.TypeTask, "needs:go")
+	docs := create("docs", types.TypeTask)
+	rust := create("rust port", types.TypeTask, "needs:rust")
+	later := create("later", types.TypeTask, "needs:go")
+	for _, child := range []*types.Issue{schema, api, docs, rust, later} {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// later is in the next wave
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: later.ID, DependsOnID: api.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := assignSwarmWave(ctx, s, epic.ID, []string{goDev.ID, sqlDev.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, a := range result.Assigned {
+		got[a.IssueID] = a.Agent
+	}
+	// sqlDev takes schema, its best match; goDev takes api, then docs
+	want := map[string]string{api.ID: goDev.ID, schema.ID: sqlDev.ID, docs.ID: goDev.ID}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("assigned = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(result.Unroutable, []string{rust.ID}) {
+		t.Errorf("unroutable = %v, want [%s]", result.Unroutable, rust.ID)
+	}
+	for id, agent := range want {
+		if issue, _ := s.GetIssue(ctx, id); issue.Assignee != agent {
+			t.Errorf("%s assignee = %q, want %q", id, issue.Assignee, agent)
+		}
+	}
+	if issue, _ := s.GetIssue(ctx, later.ID); issue.Assignee != "" {
+		t.Errorf("next-wave issue %s was assigned to %s", later.ID, issue.Assignee)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var syncDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose sync branch divergence and optionally repair it",
+	Long: `Check the sync branch against its remote without changing any issues.
+
+Reports:
+  - Commits ahead of and behind the remote
+  - Force-pushes since the last sync (remote history rewritten)
+  - Mass-delete safety: how many issues a content merge would keep
+  - Health of the sync branch worktree
+
+With --auto-repair, the safe strategy is applied after writing a recovery
+snapshot to .beads/sync-recovery/<timestamp>/:
+  fast-forward   remote is ahead only
+  content-merge  both sides have commits; merge the JSONL issue by issue
+  reset          remote was force-pushed; adopt its history (local issues
+                 stay in the database and are re-exported on the next sync)
+
+Divergence where a merge would fail or drop more than half the issues is
+never repaired automatically.
+
+Exits 1 if problems remain.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		autoRepair, _ := cmd.Flags().GetBool("auto-repair")
+		if autoRepair {
+			CheckReadonly("sync doctor --auto-repair")
+		}
+		ctx := rootCtx
+
+		// Like 'bd sync', always work against the database directly.
+		if daemonClient != nil {
+			if err := fallbackToDirectMode("sync doctor requires direct database access"); err != nil {
+				FatalErrorRespectJSON("failed to initialize direct mode: %v", err)
+			}
+		}
+		if err := ensureStoreActive(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		jsonlPath := findJSONLPath()
+		if jsonlPath == "" {
+			FatalErrorRespectJSON("not in a bd workspace (no .beads directory found)")
+		}
+		sbc := getSyncBranchContext(ctx)
+		if !sbc.IsConfigured() {
+			FatalErrorRespectJSON("no sync branch configured (see 'bd config set sync.branch <name>')")
+		}
+
+		report, err := runSyncDoctor(ctx, sbc, jsonlPath, autoRepair)
+		if err != nil && report == nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(report)
+		} else {
+			printSyncDoctorReport(report)
+		}
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if !report.OK() {
+			os.Exit(1)
+		}
+	},
+}
+
+// syncDoctorReport is the result of 'bd sync doctor'.
+type syncDoctorReport struct {
+	Health   *syncbranch.Health        `json:"health"`
+	Strategy syncbranch.RepairStrategy `json:"strategy"`
+	Reason   string                    `json:"reason"`
+	Repair   *syncbranch.RepairResult  `json:"repair,omitempty"`
+}
+
+// OK reports whether the sync branch is healthy or was repaired.
+func (r *syncDoctorReport) OK() bool {
+	if r.Repair != nil && r.Repair.Applied {
+		return true
+	}
+	return r.Health.OK()
+}
+
+// runSyncDoctor diagnoses the sync branch and, if autoRepair is set, applies
+// the recommended repair and imports the repaired JSONL. A non-nil report is
+// returned alongside repair errors so the diagnosis can still be shown.
+func runSyncDoctor(ctx context.Context, sbc *SyncBranchContext, jsonlPath string, autoRepair bool) (*syncDoctorReport, error) {
+	health, err := syncbranch.Diagnose(ctx, store, sbc.RepoRoot, sbc.Branch, jsonlPath)
+	if err != nil {
+		return nil, fmt.Errorf("diagnosing sync branch: %w", err)
+	}
+	report := &syncDoctorReport{Health: health}
+	report.Strategy, report.Reason = health.Strategy()
+	if !autoRepair {
+		return report, nil
+	}
+
+	report.Repair, err = syncbranch.Repair(ctx, store, sbc.RepoRoot, sbc.Branch, jsonlPath, health)
+	if err != nil {
+		return report, err
+	}
+	if report.Repair.Applied {
+		// Bring the database up to date with the repaired JSONL so the next
+		// sync exports the merged state rather than overwriting it.
+		if err := importFromJSONLInline(ctx, jsonlPath, false, false, false); err != nil {
+			return report, fmt.Errorf("repaired sync branch, but import failed (snapshot in %s): %w", report.Repair.Snapshot, err)
+		}
+	}
+	return report, nil
+}
+
+func printSyncDoctorReport(r *syncDoctorReport) {
+	h := r.Health
+	title := fmt.Sprintf("Sync Branch Doctor: %s", h.Branch)
+	if h.Remote != "" {
+		title += fmt.Sprintf(" (%s)", h.Remote)
+	}
+	fmt.Println(title)
+	fmt.Println("==================")
+
+	check := func(ok bool, name, detail string) {
+		mark := ui.RenderPass("✓")
+		if !ok {
+			mark = ui.RenderWarn("⚠")
+		}
+		fmt.Printf("  %s %-20s %s\n", mark, name, detail)
+	}
+
+	if h.WorktreeHealthy {
+		check(true, "Worktree", "healthy")
+	} else {
+		check(false, "Worktree", h.WorktreeError+" (recreated)")
+	}
+
+	switch {
+	case !h.RemoteExists:
+		check(true, "Divergence", "remote branch not pushed yet")
+	case h.Diverged:
+		detail := fmt.Sprintf("diverged: %d local, %d remote commit(s)", h.LocalAhead, h.RemoteAhead)
+		if h.Significant {
+			detail += " (significant)"
+		}
+		check(false, "Divergence", detail)
+	case h.RemoteAhead > 0:
+		check(true, "Divergence", fmt.Sprintf("remote %d commit(s) ahead", h.RemoteAhead))
+	case h.LocalAhead > 0:
+		check(true, "Divergence", fmt.Sprintf("local %d commit(s) ahead", h.LocalAhead))
+	default:
+		check(true, "Divergence", "in sync")
+	}
+
+	if h.ForcePush {
+		check(false, "Force push", h.ForcePushMessage)
+	} else {
+		check(true, "Force push", "none detected")
+	}
+
+	counts := fmt.Sprintf("local %d, remote %d", h.LocalIssues, h.RemoteIssues)
+	switch {
+	case h.MergeError != "":
+		check(false, "Mass-delete safety", "merge would fail: "+h.MergeError)
+	case h.MassDelete:
+		check(false, "Mass-delete safety", fmt.Sprintf("merge would keep only %d issues (%s)", h.MergedIssues, counts))
+	case h.Diverged:
+		check(true, "Mass-delete safety", fmt.Sprintf("merge keeps %d issues (%s)", h.MergedIssues, counts))
+	default:
+		check(true, "Mass-delete safety", counts)
+	}
+
+	for _, e := range h.Errors {
+		fmt.Printf("  %s %s\n", ui.RenderWarn("⚠"), e)
+	}
+
+	fmt.Println()
+	if r.Repair == nil {
+		if r.Strategy == syncbranch.RepairNone {
+			fmt.Printf("%s %s\n", ui.RenderPass("✓"), r.Reason)
+			return
+		}
+		fmt.Printf("Recommended repair: %s\n  %s\n", ui.RenderAccent(string(r.Strategy)), r.Reason)
+		if r.Strategy != syncbranch.RepairManual {
+			fmt.Printf("Run %s to apply it.\n", ui.RenderAccent("bd sync doctor --auto-repair"))
+		} else {
+			fmt.Println("Resolve this manually; see 'bd sync --check' and the sync branch reflog.")
+		}
+		return
+	}
+
+	switch {
+	case r.Repair.Applied:
+		fmt.Printf("%s Applied %s: %s\n", ui.RenderPass("✓"), r.Repair.Strategy, r.Repair.Reason)
+		fmt.Printf("  Recovery snapshot: %s\n", r.Repair.Snapshot)
+		fmt.Printf("  Run %s to push.\n", ui.RenderAccent("bd sync"))
+	case r.Repair.Strategy == syncbranch.RepairManual:
+		fmt.Printf("%s Not repaired automatically: %s\n", ui.RenderWarn("⚠"), r.Repair.Reason)
+	default:
+		fmt.Printf("%s Nothing to repair: %s\n", ui.RenderPass("✓"), r.Repair.Reason)
+	}
+}
+
+func init() {
+	syncDoctorCmd.Flags().Bool("auto-repair", false, "Apply the safe repair after writing a recovery snapshot")
+	syncCmd.AddCommand(syncDoctorCmd)
+}
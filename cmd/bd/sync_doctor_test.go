@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func syncDoctorJSONL(t *testing.T, ids ...string) string {
+	t.Helper()
+	var b strings.Builder
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range ids {
+		data, err := json.Marshal(&types.Issue{
+			ID: id, Title: "Issue " + id, Status: types.StatusOpen,
+			Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestSyncDoctorAutoRepairContentMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	syncBranch := "beads-sync"
+
+	remoteDir := t.TempDir()
+	runGitInDir(t, remoteDir, "init", "--bare")
+
+	repoDir := t.TempDir()
+	runGitInDir(t, repoDir, "init", "--initial-branch=main")
+	runGitInDir(t, repoDir, "config", "user.email", "test@test.com")
+	runGitInDir(t, repoDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, repoDir, "add", ".")
+	runGitInDir(t, repoDir, "commit", "-m", "initial")
+	runGitInDir(t, repoDir, "remote", "add", "origin", remoteDir)
+	runGitInDir(t, repoDir, "push", "origin", "main")
+
+	jsonlPath := filepath.Join(repoDir, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(jsonlPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, repoDir, "checkout", "-b", syncBranch)
+	if err := os.WriteFile(jsonlPath, []byte(syncDoctorJSONL(t, "test-1")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, repoDir, "add", ".beads/issues.jsonl")
+	runGitInDir(t, repoDir, "commit", "-m", "base")
+	runGitInDir(t, repoDir, "push", "origin", syncBranch)
+
+	// Another clone pushes test-2...
+	otherDir := filepath.Join(t.TempDir(), "other")
+	runGitInDir(t, filepath.Dir(otherDir), "clone", "--branch", syncBranch, remoteDir, otherDir)
+	runGitInDir(t, otherDir, "config", "user.email", "other@test.com")
+	runGitInDir(t, otherDir, "config", "user.name", "Other User")
+	if err := os.WriteFile(filepath.Join(otherDir, ".beads", "issues.jsonl"), []byte(syncDoctorJSONL(t, "test-1", "test-2")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, otherDir, "commit", "-am", "remote issue")
+	runGitInDir(t, otherDir, "push", "origin", syncBranch)
+
+	// ...while this clone commits test-3 to its sync branch.
+	if err := os.WriteFile(jsonlPath, []byte(syncDoctorJSONL(t, "test-1", "test-3")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitInDir(t, repoDir, "commit", "-am", "local issue")
+	runGitInDir(t, repoDir, "checkout", "main")
+
+	oldStore, oldDBPath := store, dbPath
+	defer func() { store, dbPath = oldStore, oldDBPath }()
+	dbPath = filepath.Join(repoDir, ".beads", "beads.db")
+	store = newTestStore(t, dbPath)
+	defer store.Close()
+
+	sbc := &SyncBranchContext{Branch: syncBranch, RepoRoot: repoDir}
+
+	report, err := runSyncDoctor(ctx, sbc, jsonlPath, false)
+	if err != nil {
+		t.Fatalf("runSyncDoctor() error = %v", err)
+	}
+	if report.Strategy != syncbranch.RepairContentMerge || report.Repair != nil {
+		t.Fatalf("diagnosis: strategy=%s repair=%+v", report.Strategy, report.Repair)
+	}
+	if report.OK() {
+		t.Error("diverged branch reported OK")
+	}
+
+	report, err = runSyncDoctor(ctx, sbc, jsonlPath, true)
+	if err != nil {
+		t.Fatalf("runSyncDoctor(autoRepair) error = %v", err)
+	}
+	if report.Repair == nil || !report.Repair.Applied {
+		t.Fatalf("repair not applied: %+v", report.Repair)
+	}
+	if !report.OK() {
+		t.Error("repaired branch not reported OK")
+	}
+	if _, err := os.Stat(filepath.Join(report.Repair.Snapshot, "manifest.json")); err != nil {
+		t.Errorf("recovery snapshot missing manifest: %v", err)
+	}
+
+	// Both sides' issues were imported into the database.
+	for _, id := range []string{"test-1", "test-2", "test-3"} {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			t.Errorf("issue %s not imported after repair (err=%v)", id, err)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -220,6 +221,16 @@ func exportToJSONLDeferred(ctx context.Context, jsonlPath string) (*ExportResult
 		issue.Comments = comments
 	}
 
+	// Populate attachment metadata for all issues (blob content stays in .beads/blobs)
+	if err := populateAttachments(ctx, store, issues); err != nil {
+		return nil, err
+	}
+
+	// Populate work logs for all issues
+	if err := populateWorkLogs(ctx, store, issues); err != nil {
+		return nil, err
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -257,6 +268,14 @@ func exportToJSONLDeferred(ctx context.Context, jsonlPath string) (*ExportResult
 		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
 	}
 
+	if err := shard.Export(jsonlPath); err != nil {
+		return nil, fmt.Errorf("failed to write JSONL shards: %w", err)
+	}
+
+	// Carry ID length pins alongside the issues
+	exportIDNamespaceBestEffort(ctx, store, filepath.Dir(jsonlPath))
+	signExportBestEffort(jsonlPath)
+
 	// Compute hash and time for the result (but don't update metadata yet)
 	contentHash, _ := computeJSONLHash(jsonlPath)
 	exportTime := time.Now().Format(time.RFC3339Nano)
@@ -408,6 +427,16 @@ func performIncrementalExport(ctx context.Context, jsonlPath string, dirtyIDs []
 		issue.Comments = commentsMap[issue.ID]
 	}
 
+	// Get attachment metadata for dirty issues (batch query)
+	if err := populateAttachments(ctx, store, dirtyIssues); err != nil {
+		return nil, err
+	}
+
+	// Get work logs for dirty issues (batch query)
+	if err := populateWorkLogs(ctx, store, dirtyIssues); err != nil {
+		return nil, err
+	}
+
 	// Update map with dirty issues
 	idSet := make(map[string]bool, len(allIDs))
 	for _, id := range allIDs {
@@ -495,6 +524,14 @@ func performIncrementalExport(ctx context.Context, jsonlPath string, dirtyIDs []
 		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
 	}
 
+	if err := shard.Export(jsonlPath); err != nil {
+		return nil, fmt.Errorf("failed to write JSONL shards: %w", err)
+	}
+
+	// Carry ID length pins alongside the issues
+	exportIDNamespaceBestEffort(ctx, store, filepath.Dir(jsonlPath))
+	signExportBestEffort(jsonlPath)
+
 	// Compute hash
 	contentHash, _ := computeJSONLHash(jsonlPath)
 	exportTime := time.Now().Format(time.RFC3339Nano)
@@ -10,8 +10,12 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/blobs"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/shard"
+	"github.com/steveyegge/beads/internal/signing"
 )
 
 // isGitRepo checks if the current working directory is in a git repository.
@@ -225,7 +229,7 @@ func gitCommit(ctx context.Context, filePath string, message string) error {
 
 // gitCommitBeadsDir stages and commits only sync-related files in .beads/
 // This ensures bd sync doesn't accidentally commit other staged files.
-// Only stages specific sync files (issues.jsonl, deletions.jsonl, metadata.json)
+// Only stages specific sync files (issues.jsonl, deletions.jsonl, namespace.jsonl, metadata.json)
 // to avoid staging gitignored snapshot files that may be tracked.
 // Uses RepoContext to ensure git commands run in the correct repository.
 // Handles worktrees and redirected beads directories.
@@ -237,12 +241,20 @@ func gitCommitBeadsDir(ctx context.Context, message string) error {
 
 	// Stage only the specific sync-related files
 	// This avoids staging gitignored snapshot files (beads.*.jsonl, *.meta.json)
-	// that may still be tracked from before they were added to .gitignore
+	// that may still be tracked from before they were added to .gitignore.
+	// Attachment blobs and the .gitattributes that routes them through
+	// git-lfs travel with the JSONL that references them. In the sharded
+	// layout the shard directory is tracked instead of issues.jsonl. Export
+	// signatures are committed with the records they cover.
 	syncFiles := []string{
-		filepath.Join(rc.BeadsDir, "issues.jsonl"),
+		shard.TrackedPath(filepath.Join(rc.BeadsDir, "issues.jsonl")),
 		filepath.Join(rc.BeadsDir, "deletions.jsonl"),
 		filepath.Join(rc.BeadsDir, "interactions.jsonl"),
+		filepath.Join(rc.BeadsDir, idgen.NamespaceFileName),
 		filepath.Join(rc.BeadsDir, "metadata.json"),
+		filepath.Join(rc.BeadsDir, blobs.DirName),
+		signing.Dir(rc.BeadsDir),
+		filepath.Join(rc.BeadsDir, ".gitattributes"),
 	}
 
 	// Only add files that exist
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,7 +12,9 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -79,6 +82,11 @@ func importFromJSONLInline(ctx context.Context, jsonlPath string, renameOnImport
 		return fmt.Errorf("no database store available for inline import")
 	}
 
+	// Sharded layout: import what git checked out, not a stale working copy
+	if err := shard.Sync(jsonlPath); err != nil {
+		return fmt.Errorf("failed to reconcile JSONL shards: %w", err)
+	}
+
 	// Read and parse the JSONL file
 	// #nosec G304 - jsonlPath is from findJSONLPath() which uses trusted paths
 	f, err := os.Open(jsonlPath)
@@ -87,7 +95,9 @@ func importFromJSONLInline(ctx context.Context, jsonlPath string, renameOnImport
 	}
 	defer func() { _ = f.Close() }()
 
+	verify := config.GetBool("import.verify")
 	var allIssues []*types.Issue
+	var rawLines [][]byte // Exact record bytes for import.verify, parallel to allIssues
 	scanner := bufio.NewScanner(f)
 	lineNum := 0
 
@@ -104,12 +114,21 @@ func importFromJSONLInline(ctx context.Context, jsonlPath string, renameOnImport
 		}
 		issue.SetDefaults()
 		allIssues = append(allIssues, &issue)
+		if verify {
+			rawLines = append(rawLines, bytes.Clone(scanner.Bytes()))
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return fmt.Errorf("error reading JSONL: %w", err)
 	}
 
+	if verify {
+		if allIssues, err = verifySignedImport(jsonlPath, allIssues, rawLines); err != nil {
+			return err
+		}
+	}
+
 	// Import using shared logic
 	opts := ImportOptions{
 		RenameOnImport: renameOnImport,
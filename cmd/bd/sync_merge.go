@@ -63,6 +63,8 @@ var FieldRules = map[string]FieldMergeRule{
 // - Labels: union of both
 // - Dependencies: union of both (by DependsOnID+Type)
 // - Comments: append from both (deduplicated by ID or content)
+// - Attachments: union of both (by blob hash)
+// - Work logs: union of both (by actor and start time, stopped entries win)
 func mergeFieldLevel(_base, local, remote *beads.Issue) *beads.Issue {
 	// Determine which is newer for LWW scalars
 	localNewer := local.UpdatedAt.After(remote.UpdatedAt)
@@ -94,9 +96,64 @@ func mergeFieldLevel(_base, local, remote *beads.Issue) *beads.Issue {
 	// Append merge: Comments (deduplicated)
 	merged.Comments = mergeComments(local.Comments, remote.Comments)
 
+	// Union merge: Attachments (by blob hash)
+	merged.Attachments = mergeAttachments(local.Attachments, remote.Attachments)
+
+	// Union merge: Work logs (by actor and start time)
+	merged.WorkLogs = mergeWorkLogs(local.WorkLogs, remote.WorkLogs)
+
 	return &merged
 }
 
+// mergeAttachments performs set union on attachments keyed by blob hash.
+// When both sides have the same blob, the local metadata wins.
+func mergeAttachments(local, remote []*beads.Attachment) []*beads.Attachment {
+	seen := make(map[string]bool)
+	var result []*beads.Attachment
+	for _, list := range [][]*beads.Attachment{local, remote} {
+		for _, a := range list {
+			if a == nil || seen[a.Hash] {
+				continue
+			}
+			seen[a.Hash] = true
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// mergeWorkLogs performs set union on work logs keyed by actor and start time.
+// When both sides have the same entry, a stopped timer wins over a running one
+// so a stop on either clone is never lost; otherwise the local entry wins.
+func mergeWorkLogs(local, remote []*beads.WorkLog) []*beads.WorkLog {
+	type key struct {
+		actor string
+		start int64
+	}
+	index := make(map[key]int)
+	var result []*beads.WorkLog
+	for _, list := range [][]*beads.WorkLog{local, remote} {
+		for _, w := range list {
+			if w == nil {
+				continue
+			}
+			k := key{w.Actor, w.StartedAt.Unix()}
+			if i, ok := index[k]; ok {
+				if result[i].IsRunning() && !w.IsRunning() {
+					result[i] = w
+				}
+				continue
+			}
+			index[k] = len(result)
+			result = append(result, w)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
 // mergeLabels performs set union on labels
 func mergeLabels(local, remote []string) []string {
 	seen := make(map[string]bool)
@@ -1409,3 +1409,30 @@ func TestMergeLabels(t *testing.T) {
 		})
 	}
 }
+
+// TestMergeWorkLogs tests that work logs are unioned and a stopped timer wins
+// over a stale running copy of the same entry
+func TestMergeWorkLogs(t *testing.T) {
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	local := []*types.WorkLog{
+		{Actor: "alice", StartedAt: start},
+		{Actor: "bob", StartedAt: start.Add(2 * time.Hour)},
+	}
+	remote := []*types.WorkLog{
+		{Actor: "carol", StartedAt: start.Add(-time.Hour)},
+		{Actor: "alice", StartedAt: start, EndedAt: &end, Note: "done"},
+	}
+
+	result := mergeWorkLogs(local, remote)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 work logs, got %d: %+v", len(result), result)
+	}
+	if result[0].Actor != "carol" || result[1].Actor != "alice" || result[2].Actor != "bob" {
+		t.Errorf("Expected entries sorted by start time, got %+v", result)
+	}
+	if result[1].IsRunning() || result[1].Note != "done" {
+		t.Errorf("Expected stopped remote entry to win, got %+v", result[1])
+	}
+}
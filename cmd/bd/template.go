@@ -52,6 +52,13 @@ type CloneOptions struct {
 	// Dynamic bonding fields (for Christmas Ornament pattern)
 	ParentID string // Parent molecule ID to bond under (e.g., "patrol-x7k")
 	ChildRef string // Child reference with variables (e.g., "arm-{{polecat_name}}")
+
+	// Fixed root ID (for schedules, whose instances dedupe by ID across clones).
+	// Other issues become hierarchical children of it.
+	RootID     string
+	RootLabels []string   // Labels added to the root issue
+	DueAt      *time.Time // Due date for the root issue
+	DeferUntil *time.Time // Hide the root issue from bd ready until this time
 }
 
 // bondedIDPattern validates bonded IDs (alphanumeric, dash, underscore, dot)
@@ -897,6 +904,18 @@ func generateBondedID(oldID string, rootID string, opts CloneOptions) (string, e
 	return newID, nil
 }
 
+// fixedRootChildID maps a template issue to its ID under a fixed root:
+// the root itself, or rootID.<relative> for its descendants.
+func fixedRootChildID(oldID, templateRootID, rootID string) string {
+	if oldID == templateRootID {
+		return rootID
+	}
+	if relativeID := getRelativeID(oldID, templateRootID); relativeID != "" {
+		return rootID + "." + relativeID
+	}
+	return rootID + "." + extractIDSuffix(oldID)
+}
+
 // extractIDSuffix extracts a suffix from an ID for use when IDs aren't hierarchical.
 // For "patrol-abc123", returns "abc123".
 // For "bd-xyz.1", returns "1".
@@ -969,6 +988,9 @@ func cloneSubgraph(ctx context.Context, s storage.Storage, subgraph *TemplateSub
 				Timeout:   oldIssue.Timeout,
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
+				// Source tracing (lets bd mol upgrade match steps later)
+				SourceFormula:  oldIssue.SourceFormula,
+				SourceLocation: oldIssue.SourceLocation,
 			}
 
 			// Generate custom ID for dynamic bonding if ParentID is set
@@ -978,11 +1000,24 @@ func cloneSubgraph(ctx context.Context, s storage.Storage, subgraph *TemplateSub
 					return fmt.Errorf("failed to generate bonded ID for %s: %w", oldIssue.ID, err)
 				}
 				newIssue.ID = bondedID
+			} else if opts.RootID != "" {
+				newIssue.ID = fixedRootChildID(oldIssue.ID, subgraph.Root.ID, opts.RootID)
+			}
+			if oldIssue.ID == subgraph.Root.ID {
+				newIssue.DueAt = opts.DueAt
+				newIssue.DeferUntil = opts.DeferUntil
 			}
 
 			if err := tx.CreateIssue(ctx, newIssue, opts.Actor); err != nil {
 				return fmt.Errorf("failed to create issue from %s: %w", oldIssue.ID, err)
 			}
+			if oldIssue.ID == subgraph.Root.ID {
+				for _, label := range opts.RootLabels {
+					if err := tx.AddLabel(ctx, newIssue.ID, label, opts.Actor); err != nil {
+						return fmt.Errorf("failed to label %s: %w", newIssue.ID, err)
+					}
+				}
+			}
 
 			idMapping[oldIssue.ID] = newIssue.ID
 		}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:     "tui",
+	GroupID: "views",
+	Short:   "Full-screen board, ready queue and issue inspector",
+	Long: `Open a full-screen terminal UI over the issue database.
+
+Views:
+  Board   Kanban columns for open, in_progress, blocked, deferred and closed
+  Ready   The ready queue ('bd ready'): open work with no open blockers
+
+The right-hand pane shows the selected issue with its labels, dependents and
+dependency tree. Press enter to expand it to the full screen.
+
+When a daemon is running the UI follows its mutation feed and refreshes as
+soon as anything changes. Without a daemon it reloads every --refresh interval.
+
+Keys:
+  tab            Switch between Board and Ready
+  ←/→  h/l       Move between columns
+  ↑/↓  k/j       Move within a column
+  enter          Expand or collapse the detail pane
+  > / <          Move the issue to the next/previous status column
+  L              Edit labels ("+urgent -triage", bare names are added)
+  D              Link a dependency ("bd-12" or "bd-12 related")
+  r              Reload now
+  q / ctrl+c     Quit`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+		if refresh <= 0 {
+			FatalError("--refresh must be positive")
+		}
+
+		var backend tuiBackend
+		if client := getDaemonClient(); client != nil {
+			backend = &daemonTUIBackend{client: client}
+		} else {
+			if err := ensureStoreActive(); err != nil {
+				FatalError("%v", err)
+			}
+			backend = &storeTUIBackend{store: getStore(), actor: actor}
+		}
+
+		model := newTUIModel(rootCtx, backend, refresh)
+		model.readonly = readonlyMode
+		if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(rootCtx)).Run(); err != nil {
+			FatalError("tui: %v", err)
+		}
+	},
+}
+
+// tuiBackend is everything the TUI reads and writes. It is served by the
+// daemon when one is running and by the local store otherwise.
+type tuiBackend interface {
+	// Issues returns all issues shown on the board.
+	Issues(ctx context.Context) ([]*types.Issue, error)
+	// Ready returns the ready queue.
+	Ready(ctx context.Context) ([]*types.Issue, error)
+	// Details returns an issue with labels, dependencies and dependents.
+	Details(ctx context.Context, id string) (*types.IssueDetails, error)
+	// Dependencies returns the direct dependencies of an issue.
+	Dependencies(ctx context.Context, id string) ([]*types.IssueWithDependencyMetadata, error)
+
+	SetStatus(ctx context.Context, id string, status types.Status) error
+	AddLabel(ctx context.Context, id, label string) error
+	RemoveLabel(ctx context.Context, id, label string) error
+	// AddDependency records that id depends on target (which may be a partial ID).
+	AddDependency(ctx context.Context, id, target string, depType types.DependencyType) (string, error)
+
+	// Changes reports whether anything changed since the given time, and the
+	// timestamp to poll from next. Live is false for backends without a
+	// mutation feed, which always report a change.
+	Changes(ctx context.Context, since time.Time) (changed bool, latest time.Time, err error)
+	Live() bool
+}
+
+// storeTUIBackend serves the TUI from the local store.
+type storeTUIBackend struct {
+	store storage.Storage
+	actor string
+}
+
+func (b *storeTUIBackend) Issues(ctx context.Context) ([]*types.Issue, error) {
+	return b.store.SearchIssues(ctx, "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusTombstone}})
+}
+
+func (b *storeTUIBackend) Ready(ctx context.Context) ([]*types.Issue, error) {
+	return b.store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
+}
+
+func (b *storeTUIBackend) Details(ctx context.Context, id string) (*types.IssueDetails, error) {
+	issue, err := b.store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	details := &types.IssueDetails{Issue: *issue}
+	if details.Labels, err = b.store.GetLabels(ctx, id); err != nil {
+		return nil, err
+	}
+	if details.Dependencies, err = b.store.GetDependenciesWithMetadata(ctx, id); err != nil {
+		return nil, err
+	}
+	if details.Dependents, err = b.store.GetDependentsWithMetadata(ctx, id); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+func (b *storeTUIBackend) Dependencies(ctx context.Context, id string) ([]*types.IssueWithDependencyMetadata, error) {
+	return b.store.GetDependenciesWithMetadata(ctx, id)
+}
+
+func (b *storeTUIBackend) SetStatus(ctx context.Context, id string, status types.Status) error {
+	issue, err := b.store.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if status == types.StatusClosed {
+		err = b.store.CloseIssue(ctx, id, "Closed", b.actor, "")
+	} else {
+		err = b.store.UpdateIssue(ctx, id, map[string]interface{}{"status": string(status)}, b.actor)
+	}
+	if err != nil {
+		return err
+	}
+	applyAutoTimer(ctx, b.store, id, issue.Status, status)
+	markDirtyAndScheduleFlush()
+	return nil
+}
+
+func (b *storeTUIBackend) AddLabel(ctx context.Context, id, label string) error {
+	if err := b.store.AddLabel(ctx, id, label, b.actor); err != nil {
+		return err
+	}
+	markDirtyAndScheduleFlush()
+	return nil
+}
+
+func (b *storeTUIBackend) RemoveLabel(ctx context.Context, id, label string) error {
+	if err := b.store.RemoveLabel(ctx, id, label, b.actor); err != nil {
+		return err
+	}
+	markDirtyAndScheduleFlush()
+	return nil
+}
+
+func (b *storeTUIBackend) AddDependency(ctx context.Context, id, target string, depType types.DependencyType) (string, error) {
+	targetID, err := utils.ResolvePartialID(ctx, b.store, target)
+	if err != nil {
+		return "", err
+	}
+	dep := &types.Dependency{IssueID: id, DependsOnID: targetID, Type: depType}
+	if err := b.store.AddDependency(ctx, dep, b.actor); err != nil {
+		return "", err
+	}
+	markDirtyAndScheduleFlush()
+	return targetID, nil
+}
+
+func (b *storeTUIBackend) Changes(ctx context.Context, since time.Time) (bool, time.Time, error) {
+	return true, time.Now(), nil
+}
+
+func (b *storeTUIBackend) Live() bool { return false }
+
+// daemonTUIBackend serves the TUI over RPC and follows the daemon's
+// mutation feed.
+type daemonTUIBackend struct {
+	client *rpc.Client
+}
+
+func (b *daemonTUIBackend) Issues(ctx context.Context) ([]*types.Issue, error) {
+	resp, err := b.client.List(&rpc.ListArgs{ExcludeStatus: []string{string(types.StatusTombstone)}})
+	if err != nil {
+		return nil, err
+	}
+	var withCounts []*types.IssueWithCounts
+	if err := json.Unmarshal(resp.Data, &withCounts); err != nil {
+		return nil, fmt.Errorf("parsing list response: %w", err)
+	}
+	issues := make([]*types.Issue, 0, len(withCounts))
+	for _, ic := range withCounts {
+		if ic.Issue != nil {
+			issues = append(issues, ic.Issue)
+		}
+	}
+	return issues, nil
+}
+
+func (b *daemonTUIBackend) Ready(ctx context.Context) ([]*types.Issue, error) {
+	resp, err := b.client.Ready(&rpc.ReadyArgs{})
+	if err != nil {
+		return nil, err
+	}
+	var withRepo []*types.IssueWithRepo
+	if err := json.Unmarshal(resp.Data, &withRepo); err != nil {
+		return nil, fmt.Errorf("parsing ready response: %w", err)
+	}
+	return unwrapIssuesWithRepo(withRepo), nil
+}
+
+func (b *daemonTUIBackend) Details(ctx context.Context, id string) (*types.IssueDetails, error) {
+	resp, err := b.client.Show(&rpc.ShowArgs{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	var details types.IssueDetails
+	if err := json.Unmarshal(resp.Data, &details); err != nil {
+		return nil, fmt.Errorf("parsing show response: %w", err)
+	}
+	return &details, nil
+}
+
+func (b *daemonTUIBackend) Dependencies(ctx context.Context, id string) ([]*types.IssueWithDependencyMetadata, error) {
+	details, err := b.Details(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return details.Dependencies, nil
+}
+
+func (b *daemonTUIBackend) SetStatus(ctx context.Context, id string, status types.Status) error {
+	if status == types.StatusClosed {
+		_, err := b.client.CloseIssue(&rpc.CloseArgs{ID: id, Reason: "Closed"})
+		return err
+	}
+	s := string(status)
+	_, err := b.client.Update(&rpc.UpdateArgs{ID: id, Status: &s})
+	return err
+}
+
+func (b *daemonTUIBackend) AddLabel(ctx context.Context, id, label string) error {
+	_, err := b.client.AddLabel(&rpc.LabelAddArgs{ID: id, Label: label})
+	return err
+}
+
+func (b *daemonTUIBackend) RemoveLabel(ctx context.Context, id, label string) error {
+	_, err := b.client.RemoveLabel(&rpc.LabelRemoveArgs{ID: id, Label: label})
+	return err
+}
+
+func (b *daemonTUIBackend) AddDependency(ctx context.Context, id, target string, depType types.DependencyType) (string, error) {
+	resp, err := b.client.ResolveID(&rpc.ResolveIDArgs{ID: target})
+	if err != nil {
+		return "", err
+	}
+	var targetID string
+	if err := json.Unmarshal(resp.Data, &targetID); err != nil {
+		return "", fmt.Errorf("parsing resolve response: %w", err)
+	}
+	_, err = b.client.AddDependency(&rpc.DepAddArgs{FromID: id, ToID: targetID, DepType: string(depType)})
+	return targetID, err
+}
+
+func (b *daemonTUIBackend) Changes(ctx context.Context, since time.Time) (bool, time.Time, error) {
+	var sinceMillis int64
+	if !since.IsZero() {
+		sinceMillis = since.UnixMilli()
+	}
+	resp, err := b.client.GetMutations(&rpc.GetMutationsArgs{Since: sinceMillis})
+	if err != nil {
+		return false, since, fmt.Errorf("failed to get mutations: %w", err)
+	}
+	var mutations []rpc.MutationEvent
+	if err := json.Unmarshal(resp.Data, &mutations); err != nil {
+		return false, since, fmt.Errorf("failed to parse mutations: %w", err)
+	}
+	latest := since
+	for _, m := range mutations {
+		if m.Timestamp.After(latest) {
+			latest = m.Timestamp
+		}
+	}
+	return len(mutations) > 0, latest, nil
+}
+
+func (b *daemonTUIBackend) Live() bool { return true }
+
+func init() {
+	tuiCmd.Flags().Duration("refresh", 2*time.Second, "How often to poll for changes")
+	rootCmd.AddCommand(tuiCmd)
+}
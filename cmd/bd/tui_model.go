@@ -0,0 +1,808 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// tuiBoardStatuses are the board columns, in the order '>' walks them.
+var tuiBoardStatuses = []types.Status{
+	types.StatusOpen,
+	types.StatusInProgress,
+	types.StatusBlocked,
+	types.StatusDeferred,
+	types.StatusClosed,
+}
+
+const (
+	tuiClosedLimit = 50 // most recently closed issues kept on the board
+	tuiTreeDepth   = 4  // levels of the dependency tree shown in the detail pane
+)
+
+type tuiView int
+
+const (
+	tuiViewBoard tuiView = iota
+	tuiViewReady
+)
+
+type tuiPrompt int
+
+const (
+	tuiPromptNone tuiPrompt = iota
+	tuiPromptLabels
+	tuiPromptDep
+)
+
+// tuiColumn is one status column of the board.
+type tuiColumn struct {
+	Status types.Status
+	Issues []*types.Issue
+}
+
+// tuiTreeLine is one row of the dependency tree in the detail pane.
+type tuiTreeLine struct {
+	Depth   int
+	Issue   *types.Issue
+	DepType types.DependencyType
+	Seen    bool // already expanded higher up; children are not repeated
+}
+
+type (
+	tuiLoadedMsg struct {
+		issues []*types.Issue
+		ready  []*types.Issue
+		err    error
+	}
+	tuiDetailMsg struct {
+		id      string
+		details *types.IssueDetails
+		tree    []tuiTreeLine
+		err     error
+	}
+	tuiTickMsg    time.Time
+	tuiChangesMsg struct {
+		changed bool
+		latest  time.Time
+		err     error
+	}
+	tuiActionMsg struct {
+		text string
+		err  error
+	}
+)
+
+// tuiModel is the bubbletea model behind 'bd tui'.
+type tuiModel struct {
+	ctx      context.Context
+	backend  tuiBackend
+	refresh  time.Duration
+	readonly bool
+
+	width, height int
+	view          tuiView
+	expanded      bool
+
+	columns  []tuiColumn
+	ready    []*types.Issue
+	col, row int
+	readyRow int
+
+	detailID string
+	details  *types.IssueDetails
+	tree     []tuiTreeLine
+
+	prompt tuiPrompt
+	input  textinput.Model
+
+	since    time.Time // mutation feed position
+	lastLoad time.Time
+	flash    string
+	flashErr bool
+}
+
+func newTUIModel(ctx context.Context, backend tuiBackend, refresh time.Duration) tuiModel {
+	input := textinput.New()
+	input.CharLimit = 200
+	input.Cursor.SetMode(cursor.CursorStatic)
+	return tuiModel{
+		ctx:     ctx,
+		backend: backend,
+		refresh: refresh,
+		columns: buildTUIColumns(nil, tuiClosedLimit),
+		input:   input,
+		since:   time.Now(),
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.loadCmd(), m.tickCmd())
+}
+
+func (m tuiModel) loadCmd() tea.Cmd {
+	ctx, backend := m.ctx, m.backend
+	return func() tea.Msg {
+		issues, err := backend.Issues(ctx)
+		if err != nil {
+			return tuiLoadedMsg{err: err}
+		}
+		ready, err := backend.Ready(ctx)
+		return tuiLoadedMsg{issues: issues, ready: ready, err: err}
+	}
+}
+
+func (m tuiModel) detailCmd(id string) tea.Cmd {
+	ctx, backend := m.ctx, m.backend
+	return func() tea.Msg {
+		details, err := backend.Details(ctx, id)
+		if err != nil {
+			return tuiDetailMsg{id: id, err: err}
+		}
+		tree, err := buildTUITree(ctx, backend.Dependencies, id, tuiTreeDepth)
+		return tuiDetailMsg{id: id, details: details, tree: tree, err: err}
+	}
+}
+
+func (m tuiModel) tickCmd() tea.Cmd {
+	return tea.Tick(m.refresh, func(t time.Time) tea.Msg { return tuiTickMsg(t) })
+}
+
+func (m tuiModel) changesCmd() tea.Cmd {
+	ctx, backend, since := m.ctx, m.backend, m.since
+	return func() tea.Msg {
+		changed, latest, err := backend.Changes(ctx, since)
+		return tuiChangesMsg{changed: changed, latest: latest, err: err}
+	}
+}
+
+// actionCmd runs a mutation and reports its outcome as a tuiActionMsg.
+func (m tuiModel) actionCmd(fn func(ctx context.Context) (string, error)) tea.Cmd {
+	ctx := m.ctx
+	return func() tea.Msg {
+		text, err := fn(ctx)
+		return tuiActionMsg{text: text, err: err}
+	}
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case tuiLoadedMsg:
+		if msg.err != nil {
+			m.setFlash(fmt.Sprintf("load failed: %v", msg.err), true)
+			return m, nil
+		}
+		selected := m.selectedID()
+		m.columns = buildTUIColumns(msg.issues, tuiClosedLimit)
+		m.ready = msg.ready
+		m.lastLoad = time.Now()
+		m.selectID(selected)
+		// Reloads mean something changed, so refresh the detail pane too.
+		if id := m.selectedID(); id != "" {
+			return m, m.detailCmd(id)
+		}
+		m.detailID, m.details, m.tree = "", nil, nil
+		return m, nil
+
+	case tuiDetailMsg:
+		if msg.id != m.selectedID() {
+			return m, nil // selection moved on while this was loading
+		}
+		if msg.err != nil {
+			m.setFlash(fmt.Sprintf("%s: %v", msg.id, msg.err), true)
+		}
+		m.detailID, m.details, m.tree = msg.id, msg.details, msg.tree
+		return m, nil
+
+	case tuiTickMsg:
+		return m, m.changesCmd()
+
+	case tuiChangesMsg:
+		if msg.err != nil {
+			m.setFlash(msg.err.Error(), true)
+			return m, m.tickCmd()
+		}
+		if !msg.latest.IsZero() {
+			m.since = msg.latest
+		}
+		if msg.changed {
+			return m, tea.Batch(m.loadCmd(), m.tickCmd())
+		}
+		return m, m.tickCmd()
+
+	case tuiActionMsg:
+		if msg.err != nil {
+			m.setFlash(msg.err.Error(), true)
+		} else {
+			m.setFlash(msg.text, false)
+		}
+		return m, m.loadCmd()
+
+	case tea.KeyMsg:
+		if m.prompt != tuiPromptNone {
+			return m.updatePrompt(msg)
+		}
+		return m.updateKeys(msg)
+	}
+	return m, nil
+}
+
+func (m tuiModel) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "tab":
+		if m.view == tuiViewBoard {
+			m.view = tuiViewReady
+		} else {
+			m.view = tuiViewBoard
+		}
+	case "left", "h":
+		if m.view == tuiViewBoard && m.col > 0 {
+			m.col--
+			m.row = clampTUIRow(m.row, len(m.columns[m.col].Issues))
+		}
+	case "right", "l":
+		if m.view == tuiViewBoard && m.col < len(m.columns)-1 {
+			m.col++
+			m.row = clampTUIRow(m.row, len(m.columns[m.col].Issues))
+		}
+	case "up", "k":
+		if m.view == tuiViewBoard {
+			m.row = clampTUIRow(m.row-1, len(m.columns[m.col].Issues))
+		} else {
+			m.readyRow = clampTUIRow(m.readyRow-1, len(m.ready))
+		}
+	case "down", "j":
+		if m.view == tuiViewBoard {
+			m.row = clampTUIRow(m.row+1, len(m.columns[m.col].Issues))
+		} else {
+			m.readyRow = clampTUIRow(m.readyRow+1, len(m.ready))
+		}
+	case "enter":
+		m.expanded = !m.expanded
+	case "esc":
+		m.expanded = false
+	case "r":
+		return m, m.loadCmd()
+	case ">", "<":
+		delta := 1
+		if msg.String() == "<" {
+			delta = -1
+		}
+		return m.moveStatus(delta)
+	case "L":
+		return m.openPrompt(tuiPromptLabels, "+label -label")
+	case "D":
+		return m.openPrompt(tuiPromptDep, "issue-id [type]")
+	default:
+		return m, nil
+	}
+	cmd := m.syncDetail()
+	return m, cmd
+}
+
+// syncDetail fetches details when the selection has moved to another issue.
+func (m *tuiModel) syncDetail() tea.Cmd {
+	id := m.selectedID()
+	if id == m.detailID {
+		return nil
+	}
+	if id == "" {
+		m.detailID, m.details, m.tree = "", nil, nil
+		return nil
+	}
+	return m.detailCmd(id)
+}
+
+func (m tuiModel) moveStatus(delta int) (tea.Model, tea.Cmd) {
+	issue := m.selected()
+	if issue == nil {
+		return m, nil
+	}
+	next, ok := shiftTUIStatus(issue.Status, delta)
+	if !ok {
+		return m, nil
+	}
+	if m.readonly {
+		m.setFlash("status changes are not allowed in read-only mode", true)
+		return m, nil
+	}
+	id, backend := issue.ID, m.backend
+	return m, m.actionCmd(func(ctx context.Context) (string, error) {
+		if err := backend.SetStatus(ctx, id, next); err != nil {
+			return "", fmt.Errorf("%s: %w", id, err)
+		}
+		return fmt.Sprintf("%s → %s", id, next), nil
+	})
+}
+
+func (m tuiModel) openPrompt(p tuiPrompt, placeholder string) (tea.Model, tea.Cmd) {
+	if m.selected() == nil {
+		return m, nil
+	}
+	if m.readonly {
+		m.setFlash("edits are not allowed in read-only mode", true)
+		return m, nil
+	}
+	m.prompt = p
+	m.input.SetValue("")
+	m.input.Placeholder = placeholder
+	return m, m.input.Focus()
+}
+
+func (m tuiModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.prompt = tuiPromptNone
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		p, value := m.prompt, m.input.Value()
+		m.prompt = tuiPromptNone
+		m.input.Blur()
+		return m.submitPrompt(p, value)
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m tuiModel) submitPrompt(p tuiPrompt, value string) (tea.Model, tea.Cmd) {
+	issue := m.selected()
+	if issue == nil {
+		return m, nil
+	}
+	id, backend := issue.ID, m.backend
+
+	switch p {
+	case tuiPromptLabels:
+		add, remove, err := parseTUILabelEdit(value)
+		if err != nil {
+			m.setFlash(err.Error(), true)
+			return m, nil
+		}
+		return m, m.actionCmd(func(ctx context.Context) (string, error) {
+			for _, label := range add {
+				if err := backend.AddLabel(ctx, id, label); err != nil {
+					return "", fmt.Errorf("adding %s to %s: %w", label, id, err)
+				}
+			}
+			for _, label := range remove {
+				if err := backend.RemoveLabel(ctx, id, label); err != nil {
+					return "", fmt.Errorf("removing %s from %s: %w", label, id, err)
+				}
+			}
+			return fmt.Sprintf("%s labels: %s", id, strings.TrimSpace(value)), nil
+		})
+
+	case tuiPromptDep:
+		target, depType, err := parseTUIDepLink(value)
+		if err != nil {
+			m.setFlash(err.Error(), true)
+			return m, nil
+		}
+		return m, m.actionCmd(func(ctx context.Context) (string, error) {
+			targetID, err := backend.AddDependency(ctx, id, target, depType)
+			if err != nil {
+				return "", fmt.Errorf("linking %s to %s: %w", id, target, err)
+			}
+			return fmt.Sprintf("%s depends on %s (%s)", id, targetID, depType), nil
+		})
+	}
+	return m, nil
+}
+
+func (m *tuiModel) setFlash(text string, isErr bool) {
+	m.flash, m.flashErr = text, isErr
+}
+
+// selected returns the issue under the cursor in the current view.
+func (m tuiModel) selected() *types.Issue {
+	if m.view == tuiViewReady {
+		if m.readyRow < len(m.ready) {
+			return m.ready[m.readyRow]
+		}
+		return nil
+	}
+	if m.col < len(m.columns) && m.row < len(m.columns[m.col].Issues) {
+		return m.columns[m.col].Issues[m.row]
+	}
+	return nil
+}
+
+func (m tuiModel) selectedID() string {
+	if issue := m.selected(); issue != nil {
+		return issue.ID
+	}
+	return ""
+}
+
+// selectID moves the cursor to id after a reload, following the issue to its
+// new column if its status changed. The cursor is clamped if it is gone.
+func (m *tuiModel) selectID(id string) {
+	if m.view == tuiViewReady {
+		for i, issue := range m.ready {
+			if issue.ID == id {
+				m.readyRow = i
+				return
+			}
+		}
+		m.readyRow = clampTUIRow(m.readyRow, len(m.ready))
+		return
+	}
+	for c, col := range m.columns {
+		for r, issue := range col.Issues {
+			if issue.ID == id {
+				m.col, m.row = c, r
+				return
+			}
+		}
+	}
+	m.row = clampTUIRow(m.row, len(m.columns[m.col].Issues))
+}
+
+// buildTUIColumns groups issues into board columns. Open columns are sorted
+// by priority then recency; closed keeps only the most recently closed.
+// Issues with statuses that have no column (pinned, hooked, custom) are left
+// off the board.
+func buildTUIColumns(issues []*types.Issue, closedLimit int) []tuiColumn {
+	columns := make([]tuiColumn, len(tuiBoardStatuses))
+	index := make(map[types.Status]int, len(tuiBoardStatuses))
+	for i, status := range tuiBoardStatuses {
+		columns[i].Status = status
+		index[status] = i
+	}
+	for _, issue := range issues {
+		if i, ok := index[issue.Status]; ok {
+			columns[i].Issues = append(columns[i].Issues, issue)
+		}
+	}
+	for i := range columns {
+		col := columns[i].Issues
+		if columns[i].Status == types.StatusClosed {
+			sort.SliceStable(col, func(a, b int) bool {
+				return closedTime(col[a]).After(closedTime(col[b]))
+			})
+			if len(col) > closedLimit {
+				columns[i].Issues = col[:closedLimit]
+			}
+			continue
+		}
+		sort.SliceStable(col, func(a, b int) bool {
+			if col[a].Priority != col[b].Priority {
+				return col[a].Priority < col[b].Priority
+			}
+			return col[a].UpdatedAt.After(col[b].UpdatedAt)
+		})
+	}
+	return columns
+}
+
+func closedTime(issue *types.Issue) time.Time {
+	if issue.ClosedAt != nil {
+		return *issue.ClosedAt
+	}
+	return issue.UpdatedAt
+}
+
+// shiftTUIStatus returns the board status delta columns away from status.
+// It reports false at either end of the board and for statuses with no column.
+func shiftTUIStatus(status types.Status, delta int) (types.Status, bool) {
+	for i, s := range tuiBoardStatuses {
+		if s != status {
+			continue
+		}
+		j := i + delta
+		if j < 0 || j >= len(tuiBoardStatuses) {
+			return "", false
+		}
+		return tuiBoardStatuses[j], true
+	}
+	return "", false
+}
+
+// parseTUILabelEdit parses the label prompt: "+name" or "name" adds, "-name"
+// removes. Separators may be spaces or commas.
+func parseTUILabelEdit(input string) (add, remove []string, err error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' })
+	for _, f := range fields {
+		switch {
+		case strings.HasPrefix(f, "-"):
+			if name := f[1:]; name != "" {
+				remove = append(remove, name)
+			}
+		case strings.HasPrefix(f, "+"):
+			if name := f[1:]; name != "" {
+				add = append(add, name)
+			}
+		default:
+			add = append(add, f)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, fmt.Errorf("no labels given (use +name to add, -name to remove)")
+	}
+	return add, remove, nil
+}
+
+// parseTUIDepLink parses the dependency prompt: "<id> [type]", where type
+// defaults to blocks.
+func parseTUIDepLink(input string) (string, types.DependencyType, error) {
+	fields := strings.Fields(input)
+	switch len(fields) {
+	case 1:
+		return fields[0], types.DepBlocks, nil
+	case 2:
+		depType := types.DependencyType(fields[1])
+		if !depType.IsValid() {
+			return "", "", fmt.Errorf("invalid dependency type %q", fields[1])
+		}
+		return fields[0], depType, nil
+	default:
+		return "", "", fmt.Errorf("expected <issue-id> [type]")
+	}
+}
+
+// buildTUITree walks dependencies depth-first from rootID. Issues reached a
+// second time are listed but not expanded again, which also stops cycles.
+func buildTUITree(ctx context.Context, deps func(context.Context, string) ([]*types.IssueWithDependencyMetadata, error), rootID string, maxDepth int) ([]tuiTreeLine, error) {
+	var lines []tuiTreeLine
+	seen := map[string]bool{rootID: true}
+	var walk func(id string, depth int) error
+	walk = func(id string, depth int) error {
+		if depth > maxDepth {
+			return nil
+		}
+		children, err := deps(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			issue := child.Issue
+			line := tuiTreeLine{Depth: depth, Issue: &issue, DepType: child.DependencyType, Seen: seen[issue.ID]}
+			lines = append(lines, line)
+			if line.Seen {
+				continue
+			}
+			seen[issue.ID] = true
+			if err := walk(issue.ID, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err := walk(rootID, 1)
+	return lines, err
+}
+
+func clampTUIRow(row, n int) int {
+	if row >= n {
+		row = n - 1
+	}
+	if row < 0 {
+		row = 0
+	}
+	return row
+}
+
+// truncateTUI shortens s to width runes, marking the cut with an ellipsis.
+func truncateTUI(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(r[:width-1]) + "…"
+}
+
+var (
+	tuiSelectedStyle = lipgloss.NewStyle().Reverse(true)
+	tuiTitleStyle    = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorAccent)
+	tuiTabStyle      = lipgloss.NewStyle().Padding(0, 1)
+	tuiActiveTab     = tuiTabStyle.Bold(true).Underline(true)
+)
+
+func (m tuiModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading…"
+	}
+	header := m.renderHeader()
+	footer := m.renderFooter()
+	bodyHeight := m.height - 2
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+
+	var body string
+	if m.expanded {
+		body = m.renderDetail(m.width, bodyHeight)
+	} else {
+		leftWidth := m.width * 3 / 5
+		rightWidth := m.width - leftWidth - 1
+		var left string
+		if m.view == tuiViewBoard {
+			left = m.renderBoard(leftWidth, bodyHeight)
+		} else {
+			left = m.renderReady(leftWidth, bodyHeight)
+		}
+		divider := ui.MutedStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", bodyHeight), "\n"))
+		body = lipgloss.JoinHorizontal(lipgloss.Top,
+			fixedTUIBox(left, leftWidth, bodyHeight), divider, fixedTUIBox(lipgloss.NewStyle().PaddingLeft(1).Render(m.renderDetail(rightWidth-1, bodyHeight)), rightWidth, bodyHeight))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, fixedTUIBox(body, m.width, bodyHeight), footer)
+}
+
+// fixedTUIBox pads or clips rendered content to exactly width x height.
+func fixedTUIBox(content string, width, height int) string {
+	return lipgloss.NewStyle().Width(width).Height(height).MaxWidth(width).MaxHeight(height).Render(content)
+}
+
+func (m tuiModel) renderHeader() string {
+	board, ready := tuiTabStyle.Render("Board"), tuiTabStyle.Render(fmt.Sprintf("Ready (%d)", len(m.ready)))
+	if m.view == tuiViewBoard {
+		board = tuiActiveTab.Render("Board")
+	} else {
+		ready = tuiActiveTab.Render(fmt.Sprintf("Ready (%d)", len(m.ready)))
+	}
+	mode := fmt.Sprintf("↻ every %s", m.refresh)
+	if m.backend != nil && m.backend.Live() {
+		mode = "● live"
+	}
+	if !m.lastLoad.IsZero() {
+		mode += " · " + m.lastLoad.Format("15:04:05")
+	}
+	left := tuiTitleStyle.Render(" beads ") + board + ready
+	gap := m.width - lipgloss.Width(left) - lipgloss.Width(mode) - 1
+	if gap < 1 {
+		gap = 1
+	}
+	return left + strings.Repeat(" ", gap) + ui.MutedStyle.Render(mode)
+}
+
+func (m tuiModel) renderFooter() string {
+	if m.prompt != tuiPromptNone {
+		label := "Labels: "
+		if m.prompt == tuiPromptDep {
+			label = "Depends on: "
+		}
+		return ui.AccentStyle.Render(label) + m.input.View()
+	}
+	if m.flash != "" {
+		if m.flashErr {
+			return ui.FailStyle.Render(truncateTUI(m.flash, m.width))
+		}
+		return ui.PassStyle.Render(truncateTUI(m.flash, m.width))
+	}
+	return ui.MutedStyle.Render(truncateTUI("tab view · ←→↑↓ move · enter detail · >/< status · L labels · D link dep · r reload · q quit", m.width))
+}
+
+func (m tuiModel) renderBoard(width, height int) string {
+	if len(m.columns) == 0 {
+		return ""
+	}
+	colWidth := width/len(m.columns) - 1
+	if colWidth < 4 {
+		colWidth = 4
+	}
+	visible := height - 1
+	rendered := make([]string, len(m.columns))
+	for c, col := range m.columns {
+		style := ui.GetStatusStyle(string(col.Status)).Bold(true)
+		lines := []string{style.Render(truncateTUI(fmt.Sprintf("%s %d", strings.ToUpper(string(col.Status)), len(col.Issues)), colWidth))}
+
+		offset := 0
+		if c == m.col && m.row >= visible {
+			offset = m.row - visible + 1
+		}
+		for r := offset; r < len(col.Issues) && r-offset < visible; r++ {
+			issue := col.Issues[r]
+			text := truncateTUI(fmt.Sprintf("%s P%d %s", issue.ID, issue.Priority, issue.Title), colWidth)
+			if c == m.col && r == m.row && m.view == tuiViewBoard {
+				text = tuiSelectedStyle.Render(text)
+			}
+			lines = append(lines, text)
+		}
+		rendered[c] = lipgloss.NewStyle().Width(colWidth + 1).Render(strings.Join(lines, "\n"))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (m tuiModel) renderReady(width, height int) string {
+	if len(m.ready) == 0 {
+		return ui.MutedStyle.Render("No ready work")
+	}
+	offset := 0
+	if m.readyRow >= height {
+		offset = m.readyRow - height + 1
+	}
+	var lines []string
+	for r := offset; r < len(m.ready) && r-offset < height; r++ {
+		issue := m.ready[r]
+		text := fmt.Sprintf("P%d %s [%s] %s", issue.Priority, issue.ID, issue.IssueType, issue.Title)
+		if issue.Assignee != "" {
+			text += " @" + issue.Assignee
+		}
+		text = truncateTUI(text, width)
+		if r == m.readyRow {
+			text = tuiSelectedStyle.Render(text)
+		}
+		lines = append(lines, text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m tuiModel) renderDetail(width, height int) string {
+	d := m.details
+	if d == nil {
+		return ui.MutedStyle.Render("No issue selected")
+	}
+	var b strings.Builder
+	b.WriteString(ui.RenderBold(truncateTUI(d.ID+"  "+d.Title, width)) + "\n")
+	meta := fmt.Sprintf("%s · P%d · %s", d.Status, d.Priority, d.IssueType)
+	if d.Assignee != "" {
+		meta += " · @" + d.Assignee
+	}
+	b.WriteString(ui.MutedStyle.Render(truncateTUI(meta, width)) + "\n")
+	if len(d.Labels) > 0 {
+		b.WriteString(truncateTUI("Labels: "+strings.Join(d.Labels, ", "), width) + "\n")
+	}
+
+	if d.Description != "" {
+		desc := d.Description
+		if !m.expanded {
+			if lines := strings.Split(desc, "\n"); len(lines) > 6 {
+				desc = strings.Join(lines[:6], "\n") + "\n…"
+			}
+		}
+		b.WriteString("\n" + lipgloss.NewStyle().Width(width).Render(desc) + "\n")
+	}
+
+	if len(m.tree) > 0 {
+		b.WriteString("\n" + ui.RenderBold("DEPENDS ON") + "\n")
+		for _, line := range m.tree {
+			text := fmt.Sprintf("%s↳ %s %s [%s]", strings.Repeat("  ", line.Depth-1), line.Issue.ID, line.Issue.Title, line.Issue.Status)
+			if line.DepType != types.DepBlocks {
+				text += " (" + string(line.DepType) + ")"
+			}
+			if line.Seen {
+				text += " ↺"
+			}
+			text = truncateTUI(text, width)
+			if line.Issue.Status == types.StatusClosed {
+				text = ui.MutedStyle.Render(text)
+			}
+			b.WriteString(text + "\n")
+		}
+	}
+
+	if len(d.Dependents) > 0 {
+		b.WriteString("\n" + ui.RenderBold("DEPENDENTS") + "\n")
+		for _, dep := range d.Dependents {
+			text := fmt.Sprintf("↰ %s %s [%s]", dep.ID, dep.Title, dep.Status)
+			if dep.DependencyType != types.DepBlocks {
+				text += " (" + string(dep.DependencyType) + ")"
+			}
+			b.WriteString(truncateTUI(text, width) + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
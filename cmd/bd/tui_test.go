@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildTUIColumns(t *testing.T) {
+	now := time.Now()
+	closedAt := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+	issues := []*types.Issue{
+		{ID: "bd-1", Status: types.StatusOpen, Priority: 2, UpdatedAt: now},
+		{ID: "bd-2", Status: types.StatusOpen, Priority: 0, UpdatedAt: now.Add(-time.Hour)},
+		{ID: "bd-3", Status: types.StatusOpen, Priority: 2, UpdatedAt: now.Add(time.Minute)},
+		{ID: "bd-4", Status: types.StatusInProgress},
+		{ID: "bd-5", Status: types.StatusClosed, ClosedAt: closedAt(3 * time.Hour)},
+		{ID: "bd-6", Status: types.StatusClosed, ClosedAt: closedAt(time.Hour)},
+		{ID: "bd-7", Status: types.StatusClosed, ClosedAt: closedAt(2 * time.Hour)},
+		{ID: "bd-8", Status: types.StatusPinned},
+	}
+
+	columns := buildTUIColumns(issues, 2)
+	if len(columns) != len(tuiBoardStatuses) {
+		t.Fatalf("got %d columns, want %d", len(columns), len(tuiBoardStatuses))
+	}
+	ids := func(c tuiColumn) []string {
+		var out []string
+		for _, issue := range c.Issues {
+			out = append(out, issue.ID)
+		}
+		return out
+	}
+	if got, want := ids(columns[0]), []string{"bd-2", "bd-3", "bd-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("open column = %v, want %v (priority, then most recently updated)", got, want)
+	}
+	if got, want := ids(columns[1]), []string{"bd-4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("in_progress column = %v, want %v", got, want)
+	}
+	if got, want := ids(columns[4]), []string{"bd-6", "bd-7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("closed column = %v, want %v (most recent, limited)", got, want)
+	}
+	for _, col := range columns {
+		for _, issue := range col.Issues {
+			if issue.ID == "bd-8" {
+				t.Errorf("pinned issue should not be on the board")
+			}
+		}
+	}
+}
+
+func TestShiftTUIStatus(t *testing.T) {
+	tests := []struct {
+		status types.Status
+		delta  int
+		want   types.Status
+		ok     bool
+	}{
+		{types.StatusOpen, 1, types.StatusInProgress, true},
+		{types.StatusInProgress, -1, types.StatusOpen, true},
+		{types.StatusDeferred, 1, types.StatusClosed, true},
+		{types.StatusOpen, -1, "", false},
+		{types.StatusClosed, 1, "", false},
+		{types.StatusPinned, 1, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := shiftTUIStatus(tt.status, tt.delta)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("shiftTUIStatus(%s, %d) = %q, %v; want %q, %v", tt.status, tt.delta, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseTUILabelEdit(t *testing.T) {
+	add, remove, err := parseTUILabelEdit("+urgent, -triage backend")
+	if err != nil {
+		t.Fatalf("parseTUILabelEdit: %v", err)
+	}
+	if !reflect.DeepEqual(add, []string{"urgent", "backend"}) || !reflect.DeepEqual(remove, []string{"triage"}) {
+		t.Errorf("got add=%v remove=%v", add, remove)
+	}
+	if _, _, err := parseTUILabelEdit("  + - "); err == nil {
+		t.Error("expected error for empty edit")
+	}
+}
+
+func TestParseTUIDepLink(t *testing.T) {
+	target, depType, err := parseTUIDepLink("bd-12")
+	if err != nil || target != "bd-12" || depType != types.DepBlocks {
+		t.Errorf("got %q %q %v, want bd-12 blocks", target, depType, err)
+	}
+	target, depType, err = parseTUIDepLink("bd-12 related")
+	if err != nil || target != "bd-12" || depType != types.DepRelated {
+		t.Errorf("got %q %q %v, want bd-12 related", target, depType, err)
+	}
+	if _, _, err := parseTUIDepLink(""); err == nil {
+		t.Error("expected error for empty input")
+	}
+	if _, _, err := parseTUIDepLink("bd-1 blocks extra"); err == nil {
+		t.Error("expected error for extra fields")
+	}
+}
+
+func TestBuildTUITree(t *testing.T) {
+	edges := map[string][]string{
+		"bd-1": {"bd-2", "bd-3"},
+		"bd-2": {"bd-4"},
+		"bd-3": {"bd-4"},
+		"bd-4": {"bd-1"}, // cycle back to the root
+	}
+	deps := func(_ context.Context, id string) ([]*types.IssueWithDependencyMetadata, error) {
+		var out []*types.IssueWithDependencyMetadata
+		for _, child := range edges[id] {
+			out = append(out, &types.IssueWithDependencyMetadata{Issue: types.Issue{ID: child}, DependencyType: types.DepBlocks})
+		}
+		return out, nil
+	}
+
+	lines, err := buildTUITree(context.Background(), deps, "bd-1", 10)
+	if err != nil {
+		t.Fatalf("buildTUITree: %v", err)
+	}
+	type row struct {
+		id    string
+		depth int
+		seen  bool
+	}
+	var got []row
+	for _, l := range lines {
+		got = append(got, row{l.Issue.ID, l.Depth, l.Seen})
+	}
+	want := []row{
+		{"bd-2", 1, false},
+		{"bd-4", 2, false},
+		{"bd-1", 3, true},
+		{"bd-3", 1, false},
+		{"bd-4", 2, true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %v, want %v", got, want)
+	}
+
+	shallow, _ := buildTUITree(context.Background(), deps, "bd-1", 1)
+	if len(shallow) != 2 {
+		t.Errorf("depth 1 tree has %d lines, want 2", len(shallow))
+	}
+}
+
+// runTUICmd executes a command and feeds its messages back into the model,
+// following batches, until no commands are left. Ticks are dropped.
+func runTUICmd(t *testing.T, m tuiModel, cmd tea.Cmd) tuiModel {
+	t.Helper()
+	queue := []tea.Cmd{cmd}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+		switch msg := next().(type) {
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		case tuiTickMsg, nil:
+		default:
+			updated, more := m.Update(msg)
+			m = updated.(tuiModel)
+			queue = append(queue, more)
+		}
+	}
+	return m
+}
+
+func pressTUIKeys(t *testing.T, m tuiModel, keys ...string) tuiModel {
+	t.Helper()
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		updated, cmd := m.Update(msg)
+		m = runTUICmd(t, updated.(tuiModel), cmd)
+	}
+	return m
+}
+
+func TestTUIModelEdits(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	work := &types.Issue{Title: "Work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{work, blocker} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+
+	m := newTUIModel(ctx, &storeTUIBackend{store: s, actor: "test"}, time.Millisecond)
+	m = runTUICmd(t, m, m.Init())
+	if got := m.selectedID(); got != work.ID {
+		t.Fatalf("selected %q, want highest priority issue %s", got, work.ID)
+	}
+	if m.details == nil || m.details.ID != work.ID {
+		t.Fatalf("detail pane not loaded for %s", work.ID)
+	}
+
+	// '>' moves the issue to in_progress and the cursor follows it.
+	m = pressTUIKeys(t, m, ">")
+	got, _ := s.GetIssue(ctx, work.ID)
+	if got.Status != types.StatusInProgress {
+		t.Fatalf("status = %s, want in_progress", got.Status)
+	}
+	if m.col != 1 || m.selectedID() != work.ID {
+		t.Errorf("cursor at column %d on %q, want column 1 on %s", m.col, m.selectedID(), work.ID)
+	}
+
+	m = pressTUIKeys(t, m, "L", "+urgent triage", "enter")
+	labels, _ := s.GetLabels(ctx, work.ID)
+	if !reflect.DeepEqual(labels, []string{"triage", "urgent"}) {
+		t.Errorf("labels = %v, want [triage urgent]", labels)
+	}
+	m = pressTUIKeys(t, m, "L", "-triage", "enter")
+	labels, _ = s.GetLabels(ctx, work.ID)
+	if !reflect.DeepEqual(labels, []string{"urgent"}) {
+		t.Errorf("labels = %v, want [urgent]", labels)
+	}
+
+	m = pressTUIKeys(t, m, "D", blocker.ID, "enter")
+	deps, _ := s.GetDependencies(ctx, work.ID)
+	if len(deps) != 1 || deps[0].ID != blocker.ID {
+		t.Fatalf("dependencies = %v, want %s", deps, blocker.ID)
+	}
+	if len(m.tree) != 1 || m.tree[0].Issue.ID != blocker.ID {
+		t.Errorf("dependency tree not refreshed: %+v", m.tree)
+	}
+	if m.flashErr {
+		t.Errorf("unexpected error flash: %s", m.flash)
+	}
+
+	// Read-only mode refuses edits without touching the store.
+	m.readonly = true
+	m = pressTUIKeys(t, m, "<")
+	got, _ = s.GetIssue(ctx, work.ID)
+	if got.Status != types.StatusInProgress || !m.flashErr {
+		t.Errorf("read-only status change: status=%s flashErr=%v", got.Status, m.flashErr)
+	}
+}
+
+// feedTUIBackend reports changes only when told to, like the daemon feed.
+type feedTUIBackend struct {
+	*storeTUIBackend
+	pending bool
+	loads   int
+}
+
+func (b *feedTUIBackend) Issues(ctx context.Context) ([]*types.Issue, error) {
+	b.loads++
+	return b.storeTUIBackend.Issues(ctx)
+}
+
+func (b *feedTUIBackend) Changes(ctx context.Context, since time.Time) (bool, time.Time, error) {
+	if !b.pending {
+		return false, since, nil
+	}
+	b.pending = false
+	return true, since.Add(time.Second), nil
+}
+
+func (b *feedTUIBackend) Live() bool { return true }
+
+func TestTUIModelLiveUpdates(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	backend := &feedTUIBackend{storeTUIBackend: &storeTUIBackend{store: s, actor: "test"}}
+
+	m := newTUIModel(ctx, backend, time.Millisecond)
+	m = runTUICmd(t, m, m.Init())
+	if backend.loads != 1 {
+		t.Fatalf("loads = %d after init, want 1", backend.loads)
+	}
+
+	// A quiet feed does not reload.
+	updated, cmd := m.Update(tuiTickMsg(time.Now()))
+	m = runTUICmd(t, updated.(tuiModel), cmd)
+	if backend.loads != 1 {
+		t.Errorf("loads = %d after quiet tick, want 1", backend.loads)
+	}
+
+	// Another writer creates an issue; the next tick picks it up.
+	issue := &types.Issue{Title: "From elsewhere", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, issue, "other"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	backend.pending = true
+	since := m.since
+	updated, cmd = m.Update(tuiTickMsg(time.Now()))
+	m = runTUICmd(t, updated.(tuiModel), cmd)
+	if backend.loads != 2 {
+		t.Errorf("loads = %d after change, want 2", backend.loads)
+	}
+	if !m.since.After(since) {
+		t.Errorf("feed position did not advance")
+	}
+	if m.selectedID() != issue.ID {
+		t.Errorf("selected %q, want new issue %s", m.selectedID(), issue.ID)
+	}
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	if view := updated.View(); !strings.Contains(view, issue.ID) {
+		t.Errorf("view does not show %s:\n%s", issue.ID, view)
+	}
+}
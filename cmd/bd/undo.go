@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var undoCmd = &cobra.Command{
+	Use:     "undo [count]",
+	GroupID: "issues",
+	Short:   "Revert your most recent changes",
+	Long: `Revert the most recent commands run by an actor, newest first, in one
+transaction.
+
+Every create, update, close, delete, label change and dependency change is
+recorded in the database with its inverse, tagged with the actor and the agent
+session (CLAUDE_SESSION_ID). Entries are kept for 7 days. Everything one
+command did is undone together: bd undo after bd delete restores the issue
+and the dependencies the delete removed.
+
+  bd undo              revert your last command
+  bd undo 3            revert your last 3 commands
+  bd undo --since 10m  revert everything you changed in the last 10 minutes
+
+Created issues become tombstones, deleted issues are restored with their
+dependencies, and updates and closes restore the previous field values.
+
+If an issue was changed since, by someone else or outside bd, nothing is
+reverted and the conflicts are listed. Use --force to revert anyway.
+
+The undo log is kept by the SQLite backend only; bd undo is not available
+with Dolt storage.
+
+Examples:
+  bd undo --dry-run
+  bd undo --actor agent-7 --since 1h
+  bd undo --session "$CLAUDE_SESSION_ID" --since 30m`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("undo")
+		undoActor, _ := cmd.Flags().GetString("actor")
+		session, _ := cmd.Flags().GetString("session")
+		since, _ := cmd.Flags().GetDuration("since")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if undoActor == "" {
+			undoActor = actor
+		}
+		filter := sqlite.UndoFilter{Actor: undoActor, Session: session}
+		if since > 0 {
+			filter.Since = time.Now().Add(-since)
+		} else {
+			filter.Limit = 1
+		}
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				FatalErrorRespectJSON("count must be a positive number, got %q", args[0])
+			}
+			filter.Limit = n
+		}
+
+		if err := ensureDirectMode("undo requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalErrorRespectJSON("undo requires SQLite storage")
+		}
+
+		result, err := sqliteStore.Undo(rootCtx, filter, actor, dryRun, force)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if result.Applied {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+		} else {
+			printUndoResult(result, undoActor, dryRun)
+		}
+		if len(result.Conflicts) > 0 && !force && !dryRun {
+			os.Exit(1)
+		}
+	},
+}
+
+func printUndoResult(result *sqlite.UndoResult, undoActor string, dryRun bool) {
+	if len(result.Entries) == 0 {
+		fmt.Printf("Nothing to undo for %s\n", undoActor)
+		return
+	}
+
+	switch {
+	case result.Applied:
+		fmt.Printf("%s Undid %d change(s) by %s:\n", ui.RenderPass("✓"), len(result.Entries), undoActor)
+	case dryRun:
+		fmt.Printf("Would undo %d change(s) by %s:\n", len(result.Entries), undoActor)
+	default:
+		fmt.Printf("%s Undid nothing: %d change(s) by %s conflict with later changes:\n",
+			ui.RenderFail("✗"), len(result.Entries), undoActor)
+	}
+	for _, e := range result.Entries {
+		fmt.Printf("  %s  %s  (%s)\n", ui.RenderID(e.IssueID), e.Summary, formatTimeAgo(e.CreatedAt))
+	}
+
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("\n%s\n", ui.RenderWarn("Conflicts:"))
+		for _, c := range result.Conflicts {
+			fmt.Printf("  %s  %s\n", ui.RenderID(c.IssueID), c.Reason)
+		}
+		if !result.Applied {
+			fmt.Println("\nRe-run with --force to undo anyway.")
+		}
+	}
+}
+
+func init() {
+	undoCmd.Flags().String("actor", "", "Undo this actor's changes (default: you)")
+	undoCmd.Flags().String("session", "", "Only undo changes made in this agent session")
+	undoCmd.Flags().Duration("since", 0, "Undo every change made within this long (e.g. 10m, 2h)")
+	undoCmd.Flags().Bool("dry-run", false, "Show what would be undone without changing anything")
+	undoCmd.Flags().Bool("force", false, "Undo even if issues changed since")
+	rootCmd.AddCommand(undoCmd)
+}
@@ -388,6 +388,9 @@ create, update, show, or close operation).`,
 
 				// Run update hook
 				updatedIssue, _ := issueStore.GetIssue(ctx, result.ResolvedID)
+				if updatedIssue != nil {
+					applyAutoTimer(ctx, issueStore, result.ResolvedID, issue.Status, updatedIssue.Status)
+				}
 				if updatedIssue != nil && hookRunner != nil {
 					hookRunner.Run(hooks.EventUpdate, updatedIssue)
 				}
@@ -550,6 +553,9 @@ create, update, show, or close operation).`,
 
 			// Run update hook
 			updatedIssue, _ := issueStore.GetIssue(ctx, result.ResolvedID)
+			if updatedIssue != nil {
+				applyAutoTimer(ctx, issueStore, result.ResolvedID, issue.Status, updatedIssue.Status)
+			}
 			if updatedIssue != nil && hookRunner != nil {
 				hookRunner.Run(hooks.EventUpdate, updatedIssue)
 			}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/worklog"
+)
+
+var workCmd = &cobra.Command{
+	Use:     "work",
+	GroupID: "issues",
+	Short:   "Track time spent on issues",
+	Long: `Record actual effort on issues as work log entries (actor, start, end, note).
+
+Timers are per actor, so several people can work on the same issue at once.
+Entries are synced through issues.jsonl with the rest of the issue. Compare
+logged time with EstimatedMinutes using 'bd report estimates'.
+
+Set work.auto-timer: true in config.yaml to start your timer automatically
+when an issue moves to in_progress, and stop it when the issue leaves
+in_progress or is closed.
+
+Examples:
+  bd work start bd-42                         # Start your timer
+  bd work stop bd-42 --note "fixed the flake" # Stop it
+  bd work log bd-42 --duration 45m --note "pairing"
+  bd work log bd-42                           # List entries`,
+}
+
+var workStartCmd = &cobra.Command{
+	Use:   "start <issue-id>",
+	Short: "Start a timer on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("work start")
+		if err := ensureDirectMode("work start writes work logs directly"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		note, _ := cmd.Flags().GetString("note")
+
+		entry, err := worklog.Start(ctx, store, issueID, actor, note, time.Now())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id": issueID,
+				"work_log": entry,
+			})
+			return
+		}
+		fmt.Printf("%s Started timer on %s at %s\n", ui.RenderPass("✓"), issueID, entry.StartedAt.Local().Format("15:04"))
+	},
+}
+
+var workStopCmd = &cobra.Command{
+	Use:   "stop <issue-id>",
+	Short: "Stop your running timer on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("work stop")
+		if err := ensureDirectMode("work stop writes work logs directly"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		note, _ := cmd.Flags().GetString("note")
+
+		entry, err := worklog.Stop(ctx, store, issueID, actor, note, time.Now())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id": issueID,
+				"work_log": entry,
+			})
+			return
+		}
+		fmt.Printf("%s Stopped timer on %s (%s)\n", ui.RenderPass("✓"), issueID, formatWorkDuration(entry.Duration(time.Now())))
+	},
+}
+
+var workLogCmd = &cobra.Command{
+	Use:   "log <issue-id>",
+	Short: "Record a completed entry, or list entries",
+	Long: `With --duration, record a completed work log entry ending now (or at --at).
+Without it, list the work log entries on the issue.
+
+Examples:
+  bd work log bd-42 --duration 1h30m --note "investigation"
+  bd work log bd-42 --duration 20m --at 2026-05-01T17:00
+  bd work log bd-42`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		durationStr, _ := cmd.Flags().GetString("duration")
+		if durationStr != "" {
+			CheckReadonly("work log")
+		}
+		if err := ensureDirectMode("work log reads and writes work logs directly"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+
+		if durationStr == "" {
+			logs, err := store.GetWorkLogs(ctx, issueID)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if jsonOutput {
+				if logs == nil {
+					logs = []*types.WorkLog{}
+				}
+				outputJSON(logs)
+				return
+			}
+			if len(logs) == 0 {
+				fmt.Printf("No work logged on %s\n", issueID)
+				return
+			}
+			issue, err := store.GetIssue(ctx, issueID)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			var estimate *int
+			if issue != nil {
+				estimate = issue.EstimatedMinutes
+			}
+			fmt.Printf("\n%s Work log for %s (%d):\n\n", ui.RenderAccent("⏱"), issueID, len(logs))
+			printWorkLogs(logs, estimate)
+			fmt.Println()
+			return
+		}
+
+		d, err := parseDurationString(durationStr)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --duration %q: %v", durationStr, err)
+		}
+		end := time.Now()
+		if at, _ := cmd.Flags().GetString("at"); at != "" {
+			end, err = parseWorkTime(at)
+			if err != nil {
+				FatalErrorRespectJSON("invalid --at %q: %v", at, err)
+			}
+		}
+		note, _ := cmd.Flags().GetString("note")
+
+		entry, err := worklog.Log(ctx, store, issueID, actor, note, d, end)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id": issueID,
+				"work_log": entry,
+			})
+			return
+		}
+		fmt.Printf("%s Logged %s on %s\n", ui.RenderPass("✓"), formatWorkDuration(d), issueID)
+	},
+}
+
+// applyAutoTimer starts or stops timers for a status change when
+// work.auto-timer is enabled. Failures only warn: the status change itself
+// has already been applied.
+func applyAutoTimer(ctx context.Context, s storage.Storage, issueID string, oldStatus, newStatus types.Status) {
+	if err := worklog.OnStatusChange(ctx, s, issueID, oldStatus, newStatus, actor); err != nil {
+		fmt.Fprintf(os.Stderr, "%s auto-timer for %s: %v\n", ui.RenderWarn("⚠"), issueID, err)
+	}
+}
+
+// parseWorkTime parses a local timestamp for --at (RFC3339 or YYYY-MM-DDTHH:MM).
+func parseWorkTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected RFC3339 or YYYY-MM-DDTHH:MM")
+}
+
+// populateWorkLogs fills Issue.WorkLogs for export with one batch query.
+func populateWorkLogs(ctx context.Context, s storage.Storage, issues []*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	all, err := s.GetWorkLogsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get work logs: %w", err)
+	}
+	for _, issue := range issues {
+		issue.WorkLogs = all[issue.ID]
+	}
+	return nil
+}
+
+// printWorkLogs renders work log entries one per line, followed by the total
+// and, when set, the estimate it compares against.
+func printWorkLogs(logs []*types.WorkLog, estimatedMinutes *int) {
+	now := time.Now()
+	for _, w := range logs {
+		span := formatWorkDuration(w.Duration(now))
+		if w.IsRunning() {
+			span = ui.RenderWarn(span + " (running)")
+		}
+		line := fmt.Sprintf("  %s  %s  %s", w.StartedAt.Local().Format("2006-01-02 15:04"), span, w.Actor)
+		if w.Note != "" {
+			line += "  " + ui.RenderMuted(w.Note)
+		}
+		fmt.Println(line)
+	}
+
+	total := fmt.Sprintf("  Total: %s", formatWorkDuration(worklog.Total(logs)))
+	if estimatedMinutes != nil && *estimatedMinutes > 0 {
+		total += ui.RenderMuted(fmt.Sprintf(" (estimate %s)", formatWorkDuration(time.Duration(*estimatedMinutes)*time.Minute)))
+	}
+	fmt.Println(total)
+}
+
+// formatWorkDuration renders a duration to the minute, e.g. "1h30m" or "45m".
+func formatWorkDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h := int(d / time.Hour)
+	m := int((d % time.Hour) / time.Minute)
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh%dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
+
+func init() {
+	workStartCmd.Flags().String("note", "", "Note describing the work")
+	workStopCmd.Flags().String("note", "", "Note describing the work (replaces the note given at start)")
+	workLogCmd.Flags().String("duration", "", "Record a completed entry of this length (e.g. 45m, 1h30m)")
+	workLogCmd.Flags().String("at", "", "End time of the entry (default: now)")
+	workLogCmd.Flags().String("note", "", "Note describing the work")
+
+	workCmd.AddCommand(workStartCmd)
+	workCmd.AddCommand(workStopCmd)
+	workCmd.AddCommand(workLogCmd)
+	rootCmd.AddCommand(workCmd)
+}
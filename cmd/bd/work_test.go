@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/worklog"
+)
+
+func TestBuildEstimateReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	estimate := 60
+	for _, issue := range []*types.Issue{
+		{ID: "test-1", Title: "closed bug", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, Assignee: "alice", EstimatedMinutes: &estimate},
+		{ID: "test-2", Title: "open bug", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, Assignee: "bob", EstimatedMinutes: &estimate},
+	} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	end := time.Date(2026, 5, 1, 17, 0, 0, 0, time.UTC)
+	for _, id := range []string{"test-1", "test-2"} {
+		if _, err := worklog.Log(ctx, s, id, "test", "", 90*time.Minute, end); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.CloseIssue(ctx, "test-1", "done", "test", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := buildEstimateReport(ctx, s, false)
+	if err != nil {
+		t.Fatalf("buildEstimateReport failed: %v", err)
+	}
+	if len(report.ByType) != 1 || report.ByType[0].Issues != 1 || report.ByType[0].Ratio != 1.5 {
+		t.Errorf("closed-only by type = %+v, want one bug at 1.5", report.ByType)
+	}
+	if len(report.ByAssignee) != 1 || report.ByAssignee[0].Group != "alice" {
+		t.Errorf("closed-only by assignee = %+v, want alice only", report.ByAssignee)
+	}
+
+	report, err = buildEstimateReport(ctx, s, true)
+	if err != nil {
+		t.Fatalf("buildEstimateReport failed: %v", err)
+	}
+	if len(report.ByType) != 1 || report.ByType[0].Issues != 2 {
+		t.Errorf("all by type = %+v, want both bugs", report.ByType)
+	}
+
+	// Export picks up the entries
+	issues := []*types.Issue{{ID: "test-1"}, {ID: "test-2"}}
+	if err := populateWorkLogs(ctx, s, issues); err != nil {
+		t.Fatal(err)
+	}
+	if len(issues[0].WorkLogs) != 1 || len(issues[1].WorkLogs) != 1 {
+		t.Errorf("populateWorkLogs = %+v, %+v", issues[0].WorkLogs, issues[1].WorkLogs)
+	}
+}
+
+func TestFormatWorkDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                               "0m",
+		45 * time.Minute:                "45m",
+		2 * time.Hour:                   "2h",
+		90*time.Minute + 20*time.Second: "1h30m",
+	}
+	for d, want := range tests {
+		if got := formatWorkDuration(d); got != want {
+			t.Errorf("formatWorkDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -74,6 +75,11 @@ func AutoImportIfNewer(ctx context.Context, store storage.Storage, dbPath string
 		return nil
 	}
 
+	// Sharded layout: rebuild the working copy if git changed the shards
+	if err := shard.Sync(jsonlPath); err != nil {
+		notify.Warnf("failed to reconcile JSONL shards: %v", err)
+	}
+
 	jsonlData, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path from config
 	if err != nil {
 		notify.Debugf("auto-import skipped, JSONL not readable: %v", err)
@@ -11,6 +11,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -213,6 +214,12 @@ func findLocalBeadsDir() string {
 	return ""
 }
 
+// FindDatabaseInBeadsDir returns the database path inside a known .beads/
+// directory, or "" if there is none.
+func FindDatabaseInBeadsDir(beadsDir string) string {
+	return findDatabaseInBeadsDir(beadsDir, false)
+}
+
 // findDatabaseInBeadsDir searches for a database file within a .beads directory.
 // It implements the standard search order:
 // 1. Check metadata.json first (single source of truth)
@@ -311,6 +318,10 @@ type (
 	DependencyType = types.DependencyType
 	// Comment represents a user comment on an issue.
 	Comment = types.Comment
+	// Attachment represents file metadata attached to an issue (content in .beads/blobs).
+	Attachment = types.Attachment
+	// WorkLog records time an actor spent working on an issue.
+	WorkLog = types.WorkLog
 	// Event represents an audit log event.
 	Event = types.Event
 	// EventType represents the type of audit event.
@@ -380,6 +391,10 @@ const (
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
 	EventCompacted         = types.EventCompacted
+	EventAttachmentAdded   = types.EventAttachmentAdded
+	EventAttachmentRemoved = types.EventAttachmentRemoved
+	EventWorkLogged        = types.EventWorkLogged
+	EventCascadeClosed     = types.EventCascadeClosed
 )
 
 // Storage provides the minimal interface for extension orchestration
@@ -527,7 +542,13 @@ func FindBeadsDir() string {
 		gitRoot = mainRepoRoot
 	}
 
-	for dir := cwd; dir != "/" && dir != "."; {
+	return searchBeadsDirUpward(cwd, gitRoot)
+}
+
+// searchBeadsDirUpward looks for a valid .beads/ directory in start and its
+// ancestors, stopping at gitRoot when it is non-empty.
+func searchBeadsDirUpward(start, gitRoot string) string {
+	for dir := start; dir != "/" && dir != "."; {
 		beadsDir := filepath.Join(dir, ".beads")
 		if info, err := os.Stat(beadsDir); err == nil && info.IsDir() {
 			// Follow redirect if present
@@ -558,6 +579,44 @@ func FindBeadsDir() string {
 	return ""
 }
 
+// FindBeadsDirFrom is FindBeadsDir for an explicit workspace directory
+// rather than the process's working directory, for servers handling
+// requests on behalf of several workspaces. BEADS_DIR is not consulted.
+// Like FindBeadsDir, a worktree resolves to its main repository's .beads/.
+func FindBeadsDirFrom(dir string) string {
+	start, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	// git reports resolved paths; match them so the git root boundary holds
+	if resolved, err := filepath.EvalSymlinks(start); err == nil {
+		start = resolved
+	}
+
+	// #nosec G204 - fixed git arguments; start is only used as the -C directory
+	out, err := exec.Command("git", "-C", start, "rev-parse", "--show-toplevel", "--git-common-dir").Output()
+	if err != nil {
+		return searchBeadsDirUpward(start, "")
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	gitRoot := filepath.Clean(lines[0])
+	if len(lines) > 1 {
+		commonDir := lines[1]
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitRoot, commonDir)
+		}
+		// In a worktree the common dir is the main repo's .git, not ours
+		if mainRoot := filepath.Dir(filepath.Clean(commonDir)); mainRoot != gitRoot {
+			beadsDir := FollowRedirect(filepath.Join(mainRoot, ".beads"))
+			if hasBeadsProjectFiles(beadsDir) {
+				return beadsDir
+			}
+			gitRoot = mainRoot
+		}
+	}
+	return searchBeadsDirUpward(start, gitRoot)
+}
+
 // FindJSONLPath returns the expected JSONL file path for the given database path.
 // It searches for existing *.jsonl files in the database directory and returns
 // the first one found, preferring issues.jsonl over beads.jsonl.
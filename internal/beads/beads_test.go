@@ -1278,4 +1278,37 @@ func TestFindDatabasePath_WorktreeNoLocalDB(t *testing.T) {
 	if resultResolved != mainDBPathResolved {
 		t.Errorf("FindDatabasePath() = %q, want main repo shared db %q", result, mainDBPath)
 	}
-}
\ No newline at end of file
+}
+func TestFindBeadsDirFrom(t *testing.T) {
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "-C", repo, "init", "-q").CombinedOutput(); err != nil {
+		t.Skipf("git init failed: %v: %s", err, out)
+	}
+	beadsDir := filepath.Join(repo, ".beads")
+	nested := filepath.Join(repo, "src", "pkg")
+	for _, dir := range []string{beadsDir, nested} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte("issue-prefix: x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Works from a subdirectory without changing the process's cwd
+	if got := FindBeadsDirFrom(nested); got != beadsDir {
+		t.Errorf("FindBeadsDirFrom(%s) = %q, want %q", nested, got, beadsDir)
+	}
+
+	// A repo without .beads stops at its own root
+	other, _ := filepath.EvalSymlinks(t.TempDir())
+	if out, err := exec.Command("git", "-C", other, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+	if got := FindBeadsDirFrom(other); got != "" {
+		t.Errorf("FindBeadsDirFrom(repo without .beads) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,244 @@
+// Package blobs implements the content-addressed attachment store under
+// .beads/blobs. Each blob is a plain file named by the hex SHA256 of its
+// content, so identical attachments are stored once and blobs can be synced
+// through git (or git-lfs) without ever touching issues.jsonl.
+package blobs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DirName is the blob directory inside .beads/.
+const DirName = "blobs"
+
+// lfsAttributesLine routes blobs through git-lfs when enabled.
+const lfsAttributesLine = DirName + "/** filter=lfs diff=lfs merge=lfs -text"
+
+// ErrNotFound is returned when a blob is not present in the store.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a content-addressed blob directory.
+type Store struct {
+	dir string
+}
+
+// New returns the blob store for beadsDir. The directory is created lazily
+// on the first Put.
+func New(beadsDir string) *Store {
+	return &Store{dir: filepath.Join(beadsDir, DirName)}
+}
+
+// Dir returns the blob directory path.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// ValidHash reports whether h looks like a blob key (64 lowercase hex chars).
+func ValidHash(h string) bool {
+	if len(h) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range h {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Path returns the file path for hash. It does not check existence.
+func (s *Store) Path(hash string) (string, error) {
+	if !ValidHash(hash) {
+		return "", fmt.Errorf("invalid blob hash %q", hash)
+	}
+	return filepath.Join(s.dir, hash), nil
+}
+
+// Put copies r into the store and returns its hash and size. Writing the
+// same content twice is a no-op the second time.
+func (s *Store) Put(r io.Reader) (string, int64, error) {
+	if err := os.MkdirAll(s.dir, 0750); err != nil {
+		return "", 0, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".incoming-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temp blob: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	dest := filepath.Join(s.dir, hash)
+	if _, err := os.Stat(dest); err == nil {
+		return hash, size, nil
+	}
+	// #nosec G302 -- blobs are committed to git and must be world-readable
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return "", 0, fmt.Errorf("failed to set blob permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, dest); err != nil {
+		return "", 0, fmt.Errorf("failed to store blob: %w", err)
+	}
+	return hash, size, nil
+}
+
+// PutFile stores the file at path.
+func (s *Store) PutFile(path string) (string, int64, error) {
+	f, err := os.Open(path) // #nosec G304 -- user-supplied attachment path
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	return s.Put(f)
+}
+
+// Open opens the blob for reading. Returns ErrNotFound if it is missing.
+func (s *Store) Open(hash string) (*os.File, error) {
+	path, err := s.Path(hash)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path) // #nosec G304 -- path is under .beads/blobs
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, hash)
+	}
+	return f, err
+}
+
+// Has reports whether the blob exists.
+func (s *Store) Has(hash string) bool {
+	path, err := s.Path(hash)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Verify re-hashes the blob and returns an error if the content no longer
+// matches its name.
+func (s *Store) Verify(hash string) error {
+	f, err := s.Open(hash)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", hash, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != hash {
+		return fmt.Errorf("blob %s is corrupt (content hashes to %s)", hash, got)
+	}
+	return nil
+}
+
+// Remove deletes the blob. Removing a missing blob is not an error.
+func (s *Store) Remove(hash string) error {
+	path, err := s.Path(hash)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove blob %s: %w", hash, err)
+	}
+	return nil
+}
+
+// List returns the hashes of all blobs in the store, sorted. Files that are
+// not blob keys (temp files, .gitattributes) are ignored.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read blob directory: %w", err)
+	}
+	var hashes []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && ValidHash(e.Name()) {
+			hashes = append(hashes, e.Name())
+		}
+	}
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+// Check compares the store against the set of referenced hashes and returns
+// the referenced blobs that are missing and the stored blobs nothing refers
+// to, both sorted.
+func (s *Store) Check(referenced map[string]bool) (missing, orphaned []string, err error) {
+	stored, err := s.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	have := make(map[string]bool, len(stored))
+	for _, h := range stored {
+		have[h] = true
+		if !referenced[h] {
+			orphaned = append(orphaned, h)
+		}
+	}
+	for h := range referenced {
+		if !have[h] {
+			missing = append(missing, h)
+		}
+	}
+	sort.Strings(missing)
+	return missing, orphaned, nil
+}
+
+// DetectMimeType guesses a MIME type from the file name, falling back to
+// sniffing the first bytes of content.
+func DetectMimeType(name string, head []byte) string {
+	if t := mime.TypeByExtension(strings.ToLower(filepath.Ext(name))); t != "" {
+		return t
+	}
+	return http.DetectContentType(head)
+}
+
+// EnsureLFSAttributes adds a .beads/.gitattributes rule that stores blobs
+// with git-lfs. Existing content is preserved; the rule is added once.
+func EnsureLFSAttributes(beadsDir string) error {
+	path := filepath.Join(beadsDir, ".gitattributes")
+	data, err := os.ReadFile(path) // #nosec G304 -- path is under .beads/
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitattributes: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == lfsAttributesLine {
+			return nil
+		}
+	}
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += lfsAttributesLine + "\n"
+	// #nosec G306 -- .gitattributes is committed to git
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write .gitattributes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package blobs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPutIsContentAddressed(t *testing.T) {
+	s := New(t.TempDir())
+
+	h1, size, err := s.Put(strings.NewReader("hello log"))
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if size != 9 || !ValidHash(h1) {
+		t.Fatalf("Put returned (%q, %d)", h1, size)
+	}
+	h2, _, err := s.Put(strings.NewReader("hello log"))
+	if err != nil || h2 != h1 {
+		t.Fatalf("second Put = (%q, %v), want %q", h2, err, h1)
+	}
+
+	hashes, err := s.List()
+	if err != nil || len(hashes) != 1 || hashes[0] != h1 {
+		t.Fatalf("List = %v, %v", hashes, err)
+	}
+
+	f, err := s.Open(h1)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(data) != "hello log" {
+		t.Errorf("blob content = %q", data)
+	}
+	if err := s.Verify(h1); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestVerifyDetectsCorruption(t *testing.T) {
+	s := New(t.TempDir())
+	h, _, err := s.Put(strings.NewReader("original"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, _ := s.Path(h)
+	if err := os.WriteFile(path, []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(h); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("Verify = %v, want corruption error", err)
+	}
+}
+
+func TestCheckReportsMissingAndOrphaned(t *testing.T) {
+	s := New(t.TempDir())
+	kept, _, _ := s.Put(strings.NewReader("kept"))
+	orphan, _, _ := s.Put(strings.NewReader("orphan"))
+	gone := strings.Repeat("a", 64)
+
+	// Stray files are not blobs
+	_ = os.WriteFile(filepath.Join(s.Dir(), ".gitattributes"), nil, 0644)
+
+	missing, orphaned, err := s.Check(map[string]bool{kept: true, gone: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 || missing[0] != gone {
+		t.Errorf("missing = %v, want [%s]", missing, gone)
+	}
+	if len(orphaned) != 1 || orphaned[0] != orphan {
+		t.Errorf("orphaned = %v, want [%s]", orphaned, orphan)
+	}
+
+	if err := s.Remove(orphan); err != nil || s.Has(orphan) {
+		t.Errorf("Remove(%s) = %v, still present: %v", orphan, err, s.Has(orphan))
+	}
+}
+
+func TestPathRejectsInvalidHash(t *testing.T) {
+	s := New(t.TempDir())
+	for _, h := range []string{"", "../issues.jsonl", strings.Repeat("A", 64)} {
+		if _, err := s.Path(h); err == nil {
+			t.Errorf("Path(%q) should fail", h)
+		}
+	}
+}
+
+func TestEnsureLFSAttributes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gitattributes")
+	if err := os.WriteFile(path, []byte("*.jsonl merge=beads"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := EnsureLFSAttributes(dir); err != nil {
+			t.Fatalf("EnsureLFSAttributes: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	want := "*.jsonl merge=beads\n" + lfsAttributesLine + "\n"
+	if string(data) != want {
+		t.Errorf(".gitattributes = %q, want %q", data, want)
+	}
+}
+
+func TestDetectMimeType(t *testing.T) {
+	if got := DetectMimeType("shot.PNG", nil); got != "image/png" {
+		t.Errorf("by extension = %q", got)
+	}
+	if got := DetectMimeType("build", []byte("plain text output")); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("by content = %q", got)
+	}
+}
@@ -0,0 +1,406 @@
+// Package codec converts issues to and from human-edited formats (CSV,
+// markdown, Org-mode) for bd import and bd export.
+//
+// Codecs only translate text. Matching decoded records against the database
+// and applying the differences is up to the caller; Record.Fields says which
+// fields a record actually carried, so a spreadsheet with three columns
+// doesn't wipe out the rest of an issue.
+package codec
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// Canonical field names. Mappings are keyed by these.
+const (
+	FieldID                 = "id"
+	FieldTitle              = "title"
+	FieldStatus             = "status"
+	FieldPriority           = "priority"
+	FieldType               = "type"
+	FieldAssignee           = "assignee"
+	FieldLabels             = "labels"
+	FieldDependencies       = "dependencies"
+	FieldExternalRef        = "external_ref"
+	FieldEstimate           = "estimate"
+	FieldDue                = "due"
+	FieldDefer              = "defer"
+	FieldDescription        = "description"
+	FieldDesign             = "design"
+	FieldAcceptanceCriteria = "acceptance_criteria"
+	FieldNotes              = "notes"
+)
+
+// Fields lists every field a codec can carry, in output order.
+var Fields = []string{
+	FieldID, FieldTitle, FieldStatus, FieldPriority, FieldType, FieldAssignee,
+	FieldLabels, FieldDependencies, FieldExternalRef, FieldEstimate, FieldDue,
+	FieldDefer, FieldDescription, FieldDesign, FieldAcceptanceCriteria, FieldNotes,
+}
+
+// defaultNames are the column headers and headings used when a mapping
+// doesn't override them.
+var defaultNames = map[string]string{
+	FieldID:                 "ID",
+	FieldTitle:              "Title",
+	FieldStatus:             "Status",
+	FieldPriority:           "Priority",
+	FieldType:               "Type",
+	FieldAssignee:           "Assignee",
+	FieldLabels:             "Labels",
+	FieldDependencies:       "Dependencies",
+	FieldExternalRef:        "External Ref",
+	FieldEstimate:           "Estimate",
+	FieldDue:                "Due",
+	FieldDefer:              "Defer Until",
+	FieldDescription:        "Description",
+	FieldDesign:             "Design",
+	FieldAcceptanceCriteria: "Acceptance Criteria",
+	FieldNotes:              "Notes",
+}
+
+// aliases are extra names accepted on import, matching what markdown.go
+// has always understood.
+var aliases = map[string]string{
+	"deps":              FieldDependencies,
+	"depends on":        FieldDependencies,
+	"issue type":        FieldType,
+	"acceptance":        FieldAcceptanceCriteria,
+	"estimated minutes": FieldEstimate,
+}
+
+// Record is one decoded issue.
+type Record struct {
+	// Issue holds the decoded values. Labels and Dependencies are set when
+	// the source carried them; dependency IssueIDs are the record's ID, which
+	// may be empty for new issues.
+	Issue *types.Issue
+
+	// Fields is the set of fields present in the source, even if empty.
+	Fields map[string]bool
+
+	// Line is where the record starts in the input, for error messages.
+	Line int
+}
+
+// Has reports whether the source carried a field.
+func (r *Record) Has(field string) bool {
+	return r.Fields[field]
+}
+
+// Codec reads and writes one format.
+type Codec interface {
+	// Encode writes issues, including their Labels and Dependencies.
+	Encode(w io.Writer, issues []*types.Issue) error
+
+	// Decode reads every record in r.
+	Decode(r io.Reader) ([]*Record, error)
+}
+
+// Mapping renames fields: canonical field name -> column header or heading.
+type Mapping map[string]string
+
+// NewMapping validates overrides (from config or --map flags) and returns a
+// mapping. Keys are canonical field names, case-insensitive.
+func NewMapping(overrides map[string]string) (Mapping, error) {
+	m := Mapping{}
+	for field, name := range overrides {
+		key := strings.ToLower(strings.TrimSpace(field))
+		if _, ok := defaultNames[key]; !ok {
+			return nil, fmt.Errorf("unknown field %q in mapping (valid: %s)", field, strings.Join(Fields, ", "))
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty name for field %q in mapping", field)
+		}
+		m[key] = name
+	}
+	return m, nil
+}
+
+// ParseMappingFlags parses field=Name pairs.
+func ParseMappingFlags(pairs []string) (map[string]string, error) {
+	out := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		field, name, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mapping %q (expected field=Name)", p)
+		}
+		out[field] = name
+	}
+	return out, nil
+}
+
+// Name returns the header or heading for a field.
+func (m Mapping) Name(field string) string {
+	if name, ok := m[field]; ok {
+		return name
+	}
+	return defaultNames[field]
+}
+
+// Field resolves a header or heading to a canonical field name. It accepts
+// the mapped name, the default name, the canonical name and a few aliases,
+// ignoring case.
+func (m Mapping) Field(name string) (string, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for field, mapped := range m {
+		if strings.ToLower(mapped) == key {
+			return field, true
+		}
+	}
+	for field, def := range defaultNames {
+		if strings.ToLower(def) == key || field == key {
+			return field, true
+		}
+	}
+	if field, ok := aliases[key]; ok {
+		return field, true
+	}
+	return "", false
+}
+
+// Factory creates a codec using the given mapping.
+type Factory func(m Mapping) Codec
+
+var registry = make(map[string]Factory)
+
+// Register makes a format available to New.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New returns the codec for a format.
+func New(name string, m Mapping) (Codec, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (valid: %s)", name, strings.Join(Formats(), ", "))
+	}
+	return factory(m), nil
+}
+
+// Formats lists the registered formats.
+func Formats() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register("csv", func(m Mapping) Codec { return &csvCodec{mapping: m} })
+	Register("markdown", func(m Mapping) Codec { return &markdownCodec{mapping: m} })
+	Register("org", func(m Mapping) Codec { return &orgCodec{mapping: m} })
+}
+
+// newRecord starts an empty record.
+func newRecord(line int) *Record {
+	return &Record{Issue: &types.Issue{}, Fields: make(map[string]bool), Line: line}
+}
+
+// FormatField renders one field of an issue as text. Every codec uses the
+// same text for scalar fields so files can be converted between formats.
+func FormatField(issue *types.Issue, field string) string {
+	switch field {
+	case FieldID:
+		return issue.ID
+	case FieldTitle:
+		return issue.Title
+	case FieldStatus:
+		return string(issue.Status)
+	case FieldPriority:
+		return fmt.Sprintf("P%d", issue.Priority)
+	case FieldType:
+		return string(issue.IssueType)
+	case FieldAssignee:
+		return issue.Assignee
+	case FieldLabels:
+		labels := append([]string(nil), issue.Labels...)
+		sort.Strings(labels)
+		return strings.Join(labels, ", ")
+	case FieldDependencies:
+		return formatDependencies(issue)
+	case FieldExternalRef:
+		if issue.ExternalRef != nil {
+			return *issue.ExternalRef
+		}
+	case FieldEstimate:
+		if issue.EstimatedMinutes != nil {
+			return strconv.Itoa(*issue.EstimatedMinutes)
+		}
+	case FieldDue:
+		return formatTime(issue.DueAt)
+	case FieldDefer:
+		return formatTime(issue.DeferUntil)
+	case FieldDescription:
+		return issue.Description
+	case FieldDesign:
+		return issue.Design
+	case FieldAcceptanceCriteria:
+		return issue.AcceptanceCriteria
+	case FieldNotes:
+		return issue.Notes
+	}
+	return ""
+}
+
+// SetField parses text into one field of the record and marks it present.
+func (r *Record) SetField(field, value string) error {
+	issue := r.Issue
+	value = strings.TrimSpace(value)
+	switch field {
+	case FieldID:
+		issue.ID = value
+	case FieldTitle:
+		issue.Title = value
+	case FieldStatus:
+		issue.Status = types.Status(strings.ToLower(value))
+	case FieldPriority:
+		if value == "" {
+			issue.Priority = 2
+			break
+		}
+		p := validation.ParsePriority(value)
+		if p < 0 {
+			return fmt.Errorf("invalid priority %q", value)
+		}
+		issue.Priority = p
+	case FieldType:
+		if value == "" {
+			issue.IssueType = types.TypeTask
+			break
+		}
+		t, err := validation.ParseIssueType(value)
+		if err != nil {
+			return err
+		}
+		issue.IssueType = t
+	case FieldAssignee:
+		issue.Assignee = value
+	case FieldLabels:
+		issue.Labels = splitList(value)
+	case FieldDependencies:
+		deps, err := parseDependencies(value)
+		if err != nil {
+			return err
+		}
+		issue.Dependencies = deps
+	case FieldExternalRef:
+		if value == "" {
+			issue.ExternalRef = nil
+		} else {
+			issue.ExternalRef = &value
+		}
+	case FieldEstimate:
+		if value == "" {
+			issue.EstimatedMinutes = nil
+			break
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid estimate %q (expected minutes)", value)
+		}
+		issue.EstimatedMinutes = &n
+	case FieldDue, FieldDefer:
+		t, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		if field == FieldDue {
+			issue.DueAt = t
+		} else {
+			issue.DeferUntil = t
+		}
+	case FieldDescription:
+		issue.Description = value
+	case FieldDesign:
+		issue.Design = value
+	case FieldAcceptanceCriteria:
+		issue.AcceptanceCriteria = value
+	case FieldNotes:
+		issue.Notes = value
+	default:
+		return fmt.Errorf("unknown field %q", field)
+	}
+	r.Fields[field] = true
+	return nil
+}
+
+// formatDependencies writes an issue's outgoing dependencies as
+// "bd-1, related:bd-2". Plain IDs are blocking dependencies.
+func formatDependencies(issue *types.Issue) string {
+	var parts []string
+	for _, dep := range issue.Dependencies {
+		if dep.IssueID != "" && dep.IssueID != issue.ID {
+			continue
+		}
+		if dep.Type == types.DepBlocks || dep.Type == "" {
+			parts = append(parts, dep.DependsOnID)
+		} else {
+			parts = append(parts, string(dep.Type)+":"+dep.DependsOnID)
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func parseDependencies(value string) ([]*types.Dependency, error) {
+	var deps []*types.Dependency
+	for _, item := range splitList(value) {
+		depType := types.DepBlocks
+		id := item
+		// external:project:capability references are IDs, not typed deps
+		if t, rest, ok := strings.Cut(item, ":"); ok && t != "external" {
+			depType, id = types.DependencyType(t), rest
+		}
+		if id == "" || !depType.IsValid() {
+			return nil, fmt.Errorf("invalid dependency %q (expected id or type:id)", item)
+		}
+		deps = append(deps, &types.Dependency{DependsOnID: id, Type: depType})
+	}
+	return deps, nil
+}
+
+// splitList splits on commas and whitespace, like markdown.go's lists.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	}) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time %q (expected RFC3339 or YYYY-MM-DD)", value)
+}
@@ -0,0 +1,231 @@
+package codec
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func sampleIssues() []*types.Issue {
+	ref := "gh-42"
+	est := 90
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	return []*types.Issue{
+		{
+			ID:                 "bd-a1",
+			Title:              "Fix login, again",
+			Description:        "Users get logged out.\n\n## Not a title\n* not a heading\n\\## already escaped",
+			Design:             "Refresh tokens \"early\".",
+			AcceptanceCriteria: "- stays logged in",
+			Notes:              "see thread",
+			Status:             types.StatusInProgress,
+			Priority:           1,
+			IssueType:          types.TypeBug,
+			Assignee:           "alice",
+			ExternalRef:        &ref,
+			EstimatedMinutes:   &est,
+			DueAt:              &due,
+			Labels:             []string{"area:auth", "urgent"},
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-a1", DependsOnID: "bd-b2", Type: types.DepBlocks},
+				{IssueID: "bd-a1", DependsOnID: "bd-c3", Type: types.DepRelated},
+			},
+		},
+		{
+			ID:        "bd-b2",
+			Title:     "Plain",
+			Status:    types.StatusHooked,
+			Priority:  3,
+			IssueType: types.TypeTask,
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range Formats() {
+		t.Run(format, func(t *testing.T) {
+			c, err := New(format, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := c.Encode(&buf, sampleIssues()); err != nil {
+				t.Fatal(err)
+			}
+			records, err := c.Decode(&buf)
+			if err != nil {
+				t.Fatalf("Decode: %v\n%s", err, buf.String())
+			}
+			if len(records) != 2 {
+				t.Fatalf("got %d records, want 2", len(records))
+			}
+			for i, want := range sampleIssues() {
+				got := records[i]
+				for _, field := range Fields {
+					if g, w := FormatField(got.Issue, field), FormatField(want, field); g != w {
+						t.Errorf("%s %s = %q, want %q", want.ID, field, g, w)
+					}
+				}
+				for _, field := range []string{FieldID, FieldTitle, FieldStatus, FieldLabels, FieldDependencies} {
+					// Markdown leaves out empty sections
+					if !got.Has(field) && FormatField(want, field) != "" || format != "markdown" && !got.Has(field) {
+						t.Errorf("%s: %s not marked present", want.ID, field)
+					}
+				}
+			}
+
+			// Encoding what was decoded gives the same bytes
+			var again bytes.Buffer
+			first := buf.String()
+			decoded := make([]*types.Issue, len(records))
+			for i, r := range records {
+				decoded[i] = r.Issue
+			}
+			buf.Reset()
+			if err := c.Encode(&buf, sampleIssues()); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Encode(&again, decoded); err != nil {
+				t.Fatal(err)
+			}
+			if again.String() != buf.String() {
+				t.Errorf("re-encoding differs:\n%s\n---\n%s", first, again.String())
+			}
+		})
+	}
+}
+
+func TestCSVPartialColumnsAndMapping(t *testing.T) {
+	m, err := NewMapping(map[string]string{"title": "Summary", "priority": "Sev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := New("csv", m)
+	input := "\ufeffSummary,Sev,Owner,labels\nFirst,P0,ignored,\"ui, triage\"\n,,,\nSecond,3,x,\n"
+	records, err := c.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (blank rows skipped)", len(records))
+	}
+	first := records[0]
+	if first.Issue.Title != "First" || first.Issue.Priority != 0 || !reflect.DeepEqual(first.Issue.Labels, []string{"ui", "triage"}) {
+		t.Errorf("first = %+v", first.Issue)
+	}
+	if first.Has(FieldStatus) || first.Has(FieldDescription) || !first.Has(FieldLabels) {
+		t.Errorf("fields = %v, want only the mapped columns", first.Fields)
+	}
+	if records[1].Line != 4 {
+		t.Errorf("line = %d, want 4", records[1].Line)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "ID,Summary,Status,Sev,") {
+		t.Errorf("header = %q", buf.String())
+	}
+
+	for _, bad := range []string{"Summary,Sev\nx,P9\n", "Owner\nbob\n", "Title,Summary\na,b\n"} {
+		if _, err := c.Decode(strings.NewReader(bad)); err == nil {
+			t.Errorf("Decode(%q) should fail", bad)
+		}
+	}
+	if _, err := NewMapping(map[string]string{"colour": "x"}); err == nil {
+		t.Error("unknown mapping field should fail")
+	}
+}
+
+func TestMarkdownReadsCreateFileFormat(t *testing.T) {
+	input := `# Sprint plan
+
+## Add search
+Find issues fast.
+
+### Priority
+1
+
+### Type
+feature
+
+### Deps
+bd-1, discovered-from:bd-2
+
+### Whatever
+ignored
+
+## Second
+`
+	c, _ := New("markdown", nil)
+	records, err := c.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	got := records[0].Issue
+	if got.Title != "Add search" || got.Description != "Find issues fast." || got.Priority != 1 || got.IssueType != types.TypeFeature {
+		t.Errorf("issue = %+v", got)
+	}
+	if len(got.Dependencies) != 2 || got.Dependencies[1].Type != types.DepDiscoveredFrom || got.Dependencies[1].DependsOnID != "bd-2" {
+		t.Errorf("dependencies = %+v", got.Dependencies)
+	}
+	if records[0].Has(FieldLabels) || records[0].Has(FieldStatus) {
+		t.Errorf("fields = %v, labels and status were not in the file", records[0].Fields)
+	}
+	if records[1].Issue.Title != "Second" || records[1].Has(FieldDescription) {
+		t.Errorf("second = %+v", records[1])
+	}
+}
+
+func TestOrgHeadings(t *testing.T) {
+	input := `#+TITLE: Backlog
+* DONE Ship it :release:
+Shipped.
+** Notes
+went fine
+* Untracked idea
+`
+	c, _ := New("org", nil)
+	records, err := c.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	first := records[0].Issue
+	if first.Status != types.StatusClosed || first.Title != "Ship it" || first.Description != "Shipped." ||
+		first.Notes != "went fine" || !reflect.DeepEqual(first.Labels, []string{"release"}) {
+		t.Errorf("first = %+v", first)
+	}
+	second := records[1]
+	if second.Issue.Title != "Untracked idea" || second.Has(FieldStatus) || second.Has(FieldLabels) || second.Has(FieldDescription) {
+		t.Errorf("second = %+v fields=%v", second.Issue, second.Fields)
+	}
+}
+
+func TestDependencyText(t *testing.T) {
+	deps, err := parseDependencies("bd-1 external:proj:cap, parent-child:bd-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*types.Dependency{
+		{DependsOnID: "bd-1", Type: types.DepBlocks},
+		{DependsOnID: "external:proj:cap", Type: types.DepBlocks},
+		{DependsOnID: "bd-2", Type: types.DepParentChild},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("deps = %+v", deps)
+	}
+	if _, err := parseDependencies("related:"); err == nil {
+		t.Error("empty target should fail")
+	}
+}
@@ -0,0 +1,110 @@
+package codec
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// csvCodec reads and writes one issue per row under a header row. Columns
+// whose header doesn't resolve to a field are ignored on import, so a PM's
+// spreadsheet can keep its own columns alongside ours.
+type csvCodec struct {
+	mapping Mapping
+}
+
+func (c *csvCodec) Encode(w io.Writer, issues []*types.Issue) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(Fields))
+	for i, field := range Fields {
+		header[i] = c.mapping.Name(field)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(Fields))
+	for _, issue := range issues {
+		for i, field := range Fields {
+			row[i] = FormatField(issue, field)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c *csvCodec) Decode(r io.Reader) ([]*Record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // spreadsheets often drop trailing empty cells
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(header))
+	seen := make(map[string]string)
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff") // Excel writes a BOM
+		field, ok := c.mapping.Field(name)
+		if !ok {
+			continue
+		}
+		if prev, dup := seen[field]; dup {
+			return nil, fmt.Errorf("columns %q and %q both map to %s", prev, name, field)
+		}
+		seen[field] = name
+		columns[i] = field
+	}
+	if seen[FieldID] == "" && seen[FieldTitle] == "" && seen[FieldExternalRef] == "" {
+		return nil, fmt.Errorf("header needs a %q, %q or %q column",
+			c.mapping.Name(FieldID), c.mapping.Name(FieldTitle), c.mapping.Name(FieldExternalRef))
+	}
+
+	var records []*Record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if isBlankRow(row) {
+			continue
+		}
+		rec := newRecord(line)
+		for i, field := range columns {
+			if field == "" {
+				continue
+			}
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			if err := rec.SetField(field, value); err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", line, header[i], err)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
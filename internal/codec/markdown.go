@@ -0,0 +1,129 @@
+package codec
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// markdownCodec uses the layout bd create --file reads: one "## Title" per
+// issue and a "### Section" per field. Text between the title and the first
+// section is the description. Sections that don't resolve to a field are
+// ignored, and empty fields are left out on export.
+type markdownCodec struct {
+	mapping Mapping
+}
+
+var (
+	mdIssueRegex   = regexp.MustCompile(`^##\s+(.+)$`)
+	mdSectionRegex = regexp.MustCompile(`^###\s+(.+)$`)
+
+	// Content lines that would parse as a heading get a backslash; lines
+	// that already had one get another, so decoding is exact.
+	mdEscapeRegex   = regexp.MustCompile(`^\\*#{2,3}\s`)
+	mdUnescapeRegex = regexp.MustCompile(`^\\+#{2,3}\s`)
+)
+
+func (c *markdownCodec) Encode(w io.Writer, issues []*types.Issue) error {
+	bw := bufio.NewWriter(w)
+	for i, issue := range issues {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintf(bw, "## %s\n", issue.Title)
+		for _, field := range Fields {
+			if field == FieldTitle {
+				continue
+			}
+			value := FormatField(issue, field)
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(bw, "\n### %s\n", c.mapping.Name(field))
+			for _, line := range strings.Split(value, "\n") {
+				if mdEscapeRegex.MatchString(line) {
+					line = `\` + line
+				}
+				fmt.Fprintln(bw, line)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+func (c *markdownCodec) Decode(r io.Reader) ([]*Record, error) {
+	var (
+		records []*Record
+		rec     *Record
+		field   string // current section's field; "" for the description preamble or an unknown section
+		known   bool
+		content []string
+	)
+	flush := func() error {
+		if rec == nil {
+			return nil
+		}
+		text := strings.Join(content, "\n")
+		content = nil
+		switch {
+		case known:
+			return rec.SetField(field, text)
+		case field == "" && strings.TrimSpace(text) != "" && !rec.Has(FieldDescription):
+			return rec.SetField(FieldDescription, text)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if m := mdIssueRegex.FindStringSubmatch(line); m != nil {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if rec != nil {
+				records = append(records, rec)
+			}
+			rec = newRecord(lineNo)
+			if err := rec.SetField(FieldTitle, m[1]); err != nil {
+				return nil, err
+			}
+			field, known = "", false
+			continue
+		}
+		if rec == nil {
+			continue // document preamble, e.g. a "# Project" heading
+		}
+		if m := mdSectionRegex.FindStringSubmatch(line); m != nil {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			field, known = c.mapping.Field(m[1])
+			if !known || field == FieldTitle {
+				field, known = m[1], false
+			}
+			continue
+		}
+		if mdUnescapeRegex.MatchString(line) {
+			line = line[1:]
+		}
+		content = append(content, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		if err := flush(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
@@ -0,0 +1,279 @@
+package codec
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// orgCodec writes one top-level Org heading per issue:
+//
+//	#+TITLE: Issues
+//	* TODO Fix login                                     :auth:urgent:
+//	:PROPERTIES:
+//	:ID:       bd-a1b2
+//	:PRIORITY: P1
+//	:END:
+//	The description.
+//	** Design
+//	...
+//
+// Status is the TODO keyword, scalar fields are properties, labels are tags
+// (or a LABELS property for labels that can't be tags), and long text fields
+// are second-level headings. The body under the heading is the description.
+type orgCodec struct {
+	mapping Mapping
+}
+
+// orgKeywords maps statuses to TODO keywords. Other statuses are written as
+// TODO with a STATUS property.
+var orgKeywords = map[types.Status]string{
+	types.StatusOpen:       "TODO",
+	types.StatusInProgress: "DOING",
+	types.StatusBlocked:    "WAIT",
+	types.StatusDeferred:   "HOLD",
+	types.StatusClosed:     "DONE",
+}
+
+var (
+	orgTagRegex      = regexp.MustCompile(`^[\w@#%]+$`)
+	orgTagsRegex     = regexp.MustCompile(`\s+(:(?:[\w@#%]+:)+)$`)
+	orgPropertyRegex = regexp.MustCompile(`^\s*:([^:\s]+):\s*(.*)$`)
+	orgEscapeRegex   = regexp.MustCompile(`^,*\*+\s`)
+	orgUnescapeRegex = regexp.MustCompile(`^,+\*+\s`)
+)
+
+// orgSectionFields are written as subheadings rather than properties.
+var orgSectionFields = map[string]bool{
+	FieldDesign:             true,
+	FieldAcceptanceCriteria: true,
+	FieldNotes:              true,
+}
+
+func (c *orgCodec) property(field string) string {
+	return strings.ToUpper(strings.ReplaceAll(c.mapping.Name(field), " ", "_"))
+}
+
+func (c *orgCodec) Encode(w io.Writer, issues []*types.Issue) error {
+	bw := bufio.NewWriter(w)
+	for _, issue := range issues {
+		keyword, ok := orgKeywords[issue.Status]
+		if !ok {
+			keyword = "TODO"
+		}
+		var tags, otherLabels []string
+		for _, label := range issue.Labels {
+			if orgTagRegex.MatchString(label) {
+				tags = append(tags, label)
+			} else {
+				otherLabels = append(otherLabels, label)
+			}
+		}
+		sort.Strings(tags)
+		sort.Strings(otherLabels)
+
+		heading := fmt.Sprintf("* %s %s", keyword, issue.Title)
+		if len(tags) > 0 {
+			heading += " :" + strings.Join(tags, ":") + ":"
+		}
+		fmt.Fprintln(bw, heading)
+
+		fmt.Fprintln(bw, ":PROPERTIES:")
+		for _, field := range Fields {
+			var value string
+			switch {
+			case field == FieldTitle || field == FieldDescription || orgSectionFields[field]:
+				continue
+			case field == FieldStatus:
+				if ok {
+					continue
+				}
+				value = string(issue.Status)
+			case field == FieldLabels:
+				value = strings.Join(otherLabels, ", ")
+			default:
+				value = FormatField(issue, field)
+			}
+			if value != "" {
+				fmt.Fprintf(bw, ":%s: %s\n", c.property(field), value)
+			}
+		}
+		fmt.Fprintln(bw, ":END:")
+
+		writeOrgText(bw, issue.Description)
+		for _, field := range Fields {
+			if !orgSectionFields[field] {
+				continue
+			}
+			if value := FormatField(issue, field); value != "" {
+				fmt.Fprintf(bw, "** %s\n", c.mapping.Name(field))
+				writeOrgText(bw, value)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+func writeOrgText(w io.Writer, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if orgEscapeRegex.MatchString(line) {
+			line = "," + line
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func (c *orgCodec) Decode(r io.Reader) ([]*Record, error) {
+	keywords := make(map[string]types.Status, len(orgKeywords))
+	for status, kw := range orgKeywords {
+		keywords[kw] = status
+	}
+
+	var (
+		records   []*Record
+		rec       *Record
+		labels    []string
+		hasLabels bool
+		hasDrawer bool
+		inDrawer  bool
+		afterHead bool   // only a drawer may directly follow the heading
+		section   string // "" is the description body
+		content   []string
+	)
+	flushSection := func() error {
+		text := strings.Join(content, "\n")
+		content = nil
+		if section == "" && strings.TrimSpace(text) == "" && !hasDrawer {
+			return nil
+		}
+		if section == "" {
+			section = FieldDescription
+		}
+		return rec.SetField(section, text)
+	}
+	finish := func() error {
+		if rec == nil {
+			return nil
+		}
+		if err := flushSection(); err != nil {
+			return err
+		}
+		// The drawer holds every property we export, so a property missing
+		// from it has been deleted.
+		if hasLabels || hasDrawer {
+			if err := rec.SetField(FieldLabels, strings.Join(labels, ",")); err != nil {
+				return err
+			}
+		}
+		for _, field := range Fields {
+			if !hasDrawer || rec.Has(field) || orgSectionFields[field] ||
+				field == FieldTitle || field == FieldStatus || field == FieldDescription {
+				continue
+			}
+			if err := rec.SetField(field, ""); err != nil {
+				return err
+			}
+		}
+		records = append(records, rec)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "* ") {
+			if err := finish(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", rec.Line, err)
+			}
+			rec = newRecord(lineNo)
+			labels, hasLabels, hasDrawer, inDrawer, afterHead, section = nil, false, false, false, true, ""
+
+			title := strings.TrimSpace(line[2:])
+			if kw, rest, _ := strings.Cut(title, " "); keywords[kw] != "" {
+				rec.Issue.Status = keywords[kw]
+				rec.Fields[FieldStatus] = true
+				title = strings.TrimSpace(rest)
+			} else if keywords[title] != "" {
+				rec.Issue.Status = keywords[title]
+				rec.Fields[FieldStatus] = true
+				title = ""
+			}
+			if m := orgTagsRegex.FindStringSubmatch(title); m != nil {
+				labels = strings.Split(strings.Trim(m[1], ":"), ":")
+				hasLabels = true
+				title = strings.TrimSpace(strings.TrimSuffix(title, m[0]))
+			}
+			if err := rec.SetField(FieldTitle, title); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if rec == nil {
+			continue // file preamble such as #+TITLE
+		}
+
+		if afterHead {
+			afterHead = false
+			if strings.TrimSpace(line) == ":PROPERTIES:" {
+				inDrawer, hasDrawer = true, true
+				continue
+			}
+		}
+		if inDrawer {
+			if strings.TrimSpace(line) == ":END:" {
+				inDrawer = false
+				continue
+			}
+			m := orgPropertyRegex.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			field, ok := c.mapping.Field(strings.ReplaceAll(m[1], "_", " "))
+			switch {
+			case !ok:
+				continue
+			case field == FieldLabels:
+				labels = append(labels, splitList(m[2])...)
+				hasLabels = true
+			default:
+				if err := rec.SetField(field, m[2]); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+			}
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(line, "** "); ok {
+			if field, known := c.mapping.Field(name); known && field != FieldTitle {
+				if err := flushSection(); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				section = field
+				continue
+			}
+		}
+		if orgUnescapeRegex.MatchString(line) {
+			line = line[1:]
+		}
+		content = append(content, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", lineNo, err)
+	}
+	return records, nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -138,6 +139,10 @@ func Initialize() error {
 
 	// Create command defaults
 	v.SetDefault("create.require-description", false)
+	v.SetDefault("create.similarity-check", true) // warn when a new issue looks like an open one
+
+	// Fuzzy duplicate detection (bd duplicates --fuzzy, bd create warning)
+	v.SetDefault("duplicates.threshold", 0.6)
 
 	// Validation configuration defaults (bd-t7jq)
 	// Values: "warn" | "error" | "none"
@@ -152,10 +157,48 @@ func Initialize() error {
 	// Default matches types.MaxHierarchyDepth constant
 	v.SetDefault("hierarchy.max-depth", 3)
 
+	// Per-priority SLAs: compact durations from creation to close (e.g., "24h", "3d")
+	// Empty means no SLA for that priority. See docs/CONFIG.md.
+	for p := 0; p <= 4; p++ {
+		v.SetDefault(fmt.Sprintf("sla.p%d", p), "")
+	}
+	v.SetDefault("sla.escalate", "")        // Extra actions on breach: bump, notify (comma-separated)
+	v.SetDefault("sla.label", "sla-breach") // Label added to breaching issues
+	v.SetDefault("sla.notify-to", "")       // Mail recipient for unassigned breaches
+
+	// Claim leases: how long bd claim holds an issue without a heartbeat
+	v.SetDefault("claim.lease-ttl", "30m")
+
+	// Named locks: how long a bd lock hold or queue place lasts without renewal
+	v.SetDefault("lock.ttl", "10m")
+
+	// Agent watchdog: silence before the daemon marks an agent stuck or dead (empty = off)
+	v.SetDefault("agent.stuck-after", "")
+	v.SetDefault("agent.dead-after", "")
+
+	// Close cascades: closes that follow from closing an issue, in the same transaction
+	v.SetDefault("close.cascade-epics", false)     // Close an epic when its last open child closes
+	v.SetDefault("close.cascade-delegated", false) // Close the delegator when its last open delegate closes
+	v.SetDefault("close.cascade-until", false)     // Close issues linked "until" the closed issue
+
+	// Daemon HTTP endpoints (e.g. /calendar.ics): listen address, empty = off
+	v.SetDefault("daemon.http-addr", "")
+	v.SetDefault("daemon.http-allow-remote", false) // Allow a non-loopback http-addr (no authentication)
+
+	// Time tracking: start/stop work timers when issues enter/leave in_progress
+	v.SetDefault("work.auto-timer", false)
+
 	// Git configuration defaults (GH#600)
 	v.SetDefault("git.author", "")         // Override commit author (e.g., "beads-bot <beads@example.com>")
 	v.SetDefault("git.no-gpg-sign", false) // Disable GPG signing for beads commits
 
+	// Signed JSONL exports: per-record hashes plus a detached signature
+	v.SetDefault("signing.key", "")             // SSH private key (or .pub with ssh-agent), or minisign secret key
+	v.SetDefault("signing.format", "ssh")       // ssh | minisign
+	v.SetDefault("signing.identity", "")        // Signer name (default: git user.email)
+	v.SetDefault("signing.allowed_signers", "") // Trust file for verification (must be outside the repository)
+	v.SetDefault("import.verify", false)        // Verify signatures on every JSONL import
+
 	// Directory-aware label scoping (GH#541)
 	// Maps directory patterns to labels for automatic filtering in monorepos
 	v.SetDefault("directory.labels", map[string]string{})
@@ -359,6 +402,14 @@ func GetInt(key string) int {
 	return v.GetInt(key)
 }
 
+// GetFloat64 retrieves a floating point configuration value
+func GetFloat64(key string) float64 {
+	if v == nil {
+		return 0
+	}
+	return v.GetFloat64(key)
+}
+
 // GetDuration retrieves a duration configuration value
 func GetDuration(key string) time.Duration {
 	if v == nil {
@@ -453,6 +504,59 @@ func GetDirectoryLabels() []string {
 type MultiRepoConfig struct {
 	Primary    string   // Primary repo path (where canonical issues live)
 	Additional []string // Additional repos to hydrate from
+	ReadOnly   []string // Additional repos that are hydrated but never written
+}
+
+// ErrUnknownRepo is returned by ResolveRepo when a name matches no configured repo.
+var ErrUnknownRepo = errors.New("unknown repo")
+
+// ResolveRepo maps a --repo selector to the source_repo value stored on
+// hydrated issues: "." for the primary repo, otherwise the matching
+// repos.additional entry. Additional repos may be named by their configured
+// path or by its base name (e.g. "planning" for "~/src/planning").
+func (m *MultiRepoConfig) ResolveRepo(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == m.Primary {
+		return ".", nil
+	}
+	for _, repo := range m.Additional {
+		if repo == name || filepath.Clean(repo) == filepath.Clean(name) {
+			return repo, nil
+		}
+	}
+	var matches []string
+	for _, repo := range m.Additional {
+		if filepath.Base(filepath.Clean(repo)) == name {
+			matches = append(matches, repo)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("%w %q (configured: %s)", ErrUnknownRepo, name, strings.Join(m.RepoNames(), ", "))
+	default:
+		return "", fmt.Errorf("repo name %q is ambiguous: %s", name, strings.Join(matches, ", "))
+	}
+}
+
+// IsReadOnly reports whether repo (a resolved source_repo value) is listed
+// in repos.readonly. The primary repo is always writable.
+func (m *MultiRepoConfig) IsReadOnly(repo string) bool {
+	if repo == "" || repo == "." {
+		return false
+	}
+	for _, ro := range m.ReadOnly {
+		if ro == repo || filepath.Clean(ro) == filepath.Clean(repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// RepoNames returns every selectable source_repo value, primary first.
+func (m *MultiRepoConfig) RepoNames() []string {
+	return append([]string{"."}, m.Additional...)
 }
 
 // GetMultiRepoConfig retrieves multi-repo configuration
@@ -471,6 +575,7 @@ func GetMultiRepoConfig() *MultiRepoConfig {
 	return &MultiRepoConfig{
 		Primary:    primary,
 		Additional: v.GetStringSlice("repos.additional"),
+		ReadOnly:   v.GetStringSlice("repos.readonly"),
 	}
 }
 
@@ -572,6 +677,83 @@ func GetSyncConfig() SyncConfig {
 	}
 }
 
+// SLAConfig holds the per-priority SLA settings.
+type SLAConfig struct {
+	Windows  map[int]string // Priority -> compact duration from creation to close
+	Escalate []string       // Actions beyond labeling: bump, notify
+	Label    string         // Label marking issues that breached their SLA
+	NotifyTo string         // Mail recipient when a breaching issue is unassigned
+}
+
+// GetSLAConfig returns the current SLA configuration.
+func GetSLAConfig() SLAConfig {
+	cfg := SLAConfig{
+		Windows:  make(map[int]string),
+		Label:    GetString("sla.label"),
+		NotifyTo: GetString("sla.notify-to"),
+	}
+	for p := 0; p <= 4; p++ {
+		if w := strings.TrimSpace(GetString(fmt.Sprintf("sla.p%d", p))); w != "" {
+			cfg.Windows[p] = w
+		}
+	}
+	for _, action := range strings.Split(GetString("sla.escalate"), ",") {
+		if action = strings.TrimSpace(action); action != "" {
+			cfg.Escalate = append(cfg.Escalate, action)
+		}
+	}
+	if cfg.Label == "" {
+		cfg.Label = "sla-breach"
+	}
+	return cfg
+}
+
+// AgentWatchdogConfig holds how long an agent may go without activity
+// before the daemon marks it stuck or dead. Zero disables that step.
+type AgentWatchdogConfig struct {
+	StuckAfter time.Duration
+	DeadAfter  time.Duration
+}
+
+// Enabled reports whether the watchdog has anything to do.
+func (c AgentWatchdogConfig) Enabled() bool {
+	return c.StuckAfter > 0 || c.DeadAfter > 0
+}
+
+// GetAgentWatchdogConfig returns the current agent watchdog configuration.
+// Unset or invalid durations disable the corresponding step.
+func GetAgentWatchdogConfig() AgentWatchdogConfig {
+	var cfg AgentWatchdogConfig
+	if d, err := time.ParseDuration(GetString("agent.stuck-after")); err == nil && d > 0 {
+		cfg.StuckAfter = d
+	}
+	if d, err := time.ParseDuration(GetString("agent.dead-after")); err == nil && d > 0 {
+		cfg.DeadAfter = d
+	}
+	return cfg
+}
+
+// CloseCascadeConfig holds which closes follow from closing an issue.
+type CloseCascadeConfig struct {
+	Epics     bool // Close an epic once all its children are closed
+	Delegated bool // Close the issue work was delegated from once all its delegates are closed
+	Until     bool // Close issues with an until edge to the closed issue
+}
+
+// Enabled reports whether any cascade rule is on.
+func (c CloseCascadeConfig) Enabled() bool {
+	return c.Epics || c.Delegated || c.Until
+}
+
+// GetCloseCascadeConfig returns the current close cascade configuration.
+func GetCloseCascadeConfig() CloseCascadeConfig {
+	return CloseCascadeConfig{
+		Epics:     GetBool("close.cascade-epics"),
+		Delegated: GetBool("close.cascade-delegated"),
+		Until:     GetBool("close.cascade-until"),
+	}
+}
+
 // ConflictConfig holds the conflict resolution configuration.
 type ConflictConfig struct {
 	Strategy ConflictStrategy // newest, ours, theirs, manual
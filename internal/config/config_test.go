@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -357,6 +358,50 @@ func TestGetMultiRepoConfig(t *testing.T) {
 	}
 }
 
+func TestMultiRepoConfigResolveRepo(t *testing.T) {
+	m := &MultiRepoConfig{
+		Primary:    ".",
+		Additional: []string{"~/src/planning", "../upstream", "/a/shared", "/b/shared"},
+		ReadOnly:   []string{"../upstream"},
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+		unknown bool
+	}{
+		{name: ".", want: "."},
+		{name: "", want: "."},
+		{name: "~/src/planning", want: "~/src/planning"},
+		{name: "planning", want: "~/src/planning"},
+		{name: "../upstream/", want: "../upstream"},
+		{name: "upstream", want: "../upstream"},
+		{name: "shared", wantErr: true},
+		{name: "missing", wantErr: true, unknown: true},
+	}
+	for _, tt := range tests {
+		got, err := m.ResolveRepo(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveRepo(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil && errors.Is(err, ErrUnknownRepo) != tt.unknown {
+			t.Errorf("ResolveRepo(%q) error = %v, want ErrUnknownRepo=%v", tt.name, err, tt.unknown)
+		}
+		if got != tt.want {
+			t.Errorf("ResolveRepo(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if !m.IsReadOnly("../upstream") || !m.IsReadOnly("../upstream/") {
+		t.Error("expected ../upstream to be read-only")
+	}
+	if m.IsReadOnly("~/src/planning") || m.IsReadOnly(".") {
+		t.Error("planning and primary repos should be writable")
+	}
+}
+
 func TestGetMultiRepoConfigFromFile(t *testing.T) {
 	// Create a temporary directory for config file
 	tmpDir := t.TempDir()
@@ -1543,3 +1588,42 @@ func TestGetCustomTypesFromYAML_NilViper(t *testing.T) {
 		t.Errorf("GetCustomTypesFromYAML() with nil viper = %v, want nil", got)
 	}
 }
+
+func TestGetAgentWatchdogConfig(t *testing.T) {
+	// Isolate from environment variables
+	restore := envSnapshot(t)
+	defer restore()
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	if GetAgentWatchdogConfig().Enabled() {
+		t.Error("agent watchdog should be off by default")
+	}
+
+	// Invalid durations disable their step rather than failing
+	Set("agent.stuck-after", "10m")
+	Set("agent.dead-after", "bogus")
+	cfg := GetAgentWatchdogConfig()
+	if cfg.StuckAfter != 10*time.Minute || cfg.DeadAfter != 0 || !cfg.Enabled() {
+		t.Errorf("GetAgentWatchdogConfig() = %+v, want stuck after 10m and no dead step", cfg)
+	}
+}
+
+func TestGetCloseCascadeConfig(t *testing.T) {
+	// Isolate from environment variables
+	restore := envSnapshot(t)
+	defer restore()
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	if got := GetCloseCascadeConfig(); got != (CloseCascadeConfig{}) || got.Enabled() {
+		t.Errorf("GetCloseCascadeConfig() = %+v, want every rule off by default", got)
+	}
+
+	Set("close.cascade-epics", true)
+	if cfg := GetCloseCascadeConfig(); !cfg.Epics || !cfg.Enabled() {
+		t.Errorf("GetCloseCascadeConfig() = %+v, want epics on", cfg)
+	}
+}
@@ -13,6 +13,7 @@ import (
 type ReposConfig struct {
 	Primary    string   `yaml:"primary,omitempty"`
 	Additional []string `yaml:"additional,omitempty,flow"`
+	ReadOnly   []string `yaml:"readonly,omitempty,flow"` // Additional repos that must not receive writes
 }
 
 // configFile represents the structure for reading/writing config.yaml
@@ -78,6 +79,14 @@ func GetReposFromYAML(configPath string) (*ReposConfig, error) {
 				}
 			}
 		}
+
+		if readonly, ok := reposMap["readonly"].([]interface{}); ok {
+			for _, item := range readonly {
+				if str, ok := item.(string); ok {
+					repos.ReadOnly = append(repos.ReadOnly, str)
+				}
+			}
+		}
 	}
 
 	return repos, nil
@@ -202,6 +211,19 @@ func buildReposNode(repos *ReposConfig) *yaml.Node {
 		)
 	}
 
+	if len(repos.ReadOnly) > 0 {
+		readonlyNode := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, path := range repos.ReadOnly {
+			readonlyNode.Content = append(readonlyNode.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: path, Style: yaml.DoubleQuotedStyle},
+			)
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "readonly"},
+			readonlyNode,
+		)
+	}
+
 	return node
 }
 
@@ -255,10 +277,54 @@ func RemoveRepo(configPath, repoPath string) error {
 
 	repos.Additional = newAdditional
 
+	// A removed repo can't stay read-only
+	newReadOnly := make([]string, 0, len(repos.ReadOnly))
+	for _, existing := range repos.ReadOnly {
+		if existing != repoPath {
+			newReadOnly = append(newReadOnly, existing)
+		}
+	}
+	repos.ReadOnly = newReadOnly
+
 	// If no repos left, clear primary too
 	if len(repos.Additional) == 0 {
 		repos.Primary = ""
+		repos.ReadOnly = nil
+	}
+
+	return SetReposInYAML(configPath, repos)
+}
+
+// SetRepoReadOnly marks (or unmarks) a configured additional repository as
+// read-only. Read-only repos are still hydrated, but bd refuses to create
+// issues in them and never rewrites their JSONL.
+func SetRepoReadOnly(configPath, repoPath string, readonly bool) error {
+	repos, err := GetReposFromYAML(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to get repos config: %w", err)
+	}
+
+	found := false
+	for _, existing := range repos.Additional {
+		if existing == repoPath {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("repository not found: %s", repoPath)
+	}
+
+	newReadOnly := make([]string, 0, len(repos.ReadOnly)+1)
+	for _, existing := range repos.ReadOnly {
+		if existing != repoPath {
+			newReadOnly = append(newReadOnly, existing)
+		}
+	}
+	if readonly {
+		newReadOnly = append(newReadOnly, repoPath)
 	}
+	repos.ReadOnly = newReadOnly
 
 	return SetReposInYAML(configPath, repos)
 }
@@ -244,6 +244,54 @@ func TestRemoveRepo_NotFound(t *testing.T) {
 	}
 }
 
+func TestSetRepoReadOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	config := `repos:
+  primary: "."
+  additional:
+    - ~/first
+    - ~/second
+`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetRepoReadOnly(configPath, "~/second", true); err != nil {
+		t.Fatalf("SetRepoReadOnly failed: %v", err)
+	}
+	// Marking twice must not duplicate the entry
+	if err := SetRepoReadOnly(configPath, "~/second", true); err != nil {
+		t.Fatalf("SetRepoReadOnly failed: %v", err)
+	}
+	repos, err := GetReposFromYAML(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos.ReadOnly) != 1 || repos.ReadOnly[0] != "~/second" {
+		t.Errorf("expected readonly=[~/second], got %v", repos.ReadOnly)
+	}
+	if len(repos.Additional) != 2 {
+		t.Errorf("additional repos should be unchanged, got %v", repos.Additional)
+	}
+
+	if err := SetRepoReadOnly(configPath, "~/unknown", true); err == nil {
+		t.Error("expected error marking an unconfigured repo read-only")
+	}
+
+	// Removing a repo also drops it from readonly
+	if err := RemoveRepo(configPath, "~/second"); err != nil {
+		t.Fatalf("RemoveRepo failed: %v", err)
+	}
+	repos, err = GetReposFromYAML(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos.ReadOnly) != 0 {
+		t.Errorf("expected empty readonly after removing repo, got %v", repos.ReadOnly)
+	}
+}
+
 func TestFindConfigYAMLPath(t *testing.T) {
 	// Create temp dir with .beads/config.yaml
 	tmpDir := t.TempDir()
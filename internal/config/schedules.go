@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScheduleConfig is one entry in the schedules section of config.yaml.
+// Schedules live in config.yaml rather than the database so that every
+// clone sees the same set; the instances they create dedupe by ID.
+type ScheduleConfig struct {
+	ID       string            `yaml:"id" json:"id"`
+	Rule     string            `yaml:"rule" json:"rule"`                             // Cron expression or RRULE
+	Formula  string            `yaml:"formula,omitempty" json:"formula,omitempty"`   // Formula to pour on each occurrence
+	Template string            `yaml:"template,omitempty" json:"template,omitempty"` // Or: proto issue to clone
+	Vars     map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+	Assignee string            `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Timezone string            `yaml:"timezone,omitempty" json:"timezone,omitempty"` // IANA zone for the rule (default UTC)
+	Lead     string            `yaml:"lead,omitempty" json:"lead,omitempty"`         // Create this long before the occurrence, deferred until it
+	Due      string            `yaml:"due,omitempty" json:"due,omitempty"`           // Due this long after the occurrence
+	// AllowOverlap creates new instances even while an earlier one is open
+	AllowOverlap bool      `yaml:"allow_overlap,omitempty" json:"allow_overlap,omitempty"`
+	Paused       bool      `yaml:"paused,omitempty" json:"paused,omitempty"`
+	Start        time.Time `yaml:"start" json:"start"` // No occurrence before this is materialized
+}
+
+// Location returns the schedule's time zone.
+func (s *ScheduleConfig) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: invalid timezone %q: %w", s.ID, s.Timezone, err)
+	}
+	return loc, nil
+}
+
+// GetSchedulesFromYAML reads the schedules section of config.yaml.
+// Returns nil if the file or section doesn't exist.
+func GetSchedulesFromYAML(configPath string) ([]ScheduleConfig, error) {
+	data, err := os.ReadFile(configPath) // #nosec G304 - config file path from caller
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config.yaml: %w", err)
+	}
+
+	var cfg struct {
+		Schedules []ScheduleConfig `yaml:"schedules"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse schedules in config.yaml: %w", err)
+	}
+	return cfg.Schedules, nil
+}
+
+// SetSchedulesInYAML replaces the schedules section of config.yaml,
+// preserving other sections and comments. An empty list removes it.
+func SetSchedulesInYAML(configPath string, schedules []ScheduleConfig) error {
+	data, err := os.ReadFile(configPath) // #nosec G304 - config file path from caller
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config.yaml: %w", err)
+	}
+
+	var root yaml.Node
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return fmt.Errorf("failed to parse config.yaml: %w", err)
+		}
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		root = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode}},
+		}
+	}
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		root.Content[0] = &yaml.Node{Kind: yaml.MappingNode}
+		mapping = root.Content[0]
+	}
+
+	var schedulesNode *yaml.Node
+	if len(schedules) > 0 {
+		schedulesNode = &yaml.Node{}
+		if err := schedulesNode.Encode(schedules); err != nil {
+			return fmt.Errorf("failed to encode schedules: %w", err)
+		}
+	}
+
+	index := -1
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "schedules" {
+			index = i
+			break
+		}
+	}
+	switch {
+	case index >= 0 && schedulesNode == nil:
+		mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+	case index >= 0:
+		mapping.Content[index+1] = schedulesNode
+	case schedulesNode != nil:
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "schedules"},
+			schedulesNode,
+		)
+	}
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return fmt.Errorf("failed to encode config.yaml: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to close encoder: %w", err)
+	}
+	if err := os.WriteFile(configPath, []byte(buf.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write config.yaml: %w", err)
+	}
+
+	if v != nil {
+		_ = v.ReadInConfig()
+	}
+	return nil
+}
+
+// FindSchedule returns the schedule with the given ID and its index, or
+// -1 if there is none.
+func FindSchedule(schedules []ScheduleConfig, id string) (*ScheduleConfig, int) {
+	for i := range schedules {
+		if schedules[i].ID == id {
+			return &schedules[i], i
+		}
+	}
+	return nil, -1
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchedulesYAMLRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	initial := `# project settings
+issue-prefix: "test"
+`
+	if err := os.WriteFile(configPath, []byte(initial), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	schedules := []ScheduleConfig{
+		{ID: "deps-bump", Rule: "0 9 * * MON", Formula: "mol-deps-bump", Due: "2d", Start: start},
+		{ID: "handoff", Rule: "FREQ=WEEKLY;BYDAY=FR", Template: "test-abc", Vars: map[string]string{"team": "infra"}, Timezone: "Europe/Berlin", Paused: true, Start: start},
+	}
+	if err := SetSchedulesInYAML(configPath, schedules); err != nil {
+		t.Fatalf("SetSchedulesInYAML failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "issue-prefix") || !strings.Contains(string(data), "# project settings") {
+		t.Errorf("other settings were lost:\n%s", data)
+	}
+
+	got, err := GetSchedulesFromYAML(configPath)
+	if err != nil {
+		t.Fatalf("GetSchedulesFromYAML failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d schedules, want 2", len(got))
+	}
+	if got[0].Formula != "mol-deps-bump" || got[0].Due != "2d" || !got[0].Start.Equal(start) {
+		t.Errorf("schedule 0 = %+v", got[0])
+	}
+	if got[1].Vars["team"] != "infra" || !got[1].Paused || got[1].Timezone != "Europe/Berlin" {
+		t.Errorf("schedule 1 = %+v", got[1])
+	}
+	if s, i := FindSchedule(got, "handoff"); s == nil || i != 1 {
+		t.Errorf("FindSchedule(handoff) = %v, %d", s, i)
+	}
+
+	// Removing every schedule drops the section
+	if err := SetSchedulesInYAML(configPath, nil); err != nil {
+		t.Fatalf("SetSchedulesInYAML(nil) failed: %v", err)
+	}
+	data, _ = os.ReadFile(configPath)
+	if strings.Contains(string(data), "schedules") {
+		t.Errorf("schedules section not removed:\n%s", data)
+	}
+}
+
+func TestGetSchedulesFromYAML_Missing(t *testing.T) {
+	got, err := GetSchedulesFromYAML(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil || got != nil {
+		t.Errorf("GetSchedulesFromYAML(missing) = %v, %v", got, err)
+	}
+}
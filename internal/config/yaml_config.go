@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // YamlOnlyKeys are configuration keys that must be stored in config.yaml
@@ -48,9 +49,11 @@ var YamlOnlyKeys = map[string]bool{
 	"sync.require_confirmation_on_mass_delete": true,
 
 	// Daemon settings (GH#871: team-wide auto-sync config)
-	"daemon.auto_commit": true,
-	"daemon.auto_push":   true,
-	"daemon.auto_pull":   true,
+	"daemon.auto_commit":       true,
+	"daemon.auto_push":         true,
+	"daemon.auto_pull":         true,
+	"daemon.http-addr":         true,
+	"daemon.http-allow-remote": true,
 
 	// Routing settings
 	"routing.mode":        true,
@@ -68,6 +71,24 @@ var YamlOnlyKeys = map[string]bool{
 
 	// Hierarchy settings (GH#995)
 	"hierarchy.max-depth": true,
+
+	// Time tracking settings
+	"work.auto-timer": true,
+
+	// Claim settings
+	"claim.lease-ttl": true,
+
+	// Lock settings
+	"lock.ttl": true,
+
+	// Agent watchdog settings
+	"agent.stuck-after": true,
+	"agent.dead-after":  true,
+
+	// Close cascade settings
+	"close.cascade-epics":     true,
+	"close.cascade-delegated": true,
+	"close.cascade-until":     true,
 }
 
 // IsYamlOnlyKey returns true if the given key should be stored in config.yaml
@@ -79,7 +100,7 @@ func IsYamlOnlyKey(key string) bool {
 	}
 
 	// Check prefix matches for nested keys
-	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "daemon.", "hierarchy."}
+	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "daemon.", "hierarchy.", "sla.", "codec."}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(key, prefix) {
 			return true
@@ -286,6 +307,9 @@ func needsQuoting(s string) bool {
 
 // validateYamlConfigValue validates a configuration value before setting.
 // Returns an error if the value is invalid for the given key.
+// slaWindowRe matches an SLA window: a positive compact duration.
+var slaWindowRe = regexp.MustCompile(`^\+?[1-9][0-9]*[hdwmy]$`)
+
 func validateYamlConfigValue(key, value string) error {
 	switch key {
 	case "hierarchy.max-depth":
@@ -297,6 +321,26 @@ func validateYamlConfigValue(key, value string) error {
 		if depth < 1 {
 			return fmt.Errorf("hierarchy.max-depth must be at least 1, got %d", depth)
 		}
+	case "sla.p0", "sla.p1", "sla.p2", "sla.p3", "sla.p4":
+		if value != "" && !slaWindowRe.MatchString(value) {
+			return fmt.Errorf("%s must be a duration like 24h, 3d or 2w, got %q", key, value)
+		}
+	case "sla.escalate":
+		for _, action := range strings.Split(value, ",") {
+			switch strings.TrimSpace(action) {
+			case "", "bump", "notify":
+			default:
+				return fmt.Errorf("sla.escalate: unknown action %q (use bump, notify)", action)
+			}
+		}
+	case "claim.lease-ttl", "lock.ttl", "agent.stuck-after", "agent.dead-after":
+		if ttl, err := time.ParseDuration(value); err != nil || ttl <= 0 {
+			return fmt.Errorf("%s must be a positive duration like 30m or 2h, got %q", key, value)
+		}
+	case "close.cascade-epics", "close.cascade-delegated", "close.cascade-until":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", key, value)
+		}
 	case "sync-branch", "sync.branch":
 		// GH#1166: Validate sync branch name at config time
 		// Note: Cannot import syncbranch due to import cycle, so inline the validation.
@@ -370,6 +370,81 @@ func TestValidateYamlConfigValue_HierarchyMaxDepth(t *testing.T) {
 }
 
 // TestValidateYamlConfigValue_OtherKeys tests that other keys are not validated
+func TestValidateYamlConfigValue_SLA(t *testing.T) {
+	for _, tt := range []struct {
+		key, value string
+		expectErr  bool
+	}{
+		{"sla.p0", "24h", false},
+		{"sla.p1", "3d", false},
+		{"sla.p4", "", false},
+		{"sla.p0", "0h", true},
+		{"sla.p0", "-1d", true},
+		{"sla.p2", "soon", true},
+		{"sla.escalate", "bump,notify", false},
+		{"sla.escalate", "page", true},
+	} {
+		err := validateYamlConfigValue(tt.key, tt.value)
+		if (err != nil) != tt.expectErr {
+			t.Errorf("validateYamlConfigValue(%q, %q) = %v, expectErr %v", tt.key, tt.value, err, tt.expectErr)
+		}
+	}
+	if !IsYamlOnlyKey("sla.p0") {
+		t.Error("sla.p0 should be stored in config.yaml")
+	}
+	if !IsYamlOnlyKey("codec.csv.title") {
+		t.Error("codec.csv.title should be stored in config.yaml")
+	}
+}
+
+func TestValidateYamlConfigValue_LeaseTTL(t *testing.T) {
+	for value, expectErr := range map[string]bool{"45m": false, "2h": false, "0s": true, "1d": true, "": true} {
+		err := validateYamlConfigValue("claim.lease-ttl", value)
+		if (err != nil) != expectErr {
+			t.Errorf("validateYamlConfigValue(claim.lease-ttl, %q) = %v, expectErr %v", value, err, expectErr)
+		}
+	}
+	if !IsYamlOnlyKey("claim.lease-ttl") {
+		t.Error("claim.lease-ttl should be stored in config.yaml")
+	}
+}
+
+func TestValidateYamlConfigValue_LockTTL(t *testing.T) {
+	for value, expectErr := range map[string]bool{"10m": false, "90s": false, "-1m": true, "soon": true} {
+		err := validateYamlConfigValue("lock.ttl", value)
+		if (err != nil) != expectErr {
+			t.Errorf("validateYamlConfigValue(lock.ttl, %q) = %v, expectErr %v", value, err, expectErr)
+		}
+	}
+	if !IsYamlOnlyKey("lock.ttl") {
+		t.Error("lock.ttl should be stored in config.yaml")
+	}
+}
+
+func TestValidateYamlConfigValue_AgentWatchdog(t *testing.T) {
+	for value, expectErr := range map[string]bool{"30m": false, "2h": false, "0s": true, "3d": true} {
+		err := validateYamlConfigValue("agent.dead-after", value)
+		if (err != nil) != expectErr {
+			t.Errorf("validateYamlConfigValue(agent.dead-after, %q) = %v, expectErr %v", value, err, expectErr)
+		}
+	}
+	if !IsYamlOnlyKey("agent.stuck-after") || !IsYamlOnlyKey("agent.dead-after") {
+		t.Error("agent watchdog keys should be stored in config.yaml")
+	}
+}
+
+func TestValidateYamlConfigValue_CloseCascade(t *testing.T) {
+	for value, expectErr := range map[string]bool{"true": false, "false": false, "yes": true, "": true} {
+		err := validateYamlConfigValue("close.cascade-epics", value)
+		if (err != nil) != expectErr {
+			t.Errorf("validateYamlConfigValue(close.cascade-epics, %q) = %v, expectErr %v", value, err, expectErr)
+		}
+	}
+	if !IsYamlOnlyKey("close.cascade-delegated") || !IsYamlOnlyKey("close.cascade-until") {
+		t.Error("close cascade keys should be stored in config.yaml")
+	}
+}
+
 func TestValidateYamlConfigValue_OtherKeys(t *testing.T) {
 	// Other keys should pass validation regardless of value
 	err := validateYamlConfigValue("no-db", "invalid")
@@ -10,9 +10,11 @@ import (
 type DataType string
 
 const (
-	DataTypeCore     DataType = "core"       // Issues and dependencies
-	DataTypeLabels   DataType = "labels"     // Issue labels
-	DataTypeComments DataType = "comments"   // Issue comments
+	DataTypeCore        DataType = "core"        // Issues and dependencies
+	DataTypeLabels      DataType = "labels"      // Issue labels
+	DataTypeComments    DataType = "comments"    // Issue comments
+	DataTypeAttachments DataType = "attachments" // Issue attachment metadata
+	DataTypeWorkLogs    DataType = "work_logs"   // Time tracking entries
 )
 
 // FetchResult holds the result of a data fetch operation
@@ -443,19 +443,26 @@ func SetSourceInfo(formula *Formula) {
 }
 
 // setSourceInfoRecursive recursively sets source info on steps.
+// Steps are addressed by ID rather than index, so a location keeps
+// identifying the same step when steps are inserted or reordered (bd mol
+// upgrade relies on this). Steps without an ID fall back to their index.
 func setSourceInfoRecursive(steps []*Step, formulaName, pathPrefix string) {
 	for i, step := range steps {
+		key := step.ID
+		if key == "" {
+			key = fmt.Sprintf("%d", i)
+		}
 		step.SourceFormula = formulaName
-		step.SourceLocation = fmt.Sprintf("%s[%d]", pathPrefix, i)
+		step.SourceLocation = fmt.Sprintf("%s[%s]", pathPrefix, key)
 
 		if len(step.Children) > 0 {
-			childPath := fmt.Sprintf("%s[%d].children", pathPrefix, i)
+			childPath := fmt.Sprintf("%s[%s].children", pathPrefix, key)
 			setSourceInfoRecursive(step.Children, formulaName, childPath)
 		}
 
 		// Handle loop body steps
 		if step.Loop != nil && len(step.Loop.Body) > 0 {
-			bodyPath := fmt.Sprintf("%s[%d].loop.body", pathPrefix, i)
+			bodyPath := fmt.Sprintf("%s[%s].loop.body", pathPrefix, key)
 			setSourceInfoRecursive(step.Loop.Body, formulaName, bodyPath)
 		}
 	}
@@ -203,7 +203,9 @@ type Step struct {
 	SourceFormula string `json:"-"` // Internal only, not serialized to JSON
 
 	// SourceLocation is the path within the source formula.
-	// Format: "steps[0]", "steps[2].children[1]", "advice[0].after", "loop.body[0]"
+	// Format: "steps[design]", "steps[impl].children[tests]", "steps[poll].loop.body[check]",
+	// or "advice" for steps inserted by advice. Steps are keyed by ID, so the
+	// location is stable across formula edits.
 	SourceLocation string `json:"-"` // Internal only, not serialized to JSON
 }
 
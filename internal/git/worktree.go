@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/beads/internal/merge"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -205,6 +206,15 @@ func (wm *WorktreeManager) SyncJSONLToWorktreeWithOptions(worktreePath, jsonlRel
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
+	// Sharded layout: carry a migrated layout onto the sync branch, and make
+	// sure the worktree's working copy reflects its committed shards.
+	if err := shard.Adopt(srcPath, dstPath); err != nil {
+		return fmt.Errorf("failed to adopt sharded layout in worktree: %w", err)
+	}
+	if err := shard.Sync(dstPath); err != nil {
+		return fmt.Errorf("failed to reconcile worktree JSONL shards: %w", err)
+	}
+
 	// Read source file
 	srcData, err := os.ReadFile(srcPath) // #nosec G304 - controlled path from config
 	if err != nil {
@@ -215,7 +225,7 @@ func (wm *WorktreeManager) SyncJSONLToWorktreeWithOptions(worktreePath, jsonlRel
 	dstData, dstErr := os.ReadFile(dstPath) // #nosec G304 - controlled path
 	if dstErr != nil || len(dstData) == 0 {
 		// Destination doesn't exist or is empty - just copy
-		if err := os.WriteFile(dstPath, srcData, 0644); err != nil { // #nosec G306 - JSONL needs to be readable
+		if err := writeWorktreeJSONL(dstPath, srcData); err != nil {
 			return fmt.Errorf("failed to write destination JSONL: %w", err)
 		}
 		return nil
@@ -226,7 +236,7 @@ func (wm *WorktreeManager) SyncJSONLToWorktreeWithOptions(worktreePath, jsonlRel
 	// This fixes the bug where `bd delete` mutations were not reflected in the sync branch
 	// because the merge logic would re-add the deleted issue.
 	if opts.ForceOverwrite {
-		if err := os.WriteFile(dstPath, srcData, 0644); err != nil { // #nosec G306 - JSONL needs to be readable
+		if err := writeWorktreeJSONL(dstPath, srcData); err != nil {
 			return fmt.Errorf("failed to write destination JSONL: %w", err)
 		}
 		return nil
@@ -238,7 +248,7 @@ func (wm *WorktreeManager) SyncJSONLToWorktreeWithOptions(worktreePath, jsonlRel
 
 	// If source has same or more issues, just copy (source is authoritative)
 	if srcCount >= dstCount {
-		if err := os.WriteFile(dstPath, srcData, 0644); err != nil { // #nosec G306 - JSONL needs to be readable
+		if err := writeWorktreeJSONL(dstPath, srcData); err != nil {
 			return fmt.Errorf("failed to write destination JSONL: %w", err)
 		}
 		return nil
@@ -252,19 +262,28 @@ func (wm *WorktreeManager) SyncJSONLToWorktreeWithOptions(worktreePath, jsonlRel
 		// If merge fails, fall back to copy behavior but log warning
 		// This shouldn't happen but ensures we don't break existing behavior
 		fmt.Fprintf(os.Stderr, "Warning: JSONL merge failed (%v), falling back to overwrite\n", err)
-		if writeErr := os.WriteFile(dstPath, srcData, 0644); writeErr != nil { // #nosec G306
+		if writeErr := writeWorktreeJSONL(dstPath, srcData); writeErr != nil {
 			return fmt.Errorf("failed to write destination JSONL: %w", writeErr)
 		}
 		return nil
 	}
 
-	if err := os.WriteFile(dstPath, mergedData, 0644); err != nil { // #nosec G306 - JSONL needs to be readable
+	if err := writeWorktreeJSONL(dstPath, mergedData); err != nil {
 		return fmt.Errorf("failed to write merged JSONL: %w", err)
 	}
 
 	return nil
 }
 
+// writeWorktreeJSONL writes the worktree JSONL and, in the sharded layout,
+// its shards (which are what the sync branch commits).
+func writeWorktreeJSONL(dstPath string, data []byte) error {
+	if err := os.WriteFile(dstPath, data, 0644); err != nil { // #nosec G306 - JSONL needs to be readable
+		return err
+	}
+	return shard.Export(dstPath)
+}
+
 // countJSONLIssues counts the number of valid JSON lines in JSONL data
 func countJSONLIssues(data []byte) int {
 	count := 0
@@ -15,6 +15,9 @@ const (
 	EventCreate = "create"
 	EventUpdate = "update"
 	EventClose  = "close"
+
+	EventSLABreach = "sla_breach"
+	EventAgentDead = "agent_dead"
 )
 
 // Hook file names
@@ -22,6 +25,9 @@ const (
 	HookOnCreate = "on_create"
 	HookOnUpdate = "on_update"
 	HookOnClose  = "on_close"
+
+	HookOnSLABreach = "on_sla_breach"
+	HookOnAgentDead = "on_agent_dead"
 )
 
 // Runner handles hook execution
@@ -118,6 +124,10 @@ func eventToHook(event string) string {
 		return HookOnUpdate
 	case EventClose:
 		return HookOnClose
+	case EventSLABreach:
+		return HookOnSLABreach
+	case EventAgentDead:
+		return HookOnAgentDead
 	default:
 		return ""
 	}
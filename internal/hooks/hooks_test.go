@@ -44,6 +44,8 @@ func TestEventToHook(t *testing.T) {
 		{EventCreate, HookOnCreate},
 		{EventUpdate, HookOnUpdate},
 		{EventClose, HookOnClose},
+		{EventSLABreach, HookOnSLABreach},
+		{EventAgentDead, HookOnAgentDead},
 		{"unknown", ""},
 		{"", ""},
 	}
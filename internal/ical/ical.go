@@ -0,0 +1,198 @@
+// Package ical writes issue due dates and deferrals as an iCalendar
+// (RFC 5545) feed.
+//
+// Each issue with a due date becomes a VTODO due at that time, and each open
+// issue with a defer date becomes a VEVENT at the moment it becomes ready
+// again. UIDs are derived from the issue ID, so calendar clients that
+// re-fetch the feed update entries in place instead of duplicating them.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ProdID identifies the generator in every calendar.
+const ProdID = "-//beads//bd//EN"
+
+// uidDomain scopes UIDs to beads.
+const uidDomain = "beads"
+
+// Options configures a calendar.
+type Options struct {
+	// Name is shown by clients as the calendar name (X-WR-CALNAME).
+	Name string
+}
+
+// Dated reports whether an issue has an entry in the calendar.
+func Dated(issue *types.Issue) bool {
+	return issue.DueAt != nil || (issue.DeferUntil != nil && issue.Status != types.StatusClosed)
+}
+
+// DueUID is the stable UID of an issue's due-date VTODO.
+func DueUID(id string) string {
+	return id + "-due@" + uidDomain
+}
+
+// DeferUID is the stable UID of an issue's deferral VEVENT.
+func DeferUID(id string) string {
+	return id + "-defer@" + uidDomain
+}
+
+// Write writes a VCALENDAR with entries for the dated issues, in the order
+// given. Tombstones and issues without dates are skipped.
+func Write(w io.Writer, issues []*types.Issue, opts Options) error {
+	cw := &calendarWriter{w: bufio.NewWriter(w)}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + ProdID)
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	if opts.Name != "" {
+		cw.line("X-WR-CALNAME:" + escapeText(opts.Name))
+	}
+	for _, issue := range issues {
+		if issue.Status == types.StatusTombstone {
+			continue
+		}
+		if issue.DueAt != nil {
+			cw.todo(issue)
+		}
+		if issue.DeferUntil != nil && issue.Status != types.StatusClosed {
+			cw.event(issue)
+		}
+	}
+	cw.line("END:VCALENDAR")
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+type calendarWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes one content line, folded at 75 octets (RFC 5545 3.1)
+// without splitting UTF-8 sequences.
+func (c *calendarWriter) line(s string) {
+	if c.err != nil {
+		return
+	}
+	const limit = 75
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // the leading space counts
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	_, c.err = c.w.WriteString(b.String())
+}
+
+// common writes the properties VTODO and VEVENT share.
+func (c *calendarWriter) common(issue *types.Issue, uid, summary string) {
+	c.line("UID:" + uid)
+	// DTSTAMP is the issue's last change rather than now, so an unchanged
+	// issue renders byte-for-byte the same on every fetch.
+	c.line("DTSTAMP:" + formatTime(issue.UpdatedAt))
+	if !issue.CreatedAt.IsZero() {
+		c.line("CREATED:" + formatTime(issue.CreatedAt))
+	}
+	c.line("LAST-MODIFIED:" + formatTime(issue.UpdatedAt))
+	c.line("SUMMARY:" + escapeText(summary))
+	c.line("DESCRIPTION:" + escapeText(describe(issue)))
+	c.line(fmt.Sprintf("PRIORITY:%d", Priority(issue.Priority)))
+	if len(issue.Labels) > 0 {
+		labels := append([]string(nil), issue.Labels...)
+		sort.Strings(labels)
+		for i, l := range labels {
+			labels[i] = escapeText(l)
+		}
+		c.line("CATEGORIES:" + strings.Join(labels, ","))
+	}
+}
+
+func (c *calendarWriter) todo(issue *types.Issue) {
+	c.line("BEGIN:VTODO")
+	c.common(issue, DueUID(issue.ID), issue.Title)
+	c.line("DUE:" + formatTime(*issue.DueAt))
+	c.line("STATUS:" + TodoStatus(issue.Status))
+	if issue.Status == types.StatusClosed && issue.ClosedAt != nil {
+		c.line("COMPLETED:" + formatTime(*issue.ClosedAt))
+	}
+	c.line("END:VTODO")
+}
+
+func (c *calendarWriter) event(issue *types.Issue) {
+	c.line("BEGIN:VEVENT")
+	c.common(issue, DeferUID(issue.ID), "Ready again: "+issue.Title)
+	c.line("DTSTART:" + formatTime(*issue.DeferUntil))
+	c.line("TRANSP:TRANSPARENT")
+	c.line("END:VEVENT")
+}
+
+// Priority maps P0-P4 onto iCalendar's 1 (highest) to 9 (lowest).
+func Priority(p int) int {
+	if p < 0 {
+		p = 0
+	}
+	if p > 4 {
+		p = 4
+	}
+	return 1 + 2*p
+}
+
+// TodoStatus maps an issue status onto a VTODO STATUS.
+func TodoStatus(s types.Status) string {
+	switch s {
+	case types.StatusClosed:
+		return "COMPLETED"
+	case types.StatusInProgress, types.StatusHooked:
+		return "IN-PROCESS"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+func describe(issue *types.Issue) string {
+	header := fmt.Sprintf("%s · P%d · %s", issue.ID, issue.Priority, issue.Status)
+	if issue.Assignee != "" {
+		header += " · " + issue.Assignee
+	}
+	if issue.Description == "" {
+		return header
+	}
+	return header + "\n\n" + issue.Description
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11).
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
@@ -0,0 +1,110 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWrite(t *testing.T) {
+	created := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 1, 3, 10, 30, 0, 0, time.UTC)
+	due := time.Date(2026, 2, 1, 17, 0, 0, 0, time.FixedZone("CET", 3600))
+	deferred := time.Date(2026, 1, 20, 8, 0, 0, 0, time.UTC)
+	closedAt := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Ship, finally; really", Description: "line one\nline two", Priority: 0,
+			Status: types.StatusInProgress, Assignee: "alice", Labels: []string{"release", "ui"},
+			CreatedAt: created, UpdatedAt: updated, DueAt: &due, DeferUntil: &deferred},
+		{ID: "bd-2", Title: "Done already", Priority: 4, Status: types.StatusClosed,
+			CreatedAt: created, UpdatedAt: updated, DueAt: &due, DeferUntil: &deferred, ClosedAt: &closedAt},
+		{ID: "bd-3", Title: "Undated", Status: types.StatusOpen, CreatedAt: created, UpdatedAt: updated},
+		{ID: "bd-4", Title: "Deleted", Status: types.StatusTombstone, DueAt: &due, UpdatedAt: updated},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, issues, Options{Name: "Beads"}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:" + ProdID + "\r\n",
+		"X-WR-CALNAME:Beads\r\n",
+		"BEGIN:VTODO\r\nUID:bd-1-due@beads\r\nDTSTAMP:20260103T103000Z\r\n",
+		"SUMMARY:Ship\\, finally\\; really\r\n",
+		"DESCRIPTION:bd-1 · P0 · in_progress · alice\\n\\nline one\\nline two\r\n",
+		"PRIORITY:1\r\n",
+		"CATEGORIES:release,ui\r\n",
+		"DUE:20260201T160000Z\r\nSTATUS:IN-PROCESS\r\n",
+		"BEGIN:VEVENT\r\nUID:bd-1-defer@beads\r\n",
+		"SUMMARY:Ready again: Ship\\, finally\\; really\r\n",
+		"DTSTART:20260120T080000Z\r\nTRANSP:TRANSPARENT\r\n",
+		"UID:bd-2-due@beads\r\n",
+		"PRIORITY:9\r\n",
+		"STATUS:COMPLETED\r\nCOMPLETED:20260105T120000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"bd-2-defer", "bd-3", "bd-4"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("unexpected %s entry in:\n%s", unwanted, out)
+		}
+	}
+
+	// Same input, same bytes: clients see no change until the issue changes
+	var again bytes.Buffer
+	if err := Write(&again, issues, Options{Name: "Beads"}); err != nil {
+		t.Fatal(err)
+	}
+	if again.String() != out {
+		t.Error("output is not deterministic")
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	issue := &types.Issue{ID: "bd-1", Title: strings.Repeat("é", 100), Status: types.StatusOpen}
+	due := time.Now()
+	issue.DueAt = &due
+
+	var buf bytes.Buffer
+	if err := Write(&buf, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	var unfolded strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line %d is %d octets: %q", i, len(line), line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	if !strings.Contains(unfolded.String(), "\nSUMMARY:"+issue.Title+"\n") {
+		t.Errorf("summary did not unfold to the title:\n%s", unfolded.String())
+	}
+}
+
+func TestMappings(t *testing.T) {
+	for p, want := range map[int]int{0: 1, 1: 3, 2: 5, 3: 7, 4: 9, 7: 9} {
+		if got := Priority(p); got != want {
+			t.Errorf("Priority(%d) = %d, want %d", p, got, want)
+		}
+	}
+	for s, want := range map[types.Status]string{
+		types.StatusOpen: "NEEDS-ACTION", types.StatusBlocked: "NEEDS-ACTION", types.StatusDeferred: "NEEDS-ACTION",
+		types.StatusInProgress: "IN-PROCESS", types.StatusClosed: "COMPLETED",
+	} {
+		if got := TodoStatus(s); got != want {
+			t.Errorf("TodoStatus(%s) = %s, want %s", s, got, want)
+		}
+	}
+}
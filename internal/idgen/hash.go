@@ -3,6 +3,7 @@ package idgen
 import (
 	"crypto/sha256"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 	"time"
@@ -83,3 +84,12 @@ func GenerateHashID(prefix, title, description, creator string, timestamp time.T
 
 	return fmt.Sprintf("%s-%s", prefix, shortHash)
 }
+
+// CollisionProbability estimates the chance that at least two of numIDs
+// random base36 hashes of the given length collide, using the birthday
+// approximation P ≈ 1 - e^(-n²/2N).
+func CollisionProbability(numIDs, length int) float64 {
+	totalPossibilities := math.Pow(36, float64(length))
+	exponent := -float64(numIDs) * float64(numIDs) / (2.0 * totalPossibilities)
+	return 1.0 - math.Exp(exponent)
+}
@@ -0,0 +1,122 @@
+package idgen
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NamespaceFileName is the git-tracked sidecar in .beads/ that pins the
+// minimum hash length per prefix, so every clone generates IDs from the same
+// namespace regardless of how many issues it has seen locally.
+const NamespaceFileName = "namespace.jsonl"
+
+// NamespaceRecordKind identifies id.namespace records in NamespaceFileName.
+const NamespaceRecordKind = "id.namespace"
+
+// NamespaceRecord pins the minimum hash length for one ID prefix.
+// Pins only ever grow: when two clones disagree, the longer length wins.
+type NamespaceRecord struct {
+	Kind      string `json:"kind"`
+	Prefix    string `json:"prefix"`
+	MinLength int    `json:"min_length"`
+}
+
+// LoadNamespace reads the pinned minimum lengths from beadsDir, keyed by
+// prefix. A missing file yields an empty map. Malformed lines, records of
+// other kinds, and duplicate prefixes left behind by a git merge are
+// tolerated; duplicates resolve to the longest pin.
+func LoadNamespace(beadsDir string) (map[string]int, error) {
+	pins := make(map[string]int)
+	f, err := os.Open(filepath.Join(beadsDir, NamespaceFileName)) // #nosec G304 - path is under .beads/
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pins, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", NamespaceFileName, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rec NamespaceRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			continue
+		}
+		if rec.Kind != NamespaceRecordKind || rec.Prefix == "" || rec.MinLength <= 0 {
+			continue
+		}
+		if rec.MinLength > pins[rec.Prefix] {
+			pins[rec.Prefix] = rec.MinLength
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", NamespaceFileName, err)
+	}
+	return pins, nil
+}
+
+// SaveNamespace atomically writes pins to beadsDir, one record per prefix in
+// sorted order so the file diffs and merges cleanly in git.
+func SaveNamespace(beadsDir string, pins map[string]int) error {
+	prefixes := make([]string, 0, len(pins))
+	for prefix, length := range pins {
+		if prefix != "" && length > 0 {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+
+	var b strings.Builder
+	for _, prefix := range prefixes {
+		data, err := json.Marshal(NamespaceRecord{Kind: NamespaceRecordKind, Prefix: prefix, MinLength: pins[prefix]})
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	path := filepath.Join(beadsDir, NamespaceFileName)
+	tmp := path + ".tmp"
+	// nolint:gosec // G306: JSONL is shared via git across clones
+	if err := os.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", NamespaceFileName, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace %s: %w", NamespaceFileName, err)
+	}
+	return nil
+}
+
+// MergeNamespace combines two pin sets, keeping the longer pin per prefix.
+func MergeNamespace(a, b map[string]int) map[string]int {
+	merged := make(map[string]int, len(a)+len(b))
+	for prefix, length := range a {
+		merged[prefix] = length
+	}
+	for prefix, length := range b {
+		if length > merged[prefix] {
+			merged[prefix] = length
+		}
+	}
+	return merged
+}
+
+// NamespaceConfigPrefix prefixes the config keys that mirror namespace pins
+// in the database (e.g. "id.namespace.bd" = "6").
+const NamespaceConfigPrefix = "id.namespace."
+
+// NamespaceConfigKey returns the config key holding the pin for prefix.
+func NamespaceConfigKey(prefix string) string {
+	return NamespaceConfigPrefix + prefix
+}
@@ -0,0 +1,85 @@
+package idgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadNamespace_MissingFile(t *testing.T) {
+	pins, err := LoadNamespace(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadNamespace failed: %v", err)
+	}
+	if len(pins) != 0 {
+		t.Errorf("pins = %v, want empty", pins)
+	}
+}
+
+func TestSaveLoadNamespace_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveNamespace(dir, map[string]int{"bd": 6, "gt": 5}); err != nil {
+		t.Fatalf("SaveNamespace failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, NamespaceFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"kind":"id.namespace","prefix":"bd","min_length":6}
+{"kind":"id.namespace","prefix":"gt","min_length":5}
+`
+	if string(data) != want {
+		t.Errorf("file content =\n%s\nwant\n%s", data, want)
+	}
+
+	pins, err := LoadNamespace(dir)
+	if err != nil {
+		t.Fatalf("LoadNamespace failed: %v", err)
+	}
+	if len(pins) != 2 || pins["bd"] != 6 || pins["gt"] != 5 {
+		t.Errorf("pins = %v, want map[bd:6 gt:5]", pins)
+	}
+}
+
+func TestLoadNamespace_MergedFileKeepsLongestPin(t *testing.T) {
+	dir := t.TempDir()
+	// Both sides of a git merge kept their own record for bd
+	content := `{"kind":"id.namespace","prefix":"bd","min_length":5}
+{"kind":"id.namespace","prefix":"bd","min_length":7}
+{"kind":"other","prefix":"bd","min_length":8}
+not json
+{"kind":"id.namespace","prefix":"","min_length":4}
+`
+	if err := os.WriteFile(filepath.Join(dir, NamespaceFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pins, err := LoadNamespace(dir)
+	if err != nil {
+		t.Fatalf("LoadNamespace failed: %v", err)
+	}
+	if len(pins) != 1 || pins["bd"] != 7 {
+		t.Errorf("pins = %v, want map[bd:7]", pins)
+	}
+}
+
+func TestMergeNamespace(t *testing.T) {
+	got := MergeNamespace(map[string]int{"bd": 6, "gt": 4}, map[string]int{"bd": 5, "gt": 7, "hq": 3})
+	if len(got) != 3 || got["bd"] != 6 || got["gt"] != 7 || got["hq"] != 3 {
+		t.Errorf("MergeNamespace = %v", got)
+	}
+}
+
+func TestCollisionProbability(t *testing.T) {
+	if p := CollisionProbability(0, 3); p != 0 {
+		t.Errorf("empty namespace probability = %v, want 0", p)
+	}
+	// 160 IDs in the 3-char space sits just under the 25% default threshold
+	if p := CollisionProbability(160, 3); p < 0.2 || p > 0.25 {
+		t.Errorf("CollisionProbability(160, 3) = %v, want ~0.24", p)
+	}
+	if CollisionProbability(1000, 6) >= CollisionProbability(1000, 5) {
+		t.Error("longer IDs should lower collision probability")
+	}
+}
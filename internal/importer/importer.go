@@ -6,10 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/linear"
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/storage"
@@ -158,6 +160,13 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return result, nil
 	}
 
+	// Adopt ID length pins synced from other clones before any IDs are generated
+	if !opts.DryRun {
+		if err := applyNamespacePins(ctx, sqliteStore, filepath.Dir(sqliteStore.Path())); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to apply %s: %v\n", idgen.NamespaceFileName, err)
+		}
+	}
+
 	// Upsert issues (create new or update existing)
 	if err := upsertIssues(ctx, sqliteStore, issues, opts, result); err != nil {
 		return nil, err
@@ -178,6 +187,16 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return nil, err
 	}
 
+	// Import attachment metadata (blobs arrive separately via .beads/blobs)
+	if err := importAttachments(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import work logs
+	if err := importWorkLogs(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
 	// Checkpoint WAL to ensure data persistence and reduce WAL file size
 	if err := sqliteStore.CheckpointWAL(ctx); err != nil {
 		// Non-fatal - just log warning
@@ -209,6 +228,30 @@ func getOrCreateStore(ctx context.Context, dbPath string, store storage.Storage)
 	return sqliteStore, true, nil
 }
 
+// applyNamespacePins raises the database's per-prefix ID length pins to match
+// the id.namespace records in beadsDir. Pins never shrink, so a clone that has
+// lengthened its IDs keeps doing so even if it imports an older file.
+func applyNamespacePins(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, beadsDir string) error {
+	pins, err := idgen.LoadNamespace(beadsDir)
+	if err != nil {
+		return err
+	}
+	for prefix, length := range pins {
+		key := idgen.NamespaceConfigKey(prefix)
+		current, err := sqliteStore.GetConfig(ctx, key)
+		if err != nil {
+			return err
+		}
+		if n, err := strconv.Atoi(current); err == nil && n >= length {
+			continue
+		}
+		if err := sqliteStore.SetConfig(ctx, key, strconv.Itoa(length)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // handlePrefixMismatch checks and handles prefix mismatches.
 // Returns a filtered issues slice with tombstoned issues having wrong prefixes removed.
 func handlePrefixMismatch(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
@@ -948,6 +991,62 @@ func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issu
 	return nil
 }
 
+// importAttachments imports attachment metadata for issues.
+// Like labels, attachments are additive: an attachment already on the issue
+// is left alone, so the original timestamp and uploader are preserved.
+func importAttachments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if len(issue.Attachments) == 0 {
+			continue
+		}
+
+		current, err := sqliteStore.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting attachments for %s: %w", issue.ID, err)
+		}
+		existing := make(map[string]bool, len(current))
+		for _, a := range current {
+			existing[a.Hash] = true
+		}
+
+		for _, a := range issue.Attachments {
+			if a == nil || existing[a.Hash] {
+				continue
+			}
+			if err := sqliteStore.AddAttachment(ctx, issue.ID, a, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding attachment %s to %s: %w", a.Name, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// importWorkLogs imports work log entries for issues. AddWorkLog upserts by
+// (actor, start) and never reopens a stopped timer, so re-importing the same
+// JSONL is a no-op and a stop recorded on another clone closes the local entry.
+func importWorkLogs(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		for _, w := range issue.WorkLogs {
+			if w == nil {
+				continue
+			}
+			entry := *w
+			if err := sqliteStore.AddWorkLog(ctx, issue.ID, &entry, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding work log for %s to %s: %w", w.Actor, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
 // shouldProtectFromUpdate checks if an update should be skipped due to timestamp-aware protection (GH#865).
 // Returns true if the update should be skipped (local is newer), false if the update should proceed.
 // If the issue is not in the protection map, returns false (allow update).
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -811,6 +812,109 @@ func TestImportIssues_Labels(t *testing.T) {
 	}
 }
 
+func TestImportIssues_Attachments(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(context.Background(), tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	logHash := strings.Repeat("a", 64)
+	issues := []*types.Issue{
+		{
+			ID:        "test-abc123",
+			Title:     "Test Issue",
+			Status:    types.StatusOpen,
+			Priority:  1,
+			IssueType: types.TypeTask,
+			Attachments: []*types.Attachment{
+				{Hash: logHash, Name: "build.log", Size: 42, MimeType: "text/plain", CreatedBy: "alice", CreatedAt: created},
+			},
+		},
+	}
+
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	// Re-importing with an extra attachment is additive
+	issues[0].Attachments = append(issues[0].Attachments,
+		&types.Attachment{Hash: strings.Repeat("b", 64), Name: "shot.png", Size: 7, CreatedAt: created})
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+
+	got, err := store.GetAttachments(ctx, "test-abc123")
+	if err != nil {
+		t.Fatalf("Failed to get attachments: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 attachments, got %d", len(got))
+	}
+	if got[0].Hash != logHash || got[0].CreatedBy != "alice" || !got[0].CreatedAt.Equal(created) {
+		t.Errorf("Attachment metadata not preserved: %+v", got[0])
+	}
+}
+
+func TestImportIssues_WorkLogs(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(context.Background(), tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	start := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{
+			ID:        "test-abc123",
+			Title:     "Test Issue",
+			Status:    types.StatusOpen,
+			Priority:  1,
+			IssueType: types.TypeTask,
+			WorkLogs: []*types.WorkLog{
+				{Actor: "alice", StartedAt: start},
+			},
+		},
+	}
+
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	// Re-importing the stopped timer updates the entry instead of adding one
+	end := start.Add(45 * time.Minute)
+	issues[0].WorkLogs = []*types.WorkLog{{Actor: "alice", StartedAt: start, EndedAt: &end, Note: "done"}}
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+
+	got, err := store.GetWorkLogs(ctx, "test-abc123")
+	if err != nil {
+		t.Fatalf("Failed to get work logs: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 work log, got %d", len(got))
+	}
+	if got[0].IsRunning() || got[0].Note != "done" || got[0].Duration(time.Now()) != 45*time.Minute {
+		t.Errorf("Work log not updated on re-import: %+v", got[0])
+	}
+}
+
 func TestGetOrCreateStore_ExistingStore(t *testing.T) {
 	ctx := context.Background()
 	
@@ -1799,3 +1903,40 @@ func TestBuildAllowedPrefixSet(t *testing.T) {
 		}
 	})
 }
+
+func TestImportIssues_AppliesNamespacePins(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	tmpDB := filepath.Join(dir, "test.db")
+	store, err := sqlite.New(ctx, tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	// Local pin for "other" is longer than the synced one and must survive
+	if err := store.SetConfig(ctx, "id.namespace.other", "7"); err != nil {
+		t.Fatalf("Failed to set pin: %v", err)
+	}
+	if err := idgen.SaveNamespace(dir, map[string]int{"test": 6, "other": 5}); err != nil {
+		t.Fatalf("Failed to write namespace: %v", err)
+	}
+
+	issues := []*types.Issue{
+		{ID: "test-abc123", Title: "Test Issue", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+	}
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if got, _ := store.GetConfig(ctx, "id.namespace.test"); got != "6" {
+		t.Errorf("id.namespace.test = %q, want 6", got)
+	}
+	if got, _ := store.GetConfig(ctx, "id.namespace.other"); got != "7" {
+		t.Errorf("id.namespace.other = %q, want 7 (pins never shrink)", got)
+	}
+}
@@ -0,0 +1,281 @@
+// Package lease tracks time-limited claims on issues, so that work taken by
+// an agent that stops heartbeating goes back to the ready queue.
+//
+// Leases are kept in the database metadata table under a single key and every
+// change goes through RunInTransaction. Claims from concurrent processes
+// therefore serialize on the database write lock, and an issue can only be
+// handed to one holder.
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MetadataKey is the metadata entry holding all leases as a JSON object.
+const MetadataKey = "claim_leases"
+
+// DefaultTTL is how long a claim lasts without a heartbeat.
+const DefaultTTL = 30 * time.Minute
+
+// ReclaimActor is recorded on events and comments written when an expired
+// lease returns an issue to open.
+const ReclaimActor = "lease"
+
+// ErrNoWork is returned by Claim when no candidate could be claimed.
+var ErrNoWork = errors.New("no ready work to claim")
+
+// Lease is one holder's claim on an issue.
+type Lease struct {
+	IssueID   string    `json:"issue_id"`
+	Holder    string    `json:"holder"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the lease has run out at now.
+func (l *Lease) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// metadata is satisfied by both storage.Storage and storage.Transaction.
+type metadata interface {
+	GetMetadata(ctx context.Context, key string) (string, error)
+	SetMetadata(ctx context.Context, key, value string) error
+}
+
+func load(ctx context.Context, m metadata) (map[string]*Lease, error) {
+	raw, err := m.GetMetadata(ctx, MetadataKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading leases: %w", err)
+	}
+	leases := make(map[string]*Lease)
+	if raw == "" {
+		return leases, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &leases); err != nil {
+		return nil, fmt.Errorf("parsing leases: %w", err)
+	}
+	return leases, nil
+}
+
+func save(ctx context.Context, m metadata, leases map[string]*Lease) error {
+	data, err := json.Marshal(leases)
+	if err != nil {
+		return err
+	}
+	if err := m.SetMetadata(ctx, MetadataKey, string(data)); err != nil {
+		return fmt.Errorf("writing leases: %w", err)
+	}
+	return nil
+}
+
+// held reports whether the issue is still the in-progress work of the lease
+// holder. Leases on issues that were closed, released or reassigned are stale.
+func held(issue *types.Issue, l *Lease) bool {
+	return issue != nil && issue.Status == types.StatusInProgress && issue.Assignee == l.Holder
+}
+
+// List returns all recorded leases, soonest expiry first.
+func List(ctx context.Context, s storage.Storage) ([]*Lease, error) {
+	leases, err := load(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return sorted(leases), nil
+}
+
+// Get returns the lease on an issue, or nil if it has none.
+func Get(ctx context.Context, s storage.Storage, issueID string) (*Lease, error) {
+	leases, err := load(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return leases[issueID], nil
+}
+
+// Claim assigns the first candidate that is still open and unassigned to
+// holder, sets it in_progress and records a lease expiring after ttl.
+// Candidates usually come from GetReadyWork; each is re-read inside the
+// transaction, so a candidate another process claimed first is skipped.
+func Claim(ctx context.Context, s storage.Storage, candidates []*types.Issue, holder string, ttl time.Duration, now time.Time) (*types.Issue, *Lease, error) {
+	if holder == "" {
+		return nil, nil, errors.New("claim requires a holder")
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	var claimed *types.Issue
+	var lease *Lease
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		leases, err := load(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for _, c := range candidates {
+			issue, err := tx.GetIssue(ctx, c.ID)
+			if err != nil {
+				return err
+			}
+			if issue == nil || issue.Status != types.StatusOpen || issue.Assignee != "" {
+				continue
+			}
+			updates := map[string]interface{}{
+				"assignee": holder,
+				"status":   string(types.StatusInProgress),
+			}
+			if err := tx.UpdateIssue(ctx, issue.ID, updates, holder); err != nil {
+				return fmt.Errorf("claiming %s: %w", issue.ID, err)
+			}
+			lease = &Lease{IssueID: issue.ID, Holder: holder, ClaimedAt: now, ExpiresAt: now.Add(ttl)}
+			leases[issue.ID] = lease
+			if claimed, err = tx.GetIssue(ctx, issue.ID); err != nil {
+				return err
+			}
+			return save(ctx, tx, leases)
+		}
+		return ErrNoWork
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return claimed, lease, nil
+}
+
+// ClaimNext claims the top ready issue matching filter for holder. The filter
+// is narrowed to open, unassigned work. Callers run Reclaim first so that
+// work from lapsed leases is back in the queue.
+func ClaimNext(ctx context.Context, s storage.Storage, filter types.WorkFilter, holder string, ttl time.Duration, now time.Time) (*types.Issue, *Lease, error) {
+	filter.Status = types.StatusOpen
+	filter.Unassigned = true
+	filter.Assignee = nil
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	candidates, err := s.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding ready work: %w", err)
+	}
+	return Claim(ctx, s, candidates, holder, ttl, now)
+}
+
+// Renew extends every live lease held by one of holders to expire ttl after
+// now, and returns the renewed leases. Stale leases are dropped.
+func Renew(ctx context.Context, s storage.Storage, holders []string, ttl time.Duration, now time.Time) ([]*Lease, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	want := make(map[string]bool, len(holders))
+	for _, h := range holders {
+		if h != "" {
+			want[h] = true
+		}
+	}
+
+	var renewed []*Lease
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		leases, err := load(ctx, tx)
+		if err != nil {
+			return err
+		}
+		changed := false
+		for id, l := range leases {
+			if !want[l.Holder] {
+				continue
+			}
+			issue, err := tx.GetIssue(ctx, id)
+			if err != nil {
+				return err
+			}
+			if !held(issue, l) {
+				delete(leases, id)
+				changed = true
+				continue
+			}
+			l.ExpiresAt = now.Add(ttl)
+			renewed = append(renewed, l)
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+		return save(ctx, tx, leases)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(renewed, func(i, j int) bool { return renewed[i].IssueID < renewed[j].IssueID })
+	return renewed, nil
+}
+
+// Reclaim returns issues whose lease expired at now to open and unassigned,
+// with a comment recording whose lease lapsed, and returns those leases.
+// Leases on issues that are no longer held by their holder are dropped.
+func Reclaim(ctx context.Context, s storage.Storage, now time.Time) ([]*Lease, error) {
+	var reclaimed []*Lease
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		leases, err := load(ctx, tx)
+		if err != nil {
+			return err
+		}
+		changed := false
+		for _, l := range sorted(leases) {
+			issue, err := tx.GetIssue(ctx, l.IssueID)
+			if err != nil {
+				return err
+			}
+			if !held(issue, l) {
+				delete(leases, l.IssueID)
+				changed = true
+				continue
+			}
+			if !l.Expired(now) {
+				continue
+			}
+			updates := map[string]interface{}{
+				"assignee": "",
+				"status":   string(types.StatusOpen),
+			}
+			if err := tx.UpdateIssue(ctx, l.IssueID, updates, ReclaimActor); err != nil {
+				return fmt.Errorf("reclaiming %s: %w", l.IssueID, err)
+			}
+			comment := fmt.Sprintf("Lease held by %s expired at %s; returned to open.", l.Holder, l.ExpiresAt.UTC().Format(time.RFC3339))
+			if err := tx.AddComment(ctx, l.IssueID, ReclaimActor, comment); err != nil {
+				return fmt.Errorf("reclaiming %s: %w", l.IssueID, err)
+			}
+			delete(leases, l.IssueID)
+			reclaimed = append(reclaimed, l)
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+		return save(ctx, tx, leases)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reclaimed, nil
+}
+
+func sorted(leases map[string]*Lease) []*Lease {
+	list := make([]*Lease, 0, len(leases))
+	for _, l := range leases {
+		list = append(list, l)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].ExpiresAt.Equal(list[j].ExpiresAt) {
+			return list[i].ExpiresAt.Before(list[j].ExpiresAt)
+		}
+		return list[i].IssueID < list[j].IssueID
+	})
+	return list
+}
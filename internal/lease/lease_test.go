@@ -0,0 +1,141 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func newStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	ctx := context.Background()
+	s, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	if err := s.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func createIssue(t *testing.T, s *sqlite.SQLiteStorage, title string, priority int) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+	if err := s.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	return issue
+}
+
+func TestClaimNextIsExclusive(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+	for i := 0; i < 3; i++ {
+		createIssue(t, s, "work", 2)
+	}
+	now := time.Now()
+
+	var mu sync.Mutex
+	claimed := make(map[string]string)
+	var wg sync.WaitGroup
+	var noWork int
+	for _, agent := range []string{"a1", "a2", "a3", "a4", "a5"} {
+		wg.Add(1)
+		go func(agent string) {
+			defer wg.Done()
+			issue, _, err := ClaimNext(ctx, s, types.WorkFilter{}, agent, time.Minute, now)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, ErrNoWork):
+				noWork++
+			case err != nil:
+				t.Errorf("%s: %v", agent, err)
+			default:
+				if prev, dup := claimed[issue.ID]; dup {
+					t.Errorf("%s claimed by both %s and %s", issue.ID, prev, agent)
+				}
+				claimed[issue.ID] = agent
+				if issue.Assignee != agent || issue.Status != types.StatusInProgress {
+					t.Errorf("claimed issue = %s/%s, want %s/in_progress", issue.Assignee, issue.Status, agent)
+				}
+			}
+		}(agent)
+	}
+	wg.Wait()
+	if len(claimed) != 3 || noWork != 2 {
+		t.Errorf("claimed %d, no work %d; want 3 and 2", len(claimed), noWork)
+	}
+	leases, err := List(ctx, s)
+	if err != nil || len(leases) != 3 {
+		t.Errorf("leases = %v, %v", leases, err)
+	}
+}
+
+func TestRenewAndReclaim(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+	kept := createIssue(t, s, "kept", 1)
+	lapsed := createIssue(t, s, "lapsed", 2)
+	now := time.Now()
+
+	if _, _, err := Claim(ctx, s, []*types.Issue{kept}, "alive", time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Claim(ctx, s, []*types.Issue{lapsed}, "dead", time.Minute, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Claim(ctx, s, []*types.Issue{kept}, "late", time.Minute, now); !errors.Is(err, ErrNoWork) {
+		t.Fatalf("claiming held work: err = %v, want ErrNoWork", err)
+	}
+
+	later := now.Add(50 * time.Second)
+	renewed, err := Renew(ctx, s, []string{"alive"}, time.Minute, later)
+	if err != nil || len(renewed) != 1 || renewed[0].IssueID != kept.ID {
+		t.Fatalf("Renew = %v, %v", renewed, err)
+	}
+
+	reclaimed, err := Reclaim(ctx, s, now.Add(90*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].IssueID != lapsed.ID || reclaimed[0].Holder != "dead" {
+		t.Fatalf("reclaimed = %v", reclaimed)
+	}
+	got, _ := s.GetIssue(ctx, lapsed.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("lapsed issue = %s/%q, want open and unassigned", got.Status, got.Assignee)
+	}
+	events, _ := s.GetEvents(ctx, lapsed.ID, 0)
+	found := false
+	for _, e := range events {
+		if e.Actor == ReclaimActor && e.EventType == types.EventCommented {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("no reclaim event recorded: %v", events)
+	}
+	if got, _ := s.GetIssue(ctx, kept.ID); got.Assignee != "alive" {
+		t.Errorf("renewed issue lost its assignee: %q", got.Assignee)
+	}
+
+	// Closing work makes its lease stale; the next pass drops it
+	if err := s.CloseIssue(ctx, kept.ID, "done", "alive", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Reclaim(ctx, s, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if leases, _ := List(ctx, s); len(leases) != 0 {
+		t.Errorf("stale leases kept: %v", leases)
+	}
+}
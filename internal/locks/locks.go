@@ -0,0 +1,488 @@
+// Package locks implements named counting semaphores for coordinating agents:
+// deploy slots, shared test databases, release trains.
+//
+// A lock admits up to Capacity holders. Requesters that don't fit join a
+// queue ordered first-come-first-served or by priority, and a free slot only
+// goes to the head of the queue, so a steady stream of new requesters cannot
+// starve one that has been waiting. Holders and queued waiters both hold
+// leases that lapse unless renewed, so a crashed agent cannot keep a lock.
+//
+// All locks are kept in one metadata entry and every change runs in
+// RunInTransaction, which serializes concurrent processes on the database
+// write lock.
+package locks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// MetadataKey is the metadata entry holding all locks as a JSON object.
+const MetadataKey = "locks"
+
+// DefaultTTL is how long a hold or a place in the queue lasts without renewal.
+const DefaultTTL = 10 * time.Minute
+
+// DefaultPriority is the queue priority of requesters that don't set one.
+const DefaultPriority = 2
+
+// Policy orders a lock's queue.
+type Policy string
+
+const (
+	PolicyFIFO     Policy = "fifo"     // First come, first served
+	PolicyPriority Policy = "priority" // Lowest priority number first, then FIFO
+)
+
+// IsValid reports whether p is a known policy.
+func (p Policy) IsValid() bool {
+	return p == PolicyFIFO || p == PolicyPriority
+}
+
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,63}$`)
+
+// ValidateName checks that a lock name is usable.
+func ValidateName(name string) error {
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("invalid lock name %q (letters, digits and . _ : / -, at most 64)", name)
+	}
+	return nil
+}
+
+// Entry is a holder or a queued waiter.
+type Entry struct {
+	Holder    string    `json:"holder"`
+	Priority  int       `json:"priority"`
+	Since     time.Time `json:"since"` // When the slot was acquired, or the queue joined
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Lock is one named semaphore.
+type Lock struct {
+	Name     string   `json:"name"`
+	Capacity int      `json:"capacity"`
+	Policy   Policy   `json:"policy"`
+	Holders  []*Entry `json:"holders"`
+	Waiters  []*Entry `json:"waiters"`
+}
+
+// Free returns the number of unheld slots.
+func (l *Lock) Free() int {
+	if n := l.Capacity - len(l.Holders); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// IsHeldBy reports whether holder holds a slot.
+func (l *Lock) IsHeldBy(holder string) bool {
+	return indexOf(l.Holders, holder) >= 0
+}
+
+// Position returns holder's 1-based place in the queue, or 0 if not queued.
+func (l *Lock) Position(holder string) int {
+	return indexOf(l.Waiters, holder) + 1
+}
+
+func indexOf(entries []*Entry, holder string) int {
+	for i, e := range entries {
+		if e.Holder == holder {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *Lock) sortWaiters() {
+	sort.SliceStable(l.Waiters, func(i, j int) bool {
+		a, b := l.Waiters[i], l.Waiters[j]
+		if l.Policy == PolicyPriority && a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Since.Before(b.Since)
+	})
+}
+
+// expire drops holders and waiters whose lease lapsed and returns the
+// holders that were dropped.
+func (l *Lock) expire(now time.Time) []*Entry {
+	var expired []*Entry
+	keep := l.Holders[:0]
+	for _, e := range l.Holders {
+		if now.Before(e.ExpiresAt) {
+			keep = append(keep, e)
+		} else {
+			expired = append(expired, e)
+		}
+	}
+	l.Holders = keep
+	waiting := l.Waiters[:0]
+	for _, e := range l.Waiters {
+		if now.Before(e.ExpiresAt) {
+			waiting = append(waiting, e)
+		}
+	}
+	l.Waiters = waiting
+	return expired
+}
+
+// Request asks for a slot in a lock.
+type Request struct {
+	Name     string
+	Holder   string
+	Capacity int    // Sets the lock's capacity when > 0 (new locks default to 1)
+	Policy   Policy // Sets the lock's queue policy when non-empty (new locks default to fifo)
+	Priority int    // Queue priority under PolicyPriority (0 = highest)
+	TTL      time.Duration
+	Queue    bool // Join (or stay in) the queue if the slot isn't granted
+}
+
+// Result is the outcome of Acquire.
+type Result struct {
+	Lock     *Lock `json:"lock"`
+	Acquired bool  `json:"acquired"`
+	Position int   `json:"position,omitempty"` // Place in the queue when not acquired
+}
+
+// ErrNotHeld is returned by Release when the holder holds no slot.
+var ErrNotHeld = errors.New("lock not held")
+
+// metadata is satisfied by both storage.Storage and storage.Transaction.
+type metadata interface {
+	GetMetadata(ctx context.Context, key string) (string, error)
+	SetMetadata(ctx context.Context, key, value string) error
+}
+
+func load(ctx context.Context, m metadata) (map[string]*Lock, error) {
+	raw, err := m.GetMetadata(ctx, MetadataKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading locks: %w", err)
+	}
+	all := make(map[string]*Lock)
+	if raw == "" {
+		return all, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &all); err != nil {
+		return nil, fmt.Errorf("parsing locks: %w", err)
+	}
+	return all, nil
+}
+
+func save(ctx context.Context, m metadata, all map[string]*Lock) error {
+	for name, l := range all {
+		// Forget idle locks that were never given a non-default shape
+		if len(l.Holders) == 0 && len(l.Waiters) == 0 && l.Capacity <= 1 && l.Policy == PolicyFIFO {
+			delete(all, name)
+		}
+	}
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	if err := m.SetMetadata(ctx, MetadataKey, string(data)); err != nil {
+		return fmt.Errorf("writing locks: %w", err)
+	}
+	return nil
+}
+
+// update runs fn on all locks, with leases expired as of now, and saves the
+// result.
+func update(ctx context.Context, s storage.Storage, now time.Time, fn func(all map[string]*Lock) error) error {
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		all, err := load(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for _, l := range all {
+			l.expire(now)
+		}
+		if err := fn(all); err != nil {
+			return err
+		}
+		return save(ctx, tx, all)
+	})
+}
+
+// Acquire grants req.Holder a slot if one is free and no earlier waiter is
+// entitled to it. A holder that already has a slot renews it. Otherwise, with
+// req.Queue, the holder joins the queue (or renews its place in it).
+func Acquire(ctx context.Context, s storage.Storage, req Request, now time.Time) (*Result, error) {
+	if err := ValidateName(req.Name); err != nil {
+		return nil, err
+	}
+	if req.Holder == "" {
+		return nil, errors.New("acquiring a lock requires a holder")
+	}
+	if req.Policy != "" && !req.Policy.IsValid() {
+		return nil, fmt.Errorf("invalid lock policy %q (use fifo or priority)", req.Policy)
+	}
+	ttl := req.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	var result Result
+	err := update(ctx, s, now, func(all map[string]*Lock) error {
+		l := all[req.Name]
+		if l == nil {
+			l = &Lock{Name: req.Name, Capacity: 1, Policy: PolicyFIFO}
+			all[req.Name] = l
+		}
+		if req.Capacity > 0 {
+			l.Capacity = req.Capacity
+		}
+		if req.Policy != "" {
+			l.Policy = req.Policy
+		}
+		l.sortWaiters()
+		result.Lock = l
+
+		if i := indexOf(l.Holders, req.Holder); i >= 0 {
+			l.Holders[i].ExpiresAt = now.Add(ttl)
+			result.Acquired = true
+			return nil
+		}
+
+		// The first Free() waiters are entitled to the free slots; a newcomer
+		// counts as queued behind everyone already waiting
+		pos := indexOf(l.Waiters, req.Holder)
+		place := pos
+		if place < 0 {
+			place = len(l.Waiters)
+		}
+		if place < l.Free() {
+			if pos >= 0 {
+				l.Waiters = append(l.Waiters[:pos], l.Waiters[pos+1:]...)
+			}
+			l.Holders = append(l.Holders, &Entry{Holder: req.Holder, Priority: req.Priority, Since: now, ExpiresAt: now.Add(ttl)})
+			result.Acquired = true
+			return nil
+		}
+
+		if pos >= 0 {
+			l.Waiters[pos].ExpiresAt = now.Add(ttl)
+		} else if req.Queue {
+			l.Waiters = append(l.Waiters, &Entry{Holder: req.Holder, Priority: req.Priority, Since: now, ExpiresAt: now.Add(ttl)})
+			l.sortWaiters()
+		}
+		result.Position = l.Position(req.Holder)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Release gives up holder's slot in the named lock and its place in the
+// queue. It returns ErrNotHeld if the holder neither held nor awaited it.
+func Release(ctx context.Context, s storage.Storage, name, holder string, now time.Time) (*Lock, error) {
+	var released *Lock
+	err := update(ctx, s, now, func(all map[string]*Lock) error {
+		l := all[name]
+		if l == nil {
+			return ErrNotHeld
+		}
+		found := false
+		if i := indexOf(l.Holders, holder); i >= 0 {
+			l.Holders = append(l.Holders[:i], l.Holders[i+1:]...)
+			found = true
+		}
+		if i := indexOf(l.Waiters, holder); i >= 0 {
+			l.Waiters = append(l.Waiters[:i], l.Waiters[i+1:]...)
+			found = true
+		}
+		if !found {
+			return ErrNotHeld
+		}
+		released = l
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// ReleaseAll gives up every slot and queue place belonging to holder and
+// returns the names of the locks it held or awaited.
+func ReleaseAll(ctx context.Context, s storage.Storage, holder string, now time.Time) ([]string, error) {
+	var names []string
+	err := update(ctx, s, now, func(all map[string]*Lock) error {
+		for name, l := range all {
+			found := false
+			if i := indexOf(l.Holders, holder); i >= 0 {
+				l.Holders = append(l.Holders[:i], l.Holders[i+1:]...)
+				found = true
+			}
+			if i := indexOf(l.Waiters, holder); i >= 0 {
+				l.Waiters = append(l.Waiters[:i], l.Waiters[i+1:]...)
+				found = true
+			}
+			if found {
+				names = append(names, name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Renew extends every hold and queue place belonging to one of holders to
+// expire ttl after now, and returns the names of the locks touched.
+func Renew(ctx context.Context, s storage.Storage, holders []string, ttl time.Duration, now time.Time) ([]string, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	want := make(map[string]bool, len(holders))
+	for _, h := range holders {
+		if h != "" {
+			want[h] = true
+		}
+	}
+	var names []string
+	err := update(ctx, s, now, func(all map[string]*Lock) error {
+		for name, l := range all {
+			touched := false
+			for _, e := range append(append([]*Entry{}, l.Holders...), l.Waiters...) {
+				if want[e.Holder] {
+					e.ExpiresAt = now.Add(ttl)
+					touched = true
+				}
+			}
+			if touched {
+				names = append(names, name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// List returns all locks by name, as of now (lapsed leases are not shown).
+func List(ctx context.Context, s storage.Storage, now time.Time) ([]*Lock, error) {
+	all, err := load(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Lock, 0, len(all))
+	for _, l := range all {
+		l.expire(now)
+		l.sortWaiters()
+		list = append(list, l)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// NextExpiry returns the earliest time a holder's lease in l lapses, or the
+// zero time if l has no holders. Waiters can be woken then.
+func (l *Lock) NextExpiry() time.Time {
+	var next time.Time
+	for _, e := range l.Holders {
+		if next.IsZero() || e.ExpiresAt.Before(next) {
+			next = e.ExpiresAt
+		}
+	}
+	return next
+}
+
+// Deadlock is a cycle of holders each waiting for a lock the next one holds.
+type Deadlock struct {
+	Holders []string `json:"holders"` // Holders[i] waits for Holders[i+1] (wrapping)
+	Locks   []string `json:"locks"`   // Locks[i] is the lock Holders[i] waits for
+}
+
+func (d Deadlock) String() string {
+	var parts []string
+	for i, h := range d.Holders {
+		next := d.Holders[(i+1)%len(d.Holders)]
+		parts = append(parts, fmt.Sprintf("%s waits for %s on %s", h, next, d.Locks[i]))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Involves reports whether holder is part of the cycle.
+func (d Deadlock) Involves(holder string) bool {
+	for _, h := range d.Holders {
+		if h == holder {
+			return true
+		}
+	}
+	return false
+}
+
+// FindDeadlocks reports cycles in the wait-for graph: a waiter on a full
+// lock waits for each of that lock's holders. Each cycle is reported once.
+func FindDeadlocks(list []*Lock) []Deadlock {
+	type edge struct{ to, lock string }
+	graph := make(map[string][]edge)
+	for _, l := range list {
+		if l.Free() > 0 {
+			continue
+		}
+		for _, w := range l.Waiters {
+			for _, h := range l.Holders {
+				if w.Holder != h.Holder {
+					graph[w.Holder] = append(graph[w.Holder], edge{h.Holder, l.Name})
+				}
+			}
+		}
+	}
+
+	nodes := make([]string, 0, len(graph))
+	for n := range graph {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes)
+
+	// Report each cycle from its smallest member: only follow edges to
+	// nodes greater than the start, so every rotation but one is skipped
+	var found []Deadlock
+	seen := make(map[string]bool)
+	for _, start := range nodes {
+		var path []string
+		var via []string
+		onPath := make(map[string]bool)
+		var walk func(n string)
+		walk = func(n string) {
+			path = append(path, n)
+			onPath[n] = true
+			for _, e := range graph[n] {
+				switch {
+				case e.to == start:
+					d := Deadlock{Holders: append([]string{}, path...), Locks: append(append([]string{}, via...), e.lock)}
+					key := strings.Join(d.Holders, "\x00") + "\x01" + strings.Join(d.Locks, "\x00")
+					if !seen[key] {
+						seen[key] = true
+						found = append(found, d)
+					}
+				case e.to > start && !onPath[e.to]:
+					via = append(via, e.lock)
+					walk(e.to)
+					via = via[:len(via)-1]
+				}
+			}
+			onPath[n] = false
+			path = path[:len(path)-1]
+		}
+		walk(start)
+	}
+	return found
+}
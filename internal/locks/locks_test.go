@@ -0,0 +1,134 @@
+package locks
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+func newStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	s, err := sqlite.New(context.Background(), filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func mustAcquire(t *testing.T, s *sqlite.SQLiteStorage, req Request, now time.Time) *Result {
+	t.Helper()
+	res, err := Acquire(context.Background(), s, req, now)
+	if err != nil {
+		t.Fatalf("Acquire(%s, %s): %v", req.Name, req.Holder, err)
+	}
+	return res
+}
+
+func TestSemaphoreCapacityAndFairness(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+	now := time.Now()
+
+	if r := mustAcquire(t, s, Request{Name: "test-db", Holder: "a", Capacity: 2}, now); !r.Acquired {
+		t.Fatal("a should acquire")
+	}
+	if r := mustAcquire(t, s, Request{Name: "test-db", Holder: "b"}, now); !r.Acquired || r.Lock.Capacity != 2 {
+		t.Fatalf("b should take the second slot: %+v", r)
+	}
+	if r := mustAcquire(t, s, Request{Name: "test-db", Holder: "c", Queue: true}, now); r.Acquired || r.Position != 1 {
+		t.Fatalf("c should queue at 1: %+v", r)
+	}
+
+	// A slot frees up; a newcomer may not jump the queue, c gets it
+	if _, err := Release(ctx, s, "test-db", "a", now); err != nil {
+		t.Fatal(err)
+	}
+	if r := mustAcquire(t, s, Request{Name: "test-db", Holder: "d", Queue: true}, now.Add(time.Second)); r.Acquired || r.Position != 2 {
+		t.Fatalf("d must queue behind c: %+v", r)
+	}
+	if r := mustAcquire(t, s, Request{Name: "test-db", Holder: "c"}, now.Add(2*time.Second)); !r.Acquired {
+		t.Fatalf("c should acquire the freed slot: %+v", r)
+	}
+
+	if _, err := Release(ctx, s, "test-db", "zed", now); !errors.Is(err, ErrNotHeld) {
+		t.Errorf("releasing an unheld lock: err = %v, want ErrNotHeld", err)
+	}
+
+	mustAcquire(t, s, Request{Name: "deploy", Holder: "c"}, now)
+	names, err := ReleaseAll(ctx, s, "c", now)
+	if err != nil || !reflect.DeepEqual(names, []string{"deploy", "test-db"}) {
+		t.Fatalf("ReleaseAll = %v, %v", names, err)
+	}
+	if r := mustAcquire(t, s, Request{Name: "test-db", Holder: "d"}, now); !r.Acquired {
+		t.Errorf("d should get the slot c gave up: %+v", r)
+	}
+}
+
+func TestPriorityPolicyAndTTL(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+	now := time.Now()
+
+	mustAcquire(t, s, Request{Name: "deploy", Holder: "holder", Policy: PolicyPriority, TTL: time.Minute}, now)
+	mustAcquire(t, s, Request{Name: "deploy", Holder: "low", Priority: 3, Queue: true, TTL: time.Hour}, now)
+	if r := mustAcquire(t, s, Request{Name: "deploy", Holder: "urgent", Priority: 0, Queue: true, TTL: time.Hour}, now.Add(time.Second)); r.Position != 1 {
+		t.Fatalf("P0 waiter should be first in a priority queue: %+v", r)
+	}
+
+	// The holder stops renewing; after its lease lapses the head of the queue gets in
+	later := now.Add(2 * time.Minute)
+	if r := mustAcquire(t, s, Request{Name: "deploy", Holder: "low", TTL: time.Hour}, later); r.Acquired {
+		t.Fatal("low must not overtake urgent")
+	}
+	if r := mustAcquire(t, s, Request{Name: "deploy", Holder: "urgent", TTL: time.Hour}, later); !r.Acquired {
+		t.Fatalf("urgent should acquire once the lease lapsed: %+v", r)
+	}
+
+	renewed, err := Renew(ctx, s, []string{"urgent"}, 3*time.Hour, later)
+	if err != nil || !reflect.DeepEqual(renewed, []string{"deploy"}) {
+		t.Fatalf("Renew = %v, %v", renewed, err)
+	}
+	list, err := List(ctx, s, later.Add(2*time.Hour))
+	if err != nil || len(list) != 1 {
+		t.Fatalf("List = %v, %v", list, err)
+	}
+	if l := list[0]; !l.IsHeldBy("urgent") || len(l.Waiters) != 0 {
+		t.Errorf("after 2h: holders %v, waiters %v; want urgent holding and low's queue place lapsed", l.Holders, l.Waiters)
+	}
+}
+
+func TestFindDeadlocks(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t)
+	now := time.Now()
+
+	mustAcquire(t, s, Request{Name: "db", Holder: "a"}, now)
+	mustAcquire(t, s, Request{Name: "deploy", Holder: "b"}, now)
+	mustAcquire(t, s, Request{Name: "deploy", Holder: "a", Queue: true}, now)
+	mustAcquire(t, s, Request{Name: "unrelated", Holder: "c"}, now)
+	mustAcquire(t, s, Request{Name: "unrelated", Holder: "b", Queue: true}, now)
+	list, _ := List(ctx, s, now)
+	if got := FindDeadlocks(list); len(got) != 0 {
+		t.Fatalf("no cycle yet, got %v", got)
+	}
+
+	mustAcquire(t, s, Request{Name: "db", Holder: "b", Queue: true}, now)
+	list, _ = List(ctx, s, now)
+	got := FindDeadlocks(list)
+	want := []Deadlock{{Holders: []string{"a", "b"}, Locks: []string{"deploy", "db"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindDeadlocks = %+v, want %+v", got, want)
+	}
+	if !got[0].Involves("b") || got[0].Involves("c") {
+		t.Error("Involves is wrong")
+	}
+	if s := got[0].String(); s != "a waits for b on deploy; b waits for a on db" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -0,0 +1,33 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+)
+
+// maxHTTPBody bounds a single POSTed message.
+const maxHTTPBody = 16 << 20
+
+// ServeHTTP implements the streamable HTTP transport without server-sent
+// streams: each POST carries one JSON-RPC message and gets a JSON reply,
+// or 202 Accepted for notifications. GET (server-initiated streams) is not
+// offered, which the transport allows.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPBody))
+	if err != nil {
+		http.Error(w, "reading request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	out := s.HandleMessage(r.Context(), body)
+	if out == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(out)
+}
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SchemaOptions adjusts a schema derived from a struct.
+type SchemaOptions struct {
+	// Fields limits the schema to these JSON names (all fields if empty).
+	Fields []string
+	// Optional marks fields as not required even without omitempty, for
+	// arguments the tool fills in with defaults.
+	Optional []string
+	// Descriptions documents fields by JSON name.
+	Descriptions map[string]string
+	// Extra adds properties that aren't part of the struct, such as
+	// routing arguments the tool consumes itself. Extra properties are
+	// never required.
+	Extra map[string]map[string]interface{}
+}
+
+// SchemaFor derives a JSON Schema object from a struct's exported fields and
+// their json tags. Fields tagged without omitempty are required; pointers
+// and omitempty fields are optional.
+func SchemaFor(v interface{}, opts SchemaOptions) map[string]interface{} {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	only := toSet(opts.Fields)
+	optional := toSet(opts.Optional)
+	properties := make(map[string]interface{})
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		name, omitempty := jsonName(f)
+		if name == "" || (len(only) > 0 && !only[name]) {
+			continue
+		}
+		prop := typeSchema(f.Type)
+		if d := opts.Descriptions[name]; d != "" {
+			prop["description"] = d
+		}
+		properties[name] = prop
+		if !omitempty && f.Type.Kind() != reflect.Ptr && !optional[name] {
+			required = append(required, name)
+		}
+	}
+	for name, prop := range opts.Extra {
+		properties[name] = prop
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string"} // []byte and json.RawMessage
+		}
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.PkgPath() == "time" && t.Name() == "Time" {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+		return SchemaFor(reflect.New(t).Interface(), SchemaOptions{})
+	}
+	return map[string]interface{}{}
+}
+
+// jsonName returns a field's JSON name and whether it is omitempty, or ""
+// when the field is skipped.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	omitempty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty
+}
+
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
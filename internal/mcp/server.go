@@ -0,0 +1,255 @@
+// Package mcp implements a Model Context Protocol server: JSON-RPC 2.0 over
+// stdio (newline-delimited) or streamable HTTP, exposing a fixed set of tools.
+//
+// Only the tool capability is implemented. Each tool's input schema is
+// derived from a Go struct (see SchemaFor), so tools backed by RPC
+// operations share the daemon's argument types.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// SupportedProtocolVersions lists the MCP revisions this server speaks,
+// newest first. The newest is offered when the client asks for another.
+var SupportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Handler runs a tool with its raw JSON arguments and returns a value to be
+// encoded as the tool result. A returned error is reported to the client as
+// a tool error (isError), not a protocol error, so the model can see it.
+type Handler func(ctx context.Context, args json.RawMessage) (interface{}, error)
+
+// Tool is one callable tool.
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+	Handler     Handler                `json:"-"`
+}
+
+// Server dispatches MCP requests to registered tools.
+type Server struct {
+	name    string
+	version string
+
+	mu    sync.RWMutex
+	tools []*Tool
+	index map[string]*Tool
+}
+
+// NewServer creates a server that reports the given name and version to
+// clients during initialization.
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version, index: make(map[string]*Tool)}
+}
+
+// AddTool registers a tool. Registering a name twice replaces the tool.
+func (s *Server) AddTool(t *Tool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.index[t.Name]; exists {
+		for i := range s.tools {
+			if s.tools[i].Name == t.Name {
+				s.tools[i] = t
+			}
+		}
+	} else {
+		s.tools = append(s.tools, t)
+	}
+	s.index[t.Name] = t
+}
+
+// Tools returns the registered tools in registration order.
+func (s *Server) Tools() []*Tool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]*Tool(nil), s.tools...)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Content is one block of a tool result. Only text content is produced.
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// CallToolResult is the result of tools/call.
+type CallToolResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// HandleMessage processes one JSON-RPC message and returns the encoded
+// response, or nil for notifications, which get no reply.
+func (s *Server) HandleMessage(ctx context.Context, msg []byte) []byte {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return encode(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParseError, "parse error: " + err.Error()}})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return encode(response{JSONRPC: "2.0", ID: idOrNull(req.ID), Error: &rpcError{codeInvalidRequest, "invalid request"}})
+	}
+	isNotification := len(req.ID) == 0
+
+	result, rerr := s.dispatch(ctx, &req)
+	if isNotification {
+		return nil
+	}
+	resp := response{JSONRPC: "2.0", ID: req.ID}
+	if rerr != nil {
+		resp.Error = rerr
+	} else {
+		resp.Result = result
+	}
+	return encode(resp)
+}
+
+func (s *Server) dispatch(ctx context.Context, req *request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := SupportedProtocolVersions[0]
+		for _, v := range SupportedProtocolVersions {
+			if v == params.ProtocolVersion {
+				version = v
+			}
+		}
+		return map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities": map[string]interface{}{
+				"tools": map[string]interface{}{"listChanged": false},
+			},
+			"serverInfo": map[string]string{"name": s.name, "version": s.version},
+		}, nil
+
+	case "ping":
+		return map[string]interface{}{}, nil
+
+	case "tools/list":
+		return map[string]interface{}{"tools": s.Tools()}, nil
+
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{codeInvalidParams, "invalid params: " + err.Error()}
+		}
+		s.mu.RLock()
+		tool := s.index[params.Name]
+		s.mu.RUnlock()
+		if tool == nil {
+			return nil, &rpcError{codeInvalidParams, fmt.Sprintf("unknown tool: %s", params.Name)}
+		}
+		if len(params.Arguments) == 0 || string(params.Arguments) == "null" {
+			params.Arguments = json.RawMessage("{}")
+		}
+		return callTool(ctx, tool, params.Arguments), nil
+	}
+
+	if len(req.Method) > len("notifications/") && req.Method[:len("notifications/")] == "notifications/" {
+		return nil, nil // initialized, cancelled, ...: nothing to do
+	}
+	return nil, &rpcError{codeMethodNotFound, fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+func callTool(ctx context.Context, tool *Tool, args json.RawMessage) (result *CallToolResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = &CallToolResult{Content: []Content{{Type: "text", Text: fmt.Sprintf("%s panicked: %v", tool.Name, r)}}, IsError: true}
+		}
+	}()
+
+	value, err := tool.Handler(ctx, args)
+	if err != nil {
+		return &CallToolResult{Content: []Content{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case json.RawMessage:
+		text = string(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return &CallToolResult{Content: []Content{{Type: "text", Text: "encoding result: " + err.Error()}}, IsError: true}
+		}
+		text = string(data)
+	}
+	return &CallToolResult{Content: []Content{{Type: "text", Text: text}}}
+}
+
+// ServeStdio reads newline-delimited JSON-RPC messages from r and writes
+// responses to w until r is exhausted or ctx is done. Requests are handled
+// one at a time, in order.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if out := s.HandleMessage(ctx, line); out != nil {
+			if _, err := w.Write(append(out, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func encode(resp response) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{codeInvalidRequest, err.Error()}})
+	}
+	return data
+}
+
+func idOrNull(id json.RawMessage) json.RawMessage {
+	if len(id) == 0 {
+		return json.RawMessage("null")
+	}
+	return id
+}
@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type echoArgs struct {
+	ID     string   `json:"id"`
+	Note   *string  `json:"note,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	Hidden string   `json:"hidden"`
+	skip   string
+}
+
+func newEchoServer() *Server {
+	s := NewServer("test", "1.0.0")
+	s.AddTool(&Tool{
+		Name:        "echo",
+		Description: "Echo the arguments",
+		InputSchema: SchemaFor(echoArgs{}, SchemaOptions{Fields: []string{"id", "note", "limit", "labels"}}),
+		Handler: func(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+			var args echoArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, err
+			}
+			if args.ID == "fail" {
+				return nil, errors.New("no such issue")
+			}
+			return args, nil
+		},
+	})
+	return s
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema := SchemaFor(echoArgs{}, SchemaOptions{
+		Optional:     []string{"hidden"},
+		Descriptions: map[string]string{"id": "Issue ID"},
+		Extra:        map[string]map[string]interface{}{"workspace_root": {"type": "string"}},
+	})
+	props := schema["properties"].(map[string]interface{})
+	if len(props) != 6 {
+		t.Errorf("properties = %v", props)
+	}
+	if props["id"].(map[string]interface{})["description"] != "Issue ID" {
+		t.Errorf("id = %v", props["id"])
+	}
+	if props["note"].(map[string]interface{})["type"] != "string" || props["limit"].(map[string]interface{})["type"] != "integer" {
+		t.Errorf("note/limit = %v/%v", props["note"], props["limit"])
+	}
+	if items := props["labels"].(map[string]interface{})["items"].(map[string]interface{}); items["type"] != "string" {
+		t.Errorf("labels items = %v", items)
+	}
+	if req := schema["required"].([]string); len(req) != 1 || req[0] != "id" {
+		t.Errorf("required = %v", req)
+	}
+}
+
+func TestServeStdio(t *testing.T) {
+	s := newEchoServer()
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"id":"bd-1","limit":5}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{"id":"fail"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"resources/list"}`,
+		`not json`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := s.ServeStdio(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("got %d responses (notifications must not be answered):\n%s", len(lines), out.String())
+	}
+	var resps []map[string]interface{}
+	for _, l := range lines {
+		var r map[string]interface{}
+		if err := json.Unmarshal([]byte(l), &r); err != nil {
+			t.Fatalf("bad response %q: %v", l, err)
+		}
+		resps = append(resps, r)
+	}
+
+	init := resps[0]["result"].(map[string]interface{})
+	if init["protocolVersion"] != "2025-03-26" {
+		t.Errorf("protocolVersion = %v, want the client's", init["protocolVersion"])
+	}
+	tools := resps[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 1 || tools[0].(map[string]interface{})["name"] != "echo" {
+		t.Errorf("tools = %v", tools)
+	}
+
+	call := resps[2]["result"].(map[string]interface{})
+	text := call["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	if call["isError"] == true || !strings.Contains(text, `"id":"bd-1"`) || !strings.Contains(text, `"limit":5`) {
+		t.Errorf("echo result = %v", call)
+	}
+	if failed := resps[3]["result"].(map[string]interface{}); failed["isError"] != true {
+		t.Errorf("handler error should be a tool error, got %v", resps[3])
+	}
+	for i, code := range map[int]float64{4: codeInvalidParams, 5: codeMethodNotFound, 6: codeParseError} {
+		e, ok := resps[i]["error"].(map[string]interface{})
+		if !ok || e["code"] != code {
+			t.Errorf("response %d = %v, want error %v", i, resps[i], code)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	srv := httptest.NewServer(newEchoServer())
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":"a","method":"tools/call","params":{"name":"echo","arguments":{"id":"x"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || body["id"] != "a" || body["result"] == nil {
+		t.Errorf("POST call = %d %v", resp.StatusCode, body)
+	}
+
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("notification status = %d, want 202", resp.StatusCode)
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", resp.StatusCode)
+	}
+}
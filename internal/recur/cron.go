@@ -0,0 +1,184 @@
+package recur
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros expands the @-shorthands to five-field expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// cronRule is a five-field cron expression. Each field is a bitset of the
+// values it allows.
+type cronRule struct {
+	expr                        string
+	minute, hour, dom, mon, dow uint64
+	// domStar and dowStar record an unrestricted day field. As in Vixie
+	// cron, when both day fields are restricted a day matching either one
+	// is enough.
+	domStar, dowStar bool
+	loc              *time.Location
+}
+
+func parseCron(expr string, loc *time.Location) (*cronRule, error) {
+	spec := expr
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := cronMacros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron macro %q", spec)
+		}
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week)", expr)
+	}
+
+	r := &cronRule{expr: expr, loc: loc}
+	var err error
+	if r.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if r.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if r.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if r.mon, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if r.dow, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is an alias for Sunday
+	if r.dow&(1<<7) != 0 {
+		r.dow |= 1
+	}
+	r.domStar = strings.HasPrefix(fields[2], "*") || fields[2] == "?"
+	r.dowStar = strings.HasPrefix(fields[4], "*") || fields[4] == "?"
+	return r, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps
+// ("*", "5", "1-5", "*/15", "MON-FRI", "0-30/10") into a bitset.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			v, err := cronValue(rangePart, names)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = max // "5/15" means from 5 to the end, every 15
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+func (r *cronRule) String() string { return r.expr }
+
+// Next walks forward from t, skipping a whole month, day or hour as soon as
+// that field fails to match.
+func (r *cronRule) Next(t time.Time) time.Time {
+	after := t
+	t = startOfMinute(t, r.loc)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, r.loc)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		if r.mon&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, r.loc)
+			continue
+		}
+		if !r.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, r.loc)
+			continue
+		}
+		if r.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, r.loc)
+			continue
+		}
+		if r.minute&(1<<uint(t.Minute())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, r.loc)
+			continue
+		}
+		if !t.After(after) {
+			// A wall-clock hour repeated when clocks go back resolves to its
+			// first instance; step through the repeat in absolute time.
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (r *cronRule) dayMatches(t time.Time) bool {
+	domOK := r.dom&(1<<uint(t.Day())) != 0
+	dowOK := r.dow&(1<<uint(t.Weekday())) != 0
+	if r.domStar || r.dowStar {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
@@ -0,0 +1,88 @@
+// Package recur parses recurrence rules and computes their occurrences.
+//
+// Two notations are accepted:
+//
+//   - Five-field cron ("0 9 * * MON"), including names, ranges, lists,
+//     steps and the @hourly/@daily/@weekly/@monthly/@yearly macros.
+//   - An iCalendar RRULE subset ("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"), with
+//     FREQ HOURLY through YEARLY, INTERVAL, BYMONTH, BYMONTHDAY, BYDAY
+//     (without ordinals), BYHOUR, BYMINUTE and UNTIL.
+//
+// Occurrences fall on whole minutes and are evaluated in the rule's
+// location, so the same rule yields the same instants on every machine.
+package recur
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// searchYears bounds how far ahead Next looks before giving up; rules such
+// as "Feb 30" or an UNTIL in the past never match.
+const searchYears = 20
+
+// maxLookback caps Latest's backwards search windows.
+const maxLookback = searchYears * 366 * 24 * time.Hour
+
+// Rule is a parsed recurrence rule.
+type Rule interface {
+	// Next returns the first occurrence strictly after t, or the zero time
+	// if there is none.
+	Next(t time.Time) time.Time
+	// String returns the rule as written.
+	String() string
+}
+
+// Parse parses a cron expression or RRULE. The anchor is the rule's start:
+// RRULE intervals count from it, it supplies the default time of day and
+// day for RRULEs that omit them, and no RRULE occurrence precedes it.
+// Occurrences are computed in loc (UTC if nil).
+func Parse(expr string, anchor time.Time, loc *time.Location) (Rule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+	upper := strings.ToUpper(expr)
+	if strings.HasPrefix(upper, "RRULE:") || strings.Contains(upper, "FREQ=") {
+		return parseRRule(expr, anchor, loc)
+	}
+	return parseCron(expr, loc)
+}
+
+// Latest returns the last occurrence in (after, now], or the zero time if
+// there is none. It searches backwards from now in growing windows, so a
+// rule that has been due for years does not have to be replayed from its
+// start.
+func Latest(r Rule, after, now time.Time) time.Time {
+	if !now.After(after) {
+		return time.Time{}
+	}
+	for window := time.Hour; ; window *= 2 {
+		from := after
+		if window < maxLookback && now.Add(-window).After(after) {
+			from = now.Add(-window)
+		}
+		if t := r.Next(from); !t.IsZero() && !t.After(now) {
+			for {
+				n := r.Next(t)
+				if n.IsZero() || n.After(now) {
+					return t
+				}
+				t = n
+			}
+		}
+		if from.Equal(after) {
+			return time.Time{}
+		}
+	}
+}
+
+// startOfMinute returns the minute containing t, in loc.
+func startOfMinute(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+}
@@ -0,0 +1,159 @@
+package recur
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// occurrences returns the first n occurrences after start.
+func occurrences(r Rule, start time.Time, n int) []string {
+	var out []string
+	t := start
+	for i := 0; i < n; i++ {
+		t = r.Next(t)
+		if t.IsZero() {
+			break
+		}
+		out = append(out, t.Format(time.RFC3339))
+	}
+	return out
+}
+
+func TestCronNext(t *testing.T) {
+	// 2026-10-18 is a Sunday
+	start := mustTime(t, "2026-10-18T10:30:00Z")
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"0 9 * * MON", []string{"2026-10-19T09:00:00Z", "2026-10-26T09:00:00Z"}},
+		{"*/20 10 * * *", []string{"2026-10-18T10:40:00Z", "2026-10-19T10:00:00Z", "2026-10-19T10:20:00Z"}},
+		{"0 0 1 * *", []string{"2026-11-01T00:00:00Z", "2026-12-01T00:00:00Z"}},
+		{"@weekly", []string{"2026-10-25T00:00:00Z"}},
+		{"30 17 * * 1-5", []string{"2026-10-19T17:30:00Z", "2026-10-20T17:30:00Z"}},
+		{"0 12 29 2 *", []string{"2028-02-29T12:00:00Z"}},
+		{"0 8 * * 7", []string{"2026-10-25T08:00:00Z"}},
+		// Both day fields restricted: either one matches
+		{"0 6 1 * FRI", []string{"2026-10-23T06:00:00Z", "2026-10-30T06:00:00Z", "2026-11-01T06:00:00Z"}},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.expr, time.Time{}, nil)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		got := occurrences(r, start, len(tt.want))
+		if len(got) != len(tt.want) {
+			t.Fatalf("%q: got %v, want %v", tt.expr, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q occurrence %d = %s, want %s", tt.expr, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestCronLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	r, err := Parse("0 9 * * *", time.Time{}, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := r.Next(mustTime(t, "2026-10-18T00:00:00Z"))
+	if want := mustTime(t, "2026-10-18T13:00:00Z"); !got.Equal(want) {
+		t.Errorf("Next = %s, want %s", got.UTC(), want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	anchor := mustTime(t, "2026-10-18T09:00:00Z")
+	for _, expr := range []string{
+		"",
+		"0 9 * *",
+		"60 * * * *",
+		"0 9 * * FUNDAY",
+		"*/0 * * * *",
+		"@fortnightly",
+		"FREQ=SECONDLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+	} {
+		if _, err := Parse(expr, anchor, nil); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	// Anchor: Monday 2026-10-19 09:15 UTC
+	anchor := mustTime(t, "2026-10-19T09:15:00Z")
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"FREQ=DAILY", []string{"2026-10-19T09:15:00Z", "2026-10-20T09:15:00Z"}},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2", []string{"2026-10-19T09:15:00Z", "2026-11-02T09:15:00Z"}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;BYHOUR=8;BYMINUTE=0", []string{"2026-10-22T08:00:00Z", "2026-11-02T08:00:00Z", "2026-11-05T08:00:00Z"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=16;BYMINUTE=0", []string{"2026-10-30T16:00:00Z", "2026-11-27T16:00:00Z"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", []string{"2026-10-31T09:15:00Z", "2026-11-30T09:15:00Z"}},
+		{"FREQ=MONTHLY;INTERVAL=3", []string{"2026-10-19T09:15:00Z", "2027-01-19T09:15:00Z"}},
+		{"FREQ=YEARLY;BYMONTH=1;BYDAY=1MO", []string{"2027-01-04T09:15:00Z", "2028-01-03T09:15:00Z"}},
+		{"FREQ=HOURLY;INTERVAL=6;BYMINUTE=0", []string{"2026-10-19T15:00:00Z", "2026-10-19T21:00:00Z"}},
+		{"FREQ=DAILY;UNTIL=20261020", []string{"2026-10-19T09:15:00Z", "2026-10-20T09:15:00Z"}},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.expr, anchor, nil)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		got := occurrences(r, anchor.Add(-time.Minute), len(tt.want)+1)
+		if tt.expr == "FREQ=DAILY;UNTIL=20261020" {
+			if len(got) != 2 {
+				t.Errorf("%q: got %v, want it to stop after UNTIL", tt.expr, got)
+			}
+		} else {
+			got = got[:len(tt.want)]
+		}
+		for i := range tt.want {
+			if i >= len(got) || got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.expr, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	r, err := Parse("0 9 * * MON", time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := mustTime(t, "2026-10-18T10:30:00Z")
+	got := Latest(r, time.Time{}.Add(time.Hour), now)
+	if want := mustTime(t, "2026-10-12T09:00:00Z"); !got.Equal(want) {
+		t.Errorf("Latest = %s, want %s", got, want)
+	}
+	// Nothing due since the last Monday
+	if got := Latest(r, mustTime(t, "2026-10-12T09:00:00Z"), now); !got.IsZero() {
+		t.Errorf("Latest after last occurrence = %s, want zero", got)
+	}
+	// A dense rule still returns the most recent minute
+	every, _ := Parse("* * * * *", time.Time{}, nil)
+	if got := Latest(every, mustTime(t, "2020-01-01T00:00:00Z"), now); !got.Equal(now) {
+		t.Errorf("Latest(every minute) = %s, want %s", got, now)
+	}
+}
@@ -0,0 +1,340 @@
+package recur
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// byDayRule is one BYDAY entry. A non-zero nth picks the nth (or, when
+// negative, nth-from-last) such weekday of the month.
+type byDayRule struct {
+	weekday time.Weekday
+	nth     int
+}
+
+// rrule is a supported subset of an iCalendar RRULE.
+type rrule struct {
+	expr       string
+	freq       string
+	interval   int
+	byMonth    []int
+	byMonthDay []int
+	byDay      []byDayRule
+	byHour     []int
+	byMinute   []int
+	until      time.Time
+	anchor     time.Time
+	loc        *time.Location
+}
+
+func parseRRule(expr string, anchor time.Time, loc *time.Location) (*rrule, error) {
+	if anchor.IsZero() {
+		return nil, fmt.Errorf("RRULE needs a start time")
+	}
+	r := &rrule{expr: expr, interval: 1, anchor: startOfMinute(anchor, loc), loc: loc}
+	body := expr
+	if len(body) >= 6 && strings.EqualFold(body[:6], "RRULE:") {
+		body = body[6:]
+	}
+
+	for _, part := range strings.Split(body, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		var err error
+		switch key {
+		case "FREQ":
+			switch value {
+			case "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				r.freq = value
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q (use HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY)", value)
+			}
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(value)
+			if err != nil || r.interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+		case "BYMONTH":
+			r.byMonth, err = parseIntList(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseIntList(value, 1, 31, true)
+		case "BYHOUR":
+			r.byHour, err = parseIntList(value, 0, 23, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseIntList(value, 0, 59, false)
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		case "UNTIL":
+			r.until, err = parseUntil(value, loc)
+		case "WKST":
+			if value != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("RRULE %q has no FREQ", expr)
+	}
+	for _, d := range r.byDay {
+		if d.nth != 0 && r.freq != "MONTHLY" && !(r.freq == "YEARLY" && len(r.byMonth) > 0) {
+			return nil, fmt.Errorf("BYDAY ordinals need FREQ=MONTHLY, or FREQ=YEARLY with BYMONTH")
+		}
+	}
+	sort.Ints(r.byHour)
+	sort.Ints(r.byMinute)
+	return r, nil
+}
+
+func parseIntList(value string, min, max int, allowNegative bool) ([]int, error) {
+	var out []int
+	for _, s := range strings.Split(value, ",") {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", s)
+		}
+		abs := v
+		if allowNegative && v < 0 {
+			abs = -v
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("%d is outside %d-%d", v, min, max)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func parseByDay(value string) ([]byDayRule, error) {
+	var out []byDayRule
+	for _, s := range strings.Split(value, ",") {
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", s)
+		}
+		wd, ok := rruleWeekdays[s[len(s)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", s)
+		}
+		rule := byDayRule{weekday: wd}
+		if prefix := s[:len(s)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid weekday ordinal %q", s)
+			}
+			rule.nth = n
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"20060102T150405", "20060102", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			if len(value) <= len("2006-01-02") {
+				t = t.Add(24*time.Hour - time.Second) // a bare date includes the whole day
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func (r *rrule) String() string { return r.expr }
+
+// Next scans day by day from t (or the anchor, if later) and returns the
+// first matching time of day.
+func (r *rrule) Next(t time.Time) time.Time {
+	from := t.In(r.loc)
+	if from.Before(r.anchor) {
+		from = r.anchor
+	}
+	y, m, d := from.Date()
+	for i := 0; i < searchYears*366; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, r.loc)
+		if !r.until.IsZero() && day.After(r.until) {
+			return time.Time{}
+		}
+		if !r.dayMatches(day) {
+			continue
+		}
+		for _, h := range r.hours() {
+			for _, min := range r.minutes() {
+				c := time.Date(day.Year(), day.Month(), day.Day(), h, min, 0, 0, r.loc)
+				if c.Hour() != h || !c.After(t) || c.Before(r.anchor) {
+					continue // skipped by a DST jump, or not yet due
+				}
+				if !r.until.IsZero() && c.After(r.until) {
+					return time.Time{}
+				}
+				if r.freq == "HOURLY" && int(c.Sub(r.anchorHour())/time.Hour)%r.interval != 0 {
+					continue
+				}
+				return c
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// anchorHour is the start of the anchor's hour, from which HOURLY
+// intervals count.
+func (r *rrule) anchorHour() time.Time {
+	a := r.anchor
+	return time.Date(a.Year(), a.Month(), a.Day(), a.Hour(), 0, 0, 0, r.loc)
+}
+
+func (r *rrule) hours() []int {
+	if len(r.byHour) > 0 {
+		return r.byHour
+	}
+	if r.freq == "HOURLY" {
+		hours := make([]int, 24)
+		for i := range hours {
+			hours[i] = i
+		}
+		return hours
+	}
+	return []int{r.anchor.Hour()}
+}
+
+func (r *rrule) minutes() []int {
+	if len(r.byMinute) > 0 {
+		return r.byMinute
+	}
+	return []int{r.anchor.Minute()}
+}
+
+// dayMatches reports whether day is in an active period and passes the
+// BY* filters, falling back to the anchor's weekday, day or month where
+// the frequency needs one.
+func (r *rrule) dayMatches(day time.Time) bool {
+	if len(r.byMonth) > 0 && !containsInt(r.byMonth, int(day.Month())) {
+		return false
+	}
+	a := r.anchor
+	switch r.freq {
+	case "HOURLY":
+		return r.monthDayOK(day, true) && r.weekdayOK(day, true)
+	case "DAILY":
+		if civilDays(a, day)%r.interval != 0 {
+			return false
+		}
+		return r.monthDayOK(day, true) && r.weekdayOK(day, true)
+	case "WEEKLY":
+		weeks := (civilDays(a, day) + weekdayOffset(a)) / 7
+		if weeks%r.interval != 0 {
+			return false
+		}
+		if len(r.byDay) == 0 {
+			return day.Weekday() == a.Weekday() && r.monthDayOK(day, true)
+		}
+		return r.weekdayOK(day, false) && r.monthDayOK(day, true)
+	case "MONTHLY":
+		months := (day.Year()-a.Year())*12 + int(day.Month()) - int(a.Month())
+		if months%r.interval != 0 {
+			return false
+		}
+		return r.dayInMonthOK(day)
+	case "YEARLY":
+		if (day.Year()-a.Year())%r.interval != 0 {
+			return false
+		}
+		if len(r.byMonth) == 0 && day.Month() != a.Month() {
+			return false
+		}
+		return r.dayInMonthOK(day)
+	}
+	return false
+}
+
+// dayInMonthOK applies BYMONTHDAY or BYDAY, or else the anchor's day.
+func (r *rrule) dayInMonthOK(day time.Time) bool {
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		return day.Day() == r.anchor.Day()
+	}
+	return r.monthDayOK(day, len(r.byMonthDay) == 0) && r.weekdayOK(day, len(r.byDay) == 0)
+}
+
+func (r *rrule) monthDayOK(day time.Time, emptyOK bool) bool {
+	if len(r.byMonthDay) == 0 {
+		return emptyOK
+	}
+	last := daysInMonth(day)
+	for _, v := range r.byMonthDay {
+		if v == day.Day() || (v < 0 && last+v+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rrule) weekdayOK(day time.Time, emptyOK bool) bool {
+	if len(r.byDay) == 0 {
+		return emptyOK
+	}
+	for _, d := range r.byDay {
+		if d.weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case d.nth == 0:
+			return true
+		case d.nth > 0 && (day.Day()-1)/7+1 == d.nth:
+			return true
+		case d.nth < 0 && (daysInMonth(day)-day.Day())/7+1 == -d.nth:
+			return true
+		}
+	}
+	return false
+}
+
+// civilDays counts calendar days from a's date to b's date.
+func civilDays(a, b time.Time) int {
+	ad := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	bd := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(bd.Sub(ad) / (24 * time.Hour))
+}
+
+// weekdayOffset is how many days t is past the Monday starting its week.
+func weekdayOffset(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -38,6 +38,8 @@ type Client struct {
 	timeout    time.Duration
 	dbPath     string // Expected database path for validation
 	actor      string // Actor for audit trail (who is performing operations)
+	session    string // Agent session for the undo log
+	command    string // Invocation ID grouping mutations in the undo log
 }
 
 // TryConnect attempts to connect to the daemon socket
@@ -163,6 +165,13 @@ func (c *Client) SetActor(actor string) {
 	c.actor = actor
 }
 
+// SetSession sets the agent session and invocation ID recorded with
+// mutations for bd undo
+func (c *Client) SetSession(session, command string) {
+	c.session = session
+	c.command = command
+}
+
 // Execute sends an RPC request and waits for a response
 func (c *Client) Execute(operation string, args interface{}) (*Response, error) {
 	return c.ExecuteWithCwd(operation, args, "")
@@ -184,6 +193,8 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 		Operation:     operation,
 		Args:          argsJSON,
 		Actor:         c.actor, // Who is performing this operation
+		Session:       c.session,
+		Command:       c.command,
 		ClientVersion: ClientVersion,
 		Cwd:           cwd,
 		ExpectedDB:    c.dbPath, // Send expected database path for validation
@@ -385,6 +396,11 @@ func (c *Client) ListComments(args *CommentListArgs) (*Response, error) {
 	return c.Execute(OpCommentList, args)
 }
 
+// ListEvents retrieves an issue's audit events via the daemon
+func (c *Client) ListEvents(args *EventListArgs) (*Response, error) {
+	return c.Execute(OpEventList, args)
+}
+
 // AddComment adds a comment to an issue via the daemon
 func (c *Client) AddComment(args *CommentAddArgs) (*Response, error) {
 	return c.Execute(OpCommentAdd, args)
@@ -434,6 +450,36 @@ func (c *Client) GateWait(args *GateWaitArgs) (*Response, error) {
 	return c.Execute(OpGateWait, args)
 }
 
+// Claim atomically claims the top ready issue via the daemon
+func (c *Client) Claim(args *ClaimArgs) (*Response, error) {
+	return c.Execute(OpClaim, args)
+}
+
+// RenewLeases extends claim leases via the daemon
+func (c *Client) RenewLeases(args *RenewLeasesArgs) (*Response, error) {
+	return c.Execute(OpRenewLeases, args)
+}
+
+// LockAcquire acquires (or waits for) a slot in a named lock via the daemon
+func (c *Client) LockAcquire(args *LockAcquireArgs) (*Response, error) {
+	return c.Execute(OpLockAcquire, args)
+}
+
+// LockRelease releases a named lock via the daemon
+func (c *Client) LockRelease(args *LockReleaseArgs) (*Response, error) {
+	return c.Execute(OpLockRelease, args)
+}
+
+// LockList lists named locks via the daemon
+func (c *Client) LockList() (*Response, error) {
+	return c.Execute(OpLockList, struct{}{})
+}
+
+// LockRenew extends a holder's locks via the daemon
+func (c *Client) LockRenew(args *LockRenewArgs) (*Response, error) {
+	return c.Execute(OpLockRenew, args)
+}
+
 // GetWorkerStatus retrieves worker status via the daemon
 func (c *Client) GetWorkerStatus(args *GetWorkerStatusArgs) (*GetWorkerStatusResponse, error) {
 	resp, err := c.Execute(OpGetWorkerStatus, args)
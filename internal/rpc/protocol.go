@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/steveyegge/beads/internal/lease"
+	"github.com/steveyegge/beads/internal/locks"
+	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -30,6 +33,7 @@ const (
 	OpLabelRemove     = "label_remove"
 	OpCommentList     = "comment_list"
 	OpCommentAdd      = "comment_add"
+	OpEventList       = "event_list"
 	OpBatch           = "batch"
 	OpResolveID       = "resolve_id"
 
@@ -52,6 +56,16 @@ const (
 	OpGateShow   = "gate_show"
 	OpGateClose  = "gate_close"
 	OpGateWait   = "gate_wait"
+
+	// Claim operations
+	OpClaim       = "claim"
+	OpRenewLeases = "renew_leases"
+
+	// Lock (semaphore) operations
+	OpLockAcquire = "lock_acquire"
+	OpLockRelease = "lock_release"
+	OpLockList    = "lock_list"
+	OpLockRenew   = "lock_renew"
 )
 
 // Request represents an RPC request from client to daemon
@@ -63,6 +77,8 @@ type Request struct {
 	Cwd           string          `json:"cwd,omitempty"`            // Working directory for database discovery
 	ClientVersion string          `json:"client_version,omitempty"` // Client version for compatibility checks
 	ExpectedDB    string          `json:"expected_db,omitempty"`    // Expected database path for validation (absolute)
+	Session       string          `json:"session,omitempty"`        // Agent session, recorded in the undo log
+	Command       string          `json:"command,omitempty"`        // Groups one invocation's mutations in the undo log
 }
 
 // Response represents an RPC response from daemon to client
@@ -244,6 +260,9 @@ type ListArgs struct {
 	// Molecule type filtering
 	MolType string `json:"mol_type,omitempty"`
 
+	// Multi-repo filtering: "." for the primary repo, else a repos.additional entry
+	SourceRepo string `json:"source_repo,omitempty"`
+
 	// Status exclusion (for default non-closed behavior, GH#788)
 	ExcludeStatus []string `json:"exclude_status,omitempty"`
 
@@ -323,6 +342,8 @@ type ReadyArgs struct {
 	ParentID        string   `json:"parent_id,omitempty"`        // Filter to descendants of this bead/epic
 	MolType         string   `json:"mol_type,omitempty"`         // Filter by molecule type: swarm, patrol, or work
 	IncludeDeferred bool     `json:"include_deferred,omitempty"` // Include issues with future defer_until (GH#820)
+	SourceRepo      string   `json:"source_repo,omitempty"`      // Multi-repo filter: "." = primary, else a repos.additional entry
+	ForAgent        string   `json:"for_agent,omitempty"`        // Only work this agent bead is qualified for
 }
 
 // BlockedArgs represents arguments for the blocked operation
@@ -374,6 +395,12 @@ type CommentListArgs struct {
 	ID string `json:"id"`
 }
 
+// EventListArgs represents arguments for listing an issue's audit events
+type EventListArgs struct {
+	ID    string `json:"id"`
+	Limit int    `json:"limit,omitempty"` // Newest events only; 0 = all
+}
+
 // CommentAddArgs represents arguments for adding a comment to an issue
 type CommentAddArgs struct {
 	ID     string `json:"id"`
@@ -410,6 +437,8 @@ type StatusResponse struct {
 	LocalMode    bool   `json:"local_mode"`             // Whether running in local-only mode (no git)
 	SyncInterval string `json:"sync_interval"`          // Sync interval (e.g., "5s")
 	DaemonMode   string `json:"daemon_mode"`            // Sync mode: "poll" or "events"
+	// Last sync branch diagnosis (nil when no sync branch is configured or not yet checked)
+	SyncBranch *syncbranch.Health `json:"sync_branch,omitempty"`
 }
 
 // HealthResponse is the response for a health check operation
@@ -557,6 +586,67 @@ type GateWaitResult struct {
 	AddedCount int `json:"added_count"` // Number of new waiters added
 }
 
+// ClaimArgs represents arguments for atomically claiming the top ready issue
+type ClaimArgs struct {
+	Holder     string        `json:"holder,omitempty"`    // Lease holder and new assignee (default: request actor)
+	LeaseTTL   time.Duration `json:"lease_ttl,omitempty"` // Lease length (default: lease.DefaultTTL)
+	Priority   *int          `json:"priority,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	SortPolicy string        `json:"sort_policy,omitempty"`
+	Labels     []string      `json:"labels,omitempty"`
+	LabelsAny  []string      `json:"labels_any,omitempty"`
+	ParentID   string        `json:"parent_id,omitempty"`
+}
+
+// ClaimResult is the claimed issue and its lease
+type ClaimResult struct {
+	Issue *types.Issue `json:"issue"`
+	Lease *lease.Lease `json:"lease"`
+}
+
+// RenewLeasesArgs represents arguments for extending a holder's leases
+type RenewLeasesArgs struct {
+	Holders  []string      `json:"holders"`
+	LeaseTTL time.Duration `json:"lease_ttl,omitempty"`
+}
+
+// LockAcquireArgs represents arguments for acquiring a slot in a named lock
+type LockAcquireArgs struct {
+	Name     string        `json:"name"`
+	Holder   string        `json:"holder,omitempty"`   // Default: request actor
+	Capacity int           `json:"capacity,omitempty"` // Set the lock's capacity (new locks: 1)
+	Policy   string        `json:"policy,omitempty"`   // Set the queue policy: fifo or priority
+	Priority int           `json:"priority,omitempty"` // Queue priority (0 = highest)
+	TTL      time.Duration `json:"ttl,omitempty"`      // Lease on the slot or queue place
+	Queue    bool          `json:"queue,omitempty"`    // Join the queue if not acquired
+	Wait     time.Duration `json:"wait,omitempty"`     // Block up to this long for a slot (implies Queue)
+}
+
+// LockAcquireResult is the outcome of a lock acquire; Deadlock is set when
+// a waiter gave up because it is part of a wait-for cycle
+type LockAcquireResult struct {
+	locks.Result
+	Deadlock *locks.Deadlock `json:"deadlock,omitempty"`
+}
+
+// LockReleaseArgs represents arguments for releasing a named lock
+type LockReleaseArgs struct {
+	Name   string `json:"name"`
+	Holder string `json:"holder,omitempty"` // Default: request actor
+}
+
+// LockListResult lists all locks and any deadlocks among their holders
+type LockListResult struct {
+	Locks     []*locks.Lock    `json:"locks"`
+	Deadlocks []locks.Deadlock `json:"deadlocks,omitempty"`
+}
+
+// LockRenewArgs represents arguments for renewing a holder's locks
+type LockRenewArgs struct {
+	Holders []string      `json:"holders"`
+	TTL     time.Duration `json:"ttl,omitempty"`
+}
+
 // GetWorkerStatusArgs represents arguments for retrieving worker status
 type GetWorkerStatusArgs struct {
 	// Assignee filters to a specific worker (optional, empty = all workers)
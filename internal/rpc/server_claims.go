@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/lease"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+func (s *Server) handleClaim(req *Request) Response {
+	var args ClaimArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid claim args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+
+	holder := args.Holder
+	if holder == "" {
+		holder = s.reqActor(req)
+	}
+	wf := types.WorkFilter{
+		Type:       args.Type,
+		Priority:   args.Priority,
+		SortPolicy: types.SortPolicy(args.SortPolicy),
+		Labels:     util.NormalizeLabels(args.Labels),
+		LabelsAny:  util.NormalizeLabels(args.LabelsAny),
+	}
+	if args.ParentID != "" {
+		wf.ParentID = &args.ParentID
+	}
+
+	s.claimMu.Lock()
+	defer s.claimMu.Unlock()
+
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	now := time.Now()
+	if _, err := s.reclaimExpiredLeases(req, now); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to reclaim expired leases: %v", err),
+		}
+	}
+
+	issue, l, err := lease.ClaimNext(ctx, store, wf, holder, args.LeaseTTL, now)
+	if err != nil {
+		if errors.Is(err, lease.ErrNoWork) {
+			return Response{Success: false, Error: err.Error()}
+		}
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to claim: %v", err),
+		}
+	}
+	s.emitMutation(MutationUpdate, issue.ID, issue.Title, issue.Assignee)
+
+	data, _ := json.Marshal(ClaimResult{Issue: issue, Lease: l})
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleRenewLeases(req *Request) Response {
+	var args RenewLeasesArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid renew leases args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+
+	s.claimMu.Lock()
+	defer s.claimMu.Unlock()
+
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	renewed, err := lease.Renew(ctx, store, args.Holders, args.LeaseTTL, time.Now())
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to renew leases: %v", err),
+		}
+	}
+	if renewed == nil {
+		renewed = []*lease.Lease{}
+	}
+
+	data, _ := json.Marshal(renewed)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// ReclaimExpiredLeases returns work whose claim lease lapsed to the ready
+// queue, emitting a mutation for each issue. The daemon calls this
+// periodically; claims also run it first.
+func (s *Server) ReclaimExpiredLeases() ([]*lease.Lease, error) {
+	s.claimMu.Lock()
+	defer s.claimMu.Unlock()
+	return s.reclaimExpiredLeases(nil, time.Now())
+}
+
+func (s *Server) reclaimExpiredLeases(req *Request, now time.Time) ([]*lease.Lease, error) {
+	if s.storage == nil {
+		return nil, nil
+	}
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	reclaimed, err := lease.Reclaim(ctx, s.storage, now)
+	for _, l := range reclaimed {
+		s.emitMutation(MutationUpdate, l.IssueID, "", "")
+	}
+	return reclaimed, err
+}
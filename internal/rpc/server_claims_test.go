@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/lease"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestClaimViaDaemon(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, title := range []string{"first", "second"} {
+		resp, err := client.Create(&CreateArgs{Title: title, IssueType: "task", Priority: 1})
+		if err != nil || !resp.Success {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	claimed := make(map[string]bool)
+	for _, holder := range []string{"agent-a", "agent-b"} {
+		resp, err := client.Claim(&ClaimArgs{Holder: holder, LeaseTTL: time.Minute})
+		if err != nil {
+			t.Fatalf("Claim(%s) failed: %v", holder, err)
+		}
+		var result ClaimResult
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Issue.Assignee != holder || result.Issue.Status != types.StatusInProgress || result.Lease.Holder != holder {
+			t.Errorf("claim by %s = %+v / %+v", holder, result.Issue, result.Lease)
+		}
+		if claimed[result.Issue.ID] {
+			t.Errorf("%s was claimed twice", result.Issue.ID)
+		}
+		claimed[result.Issue.ID] = true
+	}
+
+	if _, err := client.Claim(&ClaimArgs{Holder: "agent-c"}); err == nil || !strings.Contains(err.Error(), "no ready work") {
+		t.Errorf("claim with nothing ready: err = %v", err)
+	}
+
+	resp, err := client.RenewLeases(&RenewLeasesArgs{Holders: []string{"agent-a"}, LeaseTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("RenewLeases failed: %v", err)
+	}
+	var renewed []*lease.Lease
+	if err := json.Unmarshal(resp.Data, &renewed); err != nil || len(renewed) != 1 || renewed[0].Holder != "agent-a" {
+		t.Fatalf("renewed = %v, %v", renewed, err)
+	}
+
+	// Nothing has lapsed yet
+	reclaimed, err := server.ReclaimExpiredLeases()
+	if err != nil || len(reclaimed) != 0 {
+		t.Errorf("ReclaimExpiredLeases = %v, %v; want none", reclaimed, err)
+	}
+}
@@ -51,7 +51,8 @@ func (s *Server) handleCompact(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	startTime := time.Now()
 
 	if args.IssueID != "" {
@@ -249,7 +250,8 @@ func (s *Server) handleCompactStats(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	tier1, err := sqliteStore.GetTier1Candidates(ctx)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -63,6 +64,13 @@ type Server struct {
 	localMode    bool
 	syncInterval string
 	daemonMode   string
+	// Last sync branch diagnosis (set via SetSyncHealth by the daemon)
+	syncHealth *syncbranch.Health
+	// Serializes claims so concurrent clients never race for the same issue
+	claimMu sync.Mutex
+	// Closed and replaced on every lock mutation to wake blocked lock waiters
+	lockWakeMu sync.Mutex
+	lockWake   chan struct{}
 }
 
 // Mutation event types
@@ -76,6 +84,8 @@ const (
 	MutationSquashed = "squashed" // Wisp squashed to digest
 	MutationBurned   = "burned"   // Wisp discarded without digest
 	MutationStatus   = "status"   // Status change (in_progress, completed, failed)
+	// Named lock acquired or released (IssueID is the lock name, not exported)
+	MutationLock = "lock"
 )
 
 // MutationEvent represents a database mutation for event-driven sync
@@ -153,6 +163,19 @@ func (s *Server) emitMutation(eventType, issueID, title, assignee string) {
 	})
 }
 
+// NotifyMutation reports a change made outside the RPC handlers, such as an
+// issue created by a daemon background job, so it is exported like any other.
+func (s *Server) NotifyMutation(event MutationEvent) {
+	s.emitRichMutation(event)
+}
+
+// Handle processes one request in-process, without a socket, exactly as a
+// connected client's request would be. Embedders such as the MCP server use
+// it to share the daemon's handlers when no daemon is running.
+func (s *Server) Handle(req *Request) Response {
+	return s.handleRequest(req)
+}
+
 // emitRichMutation sends a pre-built mutation event with optional metadata.
 // Use this for events that include additional context (status changes, bonded events, etc.)
 // Non-blocking: drops event if channel is full (sync will happen eventually).
@@ -162,6 +185,10 @@ func (s *Server) emitRichMutation(event MutationEvent) {
 		event.Timestamp = time.Now()
 	}
 
+	if event.Type == MutationLock {
+		s.wakeLockWaiters()
+	}
+
 	// Send to mutation channel for daemon
 	select {
 	case s.mutationChan <- event:
@@ -198,6 +225,13 @@ func (s *Server) SetConfig(autoCommit, autoPush, autoPull, localMode bool, syncI
 	s.daemonMode = daemonMode
 }
 
+// SetSyncHealth records the latest sync branch diagnosis for OpStatus.
+func (s *Server) SetSyncHealth(h *syncbranch.Health) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncHealth = h
+}
+
 // ResetDroppedEventsCount resets the dropped events counter and returns the previous value
 func (s *Server) ResetDroppedEventsCount() int64 {
 	return s.droppedEvents.Swap(0)
@@ -255,7 +289,8 @@ func (s *Server) handleGetMoleculeProgress(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Get the molecule (parent issue)
 	molecule, err := store.GetIssue(ctx, args.MoleculeID)
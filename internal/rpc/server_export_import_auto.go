@@ -32,7 +32,8 @@ func (s *Server) handleExport(req *Request) Response {
 	}
 
 	store := s.storage
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Load export configuration (user-initiated export, not auto)
 	cfg, err := export.LoadConfig(ctx, store, false)
@@ -137,6 +138,58 @@ func (s *Server) handleExport(req *Request) Response {
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate attachment metadata for all issues (enrichment data)
+	var allAttachments map[string][]*types.Attachment
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeAttachments, "get attachments", func() error {
+		var err error
+		allAttachments, err = store.GetAttachmentsForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get attachments: %v", result.Err),
+		}
+	}
+	if !result.Success {
+		// Attachments fetch failed but policy allows continuing
+		allAttachments = make(map[string][]*types.Attachment) // Empty map
+		if manifest != nil {
+			manifest.PartialData = append(manifest.PartialData, "attachments")
+			manifest.Warnings = append(manifest.Warnings, result.Warnings...)
+			manifest.Complete = false
+		}
+	}
+	for _, issue := range issues {
+		issue.Attachments = allAttachments[issue.ID]
+	}
+
+	// Populate work logs for all issues (enrichment data)
+	var allWorkLogs map[string][]*types.WorkLog
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeWorkLogs, "get work logs", func() error {
+		var err error
+		allWorkLogs, err = store.GetWorkLogsForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get work logs: %v", result.Err),
+		}
+	}
+	if !result.Success {
+		// Work logs fetch failed but policy allows continuing
+		allWorkLogs = make(map[string][]*types.WorkLog) // Empty map
+		if manifest != nil {
+			manifest.PartialData = append(manifest.PartialData, "work_logs")
+			manifest.Warnings = append(manifest.Warnings, result.Warnings...)
+			manifest.Complete = false
+		}
+	}
+	for _, issue := range issues {
+		issue.WorkLogs = allWorkLogs[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(exportArgs.JSONLPath)
 	base := filepath.Base(exportArgs.JSONLPath)
@@ -256,7 +309,8 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 	// Get storage for this request
 	store := s.storage
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Get database path from storage
 	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
@@ -322,8 +376,8 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 	// This prevents daemon from hanging if import gets stuck
 	// Use shorter timeout (5s) to ensure client doesn't timeout waiting for response
 	// Client has 30s timeout, so import must complete well before that
-	importCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	importCtx, importCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer importCancel()
 
 	// Perform actual import with timeout protection
 	notify := autoimport.NewStderrNotifier(debug.Enabled())
@@ -533,6 +587,42 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate attachment metadata for all issues (enrichment data)
+	var allAttachments map[string][]*types.Attachment
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeAttachments, "get attachments", func() error {
+		var err error
+		allAttachments, err = store.GetAttachmentsForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return fmt.Errorf("failed to get attachments: %w", result.Err)
+	}
+	if !result.Success {
+		// Attachments fetch failed but policy allows continuing
+		allAttachments = make(map[string][]*types.Attachment) // Empty map
+	}
+	for _, issue := range allIssues {
+		issue.Attachments = allAttachments[issue.ID]
+	}
+
+	// Populate work logs for all issues (enrichment data)
+	var allWorkLogs map[string][]*types.WorkLog
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeWorkLogs, "get work logs", func() error {
+		var err error
+		allWorkLogs, err = store.GetWorkLogsForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return fmt.Errorf("failed to get work logs: %w", result.Err)
+	}
+	if !result.Success {
+		// Work logs fetch failed but policy allows continuing
+		allWorkLogs = make(map[string][]*types.WorkLog) // Empty map
+	}
+	for _, issue := range allIssues {
+		issue.WorkLogs = allWorkLogs[issue.ID]
+	}
+
 	// Write to JSONL file with atomic replace (temp file + rename)
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
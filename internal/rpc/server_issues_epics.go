@@ -8,10 +8,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/worklog"
 )
 
 // containsLabel checks if a label exists in the list
@@ -220,7 +223,8 @@ func (s *Server) handleCreate(req *Request) Response {
 			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
 		}
 	}
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// If parent is specified, generate child ID
 	issueID := createArgs.ID
@@ -540,7 +544,8 @@ func (s *Server) handleUpdate(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Check if issue is a template (beads-1ra): templates are read-only
 	issue, err := store.GetIssue(ctx, updateArgs.ID)
@@ -791,6 +796,13 @@ func (s *Server) handleUpdate(req *Request) Response {
 		}
 	}
 
+	// Start or stop work timers for the status change (work.auto-timer)
+	if updatedIssue != nil {
+		if err := worklog.OnStatusChange(ctx, store, updateArgs.ID, issue.Status, updatedIssue.Status, s.reqActor(req)); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARNING] auto-timer for %s: %v\n", updateArgs.ID, err)
+		}
+	}
+
 	data, _ := json.Marshal(updatedIssue)
 	return Response{
 		Success: true,
@@ -815,7 +827,8 @@ func (s *Server) handleClose(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Check if issue is a template (beads-1ra): templates are read-only
 	issue, err := store.GetIssue(ctx, closeArgs.ID)
@@ -862,6 +875,13 @@ func (s *Server) handleClose(req *Request) Response {
 		}
 	}
 
+	// Stop running work timers (work.auto-timer)
+	if issue != nil {
+		if err := worklog.OnStatusChange(ctx, store, closeArgs.ID, issue.Status, types.StatusClosed, s.reqActor(req)); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARNING] auto-timer for %s: %v\n", closeArgs.ID, err)
+		}
+	}
+
 	// Emit rich status change event for event-driven daemon
 	s.emitRichMutation(MutationEvent{
 		Type:      MutationStatus,
@@ -872,6 +892,23 @@ func (s *Server) handleClose(req *Request) Response {
 		NewStatus: "closed",
 	})
 
+	// Issues the close cascaded to (close.cascade-* in config.yaml)
+	if config.GetCloseCascadeConfig().Enabled() {
+		cascaded, err := storage.CascadedCloses(ctx, store, closeArgs.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[WARNING] close cascade from %s: %v\n", closeArgs.ID, err)
+		}
+		for _, c := range cascaded {
+			s.emitRichMutation(MutationEvent{
+				Type:      MutationStatus,
+				IssueID:   c.ID,
+				Title:     c.Title,
+				Assignee:  c.Assignee,
+				NewStatus: "closed",
+			})
+		}
+	}
+
 	closedIssue, _ := store.GetIssue(ctx, closeArgs.ID)
 
 	// If SuggestNext is requested, find newly unblocked issues (GH#679)
@@ -925,7 +962,8 @@ func (s *Server) handleDelete(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Use batch delete for cascade/multi-issue operations on SQLite storage
 	// This handles cascade delete properly by expanding dependents recursively
@@ -1224,6 +1262,11 @@ func (s *Server) handleList(req *Request) Response {
 		filter.MolType = &molType
 	}
 
+	// Multi-repo filtering
+	if listArgs.SourceRepo != "" {
+		filter.SourceRepo = &listArgs.SourceRepo
+	}
+
 	// Status exclusion (for default non-closed behavior, GH#788)
 	if len(listArgs.ExcludeStatus) > 0 {
 		for _, s := range listArgs.ExcludeStatus {
@@ -1291,7 +1334,8 @@ func (s *Server) handleList(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
 	if err != nil {
 		return Response{
@@ -1324,6 +1368,7 @@ func (s *Server) handleList(req *Request) Response {
 			Issue:           issue,
 			DependencyCount: counts.DependencyCount,
 			DependentCount:  counts.DependentCount,
+			SourceRepo:      types.ExternalSourceRepo(issue),
 		}
 	}
 
@@ -1462,7 +1507,8 @@ func (s *Server) handleCount(req *Request) Response {
 	filter.PriorityMin = countArgs.PriorityMin
 	filter.PriorityMax = countArgs.PriorityMax
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	issues, err := store.SearchIssues(ctx, countArgs.Query, filter)
 	if err != nil {
 		return Response{
@@ -1582,7 +1628,8 @@ func (s *Server) handleResolveID(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	resolvedID, err := utils.ResolvePartialID(ctx, s.storage, args.ID)
 	if err != nil {
 		return Response{
@@ -1615,7 +1662,8 @@ func (s *Server) handleShow(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	issue, err := store.GetIssue(ctx, showArgs.ID)
 	if err != nil {
 		return Response{
@@ -1660,6 +1708,12 @@ func (s *Server) handleShow(req *Request) Response {
 	// Fetch comments
 	comments, _ := store.GetIssueComments(ctx, issue.ID)
 
+	// Fetch attachment metadata
+	attachments, _ := store.GetAttachments(ctx, issue.ID)
+
+	// Fetch work logs
+	workLogs, _ := store.GetWorkLogs(ctx, issue.ID)
+
 	// Create detailed response with related data
 	details := &types.IssueDetails{
 		Issue:        *issue,
@@ -1667,6 +1721,9 @@ func (s *Server) handleShow(req *Request) Response {
 		Dependencies: deps,
 		Dependents:   dependents,
 		Comments:     comments,
+		Attachments:  attachments,
+		WorkLogs:     workLogs,
+		SourceRepo:   types.ExternalSourceRepo(issue),
 	}
 
 	data, _ := json.Marshal(details)
@@ -1714,8 +1771,22 @@ func (s *Server) handleReady(req *Request) Response {
 		molType := types.MolType(readyArgs.MolType)
 		wf.MolType = &molType
 	}
+	if readyArgs.SourceRepo != "" {
+		wf.SourceRepo = &readyArgs.SourceRepo
+	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	if readyArgs.ForAgent != "" {
+		agentID, err := utils.ResolvePartialID(ctx, store, readyArgs.ForAgent)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to resolve agent %s: %v", readyArgs.ForAgent, err),
+			}
+		}
+		wf.ForAgent = agentID
+	}
 	issues, err := store.GetReadyWork(ctx, wf)
 	if err != nil {
 		return Response{
@@ -1724,7 +1795,13 @@ func (s *Server) handleReady(req *Request) Response {
 		}
 	}
 
-	data, _ := json.Marshal(issues)
+	// Carry the owning repo for multi-repo display; decodes as []*types.Issue too
+	withRepo := make([]*types.IssueWithRepo, len(issues))
+	for i, issue := range issues {
+		withRepo[i] = &types.IssueWithRepo{Issue: issue, SourceRepo: types.ExternalSourceRepo(issue)}
+	}
+
+	data, _ := json.Marshal(withRepo)
 	return Response{
 		Success: true,
 		Data:    data,
@@ -1753,7 +1830,8 @@ func (s *Server) handleBlocked(req *Request) Response {
 		wf.ParentID = &blockedArgs.ParentID
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	blocked, err := store.GetBlockedIssues(ctx, wf)
 	if err != nil {
 		return Response{
@@ -1792,7 +1870,8 @@ func (s *Server) handleStale(req *Request) Response {
 		Limit:  staleArgs.Limit,
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	issues, err := store.GetStaleIssues(ctx, filter)
 	if err != nil {
 		return Response{
@@ -1817,7 +1896,8 @@ func (s *Server) handleStats(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	stats, err := store.GetStatistics(ctx)
 	if err != nil {
 		return Response{
@@ -1850,7 +1930,8 @@ func (s *Server) handleEpicStatus(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	epics, err := store.GetEpicsEligibleForClosure(ctx)
 	if err != nil {
 		return Response{
@@ -1901,7 +1982,8 @@ func (s *Server) handleGetConfig(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Get config value from database
 	value, err := store.GetConfig(ctx, args.Key)
@@ -1942,7 +2024,8 @@ func (s *Server) handleMolStale(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Get all epics eligible for closure (complete but unclosed)
 	epicStatuses, err := store.GetEpicsEligibleForClosure(ctx)
@@ -2047,7 +2130,8 @@ func (s *Server) handleGateCreate(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	now := time.Now()
 
 	// Create gate issue
@@ -2101,7 +2185,8 @@ func (s *Server) handleGateList(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Build filter for gates
 	gateType := types.IssueType("gate")
@@ -2145,7 +2230,8 @@ func (s *Server) handleGateShow(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Resolve partial ID
 	gateID, err := utils.ResolvePartialID(ctx, store, args.ID)
@@ -2200,7 +2286,8 @@ func (s *Server) handleGateClose(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Resolve partial ID
 	gateID, err := utils.ResolvePartialID(ctx, store, args.ID)
@@ -2279,7 +2366,8 @@ func (s *Server) handleGateWait(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Resolve partial ID
 	gateID, err := utils.ResolvePartialID(ctx, store, args.ID)
@@ -68,7 +68,8 @@ func (s *Server) handleDepAdd(req *Request) Response {
 		Type:        types.DependencyType(depArgs.DepType),
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	if err := store.AddDependency(ctx, dep, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
@@ -109,7 +110,8 @@ func (s *Server) handleSimpleStoreOp(req *Request, argsPtr interface{}, argDesc
 		}
 	}
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	if err := opFunc(ctx, store, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
@@ -170,7 +172,8 @@ func (s *Server) handleCommentList(req *Request) Response {
 
 	store := s.storage
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	comments, err := store.GetIssueComments(ctx, commentArgs.ID)
 	if err != nil {
 		return Response{
@@ -186,6 +189,34 @@ func (s *Server) handleCommentList(req *Request) Response {
 	}
 }
 
+func (s *Server) handleEventList(req *Request) Response {
+	var eventArgs EventListArgs
+	if err := json.Unmarshal(req.Args, &eventArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid event list args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	events, err := store.GetEvents(ctx, eventArgs.ID, eventArgs.Limit)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to list events: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(events)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleCommentAdd(req *Request) Response {
 	var commentArgs CommentAddArgs
 	if err := json.Unmarshal(req.Args, &commentArgs); err != nil {
@@ -197,7 +228,8 @@ func (s *Server) handleCommentAdd(req *Request) Response {
 
 	store := s.storage
 
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 	comment, err := store.AddIssueComment(ctx, commentArgs.ID, commentArgs.Author, commentArgs.Text)
 	if err != nil {
 		return Response{
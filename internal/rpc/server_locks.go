@@ -0,0 +1,252 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/locks"
+)
+
+// MaxLockWait caps how long one lock acquire request blocks, keeping it well
+// inside the client's request timeout. Callers that want to wait longer
+// repeat the request; their queue place is kept in between.
+const MaxLockWait = 20 * time.Second
+
+// wakeLockWaiters wakes every request blocked in handleLockAcquire so it can
+// try again. Called for each MutationLock event.
+func (s *Server) wakeLockWaiters() {
+	s.lockWakeMu.Lock()
+	defer s.lockWakeMu.Unlock()
+	if s.lockWake != nil {
+		close(s.lockWake)
+		s.lockWake = nil
+	}
+}
+
+// lockChanged returns a channel that is closed at the next lock mutation.
+func (s *Server) lockChanged() <-chan struct{} {
+	s.lockWakeMu.Lock()
+	defer s.lockWakeMu.Unlock()
+	if s.lockWake == nil {
+		s.lockWake = make(chan struct{})
+	}
+	return s.lockWake
+}
+
+func (s *Server) emitLockMutation(name, action, holder string) {
+	s.emitRichMutation(MutationEvent{
+		Type:      MutationLock,
+		IssueID:   name,
+		Actor:     holder,
+		NewStatus: action,
+	})
+}
+
+func (s *Server) handleLockAcquire(req *Request) Response {
+	var args LockAcquireArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid lock acquire args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+
+	lockReq := locks.Request{
+		Name:     args.Name,
+		Holder:   args.Holder,
+		Capacity: args.Capacity,
+		Policy:   locks.Policy(args.Policy),
+		Priority: args.Priority,
+		TTL:      args.TTL,
+		Queue:    args.Queue || args.Wait > 0,
+	}
+	if lockReq.Holder == "" {
+		lockReq.Holder = s.reqActor(req)
+	}
+	wait := args.Wait
+	if wait > MaxLockWait {
+		wait = MaxLockWait
+	}
+	deadline := time.Now().Add(wait)
+
+	var result LockAcquireResult
+	for {
+		// Subscribe before trying, so a release between the attempt and the
+		// select below still wakes us
+		changed := s.lockChanged()
+		now := time.Now()
+		ctx, cancel := s.reqCtx(req)
+		res, err := locks.Acquire(ctx, store, lockReq, now)
+		if err != nil {
+			cancel()
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to acquire lock: %v", err),
+			}
+		}
+		result.Result = *res
+		if res.Acquired {
+			cancel()
+			s.emitLockMutation(args.Name, "acquired", lockReq.Holder)
+			break
+		}
+		list, err := locks.List(ctx, store, now)
+		cancel()
+		if err == nil {
+			for _, d := range locks.FindDeadlocks(list) {
+				if d.Involves(lockReq.Holder) {
+					result.Deadlock = &d
+					break
+				}
+			}
+		}
+		remaining := time.Until(deadline)
+		if result.Deadlock != nil || remaining <= 0 {
+			break
+		}
+
+		// A lapsing lease frees a slot without any mutation, so also wake
+		// when the earliest one runs out
+		if next := res.Lock.NextExpiry(); !next.IsZero() {
+			if d := time.Until(next); d < remaining {
+				remaining = d
+			}
+		}
+		timer := time.NewTimer(remaining)
+		select {
+		case <-changed:
+		case <-timer.C:
+		case <-s.shutdownChan:
+			timer.Stop()
+			return Response{
+				Success: false,
+				Error:   "daemon shutting down",
+			}
+		}
+		timer.Stop()
+	}
+
+	data, _ := json.Marshal(result)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleLockRelease(req *Request) Response {
+	var args LockReleaseArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid lock release args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+
+	holder := args.Holder
+	if holder == "" {
+		holder = s.reqActor(req)
+	}
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	l, err := locks.Release(ctx, store, args.Name, holder, time.Now())
+	if err != nil {
+		if errors.Is(err, locks.ErrNotHeld) {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("%s does not hold or await lock %s", holder, args.Name),
+			}
+		}
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to release lock: %v", err),
+		}
+	}
+	s.emitLockMutation(args.Name, "released", holder)
+
+	data, _ := json.Marshal(l)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleLockList(req *Request) Response {
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	list, err := locks.List(ctx, store, time.Now())
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to list locks: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(LockListResult{Locks: list, Deadlocks: locks.FindDeadlocks(list)})
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleLockRenew(req *Request) Response {
+	var args LockRenewArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid lock renew args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
+	names, err := locks.Renew(ctx, store, args.Holders, args.TTL, time.Now())
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to renew locks: %v", err),
+		}
+	}
+	if names == nil {
+		names = []string{}
+	}
+
+	data, _ := json.Marshal(names)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLockWaitWokenByRelease(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	resp, err := client.LockAcquire(&LockAcquireArgs{Name: "deploy", Holder: "agent-a"})
+	if err != nil {
+		t.Fatalf("LockAcquire failed: %v", err)
+	}
+	var first LockAcquireResult
+	if err := json.Unmarshal(resp.Data, &first); err != nil || !first.Acquired {
+		t.Fatalf("agent-a should acquire: %+v, %v", first, err)
+	}
+
+	// agent-b blocks in the daemon; the release must wake it long before its wait runs out
+	done := make(chan LockAcquireResult, 1)
+	start := time.Now()
+	go func() {
+		args, _ := json.Marshal(LockAcquireArgs{Name: "deploy", Holder: "agent-b", Wait: 10 * time.Second})
+		resp := server.handleLockAcquire(&Request{Operation: OpLockAcquire, Args: args})
+		var result LockAcquireResult
+		if !resp.Success || json.Unmarshal(resp.Data, &result) != nil {
+			t.Errorf("blocked acquire failed: %s", resp.Error)
+		}
+		done <- result
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("agent-b acquired a held lock")
+	default:
+	}
+	if _, err := client.LockRelease(&LockReleaseArgs{Name: "deploy", Holder: "agent-a"}); err != nil {
+		t.Fatalf("LockRelease failed: %v", err)
+	}
+
+	select {
+	case result := <-done:
+		if !result.Acquired || !result.Lock.IsHeldBy("agent-b") {
+			t.Errorf("agent-b should hold deploy: %+v", result)
+		}
+		if waited := time.Since(start); waited > 5*time.Second {
+			t.Errorf("waiter was not woken by the release (waited %s)", waited)
+		}
+	case <-time.After(15 * time.Second):
+		t.Fatal("blocked acquire never returned")
+	}
+
+	if _, err := client.LockRelease(&LockReleaseArgs{Name: "deploy", Holder: "agent-a"}); err == nil {
+		t.Error("releasing a lock that is no longer held should fail")
+	}
+}
+
+func TestLockListReportsDeadlock(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, args := range []LockAcquireArgs{
+		{Name: "db", Holder: "a"},
+		{Name: "deploy", Holder: "b"},
+		{Name: "deploy", Holder: "a", Queue: true},
+	} {
+		if _, err := client.LockAcquire(&args); err != nil {
+			t.Fatalf("LockAcquire(%+v) failed: %v", args, err)
+		}
+	}
+
+	// b waiting for db closes the cycle: the acquire gives up at once
+	resp, err := client.LockAcquire(&LockAcquireArgs{Name: "db", Holder: "b", Wait: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("LockAcquire failed: %v", err)
+	}
+	var result LockAcquireResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Acquired || result.Deadlock == nil || !result.Deadlock.Involves("a") {
+		t.Fatalf("expected a deadlock report, got %+v", result)
+	}
+
+	resp, err = client.LockList()
+	if err != nil {
+		t.Fatalf("LockList failed: %v", err)
+	}
+	var list LockListResult
+	if err := json.Unmarshal(resp.Data, &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Locks) != 2 || len(list.Deadlocks) != 1 {
+		t.Errorf("LockList = %d locks, %d deadlocks; want 2 and 1", len(list.Locks), len(list.Deadlocks))
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"golang.org/x/mod/semver"
 )
@@ -206,6 +207,8 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleCommentList(req)
 	case OpCommentAdd:
 		resp = s.handleCommentAdd(req)
+	case OpEventList:
+		resp = s.handleEventList(req)
 	case OpBatch:
 		resp = s.handleBatch(req)
 	
@@ -242,6 +245,18 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleGateClose(req)
 	case OpGateWait:
 		resp = s.handleGateWait(req)
+	case OpClaim:
+		resp = s.handleClaim(req)
+	case OpRenewLeases:
+		resp = s.handleRenewLeases(req)
+	case OpLockAcquire:
+		resp = s.handleLockAcquire(req)
+	case OpLockRelease:
+		resp = s.handleLockRelease(req)
+	case OpLockList:
+		resp = s.handleLockList(req)
+	case OpLockRenew:
+		resp = s.handleLockRenew(req)
 	default:
 		s.metrics.RecordError(req.Operation)
 		return Response{
@@ -262,10 +277,18 @@ func (s *Server) handleRequest(req *Request) Response {
 
 // reqCtx returns a context with the server's request timeout applied.
 // This prevents request handlers from hanging indefinitely if database
-// operations or other internal calls stall (GH#bd-p76kv).
-func (s *Server) reqCtx(_ *Request) context.Context {
-	ctx, _ := context.WithTimeout(context.Background(), s.requestTimeout)
-	return ctx
+// operations or other internal calls stall (GH#bd-p76kv). Callers must call
+// the returned cancel func when the request is done.
+func (s *Server) reqCtx(req *Request) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout)
+	session, command := "", ""
+	if req != nil {
+		session, command = req.Session, req.Command
+	}
+	if command == "" {
+		command = storage.NewCommandID()
+	}
+	return storage.WithCommand(storage.WithSession(ctx, s.reqActor(req), session), command), cancel
 }
 
 func (s *Server) reqActor(req *Request) string {
@@ -310,6 +333,7 @@ func (s *Server) handleStatus(_ *Request) Response {
 	localMode := s.localMode
 	syncInterval := s.syncInterval
 	daemonMode := s.daemonMode
+	syncHealth := s.syncHealth
 	s.mu.RUnlock()
 	
 	statusResp := StatusResponse{
@@ -328,6 +352,7 @@ func (s *Server) handleStatus(_ *Request) Response {
 		LocalMode:           localMode,
 		SyncInterval:        syncInterval,
 		DaemonMode:          daemonMode,
+		SyncBranch:          syncHealth,
 	}
 	
 	data, _ := json.Marshal(statusResp)
@@ -407,7 +432,8 @@ func (s *Server) handleMetrics(_ *Request) Response {
 }
 
 func (s *Server) handleGetWorkerStatus(req *Request) Response {
-	ctx := s.reqCtx(req)
+	ctx, cancel := s.reqCtx(req)
+	defer cancel()
 
 	// Parse optional args
 	var args GetWorkerStatusArgs
@@ -0,0 +1,560 @@
+// Package shard implements the opt-in sharded JSONL layout for very large
+// repositories. Instead of one .beads/issues.jsonl, issues are tracked in git
+// as .beads/issues/<prefix>/<bucket>.jsonl, where the bucket is a hash of the
+// issue's root ID. Each mutation then only rewrites the shards it touches and
+// git diffs stay small.
+//
+// The single issues.jsonl file remains as a local, untracked working copy so
+// that every reader of the single-file layout keeps working unchanged: Export
+// splits it into shards after each write, and Sync materializes it again when
+// the shards change underneath it (git pull, merge, checkout).
+package shard
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+const (
+	// DirName is the shard directory inside .beads/.
+	DirName = "issues"
+	// ManifestName is the manifest file inside the shard directory. Its
+	// presence is what makes a .beads directory sharded.
+	ManifestName = "manifest.json"
+	// StateName is the local (gitignored) reconcile state inside .beads/.
+	StateName = "shard-state.json"
+	// DefaultBuckets is the number of hash buckets per prefix.
+	DefaultBuckets = 16
+	// LayoutSharded is the manifest layout name.
+	LayoutSharded = "sharded"
+	// HashFNV32a is the only supported bucket hash.
+	HashFNV32a = "fnv32a"
+
+	manifestVersion = 1
+	maxBuckets      = 256
+	noPrefix        = "_"
+)
+
+// ErrNotSharded is returned when a .beads directory has no shard manifest.
+var ErrNotSharded = errors.New("not a sharded layout")
+
+// Manifest describes a sharded layout. It is written once by Migrate and
+// rarely changes, so it does not cause merge conflicts.
+type Manifest struct {
+	Version int    `json:"version"`
+	Layout  string `json:"layout"`
+	Buckets int    `json:"buckets"`
+	Hash    string `json:"hash"`
+	// File is the name of the local working copy inside .beads/ (normally
+	// issues.jsonl). Other JSONL files in the directory are never touched.
+	File string `json:"file"`
+}
+
+// Dir returns the shard directory for beadsDir.
+func Dir(beadsDir string) string {
+	return filepath.Join(beadsDir, DirName)
+}
+
+// IsSharded reports whether beadsDir uses the sharded layout.
+func IsSharded(beadsDir string) bool {
+	_, err := os.Stat(filepath.Join(Dir(beadsDir), ManifestName))
+	return err == nil
+}
+
+// ReadManifest reads and validates the manifest in beadsDir.
+func ReadManifest(beadsDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(beadsDir), ManifestName)) // #nosec G304 - controlled path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotSharded
+		}
+		return nil, fmt.Errorf("failed to read shard manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest decodes and validates manifest content.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid shard manifest: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks that the manifest describes a supported layout.
+func (m *Manifest) Validate() error {
+	if m.Layout != LayoutSharded {
+		return fmt.Errorf("unsupported shard layout %q", m.Layout)
+	}
+	if m.Hash != HashFNV32a {
+		return fmt.Errorf("unsupported shard hash %q", m.Hash)
+	}
+	if m.Buckets < 1 || m.Buckets > maxBuckets {
+		return fmt.Errorf("invalid shard bucket count %d (must be 1-%d)", m.Buckets, maxBuckets)
+	}
+	if m.File == "" || m.File != filepath.Base(m.File) {
+		return fmt.Errorf("invalid shard working copy %q", m.File)
+	}
+	return nil
+}
+
+// manifestFor returns the manifest governing jsonlPath, or nil if jsonlPath
+// is not the working copy of a sharded layout.
+func manifestFor(jsonlPath string) (*Manifest, error) {
+	m, err := ReadManifest(filepath.Dir(jsonlPath))
+	if errors.Is(err, ErrNotSharded) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Base(jsonlPath) != m.File {
+		return nil, nil
+	}
+	return m, nil
+}
+
+func writeManifest(beadsDir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(Dir(beadsDir), ManifestName), append(data, '\n'))
+}
+
+// Path returns the shard file, relative to the shard directory, that holds
+// issueID. Hierarchical children (bd-a1b2.1) share their root's shard so an
+// epic and its tasks are rewritten together.
+func (m *Manifest) Path(issueID string) string {
+	root := rootID(issueID)
+	prefix := utils.ExtractIssuePrefix(root)
+	if prefix == "" || strings.ContainsAny(prefix, `/\`) || prefix == "." || prefix == ".." {
+		prefix = noPrefix
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(root))
+	return filepath.ToSlash(filepath.Join(prefix, fmt.Sprintf("%02x.jsonl", h.Sum32()%uint32(m.Buckets)))) // #nosec G115 - Buckets validated to 1-256
+}
+
+// rootID strips hierarchical child suffixes: "bd-a1b2.3.1" -> "bd-a1b2".
+// Only dots after the last hyphen count, so dotted prefixes are kept intact.
+func rootID(issueID string) string {
+	start := strings.LastIndex(issueID, "-") + 1
+	if dot := strings.Index(issueID[start:], "."); dot >= 0 {
+		return issueID[:start+dot]
+	}
+	return issueID
+}
+
+// line is one JSONL record and the ID it sorts by.
+type line struct {
+	id   string
+	data []byte
+}
+
+func parseLines(content []byte) ([]line, error) {
+	var lines []line
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var rec struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if rec.ID == "" {
+			return nil, fmt.Errorf("line %d: missing id", n)
+		}
+		lines = append(lines, line{id: rec.ID, data: append([]byte(nil), raw...)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func render(lines []line) []byte {
+	slices.SortStableFunc(lines, func(a, b line) int { return cmp.Compare(a.id, b.id) })
+	var buf bytes.Buffer
+	for _, l := range lines {
+		buf.Write(l.data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// Split distributes single-file JSONL content into shards keyed by their
+// path relative to the shard directory. Lines keep their exact bytes and are
+// sorted by ID within each shard.
+func Split(content []byte, m *Manifest) (map[string][]byte, error) {
+	lines, err := parseLines(content)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]line)
+	for _, l := range lines {
+		p := m.Path(l.id)
+		grouped[p] = append(grouped[p], l)
+	}
+	shards := make(map[string][]byte, len(grouped))
+	for p, ls := range grouped {
+		shards[p] = render(ls)
+	}
+	return shards, nil
+}
+
+// Join assembles shards back into single-file JSONL sorted by ID, which is
+// byte-for-byte what the exporter writes. Content hashes of the single file
+// are therefore the same in both layouts.
+func Join(shards map[string][]byte) ([]byte, error) {
+	var all []line
+	for p, content := range shards {
+		lines, err := parseLines(content)
+		if err != nil {
+			return nil, fmt.Errorf("shard %s: %w", p, err)
+		}
+		all = append(all, lines...)
+	}
+	return render(all), nil
+}
+
+// ReadShards reads every shard file in beadsDir, keyed by relative path.
+func ReadShards(beadsDir string) (map[string][]byte, error) {
+	dir := Dir(beadsDir)
+	shards := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isShardFile(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path) // #nosec G304 - path from walking the shard dir
+		if err != nil {
+			return err
+		}
+		shards[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shards: %w", err)
+	}
+	return shards, nil
+}
+
+func isShardFile(name string) bool {
+	return strings.HasSuffix(name, ".jsonl") && !strings.HasPrefix(name, ".")
+}
+
+// WriteShards replaces the shard files in beadsDir with shards. Unchanged
+// shards are left untouched (so their mtimes and git index entries stay
+// clean), and shards that no longer hold any issue are removed.
+func WriteShards(beadsDir string, shards map[string][]byte) error {
+	dir := Dir(beadsDir)
+	existing, err := ReadShards(beadsDir)
+	if err != nil {
+		return err
+	}
+	for rel, content := range shards {
+		if old, ok := existing[rel]; ok && bytes.Equal(old, content) {
+			continue
+		}
+		if err := writeFileAtomic(filepath.Join(dir, filepath.FromSlash(rel)), content); err != nil {
+			return fmt.Errorf("failed to write shard %s: %w", rel, err)
+		}
+	}
+	for rel := range existing {
+		if _, ok := shards[rel]; ok {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale shard %s: %w", rel, err)
+		}
+		// Drop the prefix directory once its last shard is gone.
+		_ = os.Remove(filepath.Dir(path))
+	}
+	return nil
+}
+
+// IsWorkingCopy reports whether jsonlPath is the local working copy of a
+// sharded layout.
+func IsWorkingCopy(jsonlPath string) bool {
+	m, err := manifestFor(jsonlPath)
+	return err == nil && m != nil
+}
+
+// TrackedPath returns what git should track for jsonlPath: the shard
+// directory when jsonlPath is a sharded working copy, otherwise jsonlPath.
+func TrackedPath(jsonlPath string) string {
+	if IsWorkingCopy(jsonlPath) {
+		return Dir(filepath.Dir(jsonlPath))
+	}
+	return jsonlPath
+}
+
+// UntrackedPaths returns the files in a sharded .beads directory that are
+// local working state and must never be committed.
+func UntrackedPaths(jsonlPath string) []string {
+	beadsDir := filepath.Dir(jsonlPath)
+	return []string{jsonlPath, filepath.Join(beadsDir, StateName)}
+}
+
+// Export splits jsonlPath into the shards of its .beads directory. It is a
+// no-op unless jsonlPath is a sharded working copy, so writers can call it
+// unconditionally.
+func Export(jsonlPath string) error {
+	beadsDir := filepath.Dir(jsonlPath)
+	m, err := manifestFor(jsonlPath)
+	if err != nil || m == nil {
+		return err
+	}
+	content, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(jsonlPath), err)
+	}
+	shards, err := Split(content, m)
+	if err != nil {
+		return fmt.Errorf("failed to shard %s: %w", filepath.Base(jsonlPath), err)
+	}
+	if err := WriteShards(beadsDir, shards); err != nil {
+		return err
+	}
+	return saveState(jsonlPath, hashBytes(content))
+}
+
+// Materialize rebuilds jsonlPath from the shards of its .beads directory.
+// It is a no-op unless jsonlPath is a sharded working copy.
+func Materialize(jsonlPath string) error {
+	beadsDir := filepath.Dir(jsonlPath)
+	if m, err := manifestFor(jsonlPath); err != nil || m == nil {
+		return err
+	}
+	content, err := joinFromDisk(beadsDir)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(jsonlPath, content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(jsonlPath), err)
+	}
+	return saveState(jsonlPath, hashBytes(content))
+}
+
+func joinFromDisk(beadsDir string) ([]byte, error) {
+	shards, err := ReadShards(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	return Join(shards)
+}
+
+// Sync reconciles jsonlPath with the shards of its .beads directory so that
+// readers of the single file see what git checked out, and commits see what
+// bd last wrote. Whichever side changed since the last reconcile wins; if
+// both changed (or there is no record of a previous reconcile) the shards,
+// being what git tracks, win. It is a no-op unless jsonlPath is a sharded
+// working copy.
+func Sync(jsonlPath string) error {
+	beadsDir := filepath.Dir(jsonlPath)
+	if m, err := manifestFor(jsonlPath); err != nil || m == nil {
+		return err
+	}
+	st := loadState(beadsDir)
+	jsonlSig := fileSignature(jsonlPath)
+	shardSig := shardSignature(beadsDir)
+	if st != nil && st.JSONLSig == jsonlSig && st.ShardSig == shardSig {
+		return nil
+	}
+
+	joined, err := joinFromDisk(beadsDir)
+	if err != nil {
+		return err
+	}
+	shardHash := hashBytes(joined)
+
+	content, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(jsonlPath), err)
+	}
+	missing := err != nil
+	jsonlHash := hashBytes(content)
+
+	switch {
+	case !missing && jsonlHash == shardHash:
+		return saveState(jsonlPath, shardHash)
+	case !missing && st != nil && shardHash == st.Hash:
+		// Only the working copy changed: bd wrote it without sharding.
+		return Export(jsonlPath)
+	default:
+		return Materialize(jsonlPath)
+	}
+}
+
+// Migrate converts beadsDir from the single-file layout to the sharded one
+// with the given number of buckets per prefix. issues.jsonl is kept as the
+// local working copy; callers are responsible for untracking it in git.
+func Migrate(jsonlPath string, buckets int) (*Manifest, error) {
+	beadsDir := filepath.Dir(jsonlPath)
+	if IsSharded(beadsDir) {
+		return nil, fmt.Errorf("%s is already sharded", beadsDir)
+	}
+	if buckets == 0 {
+		buckets = DefaultBuckets
+	}
+	m := &Manifest{Version: manifestVersion, Layout: LayoutSharded, Buckets: buckets, Hash: HashFNV32a, File: filepath.Base(jsonlPath)}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(jsonlPath); os.IsNotExist(err) {
+		if err := writeFileAtomic(jsonlPath, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(Dir(beadsDir), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create shard directory: %w", err)
+	}
+	if err := writeManifest(beadsDir, m); err != nil {
+		return nil, fmt.Errorf("failed to write shard manifest: %w", err)
+	}
+	if err := Export(jsonlPath); err != nil {
+		_ = os.Remove(filepath.Join(Dir(beadsDir), ManifestName))
+		return nil, err
+	}
+	return m, nil
+}
+
+func mustMarshal(v any) []byte {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+// state records the last reconcile so Sync can tell which side changed.
+type state struct {
+	// Hash is the SHA256 of the single-file content both sides agreed on.
+	Hash     string `json:"hash"`
+	JSONLSig string `json:"jsonl_sig"`
+	ShardSig string `json:"shard_sig"`
+}
+
+func loadState(beadsDir string) *state {
+	data, err := os.ReadFile(filepath.Join(beadsDir, StateName)) // #nosec G304 - controlled path
+	if err != nil {
+		return nil
+	}
+	var st state
+	if json.Unmarshal(data, &st) != nil {
+		return nil
+	}
+	return &st
+}
+
+func saveState(jsonlPath, hash string) error {
+	beadsDir := filepath.Dir(jsonlPath)
+	st := state{Hash: hash, JSONLSig: fileSignature(jsonlPath), ShardSig: shardSignature(beadsDir)}
+	return writeFileAtomic(filepath.Join(beadsDir, StateName), mustMarshal(st))
+}
+
+// fileSignature is a cheap change detector based on size and mtime.
+func fileSignature(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+}
+
+func shardSignature(beadsDir string) string {
+	dir := Dir(beadsDir)
+	h := sha256.New()
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isShardFile(d.Name()) {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(h, "%s=%s\n", filepath.ToSlash(rel), fileSignature(path))
+		return nil
+	})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	// nolint:gosec // G302: JSONL needs to be readable by other tools
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// Adopt gives the .beads directory of dstJSONLPath the same layout as
+// srcJSONLPath: if src is a sharded working copy and dst's directory is not
+// sharded yet, src's manifest is copied over so the next Export of dst writes
+// shards. It is used to carry a migration onto the sync branch worktree.
+func Adopt(srcJSONLPath, dstJSONLPath string) error {
+	m, err := manifestFor(srcJSONLPath)
+	if err != nil || m == nil {
+		return err
+	}
+	dstDir := filepath.Dir(dstJSONLPath)
+	if IsSharded(dstDir) {
+		return nil
+	}
+	adopted := *m
+	adopted.File = filepath.Base(dstJSONLPath)
+	return writeManifest(dstDir, &adopted)
+}
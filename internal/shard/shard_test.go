@@ -0,0 +1,268 @@
+package shard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func issueLine(id, title string) string {
+	return `{"id":"` + id + `","title":"` + title + `","status":"open"}` + "\n"
+}
+
+func writeJSONL(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "")), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// touch bumps a file's mtime so size+mtime signatures see a change even when
+// the test rewrites it within the filesystem's timestamp resolution.
+func touch(t *testing.T, path string) {
+	t.Helper()
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManifestPath(t *testing.T) {
+	m := &Manifest{Version: 1, Layout: LayoutSharded, Buckets: 16, Hash: HashFNV32a}
+	tests := []struct {
+		id, same string
+		prefix   string
+	}{
+		{"bd-a1b2.1", "bd-a1b2", "bd"},
+		{"bd-a1b2.3.1", "bd-a1b2", "bd"},
+		{"beads-vscode-12", "beads-vscode-12", "beads-vscode"},
+		{"my.app-7.2", "my.app-7", "my.app"},
+		{"noprefix", "noprefix", noPrefix},
+	}
+	for _, tt := range tests {
+		got := m.Path(tt.id)
+		if got != m.Path(tt.same) {
+			t.Errorf("Path(%q) = %s, want same shard as %q (%s)", tt.id, got, tt.same, m.Path(tt.same))
+		}
+		if !strings.HasPrefix(got, tt.prefix+"/") || !strings.HasSuffix(got, ".jsonl") {
+			t.Errorf("Path(%q) = %s, want %s/<bucket>.jsonl", tt.id, got, tt.prefix)
+		}
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", `{"version":1,"layout":"sharded","buckets":16,"hash":"fnv32a","file":"issues.jsonl"}`, false},
+		{"missing file", `{"version":1,"layout":"sharded","buckets":16,"hash":"fnv32a"}`, true},
+		{"file with dir", `{"version":1,"layout":"sharded","buckets":16,"hash":"fnv32a","file":"../x.jsonl"}`, true},
+		{"bad layout", `{"version":1,"layout":"flat","buckets":16,"hash":"fnv32a"}`, true},
+		{"bad hash", `{"version":1,"layout":"sharded","buckets":16,"hash":"md5"}`, true},
+		{"zero buckets", `{"version":1,"layout":"sharded","buckets":0,"hash":"fnv32a"}`, true},
+		{"too many buckets", `{"version":1,"layout":"sharded","buckets":1000,"hash":"fnv32a"}`, true},
+		{"not json", `sharded`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitJoinRoundTrip(t *testing.T) {
+	m := &Manifest{Version: 1, Layout: LayoutSharded, Buckets: 4, Hash: HashFNV32a}
+	var lines []string
+	for _, id := range []string{"bd-1", "bd-1.1", "bd-10", "bd-2", "bd-3", "ops-a3f", "ops-b7c.1"} {
+		lines = append(lines, issueLine(id, "Issue "+id))
+	}
+	// Already in the exporter's order (plain string comparison of IDs).
+	content := strings.Join(lines, "")
+
+	shards, err := Split([]byte(content), m)
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if len(shards) < 2 {
+		t.Errorf("expected issues spread over several shards, got %d", len(shards))
+	}
+	for p := range shards {
+		if !strings.HasPrefix(p, "bd/") && !strings.HasPrefix(p, "ops/") {
+			t.Errorf("unexpected shard path %s", p)
+		}
+	}
+	if !strings.Contains(string(shards[m.Path("bd-1")]), `"bd-1.1"`) {
+		t.Error("child bd-1.1 not stored with its parent bd-1")
+	}
+
+	joined, err := Join(shards)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	if string(joined) != content {
+		t.Errorf("round trip changed content:\n got: %s\nwant: %s", joined, content)
+	}
+}
+
+func TestSplitRejectsBadLines(t *testing.T) {
+	m := &Manifest{Version: 1, Layout: LayoutSharded, Buckets: 4, Hash: HashFNV32a}
+	for _, content := range []string{"not json\n", `{"title":"no id"}` + "\n"} {
+		if _, err := Split([]byte(content), m); err == nil {
+			t.Errorf("Split(%q) succeeded, want error", content)
+		}
+	}
+}
+
+func TestMigrateExportMaterialize(t *testing.T) {
+	beadsDir := t.TempDir()
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	writeJSONL(t, jsonlPath, issueLine("bd-1", "One"), issueLine("bd-2", "Two"), issueLine("bd-3", "Three"))
+
+	if TrackedPath(jsonlPath) != jsonlPath {
+		t.Error("TrackedPath() of single-file layout should be the JSONL itself")
+	}
+	if err := Export(jsonlPath); err != nil {
+		t.Fatalf("Export() on single-file layout error = %v", err)
+	}
+	if _, err := os.Stat(Dir(beadsDir)); !os.IsNotExist(err) {
+		t.Fatal("Export() created shards for a single-file layout")
+	}
+
+	m, err := Migrate(jsonlPath, 2)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if m.Buckets != 2 || !IsSharded(beadsDir) {
+		t.Fatalf("Migrate() manifest = %+v, sharded = %v", m, IsSharded(beadsDir))
+	}
+	if TrackedPath(jsonlPath) != Dir(beadsDir) {
+		t.Errorf("TrackedPath() = %s, want shard dir", TrackedPath(jsonlPath))
+	}
+	if _, err := Migrate(jsonlPath, 2); err == nil {
+		t.Error("second Migrate() succeeded, want error")
+	}
+
+	// Other JSONL files in .beads are not working copies.
+	other := filepath.Join(beadsDir, "deletions.jsonl")
+	writeJSONL(t, other, issueLine("bd-9", "Other"))
+	if err := Sync(other); err != nil {
+		t.Fatalf("Sync(other) error = %v", err)
+	}
+	if IsWorkingCopy(other) || TrackedPath(other) != other || !strings.Contains(readFile(t, other), "bd-9") {
+		t.Error("non-working-copy JSONL was treated as sharded")
+	}
+	original := readFile(t, jsonlPath)
+
+	// A deleted working copy (fresh clone) is rebuilt from the shards.
+	if err := os.Remove(jsonlPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := Materialize(jsonlPath); err != nil {
+		t.Fatalf("Materialize() error = %v", err)
+	}
+	if got := readFile(t, jsonlPath); got != original {
+		t.Errorf("Materialize() = %q, want %q", got, original)
+	}
+
+	// Removing every issue of a shard removes the shard file.
+	writeJSONL(t, jsonlPath, issueLine("bd-1", "One"))
+	if err := Export(jsonlPath); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	shards, err := ReadShards(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shards) != 1 {
+		t.Errorf("expected 1 shard after deleting issues, got %v", shards)
+	}
+}
+
+func TestSync(t *testing.T) {
+	setup := func(t *testing.T) (string, string) {
+		beadsDir := t.TempDir()
+		jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+		writeJSONL(t, jsonlPath, issueLine("bd-1", "One"), issueLine("bd-2", "Two"))
+		if _, err := Migrate(jsonlPath, DefaultBuckets); err != nil {
+			t.Fatal(err)
+		}
+		return beadsDir, jsonlPath
+	}
+
+	t.Run("single-file layout is untouched", func(t *testing.T) {
+		jsonlPath := filepath.Join(t.TempDir(), "issues.jsonl")
+		writeJSONL(t, jsonlPath, issueLine("bd-1", "One"))
+		if err := Sync(jsonlPath); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if _, err := os.Stat(Dir(filepath.Dir(jsonlPath))); !os.IsNotExist(err) {
+			t.Error("Sync() created shards for a single-file layout")
+		}
+	})
+
+	t.Run("changed shards are materialized", func(t *testing.T) {
+		beadsDir, jsonlPath := setup(t)
+		m, _ := ReadManifest(beadsDir)
+		shardPath := filepath.Join(Dir(beadsDir), filepath.FromSlash(m.Path("bd-2")))
+		writeJSONL(t, shardPath, strings.Replace(readFile(t, shardPath), "Two", "Pulled", 1))
+		touch(t, shardPath)
+
+		if err := Sync(jsonlPath); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		got := readFile(t, jsonlPath)
+		if !strings.Contains(got, "Pulled") || !strings.Contains(got, `"bd-1"`) {
+			t.Errorf("JSONL not rebuilt from shards: %s", got)
+		}
+	})
+
+	t.Run("changed working copy is exported", func(t *testing.T) {
+		beadsDir, jsonlPath := setup(t)
+		writeJSONL(t, jsonlPath, issueLine("bd-1", "One"), issueLine("bd-2", "Two"), issueLine("bd-3", "Three"))
+		touch(t, jsonlPath)
+
+		if err := Sync(jsonlPath); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		joined, err := joinFromDisk(beadsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(joined), `"bd-3"`) {
+			t.Errorf("shards missing bd-3: %s", joined)
+		}
+	})
+
+	t.Run("shards win when both changed", func(t *testing.T) {
+		beadsDir, jsonlPath := setup(t)
+		m, _ := ReadManifest(beadsDir)
+		shardPath := filepath.Join(Dir(beadsDir), filepath.FromSlash(m.Path("bd-1")))
+		writeJSONL(t, shardPath, issueLine("bd-1", "FromGit"))
+		touch(t, shardPath)
+		writeJSONL(t, jsonlPath, issueLine("bd-1", "Local"))
+		touch(t, jsonlPath)
+
+		if err := Sync(jsonlPath); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if got := readFile(t, jsonlPath); !strings.Contains(got, "FromGit") {
+			t.Errorf("JSONL = %s, want shard content", got)
+		}
+	})
+}
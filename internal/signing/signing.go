@@ -0,0 +1,473 @@
+// Package signing adds per-record content hashes and detached signatures to
+// JSONL exports, so that whoever imports a JSONL file can check offline which
+// trusted key vouched for each issue record.
+//
+// An export signed by a key writes two files under .beads/signatures/:
+//
+//	<file>.<identity>.json         record manifest: issue ID -> sha256 of the line
+//	<file>.<identity>.json.sig     detached signature over the manifest bytes
+//
+// (.minisig instead of .sig for minisign keys). Each signer owns its own pair
+// of files, so signatures from different people never conflict in git. A
+// record verifies when any manifest whose signature checks out against the
+// allowed signers file lists exactly its bytes.
+//
+// Signing and verification shell out to ssh-keygen (-Y sign / -Y verify) or
+// minisign, the same tools people already use for git commit signing.
+package signing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DirName is the signature directory inside .beads/.
+	DirName = "signatures"
+	// Namespace is the ssh-keygen signature namespace for beads exports. It
+	// keeps an export signature from being replayed as, say, a git commit
+	// signature and vice versa.
+	Namespace = "beads-export"
+	// FormatSSH signs with an SSH key via ssh-keygen.
+	FormatSSH = "ssh"
+	// FormatMinisign signs with a minisign key.
+	FormatMinisign = "minisign"
+
+	manifestVersion = 1
+	hashPrefix      = "sha256:"
+)
+
+var (
+	// ErrUnsigned means no trusted manifest covers the record's ID.
+	ErrUnsigned = errors.New("not signed by a trusted key")
+	// ErrTampered means trusted manifests cover the record's ID, but none of
+	// them with the record's current bytes.
+	ErrTampered = errors.New("does not match its signed hash")
+)
+
+// Manifest lists the records one signer vouched for in one JSONL file.
+type Manifest struct {
+	Version   int               `json:"version"`
+	File      string            `json:"file"`
+	Identity  string            `json:"identity"`
+	Format    string            `json:"format"`
+	PublicKey string            `json:"public_key,omitempty"`
+	SignedAt  time.Time         `json:"signed_at"`
+	Records   map[string]string `json:"records"`
+}
+
+// HashRecord returns the content hash of one JSONL record. The hash covers
+// the exact bytes of the line, so any re-encoding counts as a change.
+func HashRecord(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// RecordHashes hashes every record in JSONL content, keyed by issue ID.
+// Lines are split like bufio.ScanLines does (a trailing \r is not part of
+// the record) so that hashes match what importers read.
+func RecordHashes(content []byte) (map[string]string, error) {
+	hashes := make(map[string]string)
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		id, err := RecordID(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if _, dup := hashes[id]; dup {
+			return nil, fmt.Errorf("line %d: duplicate record %s", i+1, id)
+		}
+		hashes[id] = HashRecord(line)
+	}
+	return hashes, nil
+}
+
+// RecordID returns the issue ID of a JSONL record.
+func RecordID(line []byte) (string, error) {
+	var rec struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	if rec.ID == "" {
+		return "", errors.New("record has no id")
+	}
+	return rec.ID, nil
+}
+
+// Key is a signing key.
+type Key struct {
+	// Format is FormatSSH (default) or FormatMinisign.
+	Format string
+	// Path is the private key file. For SSH it may also be a .pub file whose
+	// private half is held by ssh-agent, as with git's user.signingkey.
+	Path string
+	// Identity names the signer in manifests and in the allowed signers file.
+	Identity string
+}
+
+func (k *Key) format() string {
+	if k.Format == "" {
+		return FormatSSH
+	}
+	return k.Format
+}
+
+// Validate checks that the key is usable.
+func (k *Key) Validate() error {
+	switch k.format() {
+	case FormatSSH, FormatMinisign:
+	default:
+		return fmt.Errorf("unknown signing format %q (want %s or %s)", k.Format, FormatSSH, FormatMinisign)
+	}
+	if k.Path == "" {
+		return errors.New("no signing key configured")
+	}
+	if k.Identity == "" {
+		return errors.New("no signing identity configured")
+	}
+	if strings.ContainsAny(k.Identity, " \t\n/\\") {
+		return fmt.Errorf("signing identity %q must not contain whitespace or slashes", k.Identity)
+	}
+	return nil
+}
+
+// Dir returns the signature directory for a .beads directory.
+func Dir(beadsDir string) string {
+	return filepath.Join(beadsDir, DirName)
+}
+
+// ManifestPath returns where key's manifest for jsonlPath lives.
+func ManifestPath(jsonlPath, identity string) string {
+	stem := strings.TrimSuffix(filepath.Base(jsonlPath), filepath.Ext(jsonlPath))
+	return filepath.Join(Dir(filepath.Dir(jsonlPath)), stem+"."+identity+".json")
+}
+
+// SignaturePath returns the detached signature file for a manifest.
+func SignaturePath(manifestPath, format string) string {
+	if format == FormatMinisign {
+		return manifestPath + ".minisig"
+	}
+	return manifestPath + ".sig"
+}
+
+// Sign hashes every record in jsonlPath and writes key's signed manifest.
+// It returns false without touching anything when key's existing manifest
+// already covers exactly the current records, so repeated exports of
+// unchanged data do not churn git.
+func Sign(jsonlPath string, key *Key) (bool, error) {
+	if err := key.Validate(); err != nil {
+		return false, err
+	}
+	content, err := os.ReadFile(jsonlPath) // #nosec G304 - path is the beads JSONL
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", jsonlPath, err)
+	}
+	records, err := RecordHashes(content)
+	if err != nil {
+		return false, fmt.Errorf("cannot sign %s: %w", filepath.Base(jsonlPath), err)
+	}
+
+	manifestPath := ManifestPath(jsonlPath, key.Identity)
+	sigPath := SignaturePath(manifestPath, key.format())
+	if existing, err := readManifest(manifestPath); err == nil && existing.Format == key.format() &&
+		maps.Equal(existing.Records, records) {
+		if _, err := os.Stat(sigPath); err == nil {
+			return false, nil
+		}
+	}
+
+	m := &Manifest{
+		Version:   manifestVersion,
+		File:      filepath.Base(jsonlPath),
+		Identity:  key.Identity,
+		Format:    key.format(),
+		PublicKey: key.publicKey(),
+		SignedAt:  time.Now().UTC().Truncate(time.Second),
+		Records:   records,
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal signature manifest: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0750); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", DirName, err)
+	}
+	sig, err := key.sign(data)
+	if err != nil {
+		return false, err
+	}
+	// Write the signature last: a manifest without a matching signature just
+	// fails verification, it never verifies the wrong records.
+	// #nosec G306 - signatures are committed and must be readable
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write signature manifest: %w", err)
+	}
+	// #nosec G306 - signatures are committed and must be readable
+	if err := os.WriteFile(sigPath, sig, 0644); err != nil {
+		return false, fmt.Errorf("failed to write signature: %w", err)
+	}
+	return true, nil
+}
+
+// sign produces a detached signature over data.
+func (k *Key) sign(data []byte) ([]byte, error) {
+	switch k.format() {
+	case FormatMinisign:
+		tmp, err := os.MkdirTemp("", "bd-sign-*")
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		msg := filepath.Join(tmp, "manifest.json")
+		sig := msg + ".minisig"
+		if err := os.WriteFile(msg, data, 0600); err != nil {
+			return nil, err
+		}
+		// #nosec G204 - key path comes from the user's own config
+		cmd := exec.Command("minisign", "-S", "-s", k.Path, "-m", msg, "-x", sig, "-t", "beads export signed by "+k.Identity)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("minisign failed: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return os.ReadFile(sig) // #nosec G304 - file in our temp dir
+	default:
+		// With no file arguments ssh-keygen signs stdin and writes the
+		// armored signature to stdout.
+		// #nosec G204 - key path comes from the user's own config
+		cmd := exec.Command("ssh-keygen", "-Y", "sign", "-n", Namespace, "-f", k.Path)
+		cmd.Stdin = bytes.NewReader(data)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("ssh-keygen sign failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return out, nil
+	}
+}
+
+// publicKey returns the public half of k for display in the manifest, or ""
+// if it cannot be determined without prompting.
+func (k *Key) publicKey() string {
+	pubPath := k.Path
+	if k.format() == FormatMinisign {
+		pubPath = strings.TrimSuffix(k.Path, ".key") + ".pub"
+		data, err := os.ReadFile(pubPath) // #nosec G304 - derived from configured key path
+		if err != nil {
+			return ""
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		return strings.TrimSpace(lines[len(lines)-1])
+	}
+	if !strings.HasSuffix(pubPath, ".pub") {
+		pubPath += ".pub"
+	}
+	if data, err := os.ReadFile(pubPath); err == nil { // #nosec G304 - derived from configured key path
+		return strings.TrimSpace(string(data))
+	}
+	return ""
+}
+
+func readManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path inside .beads/signatures
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("%s: unsupported manifest version %d", filepath.Base(path), m.Version)
+	}
+	return &m, nil
+}
+
+// AllowedSigners is a parsed trust file. It uses ssh-keygen's allowed_signers
+// format, plus lines of the form
+//
+//	<identity> minisign <base64 public key>
+//
+// for minisign keys.
+type AllowedSigners struct {
+	ssh      []string
+	minisign map[string][]string // identity -> public keys
+}
+
+// ReadAllowedSigners parses the trust file at path.
+func ReadAllowedSigners(path string) (*AllowedSigners, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - trust file path is from config
+	if err != nil {
+		return nil, err
+	}
+	a := &AllowedSigners{minisign: make(map[string][]string)}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[1] == FormatMinisign {
+			a.minisign[fields[0]] = append(a.minisign[fields[0]], fields[2])
+			continue
+		}
+		a.ssh = append(a.ssh, line)
+	}
+	return a, nil
+}
+
+// Len returns the number of trusted keys.
+func (a *AllowedSigners) Len() int {
+	n := len(a.ssh)
+	for _, keys := range a.minisign {
+		n += len(keys)
+	}
+	return n
+}
+
+// verify checks the detached signature of the manifest at manifestPath.
+func (a *AllowedSigners) verify(m *Manifest, manifestPath string) error {
+	sigPath := SignaturePath(manifestPath, m.Format)
+	if _, err := os.Stat(sigPath); err != nil {
+		return errors.New("missing signature file")
+	}
+	switch m.Format {
+	case FormatMinisign:
+		keys := a.minisign[m.Identity]
+		if len(keys) == 0 {
+			return errors.New("identity is not in the allowed signers file")
+		}
+		var lastErr error
+		for _, pub := range keys {
+			// #nosec G204 - arguments are file paths and a base64 key
+			cmd := exec.Command("minisign", "-V", "-q", "-P", pub, "-m", manifestPath, "-x", sigPath)
+			out, err := cmd.CombinedOutput()
+			if err == nil {
+				return nil
+			}
+			lastErr = fmt.Errorf("minisign: %s", strings.TrimSpace(string(out)))
+		}
+		return lastErr
+	case FormatSSH:
+		if len(a.ssh) == 0 {
+			return errors.New("no SSH keys in the allowed signers file")
+		}
+		allowed, err := os.CreateTemp("", "bd-allowed-signers-*")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(allowed.Name()) }()
+		_, err = allowed.WriteString(strings.Join(a.ssh, "\n") + "\n")
+		_ = allowed.Close()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(manifestPath) // #nosec G304 - path inside .beads/signatures
+		if err != nil {
+			return err
+		}
+		// #nosec G204 - arguments are file paths and the manifest's identity
+		cmd := exec.Command("ssh-keygen", "-Y", "verify", "-f", allowed.Name(), "-I", m.Identity, "-n", Namespace, "-s", sigPath)
+		cmd.Stdin = bytes.NewReader(data)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("ssh-keygen: %s", strings.TrimSpace(string(out)))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown signature format %q", m.Format)
+	}
+}
+
+// Verifier checks JSONL records against the trusted manifests for one file.
+type Verifier struct {
+	manifests []*Manifest
+	// Problems describes manifests that were ignored because they are
+	// malformed or their signature does not verify.
+	Problems []string
+}
+
+// NewVerifier loads and verifies every signature manifest for jsonlPath.
+// Manifests that fail verification are skipped and reported in Problems;
+// their records then count as unsigned.
+func NewVerifier(jsonlPath string, allowed *AllowedSigners) (*Verifier, error) {
+	v := &Verifier{}
+	dir := Dir(filepath.Dir(jsonlPath))
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		m, err := readManifest(path)
+		if err != nil {
+			v.Problems = append(v.Problems, err.Error())
+			continue
+		}
+		if m.File != filepath.Base(jsonlPath) {
+			continue
+		}
+		if err := allowed.verify(m, path); err != nil {
+			v.Problems = append(v.Problems, fmt.Sprintf("%s (%s): %v", e.Name(), m.Identity, err))
+			continue
+		}
+		v.manifests = append(v.manifests, m)
+	}
+	return v, nil
+}
+
+// Signers returns the identities whose verified manifests were loaded.
+func (v *Verifier) Signers() []string {
+	var ids []string
+	for _, m := range v.manifests {
+		ids = append(ids, m.Identity)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// Check verifies one record and returns the identities that vouch for it.
+// It returns ErrUnsigned or ErrTampered when no trusted signer does.
+func (v *Verifier) Check(id string, line []byte) ([]string, error) {
+	hash := HashRecord(line)
+	var signers []string
+	listed := false
+	for _, m := range v.manifests {
+		h, ok := m.Records[id]
+		if !ok {
+			continue
+		}
+		listed = true
+		if h == hash {
+			signers = append(signers, m.Identity)
+		}
+	}
+	switch {
+	case len(signers) > 0:
+		slices.Sort(signers)
+		return slices.Compact(signers), nil
+	case listed:
+		return nil, ErrTampered
+	default:
+		return nil, ErrUnsigned
+	}
+}
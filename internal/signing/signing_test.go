@@ -0,0 +1,197 @@
+package signing
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newSSHKey generates an unencrypted ed25519 key and returns its path and
+// public key line.
+func newSSHKey(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	path := filepath.Join(dir, name)
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", name, "-f", path).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	pub, err := os.ReadFile(path + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, strings.TrimSpace(string(pub))
+}
+
+func writeAllowed(t *testing.T, path string, lines ...string) *AllowedSigners {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := ReadAllowedSigners(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestRecordHashes(t *testing.T) {
+	content := []byte("{\"id\":\"bd-1\",\"title\":\"A\"}\r\n\n{\"id\":\"bd-2\",\"title\":\"B\"}\n")
+	hashes, err := RecordHashes(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 2 {
+		t.Fatalf("got %d hashes, want 2", len(hashes))
+	}
+	if hashes["bd-1"] != HashRecord([]byte(`{"id":"bd-1","title":"A"}`)) {
+		t.Error("CR before the newline should not be part of the record")
+	}
+
+	if _, err := RecordHashes([]byte(`{"title":"no id"}`)); err == nil {
+		t.Error("expected error for record without id")
+	}
+	if _, err := RecordHashes([]byte("{\"id\":\"bd-1\"}\n{\"id\":\"bd-1\"}\n")); err == nil {
+		t.Error("expected error for duplicate ids")
+	}
+}
+
+func TestKeyValidate(t *testing.T) {
+	tests := []struct {
+		key     Key
+		wantErr bool
+	}{
+		{Key{Path: "k", Identity: "alice@example.com"}, false},
+		{Key{Format: FormatMinisign, Path: "k", Identity: "alice"}, false},
+		{Key{Format: "gpg", Path: "k", Identity: "alice"}, true},
+		{Key{Identity: "alice"}, true},
+		{Key{Path: "k"}, true},
+		{Key{Path: "k", Identity: "../alice"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.key.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSignAndVerifySSH(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	aliceKey, alicePub := newSSHKey(t, dir, "alice")
+	malloryKey, _ := newSSHKey(t, dir, "mallory")
+
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	line1 := `{"id":"bd-1","title":"Signed"}`
+	line2 := `{"id":"bd-2","title":"Also signed"}`
+	if err := os.WriteFile(jsonlPath, []byte(line1+"\n"+line2+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	alice := &Key{Path: aliceKey, Identity: "alice@example.com"}
+	if changed, err := Sign(jsonlPath, alice); err != nil || !changed {
+		t.Fatalf("Sign() = %v, %v; want true, nil", changed, err)
+	}
+	if changed, err := Sign(jsonlPath, alice); err != nil || changed {
+		t.Errorf("re-signing unchanged records = %v, %v; want false, nil", changed, err)
+	}
+	manifest, err := readManifest(ManifestPath(jsonlPath, alice.Identity))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.PublicKey != alicePub || manifest.File != "issues.jsonl" {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	// Mallory signs too, but is not trusted.
+	if _, err := Sign(jsonlPath, &Key{Path: malloryKey, Identity: "mallory@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	allowed := writeAllowed(t, filepath.Join(dir, "allowed_signers"), "alice@example.com "+alicePub)
+	v, err := NewVerifier(jsonlPath, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Signers(); len(got) != 1 || got[0] != "alice@example.com" {
+		t.Errorf("Signers() = %v, want [alice@example.com]", got)
+	}
+	if len(v.Problems) != 1 || !strings.Contains(v.Problems[0], "mallory") {
+		t.Errorf("Problems = %v, want one about mallory", v.Problems)
+	}
+
+	if signers, err := v.Check("bd-1", []byte(line1)); err != nil || len(signers) != 1 {
+		t.Errorf("Check(signed) = %v, %v", signers, err)
+	}
+	if _, err := v.Check("bd-1", []byte(`{"id":"bd-1","title":"Edited"}`)); !errors.Is(err, ErrTampered) {
+		t.Errorf("Check(edited) error = %v, want ErrTampered", err)
+	}
+	if _, err := v.Check("bd-3", []byte(`{"id":"bd-3","title":"New"}`)); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Check(new) error = %v, want ErrUnsigned", err)
+	}
+
+	// Editing the manifest to vouch for a tampered record breaks its signature.
+	manifestPath := ManifestPath(jsonlPath, alice.Identity)
+	data, _ := os.ReadFile(manifestPath)
+	forged := strings.Replace(string(data), manifest.Records["bd-2"], HashRecord([]byte(`{"id":"bd-2","title":"Forged"}`)), 1)
+	if err := os.WriteFile(manifestPath, []byte(forged), 0600); err != nil {
+		t.Fatal(err)
+	}
+	v, err = NewVerifier(jsonlPath, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Check("bd-2", []byte(`{"id":"bd-2","title":"Forged"}`)); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Check(forged manifest) error = %v, want ErrUnsigned", err)
+	}
+}
+
+func TestReadAllowedSigners(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allowed_signers")
+	a := writeAllowed(t, path,
+		"# maintainers",
+		"alice@example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIExample",
+		"bob minisign RWQExampleKey",
+		"",
+	)
+	if a.Len() != 2 || len(a.ssh) != 1 || len(a.minisign["bob"]) != 1 {
+		t.Errorf("ReadAllowedSigners() = %+v", a)
+	}
+}
+
+func TestSignAndVerifyMinisign(t *testing.T) {
+	if _, err := exec.LookPath("minisign"); err != nil {
+		t.Skip("minisign not available")
+	}
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "bob.key")
+	pubPath := filepath.Join(dir, "bob.pub")
+	if out, err := exec.Command("minisign", "-G", "-W", "-s", keyPath, "-p", pubPath).CombinedOutput(); err != nil {
+		t.Fatalf("minisign -G: %v: %s", err, out)
+	}
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+	line := `{"id":"bd-1","title":"Signed"}`
+	if err := os.WriteFile(jsonlPath, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	key := &Key{Format: FormatMinisign, Path: keyPath, Identity: "bob"}
+	if _, err := Sign(jsonlPath, key); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	pub := key.publicKey()
+	allowed := writeAllowed(t, filepath.Join(dir, "allowed_signers"), "bob minisign "+pub)
+	v, err := NewVerifier(jsonlPath, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Check("bd-1", []byte(line)); err != nil {
+		t.Errorf("Check() error = %v (problems: %v)", err, v.Problems)
+	}
+}
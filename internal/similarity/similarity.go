@@ -0,0 +1,260 @@
+// Package similarity finds near-duplicate issues by comparing TF-IDF vectors
+// of their titles and descriptions.
+//
+// Everything is computed locally from the issues themselves; there is no
+// model, network call or persisted state. Scores are cosine similarities in
+// [0, 1]: identical wording scores 1, unrelated text scores near 0, and
+// reworded reports of the same problem typically land above 0.6.
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultThreshold is the minimum score reported as a likely duplicate.
+const DefaultThreshold = 0.6
+
+// titleWeight counts title terms this many times, since titles are short
+// and usually carry the distinguishing words.
+const titleWeight = 2
+
+// Document is the text of one issue.
+type Document struct {
+	ID    string
+	Title string
+	Body  string
+}
+
+// Match is a document similar to a query.
+type Match struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// Pair is two similar documents, with A < B.
+type Pair struct {
+	A     string  `json:"a"`
+	B     string  `json:"b"`
+	Score float64 `json:"score"`
+}
+
+// Index holds normalized TF-IDF vectors for a corpus.
+type Index struct {
+	ids      []string
+	vectors  []map[string]float64
+	df       map[string]int
+	postings map[string][]int // term -> documents containing it
+}
+
+// NewIndex builds an index over docs.
+func NewIndex(docs []Document) *Index {
+	ix := &Index{
+		df:       make(map[string]int),
+		postings: make(map[string][]int),
+	}
+	counts := make([]map[string]int, len(docs))
+	for i, doc := range docs {
+		counts[i] = termCounts(doc.Title, doc.Body)
+		for term := range counts[i] {
+			ix.df[term]++
+			ix.postings[term] = append(ix.postings[term], i)
+		}
+		ix.ids = append(ix.ids, doc.ID)
+	}
+	for _, c := range counts {
+		ix.vectors = append(ix.vectors, ix.vector(c))
+	}
+	return ix
+}
+
+// Len returns the number of indexed documents.
+func (ix *Index) Len() int {
+	return len(ix.ids)
+}
+
+// Similar returns indexed documents scoring at least threshold against the
+// given title and body, best first. A limit <= 0 returns all matches.
+func (ix *Index) Similar(title, body string, threshold float64, limit int) []Match {
+	query := ix.vector(termCounts(title, body))
+	scores := make(map[int]float64)
+	for term, w := range query {
+		for _, doc := range ix.postings[term] {
+			scores[doc] += w * ix.vectors[doc][term]
+		}
+	}
+	var matches []Match
+	for doc, score := range scores {
+		if score >= threshold {
+			matches = append(matches, Match{ID: ix.ids[doc], Score: clamp(score)})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Pairs returns every pair of indexed documents scoring at least threshold,
+// best first. Only documents sharing a term are compared.
+func (ix *Index) Pairs(threshold float64) []Pair {
+	type key struct{ a, b int }
+	dots := make(map[key]float64)
+	for term, docs := range ix.postings {
+		for x := 0; x < len(docs); x++ {
+			for y := x + 1; y < len(docs); y++ {
+				a, b := docs[x], docs[y]
+				dots[key{a, b}] += ix.vectors[a][term] * ix.vectors[b][term]
+			}
+		}
+	}
+	var pairs []Pair
+	for k, score := range dots {
+		if score < threshold {
+			continue
+		}
+		a, b := ix.ids[k.a], ix.ids[k.b]
+		if b < a {
+			a, b = b, a
+		}
+		pairs = append(pairs, Pair{A: a, B: b, Score: clamp(score)})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+// Clusters groups documents connected by pairs (transitively). Each cluster
+// is sorted by ID; clusters are ordered by their best pair score.
+func Clusters(pairs []Pair) [][]string {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(x string) string {
+		if parent[x] == "" || parent[x] == x {
+			parent[x] = x
+			return x
+		}
+		root := find(parent[x])
+		parent[x] = root
+		return root
+	}
+
+	var order []string // roots in order of first (best) pair
+	for _, p := range pairs {
+		ra, rb := find(p.A), find(p.B)
+		if ra == rb {
+			continue
+		}
+		if rb < ra {
+			ra, rb = rb, ra
+		}
+		parent[rb] = ra
+		order = append(order, ra)
+	}
+
+	members := make(map[string][]string)
+	for id := range parent {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+	var clusters [][]string
+	seen := make(map[string]bool)
+	for _, r := range order {
+		root := find(r)
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		group := members[root]
+		sort.Strings(group)
+		clusters = append(clusters, group)
+	}
+	return clusters
+}
+
+// vector turns raw term counts into an L2-normalized TF-IDF vector using
+// the corpus document frequencies. Terms unknown to the corpus get the
+// highest IDF.
+func (ix *Index) vector(counts map[string]int) map[string]float64 {
+	n := float64(len(ix.ids))
+	v := make(map[string]float64, len(counts))
+	var norm float64
+	for term, tf := range counts {
+		idf := math.Log((1+n)/(1+float64(ix.df[term]))) + 1
+		w := (1 + math.Log(float64(tf))) * idf
+		v[term] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for term := range v {
+		v[term] /= norm
+	}
+	return v
+}
+
+// termCounts tokenizes title and body, weighting title terms.
+func termCounts(title, body string) map[string]int {
+	counts := make(map[string]int)
+	for _, t := range Tokenize(title) {
+		counts[t] += titleWeight
+	}
+	for _, t := range Tokenize(body) {
+		counts[t]++
+	}
+	return counts
+}
+
+// Tokenize lowercases text, splits it on anything that is not a letter or
+// digit, drops stop words and one-character tokens, and strips a plural "s".
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if len(f) < 2 || stopWords[f] {
+			continue
+		}
+		if len(f) > 3 && strings.HasSuffix(f, "s") && !strings.HasSuffix(f, "ss") {
+			f = f[:len(f)-1]
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}
+
+// clamp rounds away floating point drift above 1.
+func clamp(score float64) float64 {
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "can": true, "do": true, "does": true,
+	"for": true, "from": true, "has": true, "have": true, "if": true, "in": true,
+	"into": true, "is": true, "it": true, "its": true, "not": true, "of": true,
+	"on": true, "or": true, "should": true, "so": true, "that": true, "the": true,
+	"then": true, "there": true, "this": true, "to": true, "was": true, "we": true,
+	"when": true, "which": true, "will": true, "with": true,
+}
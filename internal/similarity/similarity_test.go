@@ -0,0 +1,74 @@
+package similarity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func corpus() []Document {
+	return []Document{
+		{ID: "bd-1", Title: "Login fails with expired session token", Body: "Users are logged out and see a 500 when the session token expires."},
+		{ID: "bd-2", Title: "Expired session token makes login fail", Body: "After the session token expires, login returns a 500 error."},
+		{ID: "bd-3", Title: "Add dark mode to settings page", Body: "Theme toggle in the settings page."},
+		{ID: "bd-4", Title: "Dark mode toggle for the settings page", Body: "Let users switch the settings page theme to dark."},
+		{ID: "bd-5", Title: "Upgrade the CI runners", Body: "Move builds to the new machine images."},
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("The Tests are FAILING on CI-runners, a x bd-42!")
+	want := []string{"test", "failing", "ci", "runner", "bd", "42"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	ix := NewIndex(corpus())
+	if ix.Len() != 5 {
+		t.Fatalf("Len() = %d", ix.Len())
+	}
+
+	matches := ix.Similar("Session token expiry breaks login", "Login gives a 500 once the token expires", 0.3, 0)
+	if len(matches) < 2 || matches[0].ID != "bd-1" && matches[0].ID != "bd-2" {
+		t.Fatalf("Similar() = %+v, want the login issues first", matches)
+	}
+	for _, m := range matches {
+		if m.ID == "bd-3" || m.ID == "bd-5" {
+			t.Errorf("unrelated issue %s matched with %.2f", m.ID, m.Score)
+		}
+	}
+
+	exact := ix.Similar(corpus()[4].Title, corpus()[4].Body, DefaultThreshold, 1)
+	if len(exact) != 1 || exact[0].ID != "bd-5" || exact[0].Score < 0.999 {
+		t.Errorf("identical text = %+v, want bd-5 scoring 1", exact)
+	}
+
+	if got := ix.Similar("", "", 0.1, 0); len(got) != 0 {
+		t.Errorf("empty query matched %+v", got)
+	}
+}
+
+func TestPairsAndClusters(t *testing.T) {
+	pairs := NewIndex(corpus()).Pairs(0.4)
+	got := make(map[[2]string]bool)
+	for _, p := range pairs {
+		got[[2]string{p.A, p.B}] = true
+		if p.A >= p.B {
+			t.Errorf("pair not ordered: %+v", p)
+		}
+	}
+	if !got[[2]string{"bd-1", "bd-2"}] || !got[[2]string{"bd-3", "bd-4"}] || len(got) != 2 {
+		t.Errorf("Pairs(0.4) = %+v, want login and dark mode pairs", pairs)
+	}
+
+	clusters := Clusters([]Pair{
+		{A: "bd-1", B: "bd-2", Score: 0.9},
+		{A: "bd-7", B: "bd-8", Score: 0.8},
+		{A: "bd-2", B: "bd-3", Score: 0.7},
+	})
+	want := [][]string{{"bd-1", "bd-2", "bd-3"}, {"bd-7", "bd-8"}}
+	if !reflect.DeepEqual(clusters, want) {
+		t.Errorf("Clusters() = %v, want %v", clusters, want)
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CascadeReason is the close reason recorded on an issue closed by cascade
+// from triggerID (see the close.cascade-* settings in config.yaml).
+func CascadeReason(reason, triggerID string) string {
+	return fmt.Sprintf("%s (cascade from %s)", reason, triggerID)
+}
+
+// CascadeReader is what CascadedCloses reads. Storage satisfies it.
+type CascadeReader interface {
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetDependentsWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error)
+	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
+}
+
+// CascadedCloses returns the issues closed by cascade from id, directly or
+// through other cascaded closes, in the order they were reached.
+//
+// Backends record each cascaded close as an EventCascadeClosed event naming
+// the trigger, stamped with the close time. Closing an issue by cascade
+// also makes it a dependent of the trigger, which is how they are found.
+func CascadedCloses(ctx context.Context, r CascadeReader, id string) ([]*types.Issue, error) {
+	var closed []*types.Issue
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		trigger := queue[0]
+		queue = queue[1:]
+		deps, err := r.GetDependentsWithMetadata(ctx, trigger)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range deps {
+			if dep.Status != types.StatusClosed || seen[dep.ID] {
+				continue
+			}
+			issue, err := r.GetIssue(ctx, dep.ID)
+			if err != nil {
+				return nil, err
+			}
+			if issue == nil {
+				continue
+			}
+			from, err := cascadeTrigger(ctx, r, issue)
+			if err != nil {
+				return nil, err
+			}
+			if from != trigger {
+				continue
+			}
+			seen[dep.ID] = true
+			closed = append(closed, issue)
+			queue = append(queue, dep.ID)
+		}
+	}
+	return closed, nil
+}
+
+// cascadeTrigger returns the issue whose close cascaded to the closed issue,
+// or "" if it was closed some other way. A cascade event older than the
+// issue's close belongs to an earlier close that has since been reopened.
+func cascadeTrigger(ctx context.Context, r CascadeReader, issue *types.Issue) (string, error) {
+	events, err := r.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		return "", err
+	}
+	for _, ev := range events {
+		if ev.EventType != types.EventCascadeClosed || ev.NewValue == nil {
+			continue
+		}
+		if issue.ClosedAt != nil && ev.CreatedAt.Before(*issue.ClosedAt) {
+			continue
+		}
+		return *ev.NewValue, nil
+	}
+	return "", nil
+}
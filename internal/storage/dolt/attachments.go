@@ -0,0 +1,150 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAttachment records attachment metadata on an issue
+func (s *DoltStore) AddAttachment(ctx context.Context, issueID string, attachment *types.Attachment, actor string) error {
+	if attachment == nil || attachment.Hash == "" {
+		return fmt.Errorf("attachment hash is required")
+	}
+	if attachment.Name == "" {
+		return fmt.Errorf("attachment name is required")
+	}
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	if attachment.CreatedBy == "" {
+		attachment.CreatedBy = actor
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	var name, mimeType string
+	var size int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT name, size, mime_type FROM attachments WHERE issue_id = ? AND hash = ?
+	`, issueID, attachment.Hash).Scan(&name, &size, &mimeType)
+	switch {
+	case err == nil && name == attachment.Name && size == attachment.Size && mimeType == attachment.MimeType:
+		return nil
+	case err != nil && err != sql.ErrNoRows:
+		return fmt.Errorf("failed to check existing attachment: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO attachments (issue_id, hash, name, size, mime_type, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE name = VALUES(name), size = VALUES(size), mime_type = VALUES(mime_type)
+	`, issueID, attachment.Hash, attachment.Name, attachment.Size, attachment.MimeType,
+		attachment.CreatedBy, attachment.CreatedAt); err != nil {
+		return fmt.Errorf("failed to add attachment: %w", err)
+	}
+	if err := recordEvent(ctx, tx, issueID, types.EventAttachmentAdded, actor, "", attachment.Name); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, tx, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return tx.Commit()
+}
+
+// RemoveAttachment removes attachment metadata from an issue
+func (s *DoltStore) RemoveAttachment(ctx context.Context, issueID, hash, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var name string
+	err = tx.QueryRowContext(ctx, `
+		SELECT name FROM attachments WHERE issue_id = ? AND hash = ?
+	`, issueID, hash).Scan(&name)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("attachment %s not found on %s", hash, issueID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up attachment: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ? AND hash = ?`, issueID, hash); err != nil {
+		return fmt.Errorf("failed to remove attachment: %w", err)
+	}
+	if err := recordEvent(ctx, tx, issueID, types.EventAttachmentRemoved, actor, name, ""); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, tx, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetAttachments retrieves the attachments on an issue
+func (s *DoltStore) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	result, err := s.GetAttachmentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetAttachmentsForIssues retrieves attachments for multiple issues
+func (s *DoltStore) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.Attachment), nil
+	}
+
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT issue_id, hash, name, size, mime_type, created_by, created_at
+		FROM attachments
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, name ASC
+	`, joinStrings(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*types.Attachment)
+	for rows.Next() {
+		var issueID string
+		var mimeType, createdBy sql.NullString
+		a := &types.Attachment{}
+		if err := rows.Scan(&issueID, &a.Hash, &a.Name, &a.Size, &mimeType, &createdBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		a.MimeType = mimeType.String
+		a.CreatedBy = createdBy.String
+		result[issueID] = append(result[issueID], a)
+	}
+	return result, rows.Err()
+}
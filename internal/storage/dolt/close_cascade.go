@@ -0,0 +1,222 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// cascadeTarget is an issue a close cascades to, and why.
+type cascadeTarget struct {
+	id     string
+	reason string
+}
+
+// cascadeQuery selects the issues one rule cascades a close to. When
+// lastOf is set, an issue only qualifies once no issue still open depends
+// on it over that edge type.
+type cascadeQuery struct {
+	reason string
+	lastOf types.DependencyType
+	query  string
+}
+
+// cascadeCandidate restricts cascade targets to open, unpinned issues that
+// are not templates. Open children and blockers are checked separately,
+// since the embedded engine mis-evaluates correlated NOT EXISTS and
+// anti-joins against the recursive blockedCTE.
+const cascadeCandidate = `
+	p.status NOT IN ('closed', 'tombstone', 'pinned')
+	AND COALESCE(p.is_template, 0) = 0`
+
+// cascadeClose closes the issues that follow from closing id under the
+// close.cascade-* rules, inside the caller's transaction. It mirrors the
+// SQLite backend: each issue the cascade closes gets a caused-by edge to the
+// issue that triggered it, and its own close is cascaded in turn.
+func cascadeClose(ctx context.Context, tx *sql.Tx, id, actor, session string) error {
+	rules := config.GetCloseCascadeConfig()
+	if !rules.Enabled() {
+		return nil
+	}
+	queue := []string{id}
+	for len(queue) > 0 {
+		trigger := queue[0]
+		queue = queue[1:]
+		targets, err := findCascadeTargets(ctx, tx, trigger, rules)
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			closed, err := closeByCascade(ctx, tx, target, trigger, actor, session)
+			if err != nil {
+				return err
+			}
+			if closed {
+				queue = append(queue, target.id)
+			}
+		}
+	}
+	return nil
+}
+
+// findCascadeTargets returns the issues that closing trigger cascades to.
+func findCascadeTargets(ctx context.Context, tx *sql.Tx, trigger string, rules config.CloseCascadeConfig) ([]cascadeTarget, error) {
+	var queries []cascadeQuery
+	if rules.Epics {
+		// Epics the trigger is a child of, with no open children left
+		queries = append(queries, cascadeQuery{"All children closed", types.DepParentChild, `
+			SELECT p.id FROM dependencies d
+			JOIN issues p ON p.id = d.depends_on_id
+			WHERE d.issue_id = ? AND d.type = 'parent-child' AND p.issue_type = 'epic'
+			  AND` + cascadeCandidate})
+	}
+	if rules.Delegated {
+		// Issues the trigger was delegated from, with no open delegates left
+		queries = append(queries, cascadeQuery{"Delegated work completed", types.DepDelegatedFrom, `
+			SELECT p.id FROM dependencies d
+			JOIN issues p ON p.id = d.depends_on_id
+			WHERE d.issue_id = ? AND d.type = 'delegated-from'
+			  AND` + cascadeCandidate})
+	}
+	if rules.Until {
+		// Issues only active until the trigger closed
+		queries = append(queries, cascadeQuery{"Until condition met", "", `
+			SELECT p.id FROM dependencies d
+			JOIN issues p ON p.id = d.issue_id
+			WHERE d.depends_on_id = ? AND d.type = 'until'
+			  AND` + cascadeCandidate})
+	}
+
+	var targets []cascadeTarget
+	seen := make(map[string]bool)
+	for _, q := range queries {
+		ids, err := queryCascadeIDs(ctx, tx, q.query+` ORDER BY p.id`, trigger)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			if q.lastOf != "" {
+				open, err := hasOpenDependentsTx(ctx, tx, id, q.lastOf)
+				if err != nil {
+					return nil, err
+				}
+				if open {
+					continue
+				}
+			}
+			blocked, err := isBlockedTx(ctx, tx, id)
+			if err != nil {
+				return nil, err
+			}
+			if !blocked {
+				seen[id] = true
+				targets = append(targets, cascadeTarget{id: id, reason: q.reason})
+			}
+		}
+	}
+	return targets, nil
+}
+
+func queryCascadeIDs(ctx context.Context, tx *sql.Tx, query, trigger string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, trigger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find close cascade targets: %w", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan close cascade target: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// hasOpenDependentsTx reports whether any issue still open depends on id
+// over depType.
+func hasOpenDependentsTx(ctx context.Context, tx *sql.Tx, id string, depType types.DependencyType) (bool, error) {
+	var count int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM dependencies c
+		JOIN issues ci ON ci.id = c.issue_id
+		WHERE c.depends_on_id = ? AND c.type = ?
+		  AND ci.status NOT IN ('closed', 'tombstone')
+	`, id, depType).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to count open dependents of %s: %w", id, err)
+	}
+	return count > 0, nil
+}
+
+// isBlockedTx reports whether id is blocked, directly or through a blocked
+// parent, as GetReadyWork defines it.
+func isBlockedTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	// nolint:gosec // G202: blockedCTE is a constant
+	rows, err := tx.QueryContext(ctx, `
+		WITH RECURSIVE `+blockedCTE+`
+		SELECT b.issue_id FROM blocked b WHERE b.issue_id = ?
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether %s is blocked: %w", id, err)
+	}
+	defer rows.Close()
+	blocked := rows.Next()
+	return blocked, rows.Err()
+}
+
+// closeByCascade closes target because trigger closed and links it to
+// trigger with a caused-by edge. It reports false if an earlier step of the
+// cascade already closed target.
+func closeByCascade(ctx context.Context, tx *sql.Tx, target cascadeTarget, trigger, actor, session string) (bool, error) {
+	now := time.Now().UTC()
+	reason := storage.CascadeReason(target.reason, trigger)
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?, closed_by_session = ?
+		WHERE id = ? AND status != ?
+	`, types.StatusClosed, now, now, reason, session, target.id, types.StatusClosed)
+	if err != nil {
+		return false, fmt.Errorf("failed to cascade close to %s: %w", target.id, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return false, err
+	}
+
+	if err := recordEvent(ctx, tx, target.id, types.EventClosed, actor, "", reason); err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	// Stamped with the close time so storage.CascadedCloses can tell it from
+	// an earlier, since reopened, cascade
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, target.id, types.EventCascadeClosed, actor, trigger, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+
+	// Dolt keeps one edge per pair of issues, so an existing edge (such as
+	// the until link itself) is left as it is
+	_, err = tx.ExecContext(ctx, `
+		INSERT IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, metadata)
+		VALUES (?, ?, ?, ?, ?, '{}')
+	`, target.id, trigger, types.DepCausedBy, now, actor)
+	if err != nil {
+		return false, fmt.Errorf("failed to add caused-by dependency: %w", err)
+	}
+
+	if err := markDirty(ctx, tx, target.id); err != nil {
+		return false, fmt.Errorf("failed to mark dirty: %w", err)
+	}
+	return true, nil
+}
@@ -91,10 +91,7 @@ func (s *DoltStore) GetCustomStatuses(ctx context.Context) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	if value == "" {
-		return nil, nil
-	}
-	return strings.Split(value, ","), nil
+	return parseCommaSeparated(value), nil
 }
 
 // GetCustomTypes returns custom issue type values from config
@@ -103,8 +100,17 @@ func (s *DoltStore) GetCustomTypes(ctx context.Context) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	if value == "" {
-		return nil, nil
+	return parseCommaSeparated(value), nil
+}
+
+// parseCommaSeparated splits a comma-separated config value, trimming
+// whitespace and dropping empty entries.
+func parseCommaSeparated(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
 	}
-	return strings.Split(value, ","), nil
+	return result
 }
@@ -12,11 +12,41 @@ import (
 
 // AddDependency adds a dependency between two issues
 func (s *DoltStore) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if dep.IssueID == dep.DependsOnID {
+		return fmt.Errorf("issue cannot depend on itself")
+	}
+
 	metadata := dep.Metadata
 	if metadata == "" {
 		metadata = "{}"
 	}
 
+	// Reject edges that would close a cycle, except bidirectional relates-to
+	// links and caused-by audit edges, matching the SQLite backend's DAG invariant
+	if dep.Type.IsCycleChecked() {
+		var reachable int
+		err := s.db.QueryRowContext(ctx, `
+			WITH RECURSIVE paths AS (
+				SELECT depends_on_id, 1 AS depth
+				FROM dependencies
+				WHERE issue_id = ? AND type NOT IN ('relates-to', 'caused-by')
+				UNION ALL
+				SELECT d.depends_on_id, p.depth + 1
+				FROM dependencies d
+				JOIN paths p ON d.issue_id = p.depends_on_id
+				WHERE p.depth < ? AND d.type NOT IN ('relates-to', 'caused-by')
+			)
+			SELECT COUNT(*) FROM paths WHERE depends_on_id = ?
+		`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&reachable)
+		if err != nil {
+			return fmt.Errorf("failed to check for cycles: %w", err)
+		}
+		if reachable > 0 {
+			return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
+				dep.IssueID, dep.DependsOnID, dep.IssueID)
+		}
+	}
+
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id)
 		VALUES (?, ?, ?, NOW(), ?, ?, ?)
@@ -83,7 +113,9 @@ func (s *DoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID str
 	}
 	defer rows.Close()
 
-	var results []*types.IssueWithDependencyMetadata
+	// Drain the result set before fetching issues: embedded mode uses a
+	// single connection, so nested queries would block on open rows.
+	var edges []depEdge
 	for rows.Next() {
 		var depID, depType, createdBy string
 		var createdAt sql.NullTime
@@ -92,22 +124,14 @@ func (s *DoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID str
 		if err := rows.Scan(&depID, &depType, &createdAt, &createdBy, &metadata, &threadID); err != nil {
 			return nil, fmt.Errorf("failed to scan dependency: %w", err)
 		}
-
-		issue, err := s.GetIssue(ctx, depID)
-		if err != nil {
-			return nil, err
-		}
-		if issue == nil {
-			continue
-		}
-
-		result := &types.IssueWithDependencyMetadata{
-			Issue:          *issue,
-			DependencyType: types.DependencyType(depType),
-		}
-		results = append(results, result)
+		edges = append(edges, depEdge{id: depID, depType: types.DependencyType(depType)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return results, rows.Err()
+	_ = rows.Close()
+
+	return s.issuesWithDependencyType(ctx, edges)
 }
 
 // GetDependentsWithMetadata returns dependents with metadata
@@ -122,7 +146,9 @@ func (s *DoltStore) GetDependentsWithMetadata(ctx context.Context, issueID strin
 	}
 	defer rows.Close()
 
-	var results []*types.IssueWithDependencyMetadata
+	// Drain the result set before fetching issues: embedded mode uses a
+	// single connection, so nested queries would block on open rows.
+	var edges []depEdge
 	for rows.Next() {
 		var depID, depType, createdBy string
 		var createdAt sql.NullTime
@@ -131,22 +157,14 @@ func (s *DoltStore) GetDependentsWithMetadata(ctx context.Context, issueID strin
 		if err := rows.Scan(&depID, &depType, &createdAt, &createdBy, &metadata, &threadID); err != nil {
 			return nil, fmt.Errorf("failed to scan dependent: %w", err)
 		}
-
-		issue, err := s.GetIssue(ctx, depID)
-		if err != nil {
-			return nil, err
-		}
-		if issue == nil {
-			continue
-		}
-
-		result := &types.IssueWithDependencyMetadata{
-			Issue:          *issue,
-			DependencyType: types.DependencyType(depType),
-		}
-		results = append(results, result)
+		edges = append(edges, depEdge{id: depID, depType: types.DependencyType(depType)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return results, rows.Err()
+	_ = rows.Close()
+
+	return s.issuesWithDependencyType(ctx, edges)
 }
 
 // GetDependencyRecords returns raw dependency records for an issue
@@ -422,23 +440,23 @@ func (s *DoltStore) IsBlocked(ctx context.Context, issueID string) (bool, []stri
 
 // GetNewlyUnblockedByClose finds issues that become unblocked when an issue is closed
 func (s *DoltStore) GetNewlyUnblockedByClose(ctx context.Context, closedIssueID string) ([]*types.Issue, error) {
-	// Find issues that were blocked only by the closed issue
+	// Find open/in_progress dependents of the closed issue that are no longer
+	// blocked, directly or through a blocked ancestor
+	// nolint:gosec // G202: blockedCTE is a constant
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT DISTINCT d.issue_id
-		FROM dependencies d
-		JOIN issues i ON d.issue_id = i.id
-		WHERE d.depends_on_id = ?
-		  AND d.type = 'blocks'
-		  AND i.status IN ('open', 'blocked')
-		  AND NOT EXISTS (
-			SELECT 1 FROM dependencies d2
-			JOIN issues blocker ON d2.depends_on_id = blocker.id
-			WHERE d2.issue_id = d.issue_id
-			  AND d2.type = 'blocks'
-			  AND d2.depends_on_id != ?
-			  AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-		  )
-	`, closedIssueID, closedIssueID)
+		WITH RECURSIVE `+blockedCTE+`
+		SELECT i.id
+		FROM issues i
+		LEFT JOIN blocked b ON b.issue_id = i.id
+		WHERE i.id IN (
+			SELECT d.issue_id FROM dependencies d
+			WHERE d.depends_on_id = ? AND d.type = 'blocks'
+		)
+		  AND i.status IN ('open', 'in_progress')
+		  AND (i.pinned = 0 OR i.pinned IS NULL)
+		  AND b.issue_id IS NULL
+		ORDER BY i.priority ASC, i.id ASC
+	`, closedIssueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find newly unblocked: %w", err)
 	}
@@ -447,8 +465,36 @@ func (s *DoltStore) GetNewlyUnblockedByClose(ctx context.Context, closedIssueID
 	return s.scanIssueIDs(ctx, rows)
 }
 
+// maxDependencyDepth bounds cycle detection traversal, as in the SQLite backend.
+const maxDependencyDepth = 100
+
 // Helper functions
 
+// depEdge is a dependency endpoint collected before issues are fetched.
+type depEdge struct {
+	id      string
+	depType types.DependencyType
+}
+
+// issuesWithDependencyType fetches the issues for edges, skipping missing ones.
+func (s *DoltStore) issuesWithDependencyType(ctx context.Context, edges []depEdge) ([]*types.IssueWithDependencyMetadata, error) {
+	var results []*types.IssueWithDependencyMetadata
+	for _, e := range edges {
+		issue, err := s.GetIssue(ctx, e.id)
+		if err != nil {
+			return nil, err
+		}
+		if issue == nil {
+			continue
+		}
+		results = append(results, &types.IssueWithDependencyMetadata{
+			Issue:          *issue,
+			DependencyType: e.depType,
+		})
+	}
+	return results, nil
+}
+
 func (s *DoltStore) scanIssueIDs(ctx context.Context, rows *sql.Rows) ([]*types.Issue, error) {
 	// First, collect all IDs
 	var ids []string
@@ -467,8 +513,25 @@ func (s *DoltStore) scanIssueIDs(ctx context.Context, rows *sql.Rows) ([]*types.
 		return nil, nil
 	}
 
-	// Fetch all issues in a single batch query
-	return s.GetIssuesByIDs(ctx, ids)
+	// Close before the batch query: embedded mode has a single connection
+	_ = rows.Close()
+
+	// Fetch all issues in a single batch query, then restore the caller's ordering
+	issues, err := s.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	ordered := make([]*types.Issue, 0, len(issues))
+	for _, id := range ids {
+		if issue, ok := byID[id]; ok {
+			ordered = append(ordered, issue)
+		}
+	}
+	return ordered, nil
 }
 
 // GetIssuesByIDs retrieves multiple issues by ID in a single query to avoid N+1 performance issues
@@ -497,7 +560,7 @@ func (s *DoltStore) GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
 		       due_at, defer_until,
-		       quality_score, work_type, source_system
+		       quality_score, work_type, source_system, source_formula, source_location
 		FROM issues
 		WHERE id IN (%s)
 	`, strings.Join(placeholders, ","))
@@ -527,7 +590,7 @@ func scanIssueRow(rows *sql.Rows) (*types.Issue, error) {
 	var estimatedMinutes, originalSize, timeoutNs sql.NullInt64
 	var assignee, externalRef, compactedAtCommit, owner sql.NullString
 	var contentHash, sourceRepo, closeReason, deletedBy, deleteReason, originalType sql.NullString
-	var workType, sourceSystem sql.NullString
+	var workType, sourceSystem, sourceFormula, sourceLocation sql.NullString
 	var sender, molType, eventKind, actor, target, payload sql.NullString
 	var awaitType, awaitID, waiters sql.NullString
 	var hookBead, roleBead, agentState, roleType, rig sql.NullString
@@ -546,7 +609,7 @@ func scanIssueRow(rows *sql.Rows) (*types.Issue, error) {
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
 		&eventKind, &actor, &target, &payload,
 		&dueAt, &deferUntil,
-		&qualityScore, &workType, &sourceSystem,
+		&qualityScore, &workType, &sourceSystem, &sourceFormula, &sourceLocation,
 	); err != nil {
 		return nil, fmt.Errorf("failed to scan issue row: %w", err)
 	}
@@ -674,6 +737,12 @@ func scanIssueRow(rows *sql.Rows) (*types.Issue, error) {
 	if sourceSystem.Valid {
 		issue.SourceSystem = sourceSystem.String
 	}
+	if sourceFormula.Valid {
+		issue.SourceFormula = sourceFormula.String
+	}
+	if sourceLocation.Valid {
+		issue.SourceLocation = sourceLocation.String
+	}
 
 	return &issue, nil
 }
@@ -0,0 +1,117 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetNeighborhood returns the issues within maxDepth edges of issueID,
+// walking dependencies in both directions, nearest first.
+func (s *DoltStore) GetNeighborhood(ctx context.Context, issueID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphNeighbor, error) {
+	if maxDepth <= 0 {
+		maxDepth = storage.DefaultNeighborhoodDepth
+	}
+	hops, err := s.walkGraph(ctx, issueID, maxDepth, edgeTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	nearest := storage.NearestHops(issueID, hops)
+	ids := make([]string, len(nearest))
+	for i, h := range nearest {
+		ids[i] = h.ID
+	}
+	issues, err := s.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	var neighbors []*types.GraphNeighbor
+	for _, h := range nearest {
+		issue := byID[h.ID]
+		if issue == nil || issue.Status == types.StatusTombstone {
+			continue // external reference or deleted issue
+		}
+		neighbors = append(neighbors, &types.GraphNeighbor{
+			Issue:    *issue,
+			Distance: h.Depth,
+			Via:      h.Type,
+			ViaID:    h.PrevID,
+		})
+	}
+	return neighbors, nil
+}
+
+// FindPath returns a shortest path of typed edges from fromID to toID,
+// or nil if none exists within maxDepth edges.
+func (s *DoltStore) FindPath(ctx context.Context, fromID, toID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphPathStep, error) {
+	if maxDepth <= 0 {
+		maxDepth = storage.DefaultPathDepth
+	}
+	hops, err := s.walkGraph(ctx, fromID, maxDepth, edgeTypes)
+	if err != nil {
+		return nil, err
+	}
+	return storage.PathFromHops(fromID, toID, hops), nil
+}
+
+// walkGraph runs a breadth-first walk over the dependencies table with a
+// recursive CTE. UNION DISTINCT collapses duplicate hops, so the result is
+// bounded by maxDepth times the number of edges even in cycles. The anchor
+// columns are cast so MySQL does not size them from the empty literals.
+func (s *DoltStore) walkGraph(ctx context.Context, startID string, maxDepth int, edgeTypes []types.DependencyType) ([]storage.GraphHop, error) {
+	args := []interface{}{startID, maxDepth}
+	typeFilter := ""
+	if len(edgeTypes) > 0 {
+		placeholders := make([]string, len(edgeTypes))
+		for i, t := range edgeTypes {
+			placeholders[i] = "?"
+			args = append(args, string(t))
+		}
+		typeFilter = fmt.Sprintf("AND d.type IN (%s)", strings.Join(placeholders, ","))
+	}
+
+	// #nosec G201 - typeFilter only contains placeholders
+	query := fmt.Sprintf(`
+		WITH RECURSIVE walk(id, depth, prev_id, type, reverse) AS (
+			SELECT CAST(? AS CHAR(255)), 0, CAST('' AS CHAR(255)), CAST('' AS CHAR(32)), 0
+			UNION DISTINCT
+			SELECT
+				CASE WHEN d.issue_id = w.id THEN d.depends_on_id ELSE d.issue_id END,
+				w.depth + 1,
+				w.id,
+				d.type,
+				CASE WHEN d.issue_id = w.id THEN 0 ELSE 1 END
+			FROM walk w
+			JOIN dependencies d ON d.issue_id = w.id OR d.depends_on_id = w.id
+			WHERE w.depth < ? %s
+		)
+		SELECT id, depth, prev_id, type, reverse FROM walk WHERE depth > 0
+	`, typeFilter)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk dependency graph: %w", err)
+	}
+	defer rows.Close()
+
+	var hops []storage.GraphHop
+	for rows.Next() {
+		var h storage.GraphHop
+		var reverse int
+		if err := rows.Scan(&h.ID, &h.Depth, &h.PrevID, &h.Type, &reverse); err != nil {
+			return nil, fmt.Errorf("failed to scan graph hop: %w", err)
+		}
+		h.Reverse = reverse != 0
+		hops = append(hops, h)
+	}
+	return hops, rows.Err()
+}
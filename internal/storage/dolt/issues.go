@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -135,6 +137,11 @@ func (s *DoltStore) CreateIssues(ctx context.Context, issues []*types.Issue, act
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	var prefix string
+	if err := tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", "issue_prefix").Scan(&prefix); err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
 	for _, issue := range issues {
 		now := time.Now().UTC()
 		if issue.CreatedAt.IsZero() {
@@ -173,6 +180,17 @@ func (s *DoltStore) CreateIssues(ctx context.Context, issues []*types.Issue, act
 			issue.ContentHash = issue.ComputeContentHash()
 		}
 
+		if issue.ID == "" {
+			if prefix == "" {
+				return fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
+			}
+			generatedID, err := generateIssueID(ctx, tx, prefix, issue, actor)
+			if err != nil {
+				return fmt.Errorf("failed to generate issue ID: %w", err)
+			}
+			issue.ID = generatedID
+		}
+
 		if err := insertIssue(ctx, tx, issue); err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
 		}
@@ -251,7 +269,7 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 			columnName = "ephemeral"
 		}
 		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", columnName))
-		args = append(args, value)
+		args = append(args, updateColumnValue(key, value))
 	}
 
 	// Auto-manage closed_at
@@ -321,6 +339,11 @@ func (s *DoltStore) CloseIssue(ctx context.Context, id string, reason string, ac
 		return fmt.Errorf("failed to mark dirty: %w", err)
 	}
 
+	// Closes that follow from this one (close.cascade-* in config.yaml)
+	if err := cascadeClose(ctx, tx, id, actor, session); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -333,7 +356,7 @@ func (s *DoltStore) DeleteIssue(ctx context.Context, id string) error {
 	defer func() { _ = tx.Rollback() }()
 
 	// Delete related data (foreign keys will cascade, but be explicit)
-	tables := []string{"dependencies", "events", "comments", "labels", "dirty_issues"}
+	tables := []string{"dependencies", "events", "comments", "labels", "attachments", "work_logs", "dirty_issues"}
 	for _, table := range tables {
 		if table == "dependencies" {
 			_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE issue_id = ? OR depends_on_id = ?", table), id, id)
@@ -378,7 +401,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 			event_kind, actor, target, payload,
 			await_type, await_id, timeout_ns, waiters,
 			hook_bead, role_bead, agent_state, last_activity, role_type, rig,
-			due_at, defer_until
+			due_at, defer_until, source_formula, source_location
 		) VALUES (
 			?, ?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?, ?,
@@ -390,7 +413,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 			?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?,
-			?, ?
+			?, ?, ?, ?
 		)
 	`,
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
@@ -403,7 +426,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
 		issue.AwaitType, issue.AwaitID, issue.Timeout.Nanoseconds(), formatJSONStringArray(issue.Waiters),
 		issue.HookBead, issue.RoleBead, issue.AgentState, issue.LastActivity, issue.RoleType, issue.Rig,
-		issue.DueAt, issue.DeferUntil,
+		issue.DueAt, issue.DeferUntil, issue.SourceFormula, issue.SourceLocation,
 	)
 	return err
 }
@@ -414,7 +437,7 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 	var estimatedMinutes, originalSize, timeoutNs sql.NullInt64
 	var assignee, externalRef, compactedAtCommit, owner sql.NullString
 	var contentHash, sourceRepo, closeReason, deletedBy, deleteReason, originalType sql.NullString
-	var workType, sourceSystem sql.NullString
+	var workType, sourceSystem, sourceFormula, sourceLocation sql.NullString
 	var sender, molType, eventKind, actor, target, payload sql.NullString
 	var awaitType, awaitID, waiters sql.NullString
 	var hookBead, roleBead, agentState, roleType, rig sql.NullString
@@ -432,7 +455,7 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
 		       due_at, defer_until,
-		       quality_score, work_type, source_system
+		       quality_score, work_type, source_system, source_formula, source_location
 		FROM issues
 		WHERE id = ?
 	`, id).Scan(
@@ -447,7 +470,7 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
 		&eventKind, &actor, &target, &payload,
 		&dueAt, &deferUntil,
-		&qualityScore, &workType, &sourceSystem,
+		&qualityScore, &workType, &sourceSystem, &sourceFormula, &sourceLocation,
 	)
 
 	if err == sql.ErrNoRows {
@@ -580,6 +603,12 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 	if sourceSystem.Valid {
 		issue.SourceSystem = sourceSystem.String
 	}
+	if sourceFormula.Valid {
+		issue.SourceFormula = sourceFormula.String
+	}
+	if sourceLocation.Valid {
+		issue.SourceLocation = sourceLocation.String
+	}
 
 	return &issue, nil
 }
@@ -601,15 +630,33 @@ func markDirty(ctx context.Context, tx *sql.Tx, issueID string) error {
 	return err
 }
 
-// nolint:unparam // error return kept for interface consistency
-func generateIssueID(_ context.Context, _ *sql.Tx, prefix string, issue *types.Issue, _ string) (string, error) {
-	// Simple hash-based ID generation
-	// Use first 6 chars of content hash
-	hash := issue.ComputeContentHash()
-	if len(hash) > 6 {
-		hash = hash[:6]
+// generateIssueID generates a unique hash-based ID for an issue.
+// Like the SQLite backend it tries several nonces and longer IDs on collision,
+// so issues with identical content still get distinct IDs. A length pinned by
+// the synced id.namespace record raises the starting length.
+func generateIssueID(ctx context.Context, tx *sql.Tx, prefix string, issue *types.Issue, actor string) (string, error) {
+	const maxLength = 8
+	baseLength := 6
+	var pinned string
+	if err := tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", idgen.NamespaceConfigKey(prefix)).Scan(&pinned); err == nil {
+		if n, err := strconv.Atoi(pinned); err == nil && n > baseLength {
+			baseLength = min(n, maxLength)
+		}
+	}
+	for length := baseLength; length <= maxLength; length++ {
+		for nonce := 0; nonce < 10; nonce++ {
+			candidate := idgen.GenerateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+
+			var count int
+			if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM issues WHERE id = ?", candidate).Scan(&count); err != nil {
+				return "", fmt.Errorf("failed to check for ID collision: %w", err)
+			}
+			if count == 0 {
+				return candidate, nil
+			}
+		}
 	}
-	return fmt.Sprintf("%s-%s", prefix, hash), nil
+	return "", fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, maxLength)
 }
 
 func isAllowedUpdateField(key string) bool {
@@ -622,11 +669,19 @@ func isAllowedUpdateField(key string) bool {
 		"hook_bead": true, "role_bead": true, "agent_state": true, "last_activity": true,
 		"role_type": true, "rig": true, "mol_type": true,
 		"event_category": true, "event_actor": true, "event_target": true, "event_payload": true,
-		"due_at": true, "defer_until": true, "await_id": true,
+		"due_at": true, "defer_until": true, "await_id": true, "waiters": true,
 	}
 	return allowed[key]
 }
 
+// updateColumnValue converts an UpdateIssue value to what its column stores.
+func updateColumnValue(key string, value interface{}) interface{} {
+	if arr, ok := value.([]string); ok && key == "waiters" {
+		return formatJSONStringArray(arr)
+	}
+	return value
+}
+
 func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
 	statusVal, hasStatus := updates["status"]
 	_, hasExplicitClosedAt := updates["closed_at"]
@@ -10,24 +10,40 @@ import (
 
 // AddLabel adds a label to an issue
 func (s *DoltStore) AddLabel(ctx context.Context, issueID, label, actor string) error {
-	_, err := s.db.ExecContext(ctx, `
-		INSERT IGNORE INTO labels (issue_id, label) VALUES (?, ?)
-	`, issueID, label)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO labels (issue_id, label) VALUES (?, ?)
+	`, issueID, label); err != nil {
 		return fmt.Errorf("failed to add label: %w", err)
 	}
-	return nil
+	if err := markDirty(ctx, tx, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return tx.Commit()
 }
 
 // RemoveLabel removes a label from an issue
 func (s *DoltStore) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
-	_, err := s.db.ExecContext(ctx, `
-		DELETE FROM labels WHERE issue_id = ? AND label = ?
-	`, issueID, label)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM labels WHERE issue_id = ? AND label = ?
+	`, issueID, label); err != nil {
 		return fmt.Errorf("failed to remove label: %w", err)
 	}
-	return nil
+	if err := markDirty(ctx, tx, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return tx.Commit()
 }
 
 // GetLabels retrieves all labels for an issue
@@ -101,19 +117,5 @@ func (s *DoltStore) GetIssuesByLabel(ctx context.Context, label string) ([]*type
 	}
 	defer rows.Close()
 
-	var issues []*types.Issue
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, fmt.Errorf("failed to scan issue id: %w", err)
-		}
-		issue, err := s.GetIssue(ctx, id)
-		if err != nil {
-			return nil, err
-		}
-		if issue != nil {
-			issues = append(issues, issue)
-		}
-	}
-	return issues, rows.Err()
+	return s.scanIssueIDs(ctx, rows)
 }
@@ -192,6 +192,13 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 		args = append(args, string(*filter.MolType))
 	}
 
+	// Multi-repo filtering
+	if filter.SourceRepo != nil {
+		clause, repoArgs := sourceRepoClause("source_repo", *filter.SourceRepo)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, repoArgs...)
+	}
+
 	// Time-based scheduling filters
 	if filter.Deferred {
 		whereClauses = append(whereClauses, "defer_until IS NOT NULL")
@@ -228,60 +235,155 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 	return s.scanIssueIDs(ctx, rows)
 }
 
-// GetReadyWork returns issues that are ready to work on (not blocked)
+// blockedCTE defines "blocked": issues blocked by an open 'blocks'
+// dependency, plus all parent-child descendants of those issues. It mirrors
+// SQLite's blocked_issues_cache so ready work is computed identically.
+//
+// Use it as a top-level CTE and anti-join with LEFT JOIN ... IS NULL;
+// the embedded engine mis-evaluates NOT IN/NOT EXISTS over recursive CTEs.
+const blockedCTE = `blocked AS (
+		SELECT d.issue_id
+		FROM dependencies d
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
+		UNION
+		SELECT d.issue_id
+		FROM dependencies d
+		JOIN blocked b ON d.depends_on_id = b.issue_id
+		WHERE d.type = 'parent-child'
+	)`
+
+// descendantsCTE defines "descendants": all parent-child descendants of the
+// bound parent ID. Join against it rather than using IN.
+const descendantsCTE = `descendants AS (
+		SELECT issue_id FROM dependencies
+		WHERE type = 'parent-child' AND depends_on_id = ?
+		UNION
+		SELECT d.issue_id FROM dependencies d
+		JOIN descendants dt ON d.depends_on_id = dt.issue_id
+		WHERE d.type = 'parent-child'
+	)`
+
+// GetReadyWork returns issues that are ready to work on (not blocked).
+// By default it shows both 'open' and 'in_progress' issues and excludes
+// pinned issues, wisps, and workflow types, matching the SQLite backend.
 func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	whereClauses := []string{"status = 'open'", "(ephemeral = 0 OR ephemeral IS NULL)"}
+	ctes := []string{blockedCTE}
+	joins := []string{"LEFT JOIN blocked b ON b.issue_id = i.id"}
+	var cteArgs []interface{}
+	whereClauses := []string{
+		"b.issue_id IS NULL",
+		"(i.pinned = 0 OR i.pinned IS NULL)",
+		"(i.ephemeral = 0 OR i.ephemeral IS NULL)",
+		"i.id NOT LIKE '%-wisp-%'",
+	}
 	args := []interface{}{}
 
-	if filter.Priority != nil {
-		whereClauses = append(whereClauses, "priority = ?")
-		args = append(args, *filter.Priority)
+	if filter.Status == "" {
+		whereClauses = append(whereClauses, "i.status IN ('open', 'in_progress')")
+	} else {
+		whereClauses = append(whereClauses, "i.status = ?")
+		args = append(args, filter.Status)
 	}
+
 	if filter.Type != "" {
-		whereClauses = append(whereClauses, "issue_type = ?")
+		whereClauses = append(whereClauses, "i.issue_type = ?")
 		args = append(args, filter.Type)
+	} else {
+		// Exclude workflow and identity types, which are not claimable work
+		whereClauses = append(whereClauses, "i.issue_type NOT IN ('merge-request', 'gate', 'molecule', 'message', 'agent', 'role', 'rig')")
 	}
-	if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
+
+	if filter.Priority != nil {
+		whereClauses = append(whereClauses, "i.priority = ?")
+		args = append(args, *filter.Priority)
+	}
+
+	// Unassigned takes precedence over Assignee filter
+	if filter.Unassigned {
+		whereClauses = append(whereClauses, "(i.assignee IS NULL OR i.assignee = '')")
+	} else if filter.Assignee != nil {
+		whereClauses = append(whereClauses, "i.assignee = ?")
 		args = append(args, *filter.Assignee)
 	}
-	if len(filter.Labels) > 0 {
-		for _, label := range filter.Labels {
-			whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
+
+	for _, label := range filter.Labels {
+		whereClauses = append(whereClauses, "i.id IN (SELECT issue_id FROM labels WHERE label = ?)")
+		args = append(args, label)
+	}
+	if len(filter.LabelsAny) > 0 {
+		placeholders := make([]string, len(filter.LabelsAny))
+		for j, label := range filter.LabelsAny {
+			placeholders[j] = "?"
 			args = append(args, label)
 		}
+		whereClauses = append(whereClauses, fmt.Sprintf("i.id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ",")))
 	}
 
-	// Exclude blocked issues using subquery
-	whereClauses = append(whereClauses, `
-		id NOT IN (
-			SELECT DISTINCT d.issue_id
-			FROM dependencies d
-			JOIN issues blocker ON d.depends_on_id = blocker.id
-			WHERE d.type = 'blocks'
-			  AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-		)
-	`)
+	if filter.ParentID != nil {
+		ctes = append(ctes, descendantsCTE)
+		joins = append(joins, "JOIN descendants ds ON ds.issue_id = i.id")
+		cteArgs = append(cteArgs, *filter.ParentID)
+	}
+
+	if filter.MolType != nil {
+		whereClauses = append(whereClauses, "i.mol_type = ?")
+		args = append(args, string(*filter.MolType))
+	}
+
+	if filter.SourceRepo != nil {
+		clause, repoArgs := sourceRepoClause("i.source_repo", *filter.SourceRepo)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, repoArgs...)
+	}
+
+	now := time.Now().UTC()
+	if !filter.IncludeDeferred {
+		whereClauses = append(whereClauses, "(i.defer_until IS NULL OR i.defer_until <= ?)")
+		args = append(args, now)
+	}
 
-	whereSQL := "WHERE " + strings.Join(whereClauses, " AND ")
+	// Capability routing: every needs:<cap> label must be a capability of the agent
+	if filter.ForAgent != "" {
+		whereClauses = append(whereClauses, `NOT EXISTS (
+			SELECT 1 FROM labels n
+			WHERE n.issue_id = i.id AND n.label LIKE 'needs:%'
+			AND SUBSTRING(n.label, 7) NOT IN (`+agentCapabilitiesSQL+`))`)
+		args = append(args, filter.ForAgent, filter.ForAgent, filter.ForAgent, filter.ForAgent)
+	}
+
+	sortPolicy := filter.SortPolicy
+	if sortPolicy == "" {
+		sortPolicy = types.SortPolicyHybrid
+	}
+	orderSQL, orderArgs := buildOrderByClause(sortPolicy, now)
+	args = append(args, orderArgs...)
+	if filter.ForAgent != "" {
+		// Best match first: work that uses more of the agent's capabilities
+		orderSQL = strings.Replace(orderSQL, "ORDER BY", `ORDER BY
+			(SELECT COUNT(*) FROM labels n WHERE n.issue_id = i.id AND n.label LIKE 'needs:%') DESC,`, 1)
+	}
 
 	limitSQL := ""
 	if filter.Limit > 0 {
 		limitSQL = fmt.Sprintf(" LIMIT %d", filter.Limit)
 	}
 
-	// nolint:gosec // G201: whereSQL contains column comparisons with ?, limitSQL is a safe integer
+	// nolint:gosec // G201: CTEs and joins are constants, whereSQL contains column comparisons with ?, limitSQL is a safe integer
 	query := fmt.Sprintf(`
-		SELECT id FROM issues
+		WITH RECURSIVE %s
+		SELECT i.id FROM issues i
 		%s
-		ORDER BY priority ASC, created_at DESC
+		WHERE %s
 		%s
-	`, whereSQL, limitSQL)
+		%s
+	`, strings.Join(ctes, ",\n"), strings.Join(joins, "\n"), strings.Join(whereClauses, " AND "), orderSQL, limitSQL)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db.QueryContext(ctx, query, append(cteArgs, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ready work: %w", err)
 	}
@@ -290,74 +392,147 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 	return s.scanIssueIDs(ctx, rows)
 }
 
-// GetBlockedIssues returns issues that are blocked by other issues
+// agentCapabilitiesSQL selects the capabilities of one agent bead, which is
+// bound four times: its can:<cap> labels, the skills attested for it, and
+// role:<role_type> and rig:<rig>. Mirrors types.AgentCapabilities.
+const agentCapabilitiesSQL = `
+	SELECT SUBSTRING(label, 5) FROM labels WHERE issue_id = ? AND label LIKE 'can:%'
+	UNION
+	SELECT JSON_UNQUOTE(JSON_EXTRACT(metadata, '$.skill')) FROM dependencies
+	WHERE depends_on_id = ? AND type = 'attests' AND JSON_EXTRACT(metadata, '$.skill') IS NOT NULL
+	UNION
+	SELECT CONCAT('role:', role_type) FROM issues WHERE id = ? AND role_type IS NOT NULL AND role_type != ''
+	UNION
+	SELECT CONCAT('rig:', rig) FROM issues WHERE id = ? AND rig IS NOT NULL AND rig != ''`
+
+// sourceRepoClause builds a WHERE clause matching issues owned by repo, where
+// "." (the primary repo) also matches rows with no source_repo recorded.
+func sourceRepoClause(column, repo string) (string, []interface{}) {
+	if repo == "" || repo == "." {
+		return fmt.Sprintf("(%s IS NULL OR %s IN ('', '.'))", column, column), nil
+	}
+	return column + " = ?", []interface{}{repo}
+}
+
+// buildOrderByClause returns the ORDER BY clause for a ready-work sort policy.
+// The hybrid cutoff is bound as a parameter so results match SQLite's
+// datetime('now', '-48 hours') comparison.
+func buildOrderByClause(policy types.SortPolicy, now time.Time) (string, []interface{}) {
+	switch policy {
+	case types.SortPolicyPriority:
+		return "ORDER BY i.priority ASC, i.created_at ASC, i.id ASC", nil
+	case types.SortPolicyOldest:
+		return "ORDER BY i.created_at ASC, i.id ASC", nil
+	case types.SortPolicyDue:
+		// Mirrors types.DueBoost: overdue +3, due within 24h +2, within 72h +1
+		return `ORDER BY
+			i.priority - CASE
+				WHEN i.due_at IS NULL THEN 0
+				WHEN i.due_at < ? THEN 3
+				WHEN i.due_at < ? THEN 2
+				WHEN i.due_at < ? THEN 1
+				ELSE 0
+			END ASC,
+			CASE WHEN i.due_at IS NULL THEN 1 ELSE 0 END ASC,
+			i.due_at ASC, i.created_at ASC, i.id ASC`,
+			[]interface{}{now, now.Add(types.DueTodayWindow), now.Add(types.DueSoonWindow)}
+	default:
+		cutoff := now.Add(-48 * time.Hour)
+		return `ORDER BY
+			CASE WHEN i.created_at >= ? THEN 0 ELSE 1 END ASC,
+			CASE WHEN i.created_at >= ? THEN i.priority ELSE 0 END ASC,
+			i.created_at ASC, i.id ASC`, []interface{}{cutoff, cutoff}
+	}
+}
+
+// GetBlockedIssues returns issues that are blocked by other issues or have
+// status blocked/deferred. Pinned issues are excluded.
 func (s *DoltStore) GetBlockedIssues(ctx context.Context, filter types.WorkFilter) ([]*types.BlockedIssue, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT i.id, COUNT(d.depends_on_id) as blocked_by_count
+	withSQL, joinSQL := "", ""
+	var args []interface{}
+	if filter.ParentID != nil {
+		withSQL = "WITH RECURSIVE " + descendantsCTE
+		joinSQL = "JOIN descendants ds ON ds.issue_id = i.id"
+		args = append(args, *filter.ParentID)
+	}
+
+	// nolint:gosec // G201: withSQL and joinSQL are constants
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		%s
+		SELECT i.id
 		FROM issues i
-		JOIN dependencies d ON i.id = d.issue_id
-		JOIN issues blocker ON d.depends_on_id = blocker.id
+		%s
 		WHERE i.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-		  AND d.type = 'blocks'
-		  AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-		GROUP BY i.id
-		ORDER BY i.priority ASC, i.created_at DESC
-	`)
+		  AND (i.pinned = 0 OR i.pinned IS NULL)
+		  AND (
+		      i.status IN ('blocked', 'deferred')
+		      OR EXISTS (
+		          SELECT 1 FROM dependencies d
+		          JOIN issues blocker ON d.depends_on_id = blocker.id
+		          WHERE d.issue_id = i.id
+		            AND d.type = 'blocks'
+		            AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
+		      )
+		  )
+		ORDER BY i.priority ASC, i.created_at ASC
+	`, withSQL, joinSQL), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
 	}
 	defer rows.Close()
 
-	var results []*types.BlockedIssue
-	for rows.Next() {
-		var id string
-		var count int
-		if err := rows.Scan(&id, &count); err != nil {
-			return nil, err
-		}
-
-		issue, err := s.GetIssue(ctx, id)
-		if err != nil || issue == nil {
-			continue
-		}
+	issues, err := s.scanIssueIDs(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
 
-		// Get blocker IDs
-		var blockerIDs []string
-		blockerRows, err := s.db.QueryContext(ctx, `
-			SELECT d.depends_on_id
-			FROM dependencies d
-			JOIN issues blocker ON d.depends_on_id = blocker.id
-			WHERE d.issue_id = ?
-			  AND d.type = 'blocks'
-			  AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-		`, id)
+	results := make([]*types.BlockedIssue, 0, len(issues))
+	for _, issue := range issues {
+		blockerIDs, err := s.openBlockerIDs(ctx, issue.ID)
 		if err != nil {
 			return nil, err
 		}
-		for blockerRows.Next() {
-			var blockerID string
-			if err := blockerRows.Scan(&blockerID); err != nil {
-				_ = blockerRows.Close() // nolint:gosec // G104: error ignored on early return
-				return nil, err
-			}
-			blockerIDs = append(blockerIDs, blockerID)
-		}
-		_ = blockerRows.Close() // nolint:gosec // G104: rows already read successfully
-
 		results = append(results, &types.BlockedIssue{
 			Issue:          *issue,
-			BlockedByCount: count,
+			BlockedByCount: len(blockerIDs),
 			BlockedBy:      blockerIDs,
 		})
 	}
 
-	return results, rows.Err()
+	return results, nil
 }
 
-// GetEpicsEligibleForClosure returns epics whose children are all closed
+// openBlockerIDs returns the IDs of open issues blocking issueID.
+func (s *DoltStore) openBlockerIDs(ctx context.Context, issueID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT d.depends_on_id
+		FROM dependencies d
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE d.issue_id = ?
+		  AND d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
+		ORDER BY blocker.priority ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockers: %w", err)
+	}
+	defer rows.Close()
+
+	var blockerIDs []string
+	for rows.Next() {
+		var blockerID string
+		if err := rows.Scan(&blockerID); err != nil {
+			return nil, err
+		}
+		blockerIDs = append(blockerIDs, blockerID)
+	}
+	return blockerIDs, rows.Err()
+}
+
+// GetEpicsEligibleForClosure returns all open epics with their completion status
 func (s *DoltStore) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT e.id,
@@ -369,35 +544,45 @@ func (s *DoltStore) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.Ep
 		WHERE e.issue_type = 'epic'
 		  AND e.status != 'closed'
 		  AND e.status != 'tombstone'
-		HAVING total_children > 0 AND total_children = closed_children
+		ORDER BY e.priority ASC, e.created_at ASC
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get epics eligible for closure: %w", err)
 	}
 	defer rows.Close()
 
-	var results []*types.EpicStatus
+	type epicCounts struct {
+		id            string
+		total, closed int
+	}
+	var counts []epicCounts
 	for rows.Next() {
-		var id string
-		var total, closed int
-		if err := rows.Scan(&id, &total, &closed); err != nil {
+		var c epicCounts
+		if err := rows.Scan(&c.id, &c.total, &c.closed); err != nil {
 			return nil, err
 		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
 
-		issue, err := s.GetIssue(ctx, id)
+	var results []*types.EpicStatus
+	for _, c := range counts {
+		issue, err := s.GetIssue(ctx, c.id)
 		if err != nil || issue == nil {
 			continue
 		}
-
 		results = append(results, &types.EpicStatus{
 			Epic:             issue,
-			TotalChildren:    total,
-			ClosedChildren:   closed,
-			EligibleForClose: total > 0 && total == closed,
+			TotalChildren:    c.total,
+			ClosedChildren:   c.closed,
+			EligibleForClose: c.total > 0 && c.total == c.closed,
 		})
 	}
 
-	return results, rows.Err()
+	return results, nil
 }
 
 // GetStaleIssues returns issues that haven't been updated recently
@@ -2,6 +2,7 @@ package dolt
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -10,12 +11,34 @@ import (
 
 // UpdateIssueID updates an issue ID and all its references
 func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	// Get a dedicated connection so the foreign key setting only applies here;
+	// the referencing rows are rewritten below within the same transaction.
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = conn.ExecContext(context.Background(), "SET FOREIGN_KEY_CHECKS = 1") }()
+
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := renameIssue(ctx, tx, oldID, newID, issue, actor); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// renameIssue moves an issue and every row that references it from oldID
+// to newID. Foreign key checks must be off.
+func renameIssue(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
 	// Update the issue itself
 	result, err := tx.ExecContext(ctx, `
 		UPDATE issues
@@ -63,6 +86,24 @@ func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issu
 		return fmt.Errorf("failed to update comments: %w", err)
 	}
 
+	// Update references in attachments
+	_, err = tx.ExecContext(ctx, `UPDATE attachments SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update attachments: %w", err)
+	}
+
+	// Update references in work logs
+	_, err = tx.ExecContext(ctx, `UPDATE work_logs SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update work logs: %w", err)
+	}
+
+	// Carry the child counter over so new children don't reuse existing numbers
+	_, err = tx.ExecContext(ctx, `UPDATE child_counters SET parent_id = ? WHERE parent_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update child_counters: %w", err)
+	}
+
 	// Update dirty_issues
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
@@ -88,7 +129,7 @@ func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issu
 		return fmt.Errorf("failed to record rename event: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // RenameDependencyPrefix updates the prefix in all dependency records
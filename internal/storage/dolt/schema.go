@@ -49,6 +49,8 @@ CREATE TABLE IF NOT EXISTS issues (
     quality_score DOUBLE,
     -- Federation source system field
     source_system VARCHAR(255) DEFAULT '',
+    source_formula VARCHAR(255) DEFAULT '',
+    source_location VARCHAR(255) DEFAULT '',
     -- Source repo for multi-repo
     source_repo VARCHAR(512) DEFAULT '',
     -- Close reason
@@ -119,6 +121,32 @@ CREATE TABLE IF NOT EXISTS comments (
     CONSTRAINT fk_comments_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
 );
 
+-- Attachments table (metadata only, content is a blob in .beads/blobs/<hash>)
+CREATE TABLE IF NOT EXISTS attachments (
+    issue_id VARCHAR(255) NOT NULL,
+    hash CHAR(64) NOT NULL,
+    name VARCHAR(1024) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    mime_type VARCHAR(255) DEFAULT '',
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, hash),
+    INDEX idx_attachments_hash (hash),
+    CONSTRAINT fk_attachments_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Work logs table (time tracking, ended_at NULL means the timer is running)
+CREATE TABLE IF NOT EXISTS work_logs (
+    issue_id VARCHAR(255) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    note TEXT,
+    PRIMARY KEY (issue_id, actor, started_at),
+    INDEX idx_work_logs_actor (actor),
+    CONSTRAINT fk_work_logs_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Events table (audit trail)
 CREATE TABLE IF NOT EXISTS events (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
@@ -0,0 +1,31 @@
+package dolt
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/storagetest"
+)
+
+func TestStorageConformance(t *testing.T) {
+	skipIfNoDolt(t)
+
+	storagetest.Run(t, storagetest.Backend{
+		Name: "dolt",
+		New: func(t *testing.T) storage.Storage {
+			ctx, cancel := testContext(t)
+			defer cancel()
+
+			store, err := New(ctx, &Config{
+				Path:           t.TempDir(),
+				CommitterName:  "test",
+				CommitterEmail: "test@example.com",
+				Database:       "testdb",
+			})
+			if err != nil {
+				t.Fatalf("failed to create Dolt store: %v", err)
+			}
+			return store
+		},
+	})
+}
@@ -269,6 +269,10 @@ func (s *DoltStore) initSchema(ctx context.Context) error {
 		}
 	}
 
+	if err := s.addMissingIssueColumns(ctx); err != nil {
+		return err
+	}
+
 	// Insert default config values
 	for _, stmt := range splitStatements(defaultConfig) {
 		stmt = strings.TrimSpace(stmt)
@@ -294,6 +298,36 @@ func (s *DoltStore) initSchema(ctx context.Context) error {
 	return nil
 }
 
+// addedIssueColumns are issues columns added after databases were first
+// created. CREATE TABLE IF NOT EXISTS leaves existing tables alone, so they
+// are added explicitly.
+var addedIssueColumns = []struct{ name, definition string }{
+	{"source_formula", "VARCHAR(255) DEFAULT ''"},
+	{"source_location", "VARCHAR(255) DEFAULT ''"},
+}
+
+// addMissingIssueColumns adds addedIssueColumns to an existing issues table.
+func (s *DoltStore) addMissingIssueColumns(ctx context.Context) error {
+	for _, col := range addedIssueColumns {
+		var count int
+		err := s.db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND table_name = 'issues' AND column_name = ?
+		`, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("failed to check %s column: %w", col.name, err)
+		}
+		if count > 0 {
+			continue
+		}
+		// nolint:gosec // G201: column name and definition are constants
+		if _, err := s.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE issues ADD COLUMN %s %s", col.name, col.definition)); err != nil {
+			return fmt.Errorf("failed to add %s column: %w", col.name, err)
+		}
+	}
+	return nil
+}
+
 // splitStatements splits a SQL script into individual statements
 func splitStatements(script string) []string {
 	var statements []string
@@ -11,6 +11,9 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// Verify doltTransaction implements storage.RenamingTransaction at compile time
+var _ storage.RenamingTransaction = (*doltTransaction)(nil)
+
 // doltTransaction implements storage.Transaction for Dolt
 type doltTransaction struct {
 	tx    *sql.Tx
@@ -54,7 +57,28 @@ func (t *doltTransaction) CreateIssue(ctx context.Context, issue *types.Issue, a
 		issue.ContentHash = issue.ComputeContentHash()
 	}
 
-	return insertIssueTx(ctx, t.tx, issue)
+	if issue.ID == "" {
+		prefix, err := t.GetConfig(ctx, "issue_prefix")
+		if err != nil {
+			return fmt.Errorf("failed to get config: %w", err)
+		}
+		if prefix == "" {
+			return fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
+		}
+		generatedID, err := generateIssueID(ctx, t.tx, prefix, issue, actor)
+		if err != nil {
+			return fmt.Errorf("failed to generate issue ID: %w", err)
+		}
+		issue.ID = generatedID
+	}
+
+	if err := insertIssueTx(ctx, t.tx, issue); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, t.tx, issue.ID, types.EventCreated, actor, "", ""); err != nil {
+		return fmt.Errorf("failed to record creation event: %w", err)
+	}
+	return markDirty(ctx, t.tx, issue.ID)
 }
 
 // CreateIssues creates multiple issues within the transaction
@@ -87,6 +111,8 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 	if filter.Status != nil {
 		whereClauses = append(whereClauses, "status = ?")
 		args = append(args, *filter.Status)
+	} else if !filter.IncludeTombstones {
+		whereClauses = append(whereClauses, "status != 'tombstone'")
 	}
 
 	whereSQL := ""
@@ -102,12 +128,22 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 	}
 	defer rows.Close()
 
-	var issues []*types.Issue
+	// Collect IDs before fetching so the result set is closed first
+	var ids []string
 	for rows.Next() {
 		var id string
 		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+
+	var issues []*types.Issue
+	for _, id := range ids {
 		issue, err := t.GetIssue(ctx, id)
 		if err != nil {
 			return nil, err
@@ -116,7 +152,7 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 			issues = append(issues, issue)
 		}
 	}
-	return issues, rows.Err()
+	return issues, nil
 }
 
 // UpdateIssue updates an issue within the transaction
@@ -133,7 +169,7 @@ func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 			columnName = "ephemeral"
 		}
 		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", columnName))
-		args = append(args, value)
+		args = append(args, updateColumnValue(key, value))
 	}
 
 	args = append(args, id)
@@ -150,7 +186,20 @@ func (t *doltTransaction) CloseIssue(ctx context.Context, id string, reason stri
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?, closed_by_session = ?
 		WHERE id = ?
 	`, types.StatusClosed, now, now, reason, session, id)
-	return err
+	if err != nil {
+		return err
+	}
+	return cascadeClose(ctx, t.tx, id, actor, session)
+}
+
+// UpdateIssueID renames an issue and its references within the transaction
+func (t *doltTransaction) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	// Foreign key checks are per session, and the transaction holds its own
+	if _, err := t.tx.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = t.tx.ExecContext(context.Background(), "SET FOREIGN_KEY_CHECKS = 1") }()
+	return renameIssue(ctx, t.tx, oldID, newID, issue, actor)
 }
 
 // DeleteIssue deletes an issue within the transaction
@@ -0,0 +1,142 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddWorkLog records or updates a work log entry on an issue
+func (s *DoltStore) AddWorkLog(ctx context.Context, issueID string, entry *types.WorkLog, actor string) error {
+	if entry == nil || entry.StartedAt.IsZero() {
+		return fmt.Errorf("work log start time is required")
+	}
+	if entry.Actor == "" {
+		entry.Actor = actor
+	}
+	entry.StartedAt = entry.StartedAt.UTC().Truncate(time.Second)
+	if entry.EndedAt != nil {
+		ended := entry.EndedAt.UTC().Truncate(time.Second)
+		if ended.Before(entry.StartedAt) {
+			return fmt.Errorf("work log ends before it starts")
+		}
+		entry.EndedAt = &ended
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	var endedAt sql.NullTime
+	var note sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT ended_at, note FROM work_logs
+		WHERE issue_id = ? AND actor = ? AND started_at = ?
+	`, issueID, entry.Actor, entry.StartedAt).Scan(&endedAt, &note)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return fmt.Errorf("failed to check existing work log: %w", err)
+	default:
+		if entry.EndedAt == nil && endedAt.Valid {
+			t := endedAt.Time.UTC()
+			entry.EndedAt = &t
+		}
+		if entry.Note == "" {
+			entry.Note = note.String
+		}
+		if endedAt.Valid == (entry.EndedAt != nil) &&
+			(!endedAt.Valid || endedAt.Time.Equal(*entry.EndedAt)) &&
+			note.String == entry.Note {
+			return nil
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO work_logs (issue_id, actor, started_at, ended_at, note)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE ended_at = VALUES(ended_at), note = VALUES(note)
+	`, issueID, entry.Actor, entry.StartedAt, entry.EndedAt, entry.Note); err != nil {
+		return fmt.Errorf("failed to add work log: %w", err)
+	}
+	newValue := "started"
+	if entry.EndedAt != nil {
+		newValue = entry.EndedAt.Sub(entry.StartedAt).Round(time.Minute).String()
+	}
+	if err := recordEvent(ctx, tx, issueID, types.EventWorkLogged, actor, "", newValue); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, tx, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetWorkLogs retrieves the work log entries on an issue
+func (s *DoltStore) GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error) {
+	result, err := s.GetWorkLogsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetWorkLogsForIssues retrieves work logs for multiple issues
+func (s *DoltStore) GetWorkLogsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLog, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.WorkLog), nil
+	}
+
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT issue_id, actor, started_at, ended_at, note
+		FROM work_logs
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, started_at ASC, actor ASC
+	`, joinStrings(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*types.WorkLog)
+	for rows.Next() {
+		var issueID string
+		var endedAt sql.NullTime
+		var note sql.NullString
+		w := &types.WorkLog{}
+		if err := rows.Scan(&issueID, &w.Actor, &w.StartedAt, &endedAt, &note); err != nil {
+			return nil, fmt.Errorf("failed to scan work log: %w", err)
+		}
+		w.StartedAt = w.StartedAt.UTC()
+		if endedAt.Valid {
+			t := endedAt.Time.UTC()
+			w.EndedAt = &t
+		}
+		w.Note = note.String
+		result[issueID] = append(result[issueID], w)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Default depth limits for knowledge graph queries.
+const (
+	DefaultNeighborhoodDepth = 2
+	DefaultPathDepth         = 10
+)
+
+// GraphHop is one step of a breadth-first walk over dependency edges,
+// treating every edge as undirected. Backends produce hops (sqlite and dolt
+// with a recursive CTE) and share the reduction to neighbors and paths below.
+type GraphHop struct {
+	ID      string               // Issue reached
+	Depth   int                  // Edges from the start issue
+	PrevID  string               // Issue the edge was walked from
+	Type    types.DependencyType // Type of the edge
+	Reverse bool                 // Edge was walked from depends_on_id to issue_id
+}
+
+// NearestHops keeps the shortest hop to each issue, excluding the start.
+// Ties are broken by predecessor ID and edge type so results are stable.
+func NearestHops(startID string, hops []GraphHop) []GraphHop {
+	sorted := make([]GraphHop, len(hops))
+	copy(sorted, hops)
+	sortHops(sorted)
+
+	seen := map[string]bool{startID: true}
+	var nearest []GraphHop
+	for _, h := range sorted {
+		if seen[h.ID] {
+			continue
+		}
+		seen[h.ID] = true
+		nearest = append(nearest, h)
+	}
+	return nearest
+}
+
+// PathFromHops reconstructs a shortest path from startID to targetID.
+// It returns nil if targetID was not reached, and an empty path if the
+// two are the same issue.
+func PathFromHops(startID, targetID string, hops []GraphHop) []*types.GraphPathStep {
+	if startID == targetID {
+		return []*types.GraphPathStep{}
+	}
+	best := make(map[string]GraphHop)
+	for _, h := range NearestHops(startID, hops) {
+		best[h.ID] = h
+	}
+	if _, ok := best[targetID]; !ok {
+		return nil
+	}
+
+	// Every shortest hop's predecessor was itself reached one edge earlier,
+	// so walking predecessors back always terminates at the start.
+	var path []*types.GraphPathStep
+	for id := targetID; id != startID; {
+		h := best[id]
+		path = append(path, &types.GraphPathStep{FromID: h.PrevID, ToID: h.ID, Type: h.Type, Reverse: h.Reverse})
+		id = h.PrevID
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// WalkGraph performs the hop walk in memory over a full edge list. It is
+// used by backends without recursive SQL.
+func WalkGraph(startID string, maxDepth int, edgeTypes []types.DependencyType, deps []*types.Dependency) []GraphHop {
+	allowed := make(map[types.DependencyType]bool)
+	for _, t := range edgeTypes {
+		allowed[t] = true
+	}
+	adjacent := make(map[string][]GraphHop)
+	for _, d := range deps {
+		if len(allowed) > 0 && !allowed[d.Type] {
+			continue
+		}
+		adjacent[d.IssueID] = append(adjacent[d.IssueID], GraphHop{ID: d.DependsOnID, PrevID: d.IssueID, Type: d.Type})
+		adjacent[d.DependsOnID] = append(adjacent[d.DependsOnID], GraphHop{ID: d.IssueID, PrevID: d.DependsOnID, Type: d.Type, Reverse: true})
+	}
+
+	var hops []GraphHop
+	visited := map[string]bool{startID: true}
+	frontier := []string{startID}
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		var next []string
+		for _, id := range frontier {
+			for _, h := range adjacent[id] {
+				h.Depth = depth
+				hops = append(hops, h)
+				if !visited[h.ID] {
+					visited[h.ID] = true
+					next = append(next, h.ID)
+				}
+			}
+		}
+		frontier = next
+	}
+	return hops
+}
+
+func sortHops(hops []GraphHop) {
+	sort.SliceStable(hops, func(i, j int) bool {
+		a, b := hops[i], hops[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		if a.PrevID != b.PrevID {
+			return a.PrevID < b.PrevID
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return !a.Reverse && b.Reverse
+	})
+}
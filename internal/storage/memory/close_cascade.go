@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// cascadeTarget is an issue a close cascades to, and why.
+type cascadeTarget struct {
+	id     string
+	reason string
+}
+
+// cascadeClose closes the issues that follow from closing id under the
+// close.cascade-* rules, as the SQLite backend does. Each issue the cascade
+// closes gets a caused-by edge to the issue that triggered it, and its own
+// close is cascaded in turn. The caller must hold the write lock.
+func (m *MemoryStorage) cascadeClose(id, actor, session string) {
+	rules := config.GetCloseCascadeConfig()
+	if !rules.Enabled() {
+		return
+	}
+	queue := []string{id}
+	for len(queue) > 0 {
+		trigger := queue[0]
+		queue = queue[1:]
+		for _, target := range m.findCascadeTargets(trigger, rules) {
+			if m.closeByCascade(target, trigger, actor, session) {
+				queue = append(queue, target.id)
+			}
+		}
+	}
+}
+
+// findCascadeTargets returns the issues that closing trigger cascades to.
+// The caller must hold at least a read lock.
+func (m *MemoryStorage) findCascadeTargets(trigger string, rules config.CloseCascadeConfig) []cascadeTarget {
+	var targets []cascadeTarget
+	seen := make(map[string]bool)
+	add := func(reason string, ids []string) {
+		sort.Strings(ids)
+		for _, id := range ids {
+			if !seen[id] && m.isCascadeCandidate(id) {
+				seen[id] = true
+				targets = append(targets, cascadeTarget{id: id, reason: reason})
+			}
+		}
+	}
+
+	// Parents of trigger over depType with no open children left
+	lastOpenChildOf := func(depType types.DependencyType, epicsOnly bool) []string {
+		var ids []string
+		for _, dep := range m.dependencies[trigger] {
+			if dep.Type != depType {
+				continue
+			}
+			parent, ok := m.issues[dep.DependsOnID]
+			if !ok || (epicsOnly && parent.IssueType != types.TypeEpic) || m.hasOpenDependents(parent.ID, depType) {
+				continue
+			}
+			ids = append(ids, parent.ID)
+		}
+		return ids
+	}
+	if rules.Epics {
+		add("All children closed", lastOpenChildOf(types.DepParentChild, true))
+	}
+	if rules.Delegated {
+		add("Delegated work completed", lastOpenChildOf(types.DepDelegatedFrom, false))
+	}
+	if rules.Until {
+		// Issues only active until the trigger closed
+		var ids []string
+		for issueID, deps := range m.dependencies {
+			for _, dep := range deps {
+				if dep.DependsOnID == trigger && dep.Type == types.DepUntil {
+					ids = append(ids, issueID)
+					break
+				}
+			}
+		}
+		add("Until condition met", ids)
+	}
+	return targets
+}
+
+// hasOpenDependents reports whether any issue still open depends on id over
+// depType. The caller must hold at least a read lock.
+func (m *MemoryStorage) hasOpenDependents(id string, depType types.DependencyType) bool {
+	for issueID, deps := range m.dependencies {
+		for _, dep := range deps {
+			if dep.DependsOnID != id || dep.Type != depType {
+				continue
+			}
+			if issue, ok := m.issues[issueID]; ok && issue.Status != types.StatusClosed && issue.Status != types.StatusTombstone {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isCascadeCandidate restricts cascade targets to issues a plain bd close
+// would accept: open, not pinned, not a template and not blocked. The caller
+// must hold at least a read lock.
+func (m *MemoryStorage) isCascadeCandidate(id string) bool {
+	issue, ok := m.issues[id]
+	if !ok || issue.IsTemplate {
+		return false
+	}
+	switch issue.Status {
+	case types.StatusClosed, types.StatusTombstone, types.StatusPinned:
+		return false
+	}
+	return !m.isBlockedTransitively(id)
+}
+
+// closeByCascade closes target because trigger closed and links it to
+// trigger with a caused-by edge. It reports false if target is already
+// closed. The caller must hold the write lock.
+func (m *MemoryStorage) closeByCascade(target cascadeTarget, trigger, actor, session string) bool {
+	issue, ok := m.issues[target.id]
+	if !ok || issue.Status == types.StatusClosed {
+		return false
+	}
+	now := time.Now()
+	reason := storage.CascadeReason(target.reason, trigger)
+	issue.Status = types.StatusClosed
+	issue.ClosedAt = &now
+	issue.UpdatedAt = now
+	issue.CloseReason = reason
+	issue.ClosedBySession = session
+
+	// The cascade event is stamped with the close time so
+	// storage.CascadedCloses can tell it from an earlier, since reopened, one
+	m.events[target.id] = append(m.events[target.id],
+		&types.Event{IssueID: target.id, EventType: types.EventClosed, Actor: actor, Comment: &reason, CreatedAt: now},
+		&types.Event{IssueID: target.id, EventType: types.EventCascadeClosed, Actor: actor, NewValue: &trigger, CreatedAt: now},
+	)
+
+	// The edge may already exist if someone recorded it by hand
+	exists := false
+	for _, dep := range m.dependencies[target.id] {
+		exists = exists || (dep.DependsOnID == trigger && dep.Type == types.DepCausedBy)
+	}
+	if !exists {
+		m.dependencies[target.id] = append(m.dependencies[target.id], &types.Dependency{
+			IssueID: target.id, DependsOnID: trigger, Type: types.DepCausedBy, CreatedAt: now, CreatedBy: actor,
+		})
+	}
+
+	m.dirty[target.id] = true
+	return true
+}
@@ -6,6 +6,7 @@ package memory
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -27,6 +28,8 @@ type MemoryStorage struct {
 	labels       map[string][]string            // IssueID -> Labels
 	events       map[string][]*types.Event      // IssueID -> Events
 	comments     map[string][]*types.Comment    // IssueID -> Comments
+	attachments  map[string][]*types.Attachment // IssueID -> Attachments
+	workLogs     map[string][]*types.WorkLog    // IssueID -> Work logs
 	config       map[string]string              // Config key-value pairs
 	metadata     map[string]string              // Metadata key-value pairs
 	counters     map[string]int                 // Prefix -> Last ID
@@ -49,6 +52,8 @@ func New(jsonlPath string) *MemoryStorage {
 		labels:          make(map[string][]string),
 		events:          make(map[string][]*types.Event),
 		comments:        make(map[string][]*types.Comment),
+		attachments:     make(map[string][]*types.Attachment),
+		workLogs:        make(map[string][]*types.WorkLog),
 		config:          make(map[string]string),
 		metadata:        make(map[string]string),
 		counters:        make(map[string]int),
@@ -92,6 +97,16 @@ func (m *MemoryStorage) LoadFromIssues(issues []*types.Issue) error {
 			m.comments[issue.ID] = issue.Comments
 		}
 
+		// Store attachment metadata
+		if len(issue.Attachments) > 0 {
+			m.attachments[issue.ID] = issue.Attachments
+		}
+
+		// Store work logs
+		if len(issue.WorkLogs) > 0 {
+			m.workLogs[issue.ID] = issue.WorkLogs
+		}
+
 		// Update counter based on issue ID
 		prefix, num := extractPrefixAndNumber(issue.ID)
 		if prefix != "" && num > 0 {
@@ -137,6 +152,19 @@ func (m *MemoryStorage) GetAllIssues() []*types.Issue {
 			issueCopy.Comments = comments
 		}
 
+		// Attach attachment metadata
+		if attachments, ok := m.attachments[issue.ID]; ok {
+			issueCopy.Attachments = attachments
+		}
+		if workLogs, ok := m.workLogs[issue.ID]; ok {
+			issueCopy.WorkLogs = workLogs
+		}
+
+		// Attach work logs
+		if workLogs, ok := m.workLogs[issue.ID]; ok {
+			issueCopy.WorkLogs = workLogs
+		}
+
 		issues = append(issues, &issueCopy)
 	}
 
@@ -204,10 +232,14 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	// Set timestamps
+	// Set timestamps, preserving explicit values (imports, tests)
 	now := time.Now()
-	issue.CreatedAt = now
-	issue.UpdatedAt = now
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = now
+	}
+	if issue.UpdatedAt.IsZero() {
+		issue.UpdatedAt = now
+	}
 
 	// Generate ID if not set
 	if issue.ID == "" {
@@ -279,8 +311,12 @@ func (m *MemoryStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 
 	// Generate IDs for issues that need them
 	for _, issue := range issues {
-		issue.CreatedAt = now
-		issue.UpdatedAt = now
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = now
+		}
+		if issue.UpdatedAt.IsZero() {
+			issue.UpdatedAt = now
+		}
 
 		if issue.ID == "" {
 			m.counters[prefix]++
@@ -470,6 +506,10 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			if v, ok := value.(string); ok {
 				issue.ClosedBySession = v
 			}
+		case "waiters":
+			if v, ok := value.([]string); ok {
+				issue.Waiters = v
+			}
 		}
 	}
 
@@ -504,7 +544,15 @@ func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, reason string
 	if session != "" {
 		updates["closed_by_session"] = session
 	}
-	return m.UpdateIssue(ctx, id, updates, actor)
+	if err := m.UpdateIssue(ctx, id, updates, actor); err != nil {
+		return err
+	}
+
+	// Closes that follow from this one (close.cascade-* in config.yaml)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cascadeClose(id, actor, session)
+	return nil
 }
 
 // CreateTombstone converts an existing issue to a tombstone record.
@@ -561,11 +609,25 @@ func (m *MemoryStorage) DeleteIssue(ctx context.Context, id string) error {
 	// Delete the issue
 	delete(m.issues, id)
 
-	// Delete associated data
+	// Delete associated data, including edges from other issues
 	delete(m.dependencies, id)
+	for issueID, deps := range m.dependencies {
+		kept := deps[:0]
+		for _, dep := range deps {
+			if dep.DependsOnID != id {
+				kept = append(kept, dep)
+			}
+		}
+		if len(kept) != len(deps) {
+			m.dependencies[issueID] = kept
+			m.dirty[issueID] = true
+		}
+	}
 	delete(m.labels, id)
 	delete(m.events, id)
 	delete(m.comments, id)
+	delete(m.attachments, id)
+	delete(m.workLogs, id)
 	delete(m.dirty, id)
 
 	return nil
@@ -583,6 +645,31 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		if filter.Status != nil && issue.Status != *filter.Status {
 			continue
 		}
+		// Exclude tombstones unless requested or filtering by status explicitly
+		if filter.Status == nil && !filter.IncludeTombstones && issue.Status == types.StatusTombstone {
+			continue
+		}
+		if containsStatus(filter.ExcludeStatus, issue.Status) {
+			continue
+		}
+		if containsType(filter.ExcludeTypes, issue.IssueType) {
+			continue
+		}
+		if filter.Ephemeral != nil && issue.Ephemeral != *filter.Ephemeral {
+			continue
+		}
+		if filter.Pinned != nil && issue.Pinned != *filter.Pinned {
+			continue
+		}
+		if filter.IsTemplate != nil && issue.IsTemplate != *filter.IsTemplate {
+			continue
+		}
+		if filter.MolType != nil && issue.MolType != *filter.MolType {
+			continue
+		}
+		if filter.SourceRepo != nil && !matchesSourceRepo(issue, *filter.SourceRepo) {
+			continue
+		}
 		if filter.Priority != nil && issue.Priority != *filter.Priority {
 			continue
 		}
@@ -625,6 +712,11 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			}
 		}
 
+		// Label filtering: must have AT LEAST ONE of the specified labels
+		if len(filter.LabelsAny) > 0 && !hasAnyLabel(m.labels[issue.ID], filter.LabelsAny) {
+			continue
+		}
+
 		// ID filtering
 		if len(filter.IDs) > 0 {
 			found := false
@@ -693,6 +785,10 @@ func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if dep.IssueID == dep.DependsOnID {
+		return fmt.Errorf("issue cannot depend on itself")
+	}
+
 	// Check that both issues exist
 	if _, exists := m.issues[dep.IssueID]; !exists {
 		return fmt.Errorf("issue %s not found", dep.IssueID)
@@ -968,6 +1064,61 @@ func (m *MemoryStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, err
 	return nil, nil
 }
 
+// GetNeighborhood returns the issues within maxDepth edges of issueID,
+// walking dependencies in both directions, nearest first.
+func (m *MemoryStorage) GetNeighborhood(ctx context.Context, issueID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphNeighbor, error) {
+	if maxDepth <= 0 {
+		maxDepth = storage.DefaultNeighborhoodDepth
+	}
+	hops, err := m.walkGraph(ctx, issueID, maxDepth, edgeTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbors []*types.GraphNeighbor
+	for _, h := range storage.NearestHops(issueID, hops) {
+		issue, err := m.GetIssue(ctx, h.ID)
+		if err != nil {
+			return nil, err
+		}
+		if issue == nil || issue.Status == types.StatusTombstone {
+			continue // external reference or deleted issue
+		}
+		neighbors = append(neighbors, &types.GraphNeighbor{
+			Issue:    *issue,
+			Distance: h.Depth,
+			Via:      h.Type,
+			ViaID:    h.PrevID,
+		})
+	}
+	return neighbors, nil
+}
+
+// FindPath returns a shortest path of typed edges from fromID to toID,
+// or nil if none exists within maxDepth edges.
+func (m *MemoryStorage) FindPath(ctx context.Context, fromID, toID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphPathStep, error) {
+	if maxDepth <= 0 {
+		maxDepth = storage.DefaultPathDepth
+	}
+	hops, err := m.walkGraph(ctx, fromID, maxDepth, edgeTypes)
+	if err != nil {
+		return nil, err
+	}
+	return storage.PathFromHops(fromID, toID, hops), nil
+}
+
+func (m *MemoryStorage) walkGraph(ctx context.Context, startID string, maxDepth int, edgeTypes []types.DependencyType) ([]storage.GraphHop, error) {
+	records, err := m.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deps []*types.Dependency
+	for _, recs := range records {
+		deps = append(deps, recs...)
+	}
+	return storage.WalkGraph(startID, maxDepth, edgeTypes, deps), nil
+}
+
 // Add label methods
 func (m *MemoryStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
 	m.mu.Lock()
@@ -1050,11 +1201,42 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 	return results, nil
 }
 
+// agentCapabilities returns the capabilities of an agent bead. Caller must
+// hold the lock.
+func (m *MemoryStorage) agentCapabilities(agentID string) map[string]bool {
+	var skills []string
+	for _, deps := range m.dependencies {
+		for _, dep := range deps {
+			if dep.DependsOnID != agentID || dep.Type != types.DepAttests || dep.Metadata == "" {
+				continue
+			}
+			var meta types.AttestsMeta
+			if err := json.Unmarshal([]byte(dep.Metadata), &meta); err == nil {
+				skills = append(skills, meta.Skill)
+			}
+		}
+	}
+	return types.AgentCapabilities(m.issues[agentID], m.labels[agentID], skills)
+}
+
 // GetReadyWork returns issues that are ready to work on (no open blockers)
 func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	// Build set of descendant IDs if parent filter is specified
+	var descendantIDs map[string]bool
+	if filter.ParentID != nil {
+		descendantIDs = m.getAllDescendants(*filter.ParentID)
+	}
+
+	// Capabilities of the agent for capability routing
+	var agentCaps map[string]bool
+	if filter.ForAgent != "" {
+		agentCaps = m.agentCapabilities(filter.ForAgent)
+	}
+
+	now := time.Now()
 	var results []*types.Issue
 
 	for _, issue := range m.issues {
@@ -1063,6 +1245,28 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			continue
 		}
 
+		// Skip wisps, matching SQLite's ephemeral and wisp-ID exclusion
+		if issue.Ephemeral || strings.Contains(issue.ID, "-wisp-") {
+			continue
+		}
+
+		// Skip issues deferred into the future (GH#820)
+		if !filter.IncludeDeferred && issue.DeferUntil != nil && issue.DeferUntil.After(now) {
+			continue
+		}
+
+		// Parent filtering: only include descendants of specified parent
+		if descendantIDs != nil && !descendantIDs[issue.ID] {
+			continue
+		}
+
+		if filter.MolType != nil && issue.MolType != *filter.MolType {
+			continue
+		}
+		if filter.SourceRepo != nil && !matchesSourceRepo(issue, *filter.SourceRepo) {
+			continue
+		}
+
 		// Status filtering: default to open OR in_progress if not specified
 		if filter.Status == "" {
 			if issue.Status != types.StatusOpen && issue.Status != types.StatusInProgress {
@@ -1087,7 +1291,7 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			// These are internal workflow items, not work for polecats to claim
 			// (Gas Town types - not built into beads core)
 			switch issue.IssueType {
-			case "merge-request", "gate", "molecule", "message":
+			case "merge-request", "gate", "molecule", "message", "agent", "role", "rig":
 				continue
 			}
 		}
@@ -1145,8 +1349,22 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			}
 		}
 
-		// Skip issues with any open 'blocks' dependencies
-		if len(m.getOpenBlockers(issue.ID)) > 0 {
+		// Capability routing: the agent must have every needs:<cap>
+		if agentCaps != nil {
+			qualified := true
+			for _, c := range types.RequiredCapabilities(m.labels[issue.ID]) {
+				if !agentCaps[c] {
+					qualified = false
+					break
+				}
+			}
+			if !qualified {
+				continue
+			}
+		}
+
+		// Skip issues with open 'blocks' dependencies, directly or via an ancestor
+		if m.isBlockedTransitively(issue.ID) {
 			continue
 		}
 
@@ -1160,6 +1378,12 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		if comments, ok := m.comments[issue.ID]; ok {
 			issueCopy.Comments = comments
 		}
+		if attachments, ok := m.attachments[issue.ID]; ok {
+			issueCopy.Attachments = attachments
+		}
+		if workLogs, ok := m.workLogs[issue.ID]; ok {
+			issueCopy.WorkLogs = workLogs
+		}
 
 		results = append(results, &issueCopy)
 	}
@@ -1170,23 +1394,44 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		sortPolicy = types.SortPolicyHybrid
 	}
 
+	// Sort by ID first so ties resolve deterministically despite map iteration
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+
 	switch sortPolicy {
 	case types.SortPolicyOldest:
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			return results[i].CreatedAt.Before(results[j].CreatedAt)
 		})
 	case types.SortPolicyPriority:
-		sort.Slice(results, func(i, j int) bool {
+		sort.SliceStable(results, func(i, j int) bool {
 			if results[i].Priority != results[j].Priority {
 				return results[i].Priority < results[j].Priority
 			}
 			return results[i].CreatedAt.Before(results[j].CreatedAt)
 		})
+	case types.SortPolicyDue:
+		sort.SliceStable(results, func(i, j int) bool {
+			pi := results[i].Priority - types.DueBoost(results[i].DueAt, now)
+			pj := results[j].Priority - types.DueBoost(results[j].DueAt, now)
+			if pi != pj {
+				return pi < pj
+			}
+			di, dj := results[i].DueAt, results[j].DueAt
+			if (di == nil) != (dj == nil) {
+				return di != nil
+			}
+			if di != nil && !di.Equal(*dj) {
+				return di.Before(*dj)
+			}
+			return results[i].CreatedAt.Before(results[j].CreatedAt)
+		})
 	case types.SortPolicyHybrid:
 		fallthrough
 	default:
-		cutoff := time.Now().Add(-48 * time.Hour)
-		sort.Slice(results, func(i, j int) bool {
+		cutoff := now.Add(-48 * time.Hour)
+		sort.SliceStable(results, func(i, j int) bool {
 			iRecent := results[i].CreatedAt.After(cutoff)
 			jRecent := results[j].CreatedAt.After(cutoff)
 			if iRecent != jRecent {
@@ -1201,6 +1446,13 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		})
 	}
 
+	// Best match first: work that uses more of the agent's capabilities
+	if agentCaps != nil {
+		sort.SliceStable(results, func(i, j int) bool {
+			return len(types.RequiredCapabilities(results[i].Labels)) > len(types.RequiredCapabilities(results[j].Labels))
+		})
+	}
+
 	// Apply limit
 	if filter.Limit > 0 && len(results) > filter.Limit {
 		results = results[:filter.Limit]
@@ -1238,6 +1490,30 @@ func (m *MemoryStorage) getOpenBlockers(issueID string) []string {
 	return blockers
 }
 
+// isBlockedTransitively reports whether an issue has open blockers itself or
+// inherits blockage from a parent, matching SQLite's blocked_issues_cache.
+// The caller must hold at least a read lock.
+func (m *MemoryStorage) isBlockedTransitively(issueID string) bool {
+	seen := make(map[string]bool)
+	var walk func(id string, depth int) bool
+	walk = func(id string, depth int) bool {
+		if seen[id] || depth > 50 {
+			return false
+		}
+		seen[id] = true
+		if len(m.getOpenBlockers(id)) > 0 {
+			return true
+		}
+		for _, dep := range m.dependencies[id] {
+			if dep.Type == types.DepParentChild && walk(dep.DependsOnID, depth+1) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(issueID, 0)
+}
+
 // GetBlockedIssues returns issues that are blocked by other issues
 // Note: Pinned issues are excluded from the output (beads-ei4)
 func (m *MemoryStorage) GetBlockedIssues(ctx context.Context, filter types.WorkFilter) ([]*types.BlockedIssue, error) {
@@ -1284,6 +1560,12 @@ func (m *MemoryStorage) GetBlockedIssues(ctx context.Context, filter types.WorkF
 		if comments, ok := m.comments[issue.ID]; ok {
 			issueCopy.Comments = comments
 		}
+		if attachments, ok := m.attachments[issue.ID]; ok {
+			issueCopy.Attachments = attachments
+		}
+		if workLogs, ok := m.workLogs[issue.ID]; ok {
+			issueCopy.WorkLogs = workLogs
+		}
 
 		results = append(results, &types.BlockedIssue{
 			Issue:          issueCopy,
@@ -1337,8 +1619,55 @@ func (m *MemoryStorage) collectDescendants(parentID string, descendants map[stri
 	}
 }
 
+// GetEpicsEligibleForClosure returns all non-closed epics with their completion status
 func (m *MemoryStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
-	return nil, nil
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*types.EpicStatus
+	for _, issue := range m.issues {
+		if issue.IssueType != types.TypeEpic || issue.Status == types.StatusClosed {
+			continue
+		}
+		total, closed := 0, 0
+		for childID := range m.getChildren(issue.ID) {
+			total++
+			if child, ok := m.issues[childID]; ok && child.Status == types.StatusClosed {
+				closed++
+			}
+		}
+		epicCopy := *issue
+		results = append(results, &types.EpicStatus{
+			Epic:             &epicCopy,
+			TotalChildren:    total,
+			ClosedChildren:   closed,
+			EligibleForClose: total > 0 && closed == total,
+		})
+	}
+
+	// Match SQLite ordering: priority, then creation time
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Epic.Priority != results[j].Epic.Priority {
+			return results[i].Epic.Priority < results[j].Epic.Priority
+		}
+		return results[i].Epic.CreatedAt.Before(results[j].Epic.CreatedAt)
+	})
+
+	return results, nil
+}
+
+// getChildren returns the direct children of a parent issue.
+// The caller must hold at least a read lock.
+func (m *MemoryStorage) getChildren(parentID string) map[string]bool {
+	children := make(map[string]bool)
+	for issueID, deps := range m.dependencies {
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild && dep.DependsOnID == parentID {
+				children[issueID] = true
+			}
+		}
+	}
+	return children
 }
 
 func (m *MemoryStorage) GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error) {
@@ -1408,17 +1737,19 @@ func (m *MemoryStorage) GetNewlyUnblockedByClose(ctx context.Context, closedIssu
 			continue
 		}
 
-		// Check if now unblocked (no remaining open blockers)
-		blockers := m.getOpenBlockers(issueID)
-		if len(blockers) == 0 {
+		// Check if now unblocked (no remaining blockers, including inherited ones)
+		if !m.isBlockedTransitively(issueID) {
 			issueCopy := *issue
 			unblocked = append(unblocked, &issueCopy)
 		}
 	}
 
-	// Sort by priority ascending
+	// Sort by priority ascending, ID for stable ties
 	sort.Slice(unblocked, func(i, j int) bool {
-		return unblocked[i].Priority < unblocked[j].Priority
+		if unblocked[i].Priority != unblocked[j].Priority {
+			return unblocked[i].Priority < unblocked[j].Priority
+		}
+		return unblocked[i].ID < unblocked[j].ID
 	})
 
 	return unblocked, nil
@@ -1478,6 +1809,142 @@ func (m *MemoryStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 	return result, nil
 }
 
+func (m *MemoryStorage) AddAttachment(ctx context.Context, issueID string, attachment *types.Attachment, actor string) error {
+	if attachment == nil || attachment.Hash == "" {
+		return fmt.Errorf("attachment hash is required")
+	}
+	if attachment.Name == "" {
+		return fmt.Errorf("attachment name is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[issueID]; !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	if attachment.CreatedBy == "" {
+		attachment.CreatedBy = actor
+	}
+
+	stored := *attachment
+	for i, existing := range m.attachments[issueID] {
+		if existing.Hash == attachment.Hash {
+			stored.CreatedAt = existing.CreatedAt
+			stored.CreatedBy = existing.CreatedBy
+			m.attachments[issueID][i] = &stored
+			m.dirty[issueID] = true
+			return nil
+		}
+	}
+	m.attachments[issueID] = append(m.attachments[issueID], &stored)
+	m.dirty[issueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) RemoveAttachment(ctx context.Context, issueID, hash, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attachments := m.attachments[issueID]
+	for i, a := range attachments {
+		if a.Hash == hash {
+			m.attachments[issueID] = append(attachments[:i:i], attachments[i+1:]...)
+			m.dirty[issueID] = true
+			return nil
+		}
+	}
+	return fmt.Errorf("attachment %s not found on %s", hash, issueID)
+}
+
+func (m *MemoryStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.attachments[issueID], nil
+}
+
+func (m *MemoryStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.Attachment)
+	for _, issueID := range issueIDs {
+		if attachments, exists := m.attachments[issueID]; exists && len(attachments) > 0 {
+			result[issueID] = attachments
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) AddWorkLog(ctx context.Context, issueID string, entry *types.WorkLog, actor string) error {
+	if entry == nil || entry.StartedAt.IsZero() {
+		return fmt.Errorf("work log start time is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[issueID]; !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	if entry.Actor == "" {
+		entry.Actor = actor
+	}
+	entry.StartedAt = entry.StartedAt.UTC().Truncate(time.Second)
+	if entry.EndedAt != nil {
+		ended := entry.EndedAt.UTC().Truncate(time.Second)
+		if ended.Before(entry.StartedAt) {
+			return fmt.Errorf("work log ends before it starts")
+		}
+		entry.EndedAt = &ended
+	}
+
+	stored := *entry
+	for i, existing := range m.workLogs[issueID] {
+		if existing.Actor == entry.Actor && existing.StartedAt.Equal(entry.StartedAt) {
+			if stored.EndedAt == nil {
+				stored.EndedAt = existing.EndedAt
+			}
+			if stored.Note == "" {
+				stored.Note = existing.Note
+			}
+			m.workLogs[issueID][i] = &stored
+			m.dirty[issueID] = true
+			return nil
+		}
+	}
+	m.workLogs[issueID] = append(m.workLogs[issueID], &stored)
+	sort.SliceStable(m.workLogs[issueID], func(i, j int) bool {
+		return m.workLogs[issueID][i].StartedAt.Before(m.workLogs[issueID][j].StartedAt)
+	})
+	m.dirty[issueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.workLogs[issueID], nil
+}
+
+func (m *MemoryStorage) GetWorkLogsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLog, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.WorkLog)
+	for _, issueID := range issueIDs {
+		if workLogs, exists := m.workLogs[issueID]; exists && len(workLogs) > 0 {
+			result[issueID] = workLogs
+		}
+	}
+	return result, nil
+}
+
 func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -1831,3 +2298,48 @@ func (m *MemoryStorage) MarkIssueDirty(ctx context.Context, issueID string) erro
 	m.dirty[issueID] = true
 	return nil
 }
+
+// containsStatus reports whether status is in list.
+func containsStatus(list []types.Status, status types.Status) bool {
+	for _, s := range list {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// containsType reports whether issueType is in list.
+func containsType(list []types.IssueType, issueType types.IssueType) bool {
+	for _, t := range list {
+		if t == issueType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyLabel reports whether labels contains at least one of want.
+func hasAnyLabel(labels, want []string) bool {
+	for _, w := range want {
+		for _, l := range labels {
+			if l == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesSourceRepo reports whether issue is owned by repo, treating an empty
+// source_repo as the primary repo (".").
+func matchesSourceRepo(issue *types.Issue, repo string) bool {
+	owner := issue.SourceRepo
+	if owner == "" {
+		owner = "."
+	}
+	if repo == "" {
+		repo = "."
+	}
+	return owner == repo
+}
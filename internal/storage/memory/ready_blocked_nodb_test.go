@@ -152,3 +152,47 @@ func TestGetBlockedIssues_IncludesExplicitlyBlockedStatus(t *testing.T) {
 		t.Fatalf("expected implicitly blocked issue %s", implicitlyBlocked.ID)
 	}
 }
+
+func TestGetReadyWork_ForAgentRoutesByCapability(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	agent := &types.Issue{ID: "bd-agent", Title: "Agent", Status: types.StatusOpen, Priority: 2, IssueType: "agent", Rig: "beads"}
+	reviewer := &types.Issue{ID: "bd-rev", Title: "Reviewer", Status: types.StatusOpen, Priority: 2, IssueType: "agent"}
+	anyone := &types.Issue{ID: "bd-1", Title: "Anyone", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	migration := &types.Issue{ID: "bd-2", Title: "Migration", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	rust := &types.Issue{ID: "bd-3", Title: "Rust", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{agent, reviewer, anyone, migration, rust} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue %s failed: %v", issue.ID, err)
+		}
+	}
+	for id, labels := range map[string][]string{
+		agent.ID:     {"can:go"},
+		migration.ID: {"needs:go", "needs:db-migration", "needs:rig:beads"},
+		rust.ID:      {"needs:rust"},
+	} {
+		for _, l := range labels {
+			if err := store.AddLabel(ctx, id, l, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+	}
+	attest := &types.Dependency{IssueID: reviewer.ID, DependsOnID: agent.ID, Type: types.DepAttests, Metadata: `{"skill":"db-migration"}`}
+	if err := store.AddDependency(ctx, attest, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{ForAgent: agent.ID})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 || ready[0].ID != migration.ID || ready[1].ID != anyone.ID {
+		var ids []string
+		for _, issue := range ready {
+			ids = append(ids, issue.ID)
+		}
+		t.Fatalf("ready for agent = %v, want [%s %s]", ids, migration.ID, anyone.ID)
+	}
+}
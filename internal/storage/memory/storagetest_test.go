@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/storagetest"
+)
+
+// TestStorageConformance runs the shared storage suite. The in-memory backend
+// only exists for --no-db mode, so it opts out of transactions, the audit
+// log, renames, export hashes and deep graph queries.
+func TestStorageConformance(t *testing.T) {
+	storagetest.Run(t, storagetest.Backend{
+		Name: "memory",
+		New: func(t *testing.T) storage.Storage {
+			return New("")
+		},
+		NoTransactions:    true,
+		NoEventLog:        true,
+		NoRename:          true,
+		NoExportHashes:    true,
+		NoDependencyGraph: true,
+	})
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type sessionKey struct{}
+
+type commandKey struct{}
+
+type sessionInfo struct {
+	actor   string
+	session string
+}
+
+// WithSession tags mutations made with the returned context with the acting
+// actor and session, so backends that keep an undo log can revert them per
+// actor and session (bd undo). actor is only consulted by operations whose
+// signature has no actor of its own, such as batch deletes.
+func WithSession(ctx context.Context, actor, session string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, sessionInfo{actor: actor, session: session})
+}
+
+// SessionFromContext returns the actor and session set by WithSession.
+func SessionFromContext(ctx context.Context) (actor, session string) {
+	if ctx == nil {
+		return "", ""
+	}
+	info, _ := ctx.Value(sessionKey{}).(sessionInfo)
+	return info.actor, info.session
+}
+
+// NewCommandID returns a random ID for WithCommand.
+func NewCommandID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithCommand tags mutations made with the returned context as one command
+// (one bd invocation or RPC), so bd undo reverts them together.
+func WithCommand(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, commandKey{}, id)
+}
+
+// CommandFromContext returns the command ID set by WithCommand, if any.
+func CommandFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(commandKey{}).(string)
+	return id
+}
@@ -3,8 +3,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"math"
 	"strconv"
+
+	"github.com/steveyegge/beads/internal/idgen"
 )
 
 // AdaptiveIDConfig holds configuration for adaptive ID length scaling
@@ -39,10 +40,7 @@ func DefaultAdaptiveConfig() AdaptiveIDConfig {
 // P(collision) ≈ 1 - e^(-n²/2N)
 // where n = number of items, N = total possible values
 func collisionProbability(numIssues int, idLength int) float64 {
-	const base = 36.0 // base36 encoding (0-9, a-z)
-	totalPossibilities := math.Pow(base, float64(idLength))
-	exponent := -float64(numIssues*numIssues) / (2.0 * totalPossibilities)
-	return 1.0 - math.Exp(exponent)
+	return idgen.CollisionProbability(numIssues, idLength)
 }
 
 // computeAdaptiveLength determines the optimal ID length for the current database size
@@ -109,7 +107,24 @@ func countTopLevelIssues(ctx context.Context, conn *sql.Conn, prefix string) (in
 	return count, nil
 }
 
-// GetAdaptiveIDLength returns the appropriate hash length based on database size
+// getPinnedLength returns the minimum hash length pinned for prefix by a
+// synced id.namespace record, or 0 if the prefix is not pinned.
+func getPinnedLength(ctx context.Context, conn *sql.Conn, prefix string) int {
+	var value string
+	err := conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, idgen.NamespaceConfigKey(prefix)).Scan(&value)
+	if err != nil || value == "" {
+		return 0
+	}
+	length, err := strconv.Atoi(value)
+	if err != nil || length < 0 {
+		return 0
+	}
+	return length
+}
+
+// GetAdaptiveIDLength returns the appropriate hash length based on database size.
+// A length pinned through the id.namespace record acts as a floor, so clones
+// with fewer local issues still generate IDs as long as their peers.
 func GetAdaptiveIDLength(ctx context.Context, conn *sql.Conn, prefix string) (int, error) {
 	// Get current issue count
 	numIssues, err := countTopLevelIssues(ctx, conn, prefix)
@@ -122,6 +137,9 @@ func GetAdaptiveIDLength(ctx context.Context, conn *sql.Conn, prefix string) (in
 	
 	// Compute optimal length
 	length := computeAdaptiveLength(numIssues, config)
+	if pinned := getPinnedLength(ctx, conn, prefix); pinned > length {
+		length = pinned
+	}
 	
 	return length, nil
 }
@@ -187,3 +187,48 @@ func TestGetAdaptiveIDLength_Integration(t *testing.T) {
 		t.Errorf("With min_hash_length=5, got %d", length)
 	}
 }
+
+func TestGetAdaptiveIDLength_NamespacePin(t *testing.T) {
+	db := newTestStore(t, "")
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get connection: %v", err)
+	}
+	defer conn.Close()
+
+	// Pin only applies to its own prefix
+	if err := db.SetConfig(ctx, "id.namespace.test", "6"); err != nil {
+		t.Fatalf("Failed to set namespace pin: %v", err)
+	}
+
+	length, err := GetAdaptiveIDLength(ctx, conn, "test")
+	if err != nil {
+		t.Fatalf("GetAdaptiveIDLength failed: %v", err)
+	}
+	if length != 6 {
+		t.Errorf("Pinned prefix should use 6 chars, got %d", length)
+	}
+
+	length, err = GetAdaptiveIDLength(ctx, conn, "other")
+	if err != nil {
+		t.Fatalf("GetAdaptiveIDLength failed: %v", err)
+	}
+	if length != 3 {
+		t.Errorf("Unpinned prefix should use 3 chars, got %d", length)
+	}
+
+	// A pin below the adaptive length never shortens IDs
+	if err := db.SetConfig(ctx, "min_hash_length", "7"); err != nil {
+		t.Fatalf("Failed to set min_hash_length: %v", err)
+	}
+	length, err = GetAdaptiveIDLength(ctx, conn, "test")
+	if err != nil {
+		t.Fatalf("GetAdaptiveIDLength failed: %v", err)
+	}
+	if length != 7 {
+		t.Errorf("Adaptive length above pin should win, got %d", length)
+	}
+}
@@ -0,0 +1,194 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAttachment records attachment metadata on an issue. The blob itself must
+// already be in .beads/blobs; only the metadata is stored in the database.
+// Attaching the same hash twice updates the name/mime type in place. A zero
+// CreatedAt is set to now, so imports can preserve the original timestamp.
+func (s *SQLiteStorage) AddAttachment(ctx context.Context, issueID string, attachment *types.Attachment, actor string) error {
+	if attachment == nil || attachment.Hash == "" {
+		return fmt.Errorf("attachment hash is required")
+	}
+	if attachment.Name == "" {
+		return fmt.Errorf("attachment name is required")
+	}
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	if attachment.CreatedBy == "" {
+		attachment.CreatedBy = actor
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+
+		var name, mimeType string
+		var size int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT name, size, mime_type FROM attachments WHERE issue_id = ? AND hash = ?
+		`, issueID, attachment.Hash).Scan(&name, &size, &mimeType)
+		switch {
+		case err == nil && name == attachment.Name && size == attachment.Size && mimeType == attachment.MimeType:
+			return nil // Unchanged, don't record an event
+		case err != nil && err != sql.ErrNoRows:
+			return fmt.Errorf("failed to check existing attachment: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO attachments (issue_id, hash, name, size, mime_type, created_by, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (issue_id, hash) DO UPDATE SET
+				name = excluded.name,
+				size = excluded.size,
+				mime_type = excluded.mime_type
+		`, issueID, attachment.Hash, attachment.Name, attachment.Size, attachment.MimeType,
+			attachment.CreatedBy, attachment.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add attachment: %w", err)
+		}
+
+		return recordAttachmentEventTx(ctx, tx, issueID, actor, types.EventAttachmentAdded,
+			fmt.Sprintf("Attached: %s (%s)", attachment.Name, shortBlobHash(attachment.Hash)))
+	})
+}
+
+// RemoveAttachment removes attachment metadata from an issue. The blob is
+// left in place; unreferenced blobs are reported by bd doctor and removed by
+// bd attach gc.
+func (s *SQLiteStorage) RemoveAttachment(ctx context.Context, issueID, hash, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var name string
+		err := tx.QueryRowContext(ctx, `
+			SELECT name FROM attachments WHERE issue_id = ? AND hash = ?
+		`, issueID, hash).Scan(&name)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("attachment %s not found on %s", shortBlobHash(hash), issueID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up attachment: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ? AND hash = ?`, issueID, hash); err != nil {
+			return fmt.Errorf("failed to remove attachment: %w", err)
+		}
+
+		return recordAttachmentEventTx(ctx, tx, issueID, actor, types.EventAttachmentRemoved,
+			fmt.Sprintf("Removed attachment: %s (%s)", name, shortBlobHash(hash)))
+	})
+}
+
+// GetAttachments returns the attachments on an issue, oldest first.
+func (s *SQLiteStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, hash, name, size, mime_type, created_by, created_at
+		FROM attachments
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, name ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result, err := scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetAttachmentsForIssues fetches attachments for multiple issues in a single query
+// Returns a map of issue_id -> []*Attachment
+func (s *SQLiteStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.Attachment), nil
+	}
+
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT issue_id, hash, name, size, mime_type, created_by, created_at
+		FROM attachments
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, name ASC
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanAttachments(rows)
+}
+
+func scanAttachments(rows *sql.Rows) (map[string][]*types.Attachment, error) {
+	result := make(map[string][]*types.Attachment)
+	for rows.Next() {
+		var issueID string
+		var mimeType, createdBy sql.NullString
+		a := &types.Attachment{}
+		if err := rows.Scan(&issueID, &a.Hash, &a.Name, &a.Size, &mimeType, &createdBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		a.MimeType = mimeType.String
+		a.CreatedBy = createdBy.String
+		result[issueID] = append(result[issueID], a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+	return result, nil
+}
+
+func recordAttachmentEventTx(ctx context.Context, tx *sql.Tx, issueID, actor string, eventType types.EventType, comment string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, eventType, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	// Attachment metadata is exported with the issue, so mark it dirty
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issueID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// shortBlobHash abbreviates a blob hash for event messages.
+func shortBlobHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -310,6 +310,11 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 	if err := bulkRecordEvents(ctx, conn, issues, actor); err != nil {
 		return wrapDBError("record creation events", err)
 	}
+	for _, issue := range issues {
+		if err := recordUndo(ctx, conn, issue.ID, UndoOpCreate, actor, undoInverse{}); err != nil {
+			return err
+		}
+	}
 
 	// Phase 6: Mark issues dirty for incremental export
 	if err := bulkMarkDirty(ctx, conn, issues); err != nil {
@@ -0,0 +1,205 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// cascadeTarget is an issue a close cascades to, and why.
+type cascadeTarget struct {
+	id     string
+	reason string
+}
+
+// cascadeQuery selects the issues one rule cascades a close to.
+type cascadeQuery struct {
+	reason string
+	query  string
+}
+
+// cascadeCandidate restricts cascade targets to issues a plain bd close
+// would accept: open, not pinned, not a template and not blocked.
+const cascadeCandidate = `
+	p.status NOT IN ('closed', 'tombstone', 'pinned')
+	AND p.is_template = 0
+	AND p.id NOT IN (SELECT issue_id FROM blocked_issues_cache)`
+
+// cascadeClose closes the issues that follow from closing id under the
+// close.cascade-* rules, inside the caller's transaction. Each issue the
+// cascade closes gets a caused-by edge to the issue that triggered it, and
+// its own close is cascaded in turn.
+func (s *SQLiteStorage) cascadeClose(ctx context.Context, db undoDB, id, actor, session string) error {
+	rules := config.GetCloseCascadeConfig()
+	if !rules.Enabled() {
+		return nil
+	}
+	queue := []string{id}
+	for len(queue) > 0 {
+		trigger := queue[0]
+		queue = queue[1:]
+		targets, err := findCascadeTargets(ctx, db, trigger, rules)
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			closed, err := s.closeByCascade(ctx, db, target, trigger, actor, session)
+			if err != nil {
+				return err
+			}
+			if closed {
+				queue = append(queue, target.id)
+			}
+		}
+	}
+	return nil
+}
+
+// findCascadeTargets returns the issues that closing trigger cascades to.
+func findCascadeTargets(ctx context.Context, db undoDB, trigger string, rules config.CloseCascadeConfig) ([]cascadeTarget, error) {
+	var queries []cascadeQuery
+	if rules.Epics {
+		// Epics the trigger is a child of, with no open children left
+		queries = append(queries, cascadeQuery{"All children closed", `
+			SELECT p.id FROM dependencies d
+			JOIN issues p ON p.id = d.depends_on_id
+			WHERE d.issue_id = ? AND d.type = 'parent-child' AND p.issue_type = 'epic'
+			  AND NOT EXISTS (
+				SELECT 1 FROM dependencies c JOIN issues ci ON ci.id = c.issue_id
+				WHERE c.depends_on_id = p.id AND c.type = 'parent-child'
+				  AND ci.status NOT IN ('closed', 'tombstone'))
+			  AND` + cascadeCandidate})
+	}
+	if rules.Delegated {
+		// Issues the trigger was delegated from, with no open delegates left
+		queries = append(queries, cascadeQuery{"Delegated work completed", `
+			SELECT p.id FROM dependencies d
+			JOIN issues p ON p.id = d.depends_on_id
+			WHERE d.issue_id = ? AND d.type = 'delegated-from'
+			  AND NOT EXISTS (
+				SELECT 1 FROM dependencies c JOIN issues ci ON ci.id = c.issue_id
+				WHERE c.depends_on_id = p.id AND c.type = 'delegated-from'
+				  AND ci.status NOT IN ('closed', 'tombstone'))
+			  AND` + cascadeCandidate})
+	}
+	if rules.Until {
+		// Issues only active until the trigger closed
+		queries = append(queries, cascadeQuery{"Until condition met", `
+			SELECT p.id FROM dependencies d
+			JOIN issues p ON p.id = d.issue_id
+			WHERE d.depends_on_id = ? AND d.type = 'until'
+			  AND` + cascadeCandidate})
+	}
+
+	var targets []cascadeTarget
+	seen := make(map[string]bool)
+	for _, q := range queries {
+		rows, err := db.QueryContext(ctx, q.query+` ORDER BY p.id`, trigger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find close cascade targets: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan close cascade target: %w", err)
+			}
+			if !seen[id] {
+				seen[id] = true
+				targets = append(targets, cascadeTarget{id: id, reason: q.reason})
+			}
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("close cascade rows iteration error: %w", err)
+		}
+	}
+	return targets, nil
+}
+
+// closeByCascade closes target because trigger closed and links it to
+// trigger with a caused-by edge. It reports false if an earlier step of the
+// cascade already closed target.
+func (s *SQLiteStorage) closeByCascade(ctx context.Context, db undoDB, target cascadeTarget, trigger, actor, session string) (bool, error) {
+	now := time.Now()
+	reason := storage.CascadeReason(target.reason, trigger)
+
+	undoColumns, err := snapshotIssueColumns(ctx, db, target.id, closeUndoColumns)
+	if err != nil {
+		return false, err
+	}
+	result, err := db.ExecContext(ctx, `
+		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?, closed_by_session = ?
+		WHERE id = ? AND status != ?
+	`, types.StatusClosed, now, now, reason, session, target.id, types.StatusClosed)
+	if err != nil {
+		return false, fmt.Errorf("failed to cascade close to %s: %w", target.id, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return false, err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, target.id, types.EventClosed, actor, reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	// Stamped with the close time so storage.CascadedCloses can tell it from
+	// an earlier, since reopened, cascade
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, target.id, types.EventCascadeClosed, actor, trigger, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := recordUndo(ctx, db, target.id, UndoOpClose, actor, undoInverse{Columns: undoColumns}); err != nil {
+		return false, err
+	}
+
+	// The edge may already exist if someone recorded it by hand
+	dep := &types.Dependency{IssueID: target.id, DependsOnID: trigger, Type: types.DepCausedBy, CreatedAt: now, CreatedBy: actor}
+	result, err = db.ExecContext(ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (issue_id, depends_on_id, type) DO NOTHING
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to add caused-by dependency: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows > 0 {
+		_, err = db.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, dep.IssueID, types.EventDependencyAdded, actor,
+			fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
+		if err != nil {
+			return false, fmt.Errorf("failed to record event: %w", err)
+		}
+		if err := recordUndo(ctx, db, dep.IssueID, UndoOpDepAdd, actor, undoInverse{Dependency: dep}); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, target.id, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	// The next step may hinge on what this close unblocked
+	if err := s.invalidateBlockedCache(ctx, db); err != nil {
+		return false, fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+	return true, nil
+}
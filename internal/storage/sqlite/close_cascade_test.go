@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// setCascadeRules turns the close cascade rules on or off for one test.
+func setCascadeRules(t *testing.T, epics, delegated, until bool) {
+	t.Helper()
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("failed to initialize config: %v", err)
+	}
+	config.Set("close.cascade-epics", epics)
+	config.Set("close.cascade-delegated", delegated)
+	config.Set("close.cascade-until", until)
+	t.Cleanup(func() {
+		config.Set("close.cascade-epics", false)
+		config.Set("close.cascade-delegated", false)
+		config.Set("close.cascade-until", false)
+	})
+}
+
+func (e *testEnv) requireStatus(issue *types.Issue, want types.Status) *types.Issue {
+	e.t.Helper()
+	got, err := e.Store.GetIssue(e.Ctx, issue.ID)
+	if err != nil {
+		e.t.Fatalf("GetIssue(%s) failed: %v", issue.ID, err)
+	}
+	if got.Status != want {
+		e.t.Fatalf("%s status = %s, want %s", issue.ID, got.Status, want)
+	}
+	return got
+}
+
+func (e *testEnv) cascadedFrom(issue *types.Issue) []string {
+	e.t.Helper()
+	closed, err := storage.CascadedCloses(e.Ctx, e.Store, issue.ID)
+	if err != nil {
+		e.t.Fatalf("CascadedCloses(%s) failed: %v", issue.ID, err)
+	}
+	var ids []string
+	for _, c := range closed {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func TestCloseCascadeEpics(t *testing.T) {
+	setCascadeRules(t, true, false, false)
+	env := newTestEnv(t)
+
+	outer := env.CreateEpic("Outer")
+	inner := env.CreateEpic("Inner")
+	a := env.CreateIssue("A")
+	b := env.CreateIssue("B")
+	env.AddParentChild(inner, outer)
+	env.AddParentChild(a, inner)
+	env.AddParentChild(b, inner)
+
+	env.Close(a, "Done")
+	env.requireStatus(inner, types.StatusOpen)
+
+	// Closing the last child closes the epic, which closes its own parent
+	env.Close(b, "Done")
+	closedInner := env.requireStatus(inner, types.StatusClosed)
+	if want := "All children closed (cascade from " + b.ID + ")"; closedInner.CloseReason != want {
+		t.Errorf("close reason = %q, want %q", closedInner.CloseReason, want)
+	}
+	env.requireStatus(outer, types.StatusClosed)
+	if got := env.cascadedFrom(b); len(got) != 2 || got[0] != inner.ID || got[1] != outer.ID {
+		t.Errorf("CascadedCloses(%s) = %v, want [%s %s]", b.ID, got, inner.ID, outer.ID)
+	}
+
+	deps, err := env.Store.GetDependencyRecords(env.Ctx, inner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var causedBy bool
+	for _, dep := range deps {
+		causedBy = causedBy || (dep.Type == types.DepCausedBy && dep.DependsOnID == b.ID && dep.CreatedBy == "test-user")
+	}
+	if !causedBy {
+		t.Errorf("%s has no caused-by edge to %s: %+v", inner.ID, b.ID, deps)
+	}
+	events, err := env.Store.GetEvents(env.Ctx, inner.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var closeEvent bool
+	for _, ev := range events {
+		closeEvent = closeEvent || (ev.EventType == types.EventClosed && ev.Comment != nil && *ev.Comment == closedInner.CloseReason)
+	}
+	if !closeEvent {
+		t.Errorf("%s has no close event for the cascade", inner.ID)
+	}
+}
+
+func TestCloseCascadeSkipsBlockedAndNonEpics(t *testing.T) {
+	setCascadeRules(t, true, false, false)
+	env := newTestEnv(t)
+
+	epic := env.CreateEpic("Blocked epic")
+	blocker := env.CreateIssue("Blocker")
+	env.AddDep(epic, blocker)
+	parent := env.CreateIssue("Plain parent")
+	child := env.CreateIssue("Child")
+	env.AddParentChild(child, epic)
+	other := env.CreateIssue("Other child")
+	env.AddParentChild(other, parent)
+
+	env.Close(child, "Done")
+	env.Close(other, "Done")
+	env.requireStatus(epic, types.StatusOpen)
+	env.requireStatus(parent, types.StatusOpen)
+}
+
+func TestCloseCascadeDelegated(t *testing.T) {
+	setCascadeRules(t, false, true, false)
+	env := newTestEnv(t)
+
+	origin := env.CreateIssue("Origin")
+	x := env.CreateIssue("Delegate X")
+	y := env.CreateIssue("Delegate Y")
+	env.AddDepType(x, origin, types.DepDelegatedFrom)
+	env.AddDepType(y, origin, types.DepDelegatedFrom)
+
+	env.Close(x, "Done")
+	env.requireStatus(origin, types.StatusOpen)
+	env.Close(y, "Done")
+	closed := env.requireStatus(origin, types.StatusClosed)
+	if want := "Delegated work completed (cascade from " + y.ID + ")"; closed.CloseReason != want {
+		t.Errorf("close reason = %q, want %q", closed.CloseReason, want)
+	}
+}
+
+func TestCloseCascadeUntil(t *testing.T) {
+	env := newTestEnv(t)
+	muted := env.CreateIssue("Muted alert")
+	incident := env.CreateIssue("Incident")
+	env.AddDepType(muted, incident, types.DepUntil)
+
+	// Off by default
+	setCascadeRules(t, false, true, false)
+	other := env.CreateIssue("Other muted alert")
+	otherIncident := env.CreateIssue("Other incident")
+	env.AddDepType(other, otherIncident, types.DepUntil)
+	env.Close(otherIncident, "Done")
+	env.requireStatus(other, types.StatusOpen)
+
+	setCascadeRules(t, false, false, true)
+	env.Close(incident, "Resolved")
+	env.requireStatus(muted, types.StatusClosed)
+	if got := env.cascadedFrom(incident); len(got) != 1 || got[0] != muted.ID {
+		t.Errorf("CascadedCloses(%s) = %v, want [%s]", incident.ID, got, muted.ID)
+	}
+}
+
+func TestCloseCascadeSharesTransaction(t *testing.T) {
+	setCascadeRules(t, true, false, false)
+	env := newTestEnv(t)
+
+	epic := env.CreateEpic("Epic")
+	child := env.CreateIssue("Child")
+	env.AddParentChild(child, epic)
+
+	// A failed transaction takes the cascade down with the close
+	errAbort := errors.New("abort")
+	err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		if err := tx.CloseIssue(env.Ctx, child.ID, "Done", "test-user", ""); err != nil {
+			return err
+		}
+		epicNow, err := tx.GetIssue(env.Ctx, epic.ID)
+		if err != nil {
+			return err
+		}
+		if epicNow.Status != types.StatusClosed {
+			t.Errorf("epic status inside transaction = %s, want closed", epicNow.Status)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("RunInTransaction = %v, want abort", err)
+	}
+	env.requireStatus(child, types.StatusOpen)
+	env.requireStatus(epic, types.StatusOpen)
+
+	// bd undo reverts the cascade together with the close
+	ctx := storage.WithCommand(context.Background(), "cmd-close")
+	if err := env.Store.CloseIssue(ctx, child.ID, "Done", "test-user", ""); err != nil {
+		t.Fatal(err)
+	}
+	env.requireStatus(epic, types.StatusClosed)
+	result, err := env.Store.Undo(env.Ctx, UndoFilter{Actor: "test-user", Limit: 1}, "test-user", false, false)
+	if err != nil || !result.Applied {
+		t.Fatalf("Undo = %+v, %v", result, err)
+	}
+	env.requireStatus(child, types.StatusOpen)
+	env.requireStatus(epic, types.StatusOpen)
+	if got := env.cascadedFrom(child); len(got) != 0 {
+		t.Errorf("CascadedCloses after undo = %v, want none", got)
+	}
+}
@@ -93,6 +93,8 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		// EXCEPTION: relates-to links are inherently bidirectional ("see also" relationships).
 		// When A relates-to B, we also create B relates-to A. This is not a cycle in the
 		// problematic sense - it's a symmetric relationship that doesn't affect work ordering.
+		// Likewise caused-by edges are an audit trail: a close cascade links an epic back to
+		// the child whose close triggered it. Neither type is checked or traversed.
 		//
 		// Implementation: We use a recursive CTE to traverse from DependsOnID to see if we can
 		// reach IssueID. If yes, adding "IssueID depends on DependsOnID" would complete a cycle.
@@ -102,8 +104,8 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		// The traversal is depth-limited to maxDependencyDepth (100) to prevent infinite loops
 		// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
 
-		// Skip cycle detection for relates-to (inherently bidirectional)
-		if dep.Type != types.DepRelatesTo {
+		// Skip cycle detection for relates-to and caused-by (see above)
+		if dep.Type.IsCycleChecked() {
 			var cycleExists bool
 			err = tx.QueryRowContext(ctx, `
 				WITH RECURSIVE paths AS (
@@ -112,7 +114,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 						depends_on_id,
 						1 as depth
 					FROM dependencies
-					WHERE issue_id = ?
+					WHERE issue_id = ? AND type NOT IN ('relates-to', 'caused-by')
 
 					UNION ALL
 
@@ -122,7 +124,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 						p.depth + 1
 					FROM dependencies d
 					JOIN paths p ON d.issue_id = p.depends_on_id
-					WHERE p.depth < ?
+					WHERE p.depth < ? AND d.type NOT IN ('relates-to', 'caused-by')
 				)
 				SELECT EXISTS(
 					SELECT 1 FROM paths
@@ -158,6 +160,9 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, tx, dep.IssueID, UndoOpDepAdd, actor, undoInverse{Dependency: dep}); err != nil {
+		return err
+	}
 
 		// Mark issues as dirty for incremental export
 		// For external refs, only mark the source issue (target doesn't exist locally)
@@ -196,6 +201,12 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 			needsCacheInvalidation = depType.AffectsReadyWork()
 		}
 
+		// Capture the edges for bd undo
+		removed, err := snapshotEdges(ctx, tx, issueID, dependsOnID)
+		if err != nil {
+			return err
+		}
+
 		result, err := tx.ExecContext(ctx, `
 			DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
 		`, issueID, dependsOnID)
@@ -220,6 +231,11 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
 		}
+		if len(removed) > 0 {
+			if err := recordUndo(ctx, tx, issueID, UndoOpDepRemove, actor, undoInverse{Dependencies: removed}); err != nil {
+				return err
+			}
+		}
 
 		// Mark issues as dirty for incremental export
 		// For external refs, only mark the source issue (target doesn't exist locally)
@@ -251,6 +267,7 @@ func (s *SQLiteStorage) GetDependenciesWithMetadata(ctx context.Context, issueID
 		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
+		       i.source_formula, i.source_location,
 		       d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
@@ -274,6 +291,7 @@ func (s *SQLiteStorage) GetDependentsWithMetadata(ctx context.Context, issueID s
 		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
+		       i.source_formula, i.source_location,
 		       d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
@@ -738,7 +756,8 @@ func parseExternalRefParts(ref string) (project, capability string) {
 	return parts[1], parts[2]
 }
 
-// loadDependencyGraph loads all non-relates-to dependencies as an adjacency list.
+// loadDependencyGraph loads all cycle-checked dependencies (everything but
+// relates-to and caused-by) as an adjacency list.
 // This is used by DetectCycles for O(V+E) cycle detection instead of the O(2^n) SQL CTE.
 func (s *SQLiteStorage) loadDependencyGraph(ctx context.Context) (map[string][]string, error) {
 	// Hold read lock during database operations to prevent reconnect() from
@@ -750,7 +769,7 @@ func (s *SQLiteStorage) loadDependencyGraph(ctx context.Context) (map[string][]s
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT issue_id, depends_on_id
 		FROM dependencies
-		WHERE type != 'relates-to'
+		WHERE type NOT IN ('relates-to', 'caused-by')
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load dependency graph: %w", err)
@@ -770,7 +789,8 @@ func (s *SQLiteStorage) loadDependencyGraph(ctx context.Context) (map[string][]s
 // DetectCycles finds circular dependencies and returns the actual cycle paths.
 // Uses O(V+E) DFS with shared visited set instead of O(2^n) SQL path enumeration.
 // Note: relates-to dependencies are excluded because they are intentionally bidirectional
-// ("see also" relationships) and do not represent problematic cycles.
+// ("see also" relationships) and do not represent problematic cycles. caused-by edges are
+// excluded too, since a close cascade links an epic back to the child that closed it.
 func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	// Load all dependencies as adjacency list (one query)
 	deps, err := s.loadDependencyGraph(ctx)
@@ -924,6 +944,9 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		// Time-based scheduling fields
 		var dueAt sql.NullTime
 		var deferUntil sql.NullTime
+		// Formula source tracing fields
+		var sourceFormula sql.NullString
+		var sourceLocation sql.NullString
 
 		err := rows.Scan(
 			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -934,7 +957,7 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 			&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 			&awaitType, &awaitID, &timeoutNs, &waiters,
 			&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-			&dueAt, &deferUntil,
+			&dueAt, &deferUntil, &sourceFormula, &sourceLocation,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1044,6 +1067,13 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		if deferUntil.Valid {
 			issue.DeferUntil = &deferUntil.Time
 		}
+		// Formula source tracing fields
+		if sourceFormula.Valid {
+			issue.SourceFormula = sourceFormula.String
+		}
+		if sourceLocation.Valid {
+			issue.SourceLocation = sourceLocation.String
+		}
 
 		issues = append(issues, &issue)
 		issueIDs = append(issueIDs, issue.ID)
@@ -1102,6 +1132,9 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 		var awaitID sql.NullString
 		var timeoutNs sql.NullInt64
 		var waiters sql.NullString
+		// Formula source tracing fields
+		var sourceFormula sql.NullString
+		var sourceLocation sql.NullString
 		var depType types.DependencyType
 
 		err := rows.Scan(
@@ -1112,6 +1145,7 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 			&deletedAt, &deletedBy, &deleteReason, &originalType,
 			&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 			&awaitType, &awaitID, &timeoutNs, &waiters,
+			&sourceFormula, &sourceLocation,
 			&depType,
 		)
 		if err != nil {
@@ -1190,6 +1224,13 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 		if waiters.Valid && waiters.String != "" {
 			issue.Waiters = parseJSONStringArray(waiters.String)
 		}
+		// Formula source tracing fields
+		if sourceFormula.Valid {
+			issue.SourceFormula = sourceFormula.String
+		}
+		if sourceLocation.Valid {
+			issue.SourceLocation = sourceLocation.String
+		}
 
 		// Fetch labels for this issue
 		labels, err := s.GetLabels(ctx, issue.ID)
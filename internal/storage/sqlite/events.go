@@ -111,6 +111,40 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 	return events, nil
 }
 
+// ImportEvents inserts events for an issue, preserving their original actor
+// and timestamp. Used to carry history along when an issue moves to another
+// rig (bd move). Event IDs are reassigned by this database.
+func (s *SQLiteStorage) ImportEvents(ctx context.Context, issueID string, events []*types.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare event insert: %w", err)
+		}
+		defer func() { _ = stmt.Close() }()
+
+		for _, e := range events {
+			if _, err := stmt.ExecContext(ctx, issueID, e.EventType, e.Actor, e.OldValue, e.NewValue, e.Comment, e.CreatedAt.UTC()); err != nil {
+				return fmt.Errorf("failed to import event: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
 // GetStatistics returns aggregate statistics
 func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	// Hold read lock during database operations to prevent reconnect() from
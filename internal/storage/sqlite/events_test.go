@@ -363,3 +363,50 @@ func TestAddCommentNotFound(t *testing.T) {
 		t.Errorf("Expected error to contain %q, got %q", expectedError, err.Error())
 	}
 }
+
+func TestImportEventsPreservesActorAndTimestamp(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Moved issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "mover"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	when := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	reason := "old history"
+	events := []*types.Event{
+		{EventType: types.EventCommented, Actor: testUserAlice, Comment: &reason, CreatedAt: when},
+	}
+	if err := store.ImportEvents(ctx, issue.ID, events); err != nil {
+		t.Fatalf("ImportEvents failed: %v", err)
+	}
+
+	got, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var imported *types.Event
+	for _, e := range got {
+		if e.Actor == testUserAlice {
+			imported = e
+		}
+	}
+	if imported == nil {
+		t.Fatalf("imported event not found in %+v", got)
+	}
+	if !imported.CreatedAt.Equal(when) || imported.Comment == nil || *imported.Comment != reason {
+		t.Errorf("imported event = %+v, want original timestamp and comment", imported)
+	}
+
+	if err := store.ImportEvents(ctx, "missing-id", events); err == nil {
+		t.Error("ImportEvents on a missing issue should fail")
+	}
+}
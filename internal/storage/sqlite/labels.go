@@ -12,7 +12,8 @@ import (
 // executeLabelOperation executes a label operation (add or remove) within a transaction
 func (s *SQLiteStorage) executeLabelOperation(
 	ctx context.Context,
-	issueID, actor string,
+	issueID, label, actor string,
+	undoOp string,
 	labelSQL string,
 	labelSQLArgs []interface{},
 	eventType types.EventType,
@@ -41,6 +42,9 @@ func (s *SQLiteStorage) executeLabelOperation(
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
 		}
+		if err := recordUndo(ctx, tx, issueID, undoOp, actor, undoInverse{Label: label}); err != nil {
+			return err
+		}
 
 		// Mark issue as dirty for incremental export
 		_, err = tx.ExecContext(ctx, `
@@ -59,7 +63,7 @@ func (s *SQLiteStorage) executeLabelOperation(
 // AddLabel adds a label to an issue
 func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
 	return s.executeLabelOperation(
-		ctx, issueID, actor,
+		ctx, issueID, label, actor, UndoOpLabelAdd,
 		`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`,
 		[]interface{}{issueID, label},
 		types.EventLabelAdded,
@@ -71,7 +75,7 @@ func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor stri
 // RemoveLabel removes a label from an issue
 func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
 	return s.executeLabelOperation(
-		ctx, issueID, actor,
+		ctx, issueID, label, actor, UndoOpLabelRemove,
 		`DELETE FROM labels WHERE issue_id = ? AND label = ?`,
 		[]interface{}{issueID, label},
 		types.EventLabelRemoved,
@@ -175,7 +179,7 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		       i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		       i.due_at, i.defer_until
+		       i.due_at, i.defer_until, i.source_formula, i.source_location
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
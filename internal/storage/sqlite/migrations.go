@@ -57,6 +57,10 @@ var migrationsList = []Migration{
 	{"work_type_column", migrations.MigrateWorkTypeColumn},
 	{"source_system_column", migrations.MigrateSourceSystemColumn},
 	{"quality_score_column", migrations.MigrateQualityScoreColumn},
+	{"attachments_table", migrations.MigrateAttachmentsTable},
+	{"work_logs_table", migrations.MigrateWorkLogsTable},
+	{"source_formula_columns", migrations.MigrateSourceFormulaColumns},
+	{"undo_log_table", migrations.MigrateUndoLogTable},
 }
 
 // MigrationInfo contains metadata about a migration for inspection
@@ -121,6 +125,10 @@ func getMigrationDescription(name string) string {
 		"work_type_column":             "Adds work_type column for work assignment model (mutex vs open_competition per Decision 006)",
 		"source_system_column":         "Adds source_system column for federation adapter tracking",
 		"quality_score_column":         "Adds quality_score column for aggregate quality (0.0-1.0) set by Refineries",
+		"attachments_table":            "Adds attachments table for issue attachment metadata (content lives in .beads/blobs)",
+		"work_logs_table":              "Adds work_logs table for time tracking (actual effort vs estimated_minutes)",
+		"source_formula_columns":       "Adds source_formula and source_location columns so poured molecules can be upgraded",
+		"undo_log_table":               "Adds undo_log table recording inverse operations for bd undo",
 	}
 
 	if desc, ok := descriptions[name]; ok {
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateAttachmentsTable creates the attachments table, which records
+// attachment metadata per issue. Attachment content is stored outside the
+// database as content-addressed blobs in .beads/blobs.
+func MigrateAttachmentsTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='attachments'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE attachments (
+				issue_id TEXT NOT NULL,
+				hash TEXT NOT NULL,
+				name TEXT NOT NULL,
+				size INTEGER NOT NULL DEFAULT 0,
+				mime_type TEXT DEFAULT '',
+				created_by TEXT DEFAULT '',
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (issue_id, hash),
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create attachments table: %w", err)
+		}
+		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_attachments_hash ON attachments(hash)`); err != nil {
+			return fmt.Errorf("failed to create attachments hash index: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for attachments table: %w", err)
+	}
+
+	return nil
+}
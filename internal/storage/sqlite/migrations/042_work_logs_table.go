@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateWorkLogsTable creates the work_logs table, which records time spent
+// on issues. A row with a NULL ended_at is a running timer.
+func MigrateWorkLogsTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='work_logs'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE work_logs (
+				issue_id TEXT NOT NULL,
+				actor TEXT NOT NULL,
+				started_at DATETIME NOT NULL,
+				ended_at DATETIME,
+				note TEXT DEFAULT '',
+				PRIMARY KEY (issue_id, actor, started_at),
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create work_logs table: %w", err)
+		}
+		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_work_logs_running ON work_logs(actor) WHERE ended_at IS NULL`); err != nil {
+			return fmt.Errorf("failed to create work_logs running index: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for work_logs table: %w", err)
+	}
+
+	return nil
+}
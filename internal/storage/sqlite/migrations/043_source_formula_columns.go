@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSourceFormulaColumns adds the source_formula and source_location
+// columns to the issues table. They record which formula step a poured issue
+// was created from, so `bd mol upgrade` can match issues to updated steps.
+func MigrateSourceFormulaColumns(db *sql.DB) error {
+	for _, column := range []string{"source_formula", "source_location"} {
+		var columnExists bool
+		err := db.QueryRow(`
+			SELECT COUNT(*) > 0
+			FROM pragma_table_info('issues')
+			WHERE name = ?
+		`, column).Scan(&columnExists)
+		if err != nil {
+			return fmt.Errorf("failed to check %s column: %w", column, err)
+		}
+		if columnExists {
+			continue
+		}
+
+		// #nosec G201 - column name is from the fixed list above
+		_, err = db.Exec(fmt.Sprintf(`ALTER TABLE issues ADD COLUMN %s TEXT DEFAULT ''`, column))
+		if err != nil {
+			return fmt.Errorf("failed to add %s column: %w", column, err)
+		}
+	}
+	return nil
+}
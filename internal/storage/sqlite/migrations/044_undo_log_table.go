@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateUndoLogTable creates the undo_log table, which records the inverse
+// of each mutation so bd undo can revert it. Rows outlive the issues they
+// touch, so there is no foreign key.
+func MigrateUndoLogTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='undo_log'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE undo_log (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				issue_id TEXT NOT NULL,
+				op TEXT NOT NULL,
+				actor TEXT NOT NULL,
+				session TEXT DEFAULT '',
+				command TEXT DEFAULT '',
+				inverse TEXT NOT NULL,
+				stamp TEXT,
+				created_at DATETIME NOT NULL,
+				undone_at DATETIME,
+				undone_seq INTEGER,
+				undo_stamp TEXT
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create undo_log table: %w", err)
+		}
+		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_undo_log_actor ON undo_log(actor, created_at)`); err != nil {
+			return fmt.Errorf("failed to create undo_log actor index: %w", err)
+		}
+		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_undo_log_issue ON undo_log(issue_id)`); err != nil {
+			return fmt.Errorf("failed to create undo_log issue index: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for undo_log table: %w", err)
+	}
+
+	return nil
+}
@@ -29,9 +29,15 @@ func (s *SQLiteStorage) HydrateFromMultiRepo(ctx context.Context) (map[string]in
 
 	results := make(map[string]int)
 
+	// Dependencies may point at issues owned by another configured repo
+	// (e.g. a primary issue blocked by an issue in a planning repo). Those
+	// targets may not be imported yet, so orphan validation consults the
+	// IDs present in every configured repo's JSONL before failing.
+	known := &hydrationIDSet{multiRepo: multiRepo}
+
 	// Process primary repo first (if set)
 	if multiRepo.Primary != "" {
-		count, err := s.hydrateFromRepo(ctx, multiRepo.Primary, ".")
+		count, err := s.hydrateFromRepo(ctx, multiRepo.Primary, ".", known)
 		if err != nil {
 			return nil, fmt.Errorf("failed to hydrate primary repo %s: %w", multiRepo.Primary, err)
 		}
@@ -48,19 +54,80 @@ func (s *SQLiteStorage) HydrateFromMultiRepo(ctx context.Context) (map[string]in
 
 		// Use relative path as source_repo identifier
 		relPath := repoPath // Keep original for source_repo field
-		count, err := s.hydrateFromRepo(ctx, expandedPath, relPath)
+		count, err := s.hydrateFromRepo(ctx, expandedPath, relPath, known)
 		if err != nil {
 			return nil, fmt.Errorf("failed to hydrate repo %s: %w", repoPath, err)
 		}
 		results[relPath] = count
 	}
 
+	// Hydration writes rows directly, bypassing the code paths that keep the
+	// blocked cache current. Rebuild it so cross-repo 'blocks' dependencies
+	// are reflected in ready work.
+	for _, count := range results {
+		if count > 0 {
+			if err := s.invalidateBlockedCache(ctx, nil); err != nil {
+				return nil, fmt.Errorf("failed to rebuild blocked cache: %w", err)
+			}
+			break
+		}
+	}
+
 	return results, nil
 }
 
+// hydrationIDSet lazily collects the issue IDs present in the JSONL files of
+// every configured repo. It is only loaded when an import finds a dependency
+// whose target is not yet in the database.
+type hydrationIDSet struct {
+	multiRepo *config.MultiRepoConfig
+	ids       map[string]bool
+}
+
+// contains reports whether id exists in any configured repo's JSONL.
+func (h *hydrationIDSet) contains(id string) bool {
+	if h == nil {
+		return false
+	}
+	if h.ids == nil {
+		h.ids = make(map[string]bool)
+		repos := append([]string{h.multiRepo.Primary}, h.multiRepo.Additional...)
+		for _, repo := range repos {
+			expanded, err := expandTilde(repo)
+			if err != nil {
+				continue
+			}
+			collectJSONLIDs(filepath.Join(expanded, ".beads", "issues.jsonl"), h.ids)
+		}
+	}
+	return h.ids[id]
+}
+
+// collectJSONLIDs adds the ID of every parseable line in jsonlPath to ids.
+// Unreadable files and malformed lines are skipped; the import itself reports
+// those errors.
+func collectJSONLIDs(jsonlPath string, ids map[string]bool) {
+	file, err := os.Open(jsonlPath) // #nosec G304 -- jsonlPath is from trusted config
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var rec struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err == nil && rec.ID != "" {
+			ids[rec.ID] = true
+		}
+	}
+}
+
 // hydrateFromRepo loads issues from a single repository's JSONL file.
 // Uses mtime caching to skip unchanged files.
-func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRepo string) (int, error) {
+func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRepo string, known *hydrationIDSet) (int, error) {
 	// Get absolute path to repo
 	absRepoPath, err := filepath.Abs(repoPath)
 	if err != nil {
@@ -100,7 +167,7 @@ func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRep
 	}
 
 	// Import issues from JSONL
-	count, err := s.importJSONLFile(ctx, jsonlPath, sourceRepo)
+	count, err := s.importJSONLFileFrom(ctx, jsonlPath, sourceRepo, known)
 	if err != nil {
 		return 0, fmt.Errorf("failed to import JSONL: %w", err)
 	}
@@ -120,6 +187,13 @@ func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRep
 // importJSONLFile imports issues from a JSONL file, setting the source_repo field.
 // Disables FK checks during import to handle out-of-order dependencies.
 func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRepo string) (int, error) {
+	return s.importJSONLFileFrom(ctx, jsonlPath, sourceRepo, nil)
+}
+
+// importJSONLFileFrom is importJSONLFile for multi-repo hydration: dependencies
+// on issues listed in known (owned by another configured repo) are accepted
+// even when their target has not been imported yet.
+func (s *SQLiteStorage) importJSONLFileFrom(ctx context.Context, jsonlPath, sourceRepo string, known *hydrationIDSet) (int, error) {
 	file, err := os.Open(jsonlPath) // #nosec G304 -- jsonlPath is from trusted source
 	if err != nil {
 		return 0, fmt.Errorf("failed to open JSONL file: %w", err)
@@ -234,9 +308,12 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 	}
 	defer orphanRows.Close()
 
-	if orphanRows.Next() {
+	for orphanRows.Next() {
 		var issueID, dependsOnID string
 		_ = orphanRows.Scan(&issueID, &dependsOnID)
+		if known.contains(dependsOnID) {
+			continue // Cross-repo dependency, hydrated from another repo
+		}
 		return 0, fmt.Errorf(
 			"foreign key violation: issue %s depends on non-existent issue %s",
 			issueID, dependsOnID,
@@ -487,6 +564,22 @@ func (s *SQLiteStorage) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo
 		}
 	}
 
+	// Delete attachment metadata for all affected issues
+	for _, id := range issueIDs {
+		_, err = tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ?`, id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete attachments for %s: %w", id, err)
+		}
+	}
+
+	// Delete work logs for all affected issues
+	for _, id := range issueIDs {
+		_, err = tx.ExecContext(ctx, `DELETE FROM work_logs WHERE issue_id = ?`, id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete work logs for %s: %w", id, err)
+		}
+	}
+
 	// Delete dirty markers for all affected issues
 	for _, id := range issueIDs {
 		_, err = tx.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, id)
@@ -558,3 +651,13 @@ func expandTilde(path string) (string, error) {
 	// ~user not supported
 	return path, nil
 }
+
+// sourceRepoClause builds a WHERE clause matching issues owned by repo.
+// The primary repo (".") also matches rows written before source_repo was
+// populated, which carry NULL or an empty string.
+func sourceRepoClause(column, repo string) (string, []interface{}) {
+	if repo == "" || repo == "." {
+		return fmt.Sprintf("(%s IS NULL OR %s IN ('', '.'))", column, column), nil
+	}
+	return column + " = ?", []interface{}{repo}
+}
@@ -26,40 +26,11 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 		return nil, nil
 	}
 
-	// Get all issues including tombstones for sync propagation (bd-dve)
-	allIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query issues: %w", err)
-	}
-
-	// Populate dependencies for all issues (avoid N+1)
-	allDeps, err := s.GetAllDependencyRecords(ctx)
+	allIssues, err := s.issuesForMultiRepoExport(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get dependencies: %w", err)
-	}
-	for _, issue := range allIssues {
-		issue.Dependencies = allDeps[issue.ID]
+		return nil, err
 	}
 
-	// Populate labels for all issues
-	for _, issue := range allIssues {
-		labels, err := s.GetLabels(ctx, issue.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
-		}
-		issue.Labels = labels
-	}
-
-	// Filter out wisps - they should never be exported to JSONL (bd-687g)
-	// Wisps exist only in SQLite and are shared via .beads/redirect, not JSONL.
-	filtered := make([]*types.Issue, 0, len(allIssues))
-	for _, issue := range allIssues {
-		if !issue.Ephemeral {
-			filtered = append(filtered, issue)
-		}
-	}
-	allIssues = filtered
-
 	// Group issues by source_repo
 	issuesByRepo := make(map[string][]*types.Issue)
 	for _, issue := range allIssues {
@@ -85,8 +56,11 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 		results["."] = count
 	}
 
-	// Export additional repos
+	// Export additional repos (read-only repos are owned elsewhere; leave their JSONL alone)
 	for _, repoPath := range multiRepo.Additional {
+		if multiRepo.IsReadOnly(repoPath) {
+			continue
+		}
 		issues := issuesByRepo[repoPath]
 		if len(issues) == 0 {
 			// No issues for this repo - write empty JSONL to keep in sync
@@ -108,6 +82,102 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 	return results, nil
 }
 
+// ExportSourceRepo rewrites the JSONL of a single configured additional repo
+// from the issues it owns. It is used after a direct-mode write into a
+// hydrated repo (bd create --repo), where the daemon's multi-repo export
+// won't run. Read-only repos are refused.
+func (s *SQLiteStorage) ExportSourceRepo(ctx context.Context, sourceRepo string) (int, error) {
+	multiRepo := config.GetMultiRepoConfig()
+	if multiRepo == nil {
+		return 0, fmt.Errorf("multi-repo mode is not configured")
+	}
+	if multiRepo.IsReadOnly(sourceRepo) {
+		return 0, fmt.Errorf("repo %s is read-only", sourceRepo)
+	}
+	configured := false
+	for _, repoPath := range multiRepo.Additional {
+		if repoPath == sourceRepo {
+			configured = true
+			break
+		}
+	}
+	if !configured {
+		return 0, fmt.Errorf("repo %s is not in repos.additional", sourceRepo)
+	}
+
+	allIssues, err := s.issuesForMultiRepoExport(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var issues []*types.Issue
+	for _, issue := range allIssues {
+		if issue.SourceRepo == sourceRepo {
+			issues = append(issues, issue)
+		}
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	return s.exportToRepo(ctx, sourceRepo, issues)
+}
+
+// issuesForMultiRepoExport loads every exportable issue (tombstones included,
+// wisps excluded) with its dependencies and labels populated.
+func (s *SQLiteStorage) issuesForMultiRepoExport(ctx context.Context) ([]*types.Issue, error) {
+	// Get all issues including tombstones for sync propagation (bd-dve)
+	allIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issues: %w", err)
+	}
+
+	// Populate dependencies for all issues (avoid N+1)
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Dependencies = allDeps[issue.ID]
+	}
+
+	// Populate labels for all issues
+	for _, issue := range allIssues {
+		labels, err := s.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		issue.Labels = labels
+	}
+
+	// Populate attachments and work logs so they follow the issue to its repo
+	issueIDs := make([]string, len(allIssues))
+	for i, issue := range allIssues {
+		issueIDs[i] = issue.ID
+	}
+	allAttachments, err := s.GetAttachmentsForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	allWorkLogs, err := s.GetWorkLogsForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Attachments = allAttachments[issue.ID]
+		issue.WorkLogs = allWorkLogs[issue.ID]
+	}
+
+	// Filter out wisps - they should never be exported to JSONL (bd-687g)
+	// Wisps exist only in SQLite and are shared via .beads/redirect, not JSONL.
+	filtered := make([]*types.Issue, 0, len(allIssues))
+	for _, issue := range allIssues {
+		if !issue.Ephemeral {
+			filtered = append(filtered, issue)
+		}
+	}
+	allIssues = filtered
+	return allIssues, nil
+}
+
 // exportToRepo writes issues to a single repository's JSONL file atomically.
 func (s *SQLiteStorage) exportToRepo(ctx context.Context, repoPath string, issues []*types.Issue) (int, error) {
 	// Expand tilde in path
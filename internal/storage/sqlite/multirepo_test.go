@@ -1217,3 +1217,242 @@ func TestUpsertPreservesGateFields(t *testing.T) {
 		t.Errorf("Waiters was cleared! expected [beads/dave], got %v", updated.Waiters)
 	}
 }
+
+// writeRepoJSONL writes issues to <repoDir>/.beads/issues.jsonl.
+func writeRepoJSONL(t *testing.T, repoDir string, issues ...*types.Issue) {
+	t.Helper()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("failed to create .beads dir: %v", err)
+	}
+	f, err := os.Create(filepath.Join(beadsDir, "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to create JSONL file: %v", err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, issue := range issues {
+		issue.ContentHash = issue.ComputeContentHash()
+		if err := enc.Encode(issue); err != nil {
+			t.Fatalf("failed to write issue: %v", err)
+		}
+	}
+}
+
+// setMultiRepoConfig configures repos.* for the duration of the test.
+func setMultiRepoConfig(t *testing.T, primary string, additional, readonly []string) {
+	t.Helper()
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("failed to initialize config: %v", err)
+	}
+	config.Set("repos.primary", primary)
+	config.Set("repos.additional", additional)
+	config.Set("repos.readonly", readonly)
+	t.Cleanup(func() {
+		config.Set("repos.primary", "")
+		config.Set("repos.additional", nil)
+		config.Set("repos.readonly", nil)
+	})
+}
+
+func TestHydrateFromMultiRepo_CrossRepoBlocks(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	primaryDir := t.TempDir()
+	planningDir := t.TempDir()
+	now := time.Now()
+
+	// The primary issue is blocked by an issue that lives in the planning repo,
+	// which is hydrated after the primary
+	writeRepoJSONL(t, primaryDir,
+		&types.Issue{
+			ID: "bd-p1", Title: "Implement", Status: types.StatusOpen, Priority: 1,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*types.Dependency{{IssueID: "bd-p1", DependsOnID: "plan-a1", Type: types.DepBlocks, CreatedAt: now}},
+		},
+		&types.Issue{
+			ID: "bd-p2", Title: "Unblocked", Status: types.StatusOpen, Priority: 1,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+		},
+	)
+	writeRepoJSONL(t, planningDir,
+		&types.Issue{
+			ID: "plan-a1", Title: "Design", Status: types.StatusOpen, Priority: 1,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+		},
+	)
+	setMultiRepoConfig(t, primaryDir, []string{planningDir}, nil)
+
+	results, err := store.HydrateFromMultiRepo(ctx)
+	if err != nil {
+		t.Fatalf("HydrateFromMultiRepo() error = %v", err)
+	}
+	if results["."] != 2 || results[planningDir] != 1 {
+		t.Fatalf("unexpected hydration results: %v", results)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork() error = %v", err)
+	}
+	readyIDs := make(map[string]bool)
+	for _, issue := range ready {
+		readyIDs[issue.ID] = true
+	}
+	if readyIDs["bd-p1"] {
+		t.Error("bd-p1 is blocked by plan-a1 in another repo and should not be ready")
+	}
+	if !readyIDs["bd-p2"] || !readyIDs["plan-a1"] {
+		t.Errorf("expected bd-p2 and plan-a1 to be ready, got %v", readyIDs)
+	}
+
+	// Selecting one repo narrows both search and ready work
+	primary := "."
+	primaryReady, err := store.GetReadyWork(ctx, types.WorkFilter{SourceRepo: &primary})
+	if err != nil {
+		t.Fatalf("GetReadyWork(primary) error = %v", err)
+	}
+	if len(primaryReady) != 1 || primaryReady[0].ID != "bd-p2" {
+		t.Errorf("expected only bd-p2 ready in primary repo, got %v", primaryReady)
+	}
+	planningIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{SourceRepo: &planningDir})
+	if err != nil {
+		t.Fatalf("SearchIssues(planning) error = %v", err)
+	}
+	if len(planningIssues) != 1 || planningIssues[0].ID != "plan-a1" || planningIssues[0].SourceRepo != planningDir {
+		t.Errorf("expected only plan-a1 from planning repo, got %v", planningIssues)
+	}
+
+	// Closing the cross-repo blocker frees the primary issue
+	if err := store.CloseIssue(ctx, "plan-a1", "done", "test", ""); err != nil {
+		t.Fatalf("CloseIssue() error = %v", err)
+	}
+	ready, err = store.GetReadyWork(ctx, types.WorkFilter{SourceRepo: &primary})
+	if err != nil {
+		t.Fatalf("GetReadyWork() error = %v", err)
+	}
+	if len(ready) != 2 {
+		t.Errorf("expected bd-p1 and bd-p2 ready after closing blocker, got %d issues", len(ready))
+	}
+}
+
+func TestHydrateFromMultiRepo_RejectsUnknownDependency(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	primaryDir := t.TempDir()
+	now := time.Now()
+	writeRepoJSONL(t, primaryDir, &types.Issue{
+		ID: "bd-p1", Title: "Dangling", Status: types.StatusOpen, Priority: 1,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+		Dependencies: []*types.Dependency{{IssueID: "bd-p1", DependsOnID: "nowhere-1", Type: types.DepBlocks, CreatedAt: now}},
+	})
+	setMultiRepoConfig(t, primaryDir, []string{t.TempDir()}, nil)
+
+	if _, err := store.HydrateFromMultiRepo(ctx); err == nil {
+		t.Fatal("expected error for dependency on an issue no repo contains")
+	}
+}
+
+func TestExportToMultiRepo_SkipsReadOnly(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	primaryDir := t.TempDir()
+	writableDir := t.TempDir()
+	readonlyDir := t.TempDir()
+	now := time.Now()
+	writeRepoJSONL(t, readonlyDir, &types.Issue{
+		ID: "ro-1", Title: "Upstream", Status: types.StatusOpen, Priority: 2,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+	})
+	setMultiRepoConfig(t, primaryDir, []string{writableDir, readonlyDir}, []string{readonlyDir})
+
+	if _, err := store.HydrateFromMultiRepo(ctx); err != nil {
+		t.Fatalf("HydrateFromMultiRepo() error = %v", err)
+	}
+	readonlyJSONL := filepath.Join(readonlyDir, ".beads", "issues.jsonl")
+	before, err := os.ReadFile(readonlyJSONL)
+	if err != nil {
+		t.Fatalf("failed to read read-only JSONL: %v", err)
+	}
+
+	issue := &types.Issue{
+		Title: "Planned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		SourceRepo: writableDir,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	// Mutate the read-only issue locally; export must not write it back
+	if err := store.UpdateIssue(ctx, "ro-1", map[string]interface{}{"title": "Changed locally"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+
+	results, err := store.ExportToMultiRepo(ctx)
+	if err != nil {
+		t.Fatalf("ExportToMultiRepo() error = %v", err)
+	}
+	if _, ok := results[readonlyDir]; ok {
+		t.Errorf("read-only repo should not be exported, got %v", results)
+	}
+	if results[writableDir] != 1 {
+		t.Errorf("expected 1 issue exported to writable repo, got %v", results)
+	}
+	after, err := os.ReadFile(readonlyJSONL)
+	if err != nil {
+		t.Fatalf("failed to read read-only JSONL: %v", err)
+	}
+	if string(before) != string(after) {
+		t.Error("read-only repo JSONL was rewritten")
+	}
+}
+
+func TestExportSourceRepo(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	primaryDir := t.TempDir()
+	planningDir := t.TempDir()
+	readonlyDir := t.TempDir()
+	setMultiRepoConfig(t, primaryDir, []string{planningDir, readonlyDir}, []string{readonlyDir})
+
+	for _, issue := range []*types.Issue{
+		{Title: "Primary", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{Title: "Planning", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, SourceRepo: planningDir},
+	} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue() error = %v", err)
+		}
+	}
+
+	count, err := store.ExportSourceRepo(ctx, planningDir)
+	if err != nil {
+		t.Fatalf("ExportSourceRepo() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 issue exported, got %d", count)
+	}
+	data, err := os.ReadFile(filepath.Join(planningDir, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to read planning JSONL: %v", err)
+	}
+	if !strings.Contains(string(data), `"title":"Planning"`) || strings.Contains(string(data), `"title":"Primary"`) {
+		t.Errorf("planning JSONL has wrong contents:\n%s", data)
+	}
+	if strings.Contains(string(data), "source_repo") {
+		t.Error("source_repo must not be serialized to JSONL")
+	}
+
+	if _, err := store.ExportSourceRepo(ctx, readonlyDir); err == nil {
+		t.Error("expected error exporting a read-only repo")
+	}
+	if _, err := store.ExportSourceRepo(ctx, t.TempDir()); err == nil {
+		t.Error("expected error exporting an unconfigured repo")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -241,7 +242,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		err := conn.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = ?`, issue.ID).Scan(&existingStatus)
 		if err == nil && existingStatus == string(types.StatusTombstone) {
 			// Delete the tombstone record to allow re-creation
-			// Also clean up related tables (events, labels, dependencies, comments, dirty_issues)
+			// Also clean up related tables (events, labels, dependencies, comments, attachments, work_logs, dirty_issues)
 			if _, err := conn.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, issue.ID); err != nil {
 				return fmt.Errorf("failed to delete tombstone events: %w", err)
 			}
@@ -254,6 +255,12 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 			if _, err := conn.ExecContext(ctx, `DELETE FROM comments WHERE issue_id = ?`, issue.ID); err != nil {
 				return fmt.Errorf("failed to delete tombstone comments: %w", err)
 			}
+			if _, err := conn.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ?`, issue.ID); err != nil {
+				return fmt.Errorf("failed to delete tombstone attachments: %w", err)
+			}
+			if _, err := conn.ExecContext(ctx, `DELETE FROM work_logs WHERE issue_id = ?`, issue.ID); err != nil {
+				return fmt.Errorf("failed to delete tombstone work logs: %w", err)
+			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, issue.ID); err != nil {
 				return fmt.Errorf("failed to delete tombstone dirty marker: %w", err)
 			}
@@ -277,6 +284,9 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	if err := recordCreatedEvent(ctx, conn, issue, actor); err != nil {
 		return wrapDBError("record creation event", err)
 	}
+	if err := recordUndo(ctx, conn, issue.ID, UndoOpCreate, actor, undoInverse{}); err != nil {
+		return err
+	}
 
 	// NOTE: Graph edges (replies-to, relates-to, duplicates, supersedes) are now
 	// managed via AddDependency() per Decision 004 Phase 4.
@@ -353,6 +363,9 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	// Time-based scheduling fields (GH#820)
 	var dueAt sql.NullTime
 	var deferUntil sql.NullTime
+	// Formula source tracing fields
+	var sourceFormula sql.NullString
+	var sourceLocation sql.NullString
 
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
@@ -367,7 +380,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
-		       due_at, defer_until
+		       due_at, defer_until, source_formula, source_location
 		FROM issues
 		WHERE id = ?
 	`, id).Scan(
@@ -381,7 +394,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
 		&eventKind, &actor, &target, &payload,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &sourceFormula, &sourceLocation,
 	)
 
 	if err == sql.ErrNoRows {
@@ -518,6 +531,13 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	// Formula source tracing fields
+	if sourceFormula.Valid {
+		issue.SourceFormula = sourceFormula.String
+	}
+	if sourceLocation.Valid {
+		issue.SourceLocation = sourceLocation.String
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -804,6 +824,15 @@ var allowedUpdateFields = map[string]bool{
 	"defer_until": true,
 	// Gate fields (bd-z6kw: support await_id updates for gate discovery)
 	"await_id": true,
+	"waiters":  true,
+}
+
+// updateColumnValue converts an UpdateIssue value to what its column stores.
+func updateColumnValue(key string, value interface{}) interface{} {
+	if arr, ok := value.([]string); ok && key == "waiters" {
+		return formatJSONStringArray(arr)
+	}
+	return value
 }
 
 // validatePriority validates a priority value
@@ -913,7 +942,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			columnName = "ephemeral"
 		}
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", columnName))
-		args = append(args, value)
+		args = append(args, updateColumnValue(key, value))
 	}
 
 	// Auto-manage closed_at when status changes (enforce invariant)
@@ -995,6 +1024,12 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Capture the prior values for bd undo
+	undoColumns, err := snapshotIssueColumns(ctx, tx, id, updateUndoColumns(updates))
+	if err != nil {
+		return err
+	}
+
 	// Update issue
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
 	_, err = tx.ExecContext(ctx, query, args...)
@@ -1025,6 +1060,9 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, tx, id, UndoOpUpdate, actor, undoInverse{Columns: undoColumns}); err != nil {
+		return err
+	}
 
 	// NOTE: Graph edges now managed via AddDependency() per Decision 004 Phase 4.
 
@@ -1070,6 +1108,15 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := renameIssue(ctx, tx, oldID, newID, issue, actor); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// renameIssue moves an issue and every row that references it from oldID
+// to newID. Foreign key checks must be off or deferred.
+func renameIssue(ctx context.Context, tx execer, oldID, newID string, issue *types.Issue, actor string) error {
 	result, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
@@ -1112,6 +1159,16 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update comments: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE attachments SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update attachments: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE work_logs SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update work logs: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		UPDATE dirty_issues SET issue_id = ? WHERE issue_id = ?
 	`, newID, oldID)
@@ -1129,6 +1186,23 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update compaction_snapshots: %w", err)
 	}
 
+	// Derived rows follow too, so deferred foreign key checks pass at commit
+	_, err = tx.ExecContext(ctx, `UPDATE export_hashes SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update export_hashes: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE blocked_issues_cache SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update blocked_issues_cache: %w", err)
+	}
+
+	// Carry the child counter over so new children don't reuse existing numbers
+	_, err = tx.ExecContext(ctx, `UPDATE child_counters SET parent_id = ? WHERE parent_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update child_counters: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -1146,7 +1220,7 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to record rename event: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // RenameDependencyPrefix updates the prefix in all dependency records
@@ -1207,6 +1281,12 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Capture the prior values for bd undo
+	undoColumns, err := snapshotIssueColumns(ctx, tx, id, closeUndoColumns)
+	if err != nil {
+		return err
+	}
+
 	// NOTE: close_reason is stored in two places:
 	// 1. issues.close_reason - for direct queries (bd show --json, exports)
 	// 2. events.comment - for audit history (when was it closed, by whom)
@@ -1234,6 +1314,9 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, tx, id, UndoOpClose, actor, undoInverse{Columns: undoColumns}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty for incremental export
 	_, err = tx.ExecContext(ctx, `
@@ -1329,6 +1412,12 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 		}
 	}
 
+	// Close cascades (close.cascade-* in config.yaml) commit or roll back
+	// with the close that triggered them
+	if err := s.cascadeClose(ctx, tx, id, actor, session); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -1352,6 +1441,12 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Capture the prior values for bd undo
+	undoColumns, err := snapshotIssueColumns(ctx, tx, id, deleteUndoColumns)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	originalType := string(issue.IssueType)
 
@@ -1381,6 +1476,9 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 	if err != nil {
 		return fmt.Errorf("failed to record tombstone event: %w", err)
 	}
+	if err := recordUndo(ctx, tx, id, UndoOpDelete, actor, undoInverse{Columns: undoColumns}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty for incremental export
 	_, err = tx.ExecContext(ctx, `
@@ -1457,6 +1555,18 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete comments: %w", err)
 	}
 
+	// Delete attachment metadata (blobs are garbage-collected separately)
+	_, err = tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachments: %w", err)
+	}
+
+	// Delete work logs
+	_, err = tx.ExecContext(ctx, `DELETE FROM work_logs WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete work logs: %w", err)
+	}
+
 	// Delete from dirty_issues
 	_, err = tx.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, id)
 	if err != nil {
@@ -1477,6 +1587,13 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
+	// Dependents may have been blocked only by this issue
+	if len(dependentIDs) > 0 {
+		if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+			return fmt.Errorf("failed to invalidate blocked cache: %w", err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return wrapDBError("commit delete transaction", err)
 	}
@@ -1690,6 +1807,25 @@ func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause
 	// Note: This method now creates tombstones instead of hard-deleting
 	// Only dependencies are deleted - issues are converted to tombstones
 
+	// Capture what bd undo needs to restore each issue and its edges
+	undoActor, _ := storage.SessionFromContext(ctx)
+	if undoActor == "" {
+		undoActor = "batch delete"
+	}
+	undo := make(map[string]undoInverse, len(args))
+	for _, arg := range args {
+		id := arg.(string)
+		columns, err := snapshotIssueColumns(ctx, tx, id, deleteUndoColumns)
+		if err != nil {
+			return err
+		}
+		deps, err := snapshotDependencies(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		undo[id] = undoInverse{Columns: columns, Dependencies: deps}
+	}
+
 	// 1. Delete dependencies - tombstones don't block other issues
 	_, err := tx.ExecContext(ctx,
 		fmt.Sprintf(`DELETE FROM dependencies WHERE issue_id IN (%s) OR depends_on_id IN (%s)`, inClause, inClause),
@@ -1751,6 +1887,9 @@ func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause
 		if err != nil {
 			return fmt.Errorf("failed to record tombstone event for %s: %w", id, err)
 		}
+		if err := recordUndo(ctx, tx, id, UndoOpDelete, undoActor, undo[id]); err != nil {
+			return err
+		}
 
 		// Mark issue as dirty for incremental export
 		_, err = tx.ExecContext(ctx, `
@@ -2014,6 +2153,13 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, string(*filter.MolType))
 	}
 
+	// Multi-repo filtering
+	if filter.SourceRepo != nil {
+		clause, repoArgs := sourceRepoClause("source_repo", *filter.SourceRepo)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, repoArgs...)
+	}
+
 	// Time-based scheduling filters (GH#820)
 	if filter.Deferred {
 		whereClauses = append(whereClauses, "defer_until IS NOT NULL")
@@ -2059,7 +2205,7 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, source_formula, source_location
 		FROM issues
 		%s
 		ORDER BY priority ASC, created_at DESC
@@ -116,6 +116,13 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, string(*filter.MolType))
 	}
 
+	// Multi-repo filtering
+	if filter.SourceRepo != nil {
+		clause, repoArgs := sourceRepoClause("i.source_repo", *filter.SourceRepo)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, repoArgs...)
+	}
+
 	// Time-based deferral filtering (GH#820)
 	// By default, exclude issues where defer_until is in the future.
 	// If IncludeDeferred is true, skip this filter to show deferred issues.
@@ -123,6 +130,18 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		whereClauses = append(whereClauses, "(i.defer_until IS NULL OR datetime(i.defer_until) <= datetime('now'))")
 	}
 
+	// Capability routing: every needs:<cap> label must be a capability of the agent
+	if filter.ForAgent != "" {
+		whereClauses = append(whereClauses, `
+			NOT EXISTS (
+				SELECT 1 FROM labels n
+				WHERE n.issue_id = i.id AND n.label LIKE 'needs:%'
+				AND substr(n.label, 7) NOT IN (`+agentCapabilitiesSQL+`)
+			)
+		`)
+		args = append(args, filter.ForAgent, filter.ForAgent, filter.ForAgent, filter.ForAgent)
+	}
+
 	// Build WHERE clause properly
 	whereSQL := strings.Join(whereClauses, " AND ")
 
@@ -139,6 +158,11 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		sortPolicy = types.SortPolicyHybrid
 	}
 	orderBySQL := buildOrderByClause(sortPolicy)
+	if filter.ForAgent != "" {
+		// Best match first: work that uses more of the agent's capabilities
+		orderBySQL = strings.Replace(orderBySQL, "ORDER BY", `ORDER BY
+			(SELECT COUNT(*) FROM labels n WHERE n.issue_id = i.id AND n.label LIKE 'needs:%') DESC,`, 1)
+	}
 
 	// Use blocked_issues_cache for performance
 	// This optimization replaces the recursive CTE that computed blocked issues on every query.
@@ -160,7 +184,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		i.due_at, i.defer_until
+		i.due_at, i.defer_until, i.source_formula, i.source_location
 		FROM issues i
 		WHERE %s
 		AND NOT EXISTS (
@@ -193,6 +217,19 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	return issues, nil
 }
 
+// agentCapabilitiesSQL selects the capabilities of one agent bead, which is
+// bound four times: its can:<cap> labels, the skills attested for it, and
+// role:<role_type> and rig:<rig>. Mirrors types.AgentCapabilities.
+const agentCapabilitiesSQL = `
+	SELECT substr(label, 5) FROM labels WHERE issue_id = ? AND label LIKE 'can:%'
+	UNION
+	SELECT json_extract(metadata, '$.skill') FROM dependencies
+	WHERE depends_on_id = ? AND type = 'attests' AND json_extract(metadata, '$.skill') IS NOT NULL
+	UNION
+	SELECT 'role:' || role_type FROM issues WHERE id = ? AND role_type IS NOT NULL AND role_type != ''
+	UNION
+	SELECT 'rig:' || rig FROM issues WHERE id = ? AND rig IS NOT NULL AND rig != ''`
+
 // filterByExternalDeps removes issues that have unsatisfied external dependencies.
 // External deps have format: external:<project>:<capability>
 // They are satisfied when the target project has a closed issue with provides:<capability> label.
@@ -774,7 +811,7 @@ func (s *SQLiteStorage) GetNewlyUnblockedByClose(ctx context.Context, closedIssu
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		       i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		       i.due_at, i.defer_until
+		       i.due_at, i.defer_until, i.source_formula, i.source_location
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -805,6 +842,20 @@ func buildOrderByClause(policy types.SortPolicy) string {
 	case types.SortPolicyOldest:
 		return `ORDER BY i.created_at ASC`
 
+	case types.SortPolicyDue:
+		// Mirrors types.DueBoost: overdue +3, due within 24h +2, within 72h +1
+		return `ORDER BY
+			i.priority - CASE
+				WHEN i.due_at IS NULL THEN 0
+				WHEN datetime(i.due_at) < datetime('now') THEN 3
+				WHEN datetime(i.due_at) < datetime('now', '+24 hours') THEN 2
+				WHEN datetime(i.due_at) < datetime('now', '+72 hours') THEN 1
+				ELSE 0
+			END ASC,
+			CASE WHEN i.due_at IS NULL THEN 1 ELSE 0 END ASC,
+			datetime(i.due_at) ASC,
+			i.created_at ASC`
+
 	case types.SortPolicyHybrid:
 		fallthrough
 	default:
@@ -1905,3 +1905,67 @@ func TestIsBlocked(t *testing.T) {
 		t.Errorf("Expected issue3 to NOT be blocked (blocker is closed), got blocked=true with blockers=%v", blockers)
 	}
 }
+
+// TestReadyWorkForAgent tests capability routing: needs:<cap> labels must be
+// covered by the agent's can:<cap> labels, attested skills, role and rig.
+func TestReadyWorkForAgent(t *testing.T) {
+	env := newTestEnv(t)
+
+	agent := env.CreateIssue("Agent")
+	if err := env.Store.UpdateIssue(env.Ctx, agent.ID, map[string]interface{}{"role_type": "crew", "rig": "beads"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	reviewer := env.CreateIssue("Reviewer")
+	if err := env.Store.AddLabel(env.Ctx, agent.ID, "can:go", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	attest := &types.Dependency{
+		IssueID:     reviewer.ID,
+		DependsOnID: agent.ID,
+		Type:        types.DepAttests,
+		Metadata:    `{"skill":"db-migration","level":"expert"}`,
+	}
+	if err := env.Store.AddDependency(env.Ctx, attest, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	withNeeds := func(title string, needs ...string) *types.Issue {
+		issue := env.CreateIssue(title)
+		for _, n := range needs {
+			if err := env.Store.AddLabel(env.Ctx, issue.ID, types.NeedsLabelPrefix+n, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+	anyone := withNeeds("Anyone")
+	goOnly := withNeeds("Go", "go")
+	migration := withNeeds("Migration", "go", "db-migration", "rig:beads")
+	rust := withNeeds("Rust", "go", "rust")
+	mayor := withNeeds("Mayor", "role:mayor")
+
+	ready := env.GetReadyWork(types.WorkFilter{ForAgent: agent.ID, SortPolicy: types.SortPolicyOldest})
+	var got []string
+	for _, issue := range ready {
+		if issue.ID == agent.ID || issue.ID == reviewer.ID {
+			continue
+		}
+		got = append(got, issue.ID)
+	}
+	want := []string{migration.ID, goOnly.ID, anyone.ID}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ready for agent = %v, want %v (best match first)", got, want)
+	}
+	for _, issue := range []*types.Issue{rust, mayor} {
+		for _, id := range got {
+			if id == issue.ID {
+				t.Errorf("%s needs a capability the agent lacks", issue.Title)
+			}
+		}
+	}
+
+	// Without --for the needs: labels do not filter
+	if n := len(env.GetReadyWork(types.WorkFilter{})); n != 7 {
+		t.Errorf("ready work without ForAgent = %d issues, want 7", n)
+	}
+}
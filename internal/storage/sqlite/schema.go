@@ -47,6 +47,9 @@ CREATE TABLE IF NOT EXISTS issues (
     quality_score REAL,
     -- Federation source system field
     source_system TEXT DEFAULT '',
+    -- Formula source tracing fields (which formula step a poured issue came from)
+    source_formula TEXT DEFAULT '',
+    source_location TEXT DEFAULT '',
     -- Event fields (bd-ecmd)
     event_kind TEXT DEFAULT '',
     actor TEXT DEFAULT '',
@@ -113,6 +116,53 @@ CREATE TABLE IF NOT EXISTS comments (
 CREATE INDEX IF NOT EXISTS idx_comments_issue ON comments(issue_id);
 CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments(created_at);
 
+-- Attachments table (metadata only; content is a blob in .beads/blobs/<hash>)
+CREATE TABLE IF NOT EXISTS attachments (
+    issue_id TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    name TEXT NOT NULL,
+    size INTEGER NOT NULL DEFAULT 0,
+    mime_type TEXT DEFAULT '',
+    created_by TEXT DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, hash),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_hash ON attachments(hash);
+
+-- Work logs table (time tracking, ended_at NULL = timer still running)
+CREATE TABLE IF NOT EXISTS work_logs (
+    issue_id TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    note TEXT DEFAULT '',
+    PRIMARY KEY (issue_id, actor, started_at),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_work_logs_running ON work_logs(actor) WHERE ended_at IS NULL;
+
+-- Undo log: the inverse of each mutation, for bd undo (no FK: rows outlive hard deletes)
+CREATE TABLE IF NOT EXISTS undo_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    op TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    session TEXT DEFAULT '',
+    command TEXT DEFAULT '',
+    inverse TEXT NOT NULL,
+    stamp TEXT,
+    created_at DATETIME NOT NULL,
+    undone_at DATETIME,
+    undone_seq INTEGER,
+    undo_stamp TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_undo_log_actor ON undo_log(actor, created_at);
+CREATE INDEX IF NOT EXISTS idx_undo_log_issue ON undo_log(issue_id);
+
 -- Events table (audit trail)
 CREATE TABLE IF NOT EXISTS events (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
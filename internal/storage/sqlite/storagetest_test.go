@@ -0,0 +1,23 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/storagetest"
+)
+
+func TestStorageConformance(t *testing.T) {
+	storagetest.Run(t, storagetest.Backend{
+		Name: "sqlite",
+		New: func(t *testing.T) storage.Storage {
+			store, err := New(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+			if err != nil {
+				t.Fatalf("failed to create sqlite store: %v", err)
+			}
+			return store
+		},
+	})
+}
@@ -14,8 +14,8 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-// Verify sqliteTxStorage implements storage.Transaction at compile time
-var _ storage.Transaction = (*sqliteTxStorage)(nil)
+// Verify sqliteTxStorage implements storage.RenamingTransaction at compile time
+var _ storage.RenamingTransaction = (*sqliteTxStorage)(nil)
 
 // sqliteTxStorage implements the storage.Transaction interface for SQLite.
 // It wraps a dedicated database connection with an active transaction.
@@ -198,6 +198,9 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 	if err := recordCreatedEvent(ctx, t.conn, issue, actor); err != nil {
 		return fmt.Errorf("failed to record creation event: %w", err)
 	}
+	if err := recordUndo(ctx, t.conn, issue.ID, UndoOpCreate, actor, undoInverse{}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty for incremental export
 	if err := markDirty(ctx, t.conn, issue.ID); err != nil {
@@ -313,6 +316,11 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 	if err := recordCreatedEvents(ctx, t.conn, issues, actor); err != nil {
 		return fmt.Errorf("failed to record creation events: %w", err)
 	}
+	for _, issue := range issues {
+		if err := recordUndo(ctx, t.conn, issue.ID, UndoOpCreate, actor, undoInverse{}); err != nil {
+			return err
+		}
+	}
 
 	// Mark all issues as dirty
 	if err := markDirtyBatch(ctx, t.conn, issues); err != nil {
@@ -334,7 +342,7 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, source_formula, source_location
 		FROM issues
 		WHERE id = ?
 	`, id)
@@ -390,6 +398,12 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Capture the prior values for bd undo
+	undoColumns, err := snapshotIssueColumns(ctx, t.conn, id, updateUndoColumns(updates))
+	if err != nil {
+		return err
+	}
+
 	// Fetch custom statuses for validation
 	customStatuses, err := t.GetCustomStatuses(ctx)
 	if err != nil {
@@ -412,7 +426,7 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		}
 
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
-		args = append(args, value)
+		args = append(args, updateColumnValue(key, value))
 	}
 
 	// Auto-manage closed_at when status changes
@@ -463,6 +477,9 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, t.conn, id, UndoOpUpdate, actor, undoInverse{Columns: undoColumns}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty
 	if err := markDirty(ctx, t.conn, id); err != nil {
@@ -547,6 +564,12 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
 	now := time.Now()
 
+	// Capture the prior values for bd undo
+	undoColumns, err := snapshotIssueColumns(ctx, t.conn, id, closeUndoColumns)
+	if err != nil {
+		return err
+	}
+
 	result, err := t.conn.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?, closed_by_session = ?
 		WHERE id = ?
@@ -570,6 +593,9 @@ func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason stri
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, t.conn, id, UndoOpClose, actor, undoInverse{Columns: undoColumns}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty
 	if err := markDirty(ctx, t.conn, id); err != nil {
@@ -655,7 +681,18 @@ func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason stri
 		}
 	}
 
-	return nil
+	// Close cascades (close.cascade-* in config.yaml)
+	return t.parent.cascadeClose(ctx, t.conn, id, actor, session)
+}
+
+// UpdateIssueID renames an issue and its references within the transaction.
+// Foreign keys cannot be switched off inside a transaction, so their checks
+// are deferred to commit, by which point every reference has moved.
+func (t *sqliteTxStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	if _, err := t.conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+		return fmt.Errorf("failed to defer foreign keys: %w", err)
+	}
+	return renameIssue(ctx, t.conn, oldID, newID, issue, actor)
 }
 
 // DeleteIssue deletes an issue within the transaction.
@@ -746,9 +783,9 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 		dep.CreatedBy = actor
 	}
 
-	// Cycle detection - skip for relates-to (inherently bidirectional)
+	// Cycle detection - skip for relates-to and caused-by
 	// See dependencies.go for full rationale on cycle prevention
-	if dep.Type != types.DepRelatesTo {
+	if dep.Type.IsCycleChecked() {
 		var cycleExists bool
 		err = t.conn.QueryRowContext(ctx, `
 		WITH RECURSIVE paths AS (
@@ -757,7 +794,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 				depends_on_id,
 				1 as depth
 			FROM dependencies
-			WHERE issue_id = ?
+			WHERE issue_id = ? AND type NOT IN ('relates-to', 'caused-by')
 
 			UNION ALL
 
@@ -767,7 +804,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 				p.depth + 1
 			FROM dependencies d
 			JOIN paths p ON d.issue_id = p.depends_on_id
-			WHERE p.depth < 100
+			WHERE p.depth < 100 AND d.type NOT IN ('relates-to', 'caused-by')
 		)
 		SELECT EXISTS(
 			SELECT 1 FROM paths
@@ -803,6 +840,9 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, t.conn, dep.IssueID, UndoOpDepAdd, actor, undoInverse{Dependency: dep}); err != nil {
+		return err
+	}
 
 	// Mark issues as dirty - for external refs, only mark the source issue
 	if err := markDirty(ctx, t.conn, dep.IssueID); err != nil {
@@ -838,6 +878,12 @@ func (t *sqliteTxStorage) RemoveDependency(ctx context.Context, issueID, depends
 		needsCacheInvalidation = depType.AffectsReadyWork()
 	}
 
+	// Capture the edges for bd undo
+	removed, err := snapshotEdges(ctx, t.conn, issueID, dependsOnID)
+	if err != nil {
+		return err
+	}
+
 	result, err := t.conn.ExecContext(ctx, `
 		DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
 	`, issueID, dependsOnID)
@@ -862,6 +908,11 @@ func (t *sqliteTxStorage) RemoveDependency(ctx context.Context, issueID, depends
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if len(removed) > 0 {
+		if err := recordUndo(ctx, t.conn, issueID, UndoOpDepRemove, actor, undoInverse{Dependencies: removed}); err != nil {
+			return err
+		}
+	}
 
 	// Mark both issues as dirty
 	if err := markDirty(ctx, t.conn, issueID); err != nil {
@@ -907,6 +958,9 @@ func (t *sqliteTxStorage) AddLabel(ctx context.Context, issueID, label, actor st
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, t.conn, issueID, UndoOpLabelAdd, actor, undoInverse{Label: label}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty
 	if err := markDirty(ctx, t.conn, issueID); err != nil {
@@ -942,6 +996,9 @@ func (t *sqliteTxStorage) RemoveLabel(ctx context.Context, issueID, label, actor
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordUndo(ctx, t.conn, issueID, UndoOpLabelRemove, actor, undoInverse{Label: label}); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty
 	if err := markDirty(ctx, t.conn, issueID); err != nil {
@@ -1265,7 +1322,7 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, source_formula, source_location
 		FROM issues
 		%s
 		ORDER BY priority ASC, created_at DESC
@@ -1333,6 +1390,9 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	// Time-based scheduling fields
 	var dueAt sql.NullTime
 	var deferUntil sql.NullTime
+	// Formula source tracing fields
+	var sourceFormula sql.NullString
+	var sourceLocation sql.NullString
 
 	err := row.Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -1344,7 +1404,7 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 		&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &sourceFormula, &sourceLocation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1463,6 +1523,13 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	// Formula source tracing fields
+	if sourceFormula.Valid {
+		issue.SourceFormula = sourceFormula.String
+	}
+	if sourceLocation.Valid {
+		issue.SourceLocation = sourceLocation.String
+	}
 
 	return &issue, nil
 }
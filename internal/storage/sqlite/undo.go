@@ -0,0 +1,583 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Undo log ops: the kind of mutation an entry reverts.
+const (
+	UndoOpCreate      = "create"
+	UndoOpUpdate      = "update"
+	UndoOpClose       = "close"
+	UndoOpDelete      = "delete"
+	UndoOpLabelAdd    = "label_add"
+	UndoOpLabelRemove = "label_remove"
+	UndoOpDepAdd      = "dep_add"
+	UndoOpDepRemove   = "dep_remove"
+)
+
+// undoRetention is how long undo log entries are kept. Older entries are
+// pruned as new ones are recorded.
+const undoRetention = 7 * 24 * time.Hour
+
+// undoInverse is what reverting one mutation needs, stored as JSON.
+type undoInverse struct {
+	Columns      map[string]*string  `json:"columns,omitempty"` // Prior issue column values (update, close, delete)
+	Label        string              `json:"label,omitempty"`
+	Dependency   *types.Dependency   `json:"dependency,omitempty"`
+	Dependencies []*types.Dependency `json:"dependencies,omitempty"` // Edges a delete or dependency removal removed
+}
+
+// undoDB is satisfied by *sql.Tx and *sql.Conn.
+type undoDB interface {
+	execer
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// restorableColumns are the issue columns an undo may write back.
+var restorableColumns = map[string]bool{
+	"ephemeral":     true,
+	"content_hash":  true,
+	"deleted_at":    true,
+	"deleted_by":    true,
+	"delete_reason": true,
+	"original_type": true,
+}
+
+func isRestorableColumn(column string) bool {
+	return restorableColumns[column] || (allowedUpdateFields[column] && column != "wisp")
+}
+
+// Columns captured before closing or deleting an issue.
+var (
+	closeUndoColumns  = []string{"status", "closed_at", "close_reason", "closed_by_session", "content_hash"}
+	deleteUndoColumns = []string{"status", "closed_at", "deleted_at", "deleted_by", "delete_reason", "original_type"}
+)
+
+// updateUndoColumns returns the columns an UpdateIssue with updates may
+// change, including those it manages itself.
+func updateUndoColumns(updates map[string]interface{}) []string {
+	columns := []string{"closed_at", "close_reason", "content_hash"}
+	for key := range updates {
+		if key == "wisp" {
+			key = "ephemeral"
+		}
+		if key != "closed_at" && key != "close_reason" {
+			columns = append(columns, key)
+		}
+	}
+	return columns
+}
+
+// snapshotIssueColumns reads the current values of an issue's columns as
+// text, which SQLite converts back on write. Returns nil if the issue does
+// not exist.
+func snapshotIssueColumns(ctx context.Context, db undoDB, id string, columns []string) (map[string]*string, error) {
+	sel := make([]string, len(columns))
+	for i, c := range columns {
+		if !isRestorableColumn(c) {
+			return nil, fmt.Errorf("column %s cannot be restored", c)
+		}
+		sel[i] = fmt.Sprintf("CAST(%s AS TEXT)", c)
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	// #nosec G201 - columns are checked against restorableColumns
+	err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT %s FROM issues WHERE id = ?`, strings.Join(sel, ", ")), id).Scan(dest...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot issue %s: %w", id, err)
+	}
+	snapshot := make(map[string]*string, len(columns))
+	for i, c := range columns {
+		if values[i].Valid {
+			v := values[i].String
+			snapshot[c] = &v
+		} else {
+			snapshot[c] = nil
+		}
+	}
+	return snapshot, nil
+}
+
+// snapshotDependencies returns every edge from or to an issue.
+func snapshotDependencies(ctx context.Context, db undoDB, id string) ([]*types.Dependency, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id, type, created_at, created_by, COALESCE(metadata, ''), COALESCE(thread_id, '')
+		FROM dependencies WHERE issue_id = ? OR depends_on_id = ?
+	`, id, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot dependencies of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deps []*types.Dependency
+	for rows.Next() {
+		dep := &types.Dependency{}
+		var createdBy sql.NullString
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &createdBy, &dep.Metadata, &dep.ThreadID); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		dep.CreatedBy = createdBy.String
+		deps = append(deps, dep)
+	}
+	return deps, rows.Err()
+}
+
+// snapshotEdges returns every edge from issueID to dependsOnID, one per
+// dependency type.
+func snapshotEdges(ctx context.Context, db undoDB, issueID, dependsOnID string) ([]*types.Dependency, error) {
+	deps, err := snapshotDependencies(ctx, db, issueID)
+	if err != nil {
+		return nil, err
+	}
+	var edges []*types.Dependency
+	for _, dep := range deps {
+		if dep.IssueID == issueID && dep.DependsOnID == dependsOnID {
+			edges = append(edges, dep)
+		}
+	}
+	return edges, nil
+}
+
+// recordUndo appends the inverse of a mutation to the undo log, tagged with
+// the session from ctx (see storage.WithSession). An empty actor falls back
+// to the context's actor.
+func recordUndo(ctx context.Context, db undoDB, issueID, op, actor string, inv undoInverse) error {
+	ctxActor, session := storage.SessionFromContext(ctx)
+	command := storage.CommandFromContext(ctx)
+	if actor == "" {
+		actor = ctxActor
+	}
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return fmt.Errorf("failed to encode undo entry: %w", err)
+	}
+
+	// The issue's updated_at after the mutation detects later changes made
+	// outside the undo log
+	var stamp sql.NullString
+	err = db.QueryRowContext(ctx, `SELECT CAST(updated_at AS TEXT) FROM issues WHERE id = ?`, issueID).Scan(&stamp)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to read issue stamp: %w", err)
+	}
+
+	now := time.Now()
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO undo_log (issue_id, op, actor, session, command, inverse, stamp, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, issueID, op, actor, session, command, string(data), stamp, now)
+	if err != nil {
+		return fmt.Errorf("failed to record undo entry: %w", err)
+	}
+
+	// Prune expired entries every hundred records rather than on every write
+	if id, err := result.LastInsertId(); err == nil && id%100 == 0 {
+		if _, err := db.ExecContext(ctx, `DELETE FROM undo_log WHERE created_at < ?`, now.Add(-undoRetention)); err != nil {
+			return fmt.Errorf("failed to prune undo log: %w", err)
+		}
+	}
+	return nil
+}
+
+// UndoFilter selects undo log entries, newest first.
+type UndoFilter struct {
+	Actor   string    // Whose mutations to undo (required)
+	Session string    // Only this session's mutations, if set
+	Since   time.Time // Only mutations at or after this time, if set
+	Limit   int       // At most this many commands, if > 0
+}
+
+// UndoEntry is one recorded mutation.
+type UndoEntry struct {
+	ID        int64     `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Op        string    `json:"op"`
+	Actor     string    `json:"actor"`
+	Session   string    `json:"session,omitempty"`
+	Summary   string    `json:"summary"`
+	CreatedAt time.Time `json:"created_at"`
+
+	inverse undoInverse
+}
+
+// UndoConflict is an issue that changed after the mutation being undone.
+type UndoConflict struct {
+	IssueID string `json:"issue_id"`
+	Reason  string `json:"reason"`
+}
+
+// UndoResult reports the mutations Undo reverted, or would revert.
+type UndoResult struct {
+	Entries   []*UndoEntry   `json:"entries"`
+	Conflicts []UndoConflict `json:"conflicts,omitempty"`
+	Applied   bool           `json:"applied"`
+}
+
+// Undo reverts the mutations selected by filter, newest first, in one
+// transaction. If an issue changed since (by another actor or session, or
+// outside the undo log) nothing is reverted and the conflicts are reported,
+// unless force is set. With dryRun nothing is reverted either way.
+func (s *SQLiteStorage) Undo(ctx context.Context, filter UndoFilter, actor string, dryRun, force bool) (*UndoResult, error) {
+	if filter.Actor == "" {
+		return nil, fmt.Errorf("undo requires an actor")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrapDBError("begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	entries, err := selectUndoEntries(ctx, tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := &UndoResult{Entries: entries}
+	if len(entries) == 0 {
+		return result, nil
+	}
+	if result.Conflicts, err = findUndoConflicts(ctx, tx, entries); err != nil {
+		return nil, err
+	}
+	if dryRun || (len(result.Conflicts) > 0 && !force) {
+		return result, nil
+	}
+
+	var seq int64
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM undo_log`).Scan(&seq); err != nil {
+		return nil, fmt.Errorf("failed to read undo sequence: %w", err)
+	}
+	now := time.Now()
+	byIssue := make(map[string][]int64)
+	var touched []string
+	for _, e := range entries {
+		if err := applyUndo(ctx, tx, e, actor, now); err != nil {
+			return nil, fmt.Errorf("undoing %s of %s: %w", e.Summary, e.IssueID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, e.IssueID, "undone", actor, "Undid "+e.Summary); err != nil {
+			return nil, fmt.Errorf("failed to record event: %w", err)
+		}
+		if _, ok := byIssue[e.IssueID]; !ok {
+			touched = append(touched, e.IssueID)
+		}
+		byIssue[e.IssueID] = append(byIssue[e.IssueID], e.ID)
+		for _, dep := range append(e.inverse.Dependencies, e.inverse.Dependency) {
+			if dep != nil && !strings.HasPrefix(dep.DependsOnID, "external:") {
+				touched = append(touched, dep.IssueID, dep.DependsOnID)
+			}
+		}
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, touched); err != nil {
+		return nil, wrapDBError("mark issues dirty after undo", err)
+	}
+	for issueID, ids := range byIssue {
+		if _, err := tx.ExecContext(ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, now, issueID); err != nil {
+			return nil, fmt.Errorf("failed to touch %s: %w", issueID, err)
+		}
+		var stamp sql.NullString
+		if err := tx.QueryRowContext(ctx, `SELECT CAST(updated_at AS TEXT) FROM issues WHERE id = ?`, issueID).Scan(&stamp); err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to read issue stamp: %w", err)
+		}
+		for _, id := range ids {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE undo_log SET undone_at = ?, undone_seq = ?, undo_stamp = ? WHERE id = ?
+			`, now, seq, stamp, id); err != nil {
+				return nil, fmt.Errorf("failed to mark undo entry %d: %w", id, err)
+			}
+		}
+	}
+	if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, wrapDBError("commit undo", err)
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// undoGroupSQL groups entries by the command that recorded them; entries
+// recorded without one (see storage.WithCommand) stand alone.
+const undoGroupSQL = `COALESCE(NULLIF(command, ''), 'entry:' || id)`
+
+func selectUndoEntries(ctx context.Context, tx *sql.Tx, filter UndoFilter) ([]*UndoEntry, error) {
+	where := `undone_at IS NULL AND actor = ?`
+	args := []interface{}{filter.Actor}
+	if filter.Session != "" {
+		where += ` AND session = ?`
+		args = append(args, filter.Session)
+	}
+	if !filter.Since.IsZero() {
+		where += ` AND created_at >= ?`
+		args = append(args, filter.Since)
+	}
+
+	// #nosec G202 - where and undoGroupSQL are built from constants
+	query := `
+		SELECT id, issue_id, op, actor, COALESCE(session, ''), inverse, created_at
+		FROM undo_log WHERE ` + where
+	if filter.Limit > 0 {
+		// Undo whole commands: a bd delete, say, removes dependencies before
+		// tombstoning the issue, and one bd undo should revert all of it
+		query += ` AND ` + undoGroupSQL + ` IN (
+			SELECT ` + undoGroupSQL + ` AS grp FROM undo_log WHERE ` + where + `
+			GROUP BY grp ORDER BY MAX(id) DESC LIMIT ?
+		)`
+		args = append(args, args...)
+		args = append(args, filter.Limit)
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read undo log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := []*UndoEntry{}
+	for rows.Next() {
+		e := &UndoEntry{}
+		var inverse string
+		if err := rows.Scan(&e.ID, &e.IssueID, &e.Op, &e.Actor, &e.Session, &inverse, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan undo entry: %w", err)
+		}
+		if err := json.Unmarshal([]byte(inverse), &e.inverse); err != nil {
+			return nil, fmt.Errorf("undo entry %d is corrupt: %w", e.ID, err)
+		}
+		e.Summary = undoSummary(e)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// undoSummary describes the mutation an entry reverts.
+func undoSummary(e *UndoEntry) string {
+	switch e.Op {
+	case UndoOpUpdate:
+		var fields []string
+		for c := range e.inverse.Columns {
+			if c != "content_hash" && c != "closed_at" && c != "close_reason" {
+				fields = append(fields, c)
+			}
+		}
+		sort.Strings(fields)
+		return "update " + strings.Join(fields, ", ")
+	case UndoOpLabelAdd:
+		return "add label " + e.inverse.Label
+	case UndoOpLabelRemove:
+		return "remove label " + e.inverse.Label
+	case UndoOpDepAdd, UndoOpDepRemove:
+		verb := "add"
+		if e.Op == UndoOpDepRemove {
+			verb = "remove"
+		}
+		if dep := e.inverse.Dependency; dep != nil {
+			return fmt.Sprintf("%s dependency %s %s %s", verb, dep.IssueID, dep.Type, dep.DependsOnID)
+		}
+		if deps := e.inverse.Dependencies; len(deps) > 0 {
+			depTypes := make([]string, len(deps))
+			for i, dep := range deps {
+				depTypes[i] = string(dep.Type)
+			}
+			return fmt.Sprintf("%s dependency %s %s %s", verb, deps[0].IssueID, strings.Join(depTypes, "+"), deps[0].DependsOnID)
+		}
+		return verb + " dependency"
+	default:
+		return e.Op
+	}
+}
+
+// findUndoConflicts reports issues changed after the newest selected entry
+// touching them: by a later mutation that is not being undone, or outside
+// the undo log, which shows as an updated_at the log does not know.
+func findUndoConflicts(ctx context.Context, tx *sql.Tx, entries []*UndoEntry) ([]UndoConflict, error) {
+	selected := make(map[int64]bool, len(entries))
+	for _, e := range entries {
+		selected[e.ID] = true
+	}
+
+	var conflicts []UndoConflict
+	seen := make(map[string]bool)
+	for _, e := range entries { // Newest first: e is the newest entry for its issue
+		if seen[e.IssueID] {
+			continue
+		}
+		seen[e.IssueID] = true
+
+		reason, err := undoConflictReason(ctx, tx, e, selected)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			conflicts = append(conflicts, UndoConflict{IssueID: e.IssueID, Reason: reason})
+		}
+	}
+	return conflicts, nil
+}
+
+func undoConflictReason(ctx context.Context, tx *sql.Tx, e *UndoEntry, selected map[int64]bool) (string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, actor, op FROM undo_log
+		WHERE issue_id = ? AND id > ? AND undone_at IS NULL
+		ORDER BY id
+	`, e.IssueID, e.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check later changes: %w", err)
+	}
+	var reason string
+	for rows.Next() {
+		var id int64
+		var actor, op string
+		if err := rows.Scan(&id, &actor, &op); err != nil {
+			_ = rows.Close()
+			return "", fmt.Errorf("failed to scan undo entry: %w", err)
+		}
+		if !selected[id] && reason == "" {
+			reason = fmt.Sprintf("changed since by %s (%s)", actor, op)
+		}
+	}
+	_ = rows.Close()
+	if reason != "" || rows.Err() != nil {
+		return reason, rows.Err()
+	}
+
+	// The last write the log knows of: a mutation, or an undo of a later one
+	var expected sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT stamp FROM (
+			SELECT id AS seq, 0 AS kind, stamp FROM undo_log WHERE issue_id = ?
+			UNION ALL
+			SELECT undone_seq AS seq, 1 AS kind, undo_stamp AS stamp FROM undo_log
+			WHERE issue_id = ? AND undone_at IS NOT NULL
+		) ORDER BY seq DESC, kind DESC LIMIT 1
+	`, e.IssueID, e.IssueID).Scan(&expected)
+	if err != nil {
+		return "", fmt.Errorf("failed to read undo stamp: %w", err)
+	}
+	var current sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT CAST(updated_at AS TEXT) FROM issues WHERE id = ?`, e.IssueID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return "issue no longer exists", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read issue stamp: %w", err)
+	}
+	if current != expected {
+		return "changed since outside the undo log (sync or import)", nil
+	}
+	return "", nil
+}
+
+// applyUndo writes the inverse of one mutation.
+func applyUndo(ctx context.Context, tx *sql.Tx, e *UndoEntry, actor string, now time.Time) error {
+	inv := e.inverse
+	switch e.Op {
+	case UndoOpCreate:
+		_, err := tx.ExecContext(ctx, `
+			UPDATE issues
+			SET status = ?, closed_at = NULL, deleted_at = ?, deleted_by = ?, delete_reason = ?, original_type = issue_type
+			WHERE id = ?
+		`, types.StatusTombstone, now, actor, "undo", e.IssueID)
+		return err
+
+	case UndoOpUpdate, UndoOpClose, UndoOpDelete:
+		if err := restoreIssueColumns(ctx, tx, e.IssueID, inv.Columns); err != nil {
+			return err
+		}
+		for _, dep := range inv.Dependencies {
+			if err := restoreDependency(ctx, tx, dep); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case UndoOpLabelAdd:
+		_, err := tx.ExecContext(ctx, `DELETE FROM labels WHERE issue_id = ? AND label = ?`, e.IssueID, inv.Label)
+		return err
+
+	case UndoOpLabelRemove:
+		_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, e.IssueID, inv.Label)
+		return err
+
+	case UndoOpDepAdd:
+		if inv.Dependency == nil {
+			return fmt.Errorf("undo entry %d has no dependency", e.ID)
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ? AND type = ?`,
+			inv.Dependency.IssueID, inv.Dependency.DependsOnID, inv.Dependency.Type)
+		return err
+
+	case UndoOpDepRemove:
+		// Entries recorded before removals captured every typed edge carry one
+		removed := inv.Dependencies
+		if inv.Dependency != nil {
+			removed = append(removed, inv.Dependency)
+		}
+		if len(removed) == 0 {
+			return fmt.Errorf("undo entry %d has no dependency", e.ID)
+		}
+		for _, dep := range removed {
+			if err := restoreDependency(ctx, tx, dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown undo op %q", e.Op)
+}
+
+func restoreIssueColumns(ctx context.Context, tx *sql.Tx, id string, columns map[string]*string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(columns))
+	for c := range columns {
+		if !isRestorableColumn(c) {
+			return fmt.Errorf("column %s cannot be restored", c)
+		}
+		names = append(names, c)
+	}
+	sort.Strings(names)
+	setClauses := make([]string, len(names))
+	args := make([]interface{}, 0, len(names)+1)
+	for i, c := range names {
+		setClauses[i] = c + " = ?"
+		if v := columns[c]; v != nil {
+			args = append(args, *v)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	args = append(args, id)
+	// #nosec G201 - columns are checked against restorableColumns
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE issues SET %s WHERE id = ?`, strings.Join(setClauses, ", ")), args...)
+	return err
+}
+
+func restoreDependency(ctx context.Context, tx *sql.Tx, dep *types.Dependency) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy, dep.Metadata, dep.ThreadID)
+	return err
+}
@@ -0,0 +1,251 @@
+package sqlite
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUndoRevertsMutations(t *testing.T) {
+	env := newTestEnv(t)
+	s, ctx := env.Store, env.Ctx
+	a := env.CreateIssue("Original title")
+	b := env.CreateIssue("Blocker")
+
+	if err := s.UpdateIssue(ctx, a.ID, map[string]interface{}{"title": "Renamed", "priority": 0}, "test-user"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddLabel(ctx, a.ID, "urgent", "test-user"); err != nil {
+		t.Fatal(err)
+	}
+	env.AddDep(a, b)
+	env.Close(b, "done")
+
+	result, err := s.Undo(ctx, UndoFilter{Actor: "test-user", Limit: 4}, "test-user", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Applied || len(result.Entries) != 4 || len(result.Conflicts) != 0 {
+		t.Fatalf("Undo = %+v", result)
+	}
+	if result.Entries[0].IssueID != b.ID || result.Entries[0].Op != UndoOpClose {
+		t.Errorf("newest entry = %+v, want close of %s", result.Entries[0], b.ID)
+	}
+
+	got, _ := s.GetIssue(ctx, a.ID)
+	if got.Title != "Original title" || got.Priority != 2 {
+		t.Errorf("a = %q p%d, want original title and priority", got.Title, got.Priority)
+	}
+	if labels, _ := s.GetLabels(ctx, a.ID); len(labels) != 0 {
+		t.Errorf("labels = %v, want none", labels)
+	}
+	if deps, _ := s.GetDependencies(ctx, a.ID); len(deps) != 0 {
+		t.Errorf("dependencies = %d, want none", len(deps))
+	}
+	got, _ = s.GetIssue(ctx, b.ID)
+	if got.Status != types.StatusOpen || got.ClosedAt != nil || got.CloseReason != "" {
+		t.Errorf("b = %s closed_at=%v reason=%q, want reopened", got.Status, got.ClosedAt, got.CloseReason)
+	}
+
+	// Undoing the creates leaves tombstones
+	result, err = s.Undo(ctx, UndoFilter{Actor: "test-user", Limit: 2}, "test-user", false, false)
+	if err != nil || !result.Applied {
+		t.Fatalf("Undo creates = %+v, %v", result, err)
+	}
+	for _, id := range []string{a.ID, b.ID} {
+		if got, _ := s.GetIssue(ctx, id); got == nil || got.Status != types.StatusTombstone {
+			t.Errorf("%s should be a tombstone, got %+v", id, got)
+		}
+	}
+
+	// Nothing left
+	result, err = s.Undo(ctx, UndoFilter{Actor: "test-user", Limit: 1}, "test-user", false, false)
+	if err != nil || len(result.Entries) != 0 || result.Applied {
+		t.Errorf("empty Undo = %+v, %v", result, err)
+	}
+}
+
+func TestUndoDetectsConflicts(t *testing.T) {
+	env := newTestEnv(t)
+	s, ctx := env.Store, env.Ctx
+	issue := env.CreateIssue("Original")
+
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "By alice"}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := UndoFilter{Actor: "alice", Limit: 1}
+	result, err := s.Undo(ctx, filter, "alice", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applied || len(result.Conflicts) != 1 || !strings.Contains(result.Conflicts[0].Reason, "bob") {
+		t.Fatalf("Undo = %+v, want a conflict with bob", result)
+	}
+	if got, _ := s.GetIssue(ctx, issue.ID); got.Title != "By alice" {
+		t.Errorf("conflicting undo changed the title to %q", got.Title)
+	}
+
+	// Dry run reports the same without applying
+	result, err = s.Undo(ctx, filter, "alice", true, true)
+	if err != nil || result.Applied || len(result.Entries) != 1 || len(result.Conflicts) != 1 {
+		t.Fatalf("dry run = %+v, %v", result, err)
+	}
+
+	// Undoing bob first clears the conflict for alice
+	if result, err := s.Undo(ctx, UndoFilter{Actor: "bob"}, "bob", false, false); err != nil || !result.Applied {
+		t.Fatalf("Undo bob = %+v, %v", result, err)
+	}
+	result, err = s.Undo(ctx, filter, "alice", false, false)
+	if err != nil || !result.Applied {
+		t.Fatalf("Undo alice = %+v, %v", result, err)
+	}
+	if got, _ := s.GetIssue(ctx, issue.ID); got.Title != "Original" || got.Priority != 2 {
+		t.Errorf("issue = %q p%d, want the original", got.Title, got.Priority)
+	}
+}
+
+func TestUndoDetectsChangesOutsideLog(t *testing.T) {
+	env := newTestEnv(t)
+	s, ctx := env.Store, env.Ctx
+	issue := env.CreateIssue("Original")
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Changed"}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write that bypasses the storage methods, like an import or sync merge
+	later := time.Now().Add(time.Second)
+	if _, err := s.db.ExecContext(ctx, `UPDATE issues SET notes = 'x', updated_at = ? WHERE id = ?`, later, issue.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.Undo(ctx, UndoFilter{Actor: "alice"}, "alice", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applied || len(result.Conflicts) != 1 {
+		t.Fatalf("Undo = %+v, want a conflict", result)
+	}
+	result, err = s.Undo(ctx, UndoFilter{Actor: "alice"}, "alice", false, true)
+	if err != nil || !result.Applied {
+		t.Fatalf("forced Undo = %+v, %v", result, err)
+	}
+	if got, _ := s.GetIssue(ctx, issue.ID); got.Title != "Original" {
+		t.Errorf("title = %q, want Original", got.Title)
+	}
+}
+
+func TestUndoRevertsWholeCommand(t *testing.T) {
+	env := newTestEnv(t)
+	s := env.Store
+	a := env.CreateIssue("Dependent")
+	b := env.CreateIssue("Deleted")
+	env.AddDep(a, b)
+
+	// bd delete removes the dependencies, then tombstones the issue
+	ctx := storage.WithCommand(storage.WithSession(env.Ctx, "alice", "session-1"), "cmd-1")
+	if err := s.RemoveDependency(ctx, a.ID, b.ID, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateTombstone(ctx, b.ID, "alice", "no longer needed"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.Undo(env.Ctx, UndoFilter{Actor: "alice", Session: "session-1", Limit: 1}, "alice", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Applied || len(result.Entries) != 2 {
+		t.Fatalf("Undo = %+v, want both entries of the command", result)
+	}
+	if got, _ := s.GetIssue(env.Ctx, b.ID); got.Status != types.StatusOpen || got.DeletedAt != nil {
+		t.Errorf("b = %s deleted_at=%v, want restored", got.Status, got.DeletedAt)
+	}
+	if deps, _ := s.GetDependencies(env.Ctx, a.ID); len(deps) != 1 || deps[0].ID != b.ID {
+		t.Errorf("dependencies = %v, want %s restored", deps, b.ID)
+	}
+}
+
+func TestUndoBatchDelete(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.CreateIssue("Dependent")
+	b := env.CreateIssue("Deleted")
+	env.AddDep(a, b)
+	if err := env.Store.AddLabel(env.Ctx, b.ID, "keep", "test-user"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := storage.WithSession(env.Ctx, "alice", "")
+	if _, err := env.Store.DeleteIssues(ctx, []string{b.ID}, false, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := env.Store.GetIssue(env.Ctx, b.ID); got.Status != types.StatusTombstone {
+		t.Fatalf("b = %s, want tombstone", got.Status)
+	}
+
+	result, err := env.Store.Undo(env.Ctx, UndoFilter{Actor: "alice"}, "alice", false, false)
+	if err != nil || !result.Applied {
+		t.Fatalf("Undo = %+v, %v", result, err)
+	}
+	if got, _ := env.Store.GetIssue(env.Ctx, b.ID); got.Status != types.StatusOpen || got.Title != "Deleted" {
+		t.Errorf("b = %s %q, want restored", got.Status, got.Title)
+	}
+	if deps, _ := env.Store.GetDependencies(env.Ctx, a.ID); len(deps) != 1 {
+		t.Errorf("dependencies = %d, want 1 restored", len(deps))
+	}
+}
+
+func TestUndoTypedDependencies(t *testing.T) {
+	env := newTestEnv(t)
+	s, ctx := env.Store, env.Ctx
+	a := env.CreateIssue("Dependent")
+	b := env.CreateIssue("Target")
+	env.AddDepType(a, b, types.DepBlocks)
+	env.AddDepType(a, b, types.DepCausedBy)
+
+	depTypes := func() []string {
+		t.Helper()
+		deps, err := s.GetDependencyRecords(ctx, a.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, dep := range deps {
+			got = append(got, string(dep.Type))
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	// Undoing one add keeps the other typed edge
+	result, err := s.Undo(ctx, UndoFilter{Actor: "test-user", Limit: 1}, "test-user", false, false)
+	if err != nil || !result.Applied {
+		t.Fatalf("Undo add = %+v, %v", result, err)
+	}
+	if got := depTypes(); len(got) != 1 || got[0] != string(types.DepBlocks) {
+		t.Fatalf("after undoing caused-by add, edges = %v, want [blocks]", got)
+	}
+
+	// Undoing a removal restores every typed edge it removed
+	env.AddDepType(a, b, types.DepCausedBy)
+	if err := s.RemoveDependency(ctx, a.ID, b.ID, "test-user"); err != nil {
+		t.Fatal(err)
+	}
+	if got := depTypes(); len(got) != 0 {
+		t.Fatalf("after removal, edges = %v, want none", got)
+	}
+	result, err = s.Undo(ctx, UndoFilter{Actor: "test-user", Limit: 1}, "test-user", false, false)
+	if err != nil || !result.Applied {
+		t.Fatalf("Undo remove = %+v, %v", result, err)
+	}
+	if got := depTypes(); len(got) != 2 || got[0] != string(types.DepBlocks) || got[1] != string(types.DepCausedBy) {
+		t.Errorf("after undoing removal, edges = %v, want [blocks caused-by]", got)
+	}
+}
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddWorkLog records a work log entry on an issue. Entries are keyed by
+// (issue, actor, start), so adding an entry that already exists updates it:
+// a stop time fills in a running timer and a non-empty note replaces the old
+// one. A running entry never reopens a stopped one, which keeps re-imports of
+// older JSONL harmless. Times are stored in UTC at second precision.
+func (s *SQLiteStorage) AddWorkLog(ctx context.Context, issueID string, entry *types.WorkLog, actor string) error {
+	if entry == nil || entry.StartedAt.IsZero() {
+		return fmt.Errorf("work log start time is required")
+	}
+	if entry.Actor == "" {
+		entry.Actor = actor
+	}
+	entry.StartedAt = entry.StartedAt.UTC().Truncate(time.Second)
+	if entry.EndedAt != nil {
+		ended := entry.EndedAt.UTC().Truncate(time.Second)
+		if ended.Before(entry.StartedAt) {
+			return fmt.Errorf("work log ends before it starts")
+		}
+		entry.EndedAt = &ended
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+
+		var endedAt sql.NullTime
+		var note sql.NullString
+		err := tx.QueryRowContext(ctx, `
+			SELECT ended_at, note FROM work_logs
+			WHERE issue_id = ? AND actor = ? AND started_at = ?
+		`, issueID, entry.Actor, entry.StartedAt).Scan(&endedAt, &note)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return fmt.Errorf("failed to check existing work log: %w", err)
+		default:
+			if entry.EndedAt == nil && endedAt.Valid {
+				t := endedAt.Time.UTC()
+				entry.EndedAt = &t
+			}
+			if entry.Note == "" {
+				entry.Note = note.String
+			}
+			if endedAt.Valid == (entry.EndedAt != nil) &&
+				(!endedAt.Valid || endedAt.Time.Equal(*entry.EndedAt)) &&
+				note.String == entry.Note {
+				return nil // Unchanged, don't record an event
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO work_logs (issue_id, actor, started_at, ended_at, note)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (issue_id, actor, started_at) DO UPDATE SET
+				ended_at = excluded.ended_at,
+				note = excluded.note
+		`, issueID, entry.Actor, entry.StartedAt, entry.EndedAt, entry.Note)
+		if err != nil {
+			return fmt.Errorf("failed to add work log: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, issueID, types.EventWorkLogged, actor, workLogEventComment(entry))
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+
+		// Work logs are exported with the issue, so mark it dirty
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO dirty_issues (issue_id, marked_at)
+			VALUES (?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+		`, issueID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetWorkLogs returns the work log entries on an issue, oldest first.
+func (s *SQLiteStorage) GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error) {
+	result, err := s.GetWorkLogsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetWorkLogsForIssues fetches work logs for multiple issues in a single query
+// Returns a map of issue_id -> []*WorkLog
+func (s *SQLiteStorage) GetWorkLogsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLog, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.WorkLog), nil
+	}
+
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT issue_id, actor, started_at, ended_at, note
+		FROM work_logs
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, started_at ASC, actor ASC
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get work logs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make(map[string][]*types.WorkLog)
+	for rows.Next() {
+		var issueID string
+		var endedAt sql.NullTime
+		var note sql.NullString
+		w := &types.WorkLog{}
+		if err := rows.Scan(&issueID, &w.Actor, &w.StartedAt, &endedAt, &note); err != nil {
+			return nil, fmt.Errorf("failed to scan work log: %w", err)
+		}
+		w.StartedAt = w.StartedAt.UTC()
+		if endedAt.Valid {
+			t := endedAt.Time.UTC()
+			w.EndedAt = &t
+		}
+		w.Note = note.String
+		result[issueID] = append(result[issueID], w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating work logs: %w", err)
+	}
+	return result, nil
+}
+
+// workLogEventComment describes a work log change for the audit trail.
+func workLogEventComment(w *types.WorkLog) string {
+	if w.EndedAt == nil {
+		return fmt.Sprintf("Started work (%s)", w.Actor)
+	}
+	return fmt.Sprintf("Logged %s of work (%s)", w.EndedAt.Sub(w.StartedAt).Round(time.Minute), w.Actor)
+}
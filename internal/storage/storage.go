@@ -75,6 +75,14 @@ type Transaction interface {
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 }
 
+// RenamingTransaction is a Transaction that can also rename issues, for
+// callers that must rename several issues atomically. It is optional: check
+// for it with a type assertion on the Transaction passed to RunInTransaction.
+type RenamingTransaction interface {
+	Transaction
+	UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error
+}
+
 // Storage defines the interface for issue storage backends
 type Storage interface {
 	// Issues
@@ -99,6 +107,9 @@ type Storage interface {
 	GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error)
 	GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error)
 	DetectCycles(ctx context.Context) ([][]*types.Issue, error)
+	// Knowledge graph queries walk edges in both directions; empty edgeTypes means all types
+	GetNeighborhood(ctx context.Context, issueID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphNeighbor, error)
+	FindPath(ctx context.Context, fromID, toID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphPathStep, error)
 
 	// Labels
 	AddLabel(ctx context.Context, issueID, label, actor string) error
@@ -124,6 +135,17 @@ type Storage interface {
 	GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error)
 	GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error)
 
+	// Attachments (metadata only; blob content lives in .beads/blobs)
+	AddAttachment(ctx context.Context, issueID string, attachment *types.Attachment, actor string) error
+	RemoveAttachment(ctx context.Context, issueID, hash, actor string) error
+	GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error)
+	GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error)
+
+	// Work logs (time tracking). AddWorkLog upserts by (issue, actor, start).
+	AddWorkLog(ctx context.Context, issueID string, entry *types.WorkLog, actor string) error
+	GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error)
+	GetWorkLogsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLog, error)
+
 	// Statistics
 	GetStatistics(ctx context.Context) (*types.Statistics, error)
 
@@ -77,6 +77,12 @@ func (m *mockStorage) GetDependencyTree(ctx context.Context, issueID string, max
 func (m *mockStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	return nil, nil
 }
+func (m *mockStorage) GetNeighborhood(ctx context.Context, issueID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphNeighbor, error) {
+	return nil, nil
+}
+func (m *mockStorage) FindPath(ctx context.Context, fromID, toID string, maxDepth int, edgeTypes []types.DependencyType) ([]*types.GraphPathStep, error) {
+	return nil, nil
+}
 func (m *mockStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
 	return nil
 }
@@ -125,6 +131,27 @@ func (m *mockStorage) GetIssueComments(ctx context.Context, issueID string) ([]*
 func (m *mockStorage) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
 	return nil, nil
 }
+func (m *mockStorage) AddAttachment(ctx context.Context, issueID string, attachment *types.Attachment, actor string) error {
+	return nil
+}
+func (m *mockStorage) RemoveAttachment(ctx context.Context, issueID, hash, actor string) error {
+	return nil
+}
+func (m *mockStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	return nil, nil
+}
+func (m *mockStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	return nil, nil
+}
+func (m *mockStorage) AddWorkLog(ctx context.Context, issueID string, entry *types.WorkLog, actor string) error {
+	return nil
+}
+func (m *mockStorage) GetWorkLogs(ctx context.Context, issueID string) ([]*types.WorkLog, error) {
+	return nil, nil
+}
+func (m *mockStorage) GetWorkLogsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLog, error) {
+	return nil, nil
+}
 func (m *mockStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	return nil, nil
 }
@@ -222,6 +249,9 @@ func (m *mockTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 func (m *mockTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
 	return nil
 }
+func (m *mockTransaction) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	return nil
+}
 func (m *mockTransaction) DeleteIssue(ctx context.Context, id string) error {
 	return nil
 }
@@ -0,0 +1,115 @@
+package storagetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func attachmentCases() []testCase {
+	return []testCase{
+		{name: "AddListRemove", fn: testAttachmentAddListRemove},
+		{name: "ReattachUpdates", fn: testAttachmentReattach},
+		{name: "ForIssues", fn: testAttachmentsForIssues},
+		{name: "UnknownIssue", fn: testAttachmentUnknownIssue},
+	}
+}
+
+func blobHash(c byte) string {
+	return strings.Repeat(string(c), 64)
+}
+
+func testAttachmentAddListRemove(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "has logs", 2)
+
+	log := &types.Attachment{Hash: blobHash('a'), Name: "build.log", Size: 1200, MimeType: "text/plain"}
+	if err := s.AddAttachment(ctx, issue.ID, log, "alice"); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	shot := &types.Attachment{Hash: blobHash('b'), Name: "shot.png", Size: 4096, MimeType: "image/png"}
+	if err := s.AddAttachment(ctx, issue.ID, shot, "bob"); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+
+	got, err := s.GetAttachments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetAttachments failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetAttachments returned %d attachments, want 2", len(got))
+	}
+	byHash := map[string]*types.Attachment{got[0].Hash: got[0], got[1].Hash: got[1]}
+	a := byHash[log.Hash]
+	if a == nil || a.Name != "build.log" || a.Size != 1200 || a.MimeType != "text/plain" || a.CreatedBy != "alice" {
+		t.Errorf("log attachment = %+v", a)
+	}
+	if a != nil && a.CreatedAt.IsZero() {
+		t.Error("attachment CreatedAt not set")
+	}
+
+	if err := s.RemoveAttachment(ctx, issue.ID, log.Hash, "alice"); err != nil {
+		t.Fatalf("RemoveAttachment failed: %v", err)
+	}
+	got, err = s.GetAttachments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetAttachments failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Hash != shot.Hash {
+		t.Errorf("after remove = %+v, want only shot.png", got)
+	}
+	if err := s.RemoveAttachment(ctx, issue.ID, log.Hash, "alice"); err == nil {
+		t.Error("removing a missing attachment should fail")
+	}
+}
+
+func testAttachmentReattach(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "renamed upload", 2)
+
+	if err := s.AddAttachment(ctx, issue.ID, &types.Attachment{Hash: blobHash('c'), Name: "out.txt", Size: 3}, "alice"); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if err := s.AddAttachment(ctx, issue.ID, &types.Attachment{Hash: blobHash('c'), Name: "output.txt", Size: 3}, "bob"); err != nil {
+		t.Fatalf("AddAttachment (re-attach) failed: %v", err)
+	}
+	got, err := s.GetAttachments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetAttachments failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "output.txt" {
+		t.Errorf("re-attach = %+v, want a single output.txt", got)
+	}
+}
+
+func testAttachmentsForIssues(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	for i, id := range []string{a.ID, a.ID, b.ID} {
+		att := &types.Attachment{Hash: blobHash("def"[i]), Name: "f", Size: 1}
+		if err := s.AddAttachment(ctx, id, att, "alice"); err != nil {
+			t.Fatalf("AddAttachment failed: %v", err)
+		}
+	}
+
+	got, err := s.GetAttachmentsForIssues(ctx, []string{a.ID, b.ID, c.ID})
+	if err != nil {
+		t.Fatalf("GetAttachmentsForIssues failed: %v", err)
+	}
+	if len(got[a.ID]) != 2 || len(got[b.ID]) != 1 || len(got[c.ID]) != 0 {
+		t.Errorf("attachment counts = %d/%d/%d, want 2/1/0", len(got[a.ID]), len(got[b.ID]), len(got[c.ID]))
+	}
+}
+
+func testAttachmentUnknownIssue(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	err := s.AddAttachment(ctx, Prefix+"-missing", &types.Attachment{Hash: blobHash('e'), Name: "f"}, "alice")
+	if err == nil {
+		t.Error("AddAttachment on a missing issue should fail")
+	}
+}
@@ -0,0 +1,196 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func cascadeCases() []testCase {
+	return []testCase{
+		{name: "Epics", fn: testCascadeEpics},
+		{name: "SkipsBlocked", fn: testCascadeSkipsBlocked},
+		{name: "Delegated", fn: testCascadeDelegated},
+		{name: "Until", fn: testCascadeUntil},
+		{name: "Off", fn: testCascadeOff},
+		{name: "InTransaction", skip: noTransactions, fn: testCascadeInTransaction},
+	}
+}
+
+// setCascadeRules turns the close.cascade-* rules on or off for one test.
+func setCascadeRules(t *testing.T, epics, delegated, until bool) {
+	t.Helper()
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("config.Initialize failed: %v", err)
+	}
+	config.Set("close.cascade-epics", epics)
+	config.Set("close.cascade-delegated", delegated)
+	config.Set("close.cascade-until", until)
+	t.Cleanup(func() {
+		config.Set("close.cascade-epics", false)
+		config.Set("close.cascade-delegated", false)
+		config.Set("close.cascade-until", false)
+	})
+}
+
+func closeIssue(t *testing.T, s storage.Storage, id string) {
+	t.Helper()
+	if err := s.CloseIssue(context.Background(), id, "done", "storagetest", ""); err != nil {
+		t.Fatalf("CloseIssue(%s) failed: %v", id, err)
+	}
+}
+
+func requireStatus(t *testing.T, s storage.Storage, id string, want types.Status) *types.Issue {
+	t.Helper()
+	got := mustGet(t, s, id)
+	if got.Status != want {
+		t.Fatalf("%s status = %s, want %s", id, got.Status, want)
+	}
+	return got
+}
+
+// requireCascaded checks what storage.CascadedCloses reports for id.
+func requireCascaded(t *testing.T, s storage.Storage, id string, want ...string) {
+	t.Helper()
+	closed, err := storage.CascadedCloses(context.Background(), s, id)
+	if err != nil {
+		t.Fatalf("CascadedCloses(%s) failed: %v", id, err)
+	}
+	if got := ids(closed); !sameSet(got, want) {
+		t.Errorf("CascadedCloses(%s) = %v, want %v", id, got, want)
+	}
+}
+
+// requireNoCycles checks that the caused-by edges a cascade adds back to the
+// trigger are not reported as dependency cycles.
+func requireNoCycles(t *testing.T, s storage.Storage) {
+	t.Helper()
+	cycles, err := s.DetectCycles(context.Background())
+	if err != nil {
+		t.Fatalf("DetectCycles failed: %v", err)
+	}
+	for _, cycle := range cycles {
+		t.Errorf("DetectCycles reported a cycle after a cascade: %v", ids(cycle))
+	}
+}
+
+func testCascadeEpics(t *testing.T, s storage.Storage) {
+	setCascadeRules(t, true, false, false)
+	outer := create(t, s, issueSpec{title: "outer", itype: types.TypeEpic})
+	inner := create(t, s, issueSpec{title: "inner", itype: types.TypeEpic})
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	link(t, s, inner.ID, outer.ID, types.DepParentChild)
+	link(t, s, a.ID, inner.ID, types.DepParentChild)
+	link(t, s, b.ID, inner.ID, types.DepParentChild)
+
+	closeIssue(t, s, a.ID)
+	requireStatus(t, s, inner.ID, types.StatusOpen)
+	requireCascaded(t, s, a.ID)
+
+	// Closing the last child closes the epic, which closes its own parent
+	closeIssue(t, s, b.ID)
+	got := requireStatus(t, s, inner.ID, types.StatusClosed)
+	if want := storage.CascadeReason("All children closed", b.ID); got.CloseReason != want {
+		t.Errorf("close reason = %q, want %q", got.CloseReason, want)
+	}
+	requireStatus(t, s, outer.ID, types.StatusClosed)
+	requireCascaded(t, s, b.ID, inner.ID, outer.ID)
+
+	deps, err := s.GetDependencyRecords(context.Background(), inner.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	causedBy := false
+	for _, dep := range deps {
+		causedBy = causedBy || (dep.Type == types.DepCausedBy && dep.DependsOnID == b.ID)
+	}
+	if !causedBy {
+		t.Errorf("%s has no caused-by edge to %s: %+v", inner.ID, b.ID, deps)
+	}
+	requireNoCycles(t, s)
+}
+
+func testCascadeSkipsBlocked(t *testing.T, s storage.Storage) {
+	setCascadeRules(t, true, false, false)
+	epic := create(t, s, issueSpec{title: "blocked epic", itype: types.TypeEpic})
+	blocker := task(t, s, "blocker", 2)
+	link(t, s, epic.ID, blocker.ID, types.DepBlocks)
+	plain := task(t, s, "plain parent", 2)
+	child := task(t, s, "child", 2)
+	other := task(t, s, "other child", 2)
+	link(t, s, child.ID, epic.ID, types.DepParentChild)
+	link(t, s, other.ID, plain.ID, types.DepParentChild)
+
+	closeIssue(t, s, child.ID)
+	closeIssue(t, s, other.ID)
+	requireStatus(t, s, epic.ID, types.StatusOpen)
+	requireStatus(t, s, plain.ID, types.StatusOpen)
+}
+
+func testCascadeDelegated(t *testing.T, s storage.Storage) {
+	setCascadeRules(t, false, true, false)
+	origin := task(t, s, "origin", 2)
+	x := task(t, s, "delegate x", 2)
+	y := task(t, s, "delegate y", 2)
+	link(t, s, x.ID, origin.ID, types.DepDelegatedFrom)
+	link(t, s, y.ID, origin.ID, types.DepDelegatedFrom)
+
+	closeIssue(t, s, x.ID)
+	requireStatus(t, s, origin.ID, types.StatusOpen)
+	closeIssue(t, s, y.ID)
+	got := requireStatus(t, s, origin.ID, types.StatusClosed)
+	if want := storage.CascadeReason("Delegated work completed", y.ID); got.CloseReason != want {
+		t.Errorf("close reason = %q, want %q", got.CloseReason, want)
+	}
+	requireCascaded(t, s, y.ID, origin.ID)
+	requireCascaded(t, s, x.ID)
+	requireNoCycles(t, s)
+}
+
+func testCascadeUntil(t *testing.T, s storage.Storage) {
+	setCascadeRules(t, false, false, true)
+	muted := task(t, s, "muted alert", 2)
+	incident := task(t, s, "incident", 2)
+	link(t, s, muted.ID, incident.ID, types.DepUntil)
+
+	closeIssue(t, s, incident.ID)
+	requireStatus(t, s, muted.ID, types.StatusClosed)
+	requireCascaded(t, s, incident.ID, muted.ID)
+}
+
+func testCascadeOff(t *testing.T, s storage.Storage) {
+	setCascadeRules(t, false, false, false)
+	epic := create(t, s, issueSpec{title: "epic", itype: types.TypeEpic})
+	child := task(t, s, "child", 2)
+	link(t, s, child.ID, epic.ID, types.DepParentChild)
+
+	closeIssue(t, s, child.ID)
+	requireStatus(t, s, epic.ID, types.StatusOpen)
+
+	// A caused-by edge recorded by hand is not a cascade
+	other := task(t, s, "closed by hand", 2)
+	link(t, s, other.ID, child.ID, types.DepCausedBy)
+	closeIssue(t, s, other.ID)
+	requireCascaded(t, s, child.ID)
+}
+
+func testCascadeInTransaction(t *testing.T, s storage.Storage) {
+	setCascadeRules(t, true, false, false)
+	ctx := context.Background()
+	epic := create(t, s, issueSpec{title: "epic", itype: types.TypeEpic})
+	child := task(t, s, "child", 2)
+	link(t, s, child.ID, epic.ID, types.DepParentChild)
+
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.CloseIssue(ctx, child.ID, "done", "storagetest", "")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	requireStatus(t, s, epic.ID, types.StatusClosed)
+	requireCascaded(t, s, child.ID, epic.ID)
+}
@@ -0,0 +1,123 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func commentCases() []testCase {
+	return []testCase{
+		{name: "AddAndList", fn: testCommentAddAndList},
+		{name: "ForIssues", fn: testCommentsForIssues},
+		{name: "EventsRecorded", skip: noEventLog, fn: testEventsRecorded},
+		{name: "EventsLimit", skip: noEventLog, fn: testEventsLimit},
+	}
+}
+
+func testCommentAddAndList(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "discussed", 2)
+
+	first, err := s.AddIssueComment(ctx, issue.ID, "alice", "first")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if first.IssueID != issue.ID || first.Author != "alice" || first.Text != "first" {
+		t.Errorf("returned comment = %+v", first)
+	}
+	if first.CreatedAt.IsZero() {
+		t.Error("comment CreatedAt not set")
+	}
+	if _, err := s.AddIssueComment(ctx, issue.ID, "bob", "second"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	comments, err := s.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Text != "first" || comments[1].Text != "second" {
+		t.Fatalf("comments = %+v, want [first second] in order", comments)
+	}
+	if comments[1].Author != "bob" {
+		t.Errorf("second author = %q, want bob", comments[1].Author)
+	}
+}
+
+func testCommentsForIssues(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	for _, id := range []string{a.ID, a.ID, b.ID} {
+		if _, err := s.AddIssueComment(ctx, id, "alice", "note"); err != nil {
+			t.Fatalf("AddIssueComment failed: %v", err)
+		}
+	}
+
+	got, err := s.GetCommentsForIssues(ctx, []string{a.ID, b.ID, c.ID})
+	if err != nil {
+		t.Fatalf("GetCommentsForIssues failed: %v", err)
+	}
+	if len(got[a.ID]) != 2 || len(got[b.ID]) != 1 || len(got[c.ID]) != 0 {
+		t.Errorf("comment counts = %d/%d/%d, want 2/1/0", len(got[a.ID]), len(got[b.ID]), len(got[c.ID]))
+	}
+}
+
+func testEventsRecorded(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "audited", 2)
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := s.AddComment(ctx, issue.ID, "bob", "looks good"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := s.CloseIssue(ctx, issue.ID, "done", "carol", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	events, err := s.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	seen := make(map[types.EventType]string)
+	for _, e := range events {
+		if e.IssueID != issue.ID {
+			t.Errorf("event for %s returned for %s", e.IssueID, issue.ID)
+		}
+		seen[e.EventType] = e.Actor
+	}
+	for _, want := range []struct {
+		typ   types.EventType
+		actor string
+	}{
+		{types.EventCreated, "storagetest"},
+		{types.EventCommented, "bob"},
+		{types.EventClosed, "carol"},
+	} {
+		if actor, ok := seen[want.typ]; !ok || actor != want.actor {
+			t.Errorf("event %s by %q not recorded (seen %v)", want.typ, want.actor, seen)
+		}
+	}
+}
+
+func testEventsLimit(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "busy", 2)
+	for i := 0; i < 4; i++ {
+		if err := s.AddComment(ctx, issue.ID, "alice", "ping"); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+	events, err := s.GetEvents(ctx, issue.ID, 2)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("GetEvents(limit=2) returned %d events", len(events))
+	}
+}
@@ -0,0 +1,125 @@
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func concurrencyCases() []testCase {
+	return []testCase{
+		{name: "CreateIssue", fn: testConcurrentCreate},
+		{name: "TransactionIsolation", skip: noTransactions, fn: testConcurrentTransactions},
+	}
+}
+
+// testConcurrentCreate verifies that parallel creates never hand out the
+// same ID and that every issue is persisted.
+func testConcurrentCreate(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	const workers = 8
+	const perWorker = 5
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created := make([]string, 0, workers*perWorker)
+	errs := make(chan error, workers*perWorker)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				issue := &types.Issue{
+					Title:     fmt.Sprintf("worker %d issue %d", w, i),
+					Status:    types.StatusOpen,
+					Priority:  2,
+					IssueType: types.TypeTask,
+				}
+				if err := s.CreateIssue(ctx, issue, "storagetest"); err != nil {
+					errs <- err
+					continue
+				}
+				mu.Lock()
+				created = append(created, issue.ID)
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent CreateIssue failed: %v", err)
+	}
+
+	seen := make(map[string]bool, len(created))
+	for _, id := range created {
+		if seen[id] {
+			t.Errorf("duplicate ID %s handed out", id)
+		}
+		seen[id] = true
+	}
+	all, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(all) != len(created) {
+		t.Errorf("persisted %d issues, created %d", len(all), len(created))
+	}
+}
+
+// testConcurrentTransactions runs read-modify-write transactions in parallel.
+// Backends may serialize or abort conflicting transactions, but a committed
+// increment must never be lost.
+func testConcurrentTransactions(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	const key = "storagetest.counter"
+	if err := s.SetMetadata(ctx, key, "0"); err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+
+	const workers = 6
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	committed := 0
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+				v, err := tx.GetMetadata(ctx, key)
+				if err != nil {
+					return err
+				}
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return err
+				}
+				return tx.SetMetadata(ctx, key, strconv.Itoa(n+1))
+			})
+			if err == nil {
+				mu.Lock()
+				committed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if committed == 0 {
+		t.Fatal("no concurrent transaction committed")
+	}
+	v, err := s.GetMetadata(ctx, key)
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if v != strconv.Itoa(committed) {
+		t.Errorf("counter = %s after %d committed increments (lost update)", v, committed)
+	}
+}
@@ -0,0 +1,108 @@
+package storagetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func configCases() []testCase {
+	return []testCase{
+		{name: "SetGetDelete", fn: testConfigSetGetDelete},
+		{name: "GetAll", fn: testConfigGetAll},
+		{name: "CustomStatusesAndTypes", fn: testCustomStatusesAndTypes},
+		{name: "Metadata", fn: testMetadata},
+	}
+}
+
+func testConfigSetGetDelete(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	if got, err := s.GetConfig(ctx, "storagetest.missing"); err != nil || got != "" {
+		t.Errorf("GetConfig(missing) = %q, %v; want empty, nil", got, err)
+	}
+	if err := s.SetConfig(ctx, "storagetest.key", "one"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := s.SetConfig(ctx, "storagetest.key", "two"); err != nil {
+		t.Fatalf("SetConfig overwrite failed: %v", err)
+	}
+	if got, err := s.GetConfig(ctx, "storagetest.key"); err != nil || got != "two" {
+		t.Errorf("GetConfig = %q, %v; want two", got, err)
+	}
+	if err := s.DeleteConfig(ctx, "storagetest.key"); err != nil {
+		t.Fatalf("DeleteConfig failed: %v", err)
+	}
+	if got, err := s.GetConfig(ctx, "storagetest.key"); err != nil || got != "" {
+		t.Errorf("GetConfig after delete = %q, %v; want empty", got, err)
+	}
+}
+
+func testConfigGetAll(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	if err := s.SetConfig(ctx, "storagetest.a", "1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetAllConfig failed: %v", err)
+	}
+	if all["storagetest.a"] != "1" || all["issue_prefix"] != Prefix {
+		t.Errorf("GetAllConfig missing keys: a=%q issue_prefix=%q", all["storagetest.a"], all["issue_prefix"])
+	}
+}
+
+func testCustomStatusesAndTypes(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	if err := s.SetConfig(ctx, "status.custom", "review, qa"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	statuses, err := s.GetCustomStatuses(ctx)
+	if err != nil {
+		t.Fatalf("GetCustomStatuses failed: %v", err)
+	}
+	if strings.Join(statuses, ",") != "review,qa" {
+		t.Errorf("GetCustomStatuses = %v, want [review qa]", statuses)
+	}
+
+	customTypes, err := s.GetCustomTypes(ctx)
+	if err != nil {
+		t.Fatalf("GetCustomTypes failed: %v", err)
+	}
+	if !contains(customTypes, "molecule") || !contains(customTypes, "gate") {
+		t.Errorf("GetCustomTypes = %v, want molecule and gate", customTypes)
+	}
+
+	issue := create(t, s, issueSpec{title: "in review", status: "review", priority: 2})
+	if got := mustGet(t, s, issue.ID); got.Status != "review" {
+		t.Errorf("custom status = %q, want review", got.Status)
+	}
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": "qa"}, "storagetest"); err != nil {
+		t.Errorf("UpdateIssue to custom status failed: %v", err)
+	}
+	bad := &types.Issue{Title: "bad", Status: "nope", Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, bad, "storagetest"); err == nil {
+		t.Error("CreateIssue accepted an unconfigured status")
+	}
+}
+
+func testMetadata(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	if got, err := s.GetMetadata(ctx, "storagetest.missing"); err != nil || got != "" {
+		t.Errorf("GetMetadata(missing) = %q, %v; want empty, nil", got, err)
+	}
+	if err := s.SetMetadata(ctx, "storagetest.meta", "v1"); err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+	if err := s.SetMetadata(ctx, "storagetest.meta", "v2"); err != nil {
+		t.Fatalf("SetMetadata overwrite failed: %v", err)
+	}
+	if got, err := s.GetMetadata(ctx, "storagetest.meta"); err != nil || got != "v2" {
+		t.Errorf("GetMetadata = %q, %v; want v2", got, err)
+	}
+	if got, err := s.GetConfig(ctx, "storagetest.meta"); err != nil || got != "" {
+		t.Errorf("metadata leaked into config: %q, %v", got, err)
+	}
+}
@@ -0,0 +1,250 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func dependencyCases() []testCase {
+	return []testCase{
+		{name: "AddAndQuery", fn: testDependencyAddAndQuery},
+		{name: "Metadata", fn: testDependencyMetadata},
+		{name: "Records", fn: testDependencyRecords},
+		{name: "Counts", fn: testDependencyCounts},
+		{name: "Remove", fn: testDependencyRemove},
+		{name: "RejectsMissingTarget", fn: testDependencyRejectsMissingTarget},
+		{name: "RejectsSelf", fn: testDependencyRejectsSelf},
+		{name: "RejectsCycle", skip: noDependencyGraph, fn: testDependencyRejectsCycle},
+		{name: "Tree", skip: noDependencyGraph, fn: testDependencyTree},
+	}
+}
+
+func testDependencyAddAndQuery(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+	link(t, s, a.ID, c.ID, types.DepRelated)
+
+	deps, err := s.GetDependencies(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if !sameSet(ids(deps), []string{b.ID, c.ID}) {
+		t.Errorf("GetDependencies(a) = %v, want %v", ids(deps), []string{b.ID, c.ID})
+	}
+
+	dependents, err := s.GetDependents(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("GetDependents failed: %v", err)
+	}
+	if !sameSet(ids(dependents), []string{a.ID}) {
+		t.Errorf("GetDependents(b) = %v, want %s", ids(dependents), a.ID)
+	}
+
+	none, err := s.GetDependencies(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies(c) failed: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("GetDependencies(c) = %v, want none", ids(none))
+	}
+}
+
+func testDependencyMetadata(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	epic := create(t, s, issueSpec{title: "epic", priority: 1, itype: types.TypeEpic})
+	child := task(t, s, "child", 2)
+	blocker := task(t, s, "blocker", 2)
+	link(t, s, child.ID, epic.ID, types.DepParentChild)
+	link(t, s, child.ID, blocker.ID, types.DepBlocks)
+
+	deps, err := s.GetDependenciesWithMetadata(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("GetDependenciesWithMetadata failed: %v", err)
+	}
+	gotTypes := make(map[string]types.DependencyType)
+	for _, d := range deps {
+		gotTypes[d.ID] = d.DependencyType
+	}
+	if gotTypes[epic.ID] != types.DepParentChild || gotTypes[blocker.ID] != types.DepBlocks || len(gotTypes) != 2 {
+		t.Errorf("GetDependenciesWithMetadata types = %v", gotTypes)
+	}
+
+	dependents, err := s.GetDependentsWithMetadata(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetDependentsWithMetadata failed: %v", err)
+	}
+	if len(dependents) != 1 || dependents[0].ID != child.ID || dependents[0].DependencyType != types.DepParentChild {
+		t.Errorf("GetDependentsWithMetadata(epic) = %+v, want %s via parent-child", dependents, child.ID)
+	}
+}
+
+func testDependencyRecords(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+	link(t, s, b.ID, c.ID, types.DepDiscoveredFrom)
+
+	records, err := s.GetDependencyRecords(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("GetDependencyRecords(a) returned %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.IssueID != a.ID || r.DependsOnID != b.ID || r.Type != types.DepBlocks {
+		t.Errorf("record = %+v, want %s -> %s blocks", r, a.ID, b.ID)
+	}
+
+	all, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDependencyRecords failed: %v", err)
+	}
+	if len(all[a.ID]) != 1 || len(all[b.ID]) != 1 || len(all[c.ID]) != 0 {
+		t.Errorf("GetAllDependencyRecords = %v", all)
+	}
+	if all[b.ID][0].Type != types.DepDiscoveredFrom {
+		t.Errorf("b record type = %q, want discovered-from", all[b.ID][0].Type)
+	}
+}
+
+func testDependencyCounts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+	link(t, s, a.ID, c.ID, types.DepBlocks)
+	link(t, s, b.ID, c.ID, types.DepBlocks)
+
+	counts, err := s.GetDependencyCounts(ctx, []string{a.ID, b.ID, c.ID})
+	if err != nil {
+		t.Fatalf("GetDependencyCounts failed: %v", err)
+	}
+	want := map[string][2]int{
+		a.ID: {2, 0},
+		b.ID: {1, 1},
+		c.ID: {0, 2},
+	}
+	for id, w := range want {
+		got := counts[id]
+		if got == nil {
+			t.Errorf("no counts for %s", id)
+			continue
+		}
+		if got.DependencyCount != w[0] || got.DependentCount != w[1] {
+			t.Errorf("counts[%s] = {%d, %d}, want {%d, %d}", id, got.DependencyCount, got.DependentCount, w[0], w[1])
+		}
+	}
+}
+
+func testDependencyRemove(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+
+	if err := s.RemoveDependency(ctx, a.ID, b.ID, "storagetest"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	deps, err := s.GetDependencies(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 0 {
+		t.Errorf("dependencies remain after removal: %v", ids(deps))
+	}
+	if blocked, _, err := s.IsBlocked(ctx, a.ID); err != nil || blocked {
+		t.Errorf("IsBlocked after removal = %v, %v; want false", blocked, err)
+	}
+}
+
+func testDependencyRejectsMissingTarget(t *testing.T, s storage.Storage) {
+	a := task(t, s, "a", 2)
+	dep := &types.Dependency{IssueID: a.ID, DependsOnID: Prefix + "-missing", Type: types.DepBlocks}
+	if err := s.AddDependency(context.Background(), dep, "storagetest"); err == nil {
+		t.Error("AddDependency accepted a missing target")
+	}
+}
+
+func testDependencyRejectsSelf(t *testing.T, s storage.Storage) {
+	a := task(t, s, "a", 2)
+	dep := &types.Dependency{IssueID: a.ID, DependsOnID: a.ID, Type: types.DepBlocks}
+	if err := s.AddDependency(context.Background(), dep, "storagetest"); err == nil {
+		t.Error("AddDependency accepted a self-dependency")
+	}
+}
+
+func testDependencyRejectsCycle(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+	link(t, s, b.ID, c.ID, types.DepBlocks)
+
+	dep := &types.Dependency{IssueID: c.ID, DependsOnID: a.ID, Type: types.DepBlocks}
+	if err := s.AddDependency(ctx, dep, "storagetest"); err == nil {
+		t.Error("AddDependency accepted a cycle")
+	}
+
+	cycles, err := s.DetectCycles(ctx)
+	if err != nil {
+		t.Fatalf("DetectCycles failed: %v", err)
+	}
+	if len(cycles) != 0 {
+		t.Errorf("DetectCycles found %d cycles in an acyclic graph", len(cycles))
+	}
+}
+
+func testDependencyTree(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+	link(t, s, b.ID, c.ID, types.DepBlocks)
+
+	depthOf := func(nodes []*types.TreeNode) map[string]int {
+		m := make(map[string]int, len(nodes))
+		for _, n := range nodes {
+			m[n.ID] = n.Depth
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		root    string
+		reverse bool
+		want    map[string]int
+	}{
+		{"forward", a.ID, false, map[string]int{a.ID: 0, b.ID: 1, c.ID: 2}},
+		{"reverse", c.ID, true, map[string]int{c.ID: 0, b.ID: 1, a.ID: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := s.GetDependencyTree(ctx, tt.root, 10, false, tt.reverse)
+			if err != nil {
+				t.Fatalf("GetDependencyTree failed: %v", err)
+			}
+			got := depthOf(nodes)
+			if len(got) != len(tt.want) {
+				t.Fatalf("tree = %v, want %v", got, tt.want)
+			}
+			for id, depth := range tt.want {
+				if got[id] != depth {
+					t.Errorf("depth[%s] = %d, want %d", id, got[id], depth)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func graphCases() []testCase {
+	return []testCase{
+		{name: "Neighborhood", fn: testGraphNeighborhood},
+		{name: "NeighborhoodTypes", fn: testGraphNeighborhoodTypes},
+		{name: "Path", fn: testGraphPath},
+		{name: "NoPath", fn: testGraphNoPath},
+	}
+}
+
+// graphFixture builds a -caused-by-> b -relates-to-> c -duplicates-> d,
+// plus e -blocks-> a, and an unconnected f.
+func graphFixture(t *testing.T, s storage.Storage) map[string]*types.Issue {
+	t.Helper()
+	g := make(map[string]*types.Issue)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		g[name] = task(t, s, name, 2)
+	}
+	link(t, s, g["a"].ID, g["b"].ID, types.DepCausedBy)
+	link(t, s, g["b"].ID, g["c"].ID, types.DepRelatesTo)
+	link(t, s, g["c"].ID, g["d"].ID, types.DepDuplicates)
+	link(t, s, g["e"].ID, g["a"].ID, types.DepBlocks)
+	return g
+}
+
+func neighborDistances(neighbors []*types.GraphNeighbor) map[string]int {
+	out := make(map[string]int, len(neighbors))
+	for _, n := range neighbors {
+		out[n.ID] = n.Distance
+	}
+	return out
+}
+
+func testGraphNeighborhood(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	g := graphFixture(t, s)
+
+	neighbors, err := s.GetNeighborhood(ctx, g["b"].ID, 2, nil)
+	if err != nil {
+		t.Fatalf("GetNeighborhood failed: %v", err)
+	}
+	got := neighborDistances(neighbors)
+	want := map[string]int{g["a"].ID: 1, g["c"].ID: 1, g["d"].ID: 2, g["e"].ID: 2}
+	if len(got) != len(want) {
+		t.Fatalf("GetNeighborhood(b, 2) = %v, want %v", got, want)
+	}
+	for id, d := range want {
+		if got[id] != d {
+			t.Errorf("distance to %s = %d, want %d", id, got[id], d)
+		}
+	}
+	for i := 1; i < len(neighbors); i++ {
+		if neighbors[i].Distance < neighbors[i-1].Distance {
+			t.Errorf("neighbors not ordered by distance: %v", got)
+		}
+	}
+	for _, n := range neighbors {
+		if n.ID == g["e"].ID && (n.Via != types.DepBlocks || n.ViaID != g["a"].ID) {
+			t.Errorf("e reached via %s from %s, want blocks from a", n.Via, n.ViaID)
+		}
+	}
+}
+
+func testGraphNeighborhoodTypes(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	g := graphFixture(t, s)
+
+	neighbors, err := s.GetNeighborhood(ctx, g["b"].ID, 5, []types.DependencyType{types.DepRelatesTo, types.DepDuplicates})
+	if err != nil {
+		t.Fatalf("GetNeighborhood failed: %v", err)
+	}
+	got := neighborDistances(neighbors)
+	if len(got) != 2 || got[g["c"].ID] != 1 || got[g["d"].ID] != 2 {
+		t.Errorf("GetNeighborhood(b, relates-to+duplicates) = %v, want c:1 d:2", got)
+	}
+}
+
+func testGraphPath(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	g := graphFixture(t, s)
+
+	path, err := s.FindPath(ctx, g["e"].ID, g["d"].ID, 0, nil)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	wantTypes := []types.DependencyType{types.DepBlocks, types.DepCausedBy, types.DepRelatesTo, types.DepDuplicates}
+	if len(path) != len(wantTypes) {
+		t.Fatalf("FindPath(e, d) has %d steps, want %d: %+v", len(path), len(wantTypes), path)
+	}
+	for i, step := range path {
+		if step.Type != wantTypes[i] || step.Reverse {
+			t.Errorf("step %d = %+v, want forward %s", i, step, wantTypes[i])
+		}
+	}
+	if path[0].FromID != g["e"].ID || path[len(path)-1].ToID != g["d"].ID {
+		t.Errorf("path endpoints = %s..%s", path[0].FromID, path[len(path)-1].ToID)
+	}
+
+	// Walking the other way traverses every edge against its direction
+	back, err := s.FindPath(ctx, g["d"].ID, g["e"].ID, 0, nil)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	if len(back) != 4 || !back[0].Reverse || back[0].FromID != g["d"].ID {
+		t.Errorf("FindPath(d, e) = %+v, want 4 reversed steps from d", back)
+	}
+
+	self, err := s.FindPath(ctx, g["a"].ID, g["a"].ID, 0, nil)
+	if err != nil || self == nil || len(self) != 0 {
+		t.Errorf("FindPath(a, a) = %v, %v; want empty path", self, err)
+	}
+}
+
+func testGraphNoPath(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	g := graphFixture(t, s)
+
+	path, err := s.FindPath(ctx, g["a"].ID, g["f"].ID, 0, nil)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	if path != nil {
+		t.Errorf("FindPath(a, f) = %+v, want nil", path)
+	}
+
+	// d is three edges from a; a depth limit of two cannot reach it
+	path, err = s.FindPath(ctx, g["a"].ID, g["d"].ID, 2, nil)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	if path != nil {
+		t.Errorf("FindPath(a, d, depth 2) = %+v, want nil", path)
+	}
+}
@@ -0,0 +1,299 @@
+package storagetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func issueCases() []testCase {
+	return []testCase{
+		{name: "CreateAssignsPrefixedID", fn: testCreateAssignsPrefixedID},
+		{name: "CreateSetsTimestamps", fn: testCreateSetsTimestamps},
+		{name: "CreateRejectsInvalid", fn: testCreateRejectsInvalid},
+		{name: "CreateRejectsDuplicateID", fn: testCreateRejectsDuplicateID},
+		{name: "CreateIssuesBatch", fn: testCreateIssuesBatch},
+		{name: "GetMissingReturnsNil", fn: testGetMissingReturnsNil},
+		{name: "GetRoundTripsFields", fn: testGetRoundTripsFields},
+		{name: "GetByExternalRef", fn: testGetByExternalRef},
+		{name: "UpdateFields", fn: testUpdateFields},
+		{name: "UpdateMissingFails", fn: testUpdateMissingFails},
+		{name: "CloseAndReopen", fn: testCloseAndReopen},
+		{name: "DeleteRemovesIssueAndEdges", fn: testDeleteRemovesIssueAndEdges},
+	}
+}
+
+func testCreateAssignsPrefixedID(t *testing.T, s storage.Storage) {
+	issue := task(t, s, "prefixed", 2)
+	if !strings.HasPrefix(issue.ID, Prefix+"-") {
+		t.Errorf("ID %q does not start with %q", issue.ID, Prefix+"-")
+	}
+
+	other := task(t, s, "prefixed again", 2)
+	if other.ID == issue.ID {
+		t.Errorf("two issues received the same ID %q", issue.ID)
+	}
+}
+
+func testCreateSetsTimestamps(t *testing.T, s storage.Storage) {
+	issue := task(t, s, "timestamps", 2)
+	if issue.CreatedAt.IsZero() || issue.UpdatedAt.IsZero() {
+		t.Fatalf("CreatedAt/UpdatedAt not set: %v / %v", issue.CreatedAt, issue.UpdatedAt)
+	}
+	got := mustGet(t, s, issue.ID)
+	if got.CreatedAt.IsZero() {
+		t.Error("stored CreatedAt is zero")
+	}
+	if got.ClosedAt != nil {
+		t.Errorf("open issue has ClosedAt %v", got.ClosedAt)
+	}
+}
+
+func testCreateRejectsInvalid(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		issue *types.Issue
+	}{
+		{"empty title", &types.Issue{Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}},
+		{"priority too high", &types.Issue{Title: "x", Status: types.StatusOpen, Priority: 9, IssueType: types.TypeTask}},
+		{"negative priority", &types.Issue{Title: "x", Status: types.StatusOpen, Priority: -1, IssueType: types.TypeTask}},
+		{"unknown status", &types.Issue{Title: "x", Status: "bogus", Priority: 2, IssueType: types.TypeTask}},
+		{"unknown type", &types.Issue{Title: "x", Status: types.StatusOpen, Priority: 2, IssueType: "bogus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.CreateIssue(ctx, tt.issue, "storagetest"); err == nil {
+				t.Errorf("CreateIssue accepted invalid issue")
+			}
+		})
+	}
+}
+
+func testCreateRejectsDuplicateID(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	first := &types.Issue{ID: Prefix + "-dup", Title: "first", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, first, "storagetest"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	second := &types.Issue{ID: Prefix + "-dup", Title: "second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, second, "storagetest"); err == nil {
+		t.Fatal("CreateIssue accepted a duplicate ID")
+	}
+	if got := mustGet(t, s, first.ID); got.Title != "first" {
+		t.Errorf("duplicate create overwrote original: title=%q", got.Title)
+	}
+}
+
+func testCreateIssuesBatch(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	batch := []*types.Issue{
+		{Title: "batch a", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{Title: "batch b", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug},
+		{Title: "batch c", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeFeature},
+	}
+	if err := s.CreateIssues(ctx, batch, "storagetest"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, issue := range batch {
+		if issue.ID == "" {
+			t.Fatalf("CreateIssues did not assign an ID to %q", issue.Title)
+		}
+		if seen[issue.ID] {
+			t.Fatalf("CreateIssues assigned duplicate ID %q", issue.ID)
+		}
+		seen[issue.ID] = true
+		if got := mustGet(t, s, issue.ID); got.Title != issue.Title {
+			t.Errorf("GetIssue(%s).Title = %q, want %q", issue.ID, got.Title, issue.Title)
+		}
+	}
+
+	if err := s.CreateIssues(ctx, nil, "storagetest"); err != nil {
+		t.Errorf("CreateIssues(nil) failed: %v", err)
+	}
+}
+
+func testGetMissingReturnsNil(t *testing.T, s storage.Storage) {
+	issue, err := s.GetIssue(context.Background(), Prefix+"-missing")
+	if err != nil {
+		t.Fatalf("GetIssue(missing) returned error: %v", err)
+	}
+	if issue != nil {
+		t.Errorf("GetIssue(missing) = %+v, want nil", issue)
+	}
+}
+
+func testGetRoundTripsFields(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	est := 45
+	ref := "gh-1234"
+	issue := &types.Issue{
+		Title:              "round trip",
+		Description:        "description",
+		Design:             "design",
+		AcceptanceCriteria: "acceptance",
+		Notes:              "notes",
+		Status:             types.StatusInProgress,
+		Priority:           1,
+		IssueType:          types.TypeBug,
+		Assignee:           "alice",
+		EstimatedMinutes:   &est,
+		ExternalRef:        &ref,
+	}
+	if err := s.CreateIssue(ctx, issue, "storagetest"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	got := mustGet(t, s, issue.ID)
+	checks := []struct {
+		field     string
+		got, want interface{}
+	}{
+		{"Title", got.Title, issue.Title},
+		{"Description", got.Description, issue.Description},
+		{"Design", got.Design, issue.Design},
+		{"AcceptanceCriteria", got.AcceptanceCriteria, issue.AcceptanceCriteria},
+		{"Notes", got.Notes, issue.Notes},
+		{"Status", got.Status, issue.Status},
+		{"Priority", got.Priority, issue.Priority},
+		{"IssueType", got.IssueType, issue.IssueType},
+		{"Assignee", got.Assignee, issue.Assignee},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.field, c.got, c.want)
+		}
+	}
+	if got.EstimatedMinutes == nil || *got.EstimatedMinutes != est {
+		t.Errorf("EstimatedMinutes = %v, want %d", got.EstimatedMinutes, est)
+	}
+	if got.ExternalRef == nil || *got.ExternalRef != ref {
+		t.Errorf("ExternalRef = %v, want %q", got.ExternalRef, ref)
+	}
+}
+
+func testGetByExternalRef(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	ref := "jira-42"
+	issue := &types.Issue{Title: "external", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, ExternalRef: &ref}
+	if err := s.CreateIssue(ctx, issue, "storagetest"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	got, err := s.GetIssueByExternalRef(ctx, ref)
+	if err != nil {
+		t.Fatalf("GetIssueByExternalRef failed: %v", err)
+	}
+	if got == nil || got.ID != issue.ID {
+		t.Fatalf("GetIssueByExternalRef(%q) = %v, want %s", ref, got, issue.ID)
+	}
+
+	missing, err := s.GetIssueByExternalRef(ctx, "jira-missing")
+	if err != nil {
+		t.Fatalf("GetIssueByExternalRef(missing) failed: %v", err)
+	}
+	if missing != nil {
+		t.Errorf("GetIssueByExternalRef(missing) = %s, want nil", missing.ID)
+	}
+}
+
+func testUpdateFields(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "before", 2)
+	// Compare persisted timestamps: backends store different precisions
+	before := mustGet(t, s, issue.ID)
+
+	updates := map[string]interface{}{
+		"title":       "after",
+		"description": "new description",
+		"priority":    0,
+		"assignee":    "bob",
+		"status":      string(types.StatusInProgress),
+	}
+	if err := s.UpdateIssue(ctx, issue.ID, updates, "storagetest"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	got := mustGet(t, s, issue.ID)
+	if got.Title != "after" || got.Description != "new description" {
+		t.Errorf("text fields not updated: %q / %q", got.Title, got.Description)
+	}
+	if got.Priority != 0 {
+		t.Errorf("Priority = %d, want 0", got.Priority)
+	}
+	if got.Assignee != "bob" {
+		t.Errorf("Assignee = %q, want bob", got.Assignee)
+	}
+	if got.Status != types.StatusInProgress {
+		t.Errorf("Status = %q, want in_progress", got.Status)
+	}
+	if got.UpdatedAt.Before(before.UpdatedAt) {
+		t.Errorf("UpdatedAt moved backwards: %v < %v", got.UpdatedAt, before.UpdatedAt)
+	}
+}
+
+func testUpdateMissingFails(t *testing.T, s storage.Storage) {
+	err := s.UpdateIssue(context.Background(), Prefix+"-missing", map[string]interface{}{"title": "x"}, "storagetest")
+	if err == nil {
+		t.Error("UpdateIssue on missing issue succeeded")
+	}
+}
+
+func testCloseAndReopen(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "close me", 2)
+
+	if err := s.CloseIssue(ctx, issue.ID, "done", "storagetest", "session-1"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	closed := mustGet(t, s, issue.ID)
+	if closed.Status != types.StatusClosed {
+		t.Errorf("Status = %q, want closed", closed.Status)
+	}
+	if closed.ClosedAt == nil {
+		t.Error("ClosedAt not set after close")
+	}
+	if closed.CloseReason != "done" {
+		t.Errorf("CloseReason = %q, want done", closed.CloseReason)
+	}
+
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "storagetest"); err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	reopened := mustGet(t, s, issue.ID)
+	if reopened.Status != types.StatusOpen {
+		t.Errorf("Status = %q, want open", reopened.Status)
+	}
+	if reopened.ClosedAt != nil {
+		t.Errorf("ClosedAt = %v after reopen, want nil", reopened.ClosedAt)
+	}
+}
+
+func testDeleteRemovesIssueAndEdges(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	keep := task(t, s, "keep", 2)
+	gone := task(t, s, "gone", 2)
+	link(t, s, keep.ID, gone.ID, types.DepBlocks)
+
+	if err := s.DeleteIssue(ctx, gone.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if got, err := s.GetIssue(ctx, gone.ID); err != nil || got != nil {
+		t.Errorf("GetIssue after delete = %v, %v; want nil, nil", got, err)
+	}
+	if err := s.DeleteIssue(ctx, gone.ID); err == nil {
+		t.Error("deleting a missing issue succeeded")
+	}
+
+	blocked, blockers, err := s.IsBlocked(ctx, keep.ID)
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if blocked {
+		t.Errorf("issue still blocked by deleted issue: %v", blockers)
+	}
+}
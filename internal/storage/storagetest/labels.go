@@ -0,0 +1,122 @@
+package storagetest
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func labelCases() []testCase {
+	return []testCase{
+		{name: "AddRemove", fn: testLabelAddRemove},
+		{name: "AddIsIdempotent", fn: testLabelAddIsIdempotent},
+		{name: "ForIssues", fn: testLabelsForIssues},
+		{name: "IssuesByLabel", fn: testIssuesByLabel},
+		{name: "AttachedToIssue", fn: testLabelsAttachedToIssue},
+	}
+}
+
+func sortedLabels(t *testing.T, s storage.Storage, id string) []string {
+	t.Helper()
+	labels, err := s.GetLabels(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetLabels(%s) failed: %v", id, err)
+	}
+	out := append([]string(nil), labels...)
+	sort.Strings(out)
+	return out
+}
+
+func testLabelAddRemove(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "labelled", 2)
+	for _, l := range []string{"backend", "urgent"} {
+		if err := s.AddLabel(ctx, issue.ID, l, "storagetest"); err != nil {
+			t.Fatalf("AddLabel(%s) failed: %v", l, err)
+		}
+	}
+	if got := sortedLabels(t, s, issue.ID); len(got) != 2 || got[0] != "backend" || got[1] != "urgent" {
+		t.Errorf("labels = %v, want [backend urgent]", got)
+	}
+
+	if err := s.RemoveLabel(ctx, issue.ID, "urgent", "storagetest"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+	if got := sortedLabels(t, s, issue.ID); len(got) != 1 || got[0] != "backend" {
+		t.Errorf("labels after removal = %v, want [backend]", got)
+	}
+}
+
+func testLabelAddIsIdempotent(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "labelled twice", 2)
+	for i := 0; i < 2; i++ {
+		if err := s.AddLabel(ctx, issue.ID, "dup", "storagetest"); err != nil {
+			t.Fatalf("AddLabel attempt %d failed: %v", i+1, err)
+		}
+	}
+	if got := sortedLabels(t, s, issue.ID); len(got) != 1 {
+		t.Errorf("labels = %v, want exactly one", got)
+	}
+}
+
+func testLabelsForIssues(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	if err := s.AddLabel(ctx, a.ID, "x", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := s.AddLabel(ctx, b.ID, "y", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	got, err := s.GetLabelsForIssues(ctx, []string{a.ID, b.ID, c.ID})
+	if err != nil {
+		t.Fatalf("GetLabelsForIssues failed: %v", err)
+	}
+	if len(got[a.ID]) != 1 || got[a.ID][0] != "x" {
+		t.Errorf("labels[a] = %v, want [x]", got[a.ID])
+	}
+	if len(got[b.ID]) != 1 || got[b.ID][0] != "y" {
+		t.Errorf("labels[b] = %v, want [y]", got[b.ID])
+	}
+	if len(got[c.ID]) != 0 {
+		t.Errorf("labels[c] = %v, want none", got[c.ID])
+	}
+}
+
+func testIssuesByLabel(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	task(t, s, "c", 2)
+	for _, id := range []string{a.ID, b.ID} {
+		if err := s.AddLabel(ctx, id, "shared", "storagetest"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+
+	got, err := s.GetIssuesByLabel(ctx, "shared")
+	if err != nil {
+		t.Fatalf("GetIssuesByLabel failed: %v", err)
+	}
+	if !sameSet(ids(got), []string{a.ID, b.ID}) {
+		t.Errorf("GetIssuesByLabel = %v, want %v", ids(got), []string{a.ID, b.ID})
+	}
+}
+
+func testLabelsAttachedToIssue(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "attached", 2)
+	if err := s.AddLabel(ctx, issue.ID, "visible", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	got := mustGet(t, s, issue.ID)
+	if !contains(got.Labels, "visible") {
+		t.Errorf("GetIssue(%s).Labels = %v, want to contain visible", issue.ID, got.Labels)
+	}
+}
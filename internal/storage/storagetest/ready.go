@@ -0,0 +1,508 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func readyCases() []testCase {
+	return []testCase{
+		{name: "ExcludesBlocked", fn: testReadyExcludesBlocked},
+		{name: "DefaultStatuses", fn: testReadyDefaultStatuses},
+		{name: "ExcludesPinnedAndEphemeral", fn: testReadyExcludesPinnedAndEphemeral},
+		{name: "ExcludesWorkflowTypes", fn: testReadyExcludesWorkflowTypes},
+		{name: "ParentBlockingIsTransitive", fn: testReadyParentBlockingIsTransitive},
+		{name: "TombstonesDoNotBlock", fn: testReadyTombstonesDoNotBlock},
+		{name: "Filters", fn: testReadyFilters},
+		{name: "Deferred", fn: testReadyDeferred},
+		{name: "SortPolicies", fn: testReadySortPolicies},
+		{name: "DueSortPolicy", fn: testReadyDueSortPolicy},
+		{name: "NewlyUnblockedByClose", fn: testNewlyUnblockedByClose},
+		{name: "BlockedIssues", fn: testBlockedIssues},
+		{name: "IsBlocked", fn: testIsBlocked},
+		{name: "EpicsEligibleForClosure", fn: testEpicsEligibleForClosure},
+		{name: "StaleIssues", fn: testStaleIssues},
+		{name: "Statistics", fn: testStatistics},
+		{name: "MoleculeProgress", fn: testMoleculeProgress},
+	}
+}
+
+// ready returns the IDs of ready work for filter.
+func ready(t *testing.T, s storage.Storage, filter types.WorkFilter) []string {
+	t.Helper()
+	issues, err := s.GetReadyWork(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	return ids(issues)
+}
+
+// createAt inserts an issue with explicit creation and update timestamps.
+func createAt(t *testing.T, s storage.Storage, title string, priority int, at time.Time) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{
+		Title:     title,
+		Status:    types.StatusOpen,
+		Priority:  priority,
+		IssueType: types.TypeTask,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+	if err := s.CreateIssue(context.Background(), issue, "storagetest"); err != nil {
+		t.Fatalf("CreateIssue(%q) failed: %v", title, err)
+	}
+	return issue
+}
+
+func testReadyExcludesBlocked(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	blocker := task(t, s, "blocker", 1)
+	blocked := task(t, s, "blocked", 1)
+	link(t, s, blocked.ID, blocker.ID, types.DepBlocks)
+
+	got := ready(t, s, types.WorkFilter{})
+	if contains(got, blocked.ID) || !contains(got, blocker.ID) {
+		t.Fatalf("ready = %v, want %s but not %s", got, blocker.ID, blocked.ID)
+	}
+
+	if err := s.CloseIssue(ctx, blocker.ID, "done", "storagetest", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	got = ready(t, s, types.WorkFilter{})
+	if !sameSet(got, []string{blocked.ID}) {
+		t.Errorf("ready after close = %v, want %s", got, blocked.ID)
+	}
+}
+
+func testReadyDefaultStatuses(t *testing.T, s storage.Storage) {
+	open := task(t, s, "open", 2)
+	wip := create(t, s, issueSpec{title: "wip", status: types.StatusInProgress, priority: 2})
+	create(t, s, issueSpec{title: "blocked", status: types.StatusBlocked, priority: 2})
+	create(t, s, issueSpec{title: "deferred", status: types.StatusDeferred, priority: 2})
+	create(t, s, issueSpec{title: "closed", status: types.StatusClosed, priority: 2})
+	create(t, s, issueSpec{title: "tombstone", status: types.StatusTombstone, priority: 2})
+
+	if got := ready(t, s, types.WorkFilter{}); !sameSet(got, []string{open.ID, wip.ID}) {
+		t.Errorf("default ready = %v, want %v", got, []string{open.ID, wip.ID})
+	}
+	if got := ready(t, s, types.WorkFilter{Status: types.StatusInProgress}); !sameSet(got, []string{wip.ID}) {
+		t.Errorf("ready(status=in_progress) = %v, want %s", got, wip.ID)
+	}
+}
+
+func testReadyExcludesPinnedAndEphemeral(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	normal := task(t, s, "normal", 2)
+	for _, issue := range []*types.Issue{
+		{Title: "pinned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Pinned: true},
+		{Title: "wisp", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Ephemeral: true},
+	} {
+		if err := s.CreateIssue(ctx, issue, "storagetest"); err != nil {
+			t.Fatalf("CreateIssue(%q) failed: %v", issue.Title, err)
+		}
+	}
+
+	if got := ready(t, s, types.WorkFilter{}); !sameSet(got, []string{normal.ID}) {
+		t.Errorf("ready = %v, want only %s", got, normal.ID)
+	}
+}
+
+func testReadyExcludesWorkflowTypes(t *testing.T, s storage.Storage) {
+	work := task(t, s, "work", 2)
+	gate := create(t, s, issueSpec{title: "gate", priority: 2, itype: "gate"})
+	create(t, s, issueSpec{title: "molecule", priority: 2, itype: "molecule"})
+	create(t, s, issueSpec{title: "message", priority: 2, itype: "message"})
+	create(t, s, issueSpec{title: "agent", priority: 2, itype: "agent"})
+
+	if got := ready(t, s, types.WorkFilter{}); !sameSet(got, []string{work.ID}) {
+		t.Errorf("default ready = %v, want only %s", got, work.ID)
+	}
+	if got := ready(t, s, types.WorkFilter{Type: "gate"}); !sameSet(got, []string{gate.ID}) {
+		t.Errorf("ready(type=gate) = %v, want %s", got, gate.ID)
+	}
+}
+
+func testReadyParentBlockingIsTransitive(t *testing.T, s storage.Storage) {
+	blocker := task(t, s, "blocker", 1)
+	epic := create(t, s, issueSpec{title: "epic", priority: 1, itype: types.TypeEpic})
+	child := task(t, s, "child", 1)
+	grandchild := task(t, s, "grandchild", 1)
+	link(t, s, epic.ID, blocker.ID, types.DepBlocks)
+	link(t, s, child.ID, epic.ID, types.DepParentChild)
+	link(t, s, grandchild.ID, child.ID, types.DepParentChild)
+
+	got := ready(t, s, types.WorkFilter{})
+	for _, id := range []string{epic.ID, child.ID, grandchild.ID} {
+		if contains(got, id) {
+			t.Errorf("%s is ready although an ancestor is blocked (ready = %v)", id, got)
+		}
+	}
+	if !contains(got, blocker.ID) {
+		t.Errorf("blocker %s missing from ready = %v", blocker.ID, got)
+	}
+}
+
+func testReadyTombstonesDoNotBlock(t *testing.T, s storage.Storage) {
+	dead := create(t, s, issueSpec{title: "dead", status: types.StatusTombstone, priority: 2})
+	live := task(t, s, "live", 2)
+	link(t, s, live.ID, dead.ID, types.DepBlocks)
+
+	if got := ready(t, s, types.WorkFilter{}); !sameSet(got, []string{live.ID}) {
+		t.Errorf("ready = %v, want %s", got, live.ID)
+	}
+	if blocked, blockers, err := s.IsBlocked(context.Background(), live.ID); err != nil || blocked {
+		t.Errorf("IsBlocked = %v %v %v, want false", blocked, blockers, err)
+	}
+}
+
+func testReadyFilters(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	epic := create(t, s, issueSpec{title: "epic", priority: 0, itype: types.TypeEpic})
+	alice := create(t, s, issueSpec{title: "alice p1", priority: 1, assignee: "alice"})
+	bob := create(t, s, issueSpec{title: "bob p2", priority: 2, itype: types.TypeBug, assignee: "bob"})
+	nobody := create(t, s, issueSpec{title: "nobody p1", priority: 1})
+	link(t, s, alice.ID, epic.ID, types.DepParentChild)
+	if err := s.AddLabel(ctx, alice.ID, "backend", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := s.AddLabel(ctx, alice.ID, "api", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := s.AddLabel(ctx, bob.ID, "frontend", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter types.WorkFilter
+		want   []string
+	}{
+		{"priority", types.WorkFilter{Priority: intPtr(1)}, []string{alice.ID, nobody.ID}},
+		{"type", types.WorkFilter{Type: string(types.TypeBug)}, []string{bob.ID}},
+		{"assignee", types.WorkFilter{Assignee: strPtr("bob")}, []string{bob.ID}},
+		{"unassigned", types.WorkFilter{Unassigned: true}, []string{epic.ID, nobody.ID}},
+		{"unassigned wins over assignee", types.WorkFilter{Unassigned: true, Assignee: strPtr("bob")}, []string{epic.ID, nobody.ID}},
+		{"labels all", types.WorkFilter{Labels: []string{"backend", "api"}}, []string{alice.ID}},
+		{"labels any", types.WorkFilter{LabelsAny: []string{"api", "frontend"}}, []string{alice.ID, bob.ID}},
+		{"parent", types.WorkFilter{ParentID: &epic.ID}, []string{alice.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ready(t, s, tt.filter); !sameSet(got, tt.want) {
+				t.Errorf("ready = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := ready(t, s, types.WorkFilter{Limit: 2, SortPolicy: types.SortPolicyPriority}); len(got) != 2 || got[0] != epic.ID {
+		t.Errorf("ready(limit=2, priority) = %v, want 2 results starting with %s", got, epic.ID)
+	}
+}
+
+func testReadyDeferred(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	now := task(t, s, "now", 2)
+	future := time.Now().Add(72 * time.Hour)
+	past := time.Now().Add(-72 * time.Hour)
+	later := &types.Issue{Title: "later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DeferUntil: &future}
+	elapsed := &types.Issue{Title: "elapsed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DeferUntil: &past}
+	for _, issue := range []*types.Issue{later, elapsed} {
+		if err := s.CreateIssue(ctx, issue, "storagetest"); err != nil {
+			t.Fatalf("CreateIssue(%q) failed: %v", issue.Title, err)
+		}
+	}
+
+	if got := ready(t, s, types.WorkFilter{}); !sameSet(got, []string{now.ID, elapsed.ID}) {
+		t.Errorf("ready = %v, want %v", got, []string{now.ID, elapsed.ID})
+	}
+	if got := ready(t, s, types.WorkFilter{IncludeDeferred: true}); !sameSet(got, []string{now.ID, elapsed.ID, later.ID}) {
+		t.Errorf("ready(IncludeDeferred) = %v, want all three", got)
+	}
+}
+
+func testReadySortPolicies(t *testing.T, s storage.Storage) {
+	now := time.Now()
+	old10 := createAt(t, s, "old p0", 0, now.Add(-10*24*time.Hour))
+	old5 := createAt(t, s, "old p3", 3, now.Add(-5*24*time.Hour))
+	recent2h := createAt(t, s, "recent p1", 1, now.Add(-2*time.Hour))
+	recent1h := createAt(t, s, "recent p2", 2, now.Add(-1*time.Hour))
+
+	tests := []struct {
+		policy types.SortPolicy
+		want   []string
+	}{
+		{types.SortPolicyPriority, []string{old10.ID, recent2h.ID, recent1h.ID, old5.ID}},
+		{types.SortPolicyOldest, []string{old10.ID, old5.ID, recent2h.ID, recent1h.ID}},
+		{types.SortPolicyHybrid, []string{recent2h.ID, recent1h.ID, old10.ID, old5.ID}},
+		{"", []string{recent2h.ID, recent1h.ID, old10.ID, old5.ID}},
+	}
+	for _, tt := range tests {
+		name := string(tt.policy)
+		if name == "" {
+			name = "default"
+		}
+		t.Run(name, func(t *testing.T) {
+			got := ready(t, s, types.WorkFilter{SortPolicy: tt.policy})
+			if len(got) != len(tt.want) {
+				t.Fatalf("ready = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ready order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func testReadyDueSortPolicy(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	now := time.Now()
+	withDue := func(title string, priority int, due time.Time) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask, DueAt: &due}
+		if err := s.CreateIssue(ctx, issue, "storagetest"); err != nil {
+			t.Fatalf("CreateIssue(%q) failed: %v", title, err)
+		}
+		return issue
+	}
+	noDue := createAt(t, s, "p1 no due date", 1, now.Add(-time.Hour))
+	overdue := withDue("p3 overdue", 3, now.Add(-time.Hour))        // effective 0
+	today := withDue("p3 due today", 3, now.Add(6*time.Hour))       // effective 1, earlier due
+	soon := withDue("p2 due in two days", 2, now.Add(48*time.Hour)) // effective 1
+	later := withDue("p1 due next month", 1, now.Add(30*24*time.Hour))
+
+	got := ready(t, s, types.WorkFilter{SortPolicy: types.SortPolicyDue})
+	want := []string{overdue.ID, today.ID, soon.ID, later.ID, noDue.ID}
+	if len(got) != len(want) {
+		t.Fatalf("ready = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("ready order = %v, want %v", got, want)
+		}
+	}
+}
+
+func testNewlyUnblockedByClose(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	closing := task(t, s, "closing", 2)
+	other := task(t, s, "other blocker", 2)
+	dead := create(t, s, issueSpec{title: "dead blocker", status: types.StatusTombstone, priority: 2})
+
+	stillBlocked := task(t, s, "still blocked", 0)
+	freedLow := task(t, s, "freed p3", 3)
+	freedWIP := create(t, s, issueSpec{title: "freed wip p1", status: types.StatusInProgress, priority: 1})
+	freedPastTombstone := task(t, s, "freed past tombstone p2", 2)
+	blockedStatus := create(t, s, issueSpec{title: "status blocked", status: types.StatusBlocked, priority: 0})
+	pinned := &types.Issue{Title: "pinned", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask, Pinned: true}
+	if err := s.CreateIssue(ctx, pinned, "storagetest"); err != nil {
+		t.Fatalf("CreateIssue(pinned) failed: %v", err)
+	}
+	related := task(t, s, "only related", 0)
+
+	link(t, s, stillBlocked.ID, closing.ID, types.DepBlocks)
+	link(t, s, stillBlocked.ID, other.ID, types.DepBlocks)
+	link(t, s, freedLow.ID, closing.ID, types.DepBlocks)
+	link(t, s, freedWIP.ID, closing.ID, types.DepBlocks)
+	link(t, s, freedPastTombstone.ID, closing.ID, types.DepBlocks)
+	link(t, s, freedPastTombstone.ID, dead.ID, types.DepBlocks)
+	link(t, s, blockedStatus.ID, closing.ID, types.DepBlocks)
+	link(t, s, pinned.ID, closing.ID, types.DepBlocks)
+	link(t, s, related.ID, closing.ID, types.DepRelated)
+
+	if err := s.CloseIssue(ctx, closing.ID, "done", "storagetest", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	issues, err := s.GetNewlyUnblockedByClose(ctx, closing.ID)
+	if err != nil {
+		t.Fatalf("GetNewlyUnblockedByClose failed: %v", err)
+	}
+	got := ids(issues)
+	want := []string{freedWIP.ID, freedPastTombstone.ID, freedLow.ID}
+	if len(got) != len(want) {
+		t.Fatalf("newly unblocked = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("newly unblocked order = %v, want %v (priority ascending)", got, want)
+		}
+	}
+}
+
+func testBlockedIssues(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	epic := create(t, s, issueSpec{title: "epic", priority: 1, itype: types.TypeEpic})
+	blocker := task(t, s, "blocker", 1)
+	blocked := task(t, s, "blocked", 1)
+	manual := create(t, s, issueSpec{title: "manually blocked", status: types.StatusBlocked, priority: 2})
+	link(t, s, blocked.ID, blocker.ID, types.DepBlocks)
+	link(t, s, blocked.ID, epic.ID, types.DepParentChild)
+
+	all, err := s.GetBlockedIssues(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	byID := make(map[string]*types.BlockedIssue)
+	for _, b := range all {
+		byID[b.ID] = b
+	}
+	if len(byID) != 2 || byID[blocked.ID] == nil || byID[manual.ID] == nil {
+		t.Fatalf("GetBlockedIssues returned %v, want %s and %s", byID, blocked.ID, manual.ID)
+	}
+	if b := byID[blocked.ID]; b.BlockedByCount != 1 || len(b.BlockedBy) != 1 || b.BlockedBy[0] != blocker.ID {
+		t.Errorf("blocked entry = count %d by %v, want 1 by %s", b.BlockedByCount, b.BlockedBy, blocker.ID)
+	}
+	if b := byID[manual.ID]; b.BlockedByCount != 0 {
+		t.Errorf("manual entry BlockedByCount = %d, want 0", b.BlockedByCount)
+	}
+
+	scoped, err := s.GetBlockedIssues(ctx, types.WorkFilter{ParentID: &epic.ID})
+	if err != nil {
+		t.Fatalf("GetBlockedIssues(parent) failed: %v", err)
+	}
+	if len(scoped) != 1 || scoped[0].ID != blocked.ID {
+		t.Errorf("GetBlockedIssues(parent) = %d issues, want only %s", len(scoped), blocked.ID)
+	}
+}
+
+func testIsBlocked(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	c := task(t, s, "c", 2)
+	link(t, s, c.ID, a.ID, types.DepBlocks)
+	link(t, s, c.ID, b.ID, types.DepBlocks)
+
+	blocked, blockers, err := s.IsBlocked(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if !blocked || !sameSet(blockers, []string{a.ID, b.ID}) {
+		t.Errorf("IsBlocked(c) = %v %v, want true %v", blocked, blockers, []string{a.ID, b.ID})
+	}
+
+	blocked, blockers, err = s.IsBlocked(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("IsBlocked(a) failed: %v", err)
+	}
+	if blocked || len(blockers) != 0 {
+		t.Errorf("IsBlocked(a) = %v %v, want false", blocked, blockers)
+	}
+}
+
+func testEpicsEligibleForClosure(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	done := create(t, s, issueSpec{title: "done epic", priority: 1, itype: types.TypeEpic})
+	pending := create(t, s, issueSpec{title: "pending epic", priority: 1, itype: types.TypeEpic})
+	closedEpic := create(t, s, issueSpec{title: "closed epic", status: types.StatusClosed, priority: 1, itype: types.TypeEpic})
+
+	for _, spec := range []struct {
+		parent string
+		status types.Status
+	}{
+		{done.ID, types.StatusClosed},
+		{done.ID, types.StatusClosed},
+		{pending.ID, types.StatusClosed},
+		{pending.ID, types.StatusOpen},
+	} {
+		child := create(t, s, issueSpec{title: "child", status: spec.status, priority: 2})
+		link(t, s, child.ID, spec.parent, types.DepParentChild)
+	}
+
+	epics, err := s.GetEpicsEligibleForClosure(ctx)
+	if err != nil {
+		t.Fatalf("GetEpicsEligibleForClosure failed: %v", err)
+	}
+	byID := make(map[string]*types.EpicStatus)
+	for _, e := range epics {
+		byID[e.Epic.ID] = e
+	}
+	if byID[closedEpic.ID] != nil {
+		t.Errorf("closed epic %s reported", closedEpic.ID)
+	}
+	if e := byID[done.ID]; e == nil || !e.EligibleForClose || e.TotalChildren != 2 || e.ClosedChildren != 2 {
+		t.Errorf("done epic status = %+v, want eligible 2/2", e)
+	}
+	if e := byID[pending.ID]; e == nil || e.EligibleForClose || e.TotalChildren != 2 || e.ClosedChildren != 1 {
+		t.Errorf("pending epic status = %+v, want ineligible 1/2", e)
+	}
+}
+
+func testStaleIssues(t *testing.T, s storage.Storage) {
+	now := time.Now()
+	stale := createAt(t, s, "stale", 2, now.Add(-30*24*time.Hour))
+	older := createAt(t, s, "older", 2, now.Add(-60*24*time.Hour))
+	createAt(t, s, "fresh", 2, now)
+
+	issues, err := s.GetStaleIssues(context.Background(), types.StaleFilter{Days: 7})
+	if err != nil {
+		t.Fatalf("GetStaleIssues failed: %v", err)
+	}
+	got := ids(issues)
+	if len(got) != 2 || got[0] != older.ID || got[1] != stale.ID {
+		t.Errorf("stale = %v, want %v (oldest first)", got, []string{older.ID, stale.ID})
+	}
+
+	issues, err = s.GetStaleIssues(context.Background(), types.StaleFilter{Days: 7, Limit: 1})
+	if err != nil {
+		t.Fatalf("GetStaleIssues(limit) failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != older.ID {
+		t.Errorf("stale(limit=1) = %v, want %s", ids(issues), older.ID)
+	}
+}
+
+func testStatistics(t *testing.T, s storage.Storage) {
+	blocker := task(t, s, "open blocker", 2)
+	blocked := task(t, s, "open blocked", 2)
+	create(t, s, issueSpec{title: "wip", status: types.StatusInProgress, priority: 2})
+	create(t, s, issueSpec{title: "closed", status: types.StatusClosed, priority: 2})
+	create(t, s, issueSpec{title: "tombstone", status: types.StatusTombstone, priority: 2})
+	link(t, s, blocked.ID, blocker.ID, types.DepBlocks)
+
+	stats, err := s.GetStatistics(context.Background())
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	checks := []struct {
+		name      string
+		got, want int
+	}{
+		{"TotalIssues", stats.TotalIssues, 4},
+		{"OpenIssues", stats.OpenIssues, 2},
+		{"InProgressIssues", stats.InProgressIssues, 1},
+		{"ClosedIssues", stats.ClosedIssues, 1},
+		{"TombstoneIssues", stats.TombstoneIssues, 1},
+		{"BlockedIssues", stats.BlockedIssues, 1},
+		{"ReadyIssues", stats.ReadyIssues, 1},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+		}
+	}
+}
+
+func testMoleculeProgress(t *testing.T, s storage.Storage) {
+	mol := create(t, s, issueSpec{title: "molecule", priority: 1, itype: types.TypeEpic})
+	for _, status := range []types.Status{types.StatusClosed, types.StatusInProgress, types.StatusOpen} {
+		step := create(t, s, issueSpec{title: "step " + string(status), status: status, priority: 2})
+		link(t, s, step.ID, mol.ID, types.DepParentChild)
+	}
+
+	progress, err := s.GetMoleculeProgress(context.Background(), mol.ID)
+	if err != nil {
+		t.Fatalf("GetMoleculeProgress failed: %v", err)
+	}
+	if progress.MoleculeID != mol.ID || progress.Total != 3 || progress.Completed != 1 || progress.InProgress != 1 {
+		t.Errorf("progress = %+v, want total 3, completed 1, in progress 1", progress)
+	}
+	if _, err := s.GetMoleculeProgress(context.Background(), Prefix+"-missing"); err == nil {
+		t.Error("GetMoleculeProgress on missing molecule succeeded")
+	}
+}
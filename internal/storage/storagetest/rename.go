@@ -0,0 +1,92 @@
+package storagetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func renameCases() []testCase {
+	return []testCase{
+		{name: "UpdateIssueID", skip: noRename, fn: testUpdateIssueID},
+		{name: "RenameDependencyPrefix", skip: noRename, fn: testRenameDependencyPrefix},
+	}
+}
+
+func testUpdateIssueID(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "renamed", 2)
+	dependent := task(t, s, "dependent", 2)
+	link(t, s, dependent.ID, issue.ID, types.DepBlocks)
+	if err := s.AddLabel(ctx, issue.ID, "kept", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	oldID := issue.ID
+	newID := Prefix + "-renamed"
+	updated := mustGet(t, s, oldID)
+	updated.ID = newID
+	if err := s.UpdateIssueID(ctx, oldID, newID, updated, "storagetest"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	if got, err := s.GetIssue(ctx, oldID); err != nil || got != nil {
+		t.Errorf("old ID still resolves: %v, %v", got, err)
+	}
+	got := mustGet(t, s, newID)
+	if got.Title != "renamed" {
+		t.Errorf("renamed issue title = %q", got.Title)
+	}
+	if !contains(got.Labels, "kept") {
+		t.Errorf("labels not carried over: %v", got.Labels)
+	}
+	deps, err := s.GetDependencyRecords(ctx, dependent.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != newID {
+		t.Errorf("inbound dependency not rewritten: %+v", deps)
+	}
+}
+
+// testRenameDependencyPrefix mirrors bd rename-prefix: every issue is renamed
+// with UpdateIssueID, then dependency and counter prefixes are rewritten.
+func testRenameDependencyPrefix(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+	link(t, s, a.ID, b.ID, types.DepBlocks)
+
+	rename := func(id string) string { return "nx" + strings.TrimPrefix(id, Prefix) }
+	for _, id := range []string{a.ID, b.ID} {
+		issue := mustGet(t, s, id)
+		issue.ID = rename(id)
+		if err := s.UpdateIssueID(ctx, id, issue.ID, issue, "storagetest"); err != nil {
+			t.Fatalf("UpdateIssueID(%s) failed: %v", id, err)
+		}
+	}
+	if err := s.RenameDependencyPrefix(ctx, Prefix, "nx"); err != nil {
+		t.Fatalf("RenameDependencyPrefix failed: %v", err)
+	}
+	if err := s.RenameCounterPrefix(ctx, Prefix, "nx"); err != nil {
+		t.Fatalf("RenameCounterPrefix failed: %v", err)
+	}
+
+	deps, err := s.GetDependencyRecords(ctx, rename(a.ID))
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != rename(b.ID) {
+		t.Errorf("dependency not renamed: %+v", deps)
+	}
+	blocked, _, err := s.IsBlocked(ctx, rename(a.ID))
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if !blocked {
+		t.Errorf("%s lost its blocker after rename", rename(a.ID))
+	}
+}
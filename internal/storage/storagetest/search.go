@@ -0,0 +1,140 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func searchCases() []testCase {
+	return []testCase{
+		{name: "Filters", fn: testSearchFilters},
+		{name: "ExcludesTombstonesByDefault", fn: testSearchExcludesTombstones},
+		{name: "OrdersByPriority", fn: testSearchOrdersByPriority},
+		{name: "Limit", fn: testSearchLimit},
+		{name: "ParentFilter", fn: testSearchParentFilter},
+	}
+}
+
+func testSearchFilters(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	bug := create(t, s, issueSpec{title: "crash on login", priority: 0, itype: types.TypeBug, assignee: "alice"})
+	feature := create(t, s, issueSpec{title: "dark mode", priority: 2, itype: types.TypeFeature, assignee: "bob"})
+	closed := create(t, s, issueSpec{title: "old login bug", status: types.StatusClosed, priority: 1, itype: types.TypeBug})
+	for _, l := range []string{"frontend", "ui"} {
+		if err := s.AddLabel(ctx, feature.ID, l, "storagetest"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+	if err := s.AddLabel(ctx, bug.ID, "frontend", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	statusClosed := types.StatusClosed
+	typeBug := types.TypeBug
+	tests := []struct {
+		name   string
+		query  string
+		filter types.IssueFilter
+		want   []string
+	}{
+		{"all", "", types.IssueFilter{}, []string{bug.ID, feature.ID, closed.ID}},
+		{"text query", "login", types.IssueFilter{}, []string{bug.ID, closed.ID}},
+		{"status", "", types.IssueFilter{Status: &statusClosed}, []string{closed.ID}},
+		{"exclude status", "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}}, []string{bug.ID, feature.ID}},
+		{"type", "", types.IssueFilter{IssueType: &typeBug}, []string{bug.ID, closed.ID}},
+		{"exclude type", "", types.IssueFilter{ExcludeTypes: []types.IssueType{types.TypeBug}}, []string{feature.ID}},
+		{"priority", "", types.IssueFilter{Priority: intPtr(2)}, []string{feature.ID}},
+		{"assignee", "", types.IssueFilter{Assignee: strPtr("alice")}, []string{bug.ID}},
+		{"labels all", "", types.IssueFilter{Labels: []string{"frontend", "ui"}}, []string{feature.ID}},
+		{"labels any", "", types.IssueFilter{LabelsAny: []string{"ui", "missing"}}, []string{feature.ID}},
+		{"ids", "", types.IssueFilter{IDs: []string{bug.ID, closed.ID}}, []string{bug.ID, closed.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.SearchIssues(ctx, tt.query, tt.filter)
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			if !sameSet(ids(got), tt.want) {
+				t.Errorf("SearchIssues(%q) = %v, want %v", tt.query, ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func testSearchExcludesTombstones(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	live := task(t, s, "live", 2)
+	dead := create(t, s, issueSpec{title: "dead", status: types.StatusTombstone, priority: 2})
+
+	got, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if !sameSet(ids(got), []string{live.ID}) {
+		t.Errorf("default search = %v, want only %s", ids(got), live.ID)
+	}
+
+	got, err = s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		t.Fatalf("SearchIssues(IncludeTombstones) failed: %v", err)
+	}
+	if !sameSet(ids(got), []string{live.ID, dead.ID}) {
+		t.Errorf("IncludeTombstones search = %v, want %v", ids(got), []string{live.ID, dead.ID})
+	}
+
+	tombstone := types.StatusTombstone
+	got, err = s.SearchIssues(ctx, "", types.IssueFilter{Status: &tombstone})
+	if err != nil {
+		t.Fatalf("SearchIssues(status=tombstone) failed: %v", err)
+	}
+	if !sameSet(ids(got), []string{dead.ID}) {
+		t.Errorf("status=tombstone search = %v, want %s", ids(got), dead.ID)
+	}
+}
+
+func testSearchOrdersByPriority(t *testing.T, s storage.Storage) {
+	low := task(t, s, "low", 3)
+	high := task(t, s, "high", 0)
+	mid := task(t, s, "mid", 1)
+
+	got, err := s.SearchIssues(context.Background(), "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	want := []string{high.ID, mid.ID, low.ID}
+	if g := ids(got); len(g) != len(want) || g[0] != want[0] || g[1] != want[1] || g[2] != want[2] {
+		t.Errorf("SearchIssues order = %v, want %v", g, want)
+	}
+}
+
+func testSearchLimit(t *testing.T, s storage.Storage) {
+	for i := 0; i < 5; i++ {
+		task(t, s, "limited", 2)
+	}
+	got, err := s.SearchIssues(context.Background(), "", types.IssueFilter{Limit: 3})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("SearchIssues(Limit=3) returned %d issues", len(got))
+	}
+}
+
+func testSearchParentFilter(t *testing.T, s storage.Storage) {
+	epic := create(t, s, issueSpec{title: "epic", priority: 1, itype: types.TypeEpic})
+	child := task(t, s, "child", 2)
+	task(t, s, "unrelated", 2)
+	link(t, s, child.ID, epic.ID, types.DepParentChild)
+
+	got, err := s.SearchIssues(context.Background(), "", types.IssueFilter{ParentID: &epic.ID})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if !sameSet(ids(got), []string{child.ID}) {
+		t.Errorf("ParentID search = %v, want %s", ids(got), child.ID)
+	}
+}
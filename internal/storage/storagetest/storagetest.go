@@ -0,0 +1,254 @@
+// Package storagetest provides a backend-agnostic conformance suite for
+// storage.Storage implementations.
+//
+// Every in-tree backend (sqlite, dolt, memory) runs this suite from its own
+// package tests so that behavior such as ready-work ordering, tombstone
+// filtering and GetNewlyUnblockedByClose cannot silently drift between them.
+// Third-party backends can run the same suite against their implementation
+// via the public github.com/steveyegge/beads/storagetest package:
+//
+//	func TestConformance(t *testing.T) {
+//	    storagetest.Run(t, storagetest.Backend{
+//	        Name: "mybackend",
+//	        New: func(t *testing.T) storage.Storage {
+//	            return mybackend.New(t.TempDir())
+//	        },
+//	    })
+//	}
+//
+// Backends that intentionally omit a capability (for example the in-memory
+// --no-db backend has no transactions) opt out via the Backend flags. A fully
+// conforming backend leaves every flag false.
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Prefix is the issue_prefix configured on every store before a test runs.
+const Prefix = "st"
+
+// Factory returns a fresh, empty store for a single test.
+// The suite closes the store when the test completes; factories should
+// register any additional cleanup (temp dirs, servers) with t.Cleanup.
+type Factory func(t *testing.T) storage.Storage
+
+// Backend describes a storage implementation under test.
+type Backend struct {
+	// Name identifies the backend in failure messages (e.g. "sqlite").
+	Name string
+
+	// New creates a fresh store for each test.
+	New Factory
+
+	// NoTransactions skips RunInTransaction tests (memory/--no-db mode).
+	NoTransactions bool
+
+	// NoEventLog skips tests that expect AddComment and status changes to be
+	// recorded in the GetEvents audit trail.
+	NoEventLog bool
+
+	// NoRename skips UpdateIssueID and prefix rename tests.
+	NoRename bool
+
+	// NoExportHashes skips export/JSONL hash persistence tests.
+	NoExportHashes bool
+
+	// NoDependencyGraph skips deep dependency tree and cycle detection tests.
+	NoDependencyGraph bool
+}
+
+// testCase is a single named conformance check.
+type testCase struct {
+	name string
+	skip func(b Backend) bool
+	fn   func(t *testing.T, s storage.Storage)
+}
+
+// Run executes the full conformance suite against the backend.
+func Run(t *testing.T, b Backend) {
+	t.Helper()
+	if b.New == nil {
+		t.Fatal("storagetest: Backend.New must not be nil")
+	}
+
+	groups := []struct {
+		name  string
+		cases []testCase
+	}{
+		{"Issues", issueCases()},
+		{"Search", searchCases()},
+		{"Dependencies", dependencyCases()},
+		{"Graph", graphCases()},
+		{"Labels", labelCases()},
+		{"ReadyWork", readyCases()},
+		{"Comments", commentCases()},
+		{"Attachments", attachmentCases()},
+		{"WorkLogs", workLogCases()},
+		{"Config", configCases()},
+		{"Tracking", trackingCases()},
+		{"Rename", renameCases()},
+		{"Transactions", transactionCases()},
+		{"CloseCascade", cascadeCases()},
+		{"Concurrency", concurrencyCases()},
+	}
+
+	for _, g := range groups {
+		t.Run(g.name, func(t *testing.T) {
+			for _, tc := range g.cases {
+				t.Run(tc.name, func(t *testing.T) {
+					if tc.skip != nil && tc.skip(b) {
+						t.Skipf("%s does not support this capability", b.Name)
+					}
+					tc.fn(t, newStore(t, b))
+				})
+			}
+		})
+	}
+}
+
+// newStore creates a store from the backend factory and configures the
+// issue prefix plus the Gas Town custom types used by several tests.
+func newStore(t *testing.T, b Backend) storage.Storage {
+	t.Helper()
+	s := b.New(t)
+	if s == nil {
+		t.Fatalf("%s: factory returned nil store", b.Name)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	ctx := context.Background()
+	if err := s.SetConfig(ctx, "issue_prefix", Prefix); err != nil {
+		t.Fatalf("SetConfig(issue_prefix) failed: %v", err)
+	}
+	if err := s.SetConfig(ctx, "types.custom", "molecule,gate,agent,message"); err != nil {
+		t.Fatalf("SetConfig(types.custom) failed: %v", err)
+	}
+	return s
+}
+
+// Skip predicates shared by the case tables.
+func noTransactions(b Backend) bool    { return b.NoTransactions }
+func noEventLog(b Backend) bool        { return b.NoEventLog }
+func noRename(b Backend) bool          { return b.NoRename }
+func noExportHashes(b Backend) bool    { return b.NoExportHashes }
+func noDependencyGraph(b Backend) bool { return b.NoDependencyGraph }
+
+// issueSpec describes an issue to create in a test fixture.
+type issueSpec struct {
+	title    string
+	status   types.Status
+	priority int
+	itype    types.IssueType
+	assignee string
+}
+
+// create inserts an issue built from spec and returns it with its ID populated.
+func create(t *testing.T, s storage.Storage, spec issueSpec) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{
+		Title:     spec.title,
+		Status:    spec.status,
+		Priority:  spec.priority,
+		IssueType: spec.itype,
+		Assignee:  spec.assignee,
+	}
+	if issue.Status == "" {
+		issue.Status = types.StatusOpen
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = types.TypeTask
+	}
+	if issue.Status == types.StatusClosed {
+		now := time.Now()
+		issue.ClosedAt = &now
+	}
+	if issue.Status == types.StatusTombstone {
+		now := time.Now()
+		issue.DeletedAt = &now
+		issue.DeletedBy = "storagetest"
+	}
+	if err := s.CreateIssue(context.Background(), issue, "storagetest"); err != nil {
+		t.Fatalf("CreateIssue(%q) failed: %v", spec.title, err)
+	}
+	if issue.ID == "" {
+		t.Fatalf("CreateIssue(%q) did not assign an ID", spec.title)
+	}
+	return issue
+}
+
+// task is shorthand for an open task with the given title and priority.
+func task(t *testing.T, s storage.Storage, title string, priority int) *types.Issue {
+	t.Helper()
+	return create(t, s, issueSpec{title: title, priority: priority})
+}
+
+// link adds a dependency of the given type from issueID to dependsOnID.
+func link(t *testing.T, s storage.Storage, issueID, dependsOnID string, depType types.DependencyType) {
+	t.Helper()
+	dep := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+	if err := s.AddDependency(context.Background(), dep, "storagetest"); err != nil {
+		t.Fatalf("AddDependency(%s -> %s, %s) failed: %v", issueID, dependsOnID, depType, err)
+	}
+}
+
+// mustGet fetches an issue and fails the test if it is missing.
+func mustGet(t *testing.T, s storage.Storage, id string) *types.Issue {
+	t.Helper()
+	issue, err := s.GetIssue(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetIssue(%s) failed: %v", id, err)
+	}
+	if issue == nil {
+		t.Fatalf("GetIssue(%s) returned nil", id)
+	}
+	return issue
+}
+
+// ids extracts issue IDs preserving order.
+func ids(issues []*types.Issue) []string {
+	out := make([]string, len(issues))
+	for i, issue := range issues {
+		out[i] = issue.ID
+	}
+	return out
+}
+
+// sameSet reports whether got and want contain the same IDs, ignoring order.
+func sameSet(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]int, len(want))
+	for _, id := range want {
+		seen[id]++
+	}
+	for _, id := range got {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
+
+// contains reports whether id is present in list.
+func contains(list []string, id string) bool {
+	for _, v := range list {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// intPtr returns a pointer to v.
+func intPtr(v int) *int { return &v }
+
+// strPtr returns a pointer to v.
+func strPtr(v string) *string { return &v }
@@ -0,0 +1,112 @@
+package storagetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func trackingCases() []testCase {
+	return []testCase{
+		{name: "DirtyIssues", fn: testDirtyIssues},
+		{name: "ExportHashes", skip: noExportHashes, fn: testExportHashes},
+		{name: "JSONLFileHash", skip: noExportHashes, fn: testJSONLFileHash},
+		{name: "NextChildID", fn: testNextChildID},
+	}
+}
+
+func testDirtyIssues(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "a", 2)
+	b := task(t, s, "b", 2)
+
+	dirty, err := s.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if !contains(dirty, a.ID) || !contains(dirty, b.ID) {
+		t.Fatalf("dirty = %v, want %s and %s", dirty, a.ID, b.ID)
+	}
+
+	if err := s.ClearDirtyIssuesByID(ctx, []string{a.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+	dirty, err = s.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if contains(dirty, a.ID) || !contains(dirty, b.ID) {
+		t.Fatalf("dirty after clear = %v, want only %s", dirty, b.ID)
+	}
+
+	if err := s.AddLabel(ctx, a.ID, "touched", "storagetest"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	dirty, err = s.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if !contains(dirty, a.ID) {
+		t.Errorf("label change did not mark %s dirty (dirty = %v)", a.ID, dirty)
+	}
+}
+
+func testExportHashes(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "exported", 2)
+
+	if got, err := s.GetExportHash(ctx, issue.ID); err != nil || got != "" {
+		t.Errorf("GetExportHash before set = %q, %v; want empty", got, err)
+	}
+	if err := s.SetExportHash(ctx, issue.ID, "abc123"); err != nil {
+		t.Fatalf("SetExportHash failed: %v", err)
+	}
+	if got, err := s.GetExportHash(ctx, issue.ID); err != nil || got != "abc123" {
+		t.Errorf("GetExportHash = %q, %v; want abc123", got, err)
+	}
+	if err := s.ClearAllExportHashes(ctx); err != nil {
+		t.Fatalf("ClearAllExportHashes failed: %v", err)
+	}
+	if got, err := s.GetExportHash(ctx, issue.ID); err != nil || got != "" {
+		t.Errorf("GetExportHash after clear = %q, %v; want empty", got, err)
+	}
+}
+
+func testJSONLFileHash(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	if got, err := s.GetJSONLFileHash(ctx); err != nil || got != "" {
+		t.Errorf("GetJSONLFileHash before set = %q, %v; want empty", got, err)
+	}
+	if err := s.SetJSONLFileHash(ctx, "deadbeef"); err != nil {
+		t.Fatalf("SetJSONLFileHash failed: %v", err)
+	}
+	if got, err := s.GetJSONLFileHash(ctx); err != nil || got != "deadbeef" {
+		t.Errorf("GetJSONLFileHash = %q, %v; want deadbeef", got, err)
+	}
+}
+
+func testNextChildID(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	parent := task(t, s, "parent", 2)
+
+	first, err := s.GetNextChildID(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	second, err := s.GetNextChildID(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	if first != parent.ID+".1" || second != parent.ID+".2" {
+		t.Errorf("child IDs = %q, %q; want %s.1, %s.2", first, second, parent.ID, parent.ID)
+	}
+	if !strings.HasPrefix(first, Prefix+"-") {
+		t.Errorf("child ID %q lost the prefix", first)
+	}
+
+	if _, err := s.GetNextChildID(ctx, Prefix+"-missing"); err == nil {
+		t.Error("GetNextChildID for a missing parent succeeded")
+	}
+}
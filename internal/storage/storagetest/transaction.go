@@ -0,0 +1,211 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func transactionCases() []testCase {
+	return []testCase{
+		{name: "Commit", skip: noTransactions, fn: testTxCommit},
+		{name: "RollbackOnError", skip: noTransactions, fn: testTxRollbackOnError},
+		{name: "RollbackOnPanic", skip: noTransactions, fn: testTxRollbackOnPanic},
+		{name: "ReadYourWrites", skip: noTransactions, fn: testTxReadYourWrites},
+		{name: "AllOperations", skip: noTransactions, fn: testTxAllOperations},
+	}
+}
+
+func testTxCommit(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	var created *types.Issue
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		created = &types.Issue{Title: "in tx", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		return tx.CreateIssue(ctx, created, "storagetest")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("tx.CreateIssue did not assign an ID")
+	}
+	if got := mustGet(t, s, created.ID); got.Title != "in tx" {
+		t.Errorf("committed issue title = %q", got.Title)
+	}
+}
+
+func testTxRollbackOnError(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	existing := task(t, s, "existing", 2)
+	sentinel := errors.New("abort")
+	var created *types.Issue
+
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		created = &types.Issue{Title: "discarded", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := tx.CreateIssue(ctx, created, "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.UpdateIssue(ctx, existing.ID, map[string]interface{}{"title": "changed"}, "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.SetConfig(ctx, "storagetest.tx", "set"); err != nil {
+			return err
+		}
+		return sentinel
+	})
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("RunInTransaction error = %v, want sentinel", err)
+	}
+
+	if created.ID != "" {
+		if got, err := s.GetIssue(ctx, created.ID); err != nil || got != nil {
+			t.Errorf("rolled back issue is visible: %v, %v", got, err)
+		}
+	}
+	if got := mustGet(t, s, existing.ID); got.Title != "existing" {
+		t.Errorf("rolled back update is visible: title = %q", got.Title)
+	}
+	if got, _ := s.GetConfig(ctx, "storagetest.tx"); got != "" {
+		t.Errorf("rolled back config is visible: %q", got)
+	}
+}
+
+func testTxRollbackOnPanic(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	existing := task(t, s, "existing", 2)
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("panic inside transaction was swallowed")
+			}
+		}()
+		_ = s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+			if err := tx.UpdateIssue(ctx, existing.ID, map[string]interface{}{"title": "changed"}, "storagetest"); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	if got := mustGet(t, s, existing.ID); got.Title != "existing" {
+		t.Errorf("update survived panic: title = %q", got.Title)
+	}
+}
+
+func testTxReadYourWrites(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		issue := &types.Issue{Title: "visible inside", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := tx.CreateIssue(ctx, issue, "storagetest"); err != nil {
+			return err
+		}
+		got, err := tx.GetIssue(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if got == nil || got.Title != "visible inside" {
+			t.Errorf("tx.GetIssue = %+v, want created issue", got)
+		}
+		found, err := tx.SearchIssues(ctx, "visible inside", types.IssueFilter{})
+		if err != nil {
+			return err
+		}
+		if len(found) != 1 || found[0].ID != issue.ID {
+			t.Errorf("tx.SearchIssues = %v, want [%s]", ids(found), issue.ID)
+		}
+		if err := tx.SetMetadata(ctx, "storagetest.tx", "v"); err != nil {
+			return err
+		}
+		if v, err := tx.GetMetadata(ctx, "storagetest.tx"); err != nil || v != "v" {
+			t.Errorf("tx.GetMetadata = %q, %v; want v", v, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+}
+
+func testTxAllOperations(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	blocker := task(t, s, "blocker", 2)
+	doomed := task(t, s, "doomed", 2)
+	var a, b *types.Issue
+
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		a = &types.Issue{Title: "a", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		b = &types.Issue{Title: "b", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := tx.CreateIssues(ctx, []*types.Issue{a, b}, "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.AddDependency(ctx, &types.Dependency{IssueID: a.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.AddDependency(ctx, &types.Dependency{IssueID: b.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.RemoveDependency(ctx, b.ID, blocker.ID, "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.AddLabel(ctx, a.ID, "keep", "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.AddLabel(ctx, a.ID, "drop", "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.RemoveLabel(ctx, a.ID, "drop", "storagetest"); err != nil {
+			return err
+		}
+		if err := tx.AddComment(ctx, a.ID, "alice", "from tx"); err != nil {
+			return err
+		}
+		if err := tx.CloseIssue(ctx, blocker.ID, "done", "storagetest", ""); err != nil {
+			return err
+		}
+		return tx.DeleteIssue(ctx, doomed.ID)
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+
+	deps, err := s.GetDependencies(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].ID != blocker.ID {
+		t.Errorf("deps of a = %v, want [%s]", ids(deps), blocker.ID)
+	}
+	if deps, _ := s.GetDependencies(ctx, b.ID); len(deps) != 0 {
+		t.Errorf("deps of b = %v, want none", ids(deps))
+	}
+	labels, err := s.GetLabels(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if !sameSet(labels, []string{"keep"}) {
+		t.Errorf("labels = %v, want [keep]", labels)
+	}
+	if got := mustGet(t, s, blocker.ID); got.Status != types.StatusClosed {
+		t.Errorf("blocker status = %s, want closed", got.Status)
+	}
+	if got, err := s.GetIssue(ctx, doomed.ID); err != nil || got != nil {
+		t.Errorf("deleted issue still present: %v, %v", got, err)
+	}
+	events, err := s.GetEvents(ctx, a.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var commented bool
+	for _, e := range events {
+		if e.EventType == types.EventCommented && e.Comment != nil && *e.Comment == "from tx" {
+			commented = true
+		}
+	}
+	if !commented {
+		t.Error("tx.AddComment not recorded")
+	}
+}
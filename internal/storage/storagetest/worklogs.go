@@ -0,0 +1,121 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func workLogCases() []testCase {
+	return []testCase{
+		{name: "StartStop", fn: testWorkLogStartStop},
+		{name: "RunningNeverReopens", fn: testWorkLogRunningNeverReopens},
+		{name: "ForIssues", fn: testWorkLogsForIssues},
+		{name: "RejectsNegativeSpan", fn: testWorkLogRejectsNegativeSpan},
+	}
+}
+
+func testWorkLogStartStop(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "timed work", 2)
+
+	// Sub-second precision is dropped so the (issue, actor, start) key is stable
+	start := time.Date(2026, 3, 1, 9, 0, 0, 500_000_000, time.UTC)
+	if err := s.AddWorkLog(ctx, issue.ID, &types.WorkLog{StartedAt: start}, "alice"); err != nil {
+		t.Fatalf("AddWorkLog (start) failed: %v", err)
+	}
+	got, err := s.GetWorkLogs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLogs failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Actor != "alice" || !got[0].IsRunning() {
+		t.Fatalf("after start = %+v, want one running entry for alice", got)
+	}
+
+	end := start.Add(45 * time.Minute)
+	stop := &types.WorkLog{Actor: "alice", StartedAt: got[0].StartedAt, EndedAt: &end, Note: "fixed flake"}
+	if err := s.AddWorkLog(ctx, issue.ID, stop, "alice"); err != nil {
+		t.Fatalf("AddWorkLog (stop) failed: %v", err)
+	}
+	got, err = s.GetWorkLogs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLogs failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("stop duplicated the entry: %+v", got)
+	}
+	if got[0].IsRunning() || got[0].Duration(time.Now()) != 45*time.Minute || got[0].Note != "fixed flake" {
+		t.Errorf("after stop = %+v, want 45m with note", got[0])
+	}
+}
+
+func testWorkLogRunningNeverReopens(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "re-imported work", 2)
+
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	if err := s.AddWorkLog(ctx, issue.ID, &types.WorkLog{Actor: "bob", StartedAt: start, EndedAt: &end, Note: "done"}, "bob"); err != nil {
+		t.Fatalf("AddWorkLog failed: %v", err)
+	}
+	// An older copy of the same entry (still running, no note) must not undo the stop
+	if err := s.AddWorkLog(ctx, issue.ID, &types.WorkLog{Actor: "bob", StartedAt: start}, "import"); err != nil {
+		t.Fatalf("AddWorkLog (stale) failed: %v", err)
+	}
+	got, err := s.GetWorkLogs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLogs failed: %v", err)
+	}
+	if len(got) != 1 || got[0].IsRunning() || got[0].Note != "done" {
+		t.Errorf("stale entry changed the log: %+v", got)
+	}
+}
+
+func testWorkLogsForIssues(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	a := task(t, s, "first", 2)
+	b := task(t, s, "second", 2)
+	c := task(t, s, "untimed", 2)
+
+	start := time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC)
+	for i, actor := range []string{"alice", "bob"} {
+		entry := &types.WorkLog{StartedAt: start.Add(time.Duration(i) * time.Hour)}
+		if err := s.AddWorkLog(ctx, a.ID, entry, actor); err != nil {
+			t.Fatalf("AddWorkLog failed: %v", err)
+		}
+	}
+	if err := s.AddWorkLog(ctx, b.ID, &types.WorkLog{StartedAt: start}, "carol"); err != nil {
+		t.Fatalf("AddWorkLog failed: %v", err)
+	}
+
+	got, err := s.GetWorkLogsForIssues(ctx, []string{a.ID, b.ID, c.ID})
+	if err != nil {
+		t.Fatalf("GetWorkLogsForIssues failed: %v", err)
+	}
+	if len(got[a.ID]) != 2 || got[a.ID][0].Actor != "alice" {
+		t.Errorf("%s work logs = %+v, want alice then bob", a.ID, got[a.ID])
+	}
+	if len(got[b.ID]) != 1 || len(got[c.ID]) != 0 {
+		t.Errorf("work logs = %v", got)
+	}
+	if empty, err := s.GetWorkLogsForIssues(ctx, nil); err != nil || len(empty) != 0 {
+		t.Errorf("GetWorkLogsForIssues(nil) = %v, %v", empty, err)
+	}
+}
+
+func testWorkLogRejectsNegativeSpan(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	issue := task(t, s, "time travel", 2)
+
+	start := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	end := start.Add(-time.Minute)
+	if err := s.AddWorkLog(ctx, issue.ID, &types.WorkLog{StartedAt: start, EndedAt: &end}, "alice"); err == nil {
+		t.Error("AddWorkLog should reject an entry that ends before it starts")
+	}
+	if err := s.AddWorkLog(ctx, "does-not-exist", &types.WorkLog{StartedAt: start}, "alice"); err == nil {
+		t.Error("AddWorkLog on a missing issue should fail")
+	}
+}
@@ -0,0 +1,310 @@
+package syncbranch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// RecoveryDirName is the directory under .beads/ where 'bd sync doctor
+// --auto-repair' writes recovery snapshots before changing the sync branch.
+const RecoveryDirName = "sync-recovery"
+
+// Health is a point-in-time report on the sync branch: divergence from the
+// remote, force-push detection, what a content merge would do to the issue
+// count, and the state of the worktree. It is produced by Diagnose for
+// 'bd sync doctor' and cached by the daemon for its status report.
+type Health struct {
+	Branch    string    `json:"branch"`
+	Remote    string    `json:"remote,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Divergence
+	RemoteExists bool `json:"remote_exists"`
+	LocalAhead   int  `json:"local_ahead"`
+	RemoteAhead  int  `json:"remote_ahead"`
+	Diverged     bool `json:"diverged"`
+	Significant  bool `json:"significant"`
+
+	// Force-push detection
+	ForcePush        bool   `json:"force_push"`
+	ForcePushMessage string `json:"force_push_message,omitempty"`
+
+	// Mass-delete safety: issue counts on each side and, when diverged, the
+	// result of a dry-run content merge.
+	LocalIssues  int    `json:"local_issues"`
+	RemoteIssues int    `json:"remote_issues"`
+	MergedIssues int    `json:"merged_issues,omitempty"`
+	MassDelete   bool   `json:"mass_delete"`
+	MergeError   string `json:"merge_error,omitempty"`
+
+	// Worktree health, as found before the check repaired anything
+	WorktreePath    string `json:"worktree_path"`
+	WorktreeHealthy bool   `json:"worktree_healthy"`
+	WorktreeError   string `json:"worktree_error,omitempty"`
+
+	// Errors lists checks that could not run (e.g., offline fetch).
+	Errors []string `json:"errors,omitempty"`
+}
+
+// RepairStrategy is how --auto-repair brings the sync branch back in line.
+type RepairStrategy string
+
+const (
+	RepairNone         RepairStrategy = "none"          // Nothing to repair
+	RepairFastForward  RepairStrategy = "fast-forward"  // Remote is ahead only
+	RepairContentMerge RepairStrategy = "content-merge" // 3-way JSONL merge onto remote history
+	RepairReset        RepairStrategy = "reset"         // Adopt remote history (it was rewritten)
+	RepairManual       RepairStrategy = "manual"        // Not safe to repair automatically
+)
+
+// OK reports whether the sync branch needs no attention.
+func (h *Health) OK() bool {
+	return h.WorktreeHealthy && !h.ForcePush && !h.Diverged && !h.MassDelete &&
+		h.MergeError == "" && len(h.Errors) == 0
+}
+
+// Strategy picks the safe repair for the diagnosed state and explains why.
+//
+// Force-pushed remotes are reset to: the old merge base is no longer in the
+// remote history, so a 3-way merge against it can resurrect or drop issues.
+// Ordinary divergence is content-merged, unless the merge would fail or would
+// drop more than half the local issues, which needs a human.
+func (h *Health) Strategy() (RepairStrategy, string) {
+	switch {
+	case !h.RemoteExists:
+		return RepairNone, "remote sync branch does not exist yet; the next sync pushes it"
+	case h.ForcePush:
+		return RepairReset, "remote history was rewritten, so a merge against the old base is unreliable; local changes are kept in the database and re-exported on the next sync"
+	case h.Diverged && h.MergeError != "":
+		return RepairManual, "content merge would fail: " + h.MergeError
+	case h.Diverged && h.MassDelete:
+		return RepairManual, fmt.Sprintf("content merge would drop %d of %d local issues", h.LocalIssues-h.MergedIssues, h.LocalIssues)
+	case h.Diverged:
+		return RepairContentMerge, fmt.Sprintf("%d local and %d remote commits can be merged at the content level", h.LocalAhead, h.RemoteAhead)
+	case h.RemoteAhead > 0:
+		return RepairFastForward, fmt.Sprintf("remote is %d commit(s) ahead", h.RemoteAhead)
+	case h.LocalAhead > 0:
+		return RepairNone, fmt.Sprintf("local is %d commit(s) ahead; the next sync pushes them", h.LocalAhead)
+	default:
+		return RepairNone, "in sync with remote"
+	}
+}
+
+// Diagnose checks the sync branch without changing issue data. Like every
+// sync it fetches the remote and recreates a missing or broken worktree;
+// WorktreeHealthy reflects the state found before that happened.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - store: Storage interface for reading the last synced remote SHA
+//   - repoRoot: Path to the git repository root
+//   - syncBranch: Name of the sync branch (e.g., "beads-sync")
+//   - jsonlPath: Absolute path to the JSONL file in the main repo
+//
+// Returns an error only if the worktree cannot be set up; checks that fail
+// afterwards are recorded in Health.Errors.
+func Diagnose(ctx context.Context, store storage.Storage, repoRoot, syncBranch, jsonlPath string) (*Health, error) {
+	h := &Health{
+		Branch:       syncBranch,
+		CheckedAt:    time.Now().UTC(),
+		WorktreePath: getBeadsWorktreePath(ctx, repoRoot, syncBranch),
+	}
+
+	// A missing worktree is normal (it is created on first sync); only one
+	// that exists but is broken counts as unhealthy.
+	h.WorktreeHealthy = true
+	if _, err := os.Stat(h.WorktreePath); err == nil {
+		wtMgr := git.NewWorktreeManager(repoRoot)
+		if err := wtMgr.CheckWorktreeHealth(h.WorktreePath); err != nil {
+			h.WorktreeHealthy = false
+			h.WorktreeError = err.Error()
+		}
+	}
+
+	div, err := CheckDivergence(ctx, repoRoot, syncBranch)
+	if err != nil {
+		return nil, err
+	}
+	h.Remote = div.Remote
+	h.LocalAhead, h.RemoteAhead = div.LocalAhead, div.RemoteAhead
+	h.Diverged, h.Significant = div.IsDiverged, div.IsSignificant
+
+	remoteRef := fmt.Sprintf("%s/%s", h.Remote, syncBranch)
+	h.RemoteExists = exec.CommandContext(ctx, "git", "-C", h.WorktreePath, "rev-parse", "--verify", "--quiet", "refs/remotes/"+remoteRef).Run() == nil // #nosec G204 - ref built from validated branch name
+
+	if fp, err := CheckForcePush(ctx, store, repoRoot, syncBranch); err != nil {
+		h.Errors = append(h.Errors, fmt.Sprintf("force-push check: %v", err))
+	} else {
+		h.ForcePush = fp.Detected
+		if fp.Detected {
+			h.ForcePushMessage = fp.Message
+		}
+	}
+
+	jsonlRelPath, err := filepath.Rel(repoRoot, jsonlPath)
+	if err != nil {
+		h.Errors = append(h.Errors, fmt.Sprintf("JSONL path: %v", err))
+		return h, nil
+	}
+	jsonlRelPath = normalizeBeadsRelPath(jsonlRelPath)
+
+	if local, err := extractJSONLFromCommit(ctx, h.WorktreePath, "HEAD", jsonlRelPath); err == nil {
+		h.LocalIssues = countIssuesInContent(local)
+	}
+	if !h.RemoteExists {
+		return h, nil
+	}
+	if remote, err := extractJSONLFromCommit(ctx, h.WorktreePath, remoteRef, jsonlRelPath); err == nil {
+		h.RemoteIssues = countIssuesInContent(remote)
+	}
+
+	// Dry-run the content merge a pull would perform, to report whether it
+	// would trip the mass-delete safety check.
+	if h.Diverged {
+		merged, err := performContentMerge(ctx, h.WorktreePath, syncBranch, h.Remote, jsonlRelPath)
+		if err != nil {
+			h.MergeError = err.Error()
+		} else {
+			h.MergedIssues = countIssuesInContent(merged)
+			_, h.MassDelete = massDeletion(h.LocalIssues, h.MergedIssues)
+		}
+	}
+	return h, nil
+}
+
+// RepairResult describes what Repair did.
+type RepairResult struct {
+	Strategy RepairStrategy `json:"strategy"`
+	Reason   string         `json:"reason"`
+	Applied  bool           `json:"applied"`
+	Snapshot string         `json:"snapshot,omitempty"` // Recovery snapshot directory
+}
+
+// Repair applies the strategy chosen by h.Strategy(). Before touching the
+// sync branch it writes a recovery snapshot under .beads/sync-recovery/.
+// RepairNone and RepairManual are reported without changing anything.
+//
+// The repaired JSONL is copied to the main repo; the caller's next sync
+// imports it and pushes. The stored remote SHA is updated so an accepted
+// force-push is not reported again.
+func Repair(ctx context.Context, store storage.Storage, repoRoot, syncBranch, jsonlPath string, h *Health) (*RepairResult, error) {
+	strategy, reason := h.Strategy()
+	result := &RepairResult{Strategy: strategy, Reason: reason}
+	if strategy == RepairNone || strategy == RepairManual {
+		return result, nil
+	}
+
+	snapshot, err := WriteRecoverySnapshot(ctx, repoRoot, syncBranch, jsonlPath, h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write recovery snapshot (nothing was changed): %w", err)
+	}
+	result.Snapshot = snapshot
+
+	switch strategy {
+	case RepairFastForward, RepairContentMerge:
+		if _, err := PullFromSyncBranch(ctx, repoRoot, syncBranch, jsonlPath, false); err != nil {
+			return result, fmt.Errorf("%s failed: %w", strategy, err)
+		}
+	case RepairReset:
+		if err := ResetToRemote(ctx, repoRoot, syncBranch, jsonlPath); err != nil {
+			return result, fmt.Errorf("reset failed: %w", err)
+		}
+	}
+	result.Applied = true
+
+	if err := UpdateStoredRemoteSHA(ctx, store, repoRoot, syncBranch); err != nil {
+		return result, fmt.Errorf("repaired, but failed to record remote SHA: %w", err)
+	}
+	return result, nil
+}
+
+// recoveryManifest is written as manifest.json inside a recovery snapshot.
+type recoveryManifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Branch    string    `json:"branch"`
+	Remote    string    `json:"remote,omitempty"`
+	LocalSHA  string    `json:"local_sha,omitempty"`
+	RemoteSHA string    `json:"remote_sha,omitempty"`
+	Health    *Health   `json:"health"`
+}
+
+// WriteRecoverySnapshot saves everything a repair could overwrite into a new
+// timestamped directory under .beads/sync-recovery/ and returns its path:
+//
+//	issues.jsonl   the main repo's JSONL (current database export)
+//	local.jsonl    the sync branch HEAD
+//	remote.jsonl   the remote sync branch
+//	manifest.json  commit SHAs and the diagnosis
+//
+// Files that do not exist on a side are skipped.
+func WriteRecoverySnapshot(ctx context.Context, repoRoot, syncBranch, jsonlPath string, h *Health) (string, error) {
+	dir := filepath.Join(filepath.Dir(jsonlPath), RecoveryDirName, time.Now().UTC().Format("20060102T150405.000Z"))
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+
+	if data, err := os.ReadFile(jsonlPath); err == nil { // #nosec G304 - jsonlPath is the configured JSONL
+		if err := os.WriteFile(filepath.Join(dir, "issues.jsonl"), data, 0600); err != nil {
+			return "", err
+		}
+	}
+
+	worktreePath := getBeadsWorktreePath(ctx, repoRoot, syncBranch)
+	manifest := recoveryManifest{CreatedAt: time.Now().UTC(), Branch: syncBranch, Remote: h.Remote, Health: h}
+	jsonlRelPath := normalizeBeadsRelPath(filepath.Base(filepath.Dir(jsonlPath)) + "/" + filepath.Base(jsonlPath))
+	if rel, err := filepath.Rel(repoRoot, jsonlPath); err == nil {
+		jsonlRelPath = normalizeBeadsRelPath(rel)
+	}
+
+	sides := []struct {
+		ref, file string
+		sha       *string
+	}{
+		{"HEAD", "local.jsonl", &manifest.LocalSHA},
+		{fmt.Sprintf("%s/%s", h.Remote, syncBranch), "remote.jsonl", &manifest.RemoteSHA},
+	}
+	for _, side := range sides {
+		if side.ref != "HEAD" && !h.RemoteExists {
+			continue
+		}
+		if out, err := exec.CommandContext(ctx, "git", "-C", worktreePath, "rev-parse", side.ref).Output(); err == nil { // #nosec G204 - ref built from validated branch name
+			*side.sha = strings.TrimSpace(string(out))
+		}
+		data, err := extractJSONLFromCommit(ctx, worktreePath, side.ref, jsonlRelPath)
+		if err != nil {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, side.file), data, 0600); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0600); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// massDeletion reports the share of local issues that vanished in a merge,
+// and whether it crosses the safety threshold: more than half of more than
+// five issues. "Vanished" means removed from the JSONL entirely, not closed.
+func massDeletion(localCount, mergedCount int) (float64, bool) {
+	if localCount <= 5 || mergedCount >= localCount {
+		return 0, false
+	}
+	percent := float64(localCount-mergedCount) / float64(localCount) * 100
+	return percent, percent > 50
+}
@@ -0,0 +1,210 @@
+package syncbranch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHealthStrategy(t *testing.T) {
+	tests := []struct {
+		name   string
+		health Health
+		want   RepairStrategy
+	}{
+		{"remote missing", Health{}, RepairNone},
+		{"in sync", Health{RemoteExists: true}, RepairNone},
+		{"local ahead", Health{RemoteExists: true, LocalAhead: 2}, RepairNone},
+		{"remote ahead", Health{RemoteExists: true, RemoteAhead: 3}, RepairFastForward},
+		{"diverged", Health{RemoteExists: true, LocalAhead: 1, RemoteAhead: 1, Diverged: true}, RepairContentMerge},
+		{"diverged with mass delete", Health{RemoteExists: true, Diverged: true, MassDelete: true, LocalIssues: 10, MergedIssues: 2}, RepairManual},
+		{"diverged with merge error", Health{RemoteExists: true, Diverged: true, MergeError: "conflict"}, RepairManual},
+		{"force push wins over divergence", Health{RemoteExists: true, Diverged: true, ForcePush: true}, RepairReset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := tt.health.Strategy()
+			if got != tt.want {
+				t.Errorf("Strategy() = %s, want %s", got, tt.want)
+			}
+			if reason == "" {
+				t.Error("Strategy() returned empty reason")
+			}
+		})
+	}
+}
+
+func TestMassDeletion(t *testing.T) {
+	tests := []struct {
+		local, merged int
+		want          bool
+	}{
+		{10, 4, true},
+		{10, 5, false}, // exactly half is not more than half
+		{5, 0, false},  // too few issues to judge
+		{6, 2, true},
+		{10, 12, false},
+	}
+	for _, tt := range tests {
+		if _, got := massDeletion(tt.local, tt.merged); got != tt.want {
+			t.Errorf("massDeletion(%d, %d) = %v, want %v", tt.local, tt.merged, got, tt.want)
+		}
+	}
+}
+
+// setupDoctorRepos creates a bare remote, a local repo whose sync branch is
+// pushed to it, and a second clone standing in for another machine.
+func setupDoctorRepos(t *testing.T, syncBranch, content string) (repoDir, otherDir string) {
+	t.Helper()
+	remoteDir := t.TempDir()
+	runGit(t, remoteDir, "init", "--bare")
+
+	repoDir = setupTestRepoWithRemote(t)
+	t.Cleanup(func() { os.RemoveAll(repoDir) })
+	runGit(t, repoDir, "remote", "set-url", "origin", remoteDir)
+	runGit(t, repoDir, "push", "origin", "main")
+
+	runGit(t, repoDir, "checkout", "-b", syncBranch)
+	writeFile(t, filepath.Join(repoDir, ".beads", "issues.jsonl"), content)
+	runGit(t, repoDir, "add", ".")
+	runGit(t, repoDir, "commit", "-m", "sync base")
+	runGit(t, repoDir, "push", "origin", syncBranch)
+	runGit(t, repoDir, "checkout", "main")
+
+	otherDir = filepath.Join(t.TempDir(), "other")
+	runGit(t, filepath.Dir(otherDir), "clone", "--branch", syncBranch, remoteDir, otherDir)
+	runGit(t, otherDir, "config", "user.email", "other@test.com")
+	runGit(t, otherDir, "config", "user.name", "Other User")
+	return repoDir, otherDir
+}
+
+func TestDiagnoseAndRepair(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	syncBranch := "beads-sync"
+
+	t.Run("diverged branches are content merged", func(t *testing.T) {
+		repoDir, otherDir := setupDoctorRepos(t, syncBranch, `{"id":"bd-1","title":"Base","status":"open"}`+"\n")
+		jsonlPath := filepath.Join(repoDir, ".beads", "issues.jsonl")
+		store := newTestStoreIntegrity(t)
+		defer store.Close()
+
+		h, err := Diagnose(ctx, store, repoDir, syncBranch, jsonlPath)
+		if err != nil {
+			t.Fatalf("Diagnose() error = %v", err)
+		}
+		if !h.RemoteExists || h.Diverged || h.LocalIssues != 1 || h.RemoteIssues != 1 {
+			t.Fatalf("unexpected initial health: %+v", h)
+		}
+		if !h.OK() {
+			t.Errorf("OK() = false for in-sync branch: %+v", h)
+		}
+
+		// Another machine pushes a new issue...
+		writeFile(t, filepath.Join(otherDir, ".beads", "issues.jsonl"),
+			`{"id":"bd-1","title":"Base","status":"open"}`+"\n"+`{"id":"bd-2","title":"Remote","status":"open"}`+"\n")
+		runGit(t, otherDir, "commit", "-am", "remote issue")
+		runGit(t, otherDir, "push", "origin", syncBranch)
+
+		// ...while this one commits a different issue to its worktree.
+		writeFile(t, filepath.Join(h.WorktreePath, ".beads", "issues.jsonl"),
+			`{"id":"bd-1","title":"Base","status":"open"}`+"\n"+`{"id":"bd-3","title":"Local","status":"open"}`+"\n")
+		runGit(t, h.WorktreePath, "commit", "-am", "local issue")
+
+		h, err = Diagnose(ctx, store, repoDir, syncBranch, jsonlPath)
+		if err != nil {
+			t.Fatalf("Diagnose() error = %v", err)
+		}
+		if !h.Diverged || h.LocalAhead != 1 || h.RemoteAhead != 1 {
+			t.Fatalf("expected 1/1 divergence, got %+v", h)
+		}
+		if h.MergedIssues != 3 || h.MassDelete || h.MergeError != "" {
+			t.Errorf("dry-run merge: merged=%d massDelete=%v err=%q", h.MergedIssues, h.MassDelete, h.MergeError)
+		}
+		if s, _ := h.Strategy(); s != RepairContentMerge {
+			t.Fatalf("Strategy() = %s, want %s", s, RepairContentMerge)
+		}
+
+		result, err := Repair(ctx, store, repoDir, syncBranch, jsonlPath, h)
+		if err != nil {
+			t.Fatalf("Repair() error = %v", err)
+		}
+		if !result.Applied || result.Snapshot == "" {
+			t.Fatalf("unexpected repair result: %+v", result)
+		}
+		for _, f := range []string{"local.jsonl", "remote.jsonl", "manifest.json"} {
+			if _, err := os.Stat(filepath.Join(result.Snapshot, f)); err != nil {
+				t.Errorf("snapshot missing %s: %v", f, err)
+			}
+		}
+		local, _ := os.ReadFile(filepath.Join(result.Snapshot, "local.jsonl"))
+		if !strings.Contains(string(local), "bd-3") {
+			t.Errorf("local.jsonl snapshot = %q, want bd-3", local)
+		}
+
+		merged, err := os.ReadFile(jsonlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []string{"bd-1", "bd-2", "bd-3"} {
+			if !strings.Contains(string(merged), id) {
+				t.Errorf("merged JSONL missing %s:\n%s", id, merged)
+			}
+		}
+	})
+
+	t.Run("force-pushed remote is reset to", func(t *testing.T) {
+		repoDir, otherDir := setupDoctorRepos(t, syncBranch, `{"id":"bd-1","title":"Base","status":"open"}`+"\n")
+		jsonlPath := filepath.Join(repoDir, ".beads", "issues.jsonl")
+		store := newTestStoreIntegrity(t)
+		defer store.Close()
+
+		if _, err := Diagnose(ctx, store, repoDir, syncBranch, jsonlPath); err != nil {
+			t.Fatalf("Diagnose() error = %v", err)
+		}
+		if err := UpdateStoredRemoteSHA(ctx, store, repoDir, syncBranch); err != nil {
+			t.Fatalf("UpdateStoredRemoteSHA() error = %v", err)
+		}
+
+		// Rewrite the remote history from the other clone.
+		writeFile(t, filepath.Join(otherDir, ".beads", "issues.jsonl"), `{"id":"bd-9","title":"Rewritten","status":"open"}`+"\n")
+		runGit(t, otherDir, "commit", "-a", "--amend", "-m", "rewritten")
+		runGit(t, otherDir, "push", "--force", "origin", syncBranch)
+
+		h, err := Diagnose(ctx, store, repoDir, syncBranch, jsonlPath)
+		if err != nil {
+			t.Fatalf("Diagnose() error = %v", err)
+		}
+		if !h.ForcePush {
+			t.Fatalf("expected force-push to be detected: %+v", h)
+		}
+		if s, _ := h.Strategy(); s != RepairReset {
+			t.Fatalf("Strategy() = %s, want %s", s, RepairReset)
+		}
+
+		result, err := Repair(ctx, store, repoDir, syncBranch, jsonlPath, h)
+		if err != nil {
+			t.Fatalf("Repair() error = %v", err)
+		}
+		if !result.Applied {
+			t.Fatalf("repair not applied: %+v", result)
+		}
+		data, _ := os.ReadFile(jsonlPath)
+		if !strings.Contains(string(data), "bd-9") {
+			t.Errorf("JSONL after reset = %q, want remote content", data)
+		}
+
+		// The accepted rewrite is no longer reported.
+		h, err = Diagnose(ctx, store, repoDir, syncBranch, jsonlPath)
+		if err != nil {
+			t.Fatalf("Diagnose() error = %v", err)
+		}
+		if h.ForcePush {
+			t.Errorf("force-push still reported after reset: %+v", h)
+		}
+	})
+}
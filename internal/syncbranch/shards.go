@@ -0,0 +1,107 @@
+package syncbranch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/shard"
+)
+
+// extractShardedJSONL assembles the single-file JSONL for filePath from the
+// shards committed at commit. ok is false if the commit does not use the
+// sharded layout for filePath, in which case the caller reads the file itself.
+func extractShardedJSONL(ctx context.Context, worktreePath, commit, filePath string) (data []byte, ok bool, err error) {
+	shardDir := path.Join(filepath.ToSlash(filepath.Dir(filePath)), shard.DirName)
+	manifestCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "show",
+		fmt.Sprintf("%s:%s", commit, path.Join(shardDir, shard.ManifestName)))
+	manifestData, err := manifestCmd.Output()
+	if err != nil {
+		return nil, false, nil // No manifest: single-file layout
+	}
+	m, err := shard.ParseManifest(manifestData)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s at %s: %w", shardDir, commit, err)
+	}
+	if m.File != filepath.Base(filePath) {
+		return nil, false, nil
+	}
+
+	lsCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "ls-tree", "-r", "--name-only", commit, "--", shardDir+"/")
+	lsOutput, err := lsCmd.Output()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list shards in %s: %w", commit, err)
+	}
+	shards := make(map[string][]byte)
+	for _, name := range strings.Split(strings.TrimSpace(string(lsOutput)), "\n") {
+		if !strings.HasSuffix(name, ".jsonl") {
+			continue
+		}
+		showCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "show", fmt.Sprintf("%s:%s", commit, name))
+		content, err := showCmd.Output()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to extract shard %s from %s: %w", name, commit, err)
+		}
+		shards[strings.TrimPrefix(name, shardDir+"/")] = content
+	}
+	data, err = shard.Join(shards)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to assemble shards from %s: %w", commit, err)
+	}
+	return data, true, nil
+}
+
+// writeJSONL writes a JSONL file and, when it is the working copy of a
+// sharded layout, the shards that git actually tracks.
+func writeJSONL(jsonlPath string, data []byte) error {
+	if err := os.WriteFile(jsonlPath, data, 0600); err != nil {
+		return err
+	}
+	return shard.Export(jsonlPath)
+}
+
+// untrackedBeadsPaths returns the worktree-relative paths under .beads that
+// are local working state in the sharded layout and must not be committed.
+// It returns nil for the single-file layout.
+func untrackedBeadsPaths(worktreePath, jsonlPath string) []string {
+	if !shard.IsWorkingCopy(jsonlPath) {
+		return nil
+	}
+	var rels []string
+	for _, p := range shard.UntrackedPaths(jsonlPath) {
+		if rel, err := filepath.Rel(worktreePath, p); err == nil {
+			rels = append(rels, filepath.ToSlash(rel))
+		}
+	}
+	return rels
+}
+
+// filterPorcelain drops `git status --porcelain` lines for the given paths.
+func filterPorcelain(output []byte, exclude []string) []byte {
+	if len(exclude) == 0 {
+		return output
+	}
+	var kept [][]byte
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		if len(line) > 3 {
+			p := strings.Trim(string(line[3:]), `"`)
+			skip := false
+			for _, e := range exclude {
+				if p == e {
+					skip = true
+					break
+				}
+			}
+			if skip {
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+	return bytes.Join(kept, []byte("\n"))
+}
@@ -0,0 +1,87 @@
+package syncbranch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/shard"
+)
+
+func TestFilterPorcelain(t *testing.T) {
+	out := []byte(" M .beads/issues/bd/03.jsonl\n?? .beads/issues.jsonl\n?? .beads/shard-state.json")
+	got := string(filterPorcelain(out, []string{".beads/issues.jsonl", ".beads/shard-state.json"}))
+	if got != " M .beads/issues/bd/03.jsonl" {
+		t.Errorf("filterPorcelain() = %q", got)
+	}
+	if got := filterPorcelain(out, nil); string(got) != string(out) {
+		t.Errorf("filterPorcelain(nil) changed output: %q", got)
+	}
+}
+
+func TestShardedSyncBranch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	ctx := context.Background()
+	syncBranch := "beads-sync"
+	base := `{"id":"bd-1","title":"Base","status":"open"}` + "\n" + `{"id":"bd-2","title":"Second","status":"open"}` + "\n"
+	repoDir, otherDir := setupDoctorRepos(t, syncBranch, base)
+	jsonlPath := filepath.Join(repoDir, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(jsonlPath), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another clone migrates the sync branch to the sharded layout.
+	otherJSONL := filepath.Join(otherDir, ".beads", "issues.jsonl")
+	if _, err := shard.Migrate(otherJSONL, 4); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	runGit(t, otherDir, "add", ".beads/issues")
+	runGit(t, otherDir, "rm", "--cached", "-q", ".beads/issues.jsonl")
+	runGit(t, otherDir, "commit", "-m", "shard")
+	runGit(t, otherDir, "push", "origin", syncBranch)
+
+	// Pulling assembles the shards into this clone's single JSONL.
+	if _, err := PullFromSyncBranch(ctx, repoDir, syncBranch, jsonlPath, false); err != nil {
+		t.Fatalf("PullFromSyncBranch() error = %v", err)
+	}
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != base {
+		t.Errorf("pulled JSONL = %q, want %q", data, base)
+	}
+
+	// Committing a new issue writes shards, not issues.jsonl.
+	withNew := base + `{"id":"bd-3","title":"Third","status":"open"}` + "\n"
+	writeFile(t, jsonlPath, withNew)
+	result, err := CommitToSyncBranch(ctx, repoDir, syncBranch, jsonlPath, false)
+	if err != nil {
+		t.Fatalf("CommitToSyncBranch() error = %v", err)
+	}
+	if !result.Committed {
+		t.Fatal("expected a commit on the sync branch")
+	}
+	worktreePath := getBeadsWorktreePath(ctx, repoDir, syncBranch)
+	files := getGitOutput(t, worktreePath, "ls-tree", "-r", "--name-only", "HEAD")
+	if strings.Contains(files, ".beads/issues.jsonl\n") || strings.Contains(files, shard.StateName) {
+		t.Errorf("working copy or shard state committed:\n%s", files)
+	}
+	if !strings.Contains(files, ".beads/issues/manifest.json") {
+		t.Errorf("shard manifest not committed:\n%s", files)
+	}
+	committed, err := extractJSONLFromCommit(ctx, worktreePath, "HEAD", ".beads/issues.jsonl")
+	if err != nil {
+		t.Fatalf("extractJSONLFromCommit() error = %v", err)
+	}
+	if string(committed) != withNew {
+		t.Errorf("committed shards assemble to %q, want %q", committed, withNew)
+	}
+	if changed, err := hasChangesInWorktree(ctx, worktreePath, filepath.Join(worktreePath, ".beads", "issues.jsonl")); err != nil || changed {
+		t.Errorf("hasChangesInWorktree() = %v, %v after commit; want false", changed, err)
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/merge"
+	"github.com/steveyegge/beads/internal/shard"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -113,9 +115,9 @@ func CommitToSyncBranch(ctx context.Context, repoRoot, syncBranch, jsonlPath str
 		return nil, fmt.Errorf("failed to sync JSONL to worktree: %w", err)
 	}
 
-	// Also sync other beads files (metadata.json)
+	// Also sync other beads files (metadata.json, namespace.jsonl)
 	beadsDir := filepath.Dir(jsonlPath)
-	for _, filename := range []string{"metadata.json"} {
+	for _, filename := range []string{"metadata.json", idgen.NamespaceFileName} {
 		srcPath := filepath.Join(beadsDir, filename)
 		if _, err := os.Stat(srcPath); err == nil {
 			relPath, err := filepath.Rel(repoRoot, srcPath)
@@ -333,7 +335,7 @@ func PullFromSyncBranch(ctx context.Context, repoRoot, syncBranch, jsonlPath str
 	if err := os.MkdirAll(filepath.Dir(worktreeJSONLPath), 0750); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
-	if err := os.WriteFile(worktreeJSONLPath, mergedContent, 0600); err != nil {
+	if err := writeJSONL(worktreeJSONLPath, mergedContent); err != nil {
 		return nil, fmt.Errorf("failed to write merged JSONL: %w", err)
 	}
 
@@ -371,35 +373,32 @@ func PullFromSyncBranch(ctx context.Context, repoRoot, syncBranch, jsonlPath str
 
 		// Warn if >50% issues vanished AND >5 existed before
 		// "Vanished" = removed from JSONL entirely (not status=closed)
-		if localCount > 5 && mergedCount < localCount {
-			vanishedPercent := float64(localCount-mergedCount) / float64(localCount) * 100
-			if vanishedPercent > 50 {
-				// Set safety check fields for caller to handle confirmation
-				result.SafetyCheckTriggered = true
-				result.SafetyCheckDetails = fmt.Sprintf("%.0f%% of issues vanished during merge (%d → %d issues)",
-					vanishedPercent, localCount, mergedCount)
-
-				// Return warnings in result instead of printing directly to stderr
+		if vanishedPercent, massDelete := massDeletion(localCount, mergedCount); massDelete {
+			// Set safety check fields for caller to handle confirmation
+			result.SafetyCheckTriggered = true
+			result.SafetyCheckDetails = fmt.Sprintf("%.0f%% of issues vanished during merge (%d → %d issues)",
+				vanishedPercent, localCount, mergedCount)
+
+			// Return warnings in result instead of printing directly to stderr
+			result.SafetyWarnings = append(result.SafetyWarnings,
+				fmt.Sprintf("⚠️  Warning: %.0f%% of issues vanished during merge (%d → %d issues)",
+					vanishedPercent, localCount, mergedCount))
+
+			// Add forensic info to warnings
+			localIssues := parseIssuesFromContent(localContent)
+			mergedIssues := parseIssuesFromContent(mergedContent)
+			forensicLines := formatVanishedIssues(localIssues, mergedIssues, localCount, mergedCount)
+			result.SafetyWarnings = append(result.SafetyWarnings, forensicLines...)
+
+			// Check if confirmation is required before pushing
+			if requireConfirmation {
 				result.SafetyWarnings = append(result.SafetyWarnings,
-					fmt.Sprintf("⚠️  Warning: %.0f%% of issues vanished during merge (%d → %d issues)",
-						vanishedPercent, localCount, mergedCount))
-
-				// Add forensic info to warnings
-				localIssues := parseIssuesFromContent(localContent)
-				mergedIssues := parseIssuesFromContent(mergedContent)
-				forensicLines := formatVanishedIssues(localIssues, mergedIssues, localCount, mergedCount)
-				result.SafetyWarnings = append(result.SafetyWarnings, forensicLines...)
-
-				// Check if confirmation is required before pushing
-				if requireConfirmation {
-					result.SafetyWarnings = append(result.SafetyWarnings,
-						"   Push skipped - confirmation required (sync.require_confirmation_on_mass_delete=true)")
-					skipPushForConfirmation = true
-				} else {
-					result.SafetyWarnings = append(result.SafetyWarnings,
-						"   This may indicate accidental mass deletion. Pushing anyway.",
-						"   If this was unintended, use 'git reflog' on the sync branch to recover.")
-				}
+					"   Push skipped - confirmation required (sync.require_confirmation_on_mass_delete=true)")
+				skipPushForConfirmation = true
+			} else {
+				result.SafetyWarnings = append(result.SafetyWarnings,
+					"   This may indicate accidental mass deletion. Pushing anyway.",
+					"   If this was unintended, use 'git reflog' on the sync branch to recover.")
 			}
 		}
 
@@ -649,7 +648,11 @@ func performContentMerge(ctx context.Context, worktreePath, branch, remote, json
 }
 
 // extractJSONLFromCommit extracts a file's content from a specific git commit.
+// If the commit uses the sharded layout, the shards are assembled instead.
 func extractJSONLFromCommit(ctx context.Context, worktreePath, commit, filePath string) ([]byte, error) {
+	if data, ok, err := extractShardedJSONL(ctx, worktreePath, commit, filePath); ok || err != nil {
+		return data, err
+	}
 	cmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "show",
 		fmt.Sprintf("%s:%s", commit, filePath))
 	output, err := cmd.Output()
@@ -673,7 +676,7 @@ func copyCommittedJSONLToMainRepo(ctx context.Context, worktreePath, jsonlRelPat
 		return nil
 	}
 
-	if err := os.WriteFile(jsonlPath, data, 0600); err != nil {
+	if err := writeJSONL(jsonlPath, data); err != nil {
 		return fmt.Errorf("failed to write main JSONL: %w", err)
 	}
 
@@ -697,6 +700,11 @@ func copyJSONLToMainRepo(worktreePath, jsonlRelPath, jsonlPath string) error {
 	normalizedRelPath := normalizeBeadsRelPath(jsonlRelPath)
 	worktreeJSONLPath := filepath.Join(worktreePath, normalizedRelPath)
 
+	// Sharded layout: rebuild the worktree's working copy from its shards
+	if err := shard.Sync(worktreeJSONLPath); err != nil {
+		return fmt.Errorf("failed to reconcile worktree JSONL shards: %w", err)
+	}
+
 	// Check if worktree JSONL exists
 	if _, err := os.Stat(worktreeJSONLPath); os.IsNotExist(err) {
 		// No JSONL in worktree yet, nothing to sync
@@ -709,14 +717,14 @@ func copyJSONLToMainRepo(worktreePath, jsonlRelPath, jsonlPath string) error {
 		return fmt.Errorf("failed to read worktree JSONL: %w", err)
 	}
 
-	if err := os.WriteFile(jsonlPath, data, 0600); err != nil {
+	if err := writeJSONL(jsonlPath, data); err != nil {
 		return fmt.Errorf("failed to write main JSONL: %w", err)
 	}
 
-	// Also sync other beads files back (metadata.json)
+	// Also sync other beads files back (metadata.json, namespace.jsonl)
 	beadsDir := filepath.Dir(jsonlPath)
 	worktreeBeadsDir := filepath.Dir(worktreeJSONLPath)
-	for _, filename := range []string{"metadata.json"} {
+	for _, filename := range []string{"metadata.json", idgen.NamespaceFileName} {
 		worktreeSrcPath := filepath.Join(worktreeBeadsDir, filename)
 		if fileData, err := os.ReadFile(worktreeSrcPath); err == nil {
 			dstPath := filepath.Join(beadsDir, filename)
@@ -751,6 +759,8 @@ func hasChangesInWorktree(ctx context.Context, worktreePath, filePath string) (b
 	if err != nil {
 		return false, fmt.Errorf("git status failed in worktree: %w", err)
 	}
+	// The sharded layout's working copy is local state, not a change
+	output = filterPorcelain(output, untrackedBeadsPaths(worktreePath, filePath))
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
@@ -767,6 +777,15 @@ func commitInWorktree(ctx context.Context, worktreePath, jsonlRelPath, message s
 		return fmt.Errorf("git add failed in worktree: %w", err)
 	}
 
+	// Sharded layout: the shards are tracked, the working copy is not
+	if untracked := untrackedBeadsPaths(worktreePath, filepath.Join(worktreePath, jsonlRelPath)); len(untracked) > 0 {
+		rmArgs := append([]string{"-C", worktreePath, "rm", "--cached", "-q", "--ignore-unmatch", "--sparse", "--"}, untracked...)
+		rmCmd := exec.CommandContext(ctx, "git", rmArgs...)
+		if output, err := rmCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git rm --cached failed in worktree: %w\n%s", err, output)
+		}
+	}
+
 	// Commit with --no-verify to skip hooks (pre-commit hook would fail in worktree context)
 	// The worktree is internal to bd sync, so we don't need to run bd's pre-commit hook
 	commitCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "commit", "--no-verify", "-m", message)
@@ -828,7 +847,7 @@ func contentMergeRecovery(ctx context.Context, worktreePath, branch, remote stri
 	if err := os.MkdirAll(filepath.Dir(worktreeJSONLPath), 0750); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
-	if err := os.WriteFile(worktreeJSONLPath, mergedContent, 0600); err != nil {
+	if err := writeJSONL(worktreeJSONLPath, mergedContent); err != nil {
 		return fmt.Errorf("failed to write merged JSONL: %w", err)
 	}
 
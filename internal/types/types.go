@@ -64,6 +64,8 @@ type Issue struct {
 	Labels       []string      `json:"labels,omitempty"`
 	Dependencies []*Dependency `json:"dependencies,omitempty"`
 	Comments     []*Comment    `json:"comments,omitempty"`
+	Attachments  []*Attachment `json:"attachments,omitempty"` // Metadata only; content lives in .beads/blobs
+	WorkLogs     []*WorkLog    `json:"work_logs,omitempty"`   // Time actually spent (see EstimatedMinutes)
 
 	// ===== Tombstone Fields (soft-delete support) =====
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`    // When deleted
@@ -101,7 +103,7 @@ type Issue struct {
 
 	// ===== Source Tracing Fields (formula cooking origin) =====
 	SourceFormula  string `json:"source_formula,omitempty"`  // Formula name where step was defined
-	SourceLocation string `json:"source_location,omitempty"` // Path: "steps[0]", "advice[0].after"
+	SourceLocation string `json:"source_location,omitempty"` // Path: "steps[design]", "steps[impl].children[tests]"
 
 	// ===== Agent Identity Fields (agent-as-bead support) =====
 	HookBead     string     `json:"hook_bead,omitempty"`     // Current work on agent's hook (0..1)
@@ -659,8 +661,27 @@ type IssueWithDependencyMetadata struct {
 // IssueWithCounts extends Issue with dependency relationship counts
 type IssueWithCounts struct {
 	*Issue
-	DependencyCount int `json:"dependency_count"`
-	DependentCount  int `json:"dependent_count"`
+	DependencyCount int    `json:"dependency_count"`
+	DependentCount  int    `json:"dependent_count"`
+	SourceRepo      string `json:"source_repo,omitempty"` // Owning repo in multi-repo mode (see IssueWithRepo)
+}
+
+// IssueWithRepo pairs an issue with the repo that owns it in multi-repo mode.
+// Issue.SourceRepo is deliberately not serialized (it must never reach JSONL),
+// so RPC responses and JSON output carry it alongside the issue instead.
+// SourceRepo is empty for issues owned by the primary repo.
+type IssueWithRepo struct {
+	*Issue
+	SourceRepo string `json:"source_repo,omitempty"`
+}
+
+// ExternalSourceRepo returns issue.SourceRepo for issues hydrated from an
+// additional repo, and "" for issues owned by the primary repo.
+func ExternalSourceRepo(issue *Issue) string {
+	if issue == nil || issue.SourceRepo == "." {
+		return ""
+	}
+	return issue.SourceRepo
 }
 
 // IssueDetails extends Issue with labels, dependencies, dependents, and comments.
@@ -671,7 +692,10 @@ type IssueDetails struct {
 	Dependencies []*IssueWithDependencyMetadata `json:"dependencies,omitempty"`
 	Dependents   []*IssueWithDependencyMetadata `json:"dependents,omitempty"`
 	Comments     []*Comment                     `json:"comments,omitempty"`
+	Attachments  []*Attachment                  `json:"attachments,omitempty"`
+	WorkLogs     []*WorkLog                     `json:"work_logs,omitempty"`
 	Parent       *string                        `json:"parent,omitempty"`
+	SourceRepo   string                         `json:"source_repo,omitempty"` // Owning repo in multi-repo mode (see IssueWithRepo)
 }
 
 // DependencyType categorizes the relationship
@@ -739,6 +763,14 @@ func (d DependencyType) AffectsReadyWork() bool {
 	return d == DepBlocks || d == DepParentChild || d == DepConditionalBlocks || d == DepWaitsFor
 }
 
+// IsCycleChecked returns true if this dependency type is part of the DAG that
+// cycle detection guards. relates-to links are bidirectional by design, and a
+// caused-by edge from a close cascade points an epic or delegator back at the
+// issue that closed it, so neither is rejected or reported as a cycle.
+func (d DependencyType) IsCycleChecked() bool {
+	return d != DepRelatesTo && d != DepCausedBy
+}
+
 // WaitsForMeta holds metadata for waits-for dependencies (fanout gates).
 // Stored as JSON in the Dependency.Metadata field.
 type WaitsForMeta struct {
@@ -818,6 +850,46 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Attachment records a file attached to an issue. Only metadata is stored
+// on the issue; the content is a content-addressed blob under .beads/blobs,
+// keyed by its SHA256 so identical files are stored once.
+type Attachment struct {
+	Hash      string    `json:"hash"` // Hex SHA256 of the content (blob key)
+	Name      string    `json:"name"` // Original file name
+	Size      int64     `json:"size"`
+	MimeType  string    `json:"mime_type,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkLog records a span of time an actor spent working on an issue.
+// Entries are keyed by (issue, actor, start) so the same entry synced from
+// another clone updates in place instead of duplicating. A nil EndedAt means
+// the timer is still running.
+type WorkLog struct {
+	Actor     string     `json:"actor"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+}
+
+// IsRunning reports whether the timer has not been stopped yet.
+func (w *WorkLog) IsRunning() bool {
+	return w.EndedAt == nil
+}
+
+// Duration returns the logged time. Running timers count up to now.
+func (w *WorkLog) Duration(now time.Time) time.Duration {
+	end := now
+	if w.EndedAt != nil {
+		end = *w.EndedAt
+	}
+	if end.Before(w.StartedAt) {
+		return 0
+	}
+	return end.Sub(w.StartedAt)
+}
+
 // Event represents an audit trail entry
 type Event struct {
 	ID        int64      `json:"id"`
@@ -846,6 +918,10 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventAttachmentAdded   EventType = "attachment_added"
+	EventAttachmentRemoved EventType = "attachment_removed"
+	EventWorkLogged        EventType = "work_logged"
+	EventCascadeClosed     EventType = "cascade_closed" // NewValue is the issue whose close cascaded
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -863,6 +939,23 @@ type TreeNode struct {
 	Truncated bool   `json:"truncated"`
 }
 
+// GraphNeighbor is an issue reached by walking dependency edges in either
+// direction from a start issue (bd related)
+type GraphNeighbor struct {
+	Issue
+	Distance int            `json:"distance"` // Edges on the shortest path from the start issue
+	Via      DependencyType `json:"via"`      // Type of the last edge on that path
+	ViaID    string         `json:"via_id"`   // Issue the last edge came from
+}
+
+// GraphPathStep is one edge on a path between two issues (bd path)
+type GraphPathStep struct {
+	FromID  string         `json:"from_id"`
+	ToID    string         `json:"to_id"`
+	Type    DependencyType `json:"type"`
+	Reverse bool           `json:"reverse"` // Stored edge points ToID -> FromID (walked against its direction)
+}
+
 // MoleculeProgressStats provides efficient progress info for large molecules.
 // This uses indexed queries instead of loading all steps into memory.
 type MoleculeProgressStats struct {
@@ -944,6 +1037,10 @@ type IssueFilter struct {
 	// Molecule type filtering
 	MolType *MolType // Filter by molecule type (nil = any, swarm/patrol/work)
 
+	// Multi-repo filtering: "." selects the primary repo, anything else the
+	// repos.additional entry the issue was hydrated from
+	SourceRepo *string
+
 	// Status exclusion (for default non-closed behavior)
 	ExcludeStatus []Status // Exclude issues with these statuses
 
@@ -976,17 +1073,44 @@ const (
 	// SortPolicyOldest always sorts by creation date (oldest first)
 	// Use for backlog clearing, preventing issue starvation
 	SortPolicyOldest SortPolicy = "oldest"
+
+	// SortPolicyDue sorts by priority boosted as the due date approaches
+	// (see DueBoost), then by due date. Use for deadline-driven work.
+	SortPolicyDue SortPolicy = "due"
 )
 
 // IsValid checks if the sort policy value is valid
 func (s SortPolicy) IsValid() bool {
 	switch s {
-	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, "":
+	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, SortPolicyDue, "":
 		return true
 	}
 	return false
 }
 
+// Due-date urgency windows used by SortPolicyDue
+const (
+	DueSoonWindow  = 72 * time.Hour // Due within this: boost by 1
+	DueTodayWindow = 24 * time.Hour // Due within this: boost by 2
+)
+
+// DueBoost returns how many priority levels an issue is raised by under
+// SortPolicyDue: 3 when overdue, 2 when due within a day, 1 when due
+// within three days, otherwise 0.
+func DueBoost(dueAt *time.Time, now time.Time) int {
+	switch {
+	case dueAt == nil:
+		return 0
+	case dueAt.Before(now):
+		return 3
+	case dueAt.Before(now.Add(DueTodayWindow)):
+		return 2
+	case dueAt.Before(now.Add(DueSoonWindow)):
+		return 1
+	}
+	return 0
+}
+
 // WorkFilter is used to filter ready work queries
 type WorkFilter struct {
 	Status     Status
@@ -1005,8 +1129,57 @@ type WorkFilter struct {
 	// Molecule type filtering
 	MolType *MolType // Filter by molecule type (nil = any, swarm/patrol/work)
 
+	// Multi-repo filtering ("." = primary repo, see IssueFilter.SourceRepo)
+	SourceRepo *string
+
 	// Time-based deferral filtering (GH#820)
 	IncludeDeferred bool // If true, include issues with future defer_until timestamps
+
+	// Capability routing: only work the agent bead with this ID is qualified
+	// for, issues needing more of its capabilities first (see AgentCapabilities)
+	ForAgent string
+}
+
+// Capability routing labels. An issue labeled needs:<cap> is only ready for
+// agents that have <cap>.
+const (
+	NeedsLabelPrefix = "needs:" // On work: a capability the assignee must have
+	CanLabelPrefix   = "can:"   // On agent beads: a capability the agent declares
+)
+
+// RequiredCapabilities returns the capabilities named by needs:<cap> labels.
+func RequiredCapabilities(labels []string) []string {
+	var caps []string
+	for _, l := range labels {
+		if strings.HasPrefix(l, NeedsLabelPrefix) {
+			caps = append(caps, strings.TrimPrefix(l, NeedsLabelPrefix))
+		}
+	}
+	return caps
+}
+
+// AgentCapabilities returns the capabilities of an agent bead: those it
+// declares with can:<cap> labels, the skills attested for it by attests
+// edges, and role:<role_type> and rig:<rig>.
+func AgentCapabilities(agent *Issue, labels []string, attestedSkills []string) map[string]bool {
+	caps := make(map[string]bool)
+	for _, l := range labels {
+		if strings.HasPrefix(l, CanLabelPrefix) {
+			caps[strings.TrimPrefix(l, CanLabelPrefix)] = true
+		}
+	}
+	for _, skill := range attestedSkills {
+		if skill != "" {
+			caps[skill] = true
+		}
+	}
+	if agent != nil && agent.RoleType != "" {
+		caps["role:"+agent.RoleType] = true
+	}
+	if agent != nil && agent.Rig != "" {
+		caps["rig:"+agent.Rig] = true
+	}
+	return caps
 }
 
 // StaleFilter is used to filter stale issue queries
@@ -0,0 +1,67 @@
+package worklog
+
+import (
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// EstimateRow compares logged time with estimates for one group of issues.
+type EstimateRow struct {
+	Group            string  `json:"group"`
+	Issues           int     `json:"issues"`
+	EstimatedMinutes int     `json:"estimated_minutes"`
+	ActualMinutes    int     `json:"actual_minutes"`
+	Ratio            float64 `json:"ratio"` // Actual / estimated; >1 means underestimated
+}
+
+// ByType groups issues by issue type.
+func ByType(issue *types.Issue) string {
+	return string(issue.IssueType)
+}
+
+// ByAssignee groups issues by assignee.
+func ByAssignee(issue *types.Issue) string {
+	if issue.Assignee == "" {
+		return "(unassigned)"
+	}
+	return issue.Assignee
+}
+
+// CompareEstimates totals estimated and logged minutes per group. Only issues
+// that have both an estimate and completed work log entries are counted, so
+// the ratio compares like with like. Rows are sorted by group name.
+func CompareEstimates(issues []*types.Issue, logs map[string][]*types.WorkLog, groupBy func(*types.Issue) string) []EstimateRow {
+	rows := make(map[string]*EstimateRow)
+	for _, issue := range issues {
+		if issue.EstimatedMinutes == nil || *issue.EstimatedMinutes <= 0 {
+			continue
+		}
+		actual := Total(logs[issue.ID])
+		if actual <= 0 {
+			continue
+		}
+		group := groupBy(issue)
+		row := rows[group]
+		if row == nil {
+			row = &EstimateRow{Group: group}
+			rows[group] = row
+		}
+		row.Issues++
+		row.EstimatedMinutes += *issue.EstimatedMinutes
+		row.ActualMinutes += int(actual.Round(time.Minute) / time.Minute)
+	}
+
+	result := make([]EstimateRow, 0, len(rows))
+	for _, row := range rows {
+		if row.EstimatedMinutes > 0 {
+			row.Ratio = float64(row.ActualMinutes) / float64(row.EstimatedMinutes)
+		}
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Group < result[j].Group
+	})
+	return result
+}
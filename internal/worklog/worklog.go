@@ -0,0 +1,141 @@
+// Package worklog implements time tracking on top of the work_logs storage
+// methods: starting and stopping per-actor timers, the optional automatic
+// timers driven by status changes, and actual-vs-estimate reporting.
+package worklog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AutoTimerKey is the config.yaml key that enables automatic timers.
+const AutoTimerKey = "work.auto-timer"
+
+var (
+	// ErrAlreadyRunning is returned by Start when the actor already has a
+	// running timer on the issue.
+	ErrAlreadyRunning = errors.New("timer already running")
+	// ErrNotRunning is returned by Stop when the actor has no running timer
+	// on the issue.
+	ErrNotRunning = errors.New("no running timer")
+)
+
+// Running returns the actor's running entry, or nil if there is none.
+func Running(logs []*types.WorkLog, actor string) *types.WorkLog {
+	for _, w := range logs {
+		if w.Actor == actor && w.IsRunning() {
+			return w
+		}
+	}
+	return nil
+}
+
+// Start starts a timer for actor on the issue.
+func Start(ctx context.Context, s storage.Storage, issueID, actor, note string, now time.Time) (*types.WorkLog, error) {
+	logs, err := s.GetWorkLogs(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+	if w := Running(logs, actor); w != nil {
+		return w, fmt.Errorf("%w on %s since %s", ErrAlreadyRunning, issueID, w.StartedAt.Local().Format("15:04"))
+	}
+	entry := &types.WorkLog{Actor: actor, StartedAt: now, Note: note}
+	if err := s.AddWorkLog(ctx, issueID, entry, actor); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Stop stops actor's running timer on the issue. A non-empty note replaces
+// the one given at start.
+func Stop(ctx context.Context, s storage.Storage, issueID, actor, note string, now time.Time) (*types.WorkLog, error) {
+	logs, err := s.GetWorkLogs(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work logs: %w", err)
+	}
+	running := Running(logs, actor)
+	if running == nil {
+		return nil, fmt.Errorf("%w for %s on %s", ErrNotRunning, actor, issueID)
+	}
+	entry := *running
+	entry.EndedAt = &now
+	if note != "" {
+		entry.Note = note
+	}
+	if err := s.AddWorkLog(ctx, issueID, &entry, actor); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Log records a completed entry of the given duration ending at end.
+func Log(ctx context.Context, s storage.Storage, issueID, actor, note string, d time.Duration, end time.Time) (*types.WorkLog, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+	entry := &types.WorkLog{Actor: actor, StartedAt: end.Add(-d), EndedAt: &end, Note: note}
+	if err := s.AddWorkLog(ctx, issueID, entry, actor); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// AutoTimerEnabled reports whether status changes should start and stop
+// timers (work.auto-timer in config.yaml).
+func AutoTimerEnabled() bool {
+	return config.GetBool(AutoTimerKey)
+}
+
+// OnStatusChange starts or stops timers for a status transition when
+// automatic timers are enabled. Moving to in_progress starts actor's timer;
+// leaving in_progress stops it; closing stops every running timer on the
+// issue, since nobody is working on it anymore. Transitions that find the
+// timer already in the desired state are not errors.
+func OnStatusChange(ctx context.Context, s storage.Storage, issueID string, oldStatus, newStatus types.Status, actor string) error {
+	if !AutoTimerEnabled() || oldStatus == newStatus {
+		return nil
+	}
+	now := time.Now()
+
+	switch {
+	case newStatus == types.StatusInProgress:
+		if _, err := Start(ctx, s, issueID, actor, "", now); err != nil && !errors.Is(err, ErrAlreadyRunning) {
+			return err
+		}
+	case newStatus == types.StatusClosed:
+		logs, err := s.GetWorkLogs(ctx, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to get work logs: %w", err)
+		}
+		for _, w := range logs {
+			if !w.IsRunning() {
+				continue
+			}
+			if _, err := Stop(ctx, s, issueID, w.Actor, "", now); err != nil && !errors.Is(err, ErrNotRunning) {
+				return err
+			}
+		}
+	case oldStatus == types.StatusInProgress:
+		if _, err := Stop(ctx, s, issueID, actor, "", now); err != nil && !errors.Is(err, ErrNotRunning) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Total sums the completed entries. Running timers are not counted.
+func Total(logs []*types.WorkLog) time.Duration {
+	var total time.Duration
+	for _, w := range logs {
+		if !w.IsRunning() {
+			total += w.EndedAt.Sub(w.StartedAt)
+		}
+	}
+	return total
+}
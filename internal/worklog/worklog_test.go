@@ -0,0 +1,148 @@
+package worklog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func newStore(t *testing.T, ids ...string) *memory.MemoryStorage {
+	t.Helper()
+	s := memory.New("")
+	for _, id := range ids {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(context.Background(), issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestStartStop(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, "bd-1")
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, err := Start(ctx, s, "bd-1", "alice", "", start); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if _, err := Start(ctx, s, "bd-1", "alice", "", start.Add(time.Minute)); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("second Start = %v, want ErrAlreadyRunning", err)
+	}
+	// Timers are per actor
+	if _, err := Start(ctx, s, "bd-1", "bob", "", start); err != nil {
+		t.Fatalf("Start for bob failed: %v", err)
+	}
+
+	w, err := Stop(ctx, s, "bd-1", "alice", "reviewed", start.Add(90*time.Minute))
+	if err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if w.Note != "reviewed" || w.Duration(time.Time{}) != 90*time.Minute {
+		t.Errorf("stopped entry = %+v", w)
+	}
+	if _, err := Stop(ctx, s, "bd-1", "alice", "", start.Add(2*time.Hour)); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("second Stop = %v, want ErrNotRunning", err)
+	}
+
+	logs, _ := s.GetWorkLogs(ctx, "bd-1")
+	if got := Total(logs); got != 90*time.Minute {
+		t.Errorf("Total = %v, want 90m (running timers excluded)", got)
+	}
+}
+
+func TestLogRejectsNonPositiveDuration(t *testing.T) {
+	s := newStore(t, "bd-1")
+	if _, err := Log(context.Background(), s, "bd-1", "alice", "", 0, time.Now()); err == nil {
+		t.Error("Log with zero duration should fail")
+	}
+}
+
+func TestOnStatusChange(t *testing.T) {
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("failed to initialize config: %v", err)
+	}
+	t.Cleanup(func() { config.Set(AutoTimerKey, false) })
+
+	ctx := context.Background()
+	s := newStore(t, "bd-1")
+
+	// Disabled by default
+	if err := OnStatusChange(ctx, s, "bd-1", types.StatusOpen, types.StatusInProgress, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if logs, _ := s.GetWorkLogs(ctx, "bd-1"); len(logs) != 0 {
+		t.Fatalf("auto timer started while disabled: %+v", logs)
+	}
+
+	config.Set(AutoTimerKey, true)
+	if err := OnStatusChange(ctx, s, "bd-1", types.StatusOpen, types.StatusInProgress, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Start(ctx, s, "bd-1", "bob", "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	logs, _ := s.GetWorkLogs(ctx, "bd-1")
+	if Running(logs, "alice") == nil {
+		t.Fatal("moving to in_progress should start alice's timer")
+	}
+
+	// Closing stops everyone's timer, not just the closer's
+	if err := OnStatusChange(ctx, s, "bd-1", types.StatusInProgress, types.StatusClosed, "carol"); err != nil {
+		t.Fatal(err)
+	}
+	logs, _ = s.GetWorkLogs(ctx, "bd-1")
+	for _, w := range logs {
+		if w.IsRunning() {
+			t.Errorf("timer for %s still running after close", w.Actor)
+		}
+	}
+}
+
+func TestCompareEstimates(t *testing.T) {
+	est := func(m int) *int { return &m }
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	span := func(actor string, d time.Duration) *types.WorkLog {
+		end := at.Add(d)
+		return &types.WorkLog{Actor: actor, StartedAt: at, EndedAt: &end}
+	}
+
+	issues := []*types.Issue{
+		{ID: "bd-1", IssueType: types.TypeBug, Assignee: "alice", EstimatedMinutes: est(60)},
+		{ID: "bd-2", IssueType: types.TypeBug, Assignee: "bob", EstimatedMinutes: est(30)},
+		{ID: "bd-3", IssueType: types.TypeFeature, EstimatedMinutes: est(120)},
+		{ID: "bd-4", IssueType: types.TypeTask, Assignee: "alice"},                            // No estimate
+		{ID: "bd-5", IssueType: types.TypeTask, Assignee: "alice", EstimatedMinutes: est(15)}, // No actuals
+	}
+	logs := map[string][]*types.WorkLog{
+		"bd-1": {span("alice", 90*time.Minute)},
+		"bd-2": {span("bob", 30*time.Minute), {Actor: "bob", StartedAt: at}}, // Running entry ignored
+		"bd-3": {span("carol", 60*time.Minute)},
+		"bd-4": {span("alice", 10*time.Minute)},
+	}
+
+	byType := CompareEstimates(issues, logs, ByType)
+	if len(byType) != 2 {
+		t.Fatalf("by type = %+v, want bug and feature rows", byType)
+	}
+	bug := byType[0]
+	if bug.Group != "bug" || bug.Issues != 2 || bug.EstimatedMinutes != 90 || bug.ActualMinutes != 120 {
+		t.Errorf("bug row = %+v", bug)
+	}
+	if bug.Ratio < 1.33 || bug.Ratio > 1.34 {
+		t.Errorf("bug ratio = %v, want ~1.33", bug.Ratio)
+	}
+	if byType[1].Group != "feature" || byType[1].Ratio != 0.5 {
+		t.Errorf("feature row = %+v", byType[1])
+	}
+
+	byAssignee := CompareEstimates(issues, logs, ByAssignee)
+	if len(byAssignee) != 3 || byAssignee[0].Group != "(unassigned)" {
+		t.Errorf("by assignee = %+v", byAssignee)
+	}
+}
@@ -0,0 +1,37 @@
+// Package storagetest exposes bd's storage conformance suite to external
+// Storage implementations.
+//
+// Run it from a test in your backend's package:
+//
+//	func TestConformance(t *testing.T) {
+//	    storagetest.Run(t, storagetest.Backend{
+//	        Name: "mybackend",
+//	        New: func(t *testing.T) beads.Storage {
+//	            return mybackend.New(t.TempDir())
+//	        },
+//	    })
+//	}
+//
+// The in-tree sqlite, dolt and memory backends run the same suite.
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/storagetest"
+)
+
+// Prefix is the issue_prefix configured on every store before a test runs.
+const Prefix = storagetest.Prefix
+
+// Factory returns a fresh, empty store for a single test.
+type Factory = storagetest.Factory
+
+// Backend describes a storage implementation under test.
+type Backend = storagetest.Backend
+
+// Run executes the full conformance suite against the backend.
+func Run(t *testing.T, b Backend) {
+	t.Helper()
+	storagetest.Run(t, b)
+}